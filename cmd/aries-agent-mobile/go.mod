@@ -9,9 +9,9 @@ go 1.19
 require (
 	github.com/google/uuid v1.3.0
 	github.com/hyperledger/aries-framework-go v0.1.8-0.20220322085443-50e8f9bd208b
-	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20220322085443-50e8f9bd208b
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20220606124520-53422361c38c
-	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20220428211718-66cc046674a1
+	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20261016183624-8b2dba1f0d67
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261016183624-8b2dba1f0d67
+	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20261016183624-8b2dba1f0d67
 	github.com/piprate/json-gold v0.4.1
 	github.com/stretchr/testify v1.7.2
 	nhooyr.io/websocket v1.8.3
@@ -25,7 +25,7 @@ require (
 	github.com/btcsuite/btcd v0.22.0-beta // indirect
 	github.com/btcsuite/btcutil v1.0.3-0.20201208143702-a53e38424cce // indirect
 	github.com/cenkalti/backoff/v4 v4.0.2 // indirect
-	github.com/cespare/xxhash/v2 v2.1.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/golang/snappy v0.0.1 // indirect
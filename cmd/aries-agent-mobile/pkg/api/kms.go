@@ -16,4 +16,16 @@ type KMSController interface {
 
 	// ImportKey imports a key.
 	ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// ListKeys lists the keys with their information.
+	ListKeys(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// GetKeyInfo gets the information of a key.
+	GetKeyInfo(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// SetKeyMetadata replaces the user-defined metadata tags of a key.
+	SetKeyMetadata(request *models.RequestEnvelope) *models.ResponseEnvelope
+
+	// DeleteKey securely deletes a key.
+	DeleteKey(request *models.RequestEnvelope) *models.ResponseEnvelope
 }
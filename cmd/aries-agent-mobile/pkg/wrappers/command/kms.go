@@ -44,3 +44,61 @@ func (k *KMS) ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelop
 
 	return &models.ResponseEnvelope{Payload: response}
 }
+
+// ListKeys lists the keys with their information.
+func (k *KMS) ListKeys(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	response, cmdErr := exec(k.handlers[kms.ListKeysCommandMethod], request.Payload)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// GetKeyInfo gets the information of a key.
+func (k *KMS) GetKeyInfo(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := kms.GetKeyInfoRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(k.handlers[kms.GetKeyInfoCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// SetKeyMetadata replaces the user-defined metadata tags of a key.
+func (k *KMS) SetKeyMetadata(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := kms.SetKeyMetadataRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(k.handlers[kms.SetKeyMetadataCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
+
+// DeleteKey securely deletes a key.
+func (k *KMS) DeleteKey(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	args := kms.DeleteKeyRequest{}
+
+	if err := json.Unmarshal(request.Payload, &args); err != nil {
+		return &models.ResponseEnvelope{Error: &models.CommandError{Message: err.Error()}}
+	}
+
+	response, cmdErr := exec(k.handlers[kms.DeleteKeyCommandMethod], args)
+	if cmdErr != nil {
+		return &models.ResponseEnvelope{Error: cmdErr}
+	}
+
+	return &models.ResponseEnvelope{Payload: response}
+}
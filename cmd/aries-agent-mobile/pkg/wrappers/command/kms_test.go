@@ -72,3 +72,85 @@ func TestKMS_ImportKey(t *testing.T) {
 			string(resp.Payload))
 	})
 }
+
+func TestKMS_ListKeys(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		mockResponse := `{"keys":[{"keyID":"keyID","keyType":"ED25519","createdAt":"2022-01-01T00:00:00Z"}]}`
+
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		controller.handlers[kms.ListKeysCommandMethod] = fakeHandler.exec
+
+		req := &models.RequestEnvelope{Payload: []byte(emptyJSON)}
+		resp := controller.ListKeys(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestKMS_GetKeyInfo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		mockResponse := `{"keyID":"keyID","keyType":"ED25519","createdAt":"2022-01-01T00:00:00Z"}`
+
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		controller.handlers[kms.GetKeyInfoCommandMethod] = fakeHandler.exec
+
+		payload := `{"keyID":"keyID"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := controller.GetKeyInfo(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestKMS_SetKeyMetadata(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		mockResponse := emptyJSON
+
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		controller.handlers[kms.SetKeyMetadataCommandMethod] = fakeHandler.exec
+
+		payload := `{"keyID":"keyID","metadata":{"label":"signing"}}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := controller.SetKeyMetadata(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
+
+func TestKMS_DeleteKey(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		mockResponse := emptyJSON
+
+		fakeHandler := mockCommandRunner{data: []byte(mockResponse)}
+		controller.handlers[kms.DeleteKeyCommandMethod] = fakeHandler.exec
+
+		payload := `{"keyID":"keyID"}`
+
+		req := &models.RequestEnvelope{Payload: []byte(payload)}
+		resp := controller.DeleteKey(req)
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t,
+			mockResponse,
+			string(resp.Payload))
+	})
+}
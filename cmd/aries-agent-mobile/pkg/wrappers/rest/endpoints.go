@@ -440,6 +440,22 @@ func getKMSEndpoints() map[string]*endpoint {
 			Path:   opkms.ImportKeyPath,
 			Method: http.MethodPost,
 		},
+		cmdkms.ListKeysCommandMethod: {
+			Path:   opkms.KeysPath,
+			Method: http.MethodGet,
+		},
+		cmdkms.GetKeyInfoCommandMethod: {
+			Path:   opkms.KeyPath,
+			Method: http.MethodGet,
+		},
+		cmdkms.SetKeyMetadataCommandMethod: {
+			Path:   opkms.KeyMetadataPath,
+			Method: http.MethodPut,
+		},
+		cmdkms.DeleteKeyCommandMethod: {
+			Path:   opkms.KeyPath,
+			Method: http.MethodDelete,
+		},
 	}
 }
 
@@ -30,6 +30,26 @@ func (k *KMS) ImportKey(request *models.RequestEnvelope) *models.ResponseEnvelop
 	return k.createRespEnvelope(request, kms.ImportKeyCommandMethod)
 }
 
+// ListKeys lists the keys with their information.
+func (k *KMS) ListKeys(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.ListKeysCommandMethod)
+}
+
+// GetKeyInfo gets the information of a key.
+func (k *KMS) GetKeyInfo(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.GetKeyInfoCommandMethod)
+}
+
+// SetKeyMetadata replaces the user-defined metadata tags of a key.
+func (k *KMS) SetKeyMetadata(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.SetKeyMetadataCommandMethod)
+}
+
+// DeleteKey securely deletes a key.
+func (k *KMS) DeleteKey(request *models.RequestEnvelope) *models.ResponseEnvelope {
+	return k.createRespEnvelope(request, kms.DeleteKeyCommandMethod)
+}
+
 func (k *KMS) createRespEnvelope(request *models.RequestEnvelope, endpoint string) *models.ResponseEnvelope {
 	return exec(&restOperation{
 		url:        k.URL,
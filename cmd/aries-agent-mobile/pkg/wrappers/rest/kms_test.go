@@ -73,3 +73,86 @@ func TestKMS_ImportKey(t *testing.T) {
 		require.Equal(t, mockResponse, string(resp.Payload))
 	})
 }
+
+func TestKMS_ListKeys(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		mockResponse := `{"keys":[{"keyID":"keyID","keyType":"ED25519","createdAt":"2022-01-01T00:00:00Z"}]}`
+
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockAgentURL + kms.KeysPath,
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(emptyJSON)}
+		resp := controller.ListKeys(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestKMS_GetKeyInfo(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		reqData := `{"keyID":"keyID"}`
+		mockResponse := `{"keyID":"keyID","keyType":"ED25519","createdAt":"2022-01-01T00:00:00Z"}`
+
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodGet, url: mockAgentURL + kms.KeysPath + "/keyID",
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.GetKeyInfo(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestKMS_SetKeyMetadata(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		reqData := `{"keyID":"keyID","metadata":{"label":"signing"}}`
+		mockResponse := emptyJSON
+
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodPut, url: mockAgentURL + kms.KeysPath + "/keyID/metadata",
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.SetKeyMetadata(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
+
+func TestKMS_DeleteKey(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		controller := getKMSController(t)
+
+		reqData := `{"keyID":"keyID"}`
+		mockResponse := emptyJSON
+
+		controller.httpClient = &mockHTTPClient{
+			data:   mockResponse,
+			method: http.MethodDelete, url: mockAgentURL + kms.KeysPath + "/keyID",
+		}
+
+		req := &models.RequestEnvelope{Payload: []byte(reqData)}
+		resp := controller.DeleteKey(req)
+
+		require.NotNil(t, resp)
+		require.Nil(t, resp.Error)
+		require.Equal(t, mockResponse, string(resp.Payload))
+	})
+}
@@ -93,7 +93,7 @@ func makeHTTPRequest(httpClient httpClient, method, agentURL, token string, body
 }
 
 func embedParams(reqPath string, body []byte) (newURL string, err error) {
-	params := []string{"piid", "id", "name"}
+	params := []string{"piid", "id", "name", "keyID"}
 	newURL = reqPath
 
 	for _, param := range params {
@@ -25,8 +25,10 @@ import (
 	"github.com/hyperledger/aries-framework-go-ext/component/storage/mysql"
 	"github.com/hyperledger/aries-framework-go-ext/component/storage/postgresql"
 	"github.com/hyperledger/aries-framework-go/component/storage/leveldb"
+	"github.com/hyperledger/aries-framework-go/component/storage/sqlite"
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics/prometheus"
 	"github.com/hyperledger/aries-framework-go/pkg/controller"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/msghandler"
@@ -60,7 +62,7 @@ const (
 	databaseTypeEnvKey        = "ARIESD_DATABASE_TYPE"
 	databaseTypeFlagShorthand = "q"
 	databaseTypeFlagUsage     = "The type of database to use for everything except key storage. " +
-		"Supported options: mem, leveldb, couchdb, mongodb, mysql, postgresql. " +
+		"Supported options: mem, leveldb, sqlite, couchdb, mongodb, mysql, postgresql. " +
 		" Alternatively, this can be set with the following environment variable: " + databaseTypeEnvKey
 
 	databasePrefixFlagName      = "database-prefix"
@@ -207,11 +209,21 @@ const (
 		" Alternatively, this can be set with the following environment variable (in CSV format): " +
 		agentMediaTypeProfilesEnvKey
 
+	// metrics flag.
+	agentMetricsEnabledFlagName  = "metrics-enabled"
+	agentMetricsEnabledEnvKey    = "ARIESD_METRICS_ENABLED"
+	agentMetricsEnabledFlagUsage = "Enables framework metrics, exposed in the Prometheus text format on " +
+		metricsPath + " endpoint. Possible values [true] [false]. Defaults to false if not set." +
+		" Alternatively, this can be set with the following environment variable: " + agentMetricsEnabledEnvKey
+
+	metricsPath = "/metrics"
+
 	httpProtocol      = "http"
 	websocketProtocol = "ws"
 
 	databaseTypeMemOption        = "mem"
 	databaseTypeLevelDBOption    = "leveldb"
+	databaseTypeSQLiteOption     = "sqlite"
 	databaseTypeCouchDBOption    = "couchdb"
 	databaseTypeMongoDBOption    = "mongodb"
 	databaseTypeMySQLOption      = "mysql"
@@ -256,6 +268,8 @@ type AgentParameters struct {
 	msgHandler                                     command.MessageHandler
 	dbParam                                        *dbParam
 	autoExecuteRFC0593                             bool
+	metricsEnabled                                 bool
+	metrics                                        *prometheus.Metrics
 }
 
 type dbParam struct {
@@ -272,6 +286,9 @@ var supportedStorageProviders = map[string]func(prefix string) (storage.Provider
 	databaseTypeLevelDBOption: func(path string) (storage.Provider, error) { // nolint:unparam
 		return leveldb.NewProvider(path), nil
 	},
+	databaseTypeSQLiteOption: func(path string) (storage.Provider, error) {
+		return sqlite.NewProvider(path)
+	},
 	databaseTypeCouchDBOption: func(hostURL string) (storage.Provider, error) {
 		return couchdb.NewProvider(hostURL)
 	},
@@ -422,6 +439,11 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		return nil, err
 	}
 
+	metricsEnabled, err := getMetricsEnabled(cmd)
+	if err != nil {
+		return nil, err
+	}
+
 	parameters := &AgentParameters{
 		server:               server,
 		host:                 host,
@@ -443,6 +465,7 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		keyType:              keyType,
 		keyAgreementType:     keyAgreementType,
 		mediaTypeProfiles:    mediaTypeProfiles,
+		metricsEnabled:       metricsEnabled,
 	}
 
 	return parameters, nil
@@ -524,6 +547,19 @@ func getAutoExecuteRFC0593(cmd *cobra.Command) (bool, error) {
 	return strconv.ParseBool(autoExecuteRFC0593Str)
 }
 
+func getMetricsEnabled(cmd *cobra.Command) (bool, error) {
+	v, err := getUserSetVar(cmd, agentMetricsEnabledFlagName, agentMetricsEnabledEnvKey, true)
+	if err != nil {
+		return false, err
+	}
+
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
 func getWebSocketReadLimit(cmd *cobra.Command) (int64, error) {
 	readLimitVal, err := getUserSetVar(cmd, agentWebSocketReadLimitFlagName,
 		agentWebSocketReadLimitEnvKey, true)
@@ -613,6 +649,8 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(agentKeyAgreementTypeFlagName, "", "", agentKeyAgreementTypeUsage)
 
 	startCmd.Flags().StringSliceP(agentMediaTypeProfilesFlagName, "", []string{}, agentMediaTypeProfilesUsage)
+
+	startCmd.Flags().StringP(agentMetricsEnabledFlagName, "", "", agentMetricsEnabledFlagUsage)
 }
 
 func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
@@ -815,6 +853,10 @@ func (parameters *AgentParameters) NewRouter() (*mux.Router, error) {
 	// set message handler
 	parameters.msgHandler = msghandler.NewRegistrar()
 
+	if parameters.metricsEnabled {
+		parameters.metrics = prometheus.New()
+	}
+
 	ctx, err := createAriesAgent(parameters)
 	if err != nil {
 		return nil, err
@@ -840,6 +882,10 @@ func (parameters *AgentParameters) NewRouter() (*mux.Router, error) {
 		router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
 	}
 
+	if parameters.metrics != nil {
+		router.Handle(metricsPath, parameters.metrics.Handler()).Methods(http.MethodGet)
+	}
+
 	return router, nil
 }
 
@@ -925,6 +971,10 @@ func createAriesAgent(parameters *AgentParameters) (*context.Provider, error) {
 		opts = append(opts, aries.WithMediaTypeProfiles(parameters.mediaTypeProfiles))
 	}
 
+	if parameters.metrics != nil {
+		opts = append(opts, aries.WithMetrics(parameters.metrics))
+	}
+
 	framework, err := aries.New(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to initialize framework :  %w",
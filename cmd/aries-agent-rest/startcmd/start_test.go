@@ -13,6 +13,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -844,6 +845,31 @@ func TestCreateAriesWithMediaTypeProfiles(t *testing.T) {
 	}
 }
 
+func TestNewRouterWithMetrics(t *testing.T) {
+	parameters := &AgentParameters{
+		host:           randomURL(),
+		dbParam:        &dbParam{dbType: databaseTypeMemOption},
+		defaultLabel:   "x",
+		metricsEnabled: true,
+	}
+
+	router, err := parameters.NewRouter()
+	require.NoError(t, err)
+	require.NotNil(t, parameters.metrics)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/kms/keyset",
+		strings.NewReader(`{"keyType":"ED25519"}`)))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, metricsPath, nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Header().Get("Content-Type"), "text/plain")
+	require.Contains(t, rr.Body.String(), "# TYPE aries_kms_operations_total counter")
+}
+
 func TestStartAriesWithAuthorization(t *testing.T) {
 	const (
 		goodToken = "ABCD"
@@ -899,6 +925,29 @@ func TestStoreProvider(t *testing.T) {
 	})
 }
 
+func TestStartCmdInvalidMetricsEnabledValue(t *testing.T) {
+	startCmd, err := Cmd(&mockServer{})
+	require.NoError(t, err)
+
+	args := []string{
+		"--" + agentHostFlagName,
+		randomURL(),
+		"--" + agentInboundHostFlagName,
+		httpProtocol + "@" + randomURL(),
+		"--" + databaseTypeFlagName,
+		databaseTypeMemOption,
+		"--" + agentWebhookFlagName,
+		"",
+		"--" + agentMetricsEnabledFlagName,
+		"INVALID",
+	}
+	startCmd.SetArgs(args)
+
+	err = startCmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid syntax")
+}
+
 func TestStartCmdInvalidAutoExecuteRFC0593Value(t *testing.T) {
 	startCmd, err := Cmd(&mockServer{})
 	require.NoError(t, err)
@@ -994,6 +1043,9 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	os.Setenv(agentMediaTypeProfilesEnvKey, "agentMediaTypeProfiles")
 	defer os.Unsetenv(agentMediaTypeProfilesEnvKey)
 
+	os.Setenv(agentMetricsEnabledEnvKey, "true")
+	defer os.Unsetenv(agentMetricsEnabledEnvKey)
+
 	parameters, err := NewAgentParameters(&mockServer{}, nil)
 
 	require.Nil(t, err)
@@ -1018,6 +1070,7 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	require.Equal(t, "agentKeyType", parameters.keyType)
 	require.Equal(t, "agentKeyAgreementType", parameters.keyAgreementType)
 	require.Equal(t, "agentMediaTypeProfiles", parameters.mediaTypeProfiles[0])
+	require.Equal(t, true, parameters.metricsEnabled)
 }
 
 func waitForServerToStart(t *testing.T, host, inboundHost string) {
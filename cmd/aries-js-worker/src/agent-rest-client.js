@@ -131,6 +131,12 @@ const pkgs = {
             method: "GET",
         },
     },
+    trustping: {
+        Ping: {
+            path: "/trustping/ping",
+            method: "POST"
+        },
+    },
     verifiable: {
         ValidateCredential: {
             path: "/verifiable/credential/validate",
@@ -453,6 +459,25 @@ const pkgs = {
         ImportKey: {
             path: "/kms/import",
             method: "POST",
+        },
+        ListKeys: {
+            path: "/kms/keys",
+            method: "GET",
+        },
+        GetKeyInfo: {
+            path: "/kms/keys/{keyID}",
+            method: "GET",
+            pathParam: "keyID"
+        },
+        SetKeyMetadata: {
+            path: "/kms/keys/{keyID}/metadata",
+            method: "PUT",
+            pathParam: "keyID"
+        },
+        DeleteKey: {
+            path: "/kms/keys/{keyID}",
+            method: "DELETE",
+            pathParam: "keyID"
         }
     },
     vcwallet: {
@@ -541,6 +566,14 @@ const pkgs = {
             path: "/vcwallet/resolve-credential-manifest",
             method: "POST",
         },
+        Export: {
+            path: "/vcwallet/export",
+            method: "POST",
+        },
+        Import: {
+            path: "/vcwallet/import",
+            method: "POST",
+        },
     },
     ld: {
         AddContexts: {
@@ -1039,6 +1039,24 @@ const Aries = function (opts) {
             },
         },
 
+        /**
+         * TrustPing methods - Refer to [OpenAPI spec](docs/rest/openapi_spec.md#generate-openapi-spec) for
+         * input params and output return json values.
+         */
+        trustping: {
+            pkgname: "trustping",
+
+            /**
+             * Sends a trust ping over the given connection and returns round-trip latency.
+             *
+             * @param req - json document containing connection ID
+             * @returns {Promise<Object>}
+             */
+            ping: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Ping", req, "timeout while sending trust ping")
+            },
+        },
+
         /**
          * Verifiable methods related to credentials and presentations - Refer to [OpenAPI spec](docs/rest/openapi_spec.md#generate-openapi-spec) for
          * input params and output return json values.
@@ -1189,6 +1207,42 @@ const Aries = function (opts) {
             importKey: async function (req) {
                 return invoke(aw, pending, this.pkgname, "ImportKey", req, "timeout while importing key")
             },
+
+            /**
+             * List keys with their information.
+             *
+             * @returns {Promise<Object>}
+             */
+            listKeys: async function (req) {
+                return invoke(aw, pending, this.pkgname, "ListKeys", req, "timeout while listing keys")
+            },
+
+            /**
+             * Get key information.
+             *
+             * @returns {Promise<Object>}
+             */
+            getKeyInfo: async function (req) {
+                return invoke(aw, pending, this.pkgname, "GetKeyInfo", req, "timeout while getting key information")
+            },
+
+            /**
+             * Set key metadata tags.
+             *
+             * @returns {Promise<Object>}
+             */
+            setKeyMetadata: async function (req) {
+                return invoke(aw, pending, this.pkgname, "SetKeyMetadata", req, "timeout while setting key metadata")
+            },
+
+            /**
+             * Delete key.
+             *
+             * @returns {Promise<Object>}
+             */
+            deleteKey: async function (req) {
+                return invoke(aw, pending, this.pkgname, "DeleteKey", req, "timeout while deleting key")
+            },
         },
         /**
          * Verifiable Credential Wallet based on Universal Wallet 2020 https://w3c-ccg.github.io/universal-wallet-interop-spec/#interface
@@ -1458,6 +1512,31 @@ const Aries = function (opts) {
             resolveCredentialManifest: async function (req) {
                 return invoke(aw, pending, this.pkgname, "ResolveCredentialManifest", req, "timeout while resolving credential manifest from wallet")
             },
+
+            /**
+             *
+             * Export produces a serialized exported wallet representation locked by given passphrase.
+             * https://w3c-ccg.github.io/universal-wallet-interop-spec/#export
+             *
+             *  Returns locked wallet contents.
+             *
+             * @returns {Promise<Object>}
+             */
+            export: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Export", req, "timeout while exporting wallet")
+            },
+
+            /**
+             *
+             * Import takes a serialized exported wallet representation locked by given passphrase
+             * and imports all contents into wallet.
+             * https://w3c-ccg.github.io/universal-wallet-interop-spec/#import
+             *
+             * @returns {Promise<Object>}
+             */
+            import: async function (req) {
+                return invoke(aw, pending, this.pkgname, "Import", req, "timeout while importing wallet")
+            },
         },
         /**
          * JSON-LD management API.
@@ -19,6 +19,7 @@ import (
 	"syscall/js"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
@@ -35,13 +36,11 @@ import (
 // TODO (#2528): Proper implementation of all methods.
 
 const (
-	dbName                          = "aries-%s"
-	defDBName                       = "aries"
-	dbVersion                       = 1
-	tagMapKey                       = "TagMap"
-	storeConfigKey                  = "StoreConfig"
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
+	dbName         = "aries-%s"
+	defDBName      = "aries"
+	dbVersion      = 1
+	tagMapKey      = "TagMap"
+	storeConfigKey = "StoreConfig"
 
 	invalidTagName  = `"%s" is an invalid tag name since it contains one or more ':' characters`
 	invalidTagValue = `"%s" is an invalid tag value since it contains one or more ':' characters`
@@ -298,6 +297,11 @@ func (s *store) GetTags(key string) ([]storage.Tag, error) {
 		return nil, storage.ErrDataNotFound
 	}
 
+	// data stored without tags has no tags field
+	if !data.Get("tags").Truthy() {
+		return nil, nil
+	}
+
 	tagsBytes := []byte(data.Get("tags").String())
 
 	var tags []storage.Tag
@@ -315,6 +319,10 @@ func (s *store) GetBulk(keys ...string) ([][]byte, error) {
 	return nil, errors.New("not implemented")
 }
 
+// Query returns all data that satisfies the expression. Basic expression format: TagName:TagValue.
+// Criteria can be combined with "&&" and "||" operators, ANDs are evaluated before ORs. Instead of ":", a criterion
+// can use one of the "<", "<=", ">" and ">=" operators to compare numeric or RFC3339 timestamp tag values (see
+// query.FormatTime), and it can be negated with a "!" prefix.
 // This provider doesn't currently support any of the current query options.
 // spi.WithPageSize will simply be ignored since it only relates to performance and not the actual end result.
 // spi.WithInitialPageNum and spi.WithSortOrder will result in an error being returned since those options do
@@ -329,23 +337,16 @@ func (s *store) Query(expression string, options ...storage.QueryOption) (storag
 		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-
-	var expressionTagName string
-
-	var expressionTagValue string
+	groups, err := query.Parse(expression)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidFormat) {
+			return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+		}
 
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName = expressionSplit[0]
-	case expressionTagNameAndValueLength:
-		expressionTagName = expressionSplit[0]
-		expressionTagValue = expressionSplit[1]
-	default:
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+		return nil, err
 	}
 
-	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingQuery(expressionTagName, expressionTagValue)
+	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingQuery(groups)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database keys matching query: %w", err)
 	}
@@ -471,7 +472,17 @@ func (s *store) removeFromTagMap(keyToRemove string) error {
 	return nil
 }
 
-func (s *store) getDatabaseKeysMatchingQuery(expressionTagName, expressionTagValue string) ([]string, error) {
+func (s *store) getDatabaseKeysMatchingQuery(groups query.Expression) ([]string, error) {
+	tagNames, tagRequired := groups.RequiredTagNames()
+	if !tagRequired {
+		candidateKeys, err := s.getAllDatabaseKeys()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database keys: %w", err)
+		}
+
+		return s.filterDatabaseKeys(candidateKeys, groups)
+	}
+
 	tagMap, err := s.getTagMap(false)
 	if err != nil {
 		// If there's no tag map, then this means that tags have never been used, and therefore no matching results.
@@ -482,46 +493,55 @@ func (s *store) getDatabaseKeysMatchingQuery(expressionTagName, expressionTagVal
 		return nil, fmt.Errorf("failed to get tag map: %w", err)
 	}
 
-	if expressionTagValue == "" {
-		return getDatabaseKeysMatchingTagName(tagMap, expressionTagName), nil
-	}
+	candidateKeys := getDatabaseKeysMatchingTagNames(tagMap, tagNames)
 
-	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName, expressionTagValue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
+	// The tag map is enough to answer a query for a single tag name.
+	if len(groups) == 1 && len(groups[0]) == 1 && groups[0][0].IsTagNameOnly() {
+		return candidateKeys, nil
 	}
 
-	return matchingDatabaseKeys, nil
+	return s.filterDatabaseKeys(candidateKeys, groups)
 }
 
-func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
-	expressionTagName, expressionTagValue string) ([]string, error) {
+func (s *store) filterDatabaseKeys(candidateKeys []string, groups query.Expression) ([]string, error) {
 	var matchingDatabaseKeys []string
 
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
-				tags, err := s.GetTags(databaseKey)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get tags: %w", err)
-				}
-
-				for _, tag := range tags {
-					if tag.Name == expressionTagName && tag.Value == expressionTagValue {
-						matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
-
-						break
-					}
-				}
-			}
+	for _, databaseKey := range candidateKeys {
+		tags, err := s.GetTags(databaseKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags: %w", err)
+		}
 
-			break
+		if groups.Matches(tags) {
+			matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
 		}
 	}
 
 	return matchingDatabaseKeys, nil
 }
 
+// getAllDatabaseKeys returns the keys of all data in the store, except for the internal tag map and store config.
+func (s *store) getAllDatabaseKeys() ([]string, error) {
+	req := s.db.Call("transaction", s.name).Call("objectStore", s.name).Call("getAllKeys")
+
+	keys, err := getResult(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keys: %w", err)
+	}
+
+	var databaseKeys []string
+
+	for i := 0; i < keys.Length(); i++ {
+		databaseKey := keys.Index(i).String()
+
+		if databaseKey != tagMapKey && databaseKey != storeConfigKey {
+			databaseKeys = append(databaseKeys, databaseKey)
+		}
+	}
+
+	return databaseKeys, nil
+}
+
 func (s *store) getTagMap(createIfDoesNotExist bool) (tagMapping, error) {
 	tagMapBytes, err := s.Get(tagMapKey)
 	if err != nil {
@@ -667,16 +687,19 @@ func checkForUnsupportedQueryOptions(options []storage.QueryOption) error {
 	return nil
 }
 
-func getDatabaseKeysMatchingTagName(tagMap tagMapping, expressionTagName string) []string {
+// getDatabaseKeysMatchingTagNames returns the keys of data having at least one of the tags.
+func getDatabaseKeysMatchingTagNames(tagMap tagMapping, tagNames []string) []string {
 	var matchingDatabaseKeys []string
 
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
+	found := make(map[string]struct{})
+
+	for _, tagName := range tagNames {
+		for databaseKey := range tagMap[tagName] {
+			if _, ok := found[databaseKey]; !ok {
+				found[databaseKey] = struct{}{}
+
 				matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
 			}
-
-			break
 		}
 	}
 
@@ -31,6 +31,7 @@ func TestCommon(t *testing.T) {
 	commontest.TestProviderOpenStoreSetGetConfig(t, provider)
 	commontest.TestStoreDelete(t, provider)
 	commontest.TestStoreQuery(t, provider)
+	commontest.TestStoreQueryWithComparisonOperators(t, provider)
 	commontest.TestStoreBatch(t, provider)
 	commontest.TestStoreClose(t, provider)
 	commontest.TestProviderClose(t, provider)
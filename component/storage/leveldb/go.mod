@@ -8,8 +8,9 @@ go 1.19
 
 require (
 	github.com/google/uuid v1.1.2
-	github.com/hyperledger/aries-framework-go/spi v0.0.0-20220322085443-50e8f9bd208b
-	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20220322085443-50e8f9bd208b
+	github.com/hyperledger/aries-framework-go/component/storageutil v0.0.0-20261016183624-8b2dba1f0d67
+	github.com/hyperledger/aries-framework-go/spi v0.0.0-20261016183624-8b2dba1f0d67
+	github.com/hyperledger/aries-framework-go/test/component v0.0.0-20261016183624-8b2dba1f0d67
 	github.com/stretchr/testify v1.7.0
 	github.com/syndtr/goleveldb v1.0.0
 )
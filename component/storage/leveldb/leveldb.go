@@ -12,22 +12,22 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
 	pathPattern = "%s-%s"
 
-	invalidTagName                  = `"%s" is an invalid tag name since it contains one or more ':' characters`
-	invalidTagValue                 = `"%s" is an invalid tag value since it contains one or more ':' characters`
-	tagMapKey                       = "TagMap"
-	storeConfigKey                  = "StoreConfig"
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-	invalidQueryExpressionFormat    = `"%s" is not in a valid expression format. ` +
+	invalidTagName               = `"%s" is an invalid tag name since it contains one or more ':' characters`
+	invalidTagValue              = `"%s" is an invalid tag value since it contains one or more ':' characters`
+	tagMapKey                    = "TagMap"
+	storeConfigKey               = "StoreConfig"
+	invalidQueryExpressionFormat = `"%s" is not in a valid expression format. ` +
 		"it must be in the following format: TagName:TagValue"
 )
 
@@ -43,8 +43,9 @@ type closer func(storeName string)
 type tagMapping map[string]map[string]struct{} // map[TagName](Set of database Keys)
 
 type dbEntry struct {
-	Value []byte        `json:"value,omitempty"`
-	Tags  []storage.Tag `json:"tags,omitempty"`
+	Value     []byte        `json:"value,omitempty"`
+	Tags      []storage.Tag `json:"tags,omitempty"`
+	ExpiresAt *time.Time    `json:"expiresAt,omitempty"`
 }
 
 // NewProvider instantiates Provider.
@@ -215,6 +216,10 @@ type store struct {
 // TODO (#2947) This current implementation doesn't update the tag map if tags is empty, but this isn't correct.
 //              An empty tags slice should remove any stored tags for this key-value pair.
 func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
+	return s.put(key, value, time.Time{}, tags...)
+}
+
+func (s *store) put(key string, value []byte, expiresAt time.Time, tags ...storage.Tag) error {
 	if key == "" {
 		return errors.New("key cannot be blank")
 	}
@@ -236,6 +241,10 @@ func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
 	var newDBEntry dbEntry
 	newDBEntry.Value = value
 
+	if !expiresAt.IsZero() {
+		newDBEntry.ExpiresAt = &expiresAt
+	}
+
 	if len(tags) > 0 {
 		newDBEntry.Tags = tags
 
@@ -298,6 +307,10 @@ func (s *store) GetBulk(keys ...string) ([][]byte, error) {
 	return values, nil
 }
 
+// Query returns all data that satisfies the expression. Basic expression format: TagName:TagValue.
+// Criteria can be combined with "&&" and "||" operators, ANDs are evaluated before ORs. Instead of ":", a criterion
+// can use one of the "<", "<=", ">" and ">=" operators to compare numeric or RFC3339 timestamp tag values (see
+// query.FormatTime), and it can be negated with a "!" prefix.
 // This provider doesn't currently support any of the current query options.
 // spi.WithPageSize will simply be ignored since it only relates to performance and not the actual end result.
 // spi.WithInitialPageNum and spi.WithSortOrder will result in an error being returned since those options do
@@ -312,23 +325,16 @@ func (s *store) Query(expression string, options ...storage.QueryOption) (storag
 		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-
-	var expressionTagName string
-
-	var expressionTagValue string
+	groups, err := query.Parse(expression)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidFormat) {
+			return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+		}
 
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName = expressionSplit[0]
-	case expressionTagNameAndValueLength:
-		expressionTagName = expressionSplit[0]
-		expressionTagValue = expressionSplit[1]
-	default:
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+		return nil, err
 	}
 
-	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingQuery(expressionTagName, expressionTagValue)
+	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingQuery(groups)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database keys matching query: %w", err)
 	}
@@ -361,6 +367,7 @@ func (s *store) Delete(key string) error {
 // TODO (#2605) proper bulk retrieval implementation. This is just a naive implementation that ensures this method
 //  can at least perform the operations as expected without failing. It doesn't take advantage of LevelDB features that
 //  may allow for faster batch operations.
+// PutOptions.ExpiresAt is supported natively: expired data is hidden and purged lazily when it's next accessed.
 func (s *store) Batch(operations []storage.Operation) error {
 	if len(operations) == 0 {
 		return errors.New("batch requires at least one operation")
@@ -373,7 +380,13 @@ func (s *store) Batch(operations []storage.Operation) error {
 				return fmt.Errorf("failed to delete value: %w", err)
 			}
 		} else {
-			err := s.Put(operation.Key, operation.Value, operation.Tags...)
+			var expiresAt time.Time
+
+			if operation.PutOptions != nil {
+				expiresAt = operation.PutOptions.ExpiresAt
+			}
+
+			err := s.put(operation.Key, operation.Value, expiresAt, operation.Tags...)
 			if err != nil {
 				return fmt.Errorf("failed to put value: %w", err)
 			}
@@ -452,6 +465,15 @@ func (s *store) getDBEntry(key string) (dbEntry, error) {
 		return dbEntry{}, fmt.Errorf("failed to unmarshal retrieved DB entry: %w", err)
 	}
 
+	if retrievedDBEntry.ExpiresAt != nil && !time.Now().Before(*retrievedDBEntry.ExpiresAt) {
+		err = s.Delete(key)
+		if err != nil {
+			return dbEntry{}, fmt.Errorf("failed to purge expired DB entry: %w", err)
+		}
+
+		return dbEntry{}, storage.ErrDataNotFound
+	}
+
 	return retrievedDBEntry, nil
 }
 
@@ -487,7 +509,17 @@ func (s *store) removeFromTagMap(keyToRemove string) error {
 	return nil
 }
 
-func (s *store) getDatabaseKeysMatchingQuery(expressionTagName, expressionTagValue string) ([]string, error) {
+func (s *store) getDatabaseKeysMatchingQuery(groups query.Expression) ([]string, error) {
+	tagNames, tagRequired := groups.RequiredTagNames()
+	if !tagRequired {
+		candidateKeys, err := s.getAllDatabaseKeys()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database keys: %w", err)
+		}
+
+		return s.filterDatabaseKeys(candidateKeys, groups)
+	}
+
 	tagMap, err := s.getTagMap(false)
 	if err != nil {
 		// If there's no tag map, then this means that tags have never been used, and therefore no matching results.
@@ -498,46 +530,51 @@ func (s *store) getDatabaseKeysMatchingQuery(expressionTagName, expressionTagVal
 		return nil, fmt.Errorf("failed to get tag map: %w", err)
 	}
 
-	if expressionTagValue == "" {
-		return getDatabaseKeysMatchingTagName(tagMap, expressionTagName), nil
-	}
-
-	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName, expressionTagValue)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
-	}
+	candidateKeys := getDatabaseKeysMatchingTagNames(tagMap, tagNames)
 
-	return matchingDatabaseKeys, nil
+	return s.filterDatabaseKeys(candidateKeys, groups)
 }
 
-func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
-	expressionTagName, expressionTagValue string) ([]string, error) {
+func (s *store) filterDatabaseKeys(candidateKeys []string, groups query.Expression) ([]string, error) {
 	var matchingDatabaseKeys []string
 
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
-				tags, err := s.GetTags(databaseKey)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get tags: %w", err)
-				}
-
-				for _, tag := range tags {
-					if tag.Name == expressionTagName && tag.Value == expressionTagValue {
-						matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
-
-						break
-					}
-				}
+	for _, databaseKey := range candidateKeys {
+		tags, err := s.GetTags(databaseKey)
+		if err != nil {
+			// The data has expired.
+			if errors.Is(err, storage.ErrDataNotFound) {
+				continue
 			}
 
-			break
+			return nil, fmt.Errorf("failed to get tags: %w", err)
+		}
+
+		if groups.Matches(tags) {
+			matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
 		}
 	}
 
 	return matchingDatabaseKeys, nil
 }
 
+// getAllDatabaseKeys returns the keys of all data in the store, except for the internal tag map and store config.
+func (s *store) getAllDatabaseKeys() ([]string, error) {
+	itr := s.db.NewIterator(nil, nil)
+	defer itr.Release()
+
+	var databaseKeys []string
+
+	for itr.Next() {
+		databaseKey := string(itr.Key())
+
+		if databaseKey != tagMapKey && databaseKey != storeConfigKey {
+			databaseKeys = append(databaseKeys, databaseKey)
+		}
+	}
+
+	return databaseKeys, itr.Error()
+}
+
 func (s *store) getTagMap(createIfDoesNotExist bool) (tagMapping, error) {
 	tagMapBytes, err := s.Get(tagMapKey)
 	if err != nil {
@@ -640,16 +677,19 @@ func checkForUnsupportedQueryOptions(options []storage.QueryOption) error {
 	return nil
 }
 
-func getDatabaseKeysMatchingTagName(tagMap tagMapping, expressionTagName string) []string {
+// getDatabaseKeysMatchingTagNames returns the keys of data having at least one of the tags.
+func getDatabaseKeysMatchingTagNames(tagMap tagMapping, tagNames []string) []string {
 	var matchingDatabaseKeys []string
 
-	for tagName, databaseKeysSet := range tagMap {
-		if tagName == expressionTagName {
-			for databaseKey := range databaseKeysSet {
+	found := make(map[string]struct{})
+
+	for _, tagName := range tagNames {
+		for databaseKey := range tagMap[tagName] {
+			if _, ok := found[databaseKey]; !ok {
+				found[databaseKey] = struct{}{}
+
 				matchingDatabaseKeys = append(matchingDatabaseKeys, databaseKey)
 			}
-
-			break
 		}
 	}
 
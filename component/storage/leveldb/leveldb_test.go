@@ -43,6 +43,8 @@ func TestCommon(t *testing.T) {
 	provider := leveldb.NewProvider(path)
 
 	commontest.TestAll(t, provider, commontest.SkipSortTests(false))
+	commontest.TestStoreQueryWithComparisonOperators(t, provider)
+	commontest.TestStoreExpiry(t, provider)
 }
 
 func TestProvider_GetStoreConfig(t *testing.T) {
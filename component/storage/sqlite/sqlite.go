@@ -0,0 +1,823 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sqlite implements the spi.Provider interface on top of an embedded SQLite database.
+// All stores of a provider are kept in a single database file. Each store has a table of key + value pairs
+// and a table of tags, tag names set with SetStoreConfig are indexed.
+// This package requires cgo (see github.com/mattn/go-sqlite3).
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	driverName = "sqlite3"
+
+	// storesTable keeps the names and configurations of all stores created in the database.
+	storesTable = "aries_stores"
+
+	defaultPageSize = 25
+
+	invalidTagName  = `"%s" is an invalid tag name since it contains one or more ':' characters`
+	invalidTagValue = `"%s" is an invalid tag value since it contains one or more ':' characters`
+
+	invalidQueryExpressionFormat = `"%s" is not in a valid expression format. ` +
+		"it must be in the following format: TagName:TagValue, optionally combined with && and || operators"
+
+	// timestamps are compared as strings in the format of the SQLite strftime function below
+	timestampLayout = "2006-01-02 15:04:05.000"
+	// the ':' characters of timestamp tag values are encoded, see query.FormatTime
+	timestampFunction  = "strftime('%Y-%m-%d %H:%M:%f', replace(t.value, '%3A', ':'))"
+	timestampGlobMatch = "t.value GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*'"
+)
+
+var (
+	errBlankStoreName = errors.New("store name cannot be blank")
+	errBlankKey       = errors.New("key cannot be blank")
+)
+
+// Provider is a SQLite implementation of the spi.Provider interface.
+type Provider struct {
+	db   *sql.DB
+	dbs  map[string]*store
+	lock sync.RWMutex
+}
+
+type closer func(storeName string)
+
+// querier is implemented by both sql.DB and sql.Tx, so that store operations can run inside a transaction.
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// NewProvider opens the SQLite database file at the given path, creating it if it doesn't exist,
+// and instantiates Provider.
+// The database is accessed through a single connection since SQLite serializes writes anyway. This also allows
+// to use an in-memory database by passing ":memory:" as path.
+func NewProvider(path string) (*Provider, error) {
+	if path == "" {
+		return nil, errors.New("database path cannot be blank")
+	}
+
+	db, err := sql.Open(driverName, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (name TEXT PRIMARY KEY, config TEXT)", storesTable))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stores table: %w", closeOnError(db, err))
+	}
+
+	return &Provider{db: db, dbs: make(map[string]*store)}, nil
+}
+
+// OpenStore opens and returns a store for the given name, creating its tables if they don't exist.
+func (p *Provider) OpenStore(name string) (storage.Store, error) {
+	if name == "" {
+		return nil, errBlankStoreName
+	}
+
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if openStore, ok := p.dbs[name]; ok {
+		return openStore, nil
+	}
+
+	newStore := &store{
+		db:        p.db,
+		name:      name,
+		dataTable: quoteIdentifier("data_" + name),
+		tagsTable: quoteIdentifier("tags_" + name),
+		close:     p.removeStore,
+	}
+
+	err := withTx(p.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s (key TEXT PRIMARY KEY, value BLOB NOT NULL)", newStore.dataTable))
+		if err != nil {
+			return fmt.Errorf("create data table: %w", err)
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s "+
+			"(key TEXT NOT NULL, name TEXT NOT NULL, value TEXT NOT NULL, num REAL, PRIMARY KEY (key, name))",
+			newStore.tagsTable))
+		if err != nil {
+			return fmt.Errorf("create tags table: %w", err)
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("INSERT OR IGNORE INTO %s (name) VALUES (?)", storesTable), name)
+		if err != nil {
+			return fmt.Errorf("register store: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(`failed to open store "%s": %w`, name, err)
+	}
+
+	p.dbs[name] = newStore
+
+	return newStore, nil
+}
+
+// SetStoreConfig sets the configuration on a store and creates an index for each of its tag names.
+// Indexes of tag names which are no longer in the configuration are dropped.
+// The store must be created prior to calling this method.
+// If the store cannot be found, then an error wrapping spi.ErrStoreNotFound will be returned.
+func (p *Provider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	if name == "" {
+		return errBlankStoreName
+	}
+
+	for _, tagName := range config.TagNames {
+		if strings.Contains(tagName, ":") {
+			return fmt.Errorf(invalidTagName, tagName)
+		}
+	}
+
+	name = strings.ToLower(name)
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal store configuration: %w", err)
+	}
+
+	return withTx(p.db, func(tx *sql.Tx) error {
+		oldConfig, err := getStoreConfig(tx, name)
+		if err != nil {
+			return err
+		}
+
+		newTagNames := make(map[string]struct{})
+
+		for _, tagName := range config.TagNames {
+			newTagNames[tagName] = struct{}{}
+
+			_, err = tx.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (value, num) WHERE name = %s",
+				tagIndexName(name, tagName), quoteIdentifier("tags_"+name), quoteLiteral(tagName)))
+			if err != nil {
+				return fmt.Errorf(`failed to create index for tag name "%s": %w`, tagName, err)
+			}
+		}
+
+		for _, tagName := range oldConfig.TagNames {
+			if _, ok := newTagNames[tagName]; ok {
+				continue
+			}
+
+			_, err = tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", tagIndexName(name, tagName)))
+			if err != nil {
+				return fmt.Errorf(`failed to drop index for tag name "%s": %w`, tagName, err)
+			}
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("UPDATE %s SET config = ? WHERE name = ?", storesTable), string(configBytes), name)
+		if err != nil {
+			return fmt.Errorf("failed to update store configuration: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetStoreConfig returns the current store configuration. The database is checked for the store,
+// so this method can be used to find stores created by previous runs.
+// If the store cannot be found, then an error wrapping spi.ErrStoreNotFound will be returned.
+func (p *Provider) GetStoreConfig(name string) (storage.StoreConfiguration, error) {
+	if name == "" {
+		return storage.StoreConfiguration{}, errBlankStoreName
+	}
+
+	return getStoreConfig(p.db, strings.ToLower(name))
+}
+
+// GetOpenStores returns all Stores currently open in the Provider.
+func (p *Provider) GetOpenStores() []storage.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	openStores := make([]storage.Store, 0, len(p.dbs))
+
+	for _, openStore := range p.dbs {
+		openStores = append(openStores, openStore)
+	}
+
+	return openStores
+}
+
+// Close closes all stores created under this store provider and the underlying database.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+	p.dbs = make(map[string]*store)
+	p.lock.Unlock()
+
+	if err := p.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Provider) removeStore(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.dbs, name)
+}
+
+func getStoreConfig(q querier, name string) (storage.StoreConfiguration, error) {
+	var configBytes sql.NullString
+
+	err := q.QueryRow(fmt.Sprintf("SELECT config FROM %s WHERE name = ?", storesTable), name).Scan(&configBytes)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.StoreConfiguration{}, fmt.Errorf(`store "%s": %w`, name, storage.ErrStoreNotFound)
+		}
+
+		return storage.StoreConfiguration{}, fmt.Errorf(`failed to get store configuration for "%s": %w`, name, err)
+	}
+
+	var config storage.StoreConfiguration
+
+	if !configBytes.Valid {
+		return config, nil
+	}
+
+	err = json.Unmarshal([]byte(configBytes.String), &config)
+	if err != nil {
+		return storage.StoreConfiguration{}, fmt.Errorf("failed to unmarshal store configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+type store struct {
+	db        *sql.DB
+	name      string
+	dataTable string
+	tagsTable string
+	close     closer
+}
+
+// Put stores the key + value pair along with the (optional) tags, replacing existing tags of the key.
+func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
+	return withTx(s.db, func(tx *sql.Tx) error {
+		return s.put(tx, key, value, tags, false)
+	})
+}
+
+// Get fetches the value associated with the given key.
+func (s *store) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, errBlankKey
+	}
+
+	var value []byte
+
+	err := s.db.QueryRow(fmt.Sprintf("SELECT value FROM %s WHERE key = ?", s.dataTable), key).Scan(&value)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrDataNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get value: %w", err)
+	}
+
+	return value, nil
+}
+
+// GetTags fetches all tags associated with the given key.
+func (s *store) GetTags(key string) ([]storage.Tag, error) {
+	if key == "" {
+		return nil, errBlankKey
+	}
+
+	var exists bool
+
+	err := s.db.QueryRow(fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE key = ?)", s.dataTable), key).
+		Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if key exists: %w", err)
+	}
+
+	if !exists {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return s.getTags(key)
+}
+
+// GetBulk fetches the values associated with the given keys in a single database query.
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys slice must contain at least one key")
+	}
+
+	args := make([]interface{}, len(keys))
+
+	for i, key := range keys {
+		if key == "" {
+			return nil, errBlankKey
+		}
+
+		args[i] = key
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf("SELECT key, value FROM %s WHERE key IN (?%s)",
+		s.dataTable, strings.Repeat(", ?", len(keys)-1)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values: %w", err)
+	}
+
+	defer closeRows(rows)
+
+	found := make(map[string][]byte)
+
+	for rows.Next() {
+		var (
+			key   string
+			value []byte
+		)
+
+		if err = rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan value: %w", err)
+		}
+
+		found[key] = value
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get values: %w", err)
+	}
+
+	values := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		values[i] = found[key]
+	}
+
+	return values, nil
+}
+
+// Query returns all data that satisfies the expression. Basic expression format: TagName:TagValue.
+// Criteria can be combined with "&&" and "||" operators, ANDs are evaluated before ORs. Instead of ":", a criterion
+// can use one of the "<", "<=", ">" and ">=" operators to compare numeric or RFC3339 timestamp tag values (see
+// query.FormatTime), and it can be negated with a "!" prefix.
+// All query options are supported. If tag values used for sorting are decimal numbers, then they are sorted
+// numerically.
+func (s *store) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	if expression == "" {
+		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	}
+
+	groups, err := query.Parse(expression)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidFormat) {
+			return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+		}
+
+		return nil, err
+	}
+
+	whereClause, args := buildWhereClause(s.tagsTable, groups)
+
+	queryOptions := getQueryOptions(options)
+
+	if queryOptions.SortOptions != nil && queryOptions.SortOptions.TagName == "" {
+		return nil, errors.New("sort tag name cannot be blank")
+	}
+
+	pageSize := queryOptions.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	selectQuery := fmt.Sprintf("SELECT d.key, d.value FROM %s d", s.dataTable)
+	orderBy := "d.key"
+
+	if queryOptions.SortOptions != nil {
+		selectQuery += fmt.Sprintf(" LEFT JOIN %s sort ON sort.key = d.key AND sort.name = %s",
+			s.tagsTable, quoteLiteral(queryOptions.SortOptions.TagName))
+
+		// numbers are sorted numerically and before other values, data without the sort tag comes last
+		orderBy = "sort.key IS NULL, sort.num IS NULL, sort.num, sort.value, d.key"
+
+		if queryOptions.SortOptions.Order == storage.SortDescending {
+			orderBy = "sort.key IS NULL, sort.num IS NULL, sort.num DESC, sort.value DESC, d.key DESC"
+		}
+	}
+
+	return &iterator{
+		store:      s,
+		query:      fmt.Sprintf("%s WHERE %s ORDER BY %s LIMIT ? OFFSET ?", selectQuery, whereClause, orderBy),
+		countQuery: fmt.Sprintf("SELECT COUNT(*) FROM %s d WHERE %s", s.dataTable, whereClause),
+		args:       args,
+		pageSize:   pageSize,
+		offset:     queryOptions.InitialPageNum * pageSize,
+	}, nil
+}
+
+// Delete deletes the key + value pair (and all tags) associated with key.
+func (s *store) Delete(key string) error {
+	return withTx(s.db, func(tx *sql.Tx) error {
+		return s.delete(tx, key)
+	})
+}
+
+// Batch performs multiple Put and/or Delete operations in order inside a single transaction.
+// If any of the operations fails, then none of them is applied.
+func (s *store) Batch(operations []storage.Operation) error {
+	if len(operations) == 0 {
+		return errors.New("batch requires at least one operation")
+	}
+
+	return withTx(s.db, func(tx *sql.Tx) error {
+		for _, operation := range operations {
+			if operation.Value == nil {
+				if err := s.delete(tx, operation.Key); err != nil {
+					return fmt.Errorf("failed to delete value: %w", err)
+				}
+
+				continue
+			}
+
+			isNewKey := operation.PutOptions != nil && operation.PutOptions.IsNewKey
+
+			if err := s.put(tx, operation.Key, operation.Value, operation.Tags, isNewKey); err != nil {
+				return fmt.Errorf("failed to put value: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// This store doesn't queue values, so there's never anything to flush.
+func (s *store) Flush() error {
+	return nil
+}
+
+// Close removes the store from the open stores of the provider. The underlying database is closed by the provider.
+func (s *store) Close() error {
+	s.close(s.name)
+
+	return nil
+}
+
+func (s *store) put(tx *sql.Tx, key string, value []byte, tags []storage.Tag, isNewKey bool) error {
+	if key == "" {
+		return errBlankKey
+	}
+
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	for _, tag := range tags {
+		if strings.Contains(tag.Name, ":") {
+			return fmt.Errorf(invalidTagName, tag.Name)
+		}
+
+		if strings.Contains(tag.Value, ":") {
+			return fmt.Errorf(invalidTagValue, tag.Value)
+		}
+	}
+
+	if isNewKey {
+		_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (key, value) VALUES (?, ?)", s.dataTable), key, value)
+		if err != nil {
+			var sqliteErr sqlite3.Error
+			if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+				return fmt.Errorf(`key "%s": %w`, key, storage.ErrDuplicateKey)
+			}
+
+			return fmt.Errorf("failed to insert value: %w", err)
+		}
+	} else {
+		_, err := tx.Exec(fmt.Sprintf("INSERT OR REPLACE INTO %s (key, value) VALUES (?, ?)", s.dataTable),
+			key, value)
+		if err != nil {
+			return fmt.Errorf("failed to put value: %w", err)
+		}
+
+		_, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = ?", s.tagsTable), key)
+		if err != nil {
+			return fmt.Errorf("failed to delete previous tags: %w", err)
+		}
+	}
+
+	for _, tag := range tags {
+		_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (key, name, value, num) VALUES (?, ?, ?, ?)", s.tagsTable),
+			key, tag.Name, tag.Value, numericValue(tag.Value))
+		if err != nil {
+			return fmt.Errorf(`failed to put tag "%s": %w`, tag.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *store) delete(tx *sql.Tx, key string) error {
+	if key == "" {
+		return errBlankKey
+	}
+
+	_, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = ?", s.dataTable), key)
+	if err != nil {
+		return fmt.Errorf("failed to delete value: %w", err)
+	}
+
+	_, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = ?", s.tagsTable), key)
+	if err != nil {
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) getTags(key string) ([]storage.Tag, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT name, value FROM %s WHERE key = ?", s.tagsTable), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	defer closeRows(rows)
+
+	var tags []storage.Tag
+
+	for rows.Next() {
+		var tag storage.Tag
+
+		if err = rows.Scan(&tag.Name, &tag.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+type entry struct {
+	key   string
+	value []byte
+}
+
+// iterator fetches the query results one page at a time.
+type iterator struct {
+	store      *store
+	query      string
+	countQuery string
+	args       []interface{}
+	pageSize   int
+	offset     int
+	page       []entry
+	index      int
+	exhausted  bool
+}
+
+func (i *iterator) Next() (bool, error) {
+	if i.index+1 < len(i.page) {
+		i.index++
+
+		return true, nil
+	}
+
+	if i.exhausted {
+		return false, nil
+	}
+
+	if err := i.fetchPage(); err != nil {
+		return false, err
+	}
+
+	return len(i.page) > 0, nil
+}
+
+func (i *iterator) Key() (string, error) {
+	current, err := i.current()
+	if err != nil {
+		return "", err
+	}
+
+	return current.key, nil
+}
+
+func (i *iterator) Value() ([]byte, error) {
+	current, err := i.current()
+	if err != nil {
+		return nil, err
+	}
+
+	return current.value, nil
+}
+
+func (i *iterator) Tags() ([]storage.Tag, error) {
+	current, err := i.current()
+	if err != nil {
+		return nil, err
+	}
+
+	return i.store.getTags(current.key)
+}
+
+func (i *iterator) TotalItems() (int, error) {
+	var count int
+
+	err := i.store.db.QueryRow(i.countQuery, i.args...).Scan(&count)
+	if err != nil {
+		return -1, fmt.Errorf("failed to count query results: %w", err)
+	}
+
+	return count, nil
+}
+
+func (i *iterator) Close() error {
+	i.page = nil
+	i.exhausted = true
+
+	return nil
+}
+
+func (i *iterator) current() (entry, error) {
+	if i.index >= len(i.page) {
+		return entry{}, errors.New("iterator has no current entry")
+	}
+
+	return i.page[i.index], nil
+}
+
+func (i *iterator) fetchPage() error {
+	rows, err := i.store.db.Query(i.query, append(i.args, i.pageSize, i.offset)...)
+	if err != nil {
+		return fmt.Errorf("failed to query page: %w", err)
+	}
+
+	defer closeRows(rows)
+
+	page := make([]entry, 0, i.pageSize)
+
+	for rows.Next() {
+		var e entry
+
+		if err = rows.Scan(&e.key, &e.value); err != nil {
+			return fmt.Errorf("failed to scan query result: %w", err)
+		}
+
+		page = append(page, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to query page: %w", err)
+	}
+
+	i.page = page
+	i.index = 0
+	i.offset += len(page)
+	i.exhausted = len(page) < i.pageSize
+
+	return nil
+}
+
+// buildWhereClause converts the parsed query expression to a WHERE clause on the data table (aliased d).
+func buildWhereClause(tagsTable string, groups query.Expression) (string, []interface{}) {
+	var (
+		orClauses []string
+		args      []interface{}
+	)
+
+	for _, group := range groups {
+		var andClauses []string
+
+		for _, c := range group {
+			clause := fmt.Sprintf("EXISTS (SELECT 1 FROM %s t WHERE t.key = d.key AND t.name = %s",
+				tagsTable, quoteLiteral(c.TagName))
+
+			switch {
+			case c.Operator == "" || c.Operator == query.EqualOperator:
+				if c.Value != "" {
+					clause += " AND t.value = ?"
+
+					args = append(args, c.Value)
+				}
+			case c.Operand.IsTime:
+				// numbers aren't comparable to timestamps
+				clause += fmt.Sprintf(" AND t.num IS NULL AND %s AND %s %s ?",
+					timestampGlobMatch, timestampFunction, c.Operator)
+
+				args = append(args, c.Operand.Time.UTC().Format(timestampLayout))
+			default:
+				clause += fmt.Sprintf(" AND t.num %s ?", c.Operator)
+
+				args = append(args, c.Operand.Number)
+			}
+
+			clause += ")"
+
+			if c.Negated {
+				clause = "NOT " + clause
+			}
+
+			andClauses = append(andClauses, clause)
+		}
+
+		orClauses = append(orClauses, "("+strings.Join(andClauses, " AND ")+")")
+	}
+
+	return strings.Join(orClauses, " OR "), args
+}
+
+func getQueryOptions(options []storage.QueryOption) storage.QueryOptions {
+	var queryOptions storage.QueryOptions
+
+	for _, option := range options {
+		option(&queryOptions)
+	}
+
+	return queryOptions
+}
+
+func withTx(db *sql.DB, f func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err = f(tx); err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return fmt.Errorf("%w (rollback failed: %s)", err, errRollback.Error())
+		}
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// numericValue returns the tag value as a number if it's a decimal number, so that it's sorted numerically.
+func numericValue(value string) interface{} {
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(num) || math.IsInf(num, 0) {
+		return nil
+	}
+
+	return num
+}
+
+func tagIndexName(storeName, tagName string) string {
+	return quoteIdentifier(fmt.Sprintf("tags_%s_%x", storeName, tagName))
+}
+
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func quoteLiteral(literal string) string {
+	return "'" + strings.ReplaceAll(literal, "'", "''") + "'"
+}
+
+func closeRows(rows *sql.Rows) {
+	// rows are fully read before closing, an error is already reported by rows.Err
+	_ = rows.Close()
+}
+
+func closeOnError(db *sql.DB, err error) error {
+	if errClose := db.Close(); errClose != nil {
+		return fmt.Errorf("%w (failed to close database: %s)", err, errClose.Error())
+	}
+
+	return err
+}
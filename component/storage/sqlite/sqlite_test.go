@@ -0,0 +1,277 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlite_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storage/sqlite"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+	commontest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func setupSQLite(t testing.TB) string {
+	return filepath.Join(t.TempDir(), "aries.db")
+}
+
+func TestCommon(t *testing.T) {
+	provider, err := sqlite.NewProvider(setupSQLite(t))
+	require.NoError(t, err)
+
+	commontest.TestAll(t, provider)
+}
+
+func TestCommonComparisonOperators(t *testing.T) {
+	provider, err := sqlite.NewProvider(setupSQLite(t))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	commontest.TestStoreQueryWithComparisonOperators(t, provider)
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("Blank path", func(t *testing.T) {
+		provider, err := sqlite.NewProvider("")
+		require.EqualError(t, err, "database path cannot be blank")
+		require.Nil(t, provider)
+	})
+	t.Run("Fail to create stores table", func(t *testing.T) {
+		provider, err := sqlite.NewProvider(filepath.Join(t.TempDir(), "missing", "aries.db"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create stores table")
+		require.Nil(t, provider)
+	})
+	t.Run("In-memory database", func(t *testing.T) {
+		provider, err := sqlite.NewProvider(":memory:")
+		require.NoError(t, err)
+
+		store, err := provider.OpenStore(randomStoreName())
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put("key", []byte("value")))
+
+		value, err := store.Get("key")
+		require.NoError(t, err)
+		require.Equal(t, "value", string(value))
+
+		require.NoError(t, provider.Close())
+	})
+}
+
+func TestProvider_Persistence(t *testing.T) {
+	path := setupSQLite(t)
+	storeName := randomStoreName()
+
+	provider, err := sqlite.NewProvider(path)
+	require.NoError(t, err)
+
+	store, err := provider.OpenStore(storeName)
+	require.NoError(t, err)
+
+	require.NoError(t, provider.SetStoreConfig(storeName, storage.StoreConfiguration{TagNames: []string{"tagName"}}))
+	require.NoError(t, store.Put("key", []byte("value"), storage.Tag{Name: "tagName", Value: "tagValue"}))
+	require.NoError(t, provider.Close())
+
+	provider, err = sqlite.NewProvider(path)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	// the store config is found in the database before the store is opened
+	config, err := provider.GetStoreConfig(storeName)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tagName"}, config.TagNames)
+
+	store, err = provider.OpenStore(storeName)
+	require.NoError(t, err)
+
+	iterator, err := store.Query("tagName:tagValue")
+	require.NoError(t, err)
+
+	more, err := iterator.Next()
+	require.NoError(t, err)
+	require.True(t, more)
+
+	value, err := iterator.Value()
+	require.NoError(t, err)
+	require.Equal(t, "value", string(value))
+}
+
+func TestStore_Query(t *testing.T) {
+	provider, err := sqlite.NewProvider(setupSQLite(t))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	store, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Batch([]storage.Operation{
+		{Key: "key1", Value: []byte("value1"), Tags: []storage.Tag{{Name: "a", Value: "1"}, {Name: "b", Value: "1"}}},
+		{Key: "key2", Value: []byte("value2"), Tags: []storage.Tag{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}},
+		{Key: "key3", Value: []byte("value3"), Tags: []storage.Tag{{Name: "a", Value: "2"}, {Name: "c"}}},
+		{Key: "key4", Value: []byte("value4"), Tags: []storage.Tag{{Name: "d", Value: "o'brien"}}},
+	}))
+
+	t.Run("Advanced expressions", func(t *testing.T) {
+		tests := map[string][]string{
+			"a:1&&b:2":         {"key2"},
+			"a:1&&b":           {"key1", "key2"},
+			"b:1||c":           {"key1", "key3"},
+			"a:1&&b:1||a:2&&c": {"key1", "key3"},
+			"a:2&&b||d":        {"key4"},
+			"d:o'brien":        {"key4"},
+			"a:3||e":           nil,
+		}
+
+		for expression, expectedKeys := range tests {
+			iterator, err := store.Query(expression)
+			require.NoError(t, err, expression)
+			require.Equal(t, expectedKeys, iteratorKeys(t, iterator), expression)
+		}
+	})
+	t.Run("Invalid expressions", func(t *testing.T) {
+		for _, expression := range []string{"", "a:1&&", "||a", "a:1:2||b"} {
+			iterator, err := store.Query(expression)
+			require.Error(t, err, expression)
+			require.Nil(t, iterator)
+		}
+	})
+	t.Run("Blank sort tag name", func(t *testing.T) {
+		iterator, err := store.Query("a", storage.WithSortOrder(&storage.SortOptions{}))
+		require.EqualError(t, err, "sort tag name cannot be blank")
+		require.Nil(t, iterator)
+	})
+	t.Run("Data without the sort tag comes last", func(t *testing.T) {
+		iterator, err := store.Query("a||d", storage.WithSortOrder(&storage.SortOptions{
+			Order:   storage.SortDescending,
+			TagName: "b",
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"key2", "key1", "key4", "key3"}, iteratorKeys(t, iterator))
+	})
+	t.Run("Iterator without current entry", func(t *testing.T) {
+		iterator, err := store.Query("a")
+		require.NoError(t, err)
+
+		_, err = iterator.Key()
+		require.EqualError(t, err, "iterator has no current entry")
+
+		require.NoError(t, iterator.Close())
+
+		more, err := iterator.Next()
+		require.NoError(t, err)
+		require.False(t, more)
+	})
+}
+
+func TestStore_Batch(t *testing.T) {
+	provider, err := sqlite.NewProvider(setupSQLite(t))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	store, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put("key1", []byte("value1")))
+
+	t.Run("Duplicate key with the new key optimization", func(t *testing.T) {
+		err := store.Batch([]storage.Operation{
+			{Key: "key2", Value: []byte("value2"), PutOptions: &storage.PutOptions{IsNewKey: true}},
+			{Key: "key1", Value: []byte("value1"), PutOptions: &storage.PutOptions{IsNewKey: true}},
+		})
+		require.True(t, errors.Is(err, storage.ErrDuplicateKey))
+	})
+	t.Run("Failed operation rolls back the whole batch", func(t *testing.T) {
+		err := store.Batch([]storage.Operation{
+			{Key: "key1"},
+			{Key: "key2", Value: []byte("value2")},
+			{Key: "key3", Value: []byte("value3"), Tags: []storage.Tag{{Name: "invalid:name"}}},
+		})
+		require.EqualError(t, err, `failed to put value: "invalid:name" is an invalid tag name `+
+			`since it contains one or more ':' characters`)
+
+		value, err := store.Get("key1")
+		require.NoError(t, err)
+		require.Equal(t, "value1", string(value))
+
+		_, err = store.Get("key2")
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
+
+func TestProvider_SetStoreConfig(t *testing.T) {
+	provider, err := sqlite.NewProvider(setupSQLite(t))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	t.Run("Blank store name", func(t *testing.T) {
+		require.EqualError(t, provider.SetStoreConfig("", storage.StoreConfiguration{}), "store name cannot be blank")
+
+		_, err := provider.GetStoreConfig("")
+		require.EqualError(t, err, "store name cannot be blank")
+	})
+	t.Run("Tag names with special characters", func(t *testing.T) {
+		storeName := randomStoreName()
+
+		store, err := provider.OpenStore(storeName)
+		require.NoError(t, err)
+
+		tagNames := []string{`tag"Name`, "tag'Name", "TagName", "tagname"}
+
+		require.NoError(t, provider.SetStoreConfig(storeName, storage.StoreConfiguration{TagNames: tagNames}))
+		require.NoError(t, store.Put("key", []byte("value"), storage.Tag{Name: "tag'Name", Value: "value"}))
+
+		iterator, err := store.Query("tag'Name:value")
+		require.NoError(t, err)
+		require.Equal(t, []string{"key"}, iteratorKeys(t, iterator))
+
+		require.NoError(t, provider.SetStoreConfig(storeName, storage.StoreConfiguration{TagNames: tagNames[2:]}))
+	})
+}
+
+func iteratorKeys(t *testing.T, iterator storage.Iterator) []string {
+	t.Helper()
+
+	var keys []string
+
+	for {
+		more, err := iterator.Next()
+		require.NoError(t, err)
+
+		if !more {
+			return keys
+		}
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+}
+
+func randomStoreName() string {
+	return "store-" + uuid.New().String()
+}
@@ -0,0 +1,553 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiringstore
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// ExpiresAtTagName is the name of the tag used to persist the deadline of data put with the
+	// spi.PutOptions.ExpiresAt option in the underlying store. Its value is the deadline in nanoseconds since the
+	// Unix epoch. The tag is hidden from callers and can't be used by them.
+	ExpiresAtTagName = "expiringStoreExpiresAt"
+
+	// DefaultSweepInterval is the interval between sweeps of expired data used if none is given to NewProvider.
+	DefaultSweepInterval = time.Minute
+
+	invalidTagName  = `"%s" is an invalid tag name since it contains one or more ':' characters`
+	invalidTagValue = `"%s" is an invalid tag value since it contains one or more ':' characters`
+	reservedTagName = `"%s" is a reserved tag name`
+)
+
+// Provider is a spi.Provider that adds support for the spi.PutOptions.ExpiresAt option.
+// It acts as a wrapper around another storage provider (typically, one that doesn't support expiry natively).
+// Deadlines are persisted as a tag in the underlying store and kept in memory. Expired data is hidden immediately
+// and deleted from the underlying store by a background sweeper.
+// Deadlines are only loaded when a store is opened, so data put with a deadline by other instances sharing the same
+// underlying database afterwards won't be hidden or swept by this instance.
+type Provider struct {
+	underlyingProvider spi.Provider
+	openStores         map[string]*store
+	lock               sync.RWMutex
+	done               chan struct{}
+	closeOnce          sync.Once
+}
+
+type closer func(name string)
+
+// NewProvider instantiates a new expiring Provider and starts its sweeper, which deletes expired data from the
+// underlying stores every sweepInterval. If sweepInterval isn't positive, then DefaultSweepInterval is used.
+// Close must be called to stop the sweeper.
+func NewProvider(underlyingProvider spi.Provider, sweepInterval time.Duration) *Provider {
+	if sweepInterval <= 0 {
+		sweepInterval = DefaultSweepInterval
+	}
+
+	p := &Provider{
+		underlyingProvider: underlyingProvider,
+		openStores:         make(map[string]*store),
+		done:               make(chan struct{}),
+	}
+
+	go p.sweepPeriodically(sweepInterval)
+
+	return p
+}
+
+// OpenStore opens a store with the given name and returns a handle.
+// If the store has never been opened before, then it is created.
+// Store names are not case-sensitive. If name is blank, then an error will be returned by the underlying provider.
+func (p *Provider) OpenStore(name string) (spi.Store, error) {
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	openStore, ok := p.openStores[name]
+	if !ok {
+		underlyingStore, err := p.underlyingProvider.OpenStore(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open store in underlying provider: %w", err)
+		}
+
+		newStore := &store{
+			name:            name,
+			underlyingStore: underlyingStore,
+			deadlines:       make(map[string]time.Time),
+			close:           p.removeStore,
+		}
+
+		err = newStore.loadDeadlines()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load deadlines from underlying store: %w", err)
+		}
+
+		p.openStores[name] = newStore
+
+		return newStore, nil
+	}
+
+	return openStore, nil
+}
+
+// SetStoreConfig sets the configuration on a store.
+// The store must be created prior to calling this method.
+// If the store cannot be found, then an error wrapping ErrStoreNotFound will be returned by the underlying provider.
+// If name is blank, then an error will be returned by the underlying provider.
+func (p *Provider) SetStoreConfig(name string, config spi.StoreConfiguration) error {
+	for _, tagName := range config.TagNames {
+		if strings.Contains(tagName, ":") {
+			return fmt.Errorf(invalidTagName, tagName)
+		}
+
+		if tagName == ExpiresAtTagName {
+			return fmt.Errorf(reservedTagName, tagName)
+		}
+	}
+
+	config.TagNames = append(append([]string(nil), config.TagNames...), ExpiresAtTagName)
+
+	err := p.underlyingProvider.SetStoreConfig(name, config)
+	if err != nil {
+		return fmt.Errorf("failed to set store config in underlying provider: %w", err)
+	}
+
+	return nil
+}
+
+// GetStoreConfig gets the current store configuration.
+// The store must be created prior to calling this method.
+// If the store cannot be found, then an error wrapping ErrStoreNotFound will be returned by the underlying provider.
+// If name is blank, then an error will be returned by the underlying provider.
+func (p *Provider) GetStoreConfig(name string) (spi.StoreConfiguration, error) {
+	config, err := p.underlyingProvider.GetStoreConfig(name)
+	if err != nil {
+		return spi.StoreConfiguration{},
+			fmt.Errorf("failed to get store config from underlying provider: %w", err)
+	}
+
+	var tagNames []string
+
+	for _, tagName := range config.TagNames {
+		if tagName != ExpiresAtTagName {
+			tagNames = append(tagNames, tagName)
+		}
+	}
+
+	config.TagNames = tagNames
+
+	return config, nil
+}
+
+// GetOpenStores returns all currently open stores.
+func (p *Provider) GetOpenStores() []spi.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	openStores := make([]spi.Store, len(p.openStores))
+
+	var counter int
+
+	for _, openStore := range p.openStores {
+		openStores[counter] = openStore
+		counter++
+	}
+
+	return openStores
+}
+
+// Close stops the sweeper and closes all stores created under this store provider.
+// For persistent store implementations, this does not delete any data in the underlying databases.
+func (p *Provider) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+
+	p.lock.Lock()
+	p.openStores = make(map[string]*store)
+	p.lock.Unlock()
+
+	err := p.underlyingProvider.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close underlying provider: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Provider) removeStore(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.openStores, name)
+}
+
+func (p *Provider) sweepPeriodically(sweepInterval time.Duration) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.sweep()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// sweep deletes expired data from all open stores. Failures are retried on the next sweep.
+func (p *Provider) sweep() {
+	p.lock.RLock()
+
+	openStores := make([]*store, 0, len(p.openStores))
+
+	for _, openStore := range p.openStores {
+		openStores = append(openStores, openStore)
+	}
+
+	p.lock.RUnlock()
+
+	for _, openStore := range openStores {
+		openStore.sweep()
+	}
+}
+
+type store struct {
+	name            string
+	underlyingStore spi.Store
+	deadlines       map[string]time.Time
+	close           closer
+	lock            sync.RWMutex
+}
+
+func (s *store) Put(key string, value []byte, tags ...spi.Tag) error {
+	err := checkTags(tags)
+	if err != nil {
+		return err
+	}
+
+	// The lock is held during the write so that the sweeper can't delete the data that's being put.
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err = s.underlyingStore.Put(key, value, tags...)
+	if err != nil {
+		return fmt.Errorf("failed to put data in underlying store: %w", err)
+	}
+
+	delete(s.deadlines, key)
+
+	return nil
+}
+
+func (s *store) Get(key string) ([]byte, error) {
+	value, err := s.underlyingStore.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get value from underlying store: %w", err)
+	}
+
+	if s.isExpired(key, time.Now()) {
+		return nil, spi.ErrDataNotFound
+	}
+
+	return value, nil
+}
+
+func (s *store) GetTags(key string) ([]spi.Tag, error) {
+	tags, err := s.underlyingStore.GetTags(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags from underlying store: %w", err)
+	}
+
+	if s.isExpired(key, time.Now()) {
+		return nil, spi.ErrDataNotFound
+	}
+
+	return withoutExpiresAtTag(tags), nil
+}
+
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	values, err := s.underlyingStore.GetBulk(keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values from underlying store: %w", err)
+	}
+
+	now := time.Now()
+
+	for i, key := range keys {
+		if s.isExpired(key, now) {
+			values[i] = nil
+		}
+	}
+
+	return values, nil
+}
+
+func (s *store) Query(expression string, options ...spi.QueryOption) (spi.Iterator, error) {
+	underlyingIterator, err := s.underlyingStore.Query(expression, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query underlying store: %w", err)
+	}
+
+	return &iterator{underlyingIterator: underlyingIterator, store: s, now: time.Now()}, nil
+}
+
+func (s *store) Delete(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.underlyingStore.Delete(key)
+	if err != nil {
+		return fmt.Errorf("failed to delete data in underlying store: %w", err)
+	}
+
+	delete(s.deadlines, key)
+
+	return nil
+}
+
+// Batch performs the operations in the underlying store. The deadline of each operation using the
+// spi.PutOptions.ExpiresAt option is persisted as a tag instead.
+func (s *store) Batch(operations []spi.Operation) error {
+	underlyingOperations := make([]spi.Operation, len(operations))
+
+	for i, operation := range operations {
+		err := checkTags(operation.Tags)
+		if err != nil {
+			return err
+		}
+
+		underlyingOperations[i] = operation
+
+		if operation.Value == nil || operation.PutOptions == nil || operation.PutOptions.ExpiresAt.IsZero() {
+			continue
+		}
+
+		underlyingOperations[i].Tags = append(append([]spi.Tag(nil), operation.Tags...), spi.Tag{
+			Name:  ExpiresAtTagName,
+			Value: strconv.FormatInt(operation.PutOptions.ExpiresAt.UnixNano(), 10),
+		})
+		underlyingOperations[i].PutOptions = &spi.PutOptions{IsNewKey: operation.PutOptions.IsNewKey}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.underlyingStore.Batch(underlyingOperations)
+	if err != nil {
+		return fmt.Errorf("failed to perform operations in underlying store: %w", err)
+	}
+
+	for _, operation := range operations {
+		if operation.Value == nil || operation.PutOptions == nil || operation.PutOptions.ExpiresAt.IsZero() {
+			delete(s.deadlines, operation.Key)
+
+			continue
+		}
+
+		s.deadlines[operation.Key] = operation.PutOptions.ExpiresAt
+	}
+
+	return nil
+}
+
+func (s *store) Flush() error {
+	err := s.underlyingStore.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush underlying store: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) Close() error {
+	s.close(s.name)
+
+	err := s.underlyingStore.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close underlying store: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) loadDeadlines() error {
+	underlyingIterator, err := s.underlyingStore.Query(ExpiresAtTagName)
+	if err != nil {
+		return fmt.Errorf("failed to query underlying store: %w", err)
+	}
+
+	defer func() {
+		_ = underlyingIterator.Close() // nolint:errcheck // Nothing is left to clean up if this fails.
+	}()
+
+	for {
+		more, err := underlyingIterator.Next()
+		if err != nil {
+			return fmt.Errorf("failed to get next entry: %w", err)
+		}
+
+		if !more {
+			return nil
+		}
+
+		key, err := underlyingIterator.Key()
+		if err != nil {
+			return fmt.Errorf("failed to get key: %w", err)
+		}
+
+		tags, err := underlyingIterator.Tags()
+		if err != nil {
+			return fmt.Errorf("failed to get tags: %w", err)
+		}
+
+		for _, tag := range tags {
+			if tag.Name != ExpiresAtTagName {
+				continue
+			}
+
+			deadline, err := strconv.ParseInt(tag.Value, 10, 64)
+			if err != nil {
+				return fmt.Errorf(`invalid deadline "%s" stored under %s: %w`, tag.Value, key, err)
+			}
+
+			s.deadlines[key] = time.Unix(0, deadline)
+		}
+	}
+}
+
+func (s *store) isExpired(key string, now time.Time) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	deadline, ok := s.deadlines[key]
+
+	return ok && !now.Before(deadline)
+}
+
+// sweep deletes expired data from the underlying store.
+func (s *store) sweep() {
+	now := time.Now()
+
+	var expiredKeys []string
+
+	s.lock.RLock()
+
+	for key, deadline := range s.deadlines {
+		if !now.Before(deadline) {
+			expiredKeys = append(expiredKeys, key)
+		}
+	}
+
+	s.lock.RUnlock()
+
+	for _, key := range expiredKeys {
+		s.lock.Lock()
+
+		// The data may have been put again since the deadlines were checked.
+		deadline, ok := s.deadlines[key]
+		if ok && !now.Before(deadline) {
+			err := s.underlyingStore.Delete(key)
+			if err == nil || errors.Is(err, spi.ErrDataNotFound) {
+				delete(s.deadlines, key)
+			}
+		}
+
+		s.lock.Unlock()
+	}
+}
+
+type iterator struct {
+	underlyingIterator spi.Iterator
+	store              *store
+	now                time.Time
+}
+
+// Next moves the pointer to the next entry in the iterator, skipping any expired data.
+func (i *iterator) Next() (bool, error) {
+	for {
+		more, err := i.underlyingIterator.Next()
+		if err != nil || !more {
+			return more, err
+		}
+
+		key, err := i.underlyingIterator.Key()
+		if err != nil {
+			return false, err
+		}
+
+		if !i.store.isExpired(key, i.now) {
+			return true, nil
+		}
+	}
+}
+
+func (i *iterator) Key() (string, error) {
+	return i.underlyingIterator.Key()
+}
+
+func (i *iterator) Value() ([]byte, error) {
+	return i.underlyingIterator.Value()
+}
+
+func (i *iterator) Tags() ([]spi.Tag, error) {
+	tags, err := i.underlyingIterator.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutExpiresAtTag(tags), nil
+}
+
+// TotalItems returns the total number of items reported by the underlying iterator. Expired data that hasn't been
+// swept yet is still counted.
+func (i *iterator) TotalItems() (int, error) {
+	return i.underlyingIterator.TotalItems()
+}
+
+func (i *iterator) Close() error {
+	return i.underlyingIterator.Close()
+}
+
+func checkTags(tags []spi.Tag) error {
+	for _, tag := range tags {
+		if strings.Contains(tag.Name, ":") {
+			return fmt.Errorf(invalidTagName, tag.Name)
+		}
+
+		if strings.Contains(tag.Value, ":") {
+			return fmt.Errorf(invalidTagValue, tag.Value)
+		}
+
+		if tag.Name == ExpiresAtTagName {
+			return fmt.Errorf(reservedTagName, tag.Name)
+		}
+	}
+
+	return nil
+}
+
+func withoutExpiresAtTag(tags []spi.Tag) []spi.Tag {
+	var filteredTags []spi.Tag
+
+	for _, tag := range tags {
+		if tag.Name != ExpiresAtTagName {
+			filteredTags = append(filteredTags, tag)
+		}
+	}
+
+	return filteredTags
+}
@@ -0,0 +1,211 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiringstore_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/expiringstore"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+	commonstoragetest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func TestCommon(t *testing.T) {
+	provider := expiringstore.NewProvider(mem.NewProvider(), 0)
+
+	commonstoragetest.TestAll(t, provider, commonstoragetest.SkipSortTests(false))
+	commonstoragetest.TestStoreExpiry(t, provider)
+}
+
+func TestProvider_OpenStore(t *testing.T) {
+	t.Run("Fail to open store in the underlying provider", func(t *testing.T) {
+		provider := expiringstore.NewProvider(&mock.Provider{ErrOpenStore: errors.New("open store failure")}, 0)
+
+		defer func() {
+			require.NoError(t, provider.Close())
+		}()
+
+		store, err := provider.OpenStore("StoreName")
+		require.EqualError(t, err, "failed to open store in underlying provider: open store failure")
+		require.Nil(t, store)
+	})
+	t.Run("Fail to load deadlines", func(t *testing.T) {
+		provider := expiringstore.NewProvider(&mock.Provider{
+			OpenStoreReturn: &mock.Store{ErrQuery: errors.New("query failure")},
+		}, 0)
+
+		defer func() {
+			require.NoError(t, provider.Close())
+		}()
+
+		store, err := provider.OpenStore("StoreName")
+		require.EqualError(t, err, "failed to load deadlines from underlying store: "+
+			"failed to query underlying store: query failure")
+		require.Nil(t, store)
+	})
+	t.Run("Deadlines are loaded from the underlying store", func(t *testing.T) {
+		underlyingProvider := mem.NewProvider()
+
+		provider := expiringstore.NewProvider(underlyingProvider, 0)
+
+		store, err := provider.OpenStore("StoreName")
+		require.NoError(t, err)
+
+		require.NoError(t, store.Batch([]spi.Operation{
+			{Key: "key", Value: []byte("value"), PutOptions: &spi.PutOptions{
+				ExpiresAt: time.Now().Add(100 * time.Millisecond),
+			}},
+		}))
+
+		// A new instance over the same underlying provider still knows about the deadline.
+		provider = expiringstore.NewProvider(underlyingProvider, 0)
+
+		defer func() {
+			require.NoError(t, provider.Close())
+		}()
+
+		store, err = provider.OpenStore("StoreName")
+		require.NoError(t, err)
+
+		tags, err := store.GetTags("key")
+		require.NoError(t, err)
+		require.Empty(t, tags)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = store.Get("key")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+	})
+}
+
+func TestProvider_SetStoreConfig(t *testing.T) {
+	provider := expiringstore.NewProvider(mem.NewProvider(), 0)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	_, err := provider.OpenStore("StoreName")
+	require.NoError(t, err)
+
+	t.Run("Reserved tag name", func(t *testing.T) {
+		err := provider.SetStoreConfig("StoreName",
+			spi.StoreConfiguration{TagNames: []string{expiringstore.ExpiresAtTagName}})
+		require.EqualError(t, err, `"expiringStoreExpiresAt" is a reserved tag name`)
+	})
+	t.Run("The tag used for deadlines is hidden", func(t *testing.T) {
+		require.NoError(t, provider.SetStoreConfig("StoreName", spi.StoreConfiguration{TagNames: []string{"tagName"}}))
+
+		config, err := provider.GetStoreConfig("StoreName")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tagName"}, config.TagNames)
+	})
+}
+
+func TestStore_Batch(t *testing.T) {
+	provider := expiringstore.NewProvider(mem.NewProvider(), 0)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	store, err := provider.OpenStore("StoreName")
+	require.NoError(t, err)
+
+	t.Run("Reserved tag name", func(t *testing.T) {
+		err := store.Batch([]spi.Operation{
+			{Key: "key", Value: []byte("value"), Tags: []spi.Tag{{Name: expiringstore.ExpiresAtTagName}}},
+		})
+		require.EqualError(t, err, `"expiringStoreExpiresAt" is a reserved tag name`)
+
+		err = store.Put("key", []byte("value"), spi.Tag{Name: expiringstore.ExpiresAtTagName})
+		require.EqualError(t, err, `"expiringStoreExpiresAt" is a reserved tag name`)
+	})
+	t.Run("The tag used for deadlines is hidden", func(t *testing.T) {
+		tags := []spi.Tag{{Name: "tagName", Value: "tagValue"}}
+
+		require.NoError(t, store.Batch([]spi.Operation{
+			{Key: "key", Value: []byte("value"), Tags: tags, PutOptions: &spi.PutOptions{
+				ExpiresAt: time.Now().Add(time.Hour),
+			}},
+		}))
+
+		retrievedTags, err := store.GetTags("key")
+		require.NoError(t, err)
+		require.Equal(t, tags, retrievedTags)
+
+		iterator, err := store.Query("tagName")
+		require.NoError(t, err)
+
+		more, err := iterator.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		retrievedTags, err = iterator.Tags()
+		require.NoError(t, err)
+		require.Equal(t, tags, retrievedTags)
+
+		require.NoError(t, iterator.Close())
+	})
+	t.Run("Fail to perform operations in the underlying store", func(t *testing.T) {
+		provider := expiringstore.NewProvider(&mock.Provider{
+			OpenStoreReturn: &mock.Store{
+				QueryReturn: &mock.Iterator{},
+				ErrBatch:    errors.New("batch failure"),
+			},
+		}, 0)
+
+		defer func() {
+			require.NoError(t, provider.Close())
+		}()
+
+		store, err := provider.OpenStore("StoreName")
+		require.NoError(t, err)
+
+		err = store.Batch([]spi.Operation{{Key: "key", Value: []byte("value")}})
+		require.EqualError(t, err, "failed to perform operations in underlying store: batch failure")
+	})
+}
+
+func TestProvider_Sweep(t *testing.T) {
+	underlyingProvider := mem.NewProvider()
+
+	provider := expiringstore.NewProvider(underlyingProvider, 10*time.Millisecond)
+
+	defer func() {
+		require.NoError(t, provider.Close())
+	}()
+
+	store, err := provider.OpenStore("StoreName")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Batch([]spi.Operation{
+		{Key: "expiring", Value: []byte("value1"), PutOptions: &spi.PutOptions{
+			ExpiresAt: time.Now().Add(10 * time.Millisecond),
+		}},
+		{Key: "permanent", Value: []byte("value2")},
+	}))
+
+	underlyingStore, err := underlyingProvider.OpenStore("StoreName")
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := underlyingStore.Get("expiring")
+
+		return errors.Is(err, spi.ErrDataNotFound)
+	}, time.Second, 10*time.Millisecond)
+
+	value, err := underlyingStore.Get("permanent")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+}
@@ -7,18 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package mem
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/watchablestore"
 	spi "github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
-	expressionTagNameOnlyLength     = 1
-	expressionTagNameAndValueLength = 2
-
 	invalidTagName  = `"%s" is an invalid tag name since it contains one or more ':' characters`
 	invalidTagValue = `"%s" is an invalid tag value since it contains one or more ':' characters`
 )
@@ -127,6 +128,10 @@ func (p *Provider) Close() error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
+	for _, db := range p.dbs {
+		db.broadcaster.Close()
+	}
+
 	p.dbs = make(map[string]*memStore)
 
 	return nil
@@ -149,15 +154,21 @@ func (p *Provider) removeStore(name string) {
 }
 
 type dbEntry struct {
-	value []byte
-	tags  []spi.Tag
+	value     []byte
+	tags      []spi.Tag
+	expiresAt time.Time
+}
+
+func (e *dbEntry) isExpired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
 }
 
 type memStore struct {
-	name   string
-	db     map[string]dbEntry
-	config spi.StoreConfiguration
-	close  closer
+	name        string
+	db          map[string]dbEntry
+	config      spi.StoreConfiguration
+	close       closer
+	broadcaster watchablestore.Broadcaster
 	sync.RWMutex
 }
 
@@ -188,6 +199,8 @@ func (m *memStore) Put(key string, value []byte, tags ...spi.Tag) error {
 		tags:  tags,
 	}
 
+	m.broadcaster.Publish(spi.Event{Type: spi.EventPut, Key: key, Value: value, Tags: tags})
+
 	return nil
 }
 
@@ -199,10 +212,7 @@ func (m *memStore) Get(key string) ([]byte, error) {
 		return nil, errEmptyKey
 	}
 
-	m.RLock()
-	defer m.RUnlock()
-	entry, ok := m.db[key]
-
+	entry, ok := m.getDBEntry(key)
 	if !ok {
 		return nil, spi.ErrDataNotFound
 	}
@@ -218,10 +228,7 @@ func (m *memStore) GetTags(key string) ([]spi.Tag, error) {
 		return nil, errEmptyKey
 	}
 
-	m.RLock()
-	defer m.RUnlock()
-	entry, ok := m.db[key]
-
+	entry, ok := m.getDBEntry(key)
 	if !ok {
 		return nil, spi.ErrDataNotFound
 	}
@@ -245,24 +252,20 @@ func (m *memStore) GetBulk(keys ...string) ([][]byte, error) {
 
 	values := make([][]byte, len(keys))
 
-	m.RLock()
-	defer m.RUnlock()
-
 	for i, key := range keys {
-		values[i] = m.db[key].value
+		if entry, ok := m.getDBEntry(key); ok {
+			values[i] = entry.value
+		}
 	}
 
 	return values, nil
 }
 
-type queryResult struct {
-	keys      []string
-	dbEntries []dbEntry
-}
-
-// Query returns all data that satisfies the expression. Expression format: TagName:TagValue.
+// Query returns all data that satisfies the expression. Basic expression format: TagName:TagValue.
 // If TagValue is not provided, then all data associated with the TagName will be returned.
-// For now, expression can only be a single tag Name + Value pair.
+// Criteria can be combined with "&&" and "||" operators, ANDs are evaluated before ORs. Instead of ":", a criterion
+// can use one of the "<", "<=", ">" and ">=" operators to compare numeric or RFC3339 timestamp tag values (see
+// query.FormatTime), and it can be negated with a "!" prefix.
 // None of the current query options are supported
 // spi.WithPageSize will simply be ignored since it only relates to performance and not the actual end result.
 // spi.WithInitialPageNum and spi.WithSortOrder will result in an error being returned since those options do
@@ -277,30 +280,16 @@ func (m *memStore) Query(expression string, options ...spi.QueryOption) (spi.Ite
 		return nil, errInvalidQueryExpressionFormat
 	}
 
-	queryResults := make(map[string]*queryResult)
-
-	for _, exp := range strings.Split(expression, "&&") {
-		expressionSplit := strings.Split(exp, ":")
-		switch len(expressionSplit) {
-		case expressionTagNameOnlyLength:
-			expressionTagName := expressionSplit[0]
-
-			keys, dbEntries := m.getMatchingKeysAndDBEntries(expressionTagName, "")
-
-			queryResults[expressionTagName] = &queryResult{keys: keys, dbEntries: dbEntries}
-		case expressionTagNameAndValueLength:
-			expressionTagName := expressionSplit[0]
-			expressionTagValue := expressionSplit[1]
-
-			keys, dbEntries := m.getMatchingKeysAndDBEntries(expressionTagName, expressionTagValue)
-
-			queryResults[expressionTagName] = &queryResult{keys: keys, dbEntries: dbEntries}
-		default:
+	groups, err := query.Parse(expression)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidFormat) {
 			return nil, errInvalidQueryExpressionFormat
 		}
+
+		return nil, err
 	}
 
-	keys, dbEntries := commonDBEntries(queryResults)
+	keys, dbEntries := m.getMatchingKeysAndDBEntries(groups)
 
 	return &memIterator{keys: keys, dbEntries: dbEntries}, nil
 }
@@ -314,13 +303,19 @@ func (m *memStore) Delete(k string) error {
 
 	m.Lock()
 	defer m.Unlock()
-	delete(m.db, k)
+
+	if entry, ok := m.db[k]; ok {
+		delete(m.db, k)
+
+		m.broadcaster.Publish(spi.Event{Type: spi.EventDelete, Key: k, Tags: entry.tags})
+	}
 
 	return nil
 }
 
 // Batch performs multiple Put and/or Delete operations in order.
 // If any of the given keys are empty, then an error will be returned.
+// PutOptions.ExpiresAt is supported natively: expired data is hidden and purged lazily when it's next accessed.
 func (m *memStore) Batch(operations []spi.Operation) error {
 	if len(operations) == 0 {
 		return errors.New("batch requires at least one operation")
@@ -335,60 +330,126 @@ func (m *memStore) Batch(operations []spi.Operation) error {
 		}
 	}
 
+	var events []spi.Event
+
 	for _, operation := range operations {
 		if operation.Value == nil {
-			delete(m.db, operation.Key)
+			if entry, ok := m.db[operation.Key]; ok {
+				delete(m.db, operation.Key)
+
+				events = append(events, spi.Event{Type: spi.EventDelete, Key: operation.Key, Tags: entry.tags})
+			}
+
 			continue
 		}
 
-		m.db[operation.Key] = dbEntry{
+		events = append(events, spi.Event{
+			Type:  spi.EventPut,
+			Key:   operation.Key,
+			Value: operation.Value,
+			Tags:  operation.Tags,
+		})
+
+		entry := dbEntry{
 			value: operation.Value,
 			tags:  operation.Tags,
 		}
+
+		if operation.PutOptions != nil {
+			entry.expiresAt = operation.PutOptions.ExpiresAt
+		}
+
+		m.db[operation.Key] = entry
 	}
 
+	m.broadcaster.Publish(events...)
+
 	return nil
 }
 
-// Close closes this store object. All data within the store is deleted.
+// Close closes this store object, which also closes the events channels of its watchers.
+// All data within the store is deleted.
 func (m *memStore) Close() error {
 	m.close(m.name)
 
+	m.broadcaster.Close()
+
 	return nil
 }
 
+// Watch returns a channel of events for the data put in or deleted from this store after this call.
+// Data that expires isn't reported as deleted. See spi.Watchable for more information.
+func (m *memStore) Watch(ctx context.Context, options ...spi.WatchOption) (<-chan spi.Event, error) {
+	return m.broadcaster.Watch(ctx, options...)
+}
+
 // memStore doesn't queue values, so there's never anything to flush.
 func (m *memStore) Flush() error {
 	return nil
 }
 
-func (m *memStore) getMatchingKeysAndDBEntries(tagName, tagValue string) ([]string, []dbEntry) {
-	var matchAnyValue bool
-	if tagValue == "" {
-		matchAnyValue = true
+// getDBEntry returns the entry stored under key. An expired entry is purged and reported as missing.
+func (m *memStore) getDBEntry(key string) (dbEntry, bool) {
+	m.RLock()
+	entry, ok := m.db[key]
+	m.RUnlock()
+
+	if ok && entry.isExpired(time.Now()) {
+		m.purgeExpired(key)
+
+		return dbEntry{}, false
 	}
 
-	var keys []string
+	return entry, ok
+}
+
+func (m *memStore) getMatchingKeysAndDBEntries(groups query.Expression) ([]string, []dbEntry) {
+	var keys, expiredKeys []string
 
 	var dbEntries []dbEntry
 
+	now := time.Now()
+
 	m.RLock()
-	defer m.RUnlock()
 
 	for key, dbEntry := range m.db {
-		for _, tag := range dbEntry.tags {
-			if tag.Name == tagName && (matchAnyValue || tag.Value == tagValue) {
-				keys = append(keys, key)
-				dbEntries = append(dbEntries, dbEntry)
+		if dbEntry.isExpired(now) {
+			expiredKeys = append(expiredKeys, key)
 
-				break
-			}
+			continue
+		}
+
+		if groups.Matches(dbEntry.tags) {
+			keys = append(keys, key)
+			dbEntries = append(dbEntries, dbEntry)
 		}
 	}
 
+	m.RUnlock()
+
+	m.purgeExpired(expiredKeys...)
+
 	return keys, dbEntries
 }
 
+// purgeExpired deletes the given keys if they're still expired, since they may have been put again in the meantime.
+func (m *memStore) purgeExpired(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	m.Lock()
+	defer m.Unlock()
+
+	for _, key := range keys {
+		if entry, ok := m.db[key]; ok && entry.isExpired(now) {
+			delete(m.db, key)
+		}
+	}
+}
+
 // memIterator represents a snapshot of some set of entries in a memStore.
 type memIterator struct {
 	currentIndex   int
@@ -472,32 +533,3 @@ func checkForUnsupportedQueryOptions(options []spi.QueryOption) error {
 
 	return nil
 }
-
-// commonDBEntries returns the DB entries that appear in all the query results.
-func commonDBEntries(results map[string]*queryResult) ([]string, []dbEntry) {
-	numTags := len(results)
-	keyMap := make(map[string]int)
-	valueMap := make(map[string]dbEntry)
-
-	for _, result := range results {
-		for i, k := range result.keys {
-			keyMap[k]++
-
-			valueMap[k] = result.dbEntries[i]
-		}
-	}
-
-	var keys []string
-
-	var values []dbEntry
-
-	for k, n := range keyMap {
-		if n == numTags {
-			keys = append(keys, k)
-
-			values = append(values, valueMap[k])
-		}
-	}
-
-	return keys, values
-}
@@ -20,6 +20,9 @@ func TestCommon(t *testing.T) {
 	provider := mem.NewProvider()
 
 	storagetest.TestAll(t, provider, storagetest.SkipSortTests(false))
+	storagetest.TestStoreQueryWithComparisonOperators(t, provider)
+	storagetest.TestStoreExpiry(t, provider)
+	storagetest.TestStoreWatch(t, provider)
 }
 
 func TestQueryNotSupportedOptions(t *testing.T) {
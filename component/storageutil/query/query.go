@@ -0,0 +1,266 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package query parses the advanced query expressions of spi.Store.Query and matches them against tags. It's
+// shared by the store implementations which evaluate query expressions themselves.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// EqualOperator separates the tag name from the tag value in a basic criterion: TagName:TagValue.
+	EqualOperator = ":"
+	// LessThanOperator is the < comparison operator.
+	LessThanOperator = "<"
+	// LessThanOrEqualOperator is the <= comparison operator.
+	LessThanOrEqualOperator = "<="
+	// GreaterThanOperator is the > comparison operator.
+	GreaterThanOperator = ">"
+	// GreaterThanOrEqualOperator is the >= comparison operator.
+	GreaterThanOrEqualOperator = ">="
+
+	andOperator      = "&&"
+	orOperator       = "||"
+	negationOperator = "!"
+
+	fullDateLayout = "2006-01-02"
+
+	// encodedColon replaces the ':' characters of timestamps stored as tag values.
+	encodedColon = "%3A"
+
+	invalidComparisonValue = `"%s" is an invalid comparison value since it's neither a number nor an RFC3339 timestamp`
+)
+
+// ErrInvalidFormat is returned by Parse if a criterion of the expression has no tag name or if its tag value
+// contains a ':' character.
+var ErrInvalidFormat = errors.New("invalid expression format")
+
+// Expression is a parsed query expression: groups of criteria. Data satisfies the expression if it matches all
+// the criteria of at least one of the groups.
+type Expression [][]*Criterion
+
+// Criterion is a single criterion of a query expression: [!]TagName, [!]TagName:TagValue or
+// [!]TagName<operator>Value, where operator is one of <, <=, > or >=.
+type Criterion struct {
+	TagName string
+	// Operator is empty if the criterion only has a tag name.
+	Operator string
+	Value    string
+	// Operand is the parsed Value of a criterion with a comparison operator.
+	Operand OrderedValue
+	Negated bool
+}
+
+// OrderedValue is a tag value which can be compared with the <, <=, > and >= operators.
+type OrderedValue struct {
+	Number float64
+	Time   time.Time
+	IsTime bool
+}
+
+// Parse splits the query expression into groups of criteria. Criteria are separated by "&&" within a group and
+// groups are separated by "||".
+func Parse(expression string) (Expression, error) {
+	var groups Expression
+
+	for _, andExpression := range strings.Split(expression, orOperator) {
+		var group []*Criterion
+
+		for _, criterionExpression := range strings.Split(andExpression, andOperator) {
+			c, err := parseCriterion(criterionExpression)
+			if err != nil {
+				return nil, err
+			}
+
+			group = append(group, c)
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func parseCriterion(expression string) (*Criterion, error) {
+	c := &Criterion{}
+
+	if strings.HasPrefix(expression, negationOperator) {
+		c.Negated = true
+		expression = strings.TrimPrefix(expression, negationOperator)
+	}
+
+	c.TagName = expression
+
+	if i := strings.IndexAny(expression, EqualOperator+LessThanOperator+GreaterThanOperator); i != -1 {
+		c.TagName = expression[:i]
+		c.Operator = expression[i : i+1]
+
+		if c.Operator != EqualOperator && strings.HasPrefix(expression[i+1:], "=") {
+			c.Operator += "="
+		}
+
+		c.Value = expression[i+len(c.Operator):]
+	}
+
+	if c.TagName == "" {
+		return nil, ErrInvalidFormat
+	}
+
+	switch c.Operator {
+	case "", EqualOperator:
+		if strings.Contains(c.Value, EqualOperator) {
+			return nil, ErrInvalidFormat
+		}
+	default:
+		operand, ok := ParseOrderedValue(c.Value)
+		if !ok {
+			return nil, fmt.Errorf(invalidComparisonValue, c.Value)
+		}
+
+		c.Operand = operand
+	}
+
+	return c, nil
+}
+
+// Matches checks if data with the given tags satisfies the expression.
+func (e Expression) Matches(tags []spi.Tag) bool {
+	for _, group := range e {
+		matchesAll := true
+
+		for _, c := range group {
+			if !c.Matches(tags) {
+				matchesAll = false
+
+				break
+			}
+		}
+
+		if matchesAll {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RequiredTagNames returns, for each group of criteria, the name of a tag which data must have to match the group.
+// If data without tags can match one of the groups (i.e. it only has negated criteria), then false is returned.
+func (e Expression) RequiredTagNames() ([]string, bool) {
+	tagNames := make([]string, len(e))
+
+	for i, group := range e {
+		for _, c := range group {
+			if !c.Negated {
+				tagNames[i] = c.TagName
+
+				break
+			}
+		}
+
+		if tagNames[i] == "" {
+			return nil, false
+		}
+	}
+
+	return tagNames, true
+}
+
+// IsTagNameOnly checks if the criterion matches all data with the tag, regardless of the tag value.
+func (c *Criterion) IsTagNameOnly() bool {
+	return !c.Negated && c.Value == ""
+}
+
+// Matches checks if data with the given tags satisfies the criterion.
+func (c *Criterion) Matches(tags []spi.Tag) bool {
+	for _, tag := range tags {
+		if tag.Name == c.TagName && c.matchesValue(tag.Value) {
+			return !c.Negated
+		}
+	}
+
+	return c.Negated
+}
+
+func (c *Criterion) matchesValue(value string) bool {
+	switch c.Operator {
+	case "", EqualOperator:
+		return c.Value == "" || c.Value == value
+	}
+
+	tagValue, ok := ParseOrderedValue(value)
+	if !ok {
+		return false
+	}
+
+	result, ok := tagValue.Compare(c.Operand)
+	if !ok {
+		return false
+	}
+
+	switch c.Operator {
+	case LessThanOperator:
+		return result < 0
+	case LessThanOrEqualOperator:
+		return result <= 0
+	case GreaterThanOperator:
+		return result > 0
+	default:
+		return result >= 0
+	}
+}
+
+// FormatTime returns timestamp t as a tag value: the RFC3339 timestamp with its ':' characters encoded as "%3A"
+// (e.g. 2022-06-01T10%3A30%3A00Z), since tag values can't contain ':' characters.
+func FormatTime(t time.Time) string {
+	return strings.ReplaceAll(t.Format(time.RFC3339Nano), ":", encodedColon)
+}
+
+// ParseOrderedValue parses a decimal number, an RFC3339 timestamp or an RFC3339 full-date (e.g. 2022-06-01).
+// The ':' characters of timestamps may be encoded as "%3A", see FormatTime.
+func ParseOrderedValue(value string) (OrderedValue, bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err == nil && !math.IsNaN(number) && !math.IsInf(number, 0) {
+		return OrderedValue{Number: number}, true
+	}
+
+	value = strings.ReplaceAll(value, encodedColon, ":")
+
+	for _, layout := range []string{time.RFC3339Nano, fullDateLayout} {
+		t, err := time.Parse(layout, value)
+		if err == nil {
+			return OrderedValue{Time: t, IsTime: true}, true
+		}
+	}
+
+	return OrderedValue{}, false
+}
+
+// Compare returns -1, 0 or 1 if v is less than, equal to or greater than other. Numbers can't be compared to
+// timestamps.
+func (v OrderedValue) Compare(other OrderedValue) (int, bool) {
+	if v.IsTime != other.IsTime {
+		return 0, false
+	}
+
+	switch {
+	case v.IsTime && v.Time.Before(other.Time), !v.IsTime && v.Number < other.Number:
+		return -1, true
+	case v.IsTime && v.Time.After(other.Time), !v.IsTime && v.Number > other.Number:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
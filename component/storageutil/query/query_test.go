@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/query"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		expression, err := query.Parse("type:vc&&!revoked||amount>=10&&issued<2022-06-01T10:00:00Z")
+		require.NoError(t, err)
+		require.Len(t, expression, 2)
+
+		require.Equal(t, &query.Criterion{TagName: "type", Operator: query.EqualOperator, Value: "vc"},
+			expression[0][0])
+		require.Equal(t, &query.Criterion{TagName: "revoked", Negated: true}, expression[0][1])
+		require.Equal(t, &query.Criterion{
+			TagName:  "amount",
+			Operator: query.GreaterThanOrEqualOperator,
+			Value:    "10",
+			Operand:  query.OrderedValue{Number: 10},
+		}, expression[1][0])
+		require.Equal(t, query.LessThanOperator, expression[1][1].Operator)
+		require.True(t, expression[1][1].Operand.IsTime)
+	})
+
+	t.Run("Invalid expression format", func(t *testing.T) {
+		for _, expression := range []string{"", ":vc", "!", "type:vc&&", "type:a:b", "<10"} {
+			_, err := query.Parse(expression)
+			require.ErrorIs(t, err, query.ErrInvalidFormat, expression)
+		}
+	})
+
+	t.Run("Invalid comparison value", func(t *testing.T) {
+		_, err := query.Parse("amount>abc")
+		require.EqualError(t, err,
+			`"abc" is an invalid comparison value since it's neither a number nor an RFC3339 timestamp`)
+	})
+}
+
+func TestExpression_Matches(t *testing.T) {
+	tags := []spi.Tag{
+		{Name: "type", Value: "vc"}, {Name: "amount", Value: "2"}, {Name: "issued", Value: "2021-06-01"},
+		{Name: "updated", Value: "2021-06-01T10%3A30%3A00.5Z"},
+	}
+
+	tests := []struct {
+		expression string
+		matches    bool
+	}{
+		{expression: "type", matches: true},
+		{expression: "type:vc", matches: true},
+		{expression: "type:vp"},
+		{expression: "!type:vp", matches: true},
+		{expression: "amount>1&&amount<=2", matches: true},
+		{expression: "amount>2"},
+		{expression: "amount>2||issued>=2021-05-31T23:00:00Z", matches: true},
+		{expression: "issued>1"},
+		{expression: "type<1"},
+		{expression: "!revoked&&type:vc", matches: true},
+		{expression: "updated>2021-06-01T10:30:00Z", matches: true},
+		{expression: "updated<2021-06-01T10%3A30%3A00.6Z", matches: true},
+		{expression: "updated>=2021-06-01T12:30:00.5+02:00&&updated<=2021-06-01T10:30:00.5Z", matches: true},
+		{expression: "updated<2021-06-01"},
+	}
+
+	for _, test := range tests {
+		expression, err := query.Parse(test.expression)
+		require.NoError(t, err)
+		require.Equal(t, test.matches, expression.Matches(tags), test.expression)
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	timestamp := time.Date(2022, time.June, 1, 10, 30, 0, 500, time.FixedZone("", 2*60*60))
+
+	value := query.FormatTime(timestamp)
+	require.Equal(t, "2022-06-01T10%3A30%3A00.0000005+02%3A00", value)
+	require.NotContains(t, value, ":")
+
+	orderedValue, ok := query.ParseOrderedValue(value)
+	require.True(t, ok)
+	require.True(t, orderedValue.IsTime)
+	require.True(t, timestamp.Equal(orderedValue.Time))
+}
+
+func TestExpression_RequiredTagNames(t *testing.T) {
+	expression, err := query.Parse("!revoked&&type:vc||amount>1")
+	require.NoError(t, err)
+
+	tagNames, ok := expression.RequiredTagNames()
+	require.True(t, ok)
+	require.Equal(t, []string{"type", "amount"}, tagNames)
+
+	expression, err = query.Parse("type:vc||!revoked")
+	require.NoError(t, err)
+
+	_, ok = expression.RequiredTagNames()
+	require.False(t, ok)
+}
+
+func TestCriterion_IsTagNameOnly(t *testing.T) {
+	expression, err := query.Parse("type&&type:vc&&!type")
+	require.NoError(t, err)
+
+	require.True(t, expression[0][0].IsTagNameOnly())
+	require.False(t, expression[0][1].IsTagNameOnly())
+	require.False(t, expression[0][2].IsTagNameOnly())
+}
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package watchablestore
+
+import (
+	"context"
+	"sync"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// EventBufferSize is the size of the buffered channel returned to each watcher.
+const EventBufferSize = 100
+
+// Broadcaster streams store events to watchers. It can be used by storage implementations to implement
+// spi.Watchable. The zero value is ready to use.
+type Broadcaster struct {
+	watchers map[*watcher]struct{}
+	lock     sync.RWMutex
+}
+
+type watcher struct {
+	options spi.WatchOptions
+	events  chan spi.Event
+	stop    chan struct{}
+}
+
+// Watch registers a new watcher and returns its events channel. The channel is closed once ctx is done or Close is
+// called. See spi.Watchable for more information.
+func (b *Broadcaster) Watch(ctx context.Context, options ...spi.WatchOption) (<-chan spi.Event, error) {
+	w := &watcher{
+		events: make(chan spi.Event, EventBufferSize),
+		stop:   make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(&w.options)
+	}
+
+	b.lock.Lock()
+
+	if b.watchers == nil {
+		b.watchers = make(map[*watcher]struct{})
+	}
+
+	b.watchers[w] = struct{}{}
+
+	b.lock.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.remove(w)
+		case <-w.stop:
+		}
+	}()
+
+	return w.events, nil
+}
+
+// HasWatchers checks if there are any watchers. Storage implementations can use it to skip work that's only needed
+// to build events.
+func (b *Broadcaster) HasWatchers() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return len(b.watchers) > 0
+}
+
+// Publish sends the given events to all watchers whose options they satisfy. It never blocks: if the buffer of a
+// watcher is full, then the event is dropped for that watcher.
+func (b *Broadcaster) Publish(events ...spi.Event) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for w := range b.watchers {
+		for i := range events {
+			if !w.options.Matches(&events[i]) {
+				continue
+			}
+
+			select {
+			case w.events <- events[i]:
+			default:
+			}
+		}
+	}
+}
+
+// Close closes the events channels of all watchers.
+func (b *Broadcaster) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for w := range b.watchers {
+		close(w.events)
+		close(w.stop)
+	}
+
+	b.watchers = nil
+}
+
+func (b *Broadcaster) remove(w *watcher) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.watchers[w]; !ok {
+		return
+	}
+
+	delete(b.watchers, w)
+	close(w.events)
+}
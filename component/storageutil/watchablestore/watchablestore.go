@@ -0,0 +1,292 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package watchablestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Provider is a spi.Provider whose stores implement spi.Watchable.
+// It acts as a wrapper around another storage provider (typically, one that doesn't support watching natively).
+// Only changes made through the stores of this Provider instance are streamed.
+type Provider struct {
+	underlyingProvider spi.Provider
+	openStores         map[string]*store
+	lock               sync.RWMutex
+}
+
+type closer func(name string)
+
+// NewProvider instantiates a new watchable Provider.
+func NewProvider(underlyingProvider spi.Provider) *Provider {
+	return &Provider{
+		underlyingProvider: underlyingProvider,
+		openStores:         make(map[string]*store),
+	}
+}
+
+// OpenStore opens a store with the given name and returns a handle.
+// If the store has never been opened before, then it is created.
+// Store names are not case-sensitive. If name is blank, then an error will be returned by the underlying provider.
+func (p *Provider) OpenStore(name string) (spi.Store, error) {
+	name = strings.ToLower(name)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	openStore, ok := p.openStores[name]
+	if !ok {
+		underlyingStore, err := p.underlyingProvider.OpenStore(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open store in underlying provider: %w", err)
+		}
+
+		newStore := &store{
+			name:            name,
+			underlyingStore: underlyingStore,
+			close:           p.removeStore,
+		}
+		p.openStores[name] = newStore
+
+		return newStore, nil
+	}
+
+	return openStore, nil
+}
+
+// SetStoreConfig sets the configuration on a store.
+// The store must be created prior to calling this method.
+// If the store cannot be found, then an error wrapping ErrStoreNotFound will be returned by the underlying provider.
+// If name is blank, then an error will be returned by the underlying provider.
+func (p *Provider) SetStoreConfig(name string, config spi.StoreConfiguration) error {
+	err := p.underlyingProvider.SetStoreConfig(name, config)
+	if err != nil {
+		return fmt.Errorf("failed to set store config in underlying provider: %w", err)
+	}
+
+	return nil
+}
+
+// GetStoreConfig gets the current store configuration.
+// The store must be created prior to calling this method.
+// If the store cannot be found, then an error wrapping ErrStoreNotFound will be returned by the underlying provider.
+// If name is blank, then an error will be returned by the underlying provider.
+func (p *Provider) GetStoreConfig(name string) (spi.StoreConfiguration, error) {
+	config, err := p.underlyingProvider.GetStoreConfig(name)
+	if err != nil {
+		return spi.StoreConfiguration{},
+			fmt.Errorf("failed to get store config from underlying provider: %w", err)
+	}
+
+	return config, nil
+}
+
+// GetOpenStores returns all currently open stores.
+func (p *Provider) GetOpenStores() []spi.Store {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	openStores := make([]spi.Store, len(p.openStores))
+
+	var counter int
+
+	for _, openStore := range p.openStores {
+		openStores[counter] = openStore
+		counter++
+	}
+
+	return openStores
+}
+
+// Close closes all stores created under this store provider, which also closes the events channels of their
+// watchers. For persistent store implementations, this does not delete any data in the underlying databases.
+func (p *Provider) Close() error {
+	p.lock.Lock()
+
+	for _, openStore := range p.openStores {
+		openStore.broadcaster.Close()
+	}
+
+	p.openStores = make(map[string]*store)
+
+	p.lock.Unlock()
+
+	err := p.underlyingProvider.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close underlying provider: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Provider) removeStore(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.openStores, name)
+}
+
+type store struct {
+	name            string
+	underlyingStore spi.Store
+	broadcaster     Broadcaster
+	close           closer
+}
+
+func (s *store) Put(key string, value []byte, tags ...spi.Tag) error {
+	err := s.underlyingStore.Put(key, value, tags...)
+	if err != nil {
+		return fmt.Errorf("failed to put data in underlying store: %w", err)
+	}
+
+	s.broadcaster.Publish(spi.Event{Type: spi.EventPut, Key: key, Value: value, Tags: tags})
+
+	return nil
+}
+
+func (s *store) Get(key string) ([]byte, error) {
+	value, err := s.underlyingStore.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get value from underlying store: %w", err)
+	}
+
+	return value, nil
+}
+
+func (s *store) GetTags(key string) ([]spi.Tag, error) {
+	tags, err := s.underlyingStore.GetTags(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags from underlying store: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (s *store) GetBulk(keys ...string) ([][]byte, error) {
+	values, err := s.underlyingStore.GetBulk(keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get values from underlying store: %w", err)
+	}
+
+	return values, nil
+}
+
+func (s *store) Query(expression string, options ...spi.QueryOption) (spi.Iterator, error) {
+	iterator, err := s.underlyingStore.Query(expression, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query underlying store: %w", err)
+	}
+
+	return iterator, nil
+}
+
+func (s *store) Delete(key string) error {
+	event, found, err := s.deleteEvent(key)
+	if err != nil {
+		return err
+	}
+
+	err = s.underlyingStore.Delete(key)
+	if err != nil {
+		return fmt.Errorf("failed to delete data in underlying store: %w", err)
+	}
+
+	if found {
+		s.broadcaster.Publish(event)
+	}
+
+	return nil
+}
+
+func (s *store) Batch(operations []spi.Operation) error {
+	var events []spi.Event
+
+	for _, operation := range operations {
+		if operation.Value != nil {
+			events = append(events, spi.Event{
+				Type:  spi.EventPut,
+				Key:   operation.Key,
+				Value: operation.Value,
+				Tags:  operation.Tags,
+			})
+
+			continue
+		}
+
+		event, found, err := s.deleteEvent(operation.Key)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			events = append(events, event)
+		}
+	}
+
+	err := s.underlyingStore.Batch(operations)
+	if err != nil {
+		return fmt.Errorf("failed to perform operations in underlying store: %w", err)
+	}
+
+	s.broadcaster.Publish(events...)
+
+	return nil
+}
+
+func (s *store) Flush() error {
+	err := s.underlyingStore.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to flush underlying store: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes this store object, which also closes the events channels of its watchers.
+func (s *store) Close() error {
+	s.close(s.name)
+
+	s.broadcaster.Close()
+
+	err := s.underlyingStore.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close underlying store: %w", err)
+	}
+
+	return nil
+}
+
+// Watch returns a channel of events for the data put in or deleted from this store after this call.
+// See spi.Watchable for more information.
+func (s *store) Watch(ctx context.Context, options ...spi.WatchOption) (<-chan spi.Event, error) {
+	return s.broadcaster.Watch(ctx, options...)
+}
+
+// deleteEvent builds the event for deleting the data stored under key, which includes the tags of the data.
+// Nothing is looked up if there are no watchers.
+func (s *store) deleteEvent(key string) (spi.Event, bool, error) {
+	if key == "" || !s.broadcaster.HasWatchers() {
+		return spi.Event{}, false, nil
+	}
+
+	tags, err := s.underlyingStore.GetTags(key)
+	if err != nil {
+		if errors.Is(err, spi.ErrDataNotFound) {
+			return spi.Event{}, false, nil
+		}
+
+		return spi.Event{}, false, fmt.Errorf("failed to get tags of data to delete from underlying store: %w", err)
+	}
+
+	return spi.Event{Type: spi.EventDelete, Key: key, Tags: tags}, true, nil
+}
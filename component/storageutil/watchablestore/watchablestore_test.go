@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package watchablestore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/watchablestore"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+	commonstoragetest "github.com/hyperledger/aries-framework-go/test/component/storage"
+)
+
+func TestCommon(t *testing.T) {
+	provider := watchablestore.NewProvider(mem.NewProvider())
+
+	commonstoragetest.TestAll(t, provider, commonstoragetest.SkipSortTests(false))
+	commonstoragetest.TestStoreWatch(t, provider)
+}
+
+func TestProvider_OpenStore(t *testing.T) {
+	provider := watchablestore.NewProvider(&mock.Provider{ErrOpenStore: errors.New("open store failure")})
+
+	store, err := provider.OpenStore("StoreName")
+	require.EqualError(t, err, "failed to open store in underlying provider: open store failure")
+	require.Nil(t, store)
+}
+
+func TestProvider_Close(t *testing.T) {
+	provider := watchablestore.NewProvider(mem.NewProvider())
+
+	store, err := provider.OpenStore("StoreName")
+	require.NoError(t, err)
+
+	events, err := store.(spi.Watchable).Watch(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Close())
+
+	_, open := <-events
+	require.False(t, open)
+}
+
+func TestStore_Watch(t *testing.T) {
+	t.Run("Fail to get the tags of the data to delete", func(t *testing.T) {
+		provider := watchablestore.NewProvider(&mock.Provider{
+			OpenStoreReturn: &mock.Store{ErrGetTags: errors.New("get tags failure")},
+		})
+
+		store, err := provider.OpenStore("StoreName")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err = store.(spi.Watchable).Watch(ctx)
+		require.NoError(t, err)
+
+		err = store.Delete("key")
+		require.EqualError(t, err, "failed to get tags of data to delete from underlying store: get tags failure")
+
+		err = store.Batch([]spi.Operation{{Key: "key"}})
+		require.EqualError(t, err, "failed to get tags of data to delete from underlying store: get tags failure")
+	})
+	t.Run("No events if the operations fail", func(t *testing.T) {
+		provider := watchablestore.NewProvider(&mock.Provider{
+			OpenStoreReturn: &mock.Store{
+				ErrPut:   errors.New("put failure"),
+				ErrBatch: errors.New("batch failure"),
+			},
+		})
+
+		store, err := provider.OpenStore("StoreName")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := store.(spi.Watchable).Watch(ctx)
+		require.NoError(t, err)
+
+		err = store.Put("key", []byte("value"))
+		require.EqualError(t, err, "failed to put data in underlying store: put failure")
+
+		err = store.Batch([]spi.Operation{{Key: "key", Value: []byte("value")}})
+		require.EqualError(t, err, "failed to perform operations in underlying store: batch failure")
+
+		require.Empty(t, events)
+	})
+}
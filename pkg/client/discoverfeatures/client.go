@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// provider contains dependencies for the discover features protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Client enable access to discover features api.
+type Client struct {
+	service.Event
+	discoverFeaturesSvc protocolService
+}
+
+// protocolService defines discover features service.
+type protocolService interface {
+	// DIDComm service
+	service.DIDComm
+
+	// Query queries features of the other party of the given connection.
+	Query(connectionID string, queries []*discoverfeatures.FeatureQuery,
+		options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Disclosure, error)
+
+	// Features returns the features supported by the agent.
+	Features() []*discoverfeatures.Disclosure
+
+	// TheirFeatures returns the features disclosed by the other party of the given connection.
+	TheirFeatures(connectionID string) ([]*connection.Feature, error)
+}
+
+// WithTimeout option is for definition of timeout value waiting for the disclose message.
+func WithTimeout(t time.Duration) discoverfeatures.QueryOption {
+	return func(opts *discoverfeatures.QueryOptions) {
+		opts.Timeout = t
+	}
+}
+
+// WithComment option is for sending a comment along with the query (DIDComm V1 only).
+func WithComment(comment string) discoverfeatures.QueryOption {
+	return func(opts *discoverfeatures.QueryOptions) {
+		opts.Comment = comment
+	}
+}
+
+// New return new instance of discover features client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(discoverfeatures.DiscoverFeatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discover features service: %w", err)
+	}
+
+	discoverFeaturesSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{
+		Event:               discoverFeaturesSvc,
+		discoverFeaturesSvc: discoverFeaturesSvc,
+	}, nil
+}
+
+// Query sends feature queries to the other party of the given connection and waits for the disclosed features.
+// '*' can be used as a wildcard in the match pattern of the query.
+// Disclosed features are cached in the connection record and can be read later using TheirFeatures.
+func (c *Client) Query(connectionID string, queries []*discoverfeatures.FeatureQuery,
+	options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Disclosure, error) {
+	disclosures, err := c.discoverFeaturesSvc.Query(connectionID, queries, options...)
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - query: %w", err)
+	}
+
+	return disclosures, nil
+}
+
+// Features returns the features supported by this agent.
+func (c *Client) Features() []*discoverfeatures.Disclosure {
+	return c.discoverFeaturesSvc.Features()
+}
+
+// TheirFeatures returns the features disclosed so far by the other party of the given connection.
+func (c *Client) TheirFeatures(connectionID string) ([]*connection.Feature, error) {
+	features, err := c.discoverFeaturesSvc.TheirFeatures(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - their features: %w", err)
+	}
+
+	return features, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockdiscoverfeatures "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to discover features service failed")
+	})
+}
+
+func TestClient_Query(t *testing.T) {
+	t.Run("query - success", func(t *testing.T) {
+		queries := []*discoverfeatures.FeatureQuery{{FeatureType: discoverfeatures.FeatureTypeProtocol, Match: "*"}}
+		expected := []*discoverfeatures.Disclosure{{FeatureType: discoverfeatures.FeatureTypeProtocol, ID: "pid"}}
+
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryFunc: func(connectionID string, q []*discoverfeatures.FeatureQuery,
+					options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Disclosure, error) {
+					require.Equal(t, "connID", connectionID)
+					require.Equal(t, queries, q)
+
+					opts := &discoverfeatures.QueryOptions{}
+					for _, option := range options {
+						option(opts)
+					}
+
+					require.Equal(t, time.Second, opts.Timeout)
+					require.Equal(t, "hello", opts.Comment)
+
+					return expected, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		disclosures, err := client.Query("connID", queries, WithTimeout(time.Second), WithComment("hello"))
+		require.NoError(t, err)
+		require.Equal(t, expected, disclosures)
+	})
+
+	t.Run("query - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+				QueryErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Query("connID", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "discover features client - query: service error")
+	})
+}
+
+func TestClient_Features(t *testing.T) {
+	features := []*discoverfeatures.Disclosure{{FeatureType: discoverfeatures.FeatureTypeGoalCode, ID: "goal"}}
+	theirFeatures := []*connection.Feature{{FeatureType: discoverfeatures.FeatureTypeProtocol, ID: "pid"}}
+
+	client, err := New(&mockprovider.Provider{
+		ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			FeaturesValue:      features,
+			TheirFeaturesValue: theirFeatures,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, features, client.Features())
+
+	result, err := client.TheirFeatures("connID")
+	require.NoError(t, err)
+	require.Equal(t, theirFeatures, result)
+
+	client, err = New(&mockprovider.Provider{
+		ServiceValue: &mockdiscoverfeatures.MockDiscoverFeaturesSvc{
+			TheirFeaturesErr: errors.New("service error"),
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.TheirFeatures("connID")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "discover features client - their features: service error")
+}
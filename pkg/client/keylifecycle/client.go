@@ -0,0 +1,628 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keylifecycle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/client/keylifecycle")
+
+const (
+	// StoreName is the name of the store holding the audit trail of the key rotations and the rotation schedules.
+	StoreName = "keylifecycle"
+
+	rotationKeyPrefix = "rotation_"
+	rotationDIDTag    = "rotationDID"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+	jsonWebKey2020             = "JsonWebKey2020"
+)
+
+// Provider contains dependencies for the key lifecycle client and is typically created by using aries.Context().
+type Provider interface {
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	VDRegistry() vdrapi.Registry
+	StorageProvider() storage.Provider
+}
+
+// Rotation is the audit record of a key rotation.
+type Rotation struct {
+	ID string `json:"id"`
+	// DID whose document references the rotated key.
+	DID string `json:"did"`
+	// OldVerificationMethodID is the verification method of the rotated key. It stays in the DID document, so that
+	// past signatures can be verified, but it's removed from all verification relationships.
+	OldVerificationMethodID string `json:"oldVerificationMethodID"`
+	// NewVerificationMethodID is the verification method of the new key. It replaces the old one in all its
+	// verification relationships.
+	NewVerificationMethodID string `json:"newVerificationMethodID,omitempty"`
+	// OldKeyID is the KMS key ID of the rotated key. It's deleted once the DID document references the new key, if the
+	// KMS supports deleting keys.
+	OldKeyID string `json:"oldKeyID"`
+	// NewKeyID is the KMS key ID of the new key. It's deleted if the DID document couldn't be updated, the old key
+	// then stays in use.
+	NewKeyID string      `json:"newKeyID,omitempty"`
+	KeyType  kms.KeyType `json:"keyType"`
+	// ScheduleID is the ID of the schedule which triggered the rotation, if any.
+	ScheduleID string    `json:"scheduleID,omitempty"`
+	RotatedAt  time.Time `json:"rotatedAt"`
+	// Error is set if the DID document couldn't be updated with the new key, the key isn't rotated in that case.
+	Error string `json:"error,omitempty"`
+}
+
+// Client rotates the keys of verification methods and updates the DID documents referencing them.
+type Client struct {
+	kms     kms.KeyManager
+	crypto  crypto.Crypto
+	vdr     vdrapi.Registry
+	store   storage.Store
+	vdrOpts []vdrapi.DIDMethodOption
+
+	checkInterval time.Duration
+	stop          chan struct{}
+	lock          sync.Mutex
+	now           func() time.Time
+}
+
+// Option configures the key lifecycle client.
+type Option func(c *Client)
+
+// WithVDROptions sets the DID method options of all the DID document updates made by the client, e.g. the signer of
+// the updates of a method which can't be set by default.
+func WithVDROptions(opts ...vdrapi.DIDMethodOption) Option {
+	return func(c *Client) {
+		c.vdrOpts = append(c.vdrOpts, opts...)
+	}
+}
+
+// WithScheduler starts checking every interval for scheduled rotations that are due, see ScheduleRotation.
+// Scheduled rotations aren't checked unless this option is set or RotateDue is called.
+func WithScheduler(interval time.Duration) Option {
+	return func(c *Client) {
+		c.checkInterval = interval
+	}
+}
+
+// New returns a new key lifecycle client.
+func New(ctx Provider, opts ...Option) (*Client, error) {
+	store, err := ctx.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+
+	err = ctx.StorageProvider().SetStoreConfig(StoreName,
+		storage.StoreConfiguration{TagNames: []string{rotationDIDTag, scheduleTag}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
+	c := &Client{
+		kms:    ctx.KMS(),
+		crypto: ctx.Crypto(),
+		vdr:    ctx.VDRegistry(),
+		store:  store,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.checkInterval > 0 {
+		c.stop = make(chan struct{})
+
+		go c.runScheduler(c.stop)
+	}
+
+	return c, nil
+}
+
+// Close stops the scheduler of the client, if any.
+func (c *Client) Close() {
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+}
+
+// RotateOption configures a key rotation.
+type RotateOption func(opts *rotateOpts)
+
+type rotateOpts struct {
+	keyID      string
+	keyType    kms.KeyType
+	vdrOpts    []vdrapi.DIDMethodOption
+	scheduleID string
+}
+
+// WithKeyID sets the KMS key ID of the key to rotate. By default, it's computed from the public key of the
+// verification method, as done by the KMS when the key is created.
+func WithKeyID(keyID string) RotateOption {
+	return func(opts *rotateOpts) {
+		opts.keyID = keyID
+	}
+}
+
+// WithKeyType sets the KMS key type of the new key. By default, it's the type the rotated key was created with.
+func WithKeyType(keyType kms.KeyType) RotateOption {
+	return func(opts *rotateOpts) {
+		opts.keyType = keyType
+	}
+}
+
+// WithUpdateOptions sets the DID method options of the DID document update, they take precedence over the
+// options of the client.
+func WithUpdateOptions(opts ...vdrapi.DIDMethodOption) RotateOption {
+	return func(o *rotateOpts) {
+		o.vdrOpts = append(o.vdrOpts, opts...)
+	}
+}
+
+// Rotate rotates the key of verification method vmID of DID didID and updates the DID document through the VDR
+// registry:
+//   - a new key of the same type is created in the KMS.
+//   - a new verification method for the new key replaces the old one in all its verification relationships.
+//   - the old verification method stays in the document, so that past signatures can still be verified.
+//   - once the document is updated, the old key is deleted from the KMS (if it supports kms.KeyDeleter).
+//
+// If the old key is authorized to modify the document (authentication or capabilityInvocation), the update of a
+// did:peer document is signed with it, other methods or keys must get their signer through WithUpdateOptions or
+// WithVDROptions.
+// The rotation is recorded in the audit trail, see History. If the document update fails, the new key is deleted
+// and the old key stays in use, the returned rotation holds the error and the rotation can be retried.
+func (c *Client) Rotate(didID, vmID string, opts ...RotateOption) (*Rotation, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.rotate(didID, vmID, opts...)
+}
+
+func (c *Client) rotate(didID, vmID string, opts ...RotateOption) (*Rotation, error) { // nolint:funlen
+	o := &rotateOpts{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	docResolution, err := c.vdr.Resolve(didID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve DID %s: %w", didID, err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	vm, found := lookupVerificationMethod(doc, vmID)
+	if !found {
+		return nil, fmt.Errorf("verification method %s not found in DID document %s", vmID, didID)
+	}
+
+	keyID, keyType, err := c.kmsKey(vm, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// the old key signs the document update, it's only deleted once the document references the new key.
+	oldKH, err := c.kms.Get(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key %s: %w", keyID, err)
+	}
+
+	newKeyID, _, err := c.kms.Create(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new key for key %s: %w", keyID, err)
+	}
+
+	rotation := &Rotation{
+		ID:                      uuid.New().String(),
+		DID:                     doc.ID,
+		OldVerificationMethodID: vm.ID,
+		OldKeyID:                keyID,
+		NewKeyID:                newKeyID,
+		KeyType:                 keyType,
+		ScheduleID:              o.scheduleID,
+		RotatedAt:               c.now().UTC(),
+	}
+
+	newVM, err := c.newVerificationMethod(doc, vm, newKeyID)
+	if err == nil {
+		rotation.NewVerificationMethodID = newVM.ID
+
+		vdrOpts := append(updateSignerOptions(doc, vm, &khSigner{crypto: c.crypto, kh: oldKH}), c.vdrOpts...)
+
+		err = c.vdr.Update(rotatedDoc(doc, vm, newVM, rotation.RotatedAt), append(vdrOpts, o.vdrOpts...)...)
+		if err != nil {
+			err = fmt.Errorf("failed to update DID document %s: %w", didID, err)
+		}
+	}
+
+	if err != nil {
+		rotation.Error = err.Error()
+
+		// the document still references the old key, which stays in use.
+		c.deleteKey(newKeyID)
+	} else {
+		c.deleteKey(keyID)
+	}
+
+	if errSave := c.saveRotation(rotation); errSave != nil {
+		return nil, fmt.Errorf("rotation of key %s not recorded: %w", keyID, errSave)
+	}
+
+	if err != nil {
+		return rotation, fmt.Errorf("key %s not rotated: %w", keyID, err)
+	}
+
+	return rotation, nil
+}
+
+// deleteKey deletes key keyID from the KMS, if it supports it. Failures are only logged, the DID document is already
+// settled.
+func (c *Client) deleteKey(keyID string) {
+	deleter, ok := c.kms.(kms.KeyDeleter)
+	if !ok {
+		logger.Warnf("KMS doesn't support deleting keys, key %s is kept", keyID)
+
+		return
+	}
+
+	if err := deleter.Delete(keyID); err != nil {
+		logger.Errorf("failed to delete key %s: %s", keyID, err.Error())
+	}
+}
+
+// History returns the audit trail of the key rotations of the verification methods of DID didID, ordered by
+// rotation time.
+func (c *Client) History(didID string) ([]*Rotation, error) {
+	iter, err := c.store.Query(fmt.Sprintf("%s:%s", rotationDIDTag, didTagValue(didID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rotations: %w", err)
+	}
+
+	defer func() {
+		if errClose := iter.Close(); errClose != nil {
+			logger.Errorf("failed to close iterator: %s", errClose.Error())
+		}
+	}()
+
+	var rotations []*Rotation
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next rotation: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rotation: %w", err)
+		}
+
+		rotation := &Rotation{}
+
+		err = json.Unmarshal(value, rotation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rotation: %w", err)
+		}
+
+		rotations = append(rotations, rotation)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next rotation: %w", err)
+		}
+	}
+
+	sort.SliceStable(rotations, func(i, j int) bool {
+		return rotations[i].RotatedAt.Before(rotations[j].RotatedAt)
+	})
+
+	return rotations, nil
+}
+
+func (c *Client) saveRotation(rotation *Rotation) error {
+	value, err := json.Marshal(rotation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rotation: %w", err)
+	}
+
+	err = c.store.Put(rotationKeyPrefix+rotation.ID, value,
+		storage.Tag{Name: rotationDIDTag, Value: didTagValue(rotation.DID)})
+	if err != nil {
+		return fmt.Errorf("failed to save rotation: %w", err)
+	}
+
+	return nil
+}
+
+// kmsKey returns the KMS key ID and type of the key of vm.
+func (c *Client) kmsKey(vm *did.VerificationMethod, o *rotateOpts) (string, kms.KeyType, error) {
+	pubKey, keyType, err := publicKeyBytes(vm)
+	if err != nil {
+		return "", "", err
+	}
+
+	keyID := o.keyID
+	if keyID == "" {
+		keyID, err = jwkkid.CreateKID(pubKey, keyType)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get KMS key ID of verification method %s: %w", vm.ID, err)
+		}
+	}
+
+	if o.keyType != "" {
+		return keyID, o.keyType, nil
+	}
+
+	// the KMS knows the exact type the key was created with (e.g. DER or IEEE-P1363 signatures).
+	if info := c.keyInfo(keyID); info != nil {
+		return keyID, info.KeyType, nil
+	}
+
+	return keyID, keyType, nil
+}
+
+// keyInfo returns the information of key keyID, or nil if the KMS doesn't have it or can't list its keys.
+func (c *Client) keyInfo(keyID string) *kms.KeyInfo {
+	lister, ok := c.kms.(kms.KeyLister)
+	if !ok {
+		return nil
+	}
+
+	info, err := lister.GetKeyInfo(keyID)
+	if err != nil {
+		return nil
+	}
+
+	return info
+}
+
+func (c *Client) newVerificationMethod(doc *did.Doc, vm *did.VerificationMethod,
+	keyID string) (*did.VerificationMethod, error) {
+	pubKey, keyType, err := c.kms.ExportPubKeyBytes(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export public key %s: %w", keyID, err)
+	}
+
+	id := doc.ID + "#" + keyID
+
+	controller := vm.Controller
+	if controller == "" {
+		controller = doc.ID
+	}
+
+	switch vm.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		return did.NewVerificationMethodFromBytes(id, vm.Type, controller, pubKey), nil
+	case x25519KeyAgreementKey2019:
+		x, err := x25519PublicKey(pubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return did.NewVerificationMethodFromBytes(id, vm.Type, controller, x), nil
+	case jsonWebKey2020:
+		if keyType == kms.X25519ECDHKWType {
+			pubKey, err = x25519PublicKey(pubKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		j, err := jwksupport.PubKeyBytesToJWK(pubKey, keyType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert public key %s to JWK: %w", keyID, err)
+		}
+
+		return did.NewVerificationMethodFromJWK(id, vm.Type, controller, j)
+	default:
+		return nil, fmt.Errorf("verification method type %s not supported", vm.Type)
+	}
+}
+
+// lookupVerificationMethod finds the verification method vmID of doc, either in its verification methods or
+// embedded in a verification relationship. vmID can be relative to the DID.
+func lookupVerificationMethod(doc *did.Doc, vmID string) (*did.VerificationMethod, bool) {
+	for i := range doc.VerificationMethod {
+		if sameID(doc.ID, doc.VerificationMethod[i].ID, vmID) {
+			return &doc.VerificationMethod[i], true
+		}
+	}
+
+	for _, verifications := range relationships(doc) {
+		for i := range *verifications {
+			if vm := &(*verifications)[i].VerificationMethod; sameID(doc.ID, vm.ID, vmID) {
+				return vm, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// rotatedDoc returns a copy of doc where newVM replaces oldVM in all verification relationships, oldVM is kept in
+// the verification methods only.
+func rotatedDoc(doc *did.Doc, oldVM, newVM *did.VerificationMethod, updated time.Time) *did.Doc {
+	rotated := *doc
+	rotated.Updated = &updated
+	rotated.VerificationMethod = nil
+
+	oldVMListed := false
+
+	for i := range doc.VerificationMethod {
+		if sameID(doc.ID, doc.VerificationMethod[i].ID, oldVM.ID) {
+			oldVMListed = true
+		}
+
+		rotated.VerificationMethod = append(rotated.VerificationMethod, doc.VerificationMethod[i])
+	}
+
+	if !oldVMListed {
+		rotated.VerificationMethod = append(rotated.VerificationMethod, *oldVM)
+	}
+
+	newVMListed := false
+
+	for _, verifications := range relationships(&rotated) {
+		var replaced []did.Verification
+
+		for _, v := range *verifications {
+			if sameID(doc.ID, v.VerificationMethod.ID, oldVM.ID) {
+				v.VerificationMethod = *newVM
+				newVMListed = newVMListed || !v.Embedded
+			}
+
+			replaced = append(replaced, v)
+		}
+
+		*verifications = replaced
+	}
+
+	if newVMListed || oldVMListed {
+		rotated.VerificationMethod = append(rotated.VerificationMethod, *newVM)
+	}
+
+	return &rotated
+}
+
+func relationships(doc *did.Doc) []*[]did.Verification {
+	return []*[]did.Verification{
+		&doc.Authentication, &doc.AssertionMethod, &doc.CapabilityDelegation, &doc.CapabilityInvocation,
+		&doc.KeyAgreement,
+	}
+}
+
+// updateSignerOptions returns the did:peer update options signing with kh if vm is authorized to modify doc.
+func updateSignerOptions(doc *did.Doc, vm *did.VerificationMethod, signer peer.Signer) []vdrapi.DIDMethodOption {
+	for _, verifications := range [][]did.Verification{doc.Authentication, doc.CapabilityInvocation} {
+		for i := range verifications {
+			if sameID(doc.ID, verifications[i].VerificationMethod.ID, vm.ID) {
+				return []vdrapi.DIDMethodOption{
+					vdrapi.WithOption(peer.UpdateSignerOption, signer),
+					vdrapi.WithOption(peer.UpdateKeyIDOption, vm.ID),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// publicKeyBytes returns the public key of vm marshalled as expected by the KMS for its key type.
+func publicKeyBytes(vm *did.VerificationMethod) ([]byte, kms.KeyType, error) {
+	switch vm.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		return vm.Value, kms.ED25519Type, nil
+	case x25519KeyAgreementKey2019:
+		pubKey, err := marshalX25519PublicKey(vm.Value)
+
+		return pubKey, kms.X25519ECDHKWType, err
+	case jsonWebKey2020:
+		j := vm.JSONWebKey()
+		if j == nil {
+			return nil, "", fmt.Errorf("verification method %s has no JWK", vm.ID)
+		}
+
+		keyType, err := j.KeyType()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get key type of verification method %s: %w", vm.ID, err)
+		}
+
+		if keyType == kms.X25519ECDHKWType {
+			x, ok := j.Key.([]byte)
+			if !ok {
+				return nil, "", fmt.Errorf("invalid X25519 key of verification method %s", vm.ID)
+			}
+
+			pubKey, err := marshalX25519PublicKey(x)
+
+			return pubKey, keyType, err
+		}
+
+		pubKey, err := j.PublicKeyBytes()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get public key of verification method %s: %w", vm.ID, err)
+		}
+
+		return pubKey, keyType, nil
+	default:
+		return nil, "", fmt.Errorf("verification method type %s not supported", vm.Type)
+	}
+}
+
+func marshalX25519PublicKey(x []byte) ([]byte, error) {
+	pubKey, err := json.Marshal(&crypto.PublicKey{X: x, Curve: "X25519", Type: "OKP"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal X25519 public key: %w", err)
+	}
+
+	return pubKey, nil
+}
+
+func x25519PublicKey(marshalledKey []byte) ([]byte, error) {
+	pubKey := &crypto.PublicKey{}
+
+	err := json.Unmarshal(marshalledKey, pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal X25519 public key: %w", err)
+	}
+
+	if len(pubKey.X) == 0 {
+		return nil, errors.New("empty X25519 public key")
+	}
+
+	return pubKey.X, nil
+}
+
+func sameID(didID, id1, id2 string) bool {
+	return absoluteID(didID, id1) == absoluteID(didID, id2)
+}
+
+func absoluteID(didID, id string) string {
+	if strings.HasPrefix(id, "#") {
+		return didID + id
+	}
+
+	return id
+}
+
+// didTagValue encodes didID for use as tag value, tag values can't contain colons.
+func didTagValue(didID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(didID))
+}
+
+// khSigner signs with a KMS key handle.
+type khSigner struct {
+	crypto crypto.Crypto
+	kh     interface{}
+}
+
+func (s *khSigner) Sign(data []byte) ([]byte, error) {
+	return s.crypto.Sign(data, s.kh)
+}
@@ -0,0 +1,431 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keylifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, err := New(newProvider(t))
+		require.NoError(t, err)
+		require.NotNil(t, c)
+
+		c.Close()
+	})
+
+	t.Run("error - open store", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{
+			StorageProviderValue: &storage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")},
+		})
+		require.EqualError(t, err, "failed to open store: open error")
+	})
+}
+
+func TestClient_Rotate(t *testing.T) {
+	t.Run("success - authentication key", func(t *testing.T) {
+		p := newProvider(t)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		doc := createPeerDID(t, p)
+		vmID := doc.Authentication[0].VerificationMethod.ID
+
+		oldKeyID := createKID(t, doc.Authentication[0].VerificationMethod.Value)
+
+		oldKH, err := p.KMSValue.Get(oldKeyID)
+		require.NoError(t, err)
+
+		msg := []byte("signed before the rotation")
+
+		sig, err := p.CryptoValue.Sign(msg, oldKH)
+		require.NoError(t, err)
+
+		rotation, err := c.Rotate(doc.ID, vmID)
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, rotation.DID)
+		require.Equal(t, vmID, rotation.OldVerificationMethodID)
+		require.Equal(t, doc.ID+"#"+rotation.NewKeyID, rotation.NewVerificationMethodID)
+		require.Equal(t, oldKeyID, rotation.OldKeyID)
+		require.Equal(t, kms.ED25519Type, rotation.KeyType)
+		require.Empty(t, rotation.Error)
+
+		docResolution, err := p.VDRegistryValue.Resolve(doc.ID)
+		require.NoError(t, err)
+
+		rotated := docResolution.DIDDocument
+		require.Len(t, rotated.Authentication, 1)
+		require.Equal(t, rotation.NewVerificationMethodID, rotated.Authentication[0].VerificationMethod.ID)
+		require.Len(t, rotated.AssertionMethod, 1)
+		require.Equal(t, rotation.NewVerificationMethodID, rotated.AssertionMethod[0].VerificationMethod.ID)
+
+		// the old verification method can still verify past signatures.
+		oldVM, found := lookupVerificationMethod(rotated, vmID)
+		require.True(t, found)
+		require.Equal(t, doc.Authentication[0].VerificationMethod.Value, oldVM.Value)
+
+		_, err = p.KMSValue.Get(oldKeyID)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		oldPubKH, err := p.KMSValue.PubKeyBytesToHandle(oldVM.Value, kms.ED25519Type)
+		require.NoError(t, err)
+		require.NoError(t, p.CryptoValue.Verify(sig, msg, oldPubKH))
+
+		newKH, err := p.KMSValue.Get(rotation.NewKeyID)
+		require.NoError(t, err)
+
+		// new signatures are made with the new key.
+		newVM, found := lookupVerificationMethod(rotated, rotation.NewVerificationMethodID)
+		require.True(t, found)
+
+		sig, err = p.CryptoValue.Sign(msg, newKH)
+		require.NoError(t, err)
+
+		newPubKH, err := p.KMSValue.PubKeyBytesToHandle(newVM.Value, kms.ED25519Type)
+		require.NoError(t, err)
+		require.NoError(t, p.CryptoValue.Verify(sig, msg, newPubKH))
+
+		// the new key is authorized to sign the next rotation.
+		next, err := c.Rotate(doc.ID, rotation.NewVerificationMethodID)
+		require.NoError(t, err)
+
+		history, err := c.History(doc.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, rotation.ID, history[0].ID)
+		require.Equal(t, next.ID, history[1].ID)
+		require.Equal(t, rotation.NewKeyID, history[1].OldKeyID)
+
+		history, err = c.History("did:example:other")
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
+
+	t.Run("success - key agreement key with update options", func(t *testing.T) {
+		p := newProvider(t)
+
+		doc := createPeerDID(t, p)
+		require.Len(t, doc.KeyAgreement, 1)
+
+		authVM := doc.Authentication[0].VerificationMethod
+
+		authKH, err := p.KMSValue.Get(createKID(t, authVM.Value))
+		require.NoError(t, err)
+
+		c, err := New(p, WithVDROptions(
+			vdrapi.WithOption(peer.UpdateSignerOption, &khSigner{crypto: p.CryptoValue, kh: authKH}),
+			vdrapi.WithOption(peer.UpdateKeyIDOption, authVM.ID)))
+		require.NoError(t, err)
+
+		rotation, err := c.Rotate(doc.ID, doc.KeyAgreement[0].VerificationMethod.ID)
+		require.NoError(t, err)
+		require.Equal(t, kms.X25519ECDHKWType, rotation.KeyType)
+
+		docResolution, err := p.VDRegistryValue.Resolve(doc.ID)
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.KeyAgreement, 1)
+		require.Equal(t, rotation.NewVerificationMethodID,
+			docResolution.DIDDocument.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, authVM.ID, docResolution.DIDDocument.Authentication[0].VerificationMethod.ID)
+	})
+
+	t.Run("error - document update failure is recorded", func(t *testing.T) {
+		p := newProvider(t)
+
+		doc := createPeerDID(t, p)
+		vmID := doc.KeyAgreement[0].VerificationMethod.ID
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		// the key agreement key can't sign the update.
+		rotation, err := c.Rotate(doc.ID, vmID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to update DID document")
+		require.Contains(t, err.Error(), "updateSigner opt is mandatory")
+		require.NotEmpty(t, rotation.NewKeyID)
+		require.Contains(t, rotation.Error, "updateSigner opt is mandatory")
+
+		history, err := c.History(doc.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, rotation.Error, history[0].Error)
+
+		// the document is unchanged and the new key is discarded.
+		docResolution, err := p.VDRegistryValue.Resolve(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, vmID, docResolution.DIDDocument.KeyAgreement[0].VerificationMethod.ID)
+
+		_, err = p.KMSValue.Get(rotation.NewKeyID)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		_, err = p.KMSValue.Get(rotation.OldKeyID)
+		require.NoError(t, err)
+	})
+
+	t.Run("error - failed document update keeps the old key", func(t *testing.T) {
+		p := newProvider(t)
+
+		doc := createPeerDID(t, p)
+		vm := doc.Authentication[0].VerificationMethod
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		c.vdr = &failingVDR{Registry: c.vdr, failures: 1}
+
+		rotation, err := c.Rotate(doc.ID, vm.ID)
+		require.EqualError(t, err, "key "+rotation.OldKeyID+" not rotated: failed to update DID document "+
+			doc.ID+": update error")
+
+		docResolution, err := p.VDRegistryValue.Resolve(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, vm.ID, docResolution.DIDDocument.Authentication[0].VerificationMethod.ID)
+
+		_, err = p.KMSValue.Get(rotation.NewKeyID)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		// the old key still signs for the verification method of the document.
+		oldKH, err := p.KMSValue.Get(rotation.OldKeyID)
+		require.NoError(t, err)
+
+		msg := []byte("signed after the failed rotation")
+
+		sig, err := p.CryptoValue.Sign(msg, oldKH)
+		require.NoError(t, err)
+
+		pubKH, err := p.KMSValue.PubKeyBytesToHandle(
+			docResolution.DIDDocument.Authentication[0].VerificationMethod.Value, kms.ED25519Type)
+		require.NoError(t, err)
+		require.NoError(t, p.CryptoValue.Verify(sig, msg, pubKH))
+	})
+
+	t.Run("error - DID or verification method not found", func(t *testing.T) {
+		p := newProvider(t)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		_, err = c.Rotate("did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa", "#key-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to resolve DID")
+
+		doc := createPeerDID(t, p)
+
+		_, err = c.Rotate(doc.ID, "#unknown")
+		require.EqualError(t, err, "verification method #unknown not found in DID document "+doc.ID)
+	})
+
+	t.Run("error - KMS failures", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+		vmID := doc.Authentication[0].VerificationMethod.ID
+
+		c, err := New(p, func(c *Client) {
+			c.kms = &mockkms.KeyManager{GetKeyErr: errors.New("get error")}
+		})
+		require.NoError(t, err)
+
+		_, err = c.Rotate(doc.ID, vmID, WithKeyID("kid"))
+		require.EqualError(t, err, "failed to get key kid: get error")
+
+		c.kms = &mockkms.KeyManager{CreateKeyErr: errors.New("create error")}
+
+		_, err = c.Rotate(doc.ID, vmID, WithKeyID("kid"), WithKeyType(kms.ED25519Type))
+		require.EqualError(t, err, "failed to create new key for key kid: create error")
+
+		c.kms = &mockkms.KeyManager{
+			CreateKeyID:          "newKID",
+			ExportPubKeyBytesErr: errors.New("export error"),
+		}
+
+		rotation, err := c.Rotate(doc.ID, vmID, WithKeyID("kid"))
+		require.EqualError(t, err, "key kid not rotated: failed to export public key newKID: export error")
+		require.Empty(t, rotation.NewVerificationMethodID)
+	})
+
+	t.Run("error - audit trail failure", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		c.store = &storage.MockStore{Store: map[string]storage.DBEntry{}, ErrPut: errors.New("put error")}
+
+		_, err = c.Rotate(doc.ID, doc.Authentication[0].VerificationMethod.ID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to save rotation: put error")
+
+		c.store = &storage.MockStore{ErrQuery: errors.New("query error")}
+
+		_, err = c.History(doc.ID)
+		require.EqualError(t, err, "failed to query rotations: query error")
+	})
+}
+
+func TestRotatedDoc(t *testing.T) {
+	oldVM := did.NewVerificationMethodFromBytes("did:example:123#key-1", ed25519VerificationKey2018,
+		"did:example:123", []byte("old"))
+	newVM := did.NewVerificationMethodFromBytes("did:example:123#key-2", ed25519VerificationKey2018,
+		"did:example:123", []byte("new"))
+
+	t.Run("embedded verification method", func(t *testing.T) {
+		doc := &did.Doc{
+			ID:             "did:example:123",
+			Authentication: []did.Verification{*did.NewEmbeddedVerification(oldVM, did.Authentication)},
+		}
+
+		rotated := rotatedDoc(doc, oldVM, newVM, time.Now())
+		require.Equal(t, []did.VerificationMethod{*oldVM}, rotated.VerificationMethod)
+		require.Equal(t, []did.Verification{*did.NewEmbeddedVerification(newVM, did.Authentication)},
+			rotated.Authentication)
+
+		// the original document isn't modified.
+		require.Empty(t, doc.VerificationMethod)
+		require.Equal(t, oldVM.ID, doc.Authentication[0].VerificationMethod.ID)
+	})
+
+	t.Run("referenced verification method", func(t *testing.T) {
+		doc := &did.Doc{
+			ID:                 "did:example:123",
+			VerificationMethod: []did.VerificationMethod{*oldVM},
+			Authentication:     []did.Verification{*did.NewReferencedVerification(oldVM, did.Authentication)},
+		}
+
+		rotated := rotatedDoc(doc, oldVM, newVM, time.Now())
+		require.Equal(t, []did.VerificationMethod{*oldVM, *newVM}, rotated.VerificationMethod)
+		require.Equal(t, []did.Verification{*did.NewReferencedVerification(newVM, did.Authentication)},
+			rotated.Authentication)
+		require.NotNil(t, rotated.Updated)
+	})
+}
+
+func TestPublicKeyBytes(t *testing.T) {
+	t.Run("X25519 key agreement key", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key-1", x25519KeyAgreementKey2019, "", []byte("x25519"))
+
+		pubKey, keyType, err := publicKeyBytes(vm)
+		require.NoError(t, err)
+		require.Equal(t, kms.X25519ECDHKWType, keyType)
+
+		x, err := x25519PublicKey(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, []byte("x25519"), x)
+
+		_, err = x25519PublicKey([]byte("{}"))
+		require.EqualError(t, err, "empty X25519 public key")
+
+		_, err = x25519PublicKey([]byte("not json"))
+		require.Error(t, err)
+	})
+
+	t.Run("error - unsupported verification method", func(t *testing.T) {
+		_, _, err := publicKeyBytes(&did.VerificationMethod{ID: "#key-1", Type: "Bls12381G2Key2020"})
+		require.EqualError(t, err, "verification method type Bls12381G2Key2020 not supported")
+
+		_, _, err = publicKeyBytes(&did.VerificationMethod{ID: "#key-1", Type: jsonWebKey2020})
+		require.EqualError(t, err, "verification method #key-1 has no JWK")
+	})
+}
+
+func newProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	kmsProvider, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	localKMS, err := localkms.New("local-lock://custom/master/key/", kmsProvider)
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	peerVDR, err := peer.New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	return &mockprovider.Provider{
+		StorageProviderValue: storage.NewMockStoreProvider(),
+		KMSValue:             localKMS,
+		CryptoValue:          tinkCrypto,
+		VDRegistryValue:      vdrpkg.New(vdrpkg.WithVDR(peerVDR)),
+	}
+}
+
+// createPeerDID creates a did:peer:1 with an Ed25519 authentication key and an X25519 key agreement key of the KMS.
+func createPeerDID(t *testing.T, p *mockprovider.Provider) *did.Doc {
+	t.Helper()
+
+	_, edPubKey, err := p.KMSValue.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, xPubKey, err := p.KMSValue.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+	require.NoError(t, err)
+
+	x := &crypto.PublicKey{}
+	require.NoError(t, json.Unmarshal(xPubKey, x))
+
+	kaVM := did.NewVerificationMethodFromBytes("#key-2", x25519KeyAgreementKey2019, "", x.X)
+
+	docResolution, err := p.VDRegistryValue.Create(peer.DIDMethod, &did.Doc{
+		VerificationMethod: []did.VerificationMethod{
+			*did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, "", edPubKey),
+		},
+		KeyAgreement: []did.Verification{*did.NewEmbeddedVerification(kaVM, did.KeyAgreement)},
+	})
+	require.NoError(t, err)
+
+	docResolution, err = p.VDRegistryValue.Resolve(docResolution.DIDDocument.ID)
+	require.NoError(t, err)
+
+	return docResolution.DIDDocument
+}
+
+func createKID(t *testing.T, pubKey []byte) string {
+	t.Helper()
+
+	kid, err := localkms.CreateKID(pubKey, kms.ED25519Type)
+	require.NoError(t, err)
+
+	return kid
+}
+
+// failingVDR fails the first document updates.
+type failingVDR struct {
+	vdrapi.Registry
+	failures int
+}
+
+func (v *failingVDR) Update(doc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+	if v.failures > 0 {
+		v.failures--
+
+		return errors.New("update error")
+	}
+
+	return v.Registry.Update(doc, opts...)
+}
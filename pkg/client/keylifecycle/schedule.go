@@ -0,0 +1,226 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keylifecycle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	scheduleKeyPrefix = "schedule_"
+	scheduleTag       = "schedule"
+)
+
+// Schedule is a periodic rotation of the key of a verification method.
+type Schedule struct {
+	ID  string `json:"id"`
+	DID string `json:"did"`
+	// VerificationMethodID is the verification method of the current key, it's updated by each successful rotation.
+	VerificationMethodID string        `json:"verificationMethodID"`
+	Period               time.Duration `json:"period"`
+	NextRotation         time.Time     `json:"nextRotation"`
+}
+
+// ScheduleRotation schedules the rotation of the key of verification method vmID of DID didID every period.
+// The first rotation is due a period after the key creation, if the KMS knows it, or else a period from now.
+// Due rotations are made by RotateDue, which is called periodically if the client is created WithScheduler.
+func (c *Client) ScheduleRotation(didID, vmID string, period time.Duration) (*Schedule, error) {
+	if period <= 0 {
+		return nil, errors.New("rotation period must be positive")
+	}
+
+	docResolution, err := c.vdr.Resolve(didID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve DID %s: %w", didID, err)
+	}
+
+	vm, found := lookupVerificationMethod(docResolution.DIDDocument, vmID)
+	if !found {
+		return nil, fmt.Errorf("verification method %s not found in DID document %s", vmID, didID)
+	}
+
+	keyID, _, err := c.kmsKey(vm, &rotateOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	since := c.now()
+
+	if info := c.keyInfo(keyID); info != nil && !info.CreatedAt.IsZero() {
+		since = info.CreatedAt
+	}
+
+	schedule := &Schedule{
+		ID:                   uuid.New().String(),
+		DID:                  docResolution.DIDDocument.ID,
+		VerificationMethodID: vm.ID,
+		Period:               period,
+		NextRotation:         since.Add(period).UTC(),
+	}
+
+	err = c.saveSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// CancelSchedule cancels the scheduled rotation with the given ID.
+func (c *Client) CancelSchedule(id string) error {
+	err := c.store.Delete(scheduleKeyPrefix + id)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+
+	return nil
+}
+
+// Schedules returns the scheduled rotations.
+func (c *Client) Schedules() ([]*Schedule, error) {
+	iter, err := c.store.Query(scheduleTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schedules: %w", err)
+	}
+
+	defer func() {
+		if errClose := iter.Close(); errClose != nil {
+			logger.Errorf("failed to close iterator: %s", errClose.Error())
+		}
+	}()
+
+	var schedules []*Schedule
+
+	more, err := iter.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next schedule: %w", err)
+	}
+
+	for more {
+		value, err := iter.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schedule: %w", err)
+		}
+
+		schedule := &Schedule{}
+
+		err = json.Unmarshal(value, schedule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal schedule: %w", err)
+		}
+
+		schedules = append(schedules, schedule)
+
+		more, err = iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next schedule: %w", err)
+		}
+	}
+
+	return schedules, nil
+}
+
+// RotateDue makes the scheduled rotations that are due and returns them. A failed rotation doesn't prevent the
+// other ones, its schedule is unchanged so that it's retried on the next call: the old key is still in use when the
+// DID document couldn't be updated (see Rotate).
+func (c *Client) RotateDue() ([]*Rotation, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	schedules, err := c.Schedules()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		rotations []*Rotation
+		errs      []string
+	)
+
+	now := c.now()
+
+	for _, schedule := range schedules {
+		if schedule.NextRotation.After(now) {
+			continue
+		}
+
+		rotation, err := c.rotate(schedule.DID, schedule.VerificationMethodID, func(opts *rotateOpts) {
+			opts.scheduleID = schedule.ID
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("schedule %s: %s", schedule.ID, err.Error()))
+		}
+
+		if rotation == nil {
+			continue
+		}
+
+		rotations = append(rotations, rotation)
+
+		// the document still references the old verification method if the update failed.
+		if rotation.Error != "" {
+			continue
+		}
+
+		schedule.VerificationMethodID = rotation.NewVerificationMethodID
+		schedule.NextRotation = rotation.RotatedAt.Add(schedule.Period)
+
+		if err = c.saveSchedule(schedule); err != nil {
+			errs = append(errs, fmt.Sprintf("schedule %s: %s", schedule.ID, err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return rotations, fmt.Errorf("failed scheduled rotations: %s", strings.Join(errs, "; "))
+	}
+
+	return rotations, nil
+}
+
+func (c *Client) saveSchedule(schedule *Schedule) error {
+	value, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+
+	err = c.store.Put(scheduleKeyPrefix+schedule.ID, value, storage.Tag{Name: scheduleTag})
+	if err != nil {
+		return fmt.Errorf("failed to save schedule: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) runScheduler(stop <-chan struct{}) {
+	ticker := time.NewTicker(c.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			rotations, err := c.RotateDue()
+			if err != nil {
+				logger.Errorf("scheduled key rotations: %s", err.Error())
+			}
+
+			for _, rotation := range rotations {
+				logger.Infof("scheduled rotation of key %s of %s to %s", rotation.OldKeyID, rotation.DID,
+					rotation.NewKeyID)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keylifecycle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const rotationPeriod = 90 * 24 * time.Hour
+
+func TestClient_ScheduleRotation(t *testing.T) {
+	t.Run("success - due rotations are made", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+		vmID := doc.Authentication[0].VerificationMethod.ID
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		keyInfo, err := p.KMSValue.(kms.KeyLister).GetKeyInfo(createKID(t, doc.Authentication[0].VerificationMethod.Value))
+		require.NoError(t, err)
+
+		schedule, err := c.ScheduleRotation(doc.ID, vmID, rotationPeriod)
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, schedule.DID)
+		require.Equal(t, vmID, schedule.VerificationMethodID)
+		require.Equal(t, keyInfo.CreatedAt.Add(rotationPeriod), schedule.NextRotation)
+
+		// not due yet.
+		rotations, err := c.RotateDue()
+		require.NoError(t, err)
+		require.Empty(t, rotations)
+
+		due := schedule.NextRotation.Add(time.Minute)
+		c.now = func() time.Time { return due }
+
+		rotations, err = c.RotateDue()
+		require.NoError(t, err)
+		require.Len(t, rotations, 1)
+		require.Equal(t, schedule.ID, rotations[0].ScheduleID)
+		require.Equal(t, vmID, rotations[0].OldVerificationMethodID)
+
+		schedules, err := c.Schedules()
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		require.Equal(t, rotations[0].NewVerificationMethodID, schedules[0].VerificationMethodID)
+		require.Equal(t, due.Add(rotationPeriod).UTC(), schedules[0].NextRotation)
+
+		// the next rotation is a period after the last one.
+		rotations, err = c.RotateDue()
+		require.NoError(t, err)
+		require.Empty(t, rotations)
+
+		c.now = func() time.Time { return due.Add(rotationPeriod) }
+
+		rotations, err = c.RotateDue()
+		require.NoError(t, err)
+		require.Len(t, rotations, 1)
+
+		history, err := c.History(doc.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		require.NoError(t, c.CancelSchedule(schedule.ID))
+
+		schedules, err = c.Schedules()
+		require.NoError(t, err)
+		require.Empty(t, schedules)
+	})
+
+	t.Run("success - scheduler", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+
+		c, err := New(p, WithScheduler(time.Millisecond))
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		_, err = c.ScheduleRotation(doc.ID, doc.Authentication[0].VerificationMethod.ID, time.Nanosecond)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			history, e := c.History(doc.ID)
+
+			return e == nil && len(history) > 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("error - failed rotations are reported", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		kaSchedule, err := c.ScheduleRotation(doc.ID, doc.KeyAgreement[0].VerificationMethod.ID, rotationPeriod)
+		require.NoError(t, err)
+
+		authSchedule, err := c.ScheduleRotation(doc.ID, doc.Authentication[0].VerificationMethod.ID, rotationPeriod)
+		require.NoError(t, err)
+
+		c.now = func() time.Time { return time.Now().Add(2 * rotationPeriod) }
+
+		// the key agreement key can't sign the update, the rotation of the authentication key isn't prevented.
+		rotations, err := c.RotateDue()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "schedule "+kaSchedule.ID)
+		require.NotContains(t, err.Error(), "schedule "+authSchedule.ID)
+		require.Len(t, rotations, 2)
+
+		schedules, err := c.Schedules()
+		require.NoError(t, err)
+		require.Len(t, schedules, 2)
+
+		for _, schedule := range schedules {
+			for _, rotation := range rotations {
+				if rotation.ScheduleID != schedule.ID {
+					continue
+				}
+
+				// the failed update leaves the old verification method in the document, the schedule keeps it.
+				if schedule.ID == kaSchedule.ID {
+					require.NotEmpty(t, rotation.Error)
+					require.NotEmpty(t, rotation.NewVerificationMethodID)
+					require.Equal(t, kaSchedule.VerificationMethodID, schedule.VerificationMethodID)
+					require.Equal(t, kaSchedule.NextRotation, schedule.NextRotation)
+				} else {
+					require.Empty(t, rotation.Error)
+					require.Equal(t, rotation.NewVerificationMethodID, schedule.VerificationMethodID)
+				}
+			}
+		}
+	})
+
+	t.Run("success - failed rotation is retried", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+		vmID := doc.Authentication[0].VerificationMethod.ID
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		c.vdr = &failingVDR{Registry: c.vdr, failures: 1}
+
+		schedule, err := c.ScheduleRotation(doc.ID, vmID, rotationPeriod)
+		require.NoError(t, err)
+
+		c.now = func() time.Time { return schedule.NextRotation.Add(time.Minute) }
+
+		rotations, err := c.RotateDue()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "update error")
+		require.Len(t, rotations, 1)
+		require.NotEmpty(t, rotations[0].Error)
+
+		_, err = p.KMSValue.Get(rotations[0].OldKeyID)
+		require.NoError(t, err)
+
+		schedules, err := c.Schedules()
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		require.Equal(t, vmID, schedules[0].VerificationMethodID)
+		require.Equal(t, schedule.NextRotation, schedules[0].NextRotation)
+
+		// the rotation is still due and succeeds with the old key.
+		rotations, err = c.RotateDue()
+		require.NoError(t, err)
+		require.Len(t, rotations, 1)
+		require.Empty(t, rotations[0].Error)
+		require.Equal(t, vmID, rotations[0].OldVerificationMethodID)
+
+		schedules, err = c.Schedules()
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		require.Equal(t, rotations[0].NewVerificationMethodID, schedules[0].VerificationMethodID)
+
+		docResolution, err := p.VDRegistryValue.Resolve(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, rotations[0].NewVerificationMethodID,
+			docResolution.DIDDocument.Authentication[0].VerificationMethod.ID)
+
+		history, err := c.History(doc.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+	})
+
+	t.Run("error - invalid schedule", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		_, err = c.ScheduleRotation(doc.ID, doc.Authentication[0].VerificationMethod.ID, 0)
+		require.EqualError(t, err, "rotation period must be positive")
+
+		_, err = c.ScheduleRotation(doc.ID, "#unknown", rotationPeriod)
+		require.EqualError(t, err, "verification method #unknown not found in DID document "+doc.ID)
+
+		_, err = c.ScheduleRotation("did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa", "#key-1",
+			rotationPeriod)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to resolve DID")
+	})
+
+	t.Run("error - store failures", func(t *testing.T) {
+		p := newProvider(t)
+		doc := createPeerDID(t, p)
+
+		c, err := New(p)
+		require.NoError(t, err)
+
+		c.store = &storage.MockStore{Store: map[string]storage.DBEntry{}, ErrPut: errors.New("put error")}
+
+		_, err = c.ScheduleRotation(doc.ID, doc.Authentication[0].VerificationMethod.ID, rotationPeriod)
+		require.EqualError(t, err, "failed to save schedule: put error")
+
+		c.store = &storage.MockStore{ErrQuery: errors.New("query error"), ErrDelete: errors.New("delete error")}
+
+		_, err = c.RotateDue()
+		require.EqualError(t, err, "failed to query schedules: query error")
+
+		err = c.CancelSchedule("id")
+		require.EqualError(t, err, "failed to delete schedule: delete error")
+	})
+}
@@ -43,6 +43,12 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config(connID string) (*mediator.Config, error)
+
+	// RemoveKey removes agents recKey from the router.
+	RemoveKey(connID, recKey string) error
+
+	// QueryRecipients returns the recipients registered with the router.
+	QueryRecipients(connID string) ([]string, error)
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -109,3 +115,24 @@ func (c *Client) GetConfig(connID string) (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// RemoveKey asks the router to stop forwarding messages for recipient key (or DID) recKey, eg. once the agent
+// doesn't use it anymore. Removing a key the router doesn't know about is not an error.
+func (c *Client) RemoveKey(connID, recKey string) error {
+	if err := c.routeSvc.RemoveKey(connID, recKey); err != nil {
+		return fmt.Errorf("remove key from the router: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRecipients returns the recipient DIDs (or keys) the router forwards messages for on behalf of the agent.
+// Requires a DIDComm V2 router connection (coordinate mediation 2.0).
+func (c *Client) QueryRecipients(connID string) ([]string, error) {
+	recipients, err := c.routeSvc.QueryRecipients(connID)
+	if err != nil {
+		return nil, fmt.Errorf("query router recipients: %w", err)
+	}
+
+	return recipients, nil
+}
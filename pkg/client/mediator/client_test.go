@@ -170,3 +170,52 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestClient_RemoveKey(t *testing.T) {
+	t.Run("removes key", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{},
+		})
+		require.NoError(t, err)
+		require.NoError(t, c.RemoveKey("conn", "did:example:alice"))
+	})
+	t.Run("wraps remove key error", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				RemoveKeyErr: expected,
+			},
+		})
+		require.NoError(t, err)
+		err = c.RemoveKey("conn", "did:example:alice")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
+
+func TestClient_QueryRecipients(t *testing.T) {
+	t.Run("returns recipients", func(t *testing.T) {
+		recipients := []string{"did:example:alice", "did:example:bob"}
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				Recipients: recipients,
+			},
+		})
+		require.NoError(t, err)
+		result, err := c.QueryRecipients("conn")
+		require.NoError(t, err)
+		require.Equal(t, recipients, result)
+	})
+	t.Run("wraps query error", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				QueryRecipientsErr: expected,
+			},
+		})
+		require.NoError(t, err)
+		_, err = c.QueryRecipients("conn")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
@@ -9,6 +9,7 @@ package messagepickup
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
@@ -33,6 +34,34 @@ type protocolService interface {
 	BatchPickup(connectionID string, size int) (int, error)
 
 	Noop(connectionID string) error
+
+	PickupStatus(connectionID string, options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error)
+
+	DeliveryRequest(connectionID string, options ...messagepickup.PickupOption) (int, error)
+
+	LiveDeliveryChange(connectionID string, liveDelivery bool,
+		options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error)
+}
+
+// WithTimeout option is for definition of timeout value waiting for the mediator response.
+func WithTimeout(t time.Duration) messagepickup.PickupOption {
+	return func(opts *messagepickup.PickupOptions) {
+		opts.Timeout = t
+	}
+}
+
+// WithLimit option is for definition of max number of messages to be delivered by a delivery request.
+func WithLimit(limit int) messagepickup.PickupOption {
+	return func(opts *messagepickup.PickupOptions) {
+		opts.Limit = limit
+	}
+}
+
+// WithRecipientKey option filters messages by recipient key (Pickup 2.0) or recipient DID (Pickup 3.0).
+func WithRecipientKey(recipientKey string) messagepickup.PickupOption {
+	return func(opts *messagepickup.PickupOptions) {
+		opts.RecipientKey = recipientKey
+	}
 }
 
 // New return new instance of messagepickup client.
@@ -78,3 +107,37 @@ func (r *Client) BatchPickup(connectionID string, size int) (int, error) {
 func (r *Client) Noop(connectionID string) error {
 	return r.messagepickupSvc.Noop(connectionID)
 }
+
+// PickupStatus requests status of the messages waiting at the mediator (Pickup 2.0/3.0).
+func (r *Client) PickupStatus(connectionID string,
+	options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error) {
+	sts, err := r.messagepickupSvc.PickupStatus(connectionID, options...)
+	if err != nil {
+		return nil, fmt.Errorf("message pickup client - pickup status: %w", err)
+	}
+
+	return sts, nil
+}
+
+// DeliveryRequest requests delivery of the messages waiting at the mediator (Pickup 2.0/3.0).
+// Delivered messages are processed and acknowledged, returns the number of processed messages.
+func (r *Client) DeliveryRequest(connectionID string, options ...messagepickup.PickupOption) (int, error) {
+	count, err := r.messagepickupSvc.DeliveryRequest(connectionID, options...)
+	if err != nil {
+		return -1, fmt.Errorf("message pickup client - delivery request: %w", err)
+	}
+
+	return count, nil
+}
+
+// LiveDeliveryChange enables or disables live delivery of messages by the mediator (Pickup 2.0/3.0).
+// Live delivery requires a duplex transport (eg. websocket) with the mediator.
+func (r *Client) LiveDeliveryChange(connectionID string, liveDelivery bool,
+	options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error) {
+	sts, err := r.messagepickupSvc.LiveDeliveryChange(connectionID, liveDelivery, options...)
+	if err != nil {
+		return nil, fmt.Errorf("message pickup client - live delivery change: %w", err)
+	}
+
+	return sts, nil
+}
@@ -10,9 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
 	mockpickup "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/messagepickup"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 )
@@ -113,3 +115,106 @@ func TestNoop(t *testing.T) {
 		require.Contains(t, err.Error(), "service error")
 	})
 }
+
+func TestPickupStatus(t *testing.T) {
+	t.Run("pickup status - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				PickupStatusFunc: func(connectionID string,
+					options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error) {
+					opts := &messagepickup.PickupOptions{}
+					for _, option := range options {
+						option(opts)
+					}
+
+					require.Equal(t, "connID", connectionID)
+					require.Equal(t, "key", opts.RecipientKey)
+					require.Equal(t, time.Second, opts.Timeout)
+
+					return &messagepickup.PickupStatus{MessageCount: 2}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		sts, err := client.PickupStatus("connID", WithRecipientKey("key"), WithTimeout(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, 2, sts.MessageCount)
+	})
+
+	t.Run("pickup status - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				PickupStatusErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.PickupStatus("connID")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message pickup client - pickup status: service error")
+	})
+}
+
+func TestDeliveryRequest(t *testing.T) {
+	t.Run("delivery request - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				DeliveryRequestFunc: func(connectionID string, options ...messagepickup.PickupOption) (int, error) {
+					opts := &messagepickup.PickupOptions{}
+					for _, option := range options {
+						option(opts)
+					}
+
+					require.Equal(t, 5, opts.Limit)
+
+					return 3, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		count, err := client.DeliveryRequest("connID", WithLimit(5))
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("delivery request - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				DeliveryRequestErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.DeliveryRequest("connID")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message pickup client - delivery request: service error")
+	})
+}
+
+func TestLiveDeliveryChange(t *testing.T) {
+	t.Run("live delivery change - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{},
+		})
+		require.NoError(t, err)
+
+		sts, err := client.LiveDeliveryChange("connID", true)
+		require.NoError(t, err)
+		require.True(t, sts.LiveDelivery)
+	})
+
+	t.Run("live delivery change - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				LiveDeliveryChangeErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.LiveDeliveryChange("connID", true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message pickup client - live delivery change: service error")
+	})
+}
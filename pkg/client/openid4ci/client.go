@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const defaultTimeout = time.Minute
+
+// HTTPClient represents an HTTP client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client is the wallet side of the OpenID4VCI pre-authorized code flow.
+type Client struct {
+	httpClient HTTPClient
+}
+
+// ClientOption configures the OpenID4VCI client.
+type ClientOption func(opts *Client)
+
+// WithHTTPClient option is for custom http client.
+func WithHTTPClient(httpClient HTTPClient) ClientOption {
+	return func(opts *Client) {
+		opts.httpClient = httpClient
+	}
+}
+
+// NewClient returns new OpenID4VCI client.
+func NewClient(opts ...ClientOption) *Client {
+	client := &Client{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// RequestOptions configures the credential request.
+type RequestOptions func(opts *requestOpts)
+
+type requestOpts struct {
+	userPIN     string
+	proofSigner jose.Signer
+	proofKeyID  string
+}
+
+// WithPIN sets the user PIN required by the credential offer.
+func WithPIN(pin string) RequestOptions {
+	return func(opts *requestOpts) {
+		opts.userPIN = pin
+	}
+}
+
+// WithProofSigner sets the signer of the proof of possession of the holder key, the issued credential
+// is bound to the DID of the given key ID (DID URL).
+func WithProofSigner(signer jose.Signer, keyID string) RequestOptions {
+	return func(opts *requestOpts) {
+		opts.proofSigner = signer
+		opts.proofKeyID = keyID
+	}
+}
+
+// RequestCredential redeems the pre-authorized code of the credential offer at the token endpoint
+// and requests the first offered credential from the credential endpoint of the credential issuer.
+func (c *Client) RequestCredential(offer *CredentialOffer, options ...RequestOptions) (*CredentialResponse, error) {
+	opts := &requestOpts{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	if offer.Grants == nil || offer.Grants.PreAuthorizedCode == nil {
+		return nil, errors.New("credential offer has no pre-authorized code grant")
+	}
+
+	if len(offer.Credentials) == 0 {
+		return nil, errors.New("credential offer has no credentials")
+	}
+
+	if offer.Grants.PreAuthorizedCode.UserPINRequired && opts.userPIN == "" {
+		return nil, errors.New("user PIN is required by the credential offer")
+	}
+
+	issuerURL := strings.TrimSuffix(offer.CredentialIssuer, "/")
+
+	issuerMetadata := &IssuerMetadata{}
+
+	err := c.doRequest(http.MethodGet, issuerURL+IssuerMetadataPath, "", nil, "", issuerMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("get credential issuer metadata: %w", err)
+	}
+
+	authServerURL := issuerURL
+	if issuerMetadata.AuthorizationServer != "" {
+		authServerURL = strings.TrimSuffix(issuerMetadata.AuthorizationServer, "/")
+	}
+
+	authServerMetadata := &AuthorizationServerMetadata{}
+
+	err = c.doRequest(http.MethodGet, authServerURL+AuthorizationServerMetadataPath, "", nil, "", authServerMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("get authorization server metadata: %w", err)
+	}
+
+	tokenResponse, err := c.requestToken(authServerMetadata.TokenEndpoint, offer.Grants.PreAuthorizedCode, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialRequest := &CredentialRequest{
+		Format: offer.Credentials[0].Format,
+		Types:  offer.Credentials[0].Types,
+	}
+
+	if opts.proofSigner != nil {
+		proofJWT, e := createProofJWT(issuerMetadata.CredentialIssuer, tokenResponse.CNonce, opts)
+		if e != nil {
+			return nil, e
+		}
+
+		credentialRequest.Proof = &Proof{ProofType: JWTProofType, JWT: proofJWT}
+	}
+
+	requestBytes, err := json.Marshal(credentialRequest)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential request: %w", err)
+	}
+
+	credentialResponse := &CredentialResponse{}
+
+	err = c.doRequest(http.MethodPost, issuerMetadata.CredentialEndpoint, "application/json", requestBytes,
+		tokenResponse.AccessToken, credentialResponse)
+	if err != nil {
+		return nil, fmt.Errorf("request credential: %w", err)
+	}
+
+	return credentialResponse, nil
+}
+
+func (c *Client) requestToken(endpoint string, grant *PreAuthorizedCodeGrant,
+	opts *requestOpts) (*TokenResponse, error) {
+	form := url.Values{
+		"grant_type":          {PreAuthorizedCodeGrantType},
+		"pre-authorized_code": {grant.PreAuthorizedCode},
+	}
+
+	if opts.userPIN != "" {
+		form.Set("user_pin", opts.userPIN)
+	}
+
+	tokenResponse := &TokenResponse{}
+
+	err := c.doRequest(http.MethodPost, endpoint, "application/x-www-form-urlencoded", []byte(form.Encode()),
+		"", tokenResponse)
+	if err != nil {
+		return nil, fmt.Errorf("request access token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+func createProofJWT(audience, nonce string, opts *requestOpts) (string, error) {
+	headers := jose.Headers{
+		jose.HeaderType:  ProofJWTType,
+		jose.HeaderKeyID: opts.proofKeyID,
+	}
+
+	proofJWT, err := jwt.NewSigned(&proofClaims{
+		Issuer:   strings.Split(opts.proofKeyID, "#")[0],
+		Audience: audience,
+		IssuedAt: time.Now().Unix(),
+		Nonce:    nonce,
+	}, headers, opts.proofSigner)
+	if err != nil {
+		return "", fmt.Errorf("create proof JWT: %w", err)
+	}
+
+	return proofJWT.Serialize(false)
+}
+
+func (c *Client) doRequest(method, endpoint, contentType string, body []byte, accessToken string,
+	response interface{}) error {
+	req, err := http.NewRequestWithContext(context.Background(), method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("new HTTP request: %w", err)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("httpClient.Do: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	responseBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := &Error{}
+		if json.Unmarshal(responseBytes, errResponse) == nil && errResponse.Code != "" {
+			return errResponse
+		}
+
+		return fmt.Errorf("endpoint %s returned status '%d' and message '%s'",
+			endpoint, resp.StatusCode, responseBytes)
+	}
+
+	err = json.Unmarshal(responseBytes, response)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Warnf("failed to close response body: %v", e)
+	}
+}
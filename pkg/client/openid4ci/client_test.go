@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestClient_RequestCredential(t *testing.T) {
+	server, issuer := newTestServer(t)
+	defer server.Close()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderDID, holderKeyID := fingerprint.CreateDIDKey(pubKey)
+
+	client := NewClient(WithHTTPClient(server.Client()))
+
+	t.Run("test request credential bound to holder DID", func(t *testing.T) {
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential), WithUserPIN("1234"))
+		require.NoError(t, err)
+
+		response, err := client.RequestCredential(offer, WithPIN("1234"),
+			WithProofSigner(jwt.NewEd25519Signer(privKey), holderKeyID))
+		require.NoError(t, err)
+		require.Equal(t, JWTVCJSONFormat, response.Format)
+
+		vc, err := verifiable.ParseCredential(response.Credential,
+			verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(issuer.vdr).PublicKeyFetcher()),
+			verifiable.WithJSONLDDocumentLoader(issuer.documentLoader))
+		require.NoError(t, err)
+		require.Equal(t, holderDID, vc.Subject.([]verifiable.Subject)[0].ID)
+	})
+
+	t.Run("test invalid proof", func(t *testing.T) {
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+
+		_, err = client.RequestCredential(offer, WithProofSigner(jwt.NewEd25519Signer(otherKey), holderKeyID))
+		require.Error(t, err)
+
+		var e *Error
+
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, InvalidOrMissingProofError, e.Code)
+		require.Contains(t, e.Description, "parse proof JWT")
+	})
+
+	t.Run("test invalid offer", func(t *testing.T) {
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential), WithUserPIN("1234"))
+		require.NoError(t, err)
+
+		_, err = client.RequestCredential(offer)
+		require.EqualError(t, err, "user PIN is required by the credential offer")
+
+		_, err = client.RequestCredential(offer, WithPIN("0000"))
+		require.EqualError(t, err, "request access token: invalid_grant: user PIN is invalid")
+
+		_, err = client.RequestCredential(&CredentialOffer{CredentialIssuer: server.URL})
+		require.EqualError(t, err, "credential offer has no pre-authorized code grant")
+
+		_, err = client.RequestCredential(&CredentialOffer{
+			CredentialIssuer: server.URL,
+			Grants:           offer.Grants,
+		})
+		require.EqualError(t, err, "credential offer has no credentials")
+
+		offer.CredentialIssuer = server.URL + "/unknown"
+
+		_, err = client.RequestCredential(offer, WithPIN("1234"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get credential issuer metadata")
+	})
+}
+
+// newTestServer returns an in-process OpenID4VCI credential issuer server.
+func newTestServer(t *testing.T) (*httptest.Server, *Issuer) {
+	t.Helper()
+
+	var issuer *Issuer
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(IssuerMetadataPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeResponse(rw, issuer.Metadata(), nil)
+	})
+
+	mux.HandleFunc(AuthorizationServerMetadataPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeResponse(rw, issuer.AuthorizationServerMetadata(), nil)
+	})
+
+	mux.HandleFunc(TokenEndpointPath, func(rw http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+
+		response, err := issuer.Token(&TokenRequest{
+			GrantType:         req.PostForm.Get("grant_type"),
+			PreAuthorizedCode: req.PostForm.Get("pre-authorized_code"),
+			UserPIN:           req.PostForm.Get("user_pin"),
+		})
+		writeResponse(rw, response, err)
+	})
+
+	mux.HandleFunc(CredentialEndpointPath, func(rw http.ResponseWriter, req *http.Request) {
+		request := &CredentialRequest{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(request))
+
+		response, err := issuer.Credential(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "), request)
+		writeResponse(rw, response, err)
+	})
+
+	server := httptest.NewServer(mux)
+
+	p := newMockProvider(t)
+
+	kid, pubKey, err := p.KMSValue.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, verificationMethod := fingerprint.CreateDIDKey(pubKey)
+
+	issuer, err = NewIssuer(p, &IssuerConfig{
+		URL:                server.URL,
+		VerificationMethod: verificationMethod,
+		KeyID:              kid,
+	})
+	require.NoError(t, err)
+
+	return server, issuer
+}
+
+func writeResponse(rw http.ResponseWriter, response interface{}, err error) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+
+		response = err
+	}
+
+	_ = json.NewEncoder(rw).Encode(response) // nolint:errchkjson
+}
@@ -0,0 +1,620 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/client/openid4ci")
+
+const (
+	// StoreName is the name of the store of credential offers and access tokens.
+	StoreName = "openid4ci"
+
+	// Ed25519Signature2018 ed25519 signature suite.
+	Ed25519Signature2018 = "Ed25519Signature2018"
+	// JSONWebSignature2020 json web signature suite.
+	JSONWebSignature2020 = "JsonWebSignature2020"
+
+	offerKeyPrefix = "offer_"
+	tokenKeyPrefix = "token_"
+
+	defaultOfferExpiry     = 15 * time.Minute
+	defaultTokenExpiry     = 5 * time.Minute
+	defaultUserPINAttempts = 3
+
+	vmSectionCount = 2
+)
+
+// provider contains dependencies for the OpenID4VCI issuer and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdr.Registry
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	JSONLDDocumentLoader() ld.DocumentLoader
+}
+
+// IssuerConfig contains properties of the OpenID4VCI credential issuer.
+type IssuerConfig struct {
+	// URL is the credential issuer identifier. Token, credential and metadata endpoints are relative to it.
+	URL string `json:"url"`
+	// VerificationMethod is the DID URL of the key signing issued credentials.
+	VerificationMethod string `json:"verificationMethod"`
+	// KeyID of the signing key in the KMS.
+	// Optional, by default fragment of the verification method is used.
+	KeyID string `json:"keyID,omitempty"`
+	// SignatureType is the linked data signature suite of ldp_vc credentials.
+	// Optional, by default Ed25519Signature2018 is used for Ed25519 keys and JsonWebSignature2020 for other keys.
+	SignatureType string `json:"signatureType,omitempty"`
+	// OfferExpiry is lifetime of the pre-authorized code of a credential offer.
+	// Optional, by default 15 minutes.
+	OfferExpiry time.Duration `json:"offerExpiry,omitempty"`
+	// TokenExpiry is lifetime of the access token.
+	// Optional, by default 5 minutes.
+	TokenExpiry time.Duration `json:"tokenExpiry,omitempty"`
+	// UserPINAttempts is the number of wrong user PINs after which the pre-authorized code is invalidated.
+	// Optional, by default 3.
+	UserPINAttempts int `json:"userPINAttempts,omitempty"`
+}
+
+// offerRecord is the state of a credential offer kept by pre-authorized code and later by access token.
+type offerRecord struct {
+	Credential json.RawMessage `json:"credential"`
+	Format     string          `json:"format"`
+	UserPIN    string          `json:"userPIN,omitempty"`
+	// WrongPINs is the number of token requests with a wrong user PIN.
+	WrongPINs int       `json:"wrongPINs,omitempty"`
+	CNonce    string    `json:"cNonce,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// OfferOptions configures the credential offer.
+type OfferOptions func(opts *offerOpts)
+
+type offerOpts struct {
+	format  string
+	userPIN string
+}
+
+// WithCredentialFormat sets format of the offered credential, jwt_vc_json by default.
+func WithCredentialFormat(format string) OfferOptions {
+	return func(opts *offerOpts) {
+		opts.format = format
+	}
+}
+
+// WithUserPIN requires the wallet to present the given PIN, transmitted to the user out of band,
+// together with the pre-authorized code.
+func WithUserPIN(pin string) OfferOptions {
+	return func(opts *offerOpts) {
+		opts.userPIN = pin
+	}
+}
+
+// Issuer is the OpenID4VCI credential issuer supporting the pre-authorized code flow.
+// Pre-authorized codes and access tokens are redeemed under a lock of the issuer, so they're for one time use as long
+// as a single Issuer instance serves the store.
+type Issuer struct {
+	config         IssuerConfig
+	store          storage.Store
+	keyManager     kms.KeyManager
+	crypto         crypto.Crypto
+	vdr            vdr.Registry
+	documentLoader ld.DocumentLoader
+	lock           sync.Mutex
+}
+
+// NewIssuer returns new OpenID4VCI credential issuer.
+func NewIssuer(ctx provider, config *IssuerConfig) (*Issuer, error) {
+	if config == nil || config.URL == "" {
+		return nil, errors.New("credential issuer URL is mandatory")
+	}
+
+	if config.VerificationMethod == "" {
+		return nil, errors.New("verification method is mandatory")
+	}
+
+	store, err := ctx.StorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+
+	issuer := &Issuer{
+		config:         *config,
+		store:          store,
+		keyManager:     ctx.KMS(),
+		crypto:         ctx.Crypto(),
+		vdr:            ctx.VDRegistry(),
+		documentLoader: ctx.JSONLDDocumentLoader(),
+	}
+
+	issuer.config.URL = strings.TrimSuffix(config.URL, "/")
+
+	if issuer.config.KeyID == "" {
+		vmSplit := strings.Split(config.VerificationMethod, "#")
+		if len(vmSplit) != vmSectionCount {
+			return nil, errors.New("invalid verification method format")
+		}
+
+		issuer.config.KeyID = vmSplit[1]
+	}
+
+	if issuer.config.OfferExpiry == 0 {
+		issuer.config.OfferExpiry = defaultOfferExpiry
+	}
+
+	if issuer.config.TokenExpiry == 0 {
+		issuer.config.TokenExpiry = defaultTokenExpiry
+	}
+
+	if issuer.config.UserPINAttempts == 0 {
+		issuer.config.UserPINAttempts = defaultUserPINAttempts
+	}
+
+	return issuer, nil
+}
+
+// Metadata returns the credential issuer metadata.
+func (i *Issuer) Metadata() *IssuerMetadata {
+	metadata := &IssuerMetadata{
+		CredentialIssuer:   i.config.URL,
+		CredentialEndpoint: i.config.URL + CredentialEndpointPath,
+		CredentialsSupported: []*SupportedCredential{
+			{
+				Format:                               JWTVCJSONFormat,
+				CryptographicBindingMethodsSupported: []string{"did"},
+			},
+			{
+				Format:                               LDPVCFormat,
+				CryptographicBindingMethodsSupported: []string{"did"},
+			},
+		},
+	}
+
+	if i.config.SignatureType != "" {
+		metadata.CredentialsSupported[1].CryptographicSuitesSupported = []string{i.config.SignatureType}
+	}
+
+	return metadata
+}
+
+// AuthorizationServerMetadata returns the metadata of the authorization server issuing access tokens.
+func (i *Issuer) AuthorizationServerMetadata() *AuthorizationServerMetadata {
+	return &AuthorizationServerMetadata{
+		Issuer:                            i.config.URL,
+		TokenEndpoint:                     i.config.URL + TokenEndpointPath,
+		PreAuthorizedGrantAnonymousAccess: true,
+	}
+}
+
+// CreateCredentialOffer creates an offer of the given credential using the pre-authorized code flow.
+// The credential is signed by the issuer once requested by the wallet.
+//
+//	Args:
+//		- credential: verifiable credential to be issued, any existing proof will be replaced.
+//		- options: credential format and user PIN of the offer.
+//
+// Returns:
+//   - credential offer to be passed to the wallet.
+//   - error if operation fails.
+func (i *Issuer) CreateCredentialOffer(credential json.RawMessage, options ...OfferOptions) (*CredentialOffer, error) {
+	opts := &offerOpts{format: JWTVCJSONFormat}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	if opts.format != JWTVCJSONFormat && opts.format != LDPVCFormat {
+		return nil, fmt.Errorf("unsupported credential format: %s", opts.format)
+	}
+
+	vc, err := verifiable.ParseCredential(credential, verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(i.documentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credential: %w", err)
+	}
+
+	code := uuid.New().String()
+
+	err = i.saveRecord(offerKeyPrefix+code, &offerRecord{
+		Credential: credential,
+		Format:     opts.format,
+		UserPIN:    opts.userPIN,
+		ExpiresAt:  time.Now().Add(i.config.OfferExpiry),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debugf("created credential offer of credential %s", vc.ID)
+
+	return &CredentialOffer{
+		CredentialIssuer: i.config.URL,
+		Credentials:      []*OfferedCredential{{Format: opts.format, Types: vc.Types}},
+		Grants: &CredentialOfferGrant{
+			PreAuthorizedCode: &PreAuthorizedCodeGrant{
+				PreAuthorizedCode: code,
+				UserPINRequired:   opts.userPIN != "",
+			},
+		},
+	}, nil
+}
+
+// Token exchanges the pre-authorized code of a credential offer for an access token.
+// The pre-authorized code is for one time use, it's also invalidated after IssuerConfig.UserPINAttempts wrong user
+// PINs.
+// Returned errors of an invalid request are of *Error type.
+func (i *Issuer) Token(request *TokenRequest) (*TokenResponse, error) {
+	if request.GrantType != PreAuthorizedCodeGrantType {
+		return nil, newError(UnsupportedGrantTypeError, fmt.Sprintf("grant type %s is not supported",
+			request.GrantType))
+	}
+
+	if request.PreAuthorizedCode == "" {
+		return nil, newError(InvalidRequestError, "pre-authorized code is mandatory")
+	}
+
+	record, err := i.redeemCode(request)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken := uuid.New().String()
+
+	record.UserPIN = ""
+	record.WrongPINs = 0
+	record.CNonce = uuid.New().String()
+	record.ExpiresAt = time.Now().Add(i.config.TokenExpiry)
+
+	err = i.saveRecord(tokenKeyPrefix+accessToken, record)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresIn := int64(i.config.TokenExpiry / time.Second)
+
+	return &TokenResponse{
+		AccessToken:     accessToken,
+		TokenType:       BearerTokenType,
+		ExpiresIn:       expiresIn,
+		CNonce:          record.CNonce,
+		CNonceExpiresIn: expiresIn,
+	}, nil
+}
+
+// redeemCode validates the token request and deletes the pre-authorized code, the code is checked and deleted under
+// the issuer lock so that concurrent requests can't redeem it twice.
+func (i *Issuer) redeemCode(request *TokenRequest) (*offerRecord, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	key := offerKeyPrefix + request.PreAuthorizedCode
+
+	record, err := i.getRecord(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, newError(InvalidGrantError, "pre-authorized code is invalid")
+		}
+
+		return nil, err
+	}
+
+	if time.Now().After(record.ExpiresAt) {
+		return nil, newError(InvalidGrantError, "pre-authorized code is expired")
+	}
+
+	if record.UserPIN != "" && request.UserPIN == "" {
+		return nil, newError(InvalidRequestError, "user PIN is required")
+	}
+
+	if record.UserPIN != request.UserPIN {
+		record.WrongPINs++
+
+		if record.WrongPINs >= i.config.UserPINAttempts {
+			err = i.store.Delete(key)
+		} else {
+			err = i.saveRecord(key, record)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to update credential offer: %w", err)
+		}
+
+		return nil, newError(InvalidGrantError, "user PIN is invalid")
+	}
+
+	// pre-authorized code is for one time use.
+	err = i.store.Delete(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete credential offer: %w", err)
+	}
+
+	return record, nil
+}
+
+// Credential issues the offered credential to the wallet presenting the access token.
+// If the request contains a proof of possession of a DID key, the credential subject is bound to this DID.
+// Returned errors of an invalid request are of *Error type.
+func (i *Issuer) Credential(accessToken string, request *CredentialRequest) (*CredentialResponse, error) {
+	record, vc, err := i.redeemToken(accessToken, request)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := i.signCredential(vc, record.Format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign credential: %w", err)
+	}
+
+	return &CredentialResponse{Format: record.Format, Credential: credential}, nil
+}
+
+// redeemToken validates the credential request and deletes the access token before the credential is issued, the
+// token is checked and deleted under the issuer lock so that concurrent requests can't redeem it twice.
+func (i *Issuer) redeemToken(accessToken string, request *CredentialRequest) (*offerRecord,
+	*verifiable.Credential, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	record, err := i.getRecord(tokenKeyPrefix + accessToken)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, nil, newError(InvalidTokenError, "access token is invalid")
+		}
+
+		return nil, nil, err
+	}
+
+	if time.Now().After(record.ExpiresAt) {
+		return nil, nil, newError(InvalidTokenError, "access token is expired")
+	}
+
+	if request.Format != record.Format {
+		return nil, nil, newError(UnsupportedCredentialFormatError,
+			fmt.Sprintf("credential format %s is not offered", request.Format))
+	}
+
+	vc, err := verifiable.ParseCredential(record.Credential, verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(i.documentLoader))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse credential: %w", err)
+	}
+
+	for _, t := range request.Types {
+		if !contains(vc.Types, t) {
+			return nil, nil, newError(UnsupportedCredentialTypeError,
+				fmt.Sprintf("credential type %s is not offered", t))
+		}
+	}
+
+	if request.Proof != nil {
+		holderDID, e := i.verifyProof(request.Proof, record.CNonce)
+		if e != nil {
+			return nil, nil, newError(InvalidOrMissingProofError, e.Error())
+		}
+
+		bindSubject(vc, holderDID)
+	}
+
+	// access token is for one time use.
+	err = i.store.Delete(tokenKeyPrefix + accessToken)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to delete access token: %w", err)
+	}
+
+	return record, vc, nil
+}
+
+// verifyProof verifies the proof JWT signed by the holder key and returns DID of the holder derived from the
+// verified key ID.
+func (i *Issuer) verifyProof(proof *Proof, cNonce string) (string, error) {
+	if proof.ProofType != JWTProofType {
+		return "", fmt.Errorf("proof type %s is not supported", proof.ProofType)
+	}
+
+	fetchPublicKey := verifiable.NewVDRKeyResolver(i.vdr).PublicKeyFetcher()
+
+	// the signing key is resolved from the DID of kid rather than from the unverified iss claim.
+	keyResolver := jwt.KeyResolverFunc(func(_, kid string) (*verifier.PublicKey, error) {
+		return fetchPublicKey(strings.Split(kid, "#")[0], kid)
+	})
+
+	jws, err := jose.ParseJWS(proof.JWT, jwt.NewVerifier(keyResolver))
+	if err != nil {
+		return "", fmt.Errorf("parse proof JWT: %w", err)
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != ProofJWTType {
+		return "", fmt.Errorf("proof JWT typ must be %s", ProofJWTType)
+	}
+
+	// the proof is verified with the key referenced by kid, so the holder is the DID controlling this key.
+	kid, _ := jws.ProtectedHeaders.KeyID()
+	holderDID := strings.Split(kid, "#")[0]
+
+	claims := &proofClaims{}
+
+	err = json.Unmarshal(jws.Payload, claims)
+	if err != nil {
+		return "", fmt.Errorf("decode proof JWT claims: %w", err)
+	}
+
+	if claims.Nonce != cNonce {
+		return "", errors.New("proof JWT nonce doesn't match")
+	}
+
+	if claims.Audience != i.config.URL {
+		return "", errors.New("proof JWT audience doesn't match the credential issuer")
+	}
+
+	if claims.Issuer != "" && claims.Issuer != holderDID {
+		return "", errors.New("proof JWT issuer doesn't match the DID of the signing key")
+	}
+
+	return holderDID, nil
+}
+
+func (i *Issuer) signCredential(vc *verifiable.Credential, format string) (json.RawMessage, error) {
+	s, err := signature.GetCryptoSigner(i.crypto, i.keyManager, i.config.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// credential is issued by DID of the signing key.
+	vc.Issuer.ID = strings.Split(i.config.VerificationMethod, "#")[0]
+	vc.Proofs = nil
+
+	if format == JWTVCJSONFormat {
+		claims, e := vc.JWTClaims(false)
+		if e != nil {
+			return nil, fmt.Errorf("failed to generate JWT claims for VC: %w", e)
+		}
+
+		jws, e := claims.MarshalJWS(jwsAlgorithm(s.Alg()), s, i.config.VerificationMethod)
+		if e != nil {
+			return nil, fmt.Errorf("failed to sign JWS: %w", e)
+		}
+
+		return json.Marshal(jws)
+	}
+
+	signatureType := i.config.SignatureType
+	if signatureType == "" {
+		signatureType = JSONWebSignature2020
+
+		if _, ok := s.PublicKey().(ed25519.PublicKey); ok {
+			signatureType = Ed25519Signature2018
+		}
+	}
+
+	var signatureSuite signer.SignatureSuite
+
+	switch signatureType {
+	case Ed25519Signature2018:
+		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
+	case JSONWebSignature2020:
+		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
+	default:
+		return nil, fmt.Errorf("signature type unsupported %s", signatureType)
+	}
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		VerificationMethod:      i.config.VerificationMethod,
+		SignatureRepresentation: verifiable.SignatureJWS,
+		SignatureType:           signatureType,
+		Suite:                   signatureSuite,
+	}, jsonld.WithDocumentLoader(i.documentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add linked data proof: %w", err)
+	}
+
+	return vc.MarshalJSON()
+}
+
+func (i *Issuer) saveRecord(key string, record *offerRecord) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	// the store drops the record once it's expired, the expiry is checked as well for stores not supporting it.
+	err = i.store.Batch([]storage.Operation{{
+		Key:        key,
+		Value:      recordBytes,
+		PutOptions: &storage.PutOptions{ExpiresAt: record.ExpiresAt},
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to save record: %w", err)
+	}
+
+	return nil
+}
+
+func (i *Issuer) getRecord(key string) (*offerRecord, error) {
+	recordBytes, err := i.store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &offerRecord{}
+
+	err = json.Unmarshal(recordBytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+
+	return record, nil
+}
+
+// bindSubject sets the holder DID as ID of the credential subject if the subject has no ID.
+func bindSubject(vc *verifiable.Credential, holderDID string) {
+	subjects, ok := vc.Subject.([]verifiable.Subject)
+	if !ok || len(subjects) != 1 || subjects[0].ID != "" {
+		return
+	}
+
+	subjects[0].ID = holderDID
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// proofClaims are claims of the proof JWT.
+type proofClaims struct {
+	Issuer   string `json:"iss,omitempty"`
+	Audience string `json:"aud"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"nonce"`
+}
+
+// jwsAlgorithm returns the JWS algorithm of the JWS alg name of a signer.
+func jwsAlgorithm(alg string) verifiable.JWSAlgorithm {
+	switch alg {
+	case "ES256":
+		return verifiable.ECDSASecp256r1
+	case "ES384":
+		return verifiable.ECDSASecp384r1
+	case "ES521":
+		return verifiable.ECDSASecp521r1
+	}
+
+	return verifiable.EdDSA
+}
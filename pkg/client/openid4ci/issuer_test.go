@@ -0,0 +1,468 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+	spi "github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	issuerURL = "https://issuer.example.com/openid4ci"
+
+	sampleCredential = `{
+		"@context": [
+			"https://www.w3.org/2018/credentials/v1",
+			"https://www.w3.org/2018/credentials/examples/v1"
+		],
+		"id": "http://example.edu/credentials/1872",
+		"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {
+			"degree": {
+				"type": "BachelorDegree",
+				"university": "MIT"
+			}
+		}
+	}`
+)
+
+func TestNewIssuer(t *testing.T) {
+	t.Run("test success", func(t *testing.T) {
+		issuer, err := NewIssuer(newMockProvider(t), &IssuerConfig{
+			URL:                issuerURL + "/",
+			VerificationMethod: "did:example:123#key-1",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "key-1", issuer.config.KeyID)
+		require.Equal(t, issuerURL+CredentialEndpointPath, issuer.Metadata().CredentialEndpoint)
+		require.Equal(t, issuerURL+TokenEndpointPath, issuer.AuthorizationServerMetadata().TokenEndpoint)
+	})
+
+	t.Run("test invalid config", func(t *testing.T) {
+		_, err := NewIssuer(newMockProvider(t), nil)
+		require.EqualError(t, err, "credential issuer URL is mandatory")
+
+		_, err = NewIssuer(newMockProvider(t), &IssuerConfig{URL: issuerURL})
+		require.EqualError(t, err, "verification method is mandatory")
+
+		_, err = NewIssuer(newMockProvider(t), &IssuerConfig{URL: issuerURL, VerificationMethod: "did:example:123"})
+		require.EqualError(t, err, "invalid verification method format")
+
+		p := newMockProvider(t)
+		p.StorageProviderValue = &storage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")}
+
+		_, err = NewIssuer(p, &IssuerConfig{URL: issuerURL, VerificationMethod: "did:example:123#key-1"})
+		require.EqualError(t, err, "failed to open store: open error")
+	})
+}
+
+func TestIssuer_PreAuthorizedCodeFlow(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	t.Run("test issue JWT-VC", func(t *testing.T) {
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+		require.Equal(t, issuerURL, offer.CredentialIssuer)
+		require.Equal(t, JWTVCJSONFormat, offer.Credentials[0].Format)
+		require.Equal(t, []string{"VerifiableCredential", "UniversityDegreeCredential"}, offer.Credentials[0].Types)
+		require.False(t, offer.Grants.PreAuthorizedCode.UserPINRequired)
+
+		token, err := issuer.Token(&TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		})
+		require.NoError(t, err)
+		require.Equal(t, BearerTokenType, token.TokenType)
+		require.NotEmpty(t, token.CNonce)
+
+		// pre-authorized code is for one time use.
+		_, err = issuer.Token(&TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		})
+		requireError(t, err, InvalidGrantError)
+
+		response, err := issuer.Credential(token.AccessToken, &CredentialRequest{Format: JWTVCJSONFormat})
+		require.NoError(t, err)
+		require.Equal(t, JWTVCJSONFormat, response.Format)
+
+		vc, err := verifiable.ParseCredential(response.Credential,
+			verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(issuer.vdr).PublicKeyFetcher()),
+			verifiable.WithJSONLDDocumentLoader(issuer.documentLoader))
+		require.NoError(t, err)
+		require.NotEmpty(t, vc.JWT)
+		require.Equal(t, issuer.Metadata().CredentialIssuer, offer.CredentialIssuer)
+		require.Contains(t, vc.Issuer.ID, "did:key:")
+
+		// access token is for one time use.
+		_, err = issuer.Credential(token.AccessToken, &CredentialRequest{Format: JWTVCJSONFormat})
+		requireError(t, err, InvalidTokenError)
+	})
+
+	t.Run("test issue LDP-VC with user PIN", func(t *testing.T) {
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential),
+			WithCredentialFormat(LDPVCFormat), WithUserPIN("1234"))
+		require.NoError(t, err)
+		require.True(t, offer.Grants.PreAuthorizedCode.UserPINRequired)
+
+		request := &TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		}
+
+		_, err = issuer.Token(request)
+		requireError(t, err, InvalidRequestError)
+
+		request.UserPIN = "0000"
+
+		_, err = issuer.Token(request)
+		requireError(t, err, InvalidGrantError)
+
+		request.UserPIN = "1234"
+
+		token, err := issuer.Token(request)
+		require.NoError(t, err)
+
+		_, err = issuer.Credential(token.AccessToken, &CredentialRequest{Format: JWTVCJSONFormat})
+		requireError(t, err, UnsupportedCredentialFormatError)
+
+		_, err = issuer.Credential(token.AccessToken, &CredentialRequest{
+			Format: LDPVCFormat,
+			Types:  []string{"VerifiableCredential", "PermanentResidentCard"},
+		})
+		requireError(t, err, UnsupportedCredentialTypeError)
+
+		_, err = issuer.Credential(token.AccessToken, &CredentialRequest{
+			Format: LDPVCFormat,
+			Proof:  &Proof{ProofType: "cwt"},
+		})
+		requireError(t, err, InvalidOrMissingProofError)
+
+		response, err := issuer.Credential(token.AccessToken, &CredentialRequest{
+			Format: LDPVCFormat,
+			Types:  []string{"UniversityDegreeCredential"},
+		})
+		require.NoError(t, err)
+
+		vc, err := verifiable.ParseCredential(response.Credential,
+			verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(issuer.vdr).PublicKeyFetcher()),
+			verifiable.WithJSONLDDocumentLoader(issuer.documentLoader))
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, Ed25519Signature2018, vc.Proofs[0]["type"])
+	})
+
+	t.Run("test invalid requests", func(t *testing.T) {
+		_, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential), WithCredentialFormat("mso_mdoc"))
+		require.EqualError(t, err, "unsupported credential format: mso_mdoc")
+
+		_, err = issuer.CreateCredentialOffer(json.RawMessage(`{}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse credential")
+
+		_, err = issuer.Token(&TokenRequest{GrantType: "authorization_code"})
+		requireError(t, err, UnsupportedGrantTypeError)
+
+		_, err = issuer.Token(&TokenRequest{GrantType: PreAuthorizedCodeGrantType})
+		requireError(t, err, InvalidRequestError)
+
+		_, err = issuer.Token(&TokenRequest{GrantType: PreAuthorizedCodeGrantType, PreAuthorizedCode: "unknown"})
+		requireError(t, err, InvalidGrantError)
+
+		_, err = issuer.Credential("unknown", &CredentialRequest{Format: JWTVCJSONFormat})
+		requireError(t, err, InvalidTokenError)
+	})
+
+	t.Run("test proof issuer doesn't match the signing key", func(t *testing.T) {
+		pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, holderKeyID := fingerprint.CreateDIDKey(pubKey)
+
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+
+		token, err := issuer.Token(&TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		})
+		require.NoError(t, err)
+
+		proofJWT, err := jwt.NewSigned(&proofClaims{
+			Issuer:   "did:example:victim",
+			Audience: issuerURL,
+			IssuedAt: time.Now().Unix(),
+			Nonce:    token.CNonce,
+		}, jose.Headers{
+			jose.HeaderType:  ProofJWTType,
+			jose.HeaderKeyID: holderKeyID,
+		}, jwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		serializedJWT, err := proofJWT.Serialize(false)
+		require.NoError(t, err)
+
+		_, err = issuer.Credential(token.AccessToken, &CredentialRequest{
+			Format: JWTVCJSONFormat,
+			Proof:  &Proof{ProofType: JWTProofType, JWT: serializedJWT},
+		})
+		requireError(t, err, InvalidOrMissingProofError)
+		require.Contains(t, err.Error(), "proof JWT issuer doesn't match the DID of the signing key")
+	})
+
+	t.Run("test pre-authorized code is invalidated after wrong user PINs", func(t *testing.T) {
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential), WithUserPIN("1234"))
+		require.NoError(t, err)
+
+		request := &TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+			UserPIN:           "0000",
+		}
+
+		for i := 0; i < defaultUserPINAttempts; i++ {
+			_, err = issuer.Token(request)
+			requireError(t, err, InvalidGrantError)
+			require.Contains(t, err.Error(), "user PIN is invalid")
+		}
+
+		request.UserPIN = "1234"
+
+		_, err = issuer.Token(request)
+		requireError(t, err, InvalidGrantError)
+		require.Contains(t, err.Error(), "pre-authorized code is invalid")
+	})
+
+	t.Run("test access token is redeemed before the credential is signed", func(t *testing.T) {
+		unsigned := newTestIssuer(t)
+		unsigned.config.KeyID = "unknown"
+
+		offer, err := unsigned.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+
+		token, err := unsigned.Token(&TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		})
+		require.NoError(t, err)
+
+		_, err = unsigned.Credential(token.AccessToken, &CredentialRequest{Format: JWTVCJSONFormat})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to sign credential")
+
+		_, err = unsigned.Credential(token.AccessToken, &CredentialRequest{Format: JWTVCJSONFormat})
+		requireError(t, err, InvalidTokenError)
+	})
+
+	t.Run("test pre-authorized code and access token are redeemed once by concurrent requests", func(t *testing.T) {
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+
+		const requests = 10
+
+		tokens := make(chan *TokenResponse, requests)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				token, e := issuer.Token(&TokenRequest{
+					GrantType:         PreAuthorizedCodeGrantType,
+					PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+				})
+				if e == nil {
+					tokens <- token
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(tokens)
+		require.Len(t, tokens, 1)
+
+		token := <-tokens
+		credentials := make(chan *CredentialResponse, requests)
+
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				response, e := issuer.Credential(token.AccessToken, &CredentialRequest{Format: JWTVCJSONFormat})
+				if e == nil {
+					credentials <- response
+				}
+			}()
+		}
+
+		wg.Wait()
+		require.Len(t, credentials, 1)
+	})
+
+	t.Run("test pre-authorized code and access token expire in the store", func(t *testing.T) {
+		p := newMockProvider(t)
+		p.StorageProviderValue = mem.NewProvider()
+
+		expiring, err := NewIssuer(p, &IssuerConfig{
+			URL:                issuerURL,
+			VerificationMethod: issuer.config.VerificationMethod,
+			OfferExpiry:        -time.Second,
+		})
+		require.NoError(t, err)
+
+		offer, err := expiring.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+
+		_, err = expiring.store.Get(offerKeyPrefix + offer.Grants.PreAuthorizedCode.PreAuthorizedCode)
+		require.True(t, errors.Is(err, spi.ErrDataNotFound))
+
+		_, err = expiring.Token(&TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		})
+		requireError(t, err, InvalidGrantError)
+		require.Contains(t, err.Error(), "pre-authorized code is invalid")
+	})
+
+	t.Run("test expired pre-authorized code and access token", func(t *testing.T) {
+		expiring := newTestIssuer(t)
+		expiring.config.OfferExpiry = -time.Second
+
+		offer, err := expiring.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+
+		_, err = expiring.Token(&TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		})
+		requireError(t, err, InvalidGrantError)
+
+		expiring.config.OfferExpiry = time.Minute
+		expiring.config.TokenExpiry = -time.Second
+
+		offer, err = expiring.CreateCredentialOffer(json.RawMessage(sampleCredential))
+		require.NoError(t, err)
+
+		token, err := expiring.Token(&TokenRequest{
+			GrantType:         PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		})
+		require.NoError(t, err)
+
+		_, err = expiring.Credential(token.AccessToken, &CredentialRequest{Format: JWTVCJSONFormat})
+		requireError(t, err, InvalidTokenError)
+	})
+}
+
+func TestCredentialOffer_URI(t *testing.T) {
+	offer := &CredentialOffer{
+		CredentialIssuer: issuerURL,
+		Credentials:      []*OfferedCredential{{Format: JWTVCJSONFormat, Types: []string{"VerifiableCredential"}}},
+		Grants: &CredentialOfferGrant{
+			PreAuthorizedCode: &PreAuthorizedCodeGrant{PreAuthorizedCode: "code", UserPINRequired: true},
+		},
+	}
+
+	offerURI, err := offer.URI()
+	require.NoError(t, err)
+	require.Contains(t, offerURI, CredentialOfferScheme+"?credential_offer=")
+
+	parsed, err := ParseCredentialOfferURI(offerURI)
+	require.NoError(t, err)
+	require.Equal(t, offer, parsed)
+
+	_, err = ParseCredentialOfferURI(CredentialOfferScheme)
+	require.EqualError(t, err, "credential offer URI has no credential_offer parameter")
+
+	_, err = ParseCredentialOfferURI(CredentialOfferScheme + "?credential_offer=%7B")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unmarshal credential offer")
+}
+
+func requireError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var e *Error
+
+	require.True(t, errors.As(err, &e), "unexpected error: %v", err)
+	require.Equal(t, code, e.Code)
+}
+
+func newMockProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	kmsProvider, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	localKMS, err := localkms.New("local-lock://custom/master/key/", kmsProvider)
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	return &mockprovider.Provider{
+		StorageProviderValue: storage.NewMockStoreProvider(),
+		KMSValue:             localKMS,
+		CryptoValue:          tinkCrypto,
+		VDRegistryValue:      vdrpkg.New(vdrpkg.WithVDR(key.New())),
+		DocumentLoaderValue:  loader,
+	}
+}
+
+func newTestIssuer(t *testing.T) *Issuer {
+	t.Helper()
+
+	p := newMockProvider(t)
+
+	kid, pubKey, err := p.KMSValue.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, verificationMethod := fingerprint.CreateDIDKey(pubKey)
+
+	issuer, err := NewIssuer(p, &IssuerConfig{
+		URL:                issuerURL,
+		VerificationMethod: verificationMethod,
+		KeyID:              kid,
+	})
+	require.NoError(t, err)
+
+	return issuer
+}
@@ -0,0 +1,195 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// OpenID for Verifiable Credential Issuance constants.
+// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0-11.html
+const (
+	// PreAuthorizedCodeGrantType is grant type of the pre-authorized code flow.
+	PreAuthorizedCodeGrantType = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+
+	// JWTVCJSONFormat is credential format of JWT-VC not using JSON-LD.
+	JWTVCJSONFormat = "jwt_vc_json"
+	// LDPVCFormat is credential format of W3C Verifiable Credential secured by Linked Data Proof.
+	LDPVCFormat = "ldp_vc"
+
+	// JWTProofType is proof type of the JWT proof of possession of the holder key.
+	JWTProofType = "jwt"
+	// ProofJWTType is "typ" header of the proof JWT.
+	ProofJWTType = "openid4vci-proof+jwt"
+
+	// BearerTokenType is type of the access token issued by the token endpoint.
+	BearerTokenType = "bearer"
+
+	// CredentialOfferScheme is URI scheme of the credential offer passed by value.
+	CredentialOfferScheme = "openid-credential-offer://"
+	// CredentialOfferParameter is query parameter containing the credential offer passed by value.
+	CredentialOfferParameter = "credential_offer"
+
+	// IssuerMetadataPath is a path of the credential issuer metadata relative to the credential issuer URL.
+	IssuerMetadataPath = "/.well-known/openid-credential-issuer"
+	// AuthorizationServerMetadataPath is a path of the authorization server metadata relative to the issuer URL.
+	AuthorizationServerMetadataPath = "/.well-known/oauth-authorization-server"
+	// TokenEndpointPath is a path of the token endpoint relative to the credential issuer URL.
+	TokenEndpointPath = "/token"
+	// CredentialEndpointPath is a path of the credential endpoint relative to the credential issuer URL.
+	CredentialEndpointPath = "/credential"
+)
+
+// OAuth 2.0 and OpenID4VCI error codes.
+const (
+	InvalidRequestError              = "invalid_request"
+	InvalidGrantError                = "invalid_grant"
+	UnsupportedGrantTypeError        = "unsupported_grant_type"
+	InvalidTokenError                = "invalid_token"
+	UnsupportedCredentialFormatError = "unsupported_credential_format"
+	UnsupportedCredentialTypeError   = "unsupported_credential_type"
+	InvalidOrMissingProofError       = "invalid_or_missing_proof"
+	ServerError                      = "server_error"
+)
+
+// Error is an error response of the token and credential endpoints.
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func newError(code, description string) *Error {
+	return &Error{Code: code, Description: description}
+}
+
+// CredentialOffer is an offer of the credential issuer to the wallet.
+type CredentialOffer struct {
+	CredentialIssuer string                `json:"credential_issuer"`
+	Credentials      []*OfferedCredential  `json:"credentials"`
+	Grants           *CredentialOfferGrant `json:"grants,omitempty"`
+}
+
+// OfferedCredential describes a credential offered by the credential issuer.
+type OfferedCredential struct {
+	Format string   `json:"format"`
+	Types  []string `json:"types,omitempty"`
+}
+
+// CredentialOfferGrant contains the grants the wallet may use with the authorization server.
+type CredentialOfferGrant struct {
+	PreAuthorizedCode *PreAuthorizedCodeGrant `json:"urn:ietf:params:oauth:grant-type:pre-authorized_code,omitempty"`
+}
+
+// PreAuthorizedCodeGrant contains the pre-authorized code exchanged for an access token.
+type PreAuthorizedCodeGrant struct {
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+	UserPINRequired   bool   `json:"user_pin_required,omitempty"`
+}
+
+// URI returns the credential offer passed by value in the openid-credential-offer URI scheme.
+func (o *CredentialOffer) URI() (string, error) {
+	offerBytes, err := json.Marshal(o)
+	if err != nil {
+		return "", fmt.Errorf("marshal credential offer: %w", err)
+	}
+
+	return CredentialOfferScheme + "?" + url.Values{CredentialOfferParameter: {string(offerBytes)}}.Encode(), nil
+}
+
+// ParseCredentialOfferURI parses the credential offer passed by value in the given URI.
+func ParseCredentialOfferURI(offerURI string) (*CredentialOffer, error) {
+	u, err := url.Parse(offerURI)
+	if err != nil {
+		return nil, fmt.Errorf("parse credential offer URI: %w", err)
+	}
+
+	offerParam := u.Query().Get(CredentialOfferParameter)
+	if offerParam == "" {
+		return nil, errors.New("credential offer URI has no credential_offer parameter")
+	}
+
+	offer := &CredentialOffer{}
+
+	err = json.Unmarshal([]byte(offerParam), offer)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal credential offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// TokenRequest is a request to the token endpoint.
+type TokenRequest struct {
+	GrantType         string `json:"grant_type"`
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+	UserPIN           string `json:"user_pin,omitempty"`
+}
+
+// TokenResponse is a response of the token endpoint.
+type TokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in,omitempty"`
+	CNonce          string `json:"c_nonce,omitempty"`
+	CNonceExpiresIn int64  `json:"c_nonce_expires_in,omitempty"`
+}
+
+// CredentialRequest is a request to the credential endpoint.
+type CredentialRequest struct {
+	Format string   `json:"format"`
+	Types  []string `json:"types,omitempty"`
+	Proof  *Proof   `json:"proof,omitempty"`
+}
+
+// Proof is a proof of possession of the key material the issued credential is bound to.
+type Proof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt,omitempty"`
+}
+
+// CredentialResponse is a response of the credential endpoint.
+type CredentialResponse struct {
+	Format string `json:"format"`
+	// Credential is a JWT string for jwt_vc_json format or a JSON object for ldp_vc format.
+	Credential      json.RawMessage `json:"credential"`
+	CNonce          string          `json:"c_nonce,omitempty"`
+	CNonceExpiresIn int64           `json:"c_nonce_expires_in,omitempty"`
+}
+
+// IssuerMetadata is the credential issuer metadata.
+type IssuerMetadata struct {
+	CredentialIssuer     string                 `json:"credential_issuer"`
+	AuthorizationServer  string                 `json:"authorization_server,omitempty"`
+	CredentialEndpoint   string                 `json:"credential_endpoint"`
+	CredentialsSupported []*SupportedCredential `json:"credentials_supported"`
+}
+
+// SupportedCredential describes a credential format supported by the credential issuer.
+type SupportedCredential struct {
+	Format                               string   `json:"format"`
+	CryptographicBindingMethodsSupported []string `json:"cryptographic_binding_methods_supported,omitempty"`
+	CryptographicSuitesSupported         []string `json:"cryptographic_suites_supported,omitempty"`
+}
+
+// AuthorizationServerMetadata is the OAuth 2.0 authorization server metadata.
+type AuthorizationServerMetadata struct {
+	Issuer                            string `json:"issuer"`
+	TokenEndpoint                     string `json:"token_endpoint"`
+	PreAuthorizedGrantAnonymousAccess bool   `json:"pre-authorized_grant_anonymous_access_supported"`
+}
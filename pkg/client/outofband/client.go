@@ -473,7 +473,8 @@ func isDIDCommV2(accept []string) bool {
 		switch a {
 		case transport.MediaTypeDIDCommV2Profile, transport.MediaTypeAIP2RFC0587Profile,
 			transport.MediaTypeV2EncryptedEnvelope, transport.MediaTypeV2EncryptedEnvelopeV1PlaintextPayload,
-			transport.MediaTypeV1EncryptedEnvelope:
+			transport.MediaTypeV1EncryptedEnvelope, transport.MediaTypeV2SignedEnvelope,
+			transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload:
 			return true
 		}
 	}
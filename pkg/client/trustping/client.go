@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// provider contains dependencies for the trust ping protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Client enable access to trust ping api.
+type Client struct {
+	service.Event
+	trustPingSvc protocolService
+}
+
+// protocolService defines trust ping service.
+type protocolService interface {
+	// DIDComm service
+	service.DIDComm
+
+	// Ping sends a trust ping over the given connection and returns round-trip time.
+	Ping(connectionID string, options ...trustping.PingOption) (time.Duration, error)
+}
+
+// WithTimeout option is for definition of timeout value waiting for the ping response.
+func WithTimeout(t time.Duration) trustping.PingOption {
+	return func(opts *trustping.PingOptions) {
+		opts.Timeout = t
+	}
+}
+
+// WithComment option is for sending a comment along with the ping (DIDComm V1 only).
+func WithComment(comment string) trustping.PingOption {
+	return func(opts *trustping.PingOptions) {
+		opts.Comment = comment
+	}
+}
+
+// New return new instance of trust ping client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(trustping.TrustPing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trust ping service: %w", err)
+	}
+
+	trustPingSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to trust ping service failed")
+	}
+
+	return &Client{
+		Event:        trustPingSvc,
+		trustPingSvc: trustPingSvc,
+	}, nil
+}
+
+// Ping sends a trust ping to the other party of the given connection and waits for the response.
+// Returns round-trip latency of the ping.
+func (c *Client) Ping(connectionID string, options ...trustping.PingOption) (time.Duration, error) {
+	latency, err := c.trustPingSvc.Ping(connectionID, options...)
+	if err != nil {
+		return 0, fmt.Errorf("trust ping client - ping: %w", err)
+	}
+
+	return latency, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: fmt.Errorf("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cast service to trust ping service failed")
+	})
+}
+
+func TestClient_Ping(t *testing.T) {
+	t.Run("ping - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingFunc: func(connectionID string, options ...trustping.PingOption) (time.Duration, error) {
+					require.Equal(t, "connID", connectionID)
+
+					opts := &trustping.PingOptions{}
+					for _, option := range options {
+						option(opts)
+					}
+
+					require.Equal(t, time.Second, opts.Timeout)
+					require.Equal(t, "hello", opts.Comment)
+
+					return time.Millisecond, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		latency, err := client.Ping("connID", WithTimeout(time.Second), WithComment("hello"))
+		require.NoError(t, err)
+		require.Equal(t, time.Millisecond, latency)
+	})
+
+	t.Run("ping - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mocktrustping.MockTrustPingSvc{
+				PingErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.Ping("connID")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "trust ping client - ping: service error")
+	})
+}
@@ -9,10 +9,10 @@ package vcwallet
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
@@ -155,7 +155,7 @@ func (c *Client) Close() bool {
 // Only ciphertext wallet contents can be exported.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- passphrase: passphrase from which key for locking the exported wallet contents will be derived.
 //
 //	Returns exported locked wallet.
 //
@@ -166,17 +166,21 @@ func (c *Client) Close() bool {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //
-func (c *Client) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+func (c *Client) Export(passphrase string) (json.RawMessage, error) {
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.Export(auth, passphrase)
 }
 
 // Import Takes a serialized exported wallet representation as input
 // and imports all contents into wallet.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- passphrase: passphrase used while exporting the wallet.
+//		- contents: locked wallet content to be imported.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -186,9 +190,13 @@ func (c *Client) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Client) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Client) Import(passphrase string, contents json.RawMessage, options ...wallet.AddContentOptions) error {
+	auth, err := c.auth()
+	if err != nil {
+		return err
+	}
+
+	return c.wallet.Import(auth, passphrase, contents, options...)
 }
 
 // Add adds given data model to wallet contents store.
@@ -494,3 +502,25 @@ func (c *Client) ResolveCredentialManifest(manifest json.RawMessage, resolve wal
 
 	return c.wallet.ResolveCredentialManifest(auth, manifest, resolve)
 }
+
+// AcceptCredentialOffer accepts OpenID4VCI credential offer using pre-authorized code flow and saves issued
+// credential into wallet.
+//
+// Supports: https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0-11.html
+//
+// Args:
+// 		- offer: credential offer received from the credential issuer.
+// 		- options: options for accepting the offer, like user PIN or proof options for binding issued credential.
+//
+// Returns:
+// 		- verifiable credential issued by the credential issuer.
+// 		- error if operation fails.
+//
+func (c *Client) AcceptCredentialOffer(offer *openid4ci.CredentialOffer, options ...wallet.AcceptCredentialOfferOptions) (*verifiable.Credential, error) { // nolint: lll
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.AcceptCredentialOffer(auth, offer, options...)
+}
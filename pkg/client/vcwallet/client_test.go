@@ -12,6 +12,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -20,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/internal/testdata"
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
@@ -60,7 +63,6 @@ const (
 	sampleRemoteKMSAuth     = "sample-auth-token"
 	sampleKeyServerURL      = "sample/keyserver/test"
 	sampleUserID            = "sample-user01"
-	toBeImplementedErr      = "to be implemented"
 	sampleClientErr         = "sample client err"
 	sampleDIDKey            = "did:key:z6MknC1wwS6DEYwtGbZZo2QvjQjkh2qSBjb4GYmbye8dv4S5"
 	sampleDIDKey2           = "did:key:z6MkwFKUCsf8wvn6eSSu1WFAKatN1yexiDM7bf7pZLSFjdz6"
@@ -454,33 +456,48 @@ func TestClient_OpenClose(t *testing.T) {
 	})
 }
 
-func TestClient_Export(t *testing.T) {
+func TestClient_ExportImport(t *testing.T) {
+	const sampleExportPassphrase = "sample-export-passphrase"
+
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	err := CreateProfile(sampleUserID, mockctx, wallet.WithPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
+	vcWalletClient, err := New(sampleUserID, mockctx, wallet.WithUnlockByPassphrase(samplePassPhrase))
 	require.NotEmpty(t, vcWalletClient)
 	require.NoError(t, err)
 
-	result, err := vcWalletClient.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+	err = vcWalletClient.Add(wallet.Metadata, testdata.SampleWalletContentMetadata)
+	require.NoError(t, err)
 
-func TestClient_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, wallet.WithKeyServerURL(sampleKeyServerURL))
+	locked, err := vcWalletClient.Export(sampleExportPassphrase)
 	require.NoError(t, err)
+	require.NotEmpty(t, locked)
 
-	vcWalletClient, err := New(sampleUserID, mockctx)
+	// remove exported content and import it back.
+	err = vcWalletClient.Remove(wallet.Metadata, "did:example:123456789abcdefghi")
+	require.NoError(t, err)
+
+	err = vcWalletClient.Import(sampleExportPassphrase, locked)
+	require.NoError(t, err)
+
+	contents, err := vcWalletClient.GetAll(wallet.Metadata)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	require.True(t, vcWalletClient.Close())
+
+	// try locked wallet
+	vcWalletClient, err = New(sampleUserID, mockctx)
 	require.NotEmpty(t, vcWalletClient)
 	require.NoError(t, err)
 
-	err = vcWalletClient.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+	locked, err = vcWalletClient.Export(sampleExportPassphrase)
+	require.True(t, errors.Is(err, ErrWalletLocked))
+	require.Empty(t, locked)
+
+	err = vcWalletClient.Import(sampleExportPassphrase, locked)
+	require.True(t, errors.Is(err, ErrWalletLocked))
 }
 
 func TestClient_Add(t *testing.T) {
@@ -1758,6 +1775,47 @@ func TestClient_ResolveCredentialManifest(t *testing.T) {
 	})
 }
 
+func TestClient_AcceptCredentialOffer(t *testing.T) {
+	sampleUser := uuid.New().String()
+	mockctx := newMockProvider(t)
+
+	err := CreateProfile(sampleUser, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWallet, err := New(sampleUser, mockctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, vcWallet)
+
+	err = vcWallet.Open(wallet.WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	offer := &openid4ci.CredentialOffer{
+		CredentialIssuer: server.URL,
+		Credentials:      []*openid4ci.OfferedCredential{{Format: openid4ci.JWTVCJSONFormat}},
+		Grants: &openid4ci.CredentialOfferGrant{
+			PreAuthorizedCode: &openid4ci.PreAuthorizedCodeGrant{PreAuthorizedCode: "code"},
+		},
+	}
+
+	t.Run("test failure while requesting credential", func(t *testing.T) {
+		vc, err := vcWallet.AcceptCredentialOffer(offer, wallet.WithOfferHTTPClient(server.Client()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to request credential")
+		require.Empty(t, vc)
+	})
+
+	t.Run("test failure while accepting offer (closed wallet)", func(t *testing.T) {
+		require.True(t, vcWallet.Close())
+
+		vc, err := vcWallet.AcceptCredentialOffer(offer, wallet.WithOfferHTTPClient(server.Client()))
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, vc)
+	})
+}
+
 func newMockProvider(t *testing.T) *mockprovider.Provider {
 	t.Helper()
 
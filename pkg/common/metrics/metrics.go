@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package metrics defines the interface used by the framework to report metrics of DIDComm messaging,
+// packers, VDRs, KMS and protocol state machines. Metrics are discarded unless an implementation
+// (e.g. the Prometheus one from the prometheus subpackage) is set through the framework options.
+package metrics
+
+import (
+	"time"
+)
+
+// Metrics collects runtime metrics of the framework. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// InboundMessage counts a DIDComm message of the given type received over the transport (e.g. http or ws).
+	InboundMessage(msgType, transport string)
+
+	// OutboundMessage counts a DIDComm message of the given type sent over the transport (e.g. http or ws).
+	OutboundMessage(msgType, transport string)
+
+	// PackTime records the time taken by the packer to pack an outbound message.
+	PackTime(packer string, value time.Duration)
+
+	// UnpackTime records the time taken by the packer to unpack an inbound message.
+	UnpackTime(packer string, value time.Duration)
+
+	// VDRResolveTime records the time taken to resolve a DID of the given method.
+	VDRResolveTime(method string, value time.Duration)
+
+	// KMSOperation counts a KMS operation (e.g. create, get, export) on a key of the given type.
+	KMSOperation(operation, keyType string)
+
+	// ProtocolStateTransition counts a transition between two states of the protocol.
+	ProtocolStateTransition(protocol, from, to string)
+}
+
+// Provider is implemented by framework providers which expose metrics (e.g. the framework context).
+type Provider interface {
+	Metrics() Metrics
+}
+
+// FromProvider returns the metrics of the given provider if it implements Provider,
+// otherwise metrics which discard all values are returned.
+func FromProvider(p interface{}) Metrics {
+	if mp, ok := p.(Provider); ok && mp.Metrics() != nil {
+		return mp.Metrics()
+	}
+
+	return NewNoop()
+}
+
+// NewNoop returns metrics which discard all values. They are used when no metrics are configured.
+func NewNoop() Metrics {
+	return &noop{}
+}
+
+type noop struct{}
+
+func (n *noop) InboundMessage(string, string) {}
+
+func (n *noop) OutboundMessage(string, string) {}
+
+func (n *noop) PackTime(string, time.Duration) {}
+
+func (n *noop) UnpackTime(string, time.Duration) {}
+
+func (n *noop) VDRResolveTime(string, time.Duration) {}
+
+func (n *noop) KMSOperation(string, string) {}
+
+func (n *noop) ProtocolStateTransition(string, string, string) {}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
+)
+
+type provider struct {
+	metrics Metrics
+}
+
+func (p *provider) Metrics() Metrics {
+	return p.metrics
+}
+
+func TestFromProvider(t *testing.T) {
+	t.Run("provider with metrics", func(t *testing.T) {
+		m := &mockmetrics.MockMetrics{}
+		require.Equal(t, m, FromProvider(&provider{metrics: m}))
+	})
+
+	t.Run("provider without metrics", func(t *testing.T) {
+		require.Equal(t, NewNoop(), FromProvider(&provider{}))
+		require.Equal(t, NewNoop(), FromProvider(struct{}{}))
+		require.Equal(t, NewNoop(), FromProvider(nil))
+	})
+}
+
+func TestNoop(t *testing.T) {
+	m := NewNoop()
+
+	require.NotPanics(t, func() {
+		m.InboundMessage("type", "http")
+		m.OutboundMessage("type", "ws")
+		m.PackTime("packer", time.Second)
+		m.UnpackTime("packer", time.Second)
+		m.VDRResolveTime("key", time.Second)
+		m.KMSOperation("create", "ED25519")
+		m.ProtocolStateTransition("didexchange", "null", "invited")
+	})
+}
@@ -0,0 +1,164 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package prometheus implements framework metrics with Prometheus collectors.
+package prometheus
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const defaultNamespace = "aries"
+
+// DefaultBuckets are the default histogram buckets (in seconds) of the timing metrics.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10} // nolint:gochecknoglobals
+
+// Metrics implements metrics.Metrics. It is also a prometheus.Collector, so it can be registered with any
+// Prometheus registry in addition to being served by its own Handler.
+type Metrics struct {
+	namespace string
+	buckets   []float64
+	registry  *prometheus.Registry
+
+	inboundMessages  *prometheus.CounterVec
+	outboundMessages *prometheus.CounterVec
+	packTime         *prometheus.HistogramVec
+	unpackTime       *prometheus.HistogramVec
+	vdrResolveTime   *prometheus.HistogramVec
+	kmsOperations    *prometheus.CounterVec
+	stateTransitions *prometheus.CounterVec
+}
+
+// Opt is an option of Prometheus metrics.
+type Opt func(m *Metrics)
+
+// WithNamespace sets the namespace prepended to all metric names, "aries" by default.
+func WithNamespace(namespace string) Opt {
+	return func(m *Metrics) {
+		m.namespace = namespace
+	}
+}
+
+// WithBuckets sets the upper bounds (in seconds) of the histogram buckets of the timing metrics.
+func WithBuckets(buckets ...float64) Opt {
+	return func(m *Metrics) {
+		m.buckets = buckets
+	}
+}
+
+// New returns new Prometheus metrics.
+func New(opts ...Opt) *Metrics {
+	m := &Metrics{
+		namespace: defaultNamespace,
+		buckets:   DefaultBuckets,
+		registry:  prometheus.NewRegistry(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.inboundMessages = m.newCounterVec("didcomm_inbound_messages_total",
+		"Number of inbound DIDComm messages.", "type", "transport")
+	m.outboundMessages = m.newCounterVec("didcomm_outbound_messages_total",
+		"Number of outbound DIDComm messages.", "type", "transport")
+	m.packTime = m.newHistogramVec("packer_pack_seconds",
+		"Time taken to pack an outbound DIDComm message.", "packer")
+	m.unpackTime = m.newHistogramVec("packer_unpack_seconds",
+		"Time taken to unpack an inbound DIDComm message.", "packer")
+	m.vdrResolveTime = m.newHistogramVec("vdr_resolve_seconds",
+		"Time taken to resolve a DID.", "method")
+	m.kmsOperations = m.newCounterVec("kms_operations_total",
+		"Number of KMS operations.", "operation", "key_type")
+	m.stateTransitions = m.newCounterVec("protocol_state_transitions_total",
+		"Number of protocol state transitions.", "protocol", "from", "to")
+
+	m.registry.MustRegister(m)
+
+	return m
+}
+
+// InboundMessage counts a DIDComm message of the given type received over the transport.
+func (m *Metrics) InboundMessage(msgType, transport string) {
+	m.inboundMessages.WithLabelValues(msgType, transport).Inc()
+}
+
+// OutboundMessage counts a DIDComm message of the given type sent over the transport.
+func (m *Metrics) OutboundMessage(msgType, transport string) {
+	m.outboundMessages.WithLabelValues(msgType, transport).Inc()
+}
+
+// PackTime records the time taken by the packer to pack an outbound message.
+func (m *Metrics) PackTime(packer string, value time.Duration) {
+	m.packTime.WithLabelValues(packer).Observe(value.Seconds())
+}
+
+// UnpackTime records the time taken by the packer to unpack an inbound message.
+func (m *Metrics) UnpackTime(packer string, value time.Duration) {
+	m.unpackTime.WithLabelValues(packer).Observe(value.Seconds())
+}
+
+// VDRResolveTime records the time taken to resolve a DID of the given method.
+func (m *Metrics) VDRResolveTime(method string, value time.Duration) {
+	m.vdrResolveTime.WithLabelValues(method).Observe(value.Seconds())
+}
+
+// KMSOperation counts a KMS operation on a key of the given type.
+func (m *Metrics) KMSOperation(operation, keyType string) {
+	m.kmsOperations.WithLabelValues(operation, keyType).Inc()
+}
+
+// ProtocolStateTransition counts a transition between two states of the protocol.
+func (m *Metrics) ProtocolStateTransition(protocol, from, to string) {
+	m.stateTransitions.WithLabelValues(protocol, from, to).Inc()
+}
+
+// Describe sends the descriptors of all metrics to the channel, see prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect sends all metrics to the channel, see prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// Handler returns HTTP handler which serves all metrics in the exposition format negotiated with the scraper.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.inboundMessages, m.outboundMessages, m.packTime, m.unpackTime, m.vdrResolveTime, m.kmsOperations,
+		m.stateTransitions,
+	}
+}
+
+func (m *Metrics) newCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      name,
+		Help:      help,
+	}, labels)
+}
+
+func (m *Metrics) newHistogramVec(name, help string, labels ...string) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Name:      name,
+		Help:      help,
+		Buckets:   m.buckets,
+	}, labels)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("counters", func(t *testing.T) {
+		m := New()
+
+		m.InboundMessage("https://didcomm.org/trust_ping/1.0/ping", "http")
+		m.InboundMessage("https://didcomm.org/trust_ping/1.0/ping", "http")
+		m.OutboundMessage("https://didcomm.org/trust_ping/1.0/ping_response", "ws")
+		m.KMSOperation("create", "ED25519")
+		m.ProtocolStateTransition("didexchange", "null", "invited")
+
+		out := scrape(t, m)
+
+		require.Contains(t, out, "# HELP aries_didcomm_inbound_messages_total Number of inbound DIDComm messages.\n")
+		require.Contains(t, out, "# TYPE aries_didcomm_inbound_messages_total counter\n")
+		require.Contains(t, out, `aries_didcomm_inbound_messages_total{transport="http",`+
+			`type="https://didcomm.org/trust_ping/1.0/ping"} 2`+"\n")
+		require.Contains(t, out, `aries_didcomm_outbound_messages_total{transport="ws",`+
+			`type="https://didcomm.org/trust_ping/1.0/ping_response"} 1`+"\n")
+		require.Contains(t, out, `aries_kms_operations_total{key_type="ED25519",operation="create"} 1`+"\n")
+		require.Contains(t, out, `aries_protocol_state_transitions_total{from="null",protocol="didexchange",`+
+			`to="invited"} 1`+"\n")
+	})
+
+	t.Run("histograms", func(t *testing.T) {
+		m := New(WithBuckets(0.1, 1))
+
+		m.PackTime("authcrypt", 50*time.Millisecond)
+		m.PackTime("authcrypt", 500*time.Millisecond)
+		m.UnpackTime("anoncrypt", 2*time.Second)
+		m.VDRResolveTime("key", time.Millisecond)
+
+		out := scrape(t, m)
+
+		require.Contains(t, out, "# TYPE aries_packer_pack_seconds histogram\n")
+		require.Contains(t, out, `aries_packer_pack_seconds_bucket{packer="authcrypt",le="0.1"} 1`+"\n")
+		require.Contains(t, out, `aries_packer_pack_seconds_bucket{packer="authcrypt",le="1"} 2`+"\n")
+		require.Contains(t, out, `aries_packer_pack_seconds_bucket{packer="authcrypt",le="+Inf"} 2`+"\n")
+		require.Contains(t, out, `aries_packer_pack_seconds_sum{packer="authcrypt"} 0.55`+"\n")
+		require.Contains(t, out, `aries_packer_pack_seconds_count{packer="authcrypt"} 2`+"\n")
+		require.Contains(t, out, `aries_packer_unpack_seconds_bucket{packer="anoncrypt",le="1"} 0`+"\n")
+		require.Contains(t, out, `aries_packer_unpack_seconds_bucket{packer="anoncrypt",le="+Inf"} 1`+"\n")
+		require.Contains(t, out, `aries_vdr_resolve_seconds_count{method="key"} 1`+"\n")
+	})
+
+	t.Run("namespace and label escaping", func(t *testing.T) {
+		m := New(WithNamespace(""))
+
+		m.InboundMessage("a\"b\\c\nd", "http")
+
+		require.Contains(t, scrape(t, m), `didcomm_inbound_messages_total{transport="http",type="a\"b\\c\nd"} 1`)
+	})
+
+	t.Run("registered with another registry", func(t *testing.T) {
+		m := New()
+		m.KMSOperation("get", "unknown")
+
+		registry := prometheus.NewPedanticRegistry()
+		require.NoError(t, registry.Register(m))
+
+		count, err := testutil.GatherAndCount(registry, "aries_kms_operations_total")
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Equal(t, float64(1), testutil.ToFloat64(m.kmsOperations.WithLabelValues("get", "unknown")))
+	})
+}
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Header().Get("Content-Type"), "text/plain")
+
+	return rr.Body.String()
+}
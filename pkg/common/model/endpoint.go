@@ -128,6 +128,15 @@ func (s *Endpoint) RoutingKeys() ([]string, error) {
 	return nil, fmt.Errorf("endpoint RoutingKeys not found")
 }
 
+// DIDCommV2Endpoints returns all the DIDComm V2 endpoints of a service endpoint, eg. one per mediator the agent
+// is registered with. It returns an empty list for DIDComm V1 and DIDCore endpoints.
+func (s *Endpoint) DIDCommV2Endpoints() []DIDCommV2Endpoint {
+	endpoints := make([]DIDCommV2Endpoint, len(s.rawDIDCommV2))
+	copy(endpoints, s.rawDIDCommV2)
+
+	return endpoints
+}
+
 // Type return endpoint type.
 func (s *Endpoint) Type() EndpointType {
 	if len(s.rawDIDCommV2) > 0 {
@@ -29,6 +29,7 @@ func TestNewEndpoint(t *testing.T) {
 	ep := NewDIDCommV2Endpoint([]DIDCommV2Endpoint{{uri, accept, routingkeys}})
 	require.EqualValues(t, didCommV2Endpoint, ep)
 	require.Equal(t, DIDCommV2, ep.Type())
+	require.Equal(t, []DIDCommV2Endpoint{{uri, accept, routingkeys}}, ep.DIDCommV2Endpoints())
 
 	didCommV1Endpoint := Endpoint{
 		rawDIDCommV1: uri,
@@ -37,6 +38,7 @@ func TestNewEndpoint(t *testing.T) {
 	ep = NewDIDCommV1Endpoint(uri)
 	require.EqualValues(t, didCommV1Endpoint, ep)
 	require.Equal(t, DIDCommV1, ep.Type())
+	require.Empty(t, ep.DIDCommV2Endpoints())
 
 	didCoreEndpoint := Endpoint{
 		rawObj: []string{uri, "uri2"},
@@ -83,6 +83,12 @@ const (
 
 	// LegacyConnection error group for legacyconnection command errors.
 	LegacyConnection = 16000
+
+	// TrustPing error group for trust ping command errors.
+	TrustPing = 17000
+
+	// OpenID4CI error group for OpenID for Verifiable Credential Issuance command errors.
+	OpenID4CI = 18000
 )
 
 // Error is the  interface for representing an command error condition, with the nil value representing no error.
@@ -118,3 +124,8 @@ func (c *commandError) Code() Code {
 func (c *commandError) Type() Type {
 	return c.errType
 }
+
+// Unwrap returns the underlying error of this command error.
+func (c *commandError) Unwrap() error {
+	return c.error
+}
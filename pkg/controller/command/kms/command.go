@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -31,6 +32,14 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// ListKeysError is for failures while listing keys.
+	ListKeysError
+	// GetKeyInfoError is for failures while getting key information.
+	GetKeyInfoError
+	// SetKeyMetadataError is for failures while setting key metadata.
+	SetKeyMetadataError
+	// DeleteKeyError is for failures while deleting key.
+	DeleteKeyError
 )
 
 // constants for KMS commands.
@@ -39,14 +48,23 @@ const (
 	CommandName = "kms"
 
 	// command methods.
-	CreateKeySetCommandMethod = "CreateKeySet"
-	ImportKeyCommandMethod    = "ImportKey"
+	CreateKeySetCommandMethod   = "CreateKeySet"
+	ImportKeyCommandMethod      = "ImportKey"
+	ListKeysCommandMethod       = "ListKeys"
+	GetKeyInfoCommandMethod     = "GetKeyInfo"
+	SetKeyMetadataCommandMethod = "SetKeyMetadata"
+	DeleteKeyCommandMethod      = "DeleteKey"
 
 	// error messages.
 	errEmptyKeyType = "key type is mandatory"
 	errEmptyKeyID   = "key id is mandatory"
 )
 
+var (
+	errListingNotSupported  = errors.New("kms doesn't support listing keys")
+	errDeletionNotSupported = errors.New("kms doesn't support deleting keys")
+)
+
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
 type provider interface {
 	KMS() kms.KeyManager
@@ -75,6 +93,10 @@ func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateKeySetCommandMethod, o.CreateKeySet),
 		cmdutil.NewCommandHandler(CommandName, ImportKeyCommandMethod, o.ImportKey),
+		cmdutil.NewCommandHandler(CommandName, ListKeysCommandMethod, o.ListKeys),
+		cmdutil.NewCommandHandler(CommandName, GetKeyInfoCommandMethod, o.GetKeyInfo),
+		cmdutil.NewCommandHandler(CommandName, SetKeyMetadataCommandMethod, o.SetKeyMetadata),
+		cmdutil.NewCommandHandler(CommandName, DeleteKeyCommandMethod, o.DeleteKey),
 	}
 }
 
@@ -160,3 +182,126 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// ListKeys lists the keys of the KMS with their information.
+func (o *Command) ListKeys(rw io.Writer, _ io.Reader) command.Error {
+	lister, ok := o.ctx.KMS().(kms.KeyLister)
+	if !ok {
+		logutil.LogError(logger, CommandName, ListKeysCommandMethod, errListingNotSupported.Error())
+		return command.NewExecuteError(ListKeysError, errListingNotSupported)
+	}
+
+	keys, err := lister.List()
+	if err != nil {
+		logutil.LogError(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewExecuteError(ListKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, &ListKeysResponse{Keys: keys}, logger)
+
+	logutil.LogDebug(logger, CommandName, ListKeysCommandMethod, "success")
+
+	return nil
+}
+
+// GetKeyInfo gets the information of a key.
+func (o *Command) GetKeyInfo(rw io.Writer, req io.Reader) command.Error {
+	var request GetKeyInfoRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetKeyInfoCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, GetKeyInfoCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	lister, ok := o.ctx.KMS().(kms.KeyLister)
+	if !ok {
+		logutil.LogError(logger, CommandName, GetKeyInfoCommandMethod, errListingNotSupported.Error())
+		return command.NewExecuteError(GetKeyInfoError, errListingNotSupported)
+	}
+
+	info, err := lister.GetKeyInfo(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetKeyInfoCommandMethod, err.Error())
+		return command.NewExecuteError(GetKeyInfoError, err)
+	}
+
+	command.WriteNillableResponse(rw, &GetKeyInfoResponse{KeyInfo: *info}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetKeyInfoCommandMethod, "success")
+
+	return nil
+}
+
+// SetKeyMetadata replaces the user-defined metadata tags of a key.
+func (o *Command) SetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	var request SetKeyMetadataRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, SetKeyMetadataCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, SetKeyMetadataCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	lister, ok := o.ctx.KMS().(kms.KeyLister)
+	if !ok {
+		logutil.LogError(logger, CommandName, SetKeyMetadataCommandMethod, errListingNotSupported.Error())
+		return command.NewExecuteError(SetKeyMetadataError, errListingNotSupported)
+	}
+
+	err = lister.SetMetadata(request.KeyID, request.Metadata)
+	if err != nil {
+		logutil.LogError(logger, CommandName, SetKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(SetKeyMetadataError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, SetKeyMetadataCommandMethod, "success")
+
+	return nil
+}
+
+// DeleteKey securely deletes a key.
+func (o *Command) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	var request DeleteKeyRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, DeleteKeyCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, DeleteKeyCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	deleter, ok := o.ctx.KMS().(kms.KeyDeleter)
+	if !ok {
+		logutil.LogError(logger, CommandName, DeleteKeyCommandMethod, errDeletionNotSupported.Error())
+		return command.NewExecuteError(DeleteKeyError, errDeletionNotSupported)
+	}
+
+	err = deleter.Delete(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, DeleteKeyCommandMethod, err.Error())
+		return command.NewExecuteError(DeleteKeyError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, DeleteKeyCommandMethod, "success")
+
+	return nil
+}
@@ -34,7 +34,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 2, len(handlers))
+		require.Equal(t, 6, len(handlers))
 	})
 
 	t.Run("test new command - error from import key", func(t *testing.T) {
@@ -275,3 +275,242 @@ func TestImportKey(t *testing.T) {
 		require.Contains(t, err.Error(), "failed request decode")
 	})
 }
+
+func TestListKeys(t *testing.T) {
+	t.Run("test list keys - success", func(t *testing.T) {
+		keys := []kms.KeyInfo{
+			{KeyID: "keyID1", KeyType: kms.ED25519Type, Metadata: map[string]string{"label": "signing"}},
+			{KeyID: "keyID2", KeyType: kms.AES256GCMType},
+		}
+
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListValue: keys},
+		})
+		require.NotNil(t, cmd)
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.ListKeys(&getRW, nil)
+		require.NoError(t, cmdErr)
+
+		response := ListKeysResponse{}
+		err := json.NewDecoder(&getRW).Decode(&response)
+		require.NoError(t, err)
+
+		// verify response
+		require.Equal(t, keys, response.Keys)
+	})
+
+	t.Run("test list keys - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListErr: fmt.Errorf("error list keys")},
+		})
+		require.NotNil(t, cmd)
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.ListKeys(&getRW, nil)
+		require.Error(t, cmdErr)
+		require.Equal(t, ListKeysError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "error list keys")
+	})
+}
+
+func TestGetKeyInfo(t *testing.T) {
+	t.Run("test get key info - success", func(t *testing.T) {
+		info := &kms.KeyInfo{KeyID: "keyID", KeyType: kms.ED25519Type, Metadata: map[string]string{"label": "signing"}}
+
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetKeyInfoValue: info},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(GetKeyInfoRequest{KeyID: "keyID"})
+		require.NoError(t, err)
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetKeyInfo(&getRW, bytes.NewBuffer(reqBytes))
+		require.NoError(t, cmdErr)
+
+		response := GetKeyInfoResponse{}
+		err = json.NewDecoder(&getRW).Decode(&response)
+		require.NoError(t, err)
+
+		// verify response
+		require.Equal(t, *info, response.KeyInfo)
+	})
+
+	t.Run("test get key info - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetKeyInfoErr: fmt.Errorf("error get key info")},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(GetKeyInfoRequest{KeyID: "keyID"})
+		require.NoError(t, err)
+
+		var getRW bytes.Buffer
+		cmdErr := cmd.GetKeyInfo(&getRW, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetKeyInfoError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "error get key info")
+	})
+
+	t.Run("test get key info - error request decode", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err := cmd.GetKeyInfo(&b, bytes.NewBuffer(nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed request decode")
+	})
+
+	t.Run("test get key info - error key id is empty", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err := cmd.GetKeyInfo(&b, bytes.NewBufferString("{}"))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+		require.Contains(t, err.Error(), errEmptyKeyID)
+	})
+}
+
+func TestSetKeyMetadata(t *testing.T) {
+	t.Run("test set key metadata - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(SetKeyMetadataRequest{
+			KeyID:    "keyID",
+			Metadata: map[string]string{"label": "signing"},
+		})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SetKeyMetadata(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("test set key metadata - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{SetMetadataErr: fmt.Errorf("error set metadata")},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(SetKeyMetadataRequest{KeyID: "keyID"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.SetKeyMetadata(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, SetKeyMetadataError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "error set metadata")
+	})
+
+	t.Run("test set key metadata - error request decode", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err := cmd.SetKeyMetadata(&b, bytes.NewBuffer(nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed request decode")
+	})
+
+	t.Run("test set key metadata - error key id is empty", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err := cmd.SetKeyMetadata(&b, bytes.NewBufferString(`{"metadata":{"label":"signing"}}`))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+		require.Contains(t, err.Error(), errEmptyKeyID)
+	})
+}
+
+func TestDeleteKey(t *testing.T) {
+	t.Run("test delete key - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(DeleteKeyRequest{KeyID: "keyID"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DeleteKey(&b, bytes.NewBuffer(reqBytes))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("test delete key - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{DeleteErr: fmt.Errorf("error delete key")},
+		})
+		require.NotNil(t, cmd)
+
+		reqBytes, err := json.Marshal(DeleteKeyRequest{KeyID: "keyID"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.DeleteKey(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, DeleteKeyError, cmdErr.Code())
+		require.Contains(t, cmdErr.Error(), "error delete key")
+	})
+
+	t.Run("test delete key - error request decode", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err := cmd.DeleteKey(&b, bytes.NewBuffer(nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed request decode")
+	})
+
+	t.Run("test delete key - error key id is empty", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		var b bytes.Buffer
+		err := cmd.DeleteKey(&b, bytes.NewBufferString("{}"))
+		require.Error(t, err)
+		require.Equal(t, InvalidRequestErrorCode, err.Code())
+		require.Contains(t, err.Error(), errEmptyKeyID)
+	})
+}
+
+func TestKeyManagementNotSupported(t *testing.T) {
+	// the KMS implements kms.KeyManager only, without the optional kms.KeyLister and kms.KeyDeleter interfaces.
+	cmd := New(&mockprovider.Provider{
+		KMSValue: &struct{ kms.KeyManager }{&mockkms.KeyManager{}},
+	})
+	require.NotNil(t, cmd)
+
+	var b bytes.Buffer
+
+	cmdErr := cmd.ListKeys(&b, nil)
+	require.Error(t, cmdErr)
+	require.Equal(t, ListKeysError, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), errListingNotSupported.Error())
+
+	cmdErr = cmd.GetKeyInfo(&b, bytes.NewBufferString(`{"keyID":"keyID"}`))
+	require.Error(t, cmdErr)
+	require.Equal(t, GetKeyInfoError, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), errListingNotSupported.Error())
+
+	cmdErr = cmd.SetKeyMetadata(&b, bytes.NewBufferString(`{"keyID":"keyID","metadata":{"label":"signing"}}`))
+	require.Error(t, cmdErr)
+	require.Equal(t, SetKeyMetadataError, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), errListingNotSupported.Error())
+
+	cmdErr = cmd.DeleteKey(&b, bytes.NewBufferString(`{"keyID":"keyID"}`))
+	require.Error(t, cmdErr)
+	require.Equal(t, DeleteKeyError, cmdErr.Code())
+	require.Contains(t, cmdErr.Error(), errDeletionNotSupported.Error())
+}
@@ -6,6 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package kms
 
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
 // CreateKeySetRequest is model for createKeySey request.
 type CreateKeySetRequest struct {
 	KeyType string `json:"keyType,omitempty"`
@@ -30,3 +34,30 @@ type JSONWebKey struct {
 	Y   string `json:"y,omitempty"`
 	D   string `json:"d,omitempty"`
 }
+
+// ListKeysResponse for returning the keys of the KMS.
+type ListKeysResponse struct {
+	Keys []kms.KeyInfo `json:"keys"`
+}
+
+// GetKeyInfoRequest is model for getKeyInfo request.
+type GetKeyInfoRequest struct {
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// GetKeyInfoResponse for returning the information of a key.
+type GetKeyInfoResponse struct {
+	kms.KeyInfo
+}
+
+// SetKeyMetadataRequest is model for setKeyMetadata request.
+type SetKeyMetadataRequest struct {
+	KeyID string `json:"keyID,omitempty"`
+	// user-defined metadata tags replacing the current tags of the key
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// DeleteKeyRequest is model for deleteKey request.
+type DeleteKeyRequest struct {
+	KeyID string `json:"keyID,omitempty"`
+}
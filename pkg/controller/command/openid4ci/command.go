@@ -0,0 +1,214 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/command/openid4ci")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode is typically a code for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.OpenID4CI)
+
+	// CreateCredentialOfferErrorCode for errors while creating a credential offer.
+	CreateCredentialOfferErrorCode
+
+	// TokenErrorCode for errors while exchanging a pre-authorized code for an access token.
+	TokenErrorCode
+
+	// CredentialErrorCode for errors while issuing a credential.
+	CredentialErrorCode
+)
+
+// All command operations.
+const (
+	CommandName = "openid4ci"
+
+	// command methods.
+	CreateCredentialOfferMethod       = "CreateCredentialOffer"
+	IssuerMetadataMethod              = "IssuerMetadata"
+	AuthorizationServerMetadataMethod = "AuthorizationServerMetadata"
+	TokenMethod                       = "Token"
+	CredentialMethod                  = "Credential"
+
+	// log constants.
+	logSuccess = "success"
+)
+
+// provider contains dependencies for the OpenID4VCI issuer command and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdr.Registry
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	JSONLDDocumentLoader() ld.DocumentLoader
+}
+
+// Command contains operations of the OpenID4VCI credential issuer.
+type Command struct {
+	issuer *openid4ci.Issuer
+}
+
+// New returns new OpenID4VCI credential issuer command instance.
+func New(p provider, config *openid4ci.IssuerConfig) (*Command, error) {
+	issuer, err := openid4ci.NewIssuer(p, config)
+	if err != nil {
+		return nil, fmt.Errorf("create OpenID4VCI issuer: %w", err)
+	}
+
+	return &Command{issuer: issuer}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (o *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, CreateCredentialOfferMethod, o.CreateCredentialOffer),
+		cmdutil.NewCommandHandler(CommandName, IssuerMetadataMethod, o.IssuerMetadata),
+		cmdutil.NewCommandHandler(CommandName, AuthorizationServerMetadataMethod, o.AuthorizationServerMetadata),
+		cmdutil.NewCommandHandler(CommandName, TokenMethod, o.Token),
+		cmdutil.NewCommandHandler(CommandName, CredentialMethod, o.Credential),
+	}
+}
+
+// CreateCredentialOffer creates an offer of the credential using the pre-authorized code flow.
+func (o *Command) CreateCredentialOffer(rw io.Writer, req io.Reader) command.Error {
+	request := &CreateCredentialOfferRequest{}
+
+	err := json.NewDecoder(req).Decode(request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateCredentialOfferMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	var options []openid4ci.OfferOptions
+
+	if request.Format != "" {
+		options = append(options, openid4ci.WithCredentialFormat(request.Format))
+	}
+
+	if request.UserPIN != "" {
+		options = append(options, openid4ci.WithUserPIN(request.UserPIN))
+	}
+
+	offer, err := o.issuer.CreateCredentialOffer(request.Credential, options...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateCredentialOfferMethod, err.Error())
+
+		return command.NewExecuteError(CreateCredentialOfferErrorCode, err)
+	}
+
+	offerURI, err := offer.URI()
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CreateCredentialOfferMethod, err.Error())
+
+		return command.NewExecuteError(CreateCredentialOfferErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &CreateCredentialOfferResponse{Offer: offer, OfferURI: offerURI}, logger)
+
+	logutil.LogDebug(logger, CommandName, CreateCredentialOfferMethod, logSuccess)
+
+	return nil
+}
+
+// IssuerMetadata returns the credential issuer metadata.
+func (o *Command) IssuerMetadata(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, o.issuer.Metadata(), logger)
+
+	logutil.LogDebug(logger, CommandName, IssuerMetadataMethod, logSuccess)
+
+	return nil
+}
+
+// AuthorizationServerMetadata returns the metadata of the authorization server issuing access tokens.
+func (o *Command) AuthorizationServerMetadata(rw io.Writer, _ io.Reader) command.Error {
+	command.WriteNillableResponse(rw, o.issuer.AuthorizationServerMetadata(), logger)
+
+	logutil.LogDebug(logger, CommandName, AuthorizationServerMetadataMethod, logSuccess)
+
+	return nil
+}
+
+// Token exchanges the pre-authorized code of a credential offer for an access token.
+func (o *Command) Token(rw io.Writer, req io.Reader) command.Error {
+	request := &openid4ci.TokenRequest{}
+
+	err := json.NewDecoder(req).Decode(request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, TokenMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	response, err := o.issuer.Token(request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, TokenMethod, err.Error())
+
+		return newError(TokenErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, TokenMethod, logSuccess)
+
+	return nil
+}
+
+// Credential issues the offered credential to the wallet presenting the access token.
+func (o *Command) Credential(rw io.Writer, req io.Reader) command.Error {
+	request := &CredentialRequest{}
+
+	err := json.NewDecoder(req).Decode(request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CredentialMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	response, err := o.issuer.Credential(request.AccessToken, &request.CredentialRequest)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, CredentialMethod, err.Error())
+
+		return newError(CredentialErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, response, logger)
+
+	logutil.LogDebug(logger, CommandName, CredentialMethod, logSuccess)
+
+	return nil
+}
+
+// newError returns validation error for invalid OpenID4VCI requests and execute error otherwise.
+func newError(code command.Code, err error) command.Error {
+	var e *openid4ci.Error
+
+	if errors.As(err, &e) {
+		return command.NewValidationError(code, err)
+	}
+
+	return command.NewExecuteError(code, err)
+}
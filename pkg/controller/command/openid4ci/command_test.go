@@ -0,0 +1,285 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const (
+	issuerURL = "https://issuer.example.com/openid4ci"
+
+	sampleCredential = `{
+		"@context": [
+			"https://www.w3.org/2018/credentials/v1",
+			"https://www.w3.org/2018/credentials/examples/v1"
+		],
+		"id": "http://example.edu/credentials/1872",
+		"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+		"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+		"credentialSubject": {
+			"degree": {
+				"type": "BachelorDegree",
+				"university": "MIT"
+			}
+		}
+	}`
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test success", func(t *testing.T) {
+		cmd := newTestCommand(t)
+		require.NotNil(t, cmd)
+		require.Len(t, cmd.GetHandlers(), 5)
+	})
+
+	t.Run("test invalid config", func(t *testing.T) {
+		cmd, err := New(newMockProvider(t), &openid4ci.IssuerConfig{})
+		require.EqualError(t, err, "create OpenID4VCI issuer: credential issuer URL is mandatory")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Metadata(t *testing.T) {
+	cmd := newTestCommand(t)
+
+	var b bytes.Buffer
+
+	cmdErr := cmd.IssuerMetadata(&b, nil)
+	require.NoError(t, cmdErr)
+
+	metadata := &openid4ci.IssuerMetadata{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), metadata))
+	require.Equal(t, issuerURL, metadata.CredentialIssuer)
+	require.Equal(t, issuerURL+openid4ci.CredentialEndpointPath, metadata.CredentialEndpoint)
+
+	b.Reset()
+
+	cmdErr = cmd.AuthorizationServerMetadata(&b, nil)
+	require.NoError(t, cmdErr)
+
+	asMetadata := &openid4ci.AuthorizationServerMetadata{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), asMetadata))
+	require.Equal(t, issuerURL+openid4ci.TokenEndpointPath, asMetadata.TokenEndpoint)
+}
+
+func TestCommand_CreateCredentialOffer(t *testing.T) {
+	cmd := newTestCommand(t)
+
+	t.Run("test success", func(t *testing.T) {
+		request := &CreateCredentialOfferRequest{
+			Credential: json.RawMessage(sampleCredential),
+			Format:     openid4ci.LDPVCFormat,
+			UserPIN:    "1234",
+		}
+
+		response := &CreateCredentialOfferResponse{}
+		requireSuccess(t, cmd.CreateCredentialOffer, request, response)
+		require.Equal(t, issuerURL, response.Offer.CredentialIssuer)
+		require.Equal(t, openid4ci.LDPVCFormat, response.Offer.Credentials[0].Format)
+		require.True(t, response.Offer.Grants.PreAuthorizedCode.UserPINRequired)
+
+		offer, err := openid4ci.ParseCredentialOfferURI(response.OfferURI)
+		require.NoError(t, err)
+		require.Equal(t, response.Offer, offer)
+	})
+
+	t.Run("test invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.CreateCredentialOffer(&b, strings.NewReader("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("test unsupported format", func(t *testing.T) {
+		request := &CreateCredentialOfferRequest{
+			Credential: json.RawMessage(sampleCredential),
+			Format:     "mso_mdoc",
+		}
+
+		cmdErr := cmd.CreateCredentialOffer(&bytes.Buffer{}, toReader(t, request))
+		require.Error(t, cmdErr)
+		require.Equal(t, CreateCredentialOfferErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "unsupported credential format: mso_mdoc")
+	})
+}
+
+func TestCommand_TokenAndCredential(t *testing.T) {
+	cmd := newTestCommand(t)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderDID, holderKeyID := fingerprint.CreateDIDKey(pubKey)
+
+	offerResponse := &CreateCredentialOfferResponse{}
+	requireSuccess(t, cmd.CreateCredentialOffer,
+		&CreateCredentialOfferRequest{Credential: json.RawMessage(sampleCredential)}, offerResponse)
+
+	t.Run("test invalid requests", func(t *testing.T) {
+		cmdErr := cmd.Token(&bytes.Buffer{}, strings.NewReader("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmdErr = cmd.Credential(&bytes.Buffer{}, strings.NewReader("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("test invalid grant", func(t *testing.T) {
+		cmdErr := cmd.Token(&bytes.Buffer{}, toReader(t, &openid4ci.TokenRequest{
+			GrantType:         openid4ci.PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: "unknown",
+		}))
+		require.Error(t, cmdErr)
+		require.Equal(t, TokenErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), openid4ci.InvalidGrantError)
+	})
+
+	tokenResponse := &openid4ci.TokenResponse{}
+
+	t.Run("test token success", func(t *testing.T) {
+		requireSuccess(t, cmd.Token, &openid4ci.TokenRequest{
+			GrantType:         openid4ci.PreAuthorizedCodeGrantType,
+			PreAuthorizedCode: offerResponse.Offer.Grants.PreAuthorizedCode.PreAuthorizedCode,
+		}, tokenResponse)
+		require.NotEmpty(t, tokenResponse.AccessToken)
+		require.NotEmpty(t, tokenResponse.CNonce)
+	})
+
+	t.Run("test invalid token", func(t *testing.T) {
+		cmdErr := cmd.Credential(&bytes.Buffer{}, toReader(t, &CredentialRequest{
+			AccessToken:       "unknown",
+			CredentialRequest: openid4ci.CredentialRequest{Format: openid4ci.JWTVCJSONFormat},
+		}))
+		require.Error(t, cmdErr)
+		require.Equal(t, CredentialErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), openid4ci.InvalidTokenError)
+	})
+
+	t.Run("test credential success", func(t *testing.T) {
+		headers := jose.Headers{
+			jose.HeaderType:  openid4ci.ProofJWTType,
+			jose.HeaderKeyID: holderKeyID,
+		}
+
+		proofJWT, err := jwt.NewSigned(map[string]interface{}{
+			"iss":   holderDID,
+			"aud":   issuerURL,
+			"iat":   time.Now().Unix(),
+			"nonce": tokenResponse.CNonce,
+		}, headers, jwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		proof, err := proofJWT.Serialize(false)
+		require.NoError(t, err)
+
+		response := &openid4ci.CredentialResponse{}
+		requireSuccess(t, cmd.Credential, &CredentialRequest{
+			AccessToken: tokenResponse.AccessToken,
+			CredentialRequest: openid4ci.CredentialRequest{
+				Format: openid4ci.JWTVCJSONFormat,
+				Proof:  &openid4ci.Proof{ProofType: openid4ci.JWTProofType, JWT: proof},
+			},
+		}, response)
+		require.Equal(t, openid4ci.JWTVCJSONFormat, response.Format)
+		require.NotEmpty(t, response.Credential)
+	})
+}
+
+func requireSuccess(t *testing.T, fn command.Exec, request, response interface{}) {
+	t.Helper()
+
+	var b bytes.Buffer
+
+	cmdErr := fn(&b, toReader(t, request))
+	require.NoError(t, cmdErr)
+	require.NoError(t, json.Unmarshal(b.Bytes(), response))
+}
+
+func toReader(t *testing.T, v interface{}) *bytes.Reader {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return bytes.NewReader(b)
+}
+
+func newTestCommand(t *testing.T) *Command {
+	t.Helper()
+
+	p := newMockProvider(t)
+
+	kid, pubKey, err := p.KMSValue.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, verificationMethod := fingerprint.CreateDIDKey(pubKey)
+
+	cmd, err := New(p, &openid4ci.IssuerConfig{
+		URL:                issuerURL,
+		VerificationMethod: verificationMethod,
+		KeyID:              kid,
+	})
+	require.NoError(t, err)
+
+	return cmd
+}
+
+func newMockProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	kmsProvider, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	localKMS, err := localkms.New("local-lock://custom/master/key/", kmsProvider)
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	return &mockprovider.Provider{
+		StorageProviderValue: storage.NewMockStoreProvider(),
+		KMSValue:             localKMS,
+		CryptoValue:          tinkCrypto,
+		VDRegistryValue:      vdrpkg.New(vdrpkg.WithVDR(key.New())),
+		DocumentLoaderValue:  loader,
+	}
+}
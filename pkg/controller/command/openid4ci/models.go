@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+)
+
+// CreateCredentialOfferRequest is request model for creating a credential offer.
+type CreateCredentialOfferRequest struct {
+	// Credential to be issued, signed by the issuer once requested by the wallet.
+	Credential json.RawMessage `json:"credential"`
+
+	// Format of the offered credential, jwt_vc_json or ldp_vc.
+	// Optional, by default jwt_vc_json.
+	Format string `json:"format,omitempty"`
+
+	// UserPIN to be presented by the wallet together with the pre-authorized code.
+	// Optional, by default PIN is not required.
+	UserPIN string `json:"userPIN,omitempty"`
+}
+
+// CreateCredentialOfferResponse is response model of creating a credential offer.
+type CreateCredentialOfferResponse struct {
+	// Offer is the credential offer.
+	Offer *openid4ci.CredentialOffer `json:"offer"`
+
+	// OfferURI is the credential offer passed by value in openid-credential-offer URI.
+	OfferURI string `json:"offerURI"`
+}
+
+// CredentialRequest is request model for issuing a credential.
+type CredentialRequest struct {
+	openid4ci.CredentialRequest
+
+	// AccessToken issued by the token endpoint.
+	AccessToken string `json:"access_token"`
+}
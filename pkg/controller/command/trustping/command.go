@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	protocol "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+)
+
+var logger = log.New("aries-framework/command/trustping")
+
+// Error codes.
+const (
+	// InvalidRequestErrorCode for invalid requests.
+	InvalidRequestErrorCode = command.Code(iota + command.TrustPing)
+
+	// PingMissingConnIDCode for connection ID validation error.
+	PingMissingConnIDCode
+
+	// PingErrorCode for ping error.
+	PingErrorCode
+)
+
+// constant for the trust ping controller.
+const (
+	// command name.
+	CommandName = "trustping"
+
+	// command methods.
+	PingCommandMethod = "Ping"
+
+	// log constants.
+	connectionID  = "connectionID"
+	successString = "success"
+)
+
+// provider contains dependencies for the trust ping protocol and is typically created by using aries.Context().
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Command contains command operations provided by trust ping controller.
+type Command struct {
+	client *trustping.Client
+}
+
+// New returns new trust ping controller command instance.
+func New(ctx provider) (*Command, error) {
+	client, err := trustping.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping client : %w", err)
+	}
+
+	return &Command{client: client}, nil
+}
+
+// GetHandlers returns list of all commands supported by this controller command.
+func (c *Command) GetHandlers() []command.Handler {
+	return []command.Handler{
+		cmdutil.NewCommandHandler(CommandName, PingCommandMethod, c.Ping),
+	}
+}
+
+// Ping sends a trust ping over the given connection and returns round-trip latency.
+func (c *Command) Ping(rw io.Writer, req io.Reader) command.Error {
+	var request PingRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, PingCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.ConnectionID == "" {
+		logutil.LogDebug(logger, CommandName, PingCommandMethod, "missing connectionID",
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewValidationError(PingMissingConnIDCode, errors.New("connectionID is mandatory"))
+	}
+
+	opts := []protocol.PingOption{trustping.WithComment(request.Comment)}
+
+	if request.Timeout > 0 {
+		opts = append(opts, trustping.WithTimeout(request.Timeout))
+	}
+
+	latency, err := c.client.Ping(request.ConnectionID, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, PingCommandMethod, err.Error(),
+			logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+		return command.NewExecuteError(PingErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &PingResponse{Latency: latency}, logger)
+
+	logutil.LogDebug(logger, CommandName, PingCommandMethod, successString,
+		logutil.CreateKeyValueString(connectionID, request.ConnectionID))
+
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const (
+	sampleConnRequest            = `{"connectionID":"123-abc","comment":"hello","timeout":1000000000}`
+	sampleEmptyConnectionRequest = `{"connectionID":""}`
+	sampleErr                    = "sample-error"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command", func(t *testing.T) {
+		cmd, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{}))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+
+		handlers := cmd.GetHandlers()
+		require.Equal(t, 1, len(handlers))
+	})
+
+	t.Run("test new command - client creation fail", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping client")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestCommand_Ping(t *testing.T) {
+	t.Run("test ping - success", func(t *testing.T) {
+		cmd, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{
+			PingFunc: func(connectionID string, options ...trustping.PingOption) (time.Duration, error) {
+				require.Equal(t, "123-abc", connectionID)
+
+				opts := &trustping.PingOptions{}
+				for _, option := range options {
+					option(opts)
+				}
+
+				require.Equal(t, "hello", opts.Comment)
+				require.Equal(t, time.Second, opts.Timeout)
+
+				return time.Millisecond, nil
+			},
+		}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(sampleConnRequest))
+		require.NoError(t, cmdErr)
+
+		var response PingResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Equal(t, time.Millisecond, response.Latency)
+	})
+
+	t.Run("test ping - invalid request", func(t *testing.T) {
+		cmd, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString("--"))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("test ping - empty connectionID", func(t *testing.T) {
+		cmd, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(sampleEmptyConnectionRequest))
+		require.Error(t, cmdErr)
+		require.Equal(t, PingMissingConnIDCode, cmdErr.Code())
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "connectionID is mandatory")
+	})
+
+	t.Run("test ping - ping error", func(t *testing.T) {
+		cmd, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{
+			PingErr: errors.New(sampleErr),
+		}))
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		cmdErr := cmd.Ping(&b, bytes.NewBufferString(sampleConnRequest))
+		require.Error(t, cmdErr)
+		require.Equal(t, PingErrorCode, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), sampleErr)
+	})
+}
+
+func newMockProvider(svc *mocktrustping.MockTrustPingSvc) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			trustping.TrustPing: svc,
+		},
+	}
+}
@@ -0,0 +1,25 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "time"
+
+// PingRequest is request for sending a trust ping over a connection.
+type PingRequest struct {
+	// ConnectionID of the connection to be pinged.
+	ConnectionID string `json:"connectionID"`
+	// Comment to be sent along with the ping (DIDComm V1 only).
+	Comment string `json:"comment,omitempty"`
+	// Timeout waiting for the ping response.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// PingResponse is response of the trust ping.
+type PingResponse struct {
+	// Latency is round-trip time between sending the ping and receiving its response.
+	Latency time.Duration `json:"latency"`
+}
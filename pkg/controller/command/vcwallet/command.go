@@ -17,6 +17,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 
 	"github.com/hyperledger/aries-framework-go/component/storage/edv"
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
@@ -82,6 +83,15 @@ const (
 
 	// ResolveCredentialManifestErrorCode for errors while resolving credential manifest from wallet.
 	ResolveCredentialManifestErrorCode
+
+	// ExportWalletErrorCode for errors while exporting wallet contents.
+	ExportWalletErrorCode
+
+	// ImportWalletErrorCode for errors while importing wallet contents.
+	ImportWalletErrorCode
+
+	// AcceptCredentialOfferErrorCode for errors while accepting OpenID4VCI credential offer from wallet.
+	AcceptCredentialOfferErrorCode
 )
 
 // All command operations.
@@ -105,6 +115,9 @@ const (
 	DeriveMethod                    = "Derive"
 	CreateKeyPairMethod             = "CreateKeyPair"
 	ResolveCredentialManifestMethod = "ResolveCredentialManifest"
+	ExportMethod                    = "Export"
+	ImportMethod                    = "Import"
+	AcceptCredentialOfferMethod     = "AcceptCredentialOffer"
 )
 
 // miscellaneous constants for the vc wallet command controller.
@@ -209,6 +222,9 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, DeriveMethod, o.Derive),
 		cmdutil.NewCommandHandler(CommandName, CreateKeyPairMethod, o.CreateKeyPair),
 		cmdutil.NewCommandHandler(CommandName, ResolveCredentialManifestMethod, o.ResolveCredentialManifest),
+		cmdutil.NewCommandHandler(CommandName, ExportMethod, o.Export),
+		cmdutil.NewCommandHandler(CommandName, ImportMethod, o.Import),
+		cmdutil.NewCommandHandler(CommandName, AcceptCredentialOfferMethod, o.AcceptCredentialOffer),
 	}
 }
 
@@ -746,6 +762,134 @@ func (o *Command) ResolveCredentialManifest(rw io.Writer, req io.Reader) command
 	return nil
 }
 
+// Export produces a serialized exported wallet representation locked by given passphrase.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#export
+//
+// Writes locked wallet contents to writer or returns error if operation fails.
+//
+func (o *Command) Export(rw io.Writer, req io.Reader) command.Error {
+	request := &ExportWalletRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	contents, err := vcWallet.Export(request.Auth, request.Passphrase)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ExportMethod, err.Error())
+
+		return command.NewExecuteError(ExportWalletErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &ExportWalletResponse{Contents: contents}, logger)
+
+	logutil.LogDebug(logger, CommandName, ExportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// Import takes a serialized exported wallet representation locked by given passphrase
+// and imports all contents into wallet.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#import
+//
+// Returns error if operation fails.
+//
+func (o *Command) Import(rw io.Writer, req io.Reader) command.Error {
+	request := &ImportWalletRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	var importOpts []wallet.AddContentOptions
+
+	if o.config.ValidateDataModel {
+		importOpts = append(importOpts, wallet.ValidateContent())
+	}
+
+	err = vcWallet.Import(request.Auth, request.Passphrase, request.Contents, importOpts...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ImportMethod, err.Error())
+
+		return command.NewExecuteError(ImportWalletErrorCode, err)
+	}
+
+	logutil.LogDebug(logger, CommandName, ImportMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
+// AcceptCredentialOffer accepts OpenID4VCI credential offer using pre-authorized code flow and saves issued
+// credential into wallet.
+// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0-11.html
+//
+// Writes issued credential to writer or returns error if operation fails.
+//
+func (o *Command) AcceptCredentialOffer(rw io.Writer, req io.Reader) command.Error {
+	request := &AcceptCredentialOfferRequest{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptCredentialOfferMethod, err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	offer := request.Offer
+
+	if offer == nil && request.OfferURI != "" {
+		offer, err = openid4ci.ParseCredentialOfferURI(request.OfferURI)
+		if err != nil {
+			logutil.LogInfo(logger, CommandName, AcceptCredentialOfferMethod, err.Error())
+
+			return command.NewValidationError(InvalidRequestErrorCode, err)
+		}
+	}
+
+	vcWallet, err := wallet.New(request.UserID, o.ctx)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptCredentialOfferMethod, err.Error())
+
+		return command.NewExecuteError(AcceptCredentialOfferErrorCode, err)
+	}
+
+	vc, err := vcWallet.AcceptCredentialOffer(request.Auth, offer, prepareAcceptCredentialOfferOptions(request)...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, AcceptCredentialOfferMethod, err.Error())
+
+		return command.NewExecuteError(AcceptCredentialOfferErrorCode, err)
+	}
+
+	command.WriteNillableResponse(rw, &AcceptCredentialOfferResponse{Credential: vc}, logger)
+
+	logutil.LogDebug(logger, CommandName, AcceptCredentialOfferMethod, logSuccess,
+		logutil.CreateKeyValueString(logUserIDKey, request.UserID))
+
+	return nil
+}
+
 // prepareProfileOptions prepares options for creating wallet profile.
 func prepareProfileOptions(rqst *CreateOrUpdateProfileRequest) []wallet.ProfileOptions {
 	var options []wallet.ProfileOptions
@@ -933,3 +1077,21 @@ func prepareResolveManifestOption(rqst *ResolveCredentialManifestRequest) wallet
 
 	return nil
 }
+
+func prepareAcceptCredentialOfferOptions(rqst *AcceptCredentialOfferRequest) []wallet.AcceptCredentialOfferOptions {
+	var options []wallet.AcceptCredentialOfferOptions
+
+	if rqst.UserPIN != "" {
+		options = append(options, wallet.WithOfferUserPIN(rqst.UserPIN))
+	}
+
+	if rqst.ProofOptions != nil {
+		options = append(options, wallet.WithOfferProofOptions(rqst.ProofOptions))
+	}
+
+	if rqst.Collection != "" {
+		options = append(options, wallet.WithOfferCollection(rqst.Collection))
+	}
+
+	return options
+}
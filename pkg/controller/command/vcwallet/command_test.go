@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/internal/testdata"
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
@@ -66,7 +68,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetHandlers(), 16)
+		require.Len(t, cmd.GetHandlers(), 19)
 	})
 }
 
@@ -1769,6 +1771,140 @@ func TestCommand_CreateKeyPair(t *testing.T) {
 	})
 }
 
+func TestCommand_ExportImport(t *testing.T) {
+	const (
+		sampleUser1      = "sample-user-01"
+		sampleUser2      = "sample-user-02"
+		sampleExportPass = "sample-export-passphrase"
+	)
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser2,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token1, lock1 := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock1()
+
+	token2, lock2 := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser2,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock2()
+
+	addContent(t, mockctx, &AddContentRequest{
+		Content:     testdata.SampleWalletContentMetadata,
+		ContentType: wallet.Metadata,
+		WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token1},
+	})
+
+	var exported ExportWalletResponse
+
+	t.Run("successfully export and import wallet contents", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportWalletRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			Passphrase: sampleExportPass,
+		}))
+		require.NoError(t, cmdErr)
+
+		require.NoError(t, json.NewDecoder(&b).Decode(&exported))
+		require.NotEmpty(t, exported.Contents)
+
+		// remove exported content and import it back.
+		cmdErr = cmd.Remove(&b, getReader(t, &RemoveContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token1},
+			ContentType: wallet.Metadata,
+			ContentID:   "did:example:123456789abcdefghi",
+		}))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportWalletRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token1},
+			Passphrase: sampleExportPass,
+			Contents:   exported.Contents,
+		}))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.GetAll(&b, getReader(t, &GetAllContentRequest{
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token1},
+			ContentType: wallet.Metadata,
+		}))
+		require.NoError(t, cmdErr)
+
+		var response GetAllContentResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.Len(t, response.Contents, 1)
+	})
+
+	t.Run("export and import using invalid request", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.Import(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("export and import using invalid profile", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportWalletRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: sampleFakeTkn},
+			Passphrase: sampleExportPass,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "failed to get VC wallet profile")
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportWalletRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: sampleFakeTkn},
+			Passphrase: sampleExportPass,
+			Contents:   exported.Contents,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "failed to get VC wallet profile")
+		require.Empty(t, b.Bytes())
+	})
+
+	t.Run("export and import using invalid auth", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.Export(&b, getReader(t, &ExportWalletRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Passphrase: sampleExportPass,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ExportWalletErrorCode, "invalid auth token")
+		require.Empty(t, b.Bytes())
+
+		cmdErr = cmd.Import(&b, getReader(t, &ImportWalletRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser2, Auth: token2},
+			Passphrase: "invalid-passphrase",
+			Contents:   exported.Contents,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, ImportWalletErrorCode, "failed to decrypt wallet contents")
+		require.Empty(t, b.Bytes())
+	})
+}
+
 func TestCommand_ResolveCredentialManifest(t *testing.T) {
 	const sampleUser1 = "sample-user-r01"
 
@@ -1895,6 +2031,127 @@ func TestCommand_ResolveCredentialManifest(t *testing.T) {
 	})
 }
 
+func TestCommand_AcceptCredentialOffer(t *testing.T) {
+	const sampleUser1 = "sample-user-oc01"
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = getMockDIDKeyVDR()
+
+	createSampleUserProfile(t, mockctx, &CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	server := newMockOpenID4CIServer(t)
+	defer server.Close()
+
+	offer := &openid4ci.CredentialOffer{
+		CredentialIssuer: server.URL,
+		Credentials:      []*openid4ci.OfferedCredential{{Format: openid4ci.LDPVCFormat}},
+		Grants: &openid4ci.CredentialOfferGrant{
+			PreAuthorizedCode: &openid4ci.PreAuthorizedCodeGrant{PreAuthorizedCode: "sample-code"},
+		},
+	}
+
+	offerURI, err := offer.URI()
+	require.NoError(t, err)
+
+	t.Run("successfully accept credential offer", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		request := &AcceptCredentialOfferRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
+			OfferURI:   offerURI,
+		}
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptCredentialOffer(&b, getReader(t, request))
+		require.NoError(t, cmdErr)
+
+		var response AcceptCredentialOfferResponse
+		require.NoError(t, json.NewDecoder(&b).Decode(&response))
+		require.NotEmpty(t, response.Credential)
+
+		b.Reset()
+		cmdErr = cmd.Get(&b, getReader(t, &GetContentRequest{
+			ContentID:   response.Credential.ID,
+			ContentType: "credential",
+			WalletAuth:  WalletAuth{UserID: sampleUser1, Auth: token},
+		}))
+		require.NoError(t, cmdErr)
+	})
+
+	t.Run("failed to accept credential offer", func(t *testing.T) {
+		cmd := New(mockctx, &Config{})
+
+		var b bytes.Buffer
+		cmdErr := cmd.AcceptCredentialOffer(&b, bytes.NewBufferString("--"))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "invalid character")
+
+		cmdErr = cmd.AcceptCredentialOffer(&b, getReader(t, &AcceptCredentialOfferRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
+			OfferURI:   "openid-credential-offer://",
+		}))
+		validateError(t, cmdErr, command.ValidationError, InvalidRequestErrorCode, "no credential_offer parameter")
+
+		cmdErr = cmd.AcceptCredentialOffer(&b, getReader(t, &AcceptCredentialOfferRequest{
+			WalletAuth: WalletAuth{UserID: sampleUserID, Auth: token},
+			Offer:      offer,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, AcceptCredentialOfferErrorCode, "profile does not exist")
+
+		cmdErr = cmd.AcceptCredentialOffer(&b, getReader(t, &AcceptCredentialOfferRequest{
+			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: sampleFakeTkn},
+			Offer:      offer,
+		}))
+		validateError(t, cmdErr, command.ExecuteError, AcceptCredentialOfferErrorCode, "wallet locked")
+	})
+}
+
+// newMockOpenID4CIServer returns OpenID4VCI credential issuer server issuing a sample credential.
+func newMockOpenID4CIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	writeJSON := func(rw http.ResponseWriter, v interface{}) {
+		rw.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(rw).Encode(v))
+	}
+
+	mux.HandleFunc(openid4ci.IssuerMetadataPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeJSON(rw, &openid4ci.IssuerMetadata{
+			CredentialIssuer:   server.URL,
+			CredentialEndpoint: server.URL + openid4ci.CredentialEndpointPath,
+		})
+	})
+
+	mux.HandleFunc(openid4ci.AuthorizationServerMetadataPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeJSON(rw, &openid4ci.AuthorizationServerMetadata{
+			Issuer:        server.URL,
+			TokenEndpoint: server.URL + openid4ci.TokenEndpointPath,
+		})
+	})
+
+	mux.HandleFunc(openid4ci.TokenEndpointPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeJSON(rw, &openid4ci.TokenResponse{AccessToken: "sample-token", TokenType: openid4ci.BearerTokenType})
+	})
+
+	mux.HandleFunc(openid4ci.CredentialEndpointPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeJSON(rw, &openid4ci.CredentialResponse{Format: openid4ci.LDPVCFormat, Credential: testdata.SampleUDCVC})
+	})
+
+	return server
+}
+
 func createSampleUserProfile(t *testing.T, ctx *mockprovider.Provider, request *CreateOrUpdateProfileRequest) {
 	cmd := New(ctx, &Config{})
 	require.NotNil(t, cmd)
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -195,6 +196,31 @@ type GetAllContentResponse struct {
 	Contents map[string]json.RawMessage `json:"contents"`
 }
 
+// ExportWalletRequest is request model for exporting wallet contents.
+type ExportWalletRequest struct {
+	WalletAuth
+
+	// passphrase from which key for locking the exported wallet contents will be derived.
+	Passphrase string `json:"passphrase"`
+}
+
+// ExportWalletResponse is response model for exporting wallet contents.
+type ExportWalletResponse struct {
+	// locked wallet containing all exported wallet contents.
+	Contents json.RawMessage `json:"contents"`
+}
+
+// ImportWalletRequest is request model for importing wallet contents.
+type ImportWalletRequest struct {
+	WalletAuth
+
+	// passphrase used while exporting the wallet.
+	Passphrase string `json:"passphrase"`
+
+	// locked wallet contents to be imported.
+	Contents json.RawMessage `json:"contents"`
+}
+
 // ContentQueryRequest is request model for querying wallet contents.
 type ContentQueryRequest struct {
 	WalletAuth
@@ -338,3 +364,31 @@ type ResolveCredentialManifestResponse struct {
 	// List of Resolved Descriptor results.
 	Resolved []*cm.ResolvedDescriptor `json:"resolved,omitempty"`
 }
+
+// AcceptCredentialOfferRequest is request model for accepting OpenID4VCI credential offer from wallet.
+type AcceptCredentialOfferRequest struct {
+	WalletAuth
+
+	// Credential offer received from the credential issuer.
+	Offer *openid4ci.CredentialOffer `json:"offer,omitempty"`
+
+	// Credential offer passed by value in openid-credential-offer URI.
+	// Used only if 'offer' is not provided.
+	OfferURI string `json:"offerURI,omitempty"`
+
+	// User PIN, if required by the credential offer.
+	UserPIN string `json:"userPIN,omitempty"`
+
+	// Proof options for binding issued credential to wallet DID.
+	// Optional, by default proof of possession of wallet key is not sent to the credential issuer.
+	ProofOptions *wallet.ProofOptions `json:"proofOptions,omitempty"`
+
+	// ID of the collection to which issued credential will be saved.
+	Collection string `json:"collectionID,omitempty"`
+}
+
+// AcceptCredentialOfferResponse is response model from wallet accept credential offer operation.
+type AcceptCredentialOfferResponse struct {
+	// credential issued by the credential issuer.
+	Credential *verifiable.Credential `json:"credential"`
+}
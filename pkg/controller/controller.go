@@ -8,9 +8,12 @@ SPDX-License-Identifier: Apache-2.0
 package controller
 
 import (
+	stdcontext "context"
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/connection"
 	didcommwalletcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/didcommwallet"
@@ -22,9 +25,11 @@ import (
 	legacyconncmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/legacyconnection"
 	routercmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/mediator"
 	messagingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/messaging"
+	openid4cicmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/openid4ci"
 	outofbandcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofband"
 	outofbandv2cmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/outofbandv2"
 	presentproofcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/presentproof"
+	trustpingcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
 	vdrcmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
@@ -37,16 +42,21 @@ import (
 	legacyconnrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/legacyconnection"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/mediator"
 	messagingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/messaging"
+	openid4cirest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/openid4ci"
 	outofbandrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofband"
 	outofbandv2rest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/outofbandv2"
 	presentproofrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest/rfc0593"
+	trustpingrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/trustping"
 	vcwalletrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vcwallet"
 	vdrrest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/vdr"
 	verifiablerest "github.com/hyperledger/aries-framework-go/pkg/controller/rest/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/webnotifier"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	ldsvc "github.com/hyperledger/aries-framework-go/pkg/ld"
+	connectionstore "github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	verifiablestore "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 // HTTPClient represents an HTTP client.
@@ -64,9 +74,20 @@ type allOpts struct {
 	walletConf         *didcommwalletcmd.Config
 	httpClient         HTTPClient
 	ldService          ldsvc.Service
+	openID4CIConf      *openid4ci.IssuerConfig
 }
 
-const wsPath = "/ws"
+const (
+	wsPath = "/ws"
+
+	// The changes of connection, credential and presentation records are pushed on these topics only if the storage
+	// supports watching (see storage.Watchable), there is no polling fallback. Stores which don't support watching,
+	// eg. LevelDB, can be wrapped with watchablestore.NewProvider. Note that the watchable stores only stream the
+	// changes made through the same provider instance: changes made by other processes sharing the database, eg.
+	// other agent instances, are not pushed.
+	connectionRecordsTopic = "connection_records"
+	verifiableRecordsTopic = "verifiable_records"
+)
 
 // Opt represents a controller option.
 type Opt func(opts *allOpts)
@@ -134,6 +155,14 @@ func WithLDService(svc ldsvc.Service) Opt {
 	}
 }
 
+// WithOpenID4CIIssuer is an option for enabling OpenID4VCI credential issuer operations with given configuration.
+// Credential issuer operations are disabled by default.
+func WithOpenID4CIIssuer(conf *openid4ci.IssuerConfig) Opt {
+	return func(opts *allOpts) {
+		opts.openID4CIConf = conf
+	}
+}
+
 // GetRESTHandlers returns all REST handlers provided by controller.
 func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error) { // nolint: funlen,gocyclo
 	restAPIOpts := &allOpts{
@@ -241,6 +270,12 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 		return nil, fmt.Errorf("create connection rest command : %w", err)
 	}
 
+	// trust ping REST operation
+	trustPingOp, err := trustpingrest.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping rest command : %w", err)
+	}
+
 	// creat handlers from all operations
 	var allHandlers []rest.Handler
 	allHandlers = append(allHandlers, exchangeOp.GetRESTHandlers()...)
@@ -259,6 +294,22 @@ func GetRESTHandlers(ctx *context.Provider, opts ...Opt) ([]rest.Handler, error)
 	allHandlers = append(allHandlers, wallet.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, ldOp.GetRESTHandlers()...)
 	allHandlers = append(allHandlers, connOp.GetRESTHandlers()...)
+	allHandlers = append(allHandlers, trustPingOp.GetRESTHandlers()...)
+
+	if restAPIOpts.openID4CIConf != nil {
+		// OpenID4VCI credential issuer REST operation
+		openID4CIOp, e := openid4cirest.New(ctx, restAPIOpts.openID4CIConf)
+		if e != nil {
+			return nil, fmt.Errorf("create openid4ci rest command : %w", e)
+		}
+
+		allHandlers = append(allHandlers, openID4CIOp.GetRESTHandlers()...)
+	}
+
+	err = notifyRecordChanges(ctx, notifier)
+	if err != nil {
+		return nil, err
+	}
 
 	nhp, ok := notifier.(handlerProvider)
 	if ok {
@@ -366,6 +417,12 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 		return nil, fmt.Errorf("create connection command : %w", err)
 	}
 
+	// trust ping command operation
+	trustping, err := trustpingcmd.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create trust ping command : %w", err)
+	}
+
 	// vc wallet command controller
 	wallet := didcommwalletcmd.New(ctx, cmdOpts.walletConf)
 
@@ -386,8 +443,80 @@ func GetCommandHandlers(ctx *context.Provider, opts ...Opt) ([]command.Handler,
 	allHandlers = append(allHandlers, outofband.GetHandlers()...)
 	allHandlers = append(allHandlers, outofbandv2.GetHandlers()...)
 	allHandlers = append(allHandlers, conncmd.GetHandlers()...)
+	allHandlers = append(allHandlers, trustping.GetHandlers()...)
 	allHandlers = append(allHandlers, wallet.GetHandlers()...)
 	allHandlers = append(allHandlers, ldCmd.GetHandlers()...)
 
+	if cmdOpts.openID4CIConf != nil {
+		// OpenID4VCI credential issuer command operation
+		openID4CICmd, e := openid4cicmd.New(ctx, cmdOpts.openID4CIConf)
+		if e != nil {
+			return nil, fmt.Errorf("create openid4ci command : %w", e)
+		}
+
+		allHandlers = append(allHandlers, openID4CICmd.GetHandlers()...)
+	}
+
+	err = notifyRecordChanges(ctx, notifier)
+	if err != nil {
+		return nil, err
+	}
+
 	return allHandlers, nil
 }
+
+// notifyRecordChanges pushes the changes of connection, credential and presentation records to the subscribers of
+// the notifier, if the storage supports watching. Record changes of a framework are pushed to the notifier of the
+// first controller only, until the framework is closed. Contexts which aren't bound to a framework aren't watched.
+// Only the changes made through the storage provider of the framework in this process are pushed, nothing is pushed
+// if the storage doesn't support watching, see connectionRecordsTopic.
+func notifyRecordChanges(ctx *context.Provider, notifier command.Notifier) error {
+	done := ctx.Done()
+	if done == nil {
+		return nil
+	}
+
+	return ctx.StartRecordsWatch(func() error {
+		watchCtx, cancel := stdcontext.WithCancel(stdcontext.Background())
+
+		err := watchRecordChanges(watchCtx, ctx, notifier)
+		if err != nil {
+			cancel()
+
+			return err
+		}
+
+		go func() {
+			<-done
+			cancel()
+		}()
+
+		return nil
+	})
+}
+
+func watchRecordChanges(watchCtx stdcontext.Context, ctx *context.Provider, notifier command.Notifier) error {
+	obs := webnotifier.NewObserver(notifier)
+
+	lookup, err := connectionstore.NewLookup(ctx)
+	if err != nil {
+		return fmt.Errorf("create connection lookup : %w", err)
+	}
+
+	err = obs.WatchConnectionRecords(watchCtx, connectionRecordsTopic, lookup)
+	if err != nil && !errors.Is(err, storage.ErrWatchNotSupported) {
+		return fmt.Errorf("watch connection records : %w", err)
+	}
+
+	vcStore, err := verifiablestore.New(ctx)
+	if err != nil {
+		return fmt.Errorf("create verifiable store : %w", err)
+	}
+
+	err = obs.WatchVerifiableRecords(watchCtx, verifiableRecordsTopic, vcStore)
+	if err != nil && !errors.Is(err, storage.ErrWatchNotSupported) {
+		return fmt.Errorf("watch verifiable records : %w", err)
+	}
+
+	return nil
+}
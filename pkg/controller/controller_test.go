@@ -13,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didcommwallet"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/mocks/webhook"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
@@ -71,10 +72,32 @@ func TestGetCommandHandlers_Success(t *testing.T) {
 		handlers, err := GetCommandHandlers(ctx, WithMessageHandler(msghandler.NewMockMsgServiceProvider()),
 			WithAutoAccept(true), WithDefaultLabel("sample-label"),
 			WithWebhookURLs("sample-wh-url"), WithNotifier(webhook.NewMockWebhookNotifier()),
-			WithHTTPClient(http.DefaultClient), WithLDService(ld.New(ctx)))
+			WithHTTPClient(http.DefaultClient), WithLDService(ld.New(ctx)),
+			WithOpenID4CIIssuer(&openid4ci.IssuerConfig{
+				URL:                "https://issuer.example.com/openid4ci",
+				VerificationMethod: "did:example:123#key-1",
+			}))
 		require.NoError(t, err)
 		require.NotEmpty(t, handlers)
 	})
+
+	t.Run("With invalid OpenID4VCI issuer configuration", func(t *testing.T) {
+		framework, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+			strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+		require.NoError(t, err)
+		require.NotNil(t, framework)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+		require.NotNil(t, ctx)
+
+		handlers, err := GetCommandHandlers(ctx, WithOpenID4CIIssuer(&openid4ci.IssuerConfig{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create openid4ci command")
+		require.Nil(t, handlers)
+	})
 }
 
 func TestGetRESTHandlers_Success(t *testing.T) {
@@ -108,10 +131,75 @@ func TestGetRESTHandlers_Success(t *testing.T) {
 
 		handlers, err := GetRESTHandlers(ctx, WithMessageHandler(msghandler.NewMockMsgServiceProvider()),
 			WithAutoAccept(true), WithDefaultLabel("sample-label"), WithAutoExecuteRFC0593(true),
-			WithWebhookURLs("sample-wh-url"), WithHTTPClient(http.DefaultClient), WithLDService(ld.New(ctx)))
+			WithWebhookURLs("sample-wh-url"), WithHTTPClient(http.DefaultClient), WithLDService(ld.New(ctx)),
+			WithOpenID4CIIssuer(&openid4ci.IssuerConfig{
+				URL:                "https://issuer.example.com/openid4ci",
+				VerificationMethod: "did:example:123#key-1",
+			}))
 		require.NoError(t, err)
 		require.NotEmpty(t, handlers)
 	})
+
+	t.Run("with invalid OpenID4VCI issuer configuration", func(t *testing.T) {
+		framework, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+			strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+		require.NoError(t, err)
+		require.NotNil(t, framework)
+
+		defer func() { require.NoError(t, framework.Close()) }()
+
+		ctx, err := framework.Context()
+		require.NoError(t, err)
+		require.NotNil(t, ctx)
+
+		handlers, err := GetRESTHandlers(ctx, WithOpenID4CIIssuer(&openid4ci.IssuerConfig{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create openid4ci rest command")
+		require.Nil(t, handlers)
+	})
+}
+
+func TestRecordChangesWatchedOncePerFramework(t *testing.T) {
+	framework, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+		strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+	require.NoError(t, err)
+
+	ctx, err := framework.Context()
+	require.NoError(t, err)
+
+	_, err = GetCommandHandlers(ctx)
+	require.NoError(t, err)
+
+	// record changes are already pushed to the notifier of the command handlers
+	require.NoError(t, notifyRecordChanges(ctx, webhook.NewMockWebhookNotifier()))
+	require.NoError(t, ctx.StartRecordsWatch(func() error {
+		require.Fail(t, "record changes watched twice")
+
+		return nil
+	}))
+
+	// the contexts of another framework are watched separately
+	other, err := aries.New(defaults.WithInboundHTTPAddr(":"+
+		strconv.Itoa(transportutil.GetRandomPort(3)), "", "", ""))
+	require.NoError(t, err)
+
+	otherCtx, err := other.Context()
+	require.NoError(t, err)
+
+	started := false
+
+	require.NoError(t, otherCtx.StartRecordsWatch(func() error {
+		started = true
+
+		return nil
+	}))
+	require.True(t, started)
+
+	require.NoError(t, other.Close())
+	require.NoError(t, framework.Close())
+
+	// contexts which aren't bound to a framework aren't watched
+	require.NoError(t, notifyRecordChanges(&context.Provider{}, webhook.NewMockWebhookNotifier()))
 }
 
 func TestWithWebhookNotifierOption(t *testing.T) {
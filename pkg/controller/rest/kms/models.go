@@ -43,3 +43,70 @@ type importKeyReq struct {
 	// in: body
 	kms.JSONWebKey
 }
+
+// listKeysRes model
+//
+// This is used for returning the keys with their information
+//
+// swagger:response listKeysRes
+type listKeysRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ListKeysResponse
+}
+
+// getKeyInfoReq model
+//
+// This is used for getting key information
+//
+// swagger:parameters getKeyInfoReq
+type getKeyInfoReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+}
+
+// getKeyInfoRes model
+//
+// This is used for returning the key information
+//
+// swagger:response getKeyInfoRes
+type getKeyInfoRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.GetKeyInfoResponse
+}
+
+// setKeyMetadataReq model
+//
+// This is used for setting key metadata tags
+//
+// swagger:parameters setKeyMetadataReq
+type setKeyMetadataReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+
+	// in: body
+	Params struct {
+		// user-defined metadata tags replacing the current tags of the key
+		Metadata map[string]string `json:"metadata,omitempty"`
+	}
+}
+
+// deleteKeyReq model
+//
+// This is used for deleting key
+//
+// swagger:parameters deleteKeyReq
+type deleteKeyReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+}
@@ -7,9 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package kms
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
@@ -22,6 +27,9 @@ const (
 	KmsOperationID   = "/kms"
 	CreateKeySetPath = KmsOperationID + "/keyset"
 	ImportKeyPath    = KmsOperationID + "/import"
+	KeysPath         = KmsOperationID + "/keys"
+	KeyPath          = KeysPath + "/{keyID}"
+	KeyMetadataPath  = KeyPath + "/metadata"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -32,6 +40,10 @@ type provider interface {
 type kmsCommand interface {
 	CreateKeySet(rw io.Writer, req io.Reader) command.Error
 	ImportKey(rw io.Writer, req io.Reader) command.Error
+	ListKeys(rw io.Writer, req io.Reader) command.Error
+	GetKeyInfo(rw io.Writer, req io.Reader) command.Error
+	SetKeyMetadata(rw io.Writer, req io.Reader) command.Error
+	DeleteKey(rw io.Writer, req io.Reader) command.Error
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -60,6 +72,10 @@ func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
+		cmdutil.NewHTTPHandler(KeysPath, http.MethodGet, o.ListKeys),
+		cmdutil.NewHTTPHandler(KeyPath, http.MethodGet, o.GetKeyInfo),
+		cmdutil.NewHTTPHandler(KeyMetadataPath, http.MethodPut, o.SetKeyMetadata),
+		cmdutil.NewHTTPHandler(KeyPath, http.MethodDelete, o.DeleteKey),
 	}
 }
 
@@ -83,3 +99,68 @@ func (o *Operation) CreateKeySet(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ImportKey(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ImportKey, rw, req.Body)
 }
+
+// ListKeys swagger:route GET /kms/keys kms listKeys
+//
+// List keys with their information.
+//
+// Responses:
+//    default: genericError
+//        200: listKeysRes
+func (o *Operation) ListKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.ListKeys, rw, req.Body)
+}
+
+// GetKeyInfo swagger:route GET /kms/keys/{keyID} kms getKeyInfoReq
+//
+// Get key information.
+//
+// Responses:
+//    default: genericError
+//        200: getKeyInfoRes
+func (o *Operation) GetKeyInfo(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.GetKeyInfo, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"keyID":%q
+	}`, mux.Vars(req)["keyID"])))
+}
+
+// SetKeyMetadata swagger:route PUT /kms/keys/{keyID}/metadata kms setKeyMetadataReq
+//
+// Set key metadata tags.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) SetKeyMetadata(rw http.ResponseWriter, req *http.Request) {
+	var request cmdkms.SetKeyMetadataRequest
+
+	err := json.NewDecoder(req.Body).Decode(&request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdkms.InvalidRequestErrorCode,
+			fmt.Errorf("failed request decode : %w", err))
+
+		return
+	}
+
+	request.KeyID = mux.Vars(req)["keyID"]
+
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, cmdkms.SetKeyMetadataError, err)
+
+		return
+	}
+
+	rest.Execute(o.command.SetKeyMetadata, rw, bytes.NewBuffer(reqBytes))
+}
+
+// DeleteKey swagger:route DELETE /kms/keys/{keyID} kms deleteKeyReq
+//
+// Delete key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DeleteKey(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.DeleteKey, rw, bytes.NewBufferString(fmt.Sprintf(`{
+		"keyID":%q
+	}`, mux.Vars(req)["keyID"])))
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 )
@@ -31,7 +32,7 @@ func TestNew(t *testing.T) {
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
-		require.Equal(t, 2, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 6, len(cmd.GetRESTHandlers()))
 	})
 }
 
@@ -42,7 +43,7 @@ func TestCreateKeySet(t *testing.T) {
 		})
 		cmd.command = &mockKMSCommand{}
 
-		handler := lookupHandler(t, cmd, CreateKeySetPath)
+		handler := lookupHandler(t, cmd, CreateKeySetPath, http.MethodPost)
 		err := getSuccessResponseFromHandler(handler, CreateKeySetPath)
 		require.NoError(t, err)
 	})
@@ -53,7 +54,7 @@ func TestCreateKeySet(t *testing.T) {
 		})
 		require.NotNil(t, cmd)
 
-		handler := lookupHandler(t, cmd, CreateKeySetPath)
+		handler := lookupHandler(t, cmd, CreateKeySetPath, http.MethodPost)
 
 		req := createKeySetReq{CreateKeySetRequest: kms.CreateKeySetRequest{
 			KeyType: "ED25519",
@@ -75,7 +76,7 @@ func TestImportKey(t *testing.T) {
 		cmd := New(&mockprovider.Provider{})
 		cmd.command = &mockKMSCommand{}
 
-		handler := lookupHandler(t, cmd, ImportKeyPath)
+		handler := lookupHandler(t, cmd, ImportKeyPath, http.MethodPost)
 		err := getSuccessResponseFromHandler(handler, ImportKeyPath)
 		require.NoError(t, err)
 	})
@@ -87,7 +88,7 @@ func TestImportKey(t *testing.T) {
 		cmd.command = &mockKMSCommand{importKeyError: command.NewExecuteError(kms.ImportKeyError,
 			fmt.Errorf("failed to import key"))}
 
-		handler := lookupHandler(t, cmd, ImportKeyPath)
+		handler := lookupHandler(t, cmd, ImportKeyPath, http.MethodPost)
 
 		req := importKeyReq{JSONWebKey: kms.JSONWebKey{Kid: "k1"}}
 		reqBytes, err := json.Marshal(req)
@@ -102,14 +103,168 @@ func TestImportKey(t *testing.T) {
 	})
 }
 
-func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
+func TestListKeys(t *testing.T) {
+	t.Run("test list keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListValue: []kmsapi.KeyInfo{{KeyID: "keyID", KeyType: kmsapi.ED25519Type}}},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeysPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		response := listKeysRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Len(t, response.Keys, 1)
+		require.Equal(t, "keyID", response.Keys[0].KeyID)
+	})
+
+	t.Run("test list keys - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListErr: fmt.Errorf("error list keys")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeysPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath)
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.ListKeysError, "error list keys", buf.Bytes())
+	})
+}
+
+func TestGetKeyInfo(t *testing.T) {
+	t.Run("test get key info - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetKeyInfoValue: &kmsapi.KeyInfo{KeyID: "keyID", KeyType: kmsapi.ED25519Type}},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeyPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"/keyID")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		response := getKeyInfoRes{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, "keyID", response.KeyID)
+		require.Equal(t, kmsapi.ED25519Type, response.KeyType)
+	})
+
+	t.Run("test get key info - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetKeyInfoErr: fmt.Errorf("error get key info")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeyPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"/keyID")
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.GetKeyInfoError, "error get key info", buf.Bytes())
+	})
+}
+
+func TestSetKeyMetadata(t *testing.T) {
+	t.Run("test set key metadata - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		mockCmd := &mockKMSCommand{}
+		cmd.command = mockCmd
+
+		handler := lookupHandler(t, cmd, KeyMetadataPath, http.MethodPut)
+
+		_, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"metadata":{"label":"signing"}}`),
+			KeysPath+"/keyID/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		require.Equal(t, kms.SetKeyMetadataRequest{
+			KeyID:    "keyID",
+			Metadata: map[string]string{"label": "signing"},
+		}, mockCmd.setKeyMetadataRequest)
+	})
+
+	t.Run("test set key metadata - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{SetMetadataErr: fmt.Errorf("error set metadata")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeyMetadataPath, http.MethodPut)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"metadata":{"label":"signing"}}`),
+			KeysPath+"/keyID/metadata")
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.SetKeyMetadataError, "error set metadata", buf.Bytes())
+	})
+
+	t.Run("test set key metadata - error request decode", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeyMetadataPath, http.MethodPut)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString("{"), KeysPath+"/keyID/metadata")
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.InvalidRequestErrorCode, "failed request decode", buf.Bytes())
+	})
+}
+
+func TestDeleteKey(t *testing.T) {
+	t.Run("test delete key - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeyPath, http.MethodDelete)
+
+		_, code, err := sendRequestToHandler(handler, nil, KeysPath+"/keyID")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("test delete key - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{DeleteErr: fmt.Errorf("error delete key")},
+		})
+		require.NotNil(t, cmd)
+
+		handler := lookupHandler(t, cmd, KeyPath, http.MethodDelete)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"/keyID")
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.DeleteKeyError, "error delete key", buf.Bytes())
+	})
+}
+
+func lookupHandler(t *testing.T, op *Operation, path, method string) rest.Handler {
 	t.Helper()
 
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
 
 	for _, h := range handlers {
-		if h.Path() == path && h.Method() == http.MethodPost {
+		if h.Path() == path && h.Method() == method {
 			return h
 		}
 	}
@@ -175,7 +330,8 @@ func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, da
 }
 
 type mockKMSCommand struct {
-	importKeyError command.Error
+	importKeyError        command.Error
+	setKeyMetadataRequest kms.SetKeyMetadataRequest
 }
 
 func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
@@ -185,3 +341,23 @@ func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error
 func (m *mockKMSCommand) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	return m.importKeyError
 }
+
+func (m *mockKMSCommand) ListKeys(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) GetKeyInfo(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) SetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	if err := json.NewDecoder(req).Decode(&m.setKeyMetadataRequest); err != nil {
+		return command.NewValidationError(kms.InvalidRequestErrorCode, err)
+	}
+
+	return nil
+}
+
+func (m *mockKMSCommand) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
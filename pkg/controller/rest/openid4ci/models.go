@@ -0,0 +1,110 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+	openid4cicmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/openid4ci"
+)
+
+// createCredentialOfferReq model
+//
+// swagger:parameters createCredentialOfferReq
+type createCredentialOfferReq struct { // nolint: unused,deadcode
+	// in: body
+	Params openid4cicmd.CreateCredentialOfferRequest
+}
+
+// createCredentialOfferResp model
+//
+// swagger:response createCredentialOfferResp
+type createCredentialOfferResp struct { // nolint: unused,deadcode
+	// in: body
+	openid4cicmd.CreateCredentialOfferResponse
+}
+
+// issuerMetadataReq model
+//
+// swagger:parameters issuerMetadataReq
+type issuerMetadataReq struct{} // nolint: unused,deadcode
+
+// issuerMetadataResp model
+//
+// swagger:response issuerMetadataResp
+type issuerMetadataResp struct { // nolint: unused,deadcode
+	// in: body
+	openid4ci.IssuerMetadata
+}
+
+// authorizationServerMetadataReq model
+//
+// swagger:parameters authorizationServerMetadataReq
+type authorizationServerMetadataReq struct{} // nolint: unused,deadcode
+
+// authorizationServerMetadataResp model
+//
+// swagger:response authorizationServerMetadataResp
+type authorizationServerMetadataResp struct { // nolint: unused,deadcode
+	// in: body
+	openid4ci.AuthorizationServerMetadata
+}
+
+// tokenReq model
+//
+// swagger:parameters tokenReq
+type tokenReq struct { // nolint: unused,deadcode
+	// Grant type, must be urn:ietf:params:oauth:grant-type:pre-authorized_code.
+	// in: formData
+	// required: true
+	GrantType string `json:"grant_type"`
+
+	// Pre-authorized code from the credential offer.
+	// in: formData
+	// required: true
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+
+	// User PIN, if required by the credential offer.
+	// in: formData
+	UserPIN string `json:"user_pin"`
+}
+
+// tokenResp model
+//
+// swagger:response tokenResp
+type tokenResp struct { // nolint: unused,deadcode
+	// in: body
+	openid4ci.TokenResponse
+}
+
+// credentialReq model
+//
+// swagger:parameters credentialReq
+type credentialReq struct { // nolint: unused,deadcode
+	// Bearer access token issued by the token endpoint.
+	// in: header
+	// required: true
+	Authorization string `json:"Authorization"`
+
+	// in: body
+	Params openid4ci.CredentialRequest
+}
+
+// credentialResp model
+//
+// swagger:response credentialResp
+type credentialResp struct { // nolint: unused,deadcode
+	// in: body
+	openid4ci.CredentialResponse
+}
+
+// oauthError model of OAuth 2.0 error response of token and credential endpoints.
+//
+// swagger:response oauthError
+type oauthError struct { // nolint: unused,deadcode
+	// in: body
+	openid4ci.Error
+}
@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	openid4cicmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/openid4ci"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/internal/cmdutil"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var logger = log.New("aries-framework/rest/openid4ci")
+
+// constants for the OpenID4VCI issuer operations.
+const (
+	OperationID                     = "/openid4ci"
+	CreateCredentialOfferPath       = OperationID + "/offer"
+	IssuerMetadataPath              = OperationID + openid4ci.IssuerMetadataPath
+	AuthorizationServerMetadataPath = OperationID + openid4ci.AuthorizationServerMetadataPath
+	TokenPath                       = OperationID + openid4ci.TokenEndpointPath
+	CredentialPath                  = OperationID + openid4ci.CredentialEndpointPath
+
+	bearerPrefix = "Bearer "
+)
+
+// provider contains dependencies for the OpenID4VCI issuer and is typically created by using aries.Context().
+type provider interface {
+	StorageProvider() storage.Provider
+	VDRegistry() vdr.Registry
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	JSONLDDocumentLoader() ld.DocumentLoader
+}
+
+// Operation contains REST operations of the OpenID4VCI credential issuer.
+//
+// The credential issuer URL in the config is expected to point to OperationID path of this controller, since
+// metadata, token and credential endpoints are resolved by wallets relative to the credential issuer URL.
+type Operation struct {
+	handlers []rest.Handler
+	command  *openid4cicmd.Command
+}
+
+// New returns new OpenID4VCI credential issuer REST controller.
+func New(p provider, config *openid4ci.IssuerConfig) (*Operation, error) {
+	cmd, err := openid4cicmd.New(p, config)
+	if err != nil {
+		return nil, fmt.Errorf("openid4ci new: %w", err)
+	}
+
+	o := &Operation{command: cmd}
+	o.registerHandler()
+
+	return o, nil
+}
+
+// GetRESTHandlers get all controller API handler available for this service.
+func (o *Operation) GetRESTHandlers() []rest.Handler {
+	return o.handlers
+}
+
+// registerHandler register handlers to be exposed from this protocol service as REST API endpoints.
+func (o *Operation) registerHandler() {
+	o.handlers = []rest.Handler{
+		cmdutil.NewHTTPHandler(CreateCredentialOfferPath, http.MethodPost, o.CreateCredentialOffer),
+		cmdutil.NewHTTPHandler(IssuerMetadataPath, http.MethodGet, o.IssuerMetadata),
+		cmdutil.NewHTTPHandler(AuthorizationServerMetadataPath, http.MethodGet, o.AuthorizationServerMetadata),
+		cmdutil.NewHTTPHandler(TokenPath, http.MethodPost, o.Token),
+		cmdutil.NewHTTPHandler(CredentialPath, http.MethodPost, o.Credential),
+	}
+}
+
+// CreateCredentialOffer swagger:route POST /openid4ci/offer openid4ci createCredentialOfferReq
+//
+// Creates an offer of the credential using the pre-authorized code flow.
+//
+// Responses:
+//    default: genericError
+//        200: createCredentialOfferResp
+func (o *Operation) CreateCredentialOffer(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.CreateCredentialOffer, rw, req.Body)
+}
+
+// IssuerMetadata swagger:route GET /openid4ci/.well-known/openid-credential-issuer openid4ci issuerMetadataReq
+//
+// Returns the credential issuer metadata.
+//
+// Responses:
+//    default: genericError
+//        200: issuerMetadataResp
+func (o *Operation) IssuerMetadata(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.IssuerMetadata, rw, req.Body)
+}
+
+// AuthorizationServerMetadata swagger:route GET /openid4ci/.well-known/oauth-authorization-server openid4ci authorizationServerMetadataReq
+//
+// Returns the metadata of the authorization server issuing access tokens.
+//
+// Responses:
+//    default: genericError
+//        200: authorizationServerMetadataResp
+func (o *Operation) AuthorizationServerMetadata(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.AuthorizationServerMetadata, rw, req.Body)
+}
+
+// Token swagger:route POST /openid4ci/token openid4ci tokenReq
+//
+// Exchanges the pre-authorized code of a credential offer for an access token.
+//
+// Responses:
+//    default: oauthError
+//        200: tokenResp
+func (o *Operation) Token(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		sendOAuthError(rw, http.StatusBadRequest, openid4ci.InvalidRequestError, err.Error())
+
+		return
+	}
+
+	request, err := json.Marshal(&openid4ci.TokenRequest{
+		GrantType:         req.PostForm.Get("grant_type"),
+		PreAuthorizedCode: req.PostForm.Get("pre-authorized_code"),
+		UserPIN:           req.PostForm.Get("user_pin"),
+	})
+	if err != nil {
+		sendOAuthError(rw, http.StatusInternalServerError, openid4ci.ServerError, err.Error())
+
+		return
+	}
+
+	execute(o.command.Token, rw, bytes.NewReader(request))
+}
+
+// Credential swagger:route POST /openid4ci/credential openid4ci credentialReq
+//
+// Issues the offered credential to the wallet presenting the access token in Authorization header.
+//
+// Responses:
+//    default: oauthError
+//        200: credentialResp
+func (o *Operation) Credential(rw http.ResponseWriter, req *http.Request) {
+	authorization := req.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		sendOAuthError(rw, http.StatusUnauthorized, openid4ci.InvalidTokenError, "bearer access token is required")
+
+		return
+	}
+
+	request := &openid4cicmd.CredentialRequest{AccessToken: strings.TrimPrefix(authorization, bearerPrefix)}
+
+	err := json.NewDecoder(req.Body).Decode(&request.CredentialRequest)
+	if err != nil {
+		sendOAuthError(rw, http.StatusBadRequest, openid4ci.InvalidRequestError, err.Error())
+
+		return
+	}
+
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		sendOAuthError(rw, http.StatusInternalServerError, openid4ci.ServerError, err.Error())
+
+		return
+	}
+
+	execute(o.command.Credential, rw, bytes.NewReader(requestBytes))
+}
+
+// execute executes the command and sends command errors in OAuth 2.0 error response format.
+func execute(exec command.Exec, rw http.ResponseWriter, req *bytes.Reader) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	cmdErr := exec(rw, req)
+	if cmdErr == nil {
+		return
+	}
+
+	var e *openid4ci.Error
+
+	if !errors.As(cmdErr, &e) {
+		sendOAuthError(rw, http.StatusInternalServerError, openid4ci.ServerError, cmdErr.Error())
+
+		return
+	}
+
+	switch e.Code {
+	case openid4ci.InvalidTokenError:
+		sendOAuthError(rw, http.StatusUnauthorized, e.Code, e.Description)
+	case openid4ci.ServerError:
+		sendOAuthError(rw, http.StatusInternalServerError, e.Code, e.Description)
+	default:
+		sendOAuthError(rw, http.StatusBadRequest, e.Code, e.Description)
+	}
+}
+
+func sendOAuthError(rw http.ResponseWriter, status int, code, description string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(status)
+
+	err := json.NewEncoder(rw).Encode(&openid4ci.Error{Code: code, Description: description})
+	if err != nil {
+		logger.Errorf("Unable to send error response, %s", err)
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4ci
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+	openid4cicmd "github.com/hyperledger/aries-framework-go/pkg/controller/command/openid4ci"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const sampleCredential = `{
+	"@context": [
+		"https://www.w3.org/2018/credentials/v1",
+		"https://www.w3.org/2018/credentials/examples/v1"
+	],
+	"id": "http://example.edu/credentials/1872",
+	"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+	"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+	"issuanceDate": "2010-01-01T19:23:24Z",
+	"credentialSubject": {
+		"degree": {
+			"type": "BachelorDegree",
+			"university": "MIT"
+		}
+	}
+}`
+
+func TestNew(t *testing.T) {
+	t.Run("test success", func(t *testing.T) {
+		op, err := New(newMockProvider(t), &openid4ci.IssuerConfig{
+			URL:                "https://issuer.example.com" + OperationID,
+			VerificationMethod: "did:example:123#key-1",
+		})
+		require.NoError(t, err)
+		require.Len(t, op.GetRESTHandlers(), 5)
+	})
+
+	t.Run("test invalid config", func(t *testing.T) {
+		op, err := New(newMockProvider(t), &openid4ci.IssuerConfig{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "openid4ci new")
+		require.Nil(t, op)
+	})
+}
+
+func TestOperation_PreAuthorizedCodeFlow(t *testing.T) {
+	server, p := newTestServer(t)
+	defer server.Close()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderDID, holderKeyID := fingerprint.CreateDIDKey(pubKey)
+
+	client := openid4ci.NewClient(openid4ci.WithHTTPClient(server.Client()))
+
+	for _, format := range []string{openid4ci.JWTVCJSONFormat, openid4ci.LDPVCFormat} {
+		t.Run("test request "+format+" credential", func(t *testing.T) {
+			offer := createOffer(t, server, &openid4cicmd.CreateCredentialOfferRequest{
+				Credential: json.RawMessage(sampleCredential),
+				Format:     format,
+				UserPIN:    "1234",
+			})
+
+			response, err := client.RequestCredential(offer, openid4ci.WithPIN("1234"),
+				openid4ci.WithProofSigner(jwt.NewEd25519Signer(privKey), holderKeyID))
+			require.NoError(t, err)
+			require.Equal(t, format, response.Format)
+
+			vc, err := verifiable.ParseCredential(response.Credential,
+				verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(p.VDRegistryValue).PublicKeyFetcher()),
+				verifiable.WithJSONLDDocumentLoader(p.DocumentLoaderValue))
+			require.NoError(t, err)
+			require.Equal(t, holderDID, vc.Subject.([]verifiable.Subject)[0].ID)
+		})
+	}
+
+	t.Run("test OAuth errors", func(t *testing.T) {
+		offer := createOffer(t, server, &openid4cicmd.CreateCredentialOfferRequest{
+			Credential: json.RawMessage(sampleCredential),
+			UserPIN:    "1234",
+		})
+
+		_, err := client.RequestCredential(offer, openid4ci.WithPIN("0000"))
+		require.Error(t, err)
+
+		var e *openid4ci.Error
+
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, openid4ci.InvalidGrantError, e.Code)
+
+		resp, err := server.Client().PostForm(server.URL+TokenPath, url.Values{"grant_type": {"unknown"}})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		resp, err = server.Client().Post(server.URL+CredentialPath, "application/json", strings.NewReader("{}"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		e = &openid4ci.Error{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(e))
+		require.Equal(t, openid4ci.InvalidTokenError, e.Code)
+		require.NoError(t, resp.Body.Close())
+
+		req, err := http.NewRequest(http.MethodPost, server.URL+CredentialPath, strings.NewReader("{}"))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", bearerPrefix+"unknown")
+
+		resp, err = server.Client().Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		req, err = http.NewRequest(http.MethodPost, server.URL+CredentialPath, strings.NewReader("--"))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", bearerPrefix+"unknown")
+
+		resp, err = server.Client().Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("test create offer error", func(t *testing.T) {
+		resp, err := server.Client().Post(server.URL+CreateCredentialOfferPath, "application/json",
+			strings.NewReader("--"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
+}
+
+func createOffer(t *testing.T, server *httptest.Server,
+	request *openid4cicmd.CreateCredentialOfferRequest) *openid4ci.CredentialOffer {
+	t.Helper()
+
+	requestBytes, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	resp, err := server.Client().Post(server.URL+CreateCredentialOfferPath, "application/json",
+		bytes.NewReader(requestBytes))
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	response := &openid4cicmd.CreateCredentialOfferResponse{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(response))
+
+	offer, err := openid4ci.ParseCredentialOfferURI(response.OfferURI)
+	require.NoError(t, err)
+
+	return offer
+}
+
+// newTestServer returns a server exposing REST handlers of the OpenID4VCI credential issuer.
+func newTestServer(t *testing.T) (*httptest.Server, *mockprovider.Provider) {
+	t.Helper()
+
+	router := mux.NewRouter()
+	server := httptest.NewServer(router)
+
+	p := newMockProvider(t)
+
+	kid, pubKey, err := p.KMSValue.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, verificationMethod := fingerprint.CreateDIDKey(pubKey)
+
+	op, err := New(p, &openid4ci.IssuerConfig{
+		URL:                server.URL + OperationID,
+		VerificationMethod: verificationMethod,
+		KeyID:              kid,
+	})
+	require.NoError(t, err)
+
+	for _, h := range op.GetRESTHandlers() {
+		router.HandleFunc(h.Path(), h.Handle()).Methods(h.Method())
+	}
+
+	return server, p
+}
+
+func newMockProvider(t *testing.T) *mockprovider.Provider {
+	t.Helper()
+
+	kmsProvider, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	localKMS, err := localkms.New("local-lock://custom/master/key/", kmsProvider)
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	return &mockprovider.Provider{
+		StorageProviderValue: storage.NewMockStoreProvider(),
+		KMSValue:             localKMS,
+		CryptoValue:          tinkCrypto,
+		VDRegistryValue:      vdrpkg.New(vdrpkg.WithVDR(key.New())),
+		DocumentLoaderValue:  loader,
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+
+// pingRequest model
+//
+// This is used for sending a trust ping over given connection.
+//
+// swagger:parameters pingRequest
+type pingRequest struct { // nolint: unused,deadcode
+	// Params for sending a trust ping over given connection.
+	//
+	// in: body
+	Params trustping.PingRequest
+}
+
+// pingResponse model
+//
+// Response containing round-trip latency of the trust ping.
+//
+// swagger:response pingResponse
+type pingResponse struct {
+	// Round-trip latency of the trust ping.
+	//
+	// in: body
+	Params trustping.PingResponse
+}
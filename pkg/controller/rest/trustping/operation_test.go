@@ -0,0 +1,188 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command/trustping"
+	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
+	trustpingSvc "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+const (
+	connIDRequest = `{"connectionID":"abc-123"}`
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new command", func(t *testing.T) {
+		cmd, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{}))
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+	})
+
+	t.Run("test new command - command creation fail", func(t *testing.T) {
+		cmd, err := New(&mockprovider.Provider{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create trust ping command")
+		require.Nil(t, cmd)
+	})
+}
+
+func TestOperation_GetRESTHandlers(t *testing.T) {
+	svc, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{}))
+	require.NoError(t, err)
+	require.NotNil(t, svc)
+
+	handlers := svc.GetRESTHandlers()
+	require.Equal(t, len(handlers), 1)
+}
+
+func TestOperation_Ping(t *testing.T) {
+	t.Run("test ping - success", func(t *testing.T) {
+		svc, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{
+			PingFunc: func(connectionID string, options ...trustpingSvc.PingOption) (time.Duration, error) {
+				return time.Millisecond, nil
+			},
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, PingPath)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer([]byte(connIDRequest)), handler.Path())
+		require.NoError(t, err)
+
+		response := pingResponse{}
+		err = json.Unmarshal(buf.Bytes(), &response.Params)
+		require.NoError(t, err)
+		require.Equal(t, time.Millisecond, response.Params.Latency)
+	})
+
+	t.Run("test ping - missing connectionID", func(t *testing.T) {
+		svc, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{}))
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, PingPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer([]byte(`{}`)), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, trustping.PingMissingConnIDCode, "connectionID is mandatory", buf.Bytes())
+	})
+
+	t.Run("test ping - ping error", func(t *testing.T) {
+		svc, err := New(newMockProvider(&mocktrustping.MockTrustPingSvc{
+			PingErr: errors.New("ping error"),
+		}))
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		handler := lookupHandler(t, svc, PingPath)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBuffer([]byte(connIDRequest)), handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, trustping.PingErrorCode, "ping error", buf.Bytes())
+	})
+}
+
+func newMockProvider(svc *mocktrustping.MockTrustPingSvc) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			trustpingSvc.TrustPing: svc,
+		},
+	}
+}
+
+func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
+	t.Helper()
+
+	handlers := op.GetRESTHandlers()
+	require.NotEmpty(t, handlers)
+
+	for _, h := range handlers {
+		if h.Path() == path {
+			return h
+		}
+	}
+
+	require.Fail(t, "unable to find handler")
+
+	return nil
+}
+
+// getSuccessResponseFromHandler reads response from given http handle func.
+// expects http status OK.
+func getSuccessResponseFromHandler(handler rest.Handler, requestBody io.Reader,
+	path string) (*bytes.Buffer, error) {
+	response, status, err := sendRequestToHandler(handler, requestBody, path)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: got %v, want %v",
+			status, http.StatusOK)
+	}
+
+	return response, err
+}
+
+// sendRequestToHandler reads response from given http handle func.
+func sendRequestToHandler(handler rest.Handler, requestBody io.Reader, path string) (*bytes.Buffer, int, error) {
+	// prepare request
+	req, err := http.NewRequest(handler.Method(), path, requestBody)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// prepare router
+	router := mux.NewRouter()
+
+	router.HandleFunc(handler.Path(), handler.Handle()).Methods(handler.Method())
+
+	// create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
+	rr := httptest.NewRecorder()
+
+	// serve http on given response and request
+	router.ServeHTTP(rr, req)
+
+	return rr.Body, rr.Code, nil
+}
+
+func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, data []byte) {
+	t.Helper()
+
+	// Parser generic error response
+	errResponse := struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	err := json.Unmarshal(data, &errResponse)
+	require.NoError(t, err)
+
+	// verify response
+	require.EqualValues(t, expectedCode, errResponse.Code)
+	require.NotEmpty(t, errResponse.Message)
+
+	if expectedMsg != "" {
+		require.Contains(t, errResponse.Message, expectedMsg)
+	}
+}
@@ -391,3 +391,53 @@ type resolveCredentialManifestResponse struct {
 	// in: body
 	Response *vcwallet.ResolveCredentialManifestResponse `json:"response"`
 }
+
+// exportRequest is request model for exporting wallet contents.
+//
+// swagger:parameters exportReq
+type exportRequest struct { // nolint: unused,deadcode
+	// Params for exporting wallet contents.
+	//
+	// in: body
+	Params *vcwallet.ExportWalletRequest
+}
+
+// exportResponse is response model for exporting wallet contents.
+//
+// swagger:response exportRes
+type exportResponse struct {
+	// Response containing locked wallet contents.
+	//
+	// in: body
+	Response *vcwallet.ExportWalletResponse `json:"response"`
+}
+
+// importRequest is request model for importing wallet contents.
+//
+// swagger:parameters importReq
+type importRequest struct { // nolint: unused,deadcode
+	// Params for importing wallet contents.
+	//
+	// in: body
+	Params *vcwallet.ImportWalletRequest
+}
+
+// acceptCredentialOfferRequest is request model for accepting OpenID4VCI credential offer from wallet.
+//
+// swagger:parameters acceptCredOfferReq
+type acceptCredentialOfferRequest struct { // nolint: unused,deadcode
+	// Params for accepting OpenID4VCI credential offer from wallet.
+	//
+	// in: body
+	Params *vcwallet.AcceptCredentialOfferRequest
+}
+
+// acceptCredentialOfferResponse is response model for accepting OpenID4VCI credential offer from wallet.
+//
+// swagger:response acceptCredOfferRes
+type acceptCredentialOfferResponse struct { // nolint: unused,deadcode
+	// Response containing credential issued by the credential issuer.
+	//
+	// in: body
+	Response *vcwallet.AcceptCredentialOfferResponse `json:"response"`
+}
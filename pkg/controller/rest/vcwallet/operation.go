@@ -50,6 +50,9 @@ const (
 	ProposeCredentialPath         = OperationID + "/propose-credential"
 	RequestCredentialPath         = OperationID + "/request-credential"
 	ResolveCredentialManifestPath = OperationID + "/resolve-credential-manifest"
+	ExportPath                    = OperationID + "/export"
+	ImportPath                    = OperationID + "/import"
+	AcceptCredentialOfferPath     = OperationID + "/accept-credential-offer"
 )
 
 // provider contains dependencies for the verifiable credential wallet command controller
@@ -120,6 +123,9 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(ProposeCredentialPath, http.MethodPost, o.ProposeCredential),
 		cmdutil.NewHTTPHandler(RequestCredentialPath, http.MethodPost, o.RequestCredential),
 		cmdutil.NewHTTPHandler(ResolveCredentialManifestPath, http.MethodPost, o.ResolveCredentialManifest),
+		cmdutil.NewHTTPHandler(ExportPath, http.MethodPost, o.Export),
+		cmdutil.NewHTTPHandler(ImportPath, http.MethodPost, o.Import),
+		cmdutil.NewHTTPHandler(AcceptCredentialOfferPath, http.MethodPost, o.AcceptCredentialOffer),
 	}
 }
 
@@ -432,6 +438,42 @@ func (o *Operation) ResolveCredentialManifest(rw http.ResponseWriter, req *http.
 	rest.Execute(o.command.ResolveCredentialManifest, rw, req.Body)
 }
 
+// Export swagger:route POST /vcwallet/export vcwallet exportReq
+//
+// Exports all wallet contents as a locked wallet, encrypted by key derived from given passphrase.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#export
+//
+// Responses:
+//    default: genericError
+//        200: exportRes
+func (o *Operation) Export(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Export, rw, req.Body)
+}
+
+// Import swagger:route POST /vcwallet/import vcwallet importReq
+//
+// Imports all contents of a locked wallet into wallet by using passphrase used while exporting.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#import
+//
+// Responses:
+//    default: genericError
+//        200: emptyRes
+func (o *Operation) Import(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.Import, rw, req.Body)
+}
+
+// AcceptCredentialOffer swagger:route POST /vcwallet/accept-credential-offer vcwallet acceptCredOfferReq
+//
+// Accepts OpenID4VCI credential offer using pre-authorized code flow and saves issued credential into wallet.
+// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0-11.html
+//
+// Responses:
+//    default: genericError
+//        200: acceptCredOfferRes
+func (o *Operation) AcceptCredentialOffer(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.AcceptCredentialOffer, rw, req.Body)
+}
+
 // getIDFromRequest returns ID from request.
 func getIDFromRequest(rw http.ResponseWriter, req *http.Request) (string, bool) {
 	id := mux.Vars(req)["id"]
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/internal/testdata"
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	outofbandClient "github.com/hyperledger/aries-framework-go/pkg/client/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/didcommwallet"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command/vcwallet"
@@ -75,7 +76,7 @@ func TestNew(t *testing.T) {
 		cmd := New(newMockProvider(t), &vcwallet.Config{})
 		require.NotNil(t, cmd)
 
-		require.Len(t, cmd.GetRESTHandlers(), 21)
+		require.Len(t, cmd.GetRESTHandlers(), 24)
 	})
 }
 
@@ -1809,6 +1810,63 @@ func TestOperation_ResolveCredentialManifest(t *testing.T) {
 	})
 }
 
+func TestOperation_AcceptCredentialOffer(t *testing.T) {
+	const sampleUser1 = "sample-user-oc01"
+
+	mockctx := newMockProvider(t)
+
+	createSampleUserProfile(t, mockctx, &vcwallet.CreateOrUpdateProfileRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	token, lock := unlockWallet(t, mockctx, &vcwallet.UnlockWalletRequest{
+		UserID:             sampleUser1,
+		LocalKMSPassphrase: samplePassPhrase,
+	})
+
+	defer lock()
+
+	t.Run("accept credential offer invalid request", func(t *testing.T) {
+		request := &vcwallet.AcceptCredentialOfferRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token},
+			OfferURI:   "openid-credential-offer://",
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, AcceptCredentialOfferPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.AcceptCredentialOffer(rw, rq)
+		require.Equal(t, rw.Code, http.StatusBadRequest)
+		require.Contains(t, rw.Body.String(), "credential offer URI has no credential_offer parameter")
+	})
+
+	t.Run("accept credential offer failure", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		request := &vcwallet.AcceptCredentialOfferRequest{
+			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token},
+			Offer: &openid4ci.CredentialOffer{
+				CredentialIssuer: server.URL,
+				Credentials:      []*openid4ci.OfferedCredential{{Format: openid4ci.JWTVCJSONFormat}},
+				Grants: &openid4ci.CredentialOfferGrant{
+					PreAuthorizedCode: &openid4ci.PreAuthorizedCodeGrant{PreAuthorizedCode: "sample-code"},
+				},
+			},
+		}
+
+		rq := httptest.NewRequest(http.MethodPost, AcceptCredentialOfferPath, getReader(t, request))
+		rw := httptest.NewRecorder()
+
+		cmd := New(mockctx, &vcwallet.Config{})
+		cmd.AcceptCredentialOffer(rw, rq)
+		require.Equal(t, rw.Code, http.StatusInternalServerError)
+		require.Contains(t, rw.Body.String(), "failed to request credential")
+	})
+}
+
 func createSampleUserProfile(t *testing.T, ctx *mockprovider.Provider, request *vcwallet.CreateOrUpdateProfileRequest) {
 	cmd := New(ctx, &vcwallet.Config{})
 	require.NotNil(t, cmd)
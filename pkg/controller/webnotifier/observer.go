@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package webnotifier
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
 const (
@@ -50,6 +54,49 @@ func (o *Observer) RegisterStateMsg(topic string, ch <-chan service.StateMsg) {
 	}()
 }
 
+type connectionRecordsWatcher interface {
+	WatchConnectionRecords(ctx context.Context) (<-chan *connection.RecordEvent, error)
+}
+
+type verifiableRecordsWatcher interface {
+	WatchRecords(ctx context.Context) (<-chan *verifiable.RecordEvent, error)
+}
+
+// WatchConnectionRecords registers the changes of connection records to observer events until ctx is done.
+// An error wrapping storage.ErrWatchNotSupported is returned if the underlying storage doesn't support it.
+func (o *Observer) WatchConnectionRecords(ctx context.Context, topic string, w connectionRecordsWatcher) error {
+	ch, err := w.WatchConnectionRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("watch connection records: %w", err)
+	}
+
+	go func() {
+		for event := range ch {
+			o.notify(topic, event)
+		}
+	}()
+
+	return nil
+}
+
+// WatchVerifiableRecords registers the changes of credential and presentation records to observer events until
+// ctx is done.
+// An error wrapping storage.ErrWatchNotSupported is returned if the underlying storage doesn't support it.
+func (o *Observer) WatchVerifiableRecords(ctx context.Context, topic string, w verifiableRecordsWatcher) error {
+	ch, err := w.WatchRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("watch verifiable records: %w", err)
+	}
+
+	go func() {
+		for event := range ch {
+			o.notify(topic, event)
+		}
+	}()
+
+	return nil
+}
+
 func (o *Observer) notify(topic string, v interface{}) {
 	src, err := json.Marshal(v)
 	if err != nil {
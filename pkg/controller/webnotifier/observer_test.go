@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package webnotifier
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -15,6 +16,9 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 func TestObserver_RegisterAction(t *testing.T) {
@@ -89,6 +93,103 @@ func TestObserver_RegisterStateMsg(t *testing.T) {
 	<-done
 }
 
+func TestObserver_WatchConnectionRecords(t *testing.T) {
+	const topic = "test"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payload := &connection.RecordEvent{ConnectionID: "connID", Removed: true}
+
+	src, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	notifier := mocks.NewMockNotifier(ctrl)
+	notifier.EXPECT().Notify(topic, src).Do(func(string, []byte) {
+		close(done)
+	})
+
+	obs := NewObserver(notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = obs.WatchConnectionRecords(ctx, topic, connectionRecordsWatcherFunc(
+		func(watchCtx context.Context) (<-chan *connection.RecordEvent, error) {
+			require.Equal(t, ctx, watchCtx)
+
+			events := make(chan *connection.RecordEvent, 1)
+			events <- payload
+
+			return events, nil
+		}))
+	require.NoError(t, err)
+
+	<-done
+
+	err = obs.WatchConnectionRecords(ctx, topic, connectionRecordsWatcherFunc(
+		func(context.Context) (<-chan *connection.RecordEvent, error) {
+			return nil, storage.ErrWatchNotSupported
+		}))
+	require.ErrorIs(t, err, storage.ErrWatchNotSupported)
+}
+
+func TestObserver_WatchVerifiableRecords(t *testing.T) {
+	const topic = "test"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payload := &verifiable.RecordEvent{Record: &verifiable.Record{Name: "name", ID: "id"}}
+
+	src, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	notifier := mocks.NewMockNotifier(ctrl)
+	notifier.EXPECT().Notify(topic, src).Do(func(string, []byte) {
+		close(done)
+	})
+
+	obs := NewObserver(notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = obs.WatchVerifiableRecords(ctx, topic, verifiableRecordsWatcherFunc(
+		func(watchCtx context.Context) (<-chan *verifiable.RecordEvent, error) {
+			require.Equal(t, ctx, watchCtx)
+
+			events := make(chan *verifiable.RecordEvent, 1)
+			events <- payload
+
+			return events, nil
+		}))
+	require.NoError(t, err)
+
+	<-done
+
+	err = obs.WatchVerifiableRecords(ctx, topic, verifiableRecordsWatcherFunc(
+		func(context.Context) (<-chan *verifiable.RecordEvent, error) {
+			return nil, storage.ErrWatchNotSupported
+		}))
+	require.ErrorIs(t, err, storage.ErrWatchNotSupported)
+}
+
+type connectionRecordsWatcherFunc func(context.Context) (<-chan *connection.RecordEvent, error)
+
+func (f connectionRecordsWatcherFunc) WatchConnectionRecords(
+	ctx context.Context) (<-chan *connection.RecordEvent, error) {
+	return f(ctx)
+}
+
+type verifiableRecordsWatcherFunc func(context.Context) (<-chan *verifiable.RecordEvent, error)
+
+func (f verifiableRecordsWatcherFunc) WatchRecords(ctx context.Context) (<-chan *verifiable.RecordEvent, error) {
+	return f(ctx)
+}
+
 type properties map[string]interface{}
 
 func (p properties) All() map[string]interface{} {
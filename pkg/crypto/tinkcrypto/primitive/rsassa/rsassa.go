@@ -0,0 +1,54 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rsassa provides the Tink key managers of the RS256 (RSASSA-PKCS1-v1_5 with SHA-256) and PS256 (RSASSA-PSS
+// with SHA-256) signatures, which Tink doesn't support in Go.
+//
+// Keys are serialized as Tink's RsaSsaPkcs1 and RsaSsaPss protos under aries type URLs. The key managers don't
+// generate keys, RSA keys are imported in a KMS (see localkms.ImportPrivateKey), then used with signature.NewSigner()
+// and signature.NewVerifier() like other Tink signature keys.
+package rsassa
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+var (
+	registerOnce sync.Once
+	errRegister  error
+)
+
+// Register registers the RS256 and PS256 signer and verifier key managers in the Tink registry. It must be called
+// before using RSA keyset handles with signature.NewSigner() or signature.NewVerifier(). Calling it more than once
+// is safe, the registration is only done once and its result is returned by every call.
+func Register() error {
+	registerOnce.Do(func() {
+		errRegister = registerKeyManagers()
+	})
+
+	return errRegister
+}
+
+func registerKeyManagers() error {
+	// TODO - avoid the tink registry singleton.
+	keyManagers := []registry.KeyManager{
+		newPKCS1SignerKeyManager(),
+		newPKCS1VerifierKeyManager(),
+		newPSSSignerKeyManager(),
+		newPSSVerifierKeyManager(),
+	}
+
+	for _, km := range keyManagers {
+		if err := registry.RegisterKeyManager(km); err != nil {
+			return fmt.Errorf("rsassa: failed to register key manager %s: %w", km.TypeURL(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsassa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	rsassapkcs1pb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+)
+
+const (
+	pkcs1KeyVersion         = 0
+	pkcs1SignerKeyTypeURL   = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPkcs1PrivateKey"
+	pkcs1VerifierKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPkcs1PublicKey"
+)
+
+// common errors.
+var (
+	errInvalidPKCS1SignerKey   = errors.New("rsassa_pkcs1_signer_key_manager: invalid key")
+	errInvalidPKCS1VerifierKey = errors.New("rsassa_pkcs1_verifier_key_manager: invalid key")
+)
+
+// pkcs1SignerKeyManager is an implementation of PrivateKeyManager interface for RS256 signatures.
+// It doesn't support key generation.
+type pkcs1SignerKeyManager struct{}
+
+// newPKCS1SignerKeyManager creates a new pkcs1SignerKeyManager.
+func newPKCS1SignerKeyManager() *pkcs1SignerKeyManager {
+	return new(pkcs1SignerKeyManager)
+}
+
+// Primitive creates an RS256 Signer for the given serialized RsaSsaPkcs1PrivateKey proto.
+func (km *pkcs1SignerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPKCS1SignerKey
+	}
+
+	key := new(rsassapkcs1pb.RsaSsaPkcs1PrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidPKCS1SignerKey
+	}
+
+	err = keyset.ValidateKeyVersion(key.Version, pkcs1KeyVersion)
+	if err != nil || key.PublicKey == nil {
+		return nil, errInvalidPKCS1SignerKey
+	}
+
+	err = validatePKCS1Params(key.PublicKey.Params)
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pkcs1_signer_key_manager: %w", err)
+	}
+
+	privKey, err := newPrivateKey(&privateKeyValues{
+		n: key.PublicKey.N, e: key.PublicKey.E, d: key.D, p: key.P, q: key.Q, dp: key.Dp, dq: key.Dq, crt: key.Crt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pkcs1_signer_key_manager: %w", err)
+	}
+
+	return &pkcs1Signer{privKey: privKey}, nil
+}
+
+// NewKey is not implemented, RSA keys are imported.
+func (km *pkcs1SignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("rsassa_pkcs1_signer_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented, RSA keys are imported.
+func (km *pkcs1SignerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("rsassa_pkcs1_signer_key_manager: NewKeyData not implemented")
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *pkcs1SignerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(rsassapkcs1pb.RsaSsaPkcs1PrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidPKCS1SignerKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidPKCS1SignerKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         pkcs1VerifierKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *pkcs1SignerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == pkcs1SignerKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *pkcs1SignerKeyManager) TypeURL() string {
+	return pkcs1SignerKeyTypeURL
+}
+
+// pkcs1VerifierKeyManager is an implementation of KeyManager interface for RS256 signature verification.
+// It doesn't support key generation.
+type pkcs1VerifierKeyManager struct{}
+
+// newPKCS1VerifierKeyManager creates a new pkcs1VerifierKeyManager.
+func newPKCS1VerifierKeyManager() *pkcs1VerifierKeyManager {
+	return new(pkcs1VerifierKeyManager)
+}
+
+// Primitive creates an RS256 Verifier for the given serialized RsaSsaPkcs1PublicKey proto.
+func (km *pkcs1VerifierKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPKCS1VerifierKey
+	}
+
+	key := new(rsassapkcs1pb.RsaSsaPkcs1PublicKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidPKCS1VerifierKey
+	}
+
+	err = keyset.ValidateKeyVersion(key.Version, pkcs1KeyVersion)
+	if err != nil {
+		return nil, errInvalidPKCS1VerifierKey
+	}
+
+	err = validatePKCS1Params(key.Params)
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pkcs1_verifier_key_manager: %w", err)
+	}
+
+	pubKey, err := newPublicKey(key.N, key.E)
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pkcs1_verifier_key_manager: %w", err)
+	}
+
+	return &pkcs1Verifier{pubKey: pubKey}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *pkcs1VerifierKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == pkcs1VerifierKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *pkcs1VerifierKeyManager) TypeURL() string {
+	return pkcs1VerifierKeyTypeURL
+}
+
+// NewKey is not implemented for public key manager.
+func (km *pkcs1VerifierKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("rsassa_pkcs1_verifier_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented for public key manager.
+func (km *pkcs1VerifierKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("rsassa_pkcs1_verifier_key_manager: NewKeyData not implemented")
+}
+
+func validatePKCS1Params(params *rsassapkcs1pb.RsaSsaPkcs1Params) error {
+	if params == nil || params.HashType != commonpb.HashType_SHA256 {
+		return errors.New("invalid key params: only SHA256 is supported")
+	}
+
+	return nil
+}
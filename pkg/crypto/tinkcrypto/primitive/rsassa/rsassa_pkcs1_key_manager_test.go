@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsassa
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPKCS1SignerKeyManager(t *testing.T) {
+	km := newPKCS1SignerKeyManager()
+
+	require.True(t, km.DoesSupport(pkcs1SignerKeyTypeURL))
+	require.Equal(t, pkcs1SignerKeyTypeURL, km.TypeURL())
+
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("Primitive() success", func(t *testing.T) {
+		serializedKey, err := proto.Marshal(newPKCS1PrivateKeyProto(privKey, commonpb.HashType_SHA256))
+		require.NoError(t, err)
+
+		p, err := km.Primitive(serializedKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+
+		pubKeyData, err := km.PublicKeyData(serializedKey)
+		require.NoError(t, err)
+		require.Equal(t, pkcs1VerifierKeyTypeURL, pubKeyData.TypeUrl)
+	})
+
+	t.Run("Primitive() with empty or bad serialized key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidPKCS1SignerKey.Error())
+
+		_, err = km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidPKCS1SignerKey.Error())
+
+		_, err = km.PublicKeyData([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidPKCS1SignerKey.Error())
+	})
+
+	t.Run("Primitive() with unsupported hash", func(t *testing.T) {
+		badHashKey, err := proto.Marshal(newPKCS1PrivateKeyProto(privKey, commonpb.HashType_SHA512))
+		require.NoError(t, err)
+
+		_, err = km.Primitive(badHashKey)
+		require.EqualError(t, err,
+			"rsassa_pkcs1_signer_key_manager: invalid key params: only SHA256 is supported")
+	})
+
+	t.Run("key generation not implemented", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, "rsassa_pkcs1_signer_key_manager: NewKey not implemented")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, "rsassa_pkcs1_signer_key_manager: NewKeyData not implemented")
+	})
+}
+
+func TestPKCS1VerifierKeyManager(t *testing.T) {
+	km := newPKCS1VerifierKeyManager()
+
+	require.True(t, km.DoesSupport(pkcs1VerifierKeyTypeURL))
+	require.Equal(t, pkcs1VerifierKeyTypeURL, km.TypeURL())
+
+	t.Run("Primitive() success", func(t *testing.T) {
+		privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		serializedPubKey, err := proto.Marshal(newPKCS1PrivateKeyProto(privKey, commonpb.HashType_SHA256).PublicKey)
+		require.NoError(t, err)
+
+		p, err := km.Primitive(serializedPubKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+	})
+
+	t.Run("Primitive() with empty or bad serialized key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidPKCS1VerifierKey.Error())
+
+		_, err = km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidPKCS1VerifierKey.Error())
+	})
+
+	t.Run("key generation not implemented", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, "rsassa_pkcs1_verifier_key_manager: NewKey not implemented")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, "rsassa_pkcs1_verifier_key_manager: NewKeyData not implemented")
+	})
+}
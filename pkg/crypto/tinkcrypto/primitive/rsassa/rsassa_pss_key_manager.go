@@ -0,0 +1,184 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsassa
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	rsassapsspb "github.com/google/tink/go/proto/rsa_ssa_pss_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+)
+
+const (
+	pssKeyVersion         = 0
+	pssSignerKeyTypeURL   = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPssPrivateKey"
+	pssVerifierKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPssPublicKey"
+)
+
+// common errors.
+var (
+	errInvalidPSSSignerKey   = errors.New("rsassa_pss_signer_key_manager: invalid key")
+	errInvalidPSSVerifierKey = errors.New("rsassa_pss_verifier_key_manager: invalid key")
+)
+
+// pssSignerKeyManager is an implementation of PrivateKeyManager interface for PS256 signatures.
+// It doesn't support key generation.
+type pssSignerKeyManager struct{}
+
+// newPSSSignerKeyManager creates a new pssSignerKeyManager.
+func newPSSSignerKeyManager() *pssSignerKeyManager {
+	return new(pssSignerKeyManager)
+}
+
+// Primitive creates a PS256 Signer for the given serialized RsaSsaPssPrivateKey proto.
+func (km *pssSignerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPSSSignerKey
+	}
+
+	key := new(rsassapsspb.RsaSsaPssPrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidPSSSignerKey
+	}
+
+	err = keyset.ValidateKeyVersion(key.Version, pssKeyVersion)
+	if err != nil || key.PublicKey == nil {
+		return nil, errInvalidPSSSignerKey
+	}
+
+	err = validatePSSParams(key.PublicKey.Params)
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pss_signer_key_manager: %w", err)
+	}
+
+	privKey, err := newPrivateKey(&privateKeyValues{
+		n: key.PublicKey.N, e: key.PublicKey.E, d: key.D, p: key.P, q: key.Q, dp: key.Dp, dq: key.Dq, crt: key.Crt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pss_signer_key_manager: %w", err)
+	}
+
+	return &pssSigner{privKey: privKey}, nil
+}
+
+// NewKey is not implemented, RSA keys are imported.
+func (km *pssSignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("rsassa_pss_signer_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented, RSA keys are imported.
+func (km *pssSignerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("rsassa_pss_signer_key_manager: NewKeyData not implemented")
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *pssSignerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(rsassapsspb.RsaSsaPssPrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidPSSSignerKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidPSSSignerKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         pssVerifierKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *pssSignerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == pssSignerKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *pssSignerKeyManager) TypeURL() string {
+	return pssSignerKeyTypeURL
+}
+
+// pssVerifierKeyManager is an implementation of KeyManager interface for PS256 signature verification.
+// It doesn't support key generation.
+type pssVerifierKeyManager struct{}
+
+// newPSSVerifierKeyManager creates a new pssVerifierKeyManager.
+func newPSSVerifierKeyManager() *pssVerifierKeyManager {
+	return new(pssVerifierKeyManager)
+}
+
+// Primitive creates a PS256 Verifier for the given serialized RsaSsaPssPublicKey proto.
+func (km *pssVerifierKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidPSSVerifierKey
+	}
+
+	key := new(rsassapsspb.RsaSsaPssPublicKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidPSSVerifierKey
+	}
+
+	err = keyset.ValidateKeyVersion(key.Version, pssKeyVersion)
+	if err != nil {
+		return nil, errInvalidPSSVerifierKey
+	}
+
+	err = validatePSSParams(key.Params)
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pss_verifier_key_manager: %w", err)
+	}
+
+	pubKey, err := newPublicKey(key.N, key.E)
+	if err != nil {
+		return nil, fmt.Errorf("rsassa_pss_verifier_key_manager: %w", err)
+	}
+
+	return &pssVerifier{pubKey: pubKey}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *pssVerifierKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == pssVerifierKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *pssVerifierKeyManager) TypeURL() string {
+	return pssVerifierKeyTypeURL
+}
+
+// NewKey is not implemented for public key manager.
+func (km *pssVerifierKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("rsassa_pss_verifier_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented for public key manager.
+func (km *pssVerifierKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("rsassa_pss_verifier_key_manager: NewKeyData not implemented")
+}
+
+// validatePSSParams checks the params of PS256: SHA256 as signature and MGF1 hash, with a salt as long as the hash.
+func validatePSSParams(params *rsassapsspb.RsaSsaPssParams) error {
+	if params == nil || params.SigHash != commonpb.HashType_SHA256 || params.Mgf1Hash != commonpb.HashType_SHA256 ||
+		params.SaltLength != sha256.Size {
+		return errors.New("invalid key params: only SHA256 with a 32 bytes salt is supported")
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsassa
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPSSSignerKeyManager(t *testing.T) {
+	km := newPSSSignerKeyManager()
+
+	require.True(t, km.DoesSupport(pssSignerKeyTypeURL))
+	require.Equal(t, pssSignerKeyTypeURL, km.TypeURL())
+
+	t.Run("Primitive() success", func(t *testing.T) {
+		privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		serializedKey, err := proto.Marshal(newPSSPrivateKeyProto(privKey))
+		require.NoError(t, err)
+
+		p, err := km.Primitive(serializedKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+
+		pubKeyData, err := km.PublicKeyData(serializedKey)
+		require.NoError(t, err)
+		require.Equal(t, pssVerifierKeyTypeURL, pubKeyData.TypeUrl)
+	})
+
+	t.Run("Primitive() with empty or bad serialized key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidPSSSignerKey.Error())
+
+		_, err = km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidPSSSignerKey.Error())
+
+		_, err = km.PublicKeyData([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidPSSSignerKey.Error())
+	})
+
+	t.Run("Primitive() with a too small key", func(t *testing.T) {
+		smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+
+		smallSerializedKey, err := proto.Marshal(newPSSPrivateKeyProto(smallKey))
+		require.NoError(t, err)
+
+		_, err = km.Primitive(smallSerializedKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rsassa_pss_signer_key_manager: ")
+	})
+
+	t.Run("key generation not implemented", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, "rsassa_pss_signer_key_manager: NewKey not implemented")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, "rsassa_pss_signer_key_manager: NewKeyData not implemented")
+	})
+}
+
+func TestPSSVerifierKeyManager(t *testing.T) {
+	km := newPSSVerifierKeyManager()
+
+	require.True(t, km.DoesSupport(pssVerifierKeyTypeURL))
+	require.Equal(t, pssVerifierKeyTypeURL, km.TypeURL())
+
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("Primitive() success", func(t *testing.T) {
+		serializedPubKey, err := proto.Marshal(newPSSPrivateKeyProto(privKey).PublicKey)
+		require.NoError(t, err)
+
+		p, err := km.Primitive(serializedPubKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+	})
+
+	t.Run("Primitive() with empty or bad serialized key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidPSSVerifierKey.Error())
+
+		_, err = km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidPSSVerifierKey.Error())
+	})
+
+	t.Run("Primitive() with unsupported salt length", func(t *testing.T) {
+		pubKeyProto := newPSSPrivateKeyProto(privKey).PublicKey
+		pubKeyProto.Params.SaltLength = 0
+
+		serializedPubKey, err := proto.Marshal(pubKeyProto)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedPubKey)
+		require.EqualError(t, err,
+			"rsassa_pss_verifier_key_manager: invalid key params: only SHA256 with a 32 bytes salt is supported")
+	})
+
+	t.Run("key generation not implemented", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, "rsassa_pss_verifier_key_manager: NewKey not implemented")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, "rsassa_pss_verifier_key_manager: NewKeyData not implemented")
+	})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsassa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/google/tink/go/signature/subtle"
+)
+
+// pkcs1Signer makes RS256 signatures.
+type pkcs1Signer struct {
+	privKey *rsa.PrivateKey
+}
+
+// Sign computes the RSASSA-PKCS1-v1_5 signature of the SHA-256 hash of data.
+func (s *pkcs1Signer) Sign(data []byte) ([]byte, error) {
+	hashed := sha256.Sum256(data)
+
+	return rsa.SignPKCS1v15(rand.Reader, s.privKey, crypto.SHA256, hashed[:])
+}
+
+// pkcs1Verifier verifies RS256 signatures.
+type pkcs1Verifier struct {
+	pubKey *rsa.PublicKey
+}
+
+// Verify verifies the RSASSA-PKCS1-v1_5 signature of the SHA-256 hash of data.
+func (v *pkcs1Verifier) Verify(signature, data []byte) error {
+	hashed := sha256.Sum256(data)
+
+	return rsa.VerifyPKCS1v15(v.pubKey, crypto.SHA256, hashed[:], signature)
+}
+
+// pssSigner makes PS256 signatures.
+type pssSigner struct {
+	privKey *rsa.PrivateKey
+}
+
+// Sign computes the RSASSA-PSS signature of the SHA-256 hash of data.
+func (s *pssSigner) Sign(data []byte) ([]byte, error) {
+	hashed := sha256.Sum256(data)
+
+	return rsa.SignPSS(rand.Reader, s.privKey, crypto.SHA256, hashed[:],
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+}
+
+// pssVerifier verifies PS256 signatures.
+type pssVerifier struct {
+	pubKey *rsa.PublicKey
+}
+
+// Verify verifies the RSASSA-PSS signature of the SHA-256 hash of data.
+func (v *pssVerifier) Verify(signature, data []byte) error {
+	hashed := sha256.Sum256(data)
+
+	return rsa.VerifyPSS(v.pubKey, crypto.SHA256, hashed[:], signature,
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+}
+
+// privateKeyValues are the big-endian values of an RSA private key, as serialized in the RsaSsaPkcs1PrivateKey and
+// RsaSsaPssPrivateKey protos.
+type privateKeyValues struct {
+	n, e, d, p, q, dp, dq, crt []byte
+}
+
+func newPrivateKey(v *privateKeyValues) (*rsa.PrivateKey, error) {
+	pubKeyData, err := newPublicKeyData(v.n, v.e)
+	if err != nil {
+		return nil, err
+	}
+
+	privKeyData := &subtle.RSAPrivateKeyData{
+		D:             new(big.Int).SetBytes(v.d),
+		P:             new(big.Int).SetBytes(v.p),
+		Q:             new(big.Int).SetBytes(v.q),
+		Dp:            new(big.Int).SetBytes(v.dp),
+		Dq:            new(big.Int).SetBytes(v.dq),
+		Qinv:          new(big.Int).SetBytes(v.crt),
+		PublicKeyData: pubKeyData,
+	}
+
+	privKey, err := privKeyData.CreateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	privKey.Precompute()
+
+	return privKey, nil
+}
+
+func newPublicKey(n, e []byte) (*rsa.PublicKey, error) {
+	pubKeyData, err := newPublicKeyData(n, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return pubKeyData.CreateKey()
+}
+
+func newPublicKeyData(n, e []byte) (*subtle.RSAPublicKeyData, error) {
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() {
+		return nil, errors.New("invalid RSA public key: public exponent is too large")
+	}
+
+	return &subtle.RSAPublicKeyData{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsassa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	rsassapkcs1pb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	rsassapsspb "github.com/google/tink/go/proto/rsa_ssa_pss_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	require.NoError(t, Register())
+
+	// registering again returns the result of the first registration.
+	require.NoError(t, Register())
+
+	// the key managers are already registered in the Tink registry.
+	require.Error(t, registerKeyManagers())
+}
+
+func TestSignAndVerifyWithKeysetHandles(t *testing.T) {
+	require.NoError(t, Register())
+
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+	hashed := sha256.Sum256(msg)
+
+	t.Run("RS256 sign and verify with keyset handles", func(t *testing.T) {
+		serializedKey, err := proto.Marshal(newPKCS1PrivateKeyProto(privKey, commonpb.HashType_SHA256))
+		require.NoError(t, err)
+
+		sig, pubKH := signWithKeysetHandle(t, pkcs1SignerKeyTypeURL, serializedKey, msg)
+		require.NoError(t, rsa.VerifyPKCS1v15(&privKey.PublicKey, crypto.SHA256, hashed[:], sig))
+
+		v, err := signature.NewVerifier(pubKH)
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(sig, msg))
+		require.Error(t, v.Verify(sig, []byte("other message")))
+	})
+
+	t.Run("PS256 sign and verify with keyset handles", func(t *testing.T) {
+		serializedKey, err := proto.Marshal(newPSSPrivateKeyProto(privKey))
+		require.NoError(t, err)
+
+		sig, pubKH := signWithKeysetHandle(t, pssSignerKeyTypeURL, serializedKey, msg)
+		require.NoError(t, rsa.VerifyPSS(&privKey.PublicKey, crypto.SHA256, hashed[:], sig, nil))
+
+		v, err := signature.NewVerifier(pubKH)
+		require.NoError(t, err)
+
+		require.NoError(t, v.Verify(sig, msg))
+		require.Error(t, v.Verify(sig, []byte("other message")))
+	})
+}
+
+func signWithKeysetHandle(t *testing.T, typeURL string, serializedKey, msg []byte) ([]byte, *keyset.Handle) {
+	t.Helper()
+
+	kh, err := insecurecleartextkeyset.Read(&keyset.MemReaderWriter{Keyset: &tinkpb.Keyset{
+		PrimaryKeyId: 1,
+		Key: []*tinkpb.Keyset_Key{{
+			KeyData: &tinkpb.KeyData{
+				TypeUrl:         typeURL,
+				Value:           serializedKey,
+				KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+			},
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			KeyId:            1,
+			OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+		}},
+	}})
+	require.NoError(t, err)
+
+	s, err := signature.NewSigner(kh)
+	require.NoError(t, err)
+
+	sig, err := s.Sign(msg)
+	require.NoError(t, err)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	return sig, pubKH
+}
+
+func newPKCS1PrivateKeyProto(privKey *rsa.PrivateKey, hashType commonpb.HashType) *rsassapkcs1pb.RsaSsaPkcs1PrivateKey {
+	return &rsassapkcs1pb.RsaSsaPkcs1PrivateKey{
+		PublicKey: &rsassapkcs1pb.RsaSsaPkcs1PublicKey{
+			Params: &rsassapkcs1pb.RsaSsaPkcs1Params{HashType: hashType},
+			N:      privKey.N.Bytes(),
+			E:      big.NewInt(int64(privKey.E)).Bytes(),
+		},
+		D:   privKey.D.Bytes(),
+		P:   privKey.Primes[0].Bytes(),
+		Q:   privKey.Primes[1].Bytes(),
+		Dp:  privKey.Precomputed.Dp.Bytes(),
+		Dq:  privKey.Precomputed.Dq.Bytes(),
+		Crt: privKey.Precomputed.Qinv.Bytes(),
+	}
+}
+
+func newPSSPrivateKeyProto(privKey *rsa.PrivateKey) *rsassapsspb.RsaSsaPssPrivateKey {
+	return &rsassapsspb.RsaSsaPssPrivateKey{
+		PublicKey: &rsassapsspb.RsaSsaPssPublicKey{
+			Params: &rsassapsspb.RsaSsaPssParams{
+				SigHash:    commonpb.HashType_SHA256,
+				Mgf1Hash:   commonpb.HashType_SHA256,
+				SaltLength: sha256.Size,
+			},
+			N: privKey.N.Bytes(),
+			E: big.NewInt(int64(privKey.E)).Bytes(),
+		},
+		D:   privKey.D.Bytes(),
+		P:   privKey.Primes[0].Bytes(),
+		Q:   privKey.Primes[1].Bytes(),
+		Dp:  privKey.Precomputed.Dp.Bytes(),
+		Dq:  privKey.Precomputed.Dq.Bytes(),
+		Crt: privKey.Precomputed.Qinv.Bytes(),
+	}
+}
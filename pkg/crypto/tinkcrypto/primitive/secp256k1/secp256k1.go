@@ -0,0 +1,53 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1 provides the Tink key managers of ECDSA signatures on the secp256k1 curve (ES256K), which Tink
+// doesn't support.
+//
+// Keys are serialized as Tink's EcdsaPrivateKey and EcdsaPublicKey protos: their type URL sets the curve and the
+// signatures are IEEE P1363 encoded (r||s) over the SHA-256 hash of the message. The key managers don't generate keys,
+// secp256k1 keys are imported in a KMS (see localkms.ImportPrivateKey), then used with signature.NewSigner() and
+// signature.NewVerifier() like other Tink signature keys.
+package secp256k1
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+var (
+	registerOnce sync.Once
+	errRegister  error
+)
+
+// Register registers the secp256k1 signer and verifier key managers in the Tink registry. It must be called before
+// using secp256k1 keyset handles with signature.NewSigner() or signature.NewVerifier(). Calling it more than once is
+// safe, the registration is only done once and its result is returned by every call.
+func Register() error {
+	registerOnce.Do(func() {
+		errRegister = registerKeyManagers()
+	})
+
+	return errRegister
+}
+
+func registerKeyManagers() error {
+	// TODO - avoid the tink registry singleton.
+	keyManagers := []registry.KeyManager{
+		newSecp256k1SignerKeyManager(),
+		newSecp256k1VerifierKeyManager(),
+	}
+
+	for _, km := range keyManagers {
+		if err := registry.RegisterKeyManager(km); err != nil {
+			return fmt.Errorf("secp256k1: failed to register key manager %s: %w", km.TypeURL(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+)
+
+// coordinateSize is the size of r and s in a signature, and of the key coordinates.
+const coordinateSize = 32
+
+// signer signs messages with a secp256k1 private key.
+type signer struct {
+	privKey *ecdsa.PrivateKey
+}
+
+func newSigner(privKey *ecdsa.PrivateKey) *signer {
+	return &signer{privKey: privKey}
+}
+
+// Sign computes the IEEE P1363 encoded signature of the SHA-256 hash of data.
+func (s *signer) Sign(data []byte) ([]byte, error) {
+	hashed := sha256.Sum256(data)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.privKey, hashed[:])
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1: failed to sign: %w", err)
+	}
+
+	signature := make([]byte, 2*coordinateSize)
+
+	r.FillBytes(signature[:coordinateSize])
+	sig.FillBytes(signature[coordinateSize:])
+
+	return signature, nil
+}
+
+// verifier verifies signatures with a secp256k1 public key.
+type verifier struct {
+	pubKey *ecdsa.PublicKey
+}
+
+func newVerifier(pubKey *ecdsa.PublicKey) *verifier {
+	return &verifier{pubKey: pubKey}
+}
+
+// Verify verifies that signature is the IEEE P1363 encoded signature of the SHA-256 hash of data.
+func (v *verifier) Verify(signature, data []byte) error {
+	if len(signature) != 2*coordinateSize {
+		return errors.New("secp256k1: invalid signature size")
+	}
+
+	hashed := sha256.Sum256(data)
+	r := new(big.Int).SetBytes(signature[:coordinateSize])
+	s := new(big.Int).SetBytes(signature[coordinateSize:])
+
+	if !ecdsa.Verify(v.pubKey, hashed[:], r, s) {
+		return errors.New("secp256k1: invalid signature")
+	}
+
+	return nil
+}
+
+func newPublicKey(x, y []byte) (*ecdsa.PublicKey, error) {
+	pubKey := &ecdsa.PublicKey{
+		Curve: btcec.S256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+
+	if !pubKey.Curve.IsOnCurve(pubKey.X, pubKey.Y) {
+		return nil, errors.New("invalid public key: point is not on the secp256k1 curve")
+	}
+
+	return pubKey, nil
+}
+
+func validateKeyParams(params *ecdsapb.EcdsaParams) error {
+	if params == nil {
+		return errors.New("secp256k1: invalid key params: params are missing")
+	}
+
+	if params.Encoding != ecdsapb.EcdsaSignatureEncoding_IEEE_P1363 {
+		return fmt.Errorf("secp256k1: invalid key params: unsupported signature encoding: %s", params.Encoding)
+	}
+
+	if params.HashType != commonpb.HashType_SHA256 {
+		return fmt.Errorf("secp256k1: invalid key params: unsupported hash type: %s", params.HashType)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+)
+
+const (
+	secp256k1SignerKeyVersion = 0
+	secp256k1SignerKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
+)
+
+// common errors.
+var errInvalidSecp256k1SignerKey = errors.New("secp256k1_signer_key_manager: invalid key")
+
+// secp256k1SignerKeyManager is an implementation of PrivateKeyManager interface for secp256k1 signatures.
+// It doesn't support key generation.
+type secp256k1SignerKeyManager struct{}
+
+// newSecp256k1SignerKeyManager creates a new secp256k1SignerKeyManager.
+func newSecp256k1SignerKeyManager() *secp256k1SignerKeyManager {
+	return new(secp256k1SignerKeyManager)
+}
+
+// Primitive creates a secp256k1 Signer for the given serialized EcdsaPrivateKey proto.
+func (km *secp256k1SignerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidSecp256k1SignerKey
+	}
+
+	key := new(ecdsapb.EcdsaPrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidSecp256k1SignerKey
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := newPublicKey(key.PublicKey.X, key.PublicKey.Y)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer_key_manager: %w", err)
+	}
+
+	return newSigner(&ecdsa.PrivateKey{PublicKey: *pubKey, D: new(big.Int).SetBytes(key.KeyValue)}), nil
+}
+
+// NewKey is not implemented, secp256k1 keys are imported.
+func (km *secp256k1SignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("secp256k1_signer_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented, secp256k1 keys are imported.
+func (km *secp256k1SignerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("secp256k1_signer_key_manager: NewKeyData not implemented")
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *secp256k1SignerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(ecdsapb.EcdsaPrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidSecp256k1SignerKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidSecp256k1SignerKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         secp256k1VerifierKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *secp256k1SignerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == secp256k1SignerKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *secp256k1SignerKeyManager) TypeURL() string {
+	return secp256k1SignerKeyTypeURL
+}
+
+// validateKey validates the given EcdsaPrivateKey.
+func (km *secp256k1SignerKeyManager) validateKey(key *ecdsapb.EcdsaPrivateKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, secp256k1SignerKeyVersion)
+	if err != nil {
+		return fmt.Errorf("secp256k1_signer_key_manager: invalid key: %w", err)
+	}
+
+	if key.PublicKey == nil {
+		return errInvalidSecp256k1SignerKey
+	}
+
+	return validateKeyParams(key.PublicKey.Params)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecp256k1SignerKeyManager(t *testing.T) {
+	km := newSecp256k1SignerKeyManager()
+
+	require.True(t, km.DoesSupport(secp256k1SignerKeyTypeURL))
+	require.Equal(t, secp256k1SignerKeyTypeURL, km.TypeURL())
+
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("Primitive() success", func(t *testing.T) {
+		serializedKey, err := proto.Marshal(newPrivateKeyProto(privKey, commonpb.HashType_SHA256))
+		require.NoError(t, err)
+
+		p, err := km.Primitive(serializedKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+
+		pubKeyData, err := km.PublicKeyData(serializedKey)
+		require.NoError(t, err)
+		require.Equal(t, secp256k1VerifierKeyTypeURL, pubKeyData.TypeUrl)
+	})
+
+	t.Run("Primitive() with empty or bad serialized key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidSecp256k1SignerKey.Error())
+
+		_, err = km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidSecp256k1SignerKey.Error())
+
+		_, err = km.PublicKeyData([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidSecp256k1SignerKey.Error())
+	})
+
+	t.Run("Primitive() with unsupported hash", func(t *testing.T) {
+		badHashKey, err := proto.Marshal(newPrivateKeyProto(privKey, commonpb.HashType_SHA384))
+		require.NoError(t, err)
+
+		_, err = km.Primitive(badHashKey)
+		require.EqualError(t, err, "secp256k1: invalid key params: unsupported hash type: SHA384")
+	})
+
+	t.Run("key generation not implemented", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, "secp256k1_signer_key_manager: NewKey not implemented")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, "secp256k1_signer_key_manager: NewKeyData not implemented")
+	})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	require.NoError(t, Register())
+
+	// registering again returns the result of the first registration.
+	require.NoError(t, Register())
+
+	// the key managers are already registered in the Tink registry.
+	require.Error(t, registerKeyManagers())
+}
+
+func TestSignAndVerifyWithKeysetHandles(t *testing.T) {
+	require.NoError(t, Register())
+
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	serializedKey, err := proto.Marshal(newPrivateKeyProto(privKey, commonpb.HashType_SHA256))
+	require.NoError(t, err)
+
+	kh, err := insecurecleartextkeyset.Read(&keyset.MemReaderWriter{Keyset: &tinkpb.Keyset{
+		PrimaryKeyId: 1,
+		Key: []*tinkpb.Keyset_Key{{
+			KeyData: &tinkpb.KeyData{
+				TypeUrl:         secp256k1SignerKeyTypeURL,
+				Value:           serializedKey,
+				KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+			},
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			KeyId:            1,
+			OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+		}},
+	}})
+	require.NoError(t, err)
+
+	s, err := signature.NewSigner(kh)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	sig, err := s.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, 2*coordinateSize)
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	v, err := signature.NewVerifier(pubKH)
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(sig, msg))
+	require.Error(t, v.Verify(sig, []byte("other message")))
+	require.Error(t, v.Verify(sig[1:], msg))
+}
+
+func newPrivateKeyProto(privKey *ecdsa.PrivateKey, hashType commonpb.HashType) *ecdsapb.EcdsaPrivateKey {
+	return &ecdsapb.EcdsaPrivateKey{
+		PublicKey: &ecdsapb.EcdsaPublicKey{
+			Params: &ecdsapb.EcdsaParams{
+				Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+				HashType: hashType,
+			},
+			X: privKey.X.Bytes(),
+			Y: privKey.Y.Bytes(),
+		},
+		KeyValue: privKey.D.Bytes(),
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecp256k1VerifierKeyManager(t *testing.T) {
+	km := newSecp256k1VerifierKeyManager()
+
+	require.True(t, km.DoesSupport(secp256k1VerifierKeyTypeURL))
+	require.Equal(t, secp256k1VerifierKeyTypeURL, km.TypeURL())
+
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	pubKeyProto := newPrivateKeyProto(privKey, commonpb.HashType_SHA256).PublicKey
+
+	t.Run("Primitive() success", func(t *testing.T) {
+		serializedPubKey, err := proto.Marshal(pubKeyProto)
+		require.NoError(t, err)
+
+		p, err := km.Primitive(serializedPubKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+	})
+
+	t.Run("Primitive() with empty or bad serialized key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.EqualError(t, err, errInvalidSecp256k1VerifierKey.Error())
+
+		_, err = km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidSecp256k1VerifierKey.Error())
+	})
+
+	t.Run("Primitive() with a public key off the curve", func(t *testing.T) {
+		// swapping the coordinates of the public key moves it off the curve.
+		offCurvePubKey := &ecdsapb.EcdsaPublicKey{
+			Params: pubKeyProto.Params,
+			X:      pubKeyProto.Y,
+			Y:      pubKeyProto.X,
+		}
+
+		serializedPubKey, err := proto.Marshal(offCurvePubKey)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedPubKey)
+		require.EqualError(t, err,
+			"secp256k1_verifier_key_manager: invalid public key: point is not on the secp256k1 curve")
+	})
+
+	t.Run("key generation not implemented", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.EqualError(t, err, "secp256k1_verifier_key_manager: NewKey not implemented")
+
+		_, err = km.NewKeyData(nil)
+		require.EqualError(t, err, "secp256k1_verifier_key_manager: NewKeyData not implemented")
+	})
+}
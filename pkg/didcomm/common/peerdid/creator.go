@@ -54,8 +54,10 @@ func New(p Provider) *Creator {
 	}
 }
 
-// CreatePeerDIDV2 create a peer DID suitable for use in DIDComm V2.
-func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
+// CreatePeerDIDV2 create a peer DID suitable for use in DIDComm V2. The peer VDR creates a did:peer:1 by default,
+// pass vdrapi.WithOption(peer.NumAlgoOption, peer.NumAlgo2) (or peer.NumAlgo4) for DIDs resolvable without
+// exchanging the DID document.
+func (s *Creator) CreatePeerDIDV2(opts ...vdrapi.DIDMethodOption) (*did.Doc, error) {
 	// TODO: add routing keys so edge agents can rotate (currently only cloud agents do)
 	newDID := &did.Doc{Service: []did.Service{{Type: vdrapi.DIDCommV2ServiceType}}}
 
@@ -67,7 +69,7 @@ func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
 	// set KeyAgreement.ID as RecipientKeys as part of DIDComm V2 service
 	newDID.Service[0].RecipientKeys = []string{newDID.KeyAgreement[0].VerificationMethod.ID}
 
-	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID)
+	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating new peer DID via VDR failed: %w", err)
 	}
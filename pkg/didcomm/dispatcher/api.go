@@ -19,6 +19,13 @@ type ProtocolService interface {
 	Initialize(interface{}) error
 }
 
+// ProtocolDisclosure is an optional interface of ProtocolService for disclosing
+// the protocols supported by the service (e.g. through discover-features protocol).
+type ProtocolDisclosure interface {
+	// Protocols returns protocol identifier URIs (PIURI) of the protocols supported by the service.
+	Protocols() []string
+}
+
 // MessageService is service for handling generic messages
 // matching accept criteria based on message header.
 type MessageService interface {
@@ -39,6 +46,12 @@ type Outbound interface {
 	Forward(interface{}, *service.Destination) error
 }
 
+// MultiOutbound is implemented by the Outbound dispatchers which can send a message to several DIDs at once.
+type MultiOutbound interface {
+	// SendToDIDs Sends the DIDComm V2 message to several DIDs, packing it once per route of the recipients.
+	SendToDIDs(msg interface{}, myDID string, theirDIDs []string) error
+}
+
 // MessageTypeTarget represents a service message type mapping value to an OOB target action.
 type MessageTypeTarget struct {
 	MsgType string
@@ -15,6 +15,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	commonmodel "github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/middleware"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
@@ -64,6 +65,10 @@ type Dispatcher struct {
 	connections          connectionRecorder
 	mediaTypeProfiles    []string
 	didcommV2Handler     *middleware.DIDCommMessageMiddleware
+	metrics              metrics.Metrics
+	outboxConfig         *outboxConfig
+	outbox               *outbox
+	service.Message
 }
 
 // legacyForward is DIDComm V1 route Forward msg as declared in
@@ -77,8 +82,10 @@ type legacyForward struct {
 
 var logger = log.New("aries-framework/didcomm/dispatcher")
 
+const unknownMetricLabel = "unknown"
+
 // NewOutbound return new dispatcher outbound instance.
-func NewOutbound(prov provider) (*Dispatcher, error) {
+func NewOutbound(prov provider, opts ...Opt) (*Dispatcher, error) {
 	o := &Dispatcher{
 		outboundTransports:   prov.OutboundTransports(),
 		packager:             prov.Packager(),
@@ -88,6 +95,7 @@ func NewOutbound(prov provider) (*Dispatcher, error) {
 		keyAgreementType:     prov.KeyAgreementType(),
 		mediaTypeProfiles:    prov.MediaTypeProfiles(),
 		didcommV2Handler:     prov.DIDRotator(),
+		metrics:              metrics.FromProvider(prov),
 	}
 
 	var err error
@@ -97,6 +105,21 @@ func NewOutbound(prov provider) (*Dispatcher, error) {
 		return nil, fmt.Errorf("failed to init connection recorder: %w", err)
 	}
 
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.outboxConfig != nil {
+		o.outbox, err = newOutbox(prov.StorageProvider(), o.outboxConfig, o.redeliver, o.notifyOutbox)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init outbox: %w", err)
+		}
+
+		if err = o.outbox.resume(); err != nil {
+			return nil, fmt.Errorf("failed to resume outbox delivery: %w", err)
+		}
+	}
+
 	return o, nil
 }
 
@@ -197,6 +220,192 @@ func (o *Dispatcher) SendToDID(msg interface{}, myDID, theirDID string) error {
 	return o.Send(msg, key, dest)
 }
 
+// route is a group of destinations sharing the same media type profile, service endpoint and routing keys.
+type route struct {
+	mediaTypeProfile string
+	dids             []string
+	destinations     []*service.Destination
+}
+
+// SendToDIDs sends a DIDComm V2 message from myDID to the agents who own theirDIDs. Rather than packing the message
+// for every recipient, the message is packed once per distinct route of the recipients (same media type profile,
+// service endpoint and routing keys) for the key agreement keys of all the recipients of the route, with its `to`
+// header set to their DIDs. If the route goes through a mediator, the packed message is then forwarded to each of
+// its recipients.
+func (o *Dispatcher) SendToDIDs(msg interface{}, myDID string, theirDIDs []string) error {
+	var didcommMsg service.DIDCommMsgMap
+
+	switch m := msg.(type) {
+	case service.DIDCommMsgMap:
+		didcommMsg = m
+	case *service.DIDCommMsgMap:
+		didcommMsg = *m
+	default:
+		didcommMsg = service.NewDIDCommMsgMap(msg)
+	}
+
+	if isV2, err := service.IsDIDCommV2(&didcommMsg); err != nil || !isV2 {
+		return fmt.Errorf("outboundDispatcher.SendToDIDs: only DIDComm V2 messages can be sent to multiple DIDs")
+	}
+
+	myDocResolution, err := o.vdRegistry.Resolve(myDID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve my DID: %w", err)
+	}
+
+	src, err := service.CreateDestination(myDocResolution.DIDDocument)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.SendToDIDs failed to get didcomm destination for myDID [%s]: %w",
+			myDID, err)
+	}
+
+	routes, err := o.routes(theirDIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range routes {
+		err = o.sendToRoute(didcommMsg, src.RecipientKeys[0], r)
+		if err != nil {
+			return fmt.Errorf("outboundDispatcher.SendToDIDs: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// routes resolves theirDIDs and groups their destinations by route, in order of first appearance.
+func (o *Dispatcher) routes(theirDIDs []string) ([]*route, error) {
+	var routes []*route
+
+	routeIndexes := map[string]int{}
+	seen := map[string]bool{}
+
+	for _, theirDID := range theirDIDs {
+		if seen[theirDID] {
+			continue
+		}
+
+		seen[theirDID] = true
+
+		theirDocResolution, err := o.vdRegistry.Resolve(theirDID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve their DID [%s]: %w", theirDID, err)
+		}
+
+		dest, err := service.CreateDestination(theirDocResolution.DIDDocument)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"outboundDispatcher.SendToDIDs failed to get didcomm destination for theirDID [%s]: %w", theirDID, err)
+		}
+
+		// pick one of the mediators if the recipient is registered with several of them
+		dest = o.selectDIDCommV2Endpoint(dest)
+		mtp := o.mediaTypeProfile(dest)
+
+		uri, err := dest.ServiceEndpoint.URI()
+		if err != nil {
+			logger.Debugf("destination ServiceEndpoint empty: %w, it will not be used to group recipients", err)
+		}
+
+		key := strings.Join(append([]string{mtp, uri}, routingKeys(dest)...), ",")
+
+		i, ok := routeIndexes[key]
+		if !ok {
+			i = len(routes)
+			routeIndexes[key] = i
+			routes = append(routes, &route{mediaTypeProfile: mtp})
+		}
+
+		routes[i].dids = append(routes[i].dids, theirDID)
+		routes[i].destinations = append(routes[i].destinations, dest)
+	}
+
+	return routes, nil
+}
+
+// sendToRoute packs the message for all the recipients of the route and sends it to the route endpoint, through
+// one forward message per recipient if the route has routing keys.
+func (o *Dispatcher) sendToRoute(didcommMsg service.DIDCommMsgMap, senderKey string, r *route) error {
+	routeMsg := service.DIDCommMsgMap{}
+
+	for k, v := range didcommMsg {
+		routeMsg[k] = v
+	}
+
+	routeMsg["to"] = r.dids
+
+	routeDest := *r.destinations[0]
+	routeDest.RecipientKeys = nil
+
+	for _, dest := range r.destinations {
+		routeDest.RecipientKeys = append(routeDest.RecipientKeys, dest.RecipientKeys...)
+	}
+
+	outboundTransport := o.sendTransport(&routeDest)
+	if outboundTransport == nil {
+		return fmt.Errorf("no transport found for destination: %+v", routeDest)
+	}
+
+	req, err := json.Marshal(routeMsg)
+	if err != nil {
+		return fmt.Errorf("failed marshal to bytes: %w", err)
+	}
+
+	req, err = o.addTransportRouteOptions(req, &routeDest)
+	if err != nil {
+		return fmt.Errorf("failed to add transport route options: %w", err)
+	}
+
+	packedMsg, err := o.packager.PackMessage(&transport.Envelope{
+		MediaTypeProfile: r.mediaTypeProfile,
+		Message:          req,
+		FromKey:          []byte(senderKey),
+		ToKeys:           routeDest.RecipientKeys,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pack msg: %w", err)
+	}
+
+	// without routing keys the recipients are reached directly, the packed message is sent once.
+	destinations := []*service.Destination{&routeDest}
+	if len(routingKeys(&routeDest)) > 0 {
+		destinations = r.destinations
+	}
+
+	var fwdMsg []byte
+
+	for _, dest := range destinations {
+		dest.TransportReturnRoute = o.transportReturnRoute
+
+		fwdMsg, err = o.createForwardMessage(packedMsg, dest)
+		if err != nil {
+			return fmt.Errorf("failed to create forward msg: %w", err)
+		}
+
+		err = o.sendOrQueue(outboundTransport, &OutboxMessage{
+			MsgID:       messageID(req),
+			MsgType:     messageType(req),
+			Message:     fwdMsg,
+			Destination: dest,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send msg using outbound transport: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// routingKeys returns the DIDComm V2 routing keys of the destination, or its DIDComm V1 routing keys.
+func routingKeys(des *service.Destination) []string {
+	if keys, err := des.ServiceEndpoint.RoutingKeys(); err == nil && len(keys) > 0 {
+		return keys
+	}
+
+	return des.RoutingKeys
+}
+
 func (o *Dispatcher) defaultMediaTypeProfiles() []string {
 	mediaTypes := make([]string, len(o.mediaTypeProfiles))
 	copy(mediaTypes, o.mediaTypeProfiles)
@@ -242,29 +451,24 @@ func (o *Dispatcher) getOrCreateConnection(myDID, theirDID string, connectionVer
 }
 
 // Send sends the message after packing with the sender key and recipient keys.
-func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destination) error { // nolint:funlen,gocyclo
-	// check if outbound accepts routing keys, else use recipient keys
-	keys := des.RecipientKeys
-	if routingKeys, err := des.ServiceEndpoint.RoutingKeys(); err == nil && len(routingKeys) > 0 { // DIDComm V2
-		keys = routingKeys
-	} else if len(des.RoutingKeys) > 0 { // DIDComm V1
-		keys = routingKeys
-	}
-
-	var outboundTransport transport.OutboundTransport
+func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destination) error {
+	return o.send(msg, senderKey, des, false)
+}
 
-	for _, v := range o.outboundTransports {
-		uri, err := des.ServiceEndpoint.URI()
-		if err != nil {
-			logger.Debugf("destination ServiceEndpoint empty: %w, it will not be checked", err)
-		}
+// SendSigned sends the message in a DIDComm V2 signed envelope (JWS) signed with the sender key, which must be a DID
+// or a DID URL of one of its authentication keys. The signed envelope isn't encrypted: its content is readable by
+// the mediators forwarding it, it's only sent on request since Send never picks it. The destination must accept
+// the transport.MediaTypeV2SignedEnvelope media type.
+func (o *Dispatcher) SendSigned(msg interface{}, senderKey string, des *service.Destination) error {
+	return o.send(msg, senderKey, des, true)
+}
 
-		if v.AcceptRecipient(keys) || v.Accept(uri) {
-			outboundTransport = v
-			break
-		}
-	}
+func (o *Dispatcher) send(msg interface{}, senderKey string, des *service.Destination,
+	signed bool) error { // nolint:funlen,gocyclo
+	// pick one of the mediators if the recipient is registered with several of them
+	des = o.selectDIDCommV2Endpoint(des)
 
+	outboundTransport := o.sendTransport(des)
 	if outboundTransport == nil {
 		return fmt.Errorf("outboundDispatcher.Send: no transport found for destination: %+v", des)
 	}
@@ -282,6 +486,15 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 
 	mtp := o.mediaTypeProfile(des)
 
+	if signed {
+		if !acceptsMediaTypeProfile(des, transport.MediaTypeV2SignedEnvelope) {
+			return fmt.Errorf("outboundDispatcher.SendSigned: destination doesn't accept media type '%s'",
+				transport.MediaTypeV2SignedEnvelope)
+		}
+
+		mtp = transport.MediaTypeV2SignedEnvelope
+	}
+
 	var fromKey []byte
 
 	if len(senderKey) > 0 {
@@ -306,7 +519,12 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 		return fmt.Errorf("outboundDispatcher.Send: failed to create forward msg: %w", err)
 	}
 
-	_, err = outboundTransport.Send(packedMsg, des)
+	err = o.sendOrQueue(outboundTransport, &OutboxMessage{
+		MsgID:       messageID(req),
+		MsgType:     messageType(req),
+		Message:     packedMsg,
+		Destination: des,
+	})
 	if err != nil {
 		return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
 	}
@@ -314,39 +532,226 @@ func (o *Dispatcher) Send(msg interface{}, senderKey string, des *service.Destin
 	return nil
 }
 
-// Forward forwards the message without packing to the destination.
-func (o *Dispatcher) Forward(msg interface{}, des *service.Destination) error {
-	var (
-		uri string
-		err error
-	)
+// sendTransport returns the outbound transport accepting the routing keys (or the recipient keys if the destination
+// has no routing keys) or the service endpoint URI of the destination.
+func (o *Dispatcher) sendTransport(des *service.Destination) transport.OutboundTransport {
+	// check if outbound accepts routing keys, else use recipient keys
+	keys := des.RecipientKeys
+	if routingKeys, err := des.ServiceEndpoint.RoutingKeys(); err == nil && len(routingKeys) > 0 { // DIDComm V2
+		keys = routingKeys
+	} else if len(des.RoutingKeys) > 0 { // DIDComm V1
+		keys = des.RoutingKeys
+	}
 
-	uri, err = des.ServiceEndpoint.URI()
+	uri, err := des.ServiceEndpoint.URI()
+	if err != nil {
+		logger.Debugf("destination ServiceEndpoint empty: %w, it will not be checked", err)
+	}
+
+	for _, v := range o.outboundTransports {
+		if v.AcceptRecipient(keys) || v.Accept(uri) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// forwardTransport returns the outbound transport accepting the recipient keys or the service endpoint URI
+// of the destination.
+func (o *Dispatcher) forwardTransport(des *service.Destination) transport.OutboundTransport {
+	uri, err := des.ServiceEndpoint.URI()
 	if err != nil {
 		logger.Debugf("destination serviceEndpoint forward URI is not set: %w, will skip value", err)
 	}
 
 	for _, v := range o.outboundTransports {
-		if !v.AcceptRecipient(des.RecipientKeys) {
-			if !v.Accept(uri) {
-				continue
-			}
+		if v.AcceptRecipient(des.RecipientKeys) || v.Accept(uri) {
+			return v
 		}
+	}
 
-		req, err := json.Marshal(msg)
-		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Forward: failed marshal to bytes: %w", err)
+	return nil
+}
+
+// sendOrQueue sends the message using the outbound transport. When the outbox is enabled, the message is queued
+// for delivery if the send fails or if previous messages for the destination are still waiting in the outbox.
+func (o *Dispatcher) sendOrQueue(outboundTransport transport.OutboundTransport, m *OutboxMessage) error {
+	if o.outbox != nil && o.outbox.pending(m.Destination) {
+		return o.queue(m)
+	}
+
+	_, err := outboundTransport.Send(m.Message, m.Destination)
+	if err != nil {
+		if o.outbox == nil {
+			return err
 		}
 
-		_, err = v.Send(req, des)
+		logger.Warnf("failed to send msg, it will be queued in the outbox: %s", err)
+
+		m.Attempts = 1
+		m.LastError = err.Error()
+
+		return o.queue(m)
+	}
+
+	o.reportOutbound(m)
+
+	return nil
+}
+
+func (o *Dispatcher) queue(m *OutboxMessage) error {
+	// DID doc of the destination isn't needed to deliver the message
+	des := *m.Destination
+	des.DIDDoc = nil
+	m.Destination = &des
+
+	return o.outbox.enqueue(m)
+}
+
+// redeliver retries the delivery of the message queued in the outbox, the transport is selected again
+// as transports may have changed since the message was queued.
+func (o *Dispatcher) redeliver(m *OutboxMessage) error {
+	var outboundTransport transport.OutboundTransport
+
+	if m.Forward {
+		outboundTransport = o.forwardTransport(m.Destination)
+	} else {
+		outboundTransport = o.sendTransport(m.Destination)
+	}
+
+	if outboundTransport == nil {
+		return errNoTransport
+	}
+
+	if _, err := outboundTransport.Send(m.Message, m.Destination); err != nil {
+		return err
+	}
+
+	o.reportOutbound(m)
+
+	return nil
+}
+
+func (o *Dispatcher) reportOutbound(m *OutboxMessage) {
+	uri, err := m.Destination.ServiceEndpoint.URI()
+	if err != nil {
+		logger.Debugf("destination ServiceEndpoint empty: %w, transport will be reported as unknown", err)
+	}
+
+	o.metrics.OutboundMessage(m.MsgType, transportName(uri))
+}
+
+// notifyOutbox reports the delivery status of the outbox message to the registered message event channels.
+func (o *Dispatcher) notifyOutbox(stateID string, m *OutboxMessage) {
+	// the message keeps being updated by the outbox while the event is handled
+	snapshot := *m
+
+	msg := service.StateMsg{
+		ProtocolName: OutboxEventName,
+		Type:         service.PostState,
+		StateID:      stateID,
+		Properties:   &outboxEventProperties{m: &snapshot},
+	}
+
+	// events are dropped rather than blocking the delivery of messages if a handler doesn't keep up
+	for _, handler := range o.MsgEvents() {
+		select {
+		case handler <- msg:
+		default:
+			logger.Warnf("outbox: event channel is full, %s event of message %s is dropped", stateID, m.ID)
+		}
+	}
+}
+
+// DeadLetterMessages returns the messages which couldn't be delivered after the maximum number of attempts
+// of the outbox (see WithOutbox).
+func (o *Dispatcher) DeadLetterMessages() ([]*OutboxMessage, error) {
+	if o.outbox == nil {
+		return nil, errOutboxDisabled
+	}
+
+	messages, err := o.outbox.deadLetterMessages()
+	if err != nil {
+		return nil, fmt.Errorf("outboundDispatcher.DeadLetterMessages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// Close stops the delivery retries of the outbox. Messages waiting for delivery are kept in the outbox store
+// and their delivery is resumed when a dispatcher with the outbox is created on the same store.
+func (o *Dispatcher) Close() error {
+	if o.outbox != nil {
+		o.outbox.close()
+	}
+
+	return nil
+}
+
+// messageType returns the type of the marshaled DIDComm message, "unknown" if it has no type
+// (e.g. a forwarded envelope).
+func messageType(msg []byte) string {
+	didCommMsg, err := service.ParseDIDCommMsgMap(msg)
+	if err != nil || didCommMsg.Type() == "" {
+		return unknownMetricLabel
+	}
+
+	return didCommMsg.Type()
+}
+
+// messageID returns the ID of the marshaled DIDComm message, empty if it has no ID.
+func messageID(msg []byte) string {
+	didCommMsg, err := service.ParseDIDCommMsgMap(msg)
+	if err != nil {
+		return ""
+	}
+
+	return didCommMsg.ID()
+}
+
+// transportName returns the name of the transport (http or ws) used for the URI.
+func transportName(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "ws"):
+		return "ws"
+	case strings.HasPrefix(uri, "http"):
+		return "http"
+	default:
+		return unknownMetricLabel
+	}
+}
+
+// Forward forwards the message without packing to the destination.
+func (o *Dispatcher) Forward(msg interface{}, des *service.Destination) error {
+	des = o.selectDIDCommV2Endpoint(des)
+
+	outboundTransport := o.forwardTransport(des)
+	if outboundTransport == nil {
+		uri, err := des.ServiceEndpoint.URI()
 		if err != nil {
-			return fmt.Errorf("outboundDispatcher.Forward: failed to send msg using outbound transport: %w", err)
+			logger.Debugf("destination serviceEndpoint forward URI is not set: %w, will skip value", err)
 		}
 
-		return nil
+		return fmt.Errorf("outboundDispatcher.Forward: no transport found for serviceEndpoint: %s", uri)
+	}
+
+	req, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Forward: failed marshal to bytes: %w", err)
+	}
+
+	err = o.sendOrQueue(outboundTransport, &OutboxMessage{
+		MsgType:     messageType(req),
+		Message:     req,
+		Destination: des,
+		Forward:     true,
+	})
+	if err != nil {
+		return fmt.Errorf("outboundDispatcher.Forward: failed to send msg using outbound transport: %w", err)
 	}
 
-	return fmt.Errorf("outboundDispatcher.Forward: no transport found for serviceEndpoint: %s", uri)
+	return nil
 }
 
 func (o *Dispatcher) createForwardMessage(msg []byte, des *service.Destination) ([]byte, error) {
@@ -362,6 +767,10 @@ func (o *Dispatcher) createForwardMessage(msg []byte, des *service.Destination)
 		transport.MediaTypeAIP2RFC0587Profile, transport.MediaTypeV2PlaintextPayload, transport.MediaTypeDIDCommV2Profile:
 		// for DIDComm V2, do not set senderKey to force Anoncrypt packing. Only set the V2 forwardMsgType.
 		forwardMsgType = service.ForwardMsgTypeV2
+	case transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload:
+		// forward messages aren't signed by the sender, they're only encrypted for the mediators.
+		forwardMsgType = service.ForwardMsgTypeV2
+		mtProfile = transport.MediaTypeV2EncryptedEnvelope
 	default: // default is DIDComm V1
 		forwardMsgType = service.ForwardMsgType
 	}
@@ -403,9 +812,12 @@ func (o *Dispatcher) createPackedNestedForwards(msg []byte, routingKeys []string
 			Msg:  msg,
 		}
 
-		var err error
+		toKey, err := o.resolveRoutingKey(routingKeys[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve routing key: %w", err)
+		}
 
-		msg, err = o.packForward(forward, []string{routingKeys[i+1]}, mtProfile)
+		msg, err = o.packForward(forward, []string{toKey}, mtProfile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to pack forward msg: %w", err)
 		}
@@ -414,6 +826,57 @@ func (o *Dispatcher) createPackedNestedForwards(msg []byte, routingKeys []string
 	return msg, nil
 }
 
+// resolveRoutingKey returns the key to pack a forward message for. DIDComm V2 mediators may publish their routing
+// keys as DIDs (Coordinate Mediation 2.0), in which case the first keyAgreement of the resolved DID doc is used.
+// did:key and DID URL routing keys are returned as is.
+func (o *Dispatcher) resolveRoutingKey(key string) (string, error) {
+	if !strings.HasPrefix(key, "did:") || strings.HasPrefix(key, "did:key:") || strings.Contains(key, "#") {
+		return key, nil
+	}
+
+	docResolution, err := o.vdRegistry.Resolve(key)
+	if err != nil {
+		return "", fmt.Errorf("resolve routing DID %s: %w", key, err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	if len(doc.KeyAgreement) == 0 {
+		return "", fmt.Errorf("routing DID %s has no keyAgreement", key)
+	}
+
+	kaID := doc.KeyAgreement[0].VerificationMethod.ID
+	if strings.HasPrefix(kaID, "#") {
+		kaID = doc.ID + kaID
+	}
+
+	return kaID, nil
+}
+
+// selectDIDCommV2Endpoint picks the first DIDComm V2 endpoint of the destination accepted by an outbound transport.
+// A destination lists several endpoints when the recipient is registered with multiple mediators.
+func (o *Dispatcher) selectDIDCommV2Endpoint(des *service.Destination) *service.Destination {
+	endpoints := des.ServiceEndpoint.DIDCommV2Endpoints()
+	if len(endpoints) <= 1 {
+		return des
+	}
+
+	for _, ep := range endpoints {
+		for _, v := range o.outboundTransports {
+			if !v.AcceptRecipient(ep.RoutingKeys) && !v.Accept(ep.URI) {
+				continue
+			}
+
+			selected := *des
+			selected.ServiceEndpoint = commonmodel.NewDIDCommV2Endpoint([]commonmodel.DIDCommV2Endpoint{ep})
+
+			return &selected
+		}
+	}
+
+	return des
+}
+
 func (o *Dispatcher) packForward(fwd model.Forward, toKeys []string, mtProfile string) ([]byte, error) {
 	env := &model.Envelope{}
 
@@ -490,6 +953,23 @@ func (o *Dispatcher) addTransportRouteOptions(req []byte, des *service.Destinati
 	return req, nil
 }
 
+// acceptsMediaTypeProfile checks if the DIDComm V2 service endpoint, or else the DIDComm V1 media type profiles, of
+// the destination accept mtp.
+func acceptsMediaTypeProfile(des *service.Destination, mtp string) bool {
+	accept, err := des.ServiceEndpoint.Accept()
+	if err != nil || len(accept) == 0 {
+		accept = des.MediaTypeProfiles
+	}
+
+	for _, a := range accept {
+		if a == mtp {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (o *Dispatcher) mediaTypeProfile(des *service.Destination) string {
 	var (
 		mt     string
@@ -515,10 +995,11 @@ func (o *Dispatcher) mediaTypeProfile(des *service.Destination) string {
 				transport.MediaTypeAIP2RFC0587Profile:
 				mt = mtp
 			case transport.MediaTypeV2EncryptedEnvelope, transport.MediaTypeV2PlaintextPayload,
-				transport.MediaTypeDIDCommV2Profile:
+				transport.MediaTypeDIDCommV2Profile, transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload:
 				// V2 is the highest priority, if found use it directly.
 				return mtp
 			}
+			// transport.MediaTypeV2SignedEnvelope isn't encrypted, it's never picked (see SendSigned).
 		}
 	}
 
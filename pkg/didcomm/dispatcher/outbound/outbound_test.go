@@ -16,9 +16,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/middleware"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -28,6 +30,7 @@ import (
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
@@ -78,6 +81,91 @@ func TestOutBoundDispatcher_createPackedNestedForwards(t *testing.T) {
 		}))
 		packager.AssertExpectations(t)
 	})
+
+	t.Run("test send with nested forward message - DID routing key", func(t *testing.T) {
+		recKey1 := "recKey1"
+		routingDID := "did:example:mediator"
+		packager := &mockPackager{}
+		expectedRequest := `{"protected":"","iv":"","ciphertext":"","tag":""}`
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           packager,
+			outboundTransportsValue: []transport.OutboundTransport{&mockOutboundTransport{expectedRequest: expectedRequest}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			vdr: &mockvdr.MockVDRegistry{ResolveValue: &did.Doc{
+				ID: routingDID,
+				KeyAgreement: []did.Verification{*did.NewReferencedVerification(
+					&did.VerificationMethod{ID: "#key-1"}, did.KeyAgreement)},
+			}},
+		})
+		require.NoError(t, err)
+
+		packager.On("PackMessage", []string{recKey1}).Return([]byte(expectedRequest))
+		packager.On("PackMessage", []string{routingDID + "#key-1"}).Return([]byte(expectedRequest))
+
+		require.NoError(t, o.Send("data", "", &service.Destination{
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{
+				{URI: "url", RoutingKeys: []string{routingDID}},
+			}),
+			RecipientKeys: []string{recKey1},
+		}))
+		packager.AssertExpectations(t)
+	})
+
+	t.Run("test send with nested forward message - DID routing key resolve error", func(t *testing.T) {
+		packager := &mockPackager{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           packager,
+			outboundTransportsValue: []transport.OutboundTransport{&mockOutboundTransport{}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                     &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")},
+		})
+		require.NoError(t, err)
+
+		err = o.Send("data", "", &service.Destination{
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{
+				{URI: "url", RoutingKeys: []string{"did:example:mediator"}},
+			}),
+			RecipientKeys: []string{"recKey1"},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve routing DID did:example:mediator: resolve error")
+	})
+
+	t.Run("test send with nested forward message - pick among mediators", func(t *testing.T) {
+		recKey1 := "recKey1"
+		packager := &mockPackager{}
+		expectedRequest := `{"protected":"","iv":"","ciphertext":"","tag":""}`
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue: packager,
+			outboundTransportsValue: []transport.OutboundTransport{&mockOutboundTransport{
+				expectedRequest: expectedRequest,
+				acceptURL:       "ws://mediator2",
+			}},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		packager.On("PackMessage", []string{recKey1}).Return([]byte(expectedRequest))
+		packager.On("PackMessage", []string{"rtKey2"}).Return([]byte(expectedRequest))
+
+		require.NoError(t, o.Send("data", "", &service.Destination{
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{
+				{URI: "http://mediator1", RoutingKeys: []string{"rtKey1"}},
+				{URI: "ws://mediator2", RoutingKeys: []string{"rtKey2"}},
+			}),
+			RecipientKeys: []string{recKey1},
+		}))
+		packager.AssertExpectations(t)
+	})
 }
 
 func TestOutboundDispatcher_Send(t *testing.T) {
@@ -269,6 +357,101 @@ type mockMessage struct {
 	Type string
 }
 
+func TestOutboundDispatcher_SendSigned(t *testing.T) {
+	newOutbound := func(t *testing.T, packager transport.Packager) *Dispatcher {
+		t.Helper()
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           packager,
+			outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+		})
+		require.NoError(t, err)
+
+		return o
+	}
+
+	accept := []string{transport.MediaTypeV2SignedEnvelope, transport.MediaTypeDIDCommV2Profile}
+
+	t.Run("test signed envelope is never picked by Send", func(t *testing.T) {
+		packager := &profilePackager{}
+		o := newOutbound(t, packager)
+
+		require.NoError(t, o.Send("data", "did:example:alice#key-1", &service.Destination{
+			RecipientKeys:   []string{"recKey"},
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{URI: "url", Accept: accept}}),
+		}))
+
+		// without any other media type accepted, the default one is used.
+		require.NoError(t, o.Send("data", "did:example:alice#key-1", &service.Destination{
+			RecipientKeys: []string{"recKey"},
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{
+				URI: "url", Accept: []string{transport.MediaTypeV2SignedEnvelope},
+			}}),
+		}))
+
+		require.Equal(t, []string{transport.MediaTypeDIDCommV2Profile, transport.MediaTypeDIDCommV2Profile},
+			packager.profiles)
+	})
+
+	t.Run("test success", func(t *testing.T) {
+		packager := &profilePackager{}
+		o := newOutbound(t, packager)
+
+		require.NoError(t, o.SendSigned("data", "did:example:alice#key-1", &service.Destination{
+			RecipientKeys:   []string{"recKey"},
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{URI: "url", Accept: accept}}),
+		}))
+		require.Equal(t, []string{transport.MediaTypeV2SignedEnvelope}, packager.profiles)
+	})
+
+	t.Run("test forward messages of a signed envelope are encrypted", func(t *testing.T) {
+		packager := &profilePackager{}
+		o := newOutbound(t, packager)
+
+		require.NoError(t, o.SendSigned("data", "did:example:alice#key-1", &service.Destination{
+			RecipientKeys: []string{"recKey"},
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{
+				URI: "url", Accept: accept, RoutingKeys: []string{"rtKey"},
+			}}),
+		}))
+		require.Equal(t, []string{transport.MediaTypeV2SignedEnvelope, transport.MediaTypeDIDCommV2Profile},
+			packager.profiles)
+
+		// sign-then-encrypt messages are forwarded in anoncrypt envelopes, the sender doesn't sign them.
+		packager.profiles = nil
+
+		require.NoError(t, o.Send("data", "did:example:alice#key-1", &service.Destination{
+			RecipientKeys: []string{"recKey"},
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{
+				URI:         "url",
+				Accept:      []string{transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload},
+				RoutingKeys: []string{"rtKey"},
+			}}),
+		}))
+		require.Equal(t, []string{
+			transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload, transport.MediaTypeV2EncryptedEnvelope,
+		}, packager.profiles)
+	})
+
+	t.Run("test destination doesn't accept signed envelopes", func(t *testing.T) {
+		packager := &profilePackager{}
+		o := newOutbound(t, packager)
+
+		err := o.SendSigned("data", "did:example:alice#key-1", &service.Destination{
+			RecipientKeys: []string{"recKey"},
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{
+				URI: "url", Accept: []string{transport.MediaTypeDIDCommV2Profile},
+			}}),
+		})
+		require.EqualError(t, err, "outboundDispatcher.SendSigned: destination doesn't accept media type '"+
+			transport.MediaTypeV2SignedEnvelope+"'")
+		require.Empty(t, packager.profiles)
+	})
+}
+
 func TestOutboundDispatcher_SendToDID(t *testing.T) {
 	mockDoc := mockdiddoc.GetMockDIDDoc(t, false)
 
@@ -564,6 +747,202 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_SendToDIDs(t *testing.T) {
+	const (
+		myDID    = "did:example:me"
+		aliceDID = "did:example:alice"
+		bobDID   = "did:example:bob"
+		carolDID = "did:example:carol"
+		daveDID  = "did:example:dave"
+	)
+
+	docs := map[string]*did.Doc{
+		myDID:    createDIDCommV2Doc(myDID, "http://me"),
+		aliceDID: createDIDCommV2Doc(aliceDID, "http://mediator", "rtKey"),
+		bobDID:   createDIDCommV2Doc(bobDID, "http://mediator", "rtKey"),
+		carolDID: createDIDCommV2Doc(carolDID, "http://agency"),
+		daveDID:  createDIDCommV2Doc(daveDID, "http://agency"),
+	}
+
+	vdr := &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			doc, ok := docs[didID]
+			if !ok {
+				return nil, vdrapi.ErrNotFound
+			}
+
+			return &did.DocResolution{DIDDocument: doc}, nil
+		},
+	}
+
+	msg := service.DIDCommMsgMap{
+		"id":   "123",
+		"type": "https://didcomm.org/basicmessage/2.0/message",
+	}
+
+	t.Run("success - message is packed once per route", func(t *testing.T) {
+		packager := &mockPackager{}
+		outboundTransport := &recordingOutboundTransport{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           packager,
+			outboundTransportsValue: []transport.OutboundTransport{outboundTransport},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                     vdr,
+		})
+		require.NoError(t, err)
+
+		packager.On("PackMessage", []string{aliceDID + "#key-1", bobDID + "#key-1"}).
+			Return([]byte("alice-bob")).Once()
+		packager.On("PackMessage", []string{"rtKey"}).Return([]byte("forward")).Twice()
+		packager.On("PackMessage", []string{carolDID + "#key-1"}).Return([]byte("carol")).Once()
+
+		multiOutbound, ok := dispatcher.Outbound(o).(dispatcher.MultiOutbound)
+		require.True(t, ok)
+
+		require.NoError(t, multiOutbound.SendToDIDs(msg, myDID, []string{aliceDID, carolDID, bobDID, aliceDID}))
+		packager.AssertExpectations(t)
+
+		require.Len(t, outboundTransport.sent, 3)
+
+		// the message packed for alice and bob is forwarded to each of them through their mediator.
+		for i, recipientKey := range []string{aliceDID + "#key-1", bobDID + "#key-1"} {
+			require.Equal(t, "forward", string(outboundTransport.sent[i].data))
+			require.Equal(t, []string{recipientKey}, outboundTransport.sent[i].destination.RecipientKeys)
+		}
+
+		require.Equal(t, "carol", string(outboundTransport.sent[2].data))
+
+		uri, err := outboundTransport.sent[2].destination.ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "http://agency", uri)
+
+		// the original message isn't updated with the recipients of the routes.
+		require.NotContains(t, msg, "to")
+	})
+
+	t.Run("success - to header is set to the DIDs of the route", func(t *testing.T) {
+		outboundTransport := &recordingOutboundTransport{}
+
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockPackager{},
+			outboundTransportsValue: []transport.OutboundTransport{outboundTransport},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                     vdr,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, o.SendToDIDs(&msg, myDID, []string{carolDID, daveDID}))
+
+		// carol and dave are reached directly through the same endpoint, the message is sent once.
+		require.Len(t, outboundTransport.sent, 1)
+		require.Equal(t, []string{carolDID + "#key-1", daveDID + "#key-1"},
+			outboundTransport.sent[0].destination.RecipientKeys)
+
+		sentMsg, err := service.ParseDIDCommMsgMap(outboundTransport.sent[0].data)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{carolDID, daveDID}, sentMsg["to"])
+	})
+
+	t.Run("error - not a DIDComm V2 message", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:        &mockpackager.Packager{},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                  vdr,
+		})
+		require.NoError(t, err)
+
+		err = o.SendToDIDs(service.DIDCommMsgMap{"@id": "123", "@type": "abc"}, myDID, []string{aliceDID})
+		require.EqualError(t, err, "outboundDispatcher.SendToDIDs: only DIDComm V2 messages can be sent to "+
+			"multiple DIDs")
+	})
+
+	t.Run("error - resolve their DID", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockpackager.Packager{},
+			outboundTransportsValue: []transport.OutboundTransport{&recordingOutboundTransport{}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                     vdr,
+		})
+		require.NoError(t, err)
+
+		err = o.SendToDIDs(msg, myDID, []string{aliceDID, "did:example:unknown"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to resolve their DID [did:example:unknown]")
+	})
+
+	t.Run("error - resolve my DID", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:        &mockpackager.Packager{},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                  vdr,
+		})
+		require.NoError(t, err)
+
+		err = o.SendToDIDs(msg, "did:example:unknown", []string{aliceDID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to resolve my DID")
+	})
+
+	t.Run("error - no outbound transport found", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:        &mockpackager.Packager{},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                  vdr,
+		})
+		require.NoError(t, err)
+
+		err = o.SendToDIDs(msg, myDID, []string{aliceDID})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "outboundDispatcher.SendToDIDs: no transport found for destination")
+	})
+
+	t.Run("error - pack msg failure", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue:           &mockpackager.Packager{PackErr: errors.New("pack error")},
+			outboundTransportsValue: []transport.OutboundTransport{&recordingOutboundTransport{}},
+			storageProvider:         mockstore.NewMockStoreProvider(),
+			protoStorageProvider:    mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:       []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                     vdr,
+		})
+		require.NoError(t, err)
+
+		err = o.SendToDIDs(msg, myDID, []string{carolDID})
+		require.EqualError(t, err, "outboundDispatcher.SendToDIDs: failed to pack msg: pack error")
+	})
+
+	t.Run("error - outbound send failure", func(t *testing.T) {
+		o, err := NewOutbound(&mockProvider{
+			packagerValue: &mockpackager.Packager{},
+			outboundTransportsValue: []transport.OutboundTransport{
+				&mockdidcomm.MockOutboundTransport{AcceptValue: true, SendErr: errors.New("send error")},
+			},
+			storageProvider:      mockstore.NewMockStoreProvider(),
+			protoStorageProvider: mockstore.NewMockStoreProvider(),
+			mediaTypeProfiles:    []string{transport.MediaTypeDIDCommV2Profile},
+			vdr:                  vdr,
+		})
+		require.NoError(t, err)
+
+		err = o.SendToDIDs(msg, myDID, []string{carolDID})
+		require.EqualError(t, err, "outboundDispatcher.SendToDIDs: failed to send msg using outbound transport: "+
+			"send error")
+	})
+}
+
 func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 	t.Run("transport route option - value set all", func(t *testing.T) {
 		transportReturnRoute := "all"
@@ -735,6 +1114,34 @@ func TestOutboundDispatcher_Forward(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_Metrics(t *testing.T) {
+	m := &mockmetrics.MockMetrics{}
+
+	o, err := NewOutbound(&mockProvider{
+		packagerValue:           &mockpackager.Packager{},
+		outboundTransportsValue: []transport.OutboundTransport{&mockdidcomm.MockOutboundTransport{AcceptValue: true}},
+		storageProvider:         mockstore.NewMockStoreProvider(),
+		protoStorageProvider:    mockstore.NewMockStoreProvider(),
+		mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+		metrics:                 m,
+	})
+	require.NoError(t, err)
+
+	msg := service.NewDIDCommMsgMap(struct {
+		Type string `json:"@type"`
+	}{Type: "https://didcomm.org/trust_ping/1.0/ping"})
+
+	require.NoError(t, o.Send(msg, mockdiddoc.MockDIDKey(t), &service.Destination{
+		ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com"),
+	}))
+	require.Equal(t, 1, m.OutboundMessages("https://didcomm.org/trust_ping/1.0/ping", "http"))
+
+	require.NoError(t, o.Forward("data", &service.Destination{
+		ServiceEndpoint: model.NewDIDCommV1Endpoint("wss://example.com"),
+	}))
+	require.Equal(t, 1, m.OutboundMessages("unknown", "ws"))
+}
+
 func createPackedMsgForForward(_ *testing.T) []byte {
 	return []byte("")
 }
@@ -751,6 +1158,7 @@ type mockProvider struct {
 	mediaTypeProfiles       []string
 	keyAgreementType        kms.KeyType
 	didRotator              middleware.DIDCommMessageMiddleware
+	metrics                 metrics.Metrics
 }
 
 func (p *mockProvider) Packager() transport.Packager {
@@ -797,10 +1205,15 @@ func (p *mockProvider) DIDRotator() *middleware.DIDCommMessageMiddleware {
 	return &p.didRotator
 }
 
+func (p *mockProvider) Metrics() metrics.Metrics {
+	return p.metrics
+}
+
 // mockOutboundTransport mock outbound transport.
 type mockOutboundTransport struct {
 	expectedRequest string
 	acceptRecipient bool
+	acceptURL       string
 }
 
 func (o *mockOutboundTransport) Start(prov transport.Provider) error {
@@ -820,9 +1233,52 @@ func (o *mockOutboundTransport) AcceptRecipient([]string) bool {
 }
 
 func (o *mockOutboundTransport) Accept(url string) bool {
+	return o.acceptURL == "" || o.acceptURL == url
+}
+
+// recordingOutboundTransport accepts all destinations and records the messages sent to them.
+type recordingOutboundTransport struct {
+	sent []sentMessage
+}
+
+type sentMessage struct {
+	data        []byte
+	destination *service.Destination
+}
+
+func (o *recordingOutboundTransport) Start(prov transport.Provider) error {
+	return nil
+}
+
+func (o *recordingOutboundTransport) Send(data []byte, destination *service.Destination) (string, error) {
+	o.sent = append(o.sent, sentMessage{data: data, destination: destination})
+
+	return "", nil
+}
+
+func (o *recordingOutboundTransport) AcceptRecipient([]string) bool {
+	return false
+}
+
+func (o *recordingOutboundTransport) Accept(string) bool {
 	return true
 }
 
+// profilePackager records the media type profiles of the packed messages.
+type profilePackager struct {
+	profiles []string
+}
+
+func (p *profilePackager) PackMessage(e *transport.Envelope) ([]byte, error) {
+	p.profiles = append(p.profiles, e.MediaTypeProfile)
+
+	return e.Message, nil
+}
+
+func (p *profilePackager) UnpackMessage([]byte) (*transport.Envelope, error) {
+	return nil, nil
+}
+
 // mockPackager mock packager.
 type mockPackager struct {
 	mock.Mock
@@ -907,3 +1363,18 @@ func countDownMockResolveFunc(first interface{}, countFirst int, rest interface{
 		return &did.DocResolution{DIDDocument: firstDoc}, firstErr
 	}
 }
+
+func createDIDCommV2Doc(id, uri string, routingKeys ...string) *did.Doc {
+	return &did.Doc{
+		ID: id,
+		KeyAgreement: []did.Verification{*did.NewReferencedVerification(
+			&did.VerificationMethod{ID: "#key-1"}, did.KeyAgreement)},
+		Service: []did.Service{{
+			ID:   id + "#didcomm",
+			Type: vdrapi.DIDCommV2ServiceType,
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{
+				{URI: uri, RoutingKeys: routingKeys},
+			}),
+		}},
+	}
+}
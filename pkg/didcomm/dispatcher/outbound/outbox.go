@@ -0,0 +1,402 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// OutboxStoreName is the name of the store keeping the messages waiting for delivery.
+	OutboxStoreName = "outbox"
+	// DeadLetterStoreName is the name of the store keeping the messages which couldn't be delivered.
+	DeadLetterStoreName = "outbox_deadletter"
+
+	// OutboxEventName is the protocol name of the delivery status events (service.StateMsg) of the outbox.
+	OutboxEventName = "outbox"
+
+	// StateIDQueued is set on the event of a message which failed to be sent and was queued for retry.
+	StateIDQueued = "queued"
+	// StateIDRetryFailed is set on the event of a failed delivery retry.
+	StateIDRetryFailed = "retry-failed"
+	// StateIDDelivered is set on the event of a queued message which was delivered.
+	StateIDDelivered = "delivered"
+	// StateIDDeadLetter is set on the event of a message moved to the dead-letter store.
+	StateIDDeadLetter = "dead-letter"
+
+	// DefaultOutboxMaxAttempts is the default number of delivery attempts of a message.
+	DefaultOutboxMaxAttempts = 10
+	// DefaultOutboxInitialInterval is the default delay of the first delivery retry.
+	DefaultOutboxInitialInterval = time.Second
+	// DefaultOutboxMaxInterval is the default maximum delay between delivery retries.
+	DefaultOutboxMaxInterval = 5 * time.Minute
+
+	outboxTag = "outbox"
+)
+
+var (
+	errNoTransport    = errors.New("no transport found for destination")
+	errOutboxDisabled = errors.New("outbox is not enabled")
+)
+
+// OutboxMessage is a message kept in the outbox (or in the dead-letter store) together with its delivery state.
+type OutboxMessage struct {
+	ID          string               `json:"id"`
+	MsgID       string               `json:"msgID,omitempty"`
+	MsgType     string               `json:"msgType,omitempty"`
+	Message     []byte               `json:"message"`
+	Destination *service.Destination `json:"destination"`
+	Forward     bool                 `json:"forward,omitempty"`
+	Attempts    uint64               `json:"attempts"`
+	LastError   string               `json:"lastError,omitempty"`
+	QueuedTime  time.Time            `json:"queuedTime"`
+}
+
+// outboxConfig configures the durable outbox of the dispatcher.
+type outboxConfig struct {
+	maxAttempts     uint64
+	initialInterval time.Duration
+	maxInterval     time.Duration
+}
+
+// Opt is an option of the outbound dispatcher.
+type Opt func(o *Dispatcher)
+
+// WithOutbox enables the durable outbox of the dispatcher. Messages which couldn't be sent to their destination
+// are queued in the outbox store and their delivery is retried with exponential backoff (from initialInterval
+// up to maxInterval), in order per destination. After maxAttempts failed attempts the message is moved to the
+// dead-letter store (see Dispatcher.DeadLetterMessages). Delivery status is reported with service.StateMsg events
+// (see Dispatcher.RegisterMsgEvent), events are dropped if the channel isn't ready so it should be buffered.
+// Zero values are replaced by the defaults. Dispatcher.Close stops the delivery retries.
+func WithOutbox(maxAttempts uint64, initialInterval, maxInterval time.Duration) Opt {
+	return func(o *Dispatcher) {
+		o.outboxConfig = &outboxConfig{
+			maxAttempts:     maxAttempts,
+			initialInterval: initialInterval,
+			maxInterval:     maxInterval,
+		}
+
+		if o.outboxConfig.maxAttempts == 0 {
+			o.outboxConfig.maxAttempts = DefaultOutboxMaxAttempts
+		}
+
+		if o.outboxConfig.initialInterval == 0 {
+			o.outboxConfig.initialInterval = DefaultOutboxInitialInterval
+		}
+
+		if o.outboxConfig.maxInterval == 0 {
+			o.outboxConfig.maxInterval = DefaultOutboxMaxInterval
+		}
+	}
+}
+
+type outbox struct {
+	*outboxConfig
+	store       storage.Store
+	deadLetters storage.Store
+	send        func(m *OutboxMessage) error
+	notify      func(stateID string, m *OutboxMessage)
+
+	mutex  sync.Mutex
+	queues map[string][]*OutboxMessage
+	closed bool
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newOutbox(p storage.Provider, config *outboxConfig, send func(m *OutboxMessage) error,
+	notify func(stateID string, m *OutboxMessage)) (*outbox, error) {
+	store, err := p.OpenStore(OutboxStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open outbox store: %w", err)
+	}
+
+	err = p.SetStoreConfig(OutboxStoreName, storage.StoreConfiguration{TagNames: []string{outboxTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set outbox store config: %w", err)
+	}
+
+	deadLetters, err := p.OpenStore(DeadLetterStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("open dead-letter store: %w", err)
+	}
+
+	err = p.SetStoreConfig(DeadLetterStoreName, storage.StoreConfiguration{TagNames: []string{outboxTag}})
+	if err != nil {
+		return nil, fmt.Errorf("set dead-letter store config: %w", err)
+	}
+
+	return &outbox{
+		outboxConfig: config,
+		store:        store,
+		deadLetters:  deadLetters,
+		send:         send,
+		notify:       notify,
+		queues:       map[string][]*OutboxMessage{},
+		stop:         make(chan struct{}),
+	}, nil
+}
+
+// resume starts the delivery of messages left in the outbox store (e.g. by a previous run of the agent).
+func (o *outbox) resume() error {
+	messages, err := queryMessages(o.store)
+	if err != nil {
+		return fmt.Errorf("query outbox: %w", err)
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].QueuedTime.Before(messages[j].QueuedTime)
+	})
+
+	for _, m := range messages {
+		o.push(m)
+	}
+
+	return nil
+}
+
+// pending checks if there are messages waiting for delivery to the destination.
+func (o *outbox) pending(des *service.Destination) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return len(o.queues[destinationKey(des)]) > 0
+}
+
+// enqueue stores the message and queues it for delivery after previously queued messages of the destination.
+func (o *outbox) enqueue(m *OutboxMessage) error {
+	m.ID = uuid.New().String()
+	m.QueuedTime = time.Now()
+
+	if err := o.save(o.store, m); err != nil {
+		return fmt.Errorf("save message to outbox: %w", err)
+	}
+
+	o.notify(StateIDQueued, m)
+	o.push(m)
+
+	return nil
+}
+
+// close stops the delivery of queued messages and waits for the delivery goroutines to exit. Messages which
+// weren't delivered are kept in the outbox store and their delivery is resumed by the next run of the agent.
+func (o *outbox) close() {
+	o.mutex.Lock()
+
+	if o.closed {
+		o.mutex.Unlock()
+
+		return
+	}
+
+	o.closed = true
+	close(o.stop)
+	o.mutex.Unlock()
+
+	o.wg.Wait()
+}
+
+// deadLetterMessages returns the messages moved to the dead-letter store.
+func (o *outbox) deadLetterMessages() ([]*OutboxMessage, error) {
+	return queryMessages(o.deadLetters)
+}
+
+func (o *outbox) push(m *OutboxMessage) {
+	key := destinationKey(m.Destination)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.closed {
+		return
+	}
+
+	o.queues[key] = append(o.queues[key], m)
+
+	if len(o.queues[key]) == 1 {
+		o.wg.Add(1)
+
+		go o.deliverQueue(key)
+	}
+}
+
+// deliverQueue delivers messages of the destination one by one until the queue is empty or the outbox is closed.
+func (o *outbox) deliverQueue(key string) {
+	defer o.wg.Done()
+
+	for {
+		o.mutex.Lock()
+		m := o.queues[key][0]
+		o.mutex.Unlock()
+
+		if !o.deliver(m) {
+			o.mutex.Lock()
+			delete(o.queues, key)
+			o.mutex.Unlock()
+
+			return
+		}
+
+		o.mutex.Lock()
+		o.queues[key] = o.queues[key][1:]
+
+		if len(o.queues[key]) == 0 {
+			delete(o.queues, key)
+			o.mutex.Unlock()
+
+			return
+		}
+
+		o.mutex.Unlock()
+	}
+}
+
+// deliver retries the delivery of the message until it's delivered or moved to the dead-letter store,
+// false is returned if the outbox was closed before.
+func (o *outbox) deliver(m *OutboxMessage) bool {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = o.initialInterval
+	b.MaxInterval = o.maxInterval
+	b.MaxElapsedTime = 0
+	b.Reset()
+
+	for m.Attempts < o.maxAttempts {
+		timer := time.NewTimer(b.NextBackOff())
+
+		select {
+		case <-o.stop:
+			timer.Stop()
+
+			return false
+		case <-timer.C:
+		}
+
+		m.Attempts++
+
+		err := o.send(m)
+		if err == nil {
+			if e := o.store.Delete(m.ID); e != nil {
+				logger.Errorf("outbox: failed to delete delivered message %s: %s", m.ID, e)
+			}
+
+			o.notify(StateIDDelivered, m)
+
+			return true
+		}
+
+		m.LastError = err.Error()
+
+		if e := o.save(o.store, m); e != nil {
+			logger.Errorf("outbox: failed to save message %s: %s", m.ID, e)
+		}
+
+		o.notify(StateIDRetryFailed, m)
+	}
+
+	if err := o.save(o.deadLetters, m); err != nil {
+		logger.Errorf("outbox: failed to save message %s to dead-letter store: %s", m.ID, err)
+	}
+
+	if err := o.store.Delete(m.ID); err != nil {
+		logger.Errorf("outbox: failed to delete message %s: %s", m.ID, err)
+	}
+
+	o.notify(StateIDDeadLetter, m)
+
+	return true
+}
+
+func (o *outbox) save(store storage.Store, m *OutboxMessage) error {
+	src, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+
+	return store.Put(m.ID, src, storage.Tag{Name: outboxTag})
+}
+
+func queryMessages(store storage.Store) ([]*OutboxMessage, error) {
+	itr, err := store.Query(outboxTag)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if errClose := itr.Close(); errClose != nil {
+			logger.Errorf("failed to close outbox iterator: %s", errClose.Error())
+		}
+	}()
+
+	var messages []*OutboxMessage
+
+	for {
+		more, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !more {
+			return messages, nil
+		}
+
+		src, err := itr.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		m := &OutboxMessage{}
+
+		if err = json.Unmarshal(src, m); err != nil {
+			return nil, fmt.Errorf("unmarshal message: %w", err)
+		}
+
+		messages = append(messages, m)
+	}
+}
+
+// destinationKey identifies the destination by its service endpoint URI or by its recipient keys.
+func destinationKey(des *service.Destination) string {
+	uri, err := des.ServiceEndpoint.URI()
+	if err == nil && uri != "" {
+		return uri
+	}
+
+	return strings.Join(des.RecipientKeys, ",")
+}
+
+// outboxEventProperties are the properties of the delivery status events of the outbox.
+type outboxEventProperties struct {
+	m *OutboxMessage
+}
+
+func (p *outboxEventProperties) All() map[string]interface{} {
+	props := map[string]interface{}{
+		"outboxID":    p.m.ID,
+		"msgID":       p.m.MsgID,
+		"msgType":     p.m.MsgType,
+		"destination": destinationKey(p.m.Destination),
+		"attempts":    p.m.Attempts,
+	}
+
+	if p.m.LastError != "" {
+		props["error"] = p.m.LastError
+	}
+
+	return props
+}
@@ -0,0 +1,356 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outbound
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const testEventTimeout = 5 * time.Second
+
+func TestWithOutbox(t *testing.T) {
+	o := &Dispatcher{}
+
+	WithOutbox(0, 0, 0)(o)
+	require.Equal(t, uint64(DefaultOutboxMaxAttempts), o.outboxConfig.maxAttempts)
+	require.Equal(t, DefaultOutboxInitialInterval, o.outboxConfig.initialInterval)
+	require.Equal(t, DefaultOutboxMaxInterval, o.outboxConfig.maxInterval)
+
+	WithOutbox(3, time.Millisecond, time.Second)(o)
+	require.Equal(t, uint64(3), o.outboxConfig.maxAttempts)
+	require.Equal(t, time.Millisecond, o.outboxConfig.initialInterval)
+	require.Equal(t, time.Second, o.outboxConfig.maxInterval)
+}
+
+func TestOutbox(t *testing.T) {
+	t.Run("queue failed send and deliver it on retry", func(t *testing.T) {
+		ot := &flakyTransport{failures: 2}
+
+		o, events := newOutboxDispatcher(t, mem.NewProvider(), 5, time.Millisecond, ot)
+
+		require.NoError(t, o.Send(pingMsg("1"), mockdiddoc.MockDIDKey(t), testDestination()))
+
+		e := nextOutboxEvent(t, events, StateIDQueued)
+		props := e.Properties.All()
+		require.Equal(t, "1", props["msgID"])
+		require.Equal(t, "https://didcomm.org/trust_ping/1.0/ping", props["msgType"])
+		require.Equal(t, "https://example.com", props["destination"])
+		require.Equal(t, uint64(1), props["attempts"])
+		require.Equal(t, "send error", props["error"])
+
+		require.Equal(t, uint64(2), nextOutboxEvent(t, events, StateIDRetryFailed).Properties.All()["attempts"])
+		require.Equal(t, uint64(3), nextOutboxEvent(t, events, StateIDDelivered).Properties.All()["attempts"])
+
+		require.Equal(t, 1, ot.delivered())
+		requireNoPendingMessages(t, o)
+	})
+
+	t.Run("move message to dead-letter store after max attempts", func(t *testing.T) {
+		ot := &flakyTransport{failures: 10}
+
+		o, events := newOutboxDispatcher(t, mem.NewProvider(), 2, time.Millisecond, ot)
+
+		require.NoError(t, o.Forward("data", testDestination()))
+
+		nextOutboxEvent(t, events, StateIDQueued)
+		nextOutboxEvent(t, events, StateIDRetryFailed)
+
+		e := nextOutboxEvent(t, events, StateIDDeadLetter)
+		require.Equal(t, uint64(2), e.Properties.All()["attempts"])
+
+		deadLetters, err := o.DeadLetterMessages()
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		require.True(t, deadLetters[0].Forward)
+		require.Equal(t, "send error", deadLetters[0].LastError)
+		require.Equal(t, []byte(`"data"`), deadLetters[0].Message)
+
+		requireNoPendingMessages(t, o)
+	})
+
+	t.Run("deliver messages of a destination in order", func(t *testing.T) {
+		ot := &flakyTransport{failures: 1}
+
+		// long enough retry interval to send the second message before the first one is delivered
+		o, events := newOutboxDispatcher(t, mem.NewProvider(), 5, 500*time.Millisecond, ot)
+
+		require.NoError(t, o.Send(pingMsg("1"), mockdiddoc.MockDIDKey(t), testDestination()))
+		// queued behind the first message although the transport is available again
+		require.NoError(t, o.Send(pingMsg("2"), mockdiddoc.MockDIDKey(t), testDestination()))
+
+		require.Equal(t, "1", nextOutboxEvent(t, events, StateIDQueued).Properties.All()["msgID"])
+		require.Equal(t, "2", nextOutboxEvent(t, events, StateIDQueued).Properties.All()["msgID"])
+		require.Equal(t, "1", nextOutboxEvent(t, events, StateIDDelivered).Properties.All()["msgID"])
+		require.Equal(t, "2", nextOutboxEvent(t, events, StateIDDelivered).Properties.All()["msgID"])
+	})
+
+	t.Run("resume delivery of queued messages", func(t *testing.T) {
+		p := mem.NewProvider()
+
+		// message left in the outbox by a previous run of the agent
+		ob, err := newOutbox(p, &outboxConfig{maxAttempts: 1}, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, ob.save(ob.store, &OutboxMessage{
+			ID:          "id",
+			MsgID:       "1",
+			Message:     []byte("msg"),
+			Destination: testDestination(),
+			QueuedTime:  time.Now(),
+		}))
+
+		ot := &flakyTransport{}
+
+		_, events := newOutboxDispatcher(t, p, 5, time.Millisecond, ot)
+
+		require.Equal(t, "1", nextOutboxEvent(t, events, StateIDDelivered).Properties.All()["msgID"])
+		require.Equal(t, 1, ot.delivered())
+	})
+
+	t.Run("stop delivery retries on close", func(t *testing.T) {
+		p := mem.NewProvider()
+		ot := &flakyTransport{failures: 1}
+
+		o, events := newOutboxDispatcher(t, p, 5, time.Hour, ot)
+
+		require.NoError(t, o.Send(pingMsg("1"), mockdiddoc.MockDIDKey(t), testDestination()))
+		nextOutboxEvent(t, events, StateIDQueued)
+
+		require.NoError(t, o.Close())
+		require.NoError(t, o.Close())
+		require.False(t, o.outbox.pending(testDestination()))
+
+		// the message is kept in the outbox store for the next run
+		messages, err := queryMessages(o.outbox.store)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+
+		_, events = newOutboxDispatcher(t, p, 5, time.Millisecond, ot)
+
+		require.Equal(t, "1", nextOutboxEvent(t, events, StateIDDelivered).Properties.All()["msgID"])
+		require.Equal(t, 1, ot.delivered())
+	})
+
+	t.Run("events aren't blocking delivery", func(t *testing.T) {
+		ot := &flakyTransport{failures: 1}
+
+		o, err := NewOutbound(outboxProvider(mem.NewProvider(), ot), WithOutbox(5, time.Millisecond, time.Millisecond))
+		require.NoError(t, err)
+
+		// nobody is receiving from this channel
+		require.NoError(t, o.RegisterMsgEvent(make(chan service.StateMsg)))
+
+		require.NoError(t, o.Send(pingMsg("1"), mockdiddoc.MockDIDKey(t), testDestination()))
+
+		require.Eventually(t, func() bool {
+			return ot.delivered() == 1 && !o.outbox.pending(testDestination())
+		}, testEventTimeout, time.Millisecond)
+
+		require.NoError(t, o.Close())
+	})
+
+	t.Run("dead-letter messages of dispatcher without outbox", func(t *testing.T) {
+		o, err := NewOutbound(outboxProvider(mem.NewProvider(), &flakyTransport{}))
+		require.NoError(t, err)
+
+		_, err = o.DeadLetterMessages()
+		require.ErrorIs(t, err, errOutboxDisabled)
+		require.NoError(t, o.Close())
+	})
+
+	t.Run("no transport error isn't queued", func(t *testing.T) {
+		o, err := NewOutbound(outboxProvider(mem.NewProvider(), &flakyTransport{}),
+			WithOutbox(5, time.Millisecond, time.Millisecond))
+		require.NoError(t, err)
+
+		err = o.Send(pingMsg("1"), mockdiddoc.MockDIDKey(t), &service.Destination{
+			ServiceEndpoint: model.NewDIDCommV1Endpoint("unknown://example.com"),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no transport found for destination")
+	})
+
+	t.Run("error if cannot init outbox", func(t *testing.T) {
+		_, err := NewOutbound(outboxProvider(&mockstore.MockStoreProvider{
+			Store:         &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)},
+			FailNamespace: OutboxStoreName,
+		}, &flakyTransport{}), WithOutbox(5, time.Millisecond, time.Millisecond))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to init outbox")
+	})
+
+	t.Run("error if cannot resume outbox", func(t *testing.T) {
+		expected := errors.New("query error")
+
+		_, err := NewOutbound(outboxProvider(&mockstore.MockStoreProvider{
+			Store: &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry), ErrQuery: expected},
+		}, &flakyTransport{}), WithOutbox(5, time.Millisecond, time.Millisecond))
+		require.ErrorIs(t, err, expected)
+		require.Contains(t, err.Error(), "failed to resume outbox delivery")
+	})
+}
+
+func TestOutbox_redeliverRoutingKeys(t *testing.T) {
+	ot := &flakyTransport{acceptedKeys: []string{"routing-key"}}
+
+	o, err := NewOutbound(outboxProvider(mem.NewProvider(), ot))
+	require.NoError(t, err)
+
+	// the transport is selected by the routing keys of the destination, as its endpoint isn't accepted
+	require.NoError(t, o.redeliver(&OutboxMessage{
+		Message: []byte("data"),
+		Destination: &service.Destination{
+			RecipientKeys:   []string{"recipient-key"},
+			RoutingKeys:     []string{"routing-key"},
+			ServiceEndpoint: model.NewDIDCommV1Endpoint("https://mediator.example.com"),
+		},
+	}))
+
+	require.NoError(t, o.redeliver(&OutboxMessage{
+		Message: []byte("data"),
+		Destination: &service.Destination{
+			RecipientKeys: []string{"recipient-key"},
+			ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{
+				URI:         "https://mediator.example.com",
+				RoutingKeys: []string{"routing-key"},
+			}}),
+		},
+	}))
+
+	require.ErrorIs(t, o.redeliver(&OutboxMessage{
+		Message: []byte("data"),
+		Destination: &service.Destination{
+			RecipientKeys:   []string{"recipient-key"},
+			ServiceEndpoint: model.NewDIDCommV1Endpoint("https://mediator.example.com"),
+		},
+	}), errNoTransport)
+
+	require.Equal(t, 2, ot.delivered())
+}
+
+func newOutboxDispatcher(t *testing.T, p storage.Provider, maxAttempts uint64, interval time.Duration,
+	ot transport.OutboundTransport) (*Dispatcher, chan service.StateMsg) {
+	t.Helper()
+
+	o, err := NewOutbound(outboxProvider(p, ot))
+	require.NoError(t, err)
+
+	events := make(chan service.StateMsg, 10)
+	require.NoError(t, o.RegisterMsgEvent(events))
+
+	// the outbox is enabled after the event channel is registered so that no event of resumed messages is missed
+	WithOutbox(maxAttempts, interval, interval)(o)
+
+	o.outbox, err = newOutbox(p, o.outboxConfig, o.redeliver, o.notifyOutbox)
+	require.NoError(t, err)
+	require.NoError(t, o.outbox.resume())
+
+	return o, events
+}
+
+func outboxProvider(p storage.Provider, ot transport.OutboundTransport) *mockProvider {
+	return &mockProvider{
+		packagerValue:           &mockpackager.Packager{},
+		outboundTransportsValue: []transport.OutboundTransport{ot},
+		storageProvider:         p,
+		protoStorageProvider:    mem.NewProvider(),
+		mediaTypeProfiles:       []string{transport.MediaTypeV1PlaintextPayload},
+	}
+}
+
+func nextOutboxEvent(t *testing.T, events chan service.StateMsg, stateID string) service.StateMsg {
+	t.Helper()
+
+	select {
+	case e := <-events:
+		require.Equal(t, OutboxEventName, e.ProtocolName)
+		require.Equal(t, service.PostState, e.Type)
+		require.Equal(t, stateID, e.StateID)
+
+		return e
+	case <-time.After(testEventTimeout):
+		require.Fail(t, "timeout waiting for outbox event "+stateID)
+	}
+
+	return service.StateMsg{}
+}
+
+func requireNoPendingMessages(t *testing.T, o *Dispatcher) {
+	t.Helper()
+
+	messages, err := queryMessages(o.outbox.store)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
+
+func pingMsg(id string) service.DIDCommMsgMap {
+	return service.DIDCommMsgMap{
+		"@id":   id,
+		"@type": "https://didcomm.org/trust_ping/1.0/ping",
+	}
+}
+
+func testDestination() *service.Destination {
+	return &service.Destination{
+		ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com"),
+	}
+}
+
+// flakyTransport fails to send the first messages. It accepts destinations by their endpoint or, if set, by the
+// accepted keys.
+type flakyTransport struct {
+	mutex        sync.Mutex
+	failures     int
+	sendCount    int
+	acceptedKeys []string
+}
+
+func (f *flakyTransport) Start(transport.Provider) error {
+	return nil
+}
+
+func (f *flakyTransport) Send([]byte, *service.Destination) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.sendCount++
+
+	if f.sendCount <= f.failures {
+		return "", errors.New("send error")
+	}
+
+	return "", nil
+}
+
+func (f *flakyTransport) AcceptRecipient(keys []string) bool {
+	return len(f.acceptedKeys) > 0 && reflect.DeepEqual(keys, f.acceptedKeys)
+}
+
+func (f *flakyTransport) Accept(url string) bool {
+	return url == "https://example.com"
+}
+
+func (f *flakyTransport) delivered() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.sendCount - f.failures
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -22,6 +23,9 @@ import (
 // MessengerStore is messenger store name.
 const MessengerStore = "messenger_store"
 
+// recordTTL is how long an inbound message payload is kept to allow replying to the message.
+const recordTTL = 7 * 24 * time.Hour
+
 // record is an internal structure and keeps payload about inbound message.
 type record struct {
 	MyDID          string `json:"my_did,omitempty"`
@@ -194,7 +198,11 @@ func (m *Messenger) saveRecord(msgID string, rec record) error {
 		return fmt.Errorf("marshal record: %w", err)
 	}
 
-	return m.store.Put(msgID, src)
+	return m.store.Batch([]storage.Operation{{
+		Key:        msgID,
+		Value:      src,
+		PutOptions: &storage.PutOptions{ExpiresAt: time.Now().Add(recordTTL)},
+	}})
 }
 
 // fillNestedReplyOption prefills missing nested reply options from record.
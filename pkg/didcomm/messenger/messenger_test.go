@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -19,6 +20,7 @@ import (
 	dispatcherMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/dispatcher"
 	messengerMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/messenger"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -73,7 +75,13 @@ func TestMessenger_HandleInbound(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		store := storageMocks.NewMockStore(ctrl)
-		store.EXPECT().Put(ID, gomock.Any()).Return(nil)
+		store.EXPECT().Batch(gomock.Any()).DoAndReturn(func(operations []storage.Operation) error {
+			require.Len(t, operations, 1)
+			require.Equal(t, ID, operations[0].Key)
+			require.True(t, operations[0].PutOptions.ExpiresAt.After(time.Now()))
+
+			return nil
+		})
 
 		storageProvider := storageMocks.NewMockProvider(ctrl)
 		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
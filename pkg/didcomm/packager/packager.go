@@ -14,10 +14,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
@@ -32,9 +34,11 @@ import (
 )
 
 const (
-	authSuffix                = "-authcrypt"
-	jsonWebKey2020            = "JsonWebKey2020"
-	x25519KeyAgreementKey2019 = "X25519KeyAgreementKey2019"
+	authSuffix                 = "-authcrypt"
+	jsonWebKey2020             = "JsonWebKey2020"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
 )
 
 var logger = log.New("aries-framework/pkg/didcomm/packager")
@@ -54,6 +58,7 @@ type Packager struct {
 	primaryPacker packer.Packer
 	packers       map[string]packer.Packer
 	vdrRegistry   vdr.Registry
+	metrics       metrics.Metrics
 }
 
 // PackerCreator holds a creator function for a Packer and the name of the Packer's encoding method.
@@ -68,6 +73,7 @@ func New(ctx Provider) (*Packager, error) {
 		primaryPacker: nil,
 		packers:       map[string]packer.Packer{},
 		vdrRegistry:   ctx.VDRegistry(),
+		metrics:       metrics.FromProvider(ctx),
 	}
 
 	for _, packerType := range ctx.Packers() {
@@ -117,14 +123,146 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 		return nil, fmt.Errorf("packMessage: %w", err)
 	}
 
+	signed := isSignedMediaType(messageEnvelope.MediaTypeProfile)
+
+	if signed {
+		senderKey, err = bp.prepareSignerKey(messageEnvelope)
+		if err != nil {
+			return nil, fmt.Errorf("packMessage: %w", err)
+		}
+	}
+
+	start := time.Now()
 	marshalledEnvelope, err := p.Pack(cty, messageEnvelope.Message, senderKey, recipients)
+
+	bp.metrics.PackTime(p.EncodingType(), time.Since(start))
+
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: failed to pack: %w", err)
 	}
 
+	if signed && messageEnvelope.MediaTypeProfile == transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload {
+		return bp.encryptSignedEnvelope(marshalledEnvelope, recipients)
+	}
+
 	return marshalledEnvelope, nil
 }
 
+func isSignedMediaType(mediaTypeProfile string) bool {
+	return mediaTypeProfile == transport.MediaTypeV2SignedEnvelope ||
+		mediaTypeProfile == transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload
+}
+
+// encryptSignedEnvelope packs a signed envelope in an anoncrypt envelope (sign-then-encrypt) for the recipients. The
+// sender is not revealed by the encrypted envelope as it is authenticated by the signature of the nested envelope.
+func (bp *Packager) encryptSignedEnvelope(signedEnvelope []byte, recipients [][]byte) ([]byte, error) {
+	p, ok := bp.packers[transport.MediaTypeV2EncryptedEnvelope]
+	if !ok {
+		return nil, errors.New("packMessage: no anoncrypt packer found to encrypt the signed envelope")
+	}
+
+	start := time.Now()
+	marshalledEnvelope, err := p.Pack(transport.MediaTypeV2SignedEnvelope, signedEnvelope, nil, recipients)
+
+	bp.metrics.PackTime(p.EncodingType(), time.Since(start))
+
+	if err != nil {
+		return nil, fmt.Errorf("packMessage: failed to encrypt signed envelope: %w", err)
+	}
+
+	return marshalledEnvelope, nil
+}
+
+// prepareSignerKey returns the sender key of a signed envelope: the kms kid of the sender's authentication key followed
+// by '.' and the ID of its verification method. The sender's DID doc is resolved from the DID of envelope.FromKey,
+// which is either a did:key, a DID or a DID URL, and the authentication key is the verification method referenced by
+// envelope.FromKey if it is one, or else the first authentication verification method.
+func (bp *Packager) prepareSignerKey(envelope *transport.Envelope) ([]byte, error) {
+	fromKey := string(envelope.FromKey)
+	senderDID := fromKey
+
+	if i := strings.Index(fromKey, "#"); i > 0 {
+		senderDID = fromKey[:i]
+	}
+
+	if !strings.HasPrefix(senderDID, "did:") {
+		return nil, fmt.Errorf("prepareSignerKey: sender key '%s' is not a DID", fromKey)
+	}
+
+	docResolution, err := bp.vdrRegistry.Resolve(senderDID)
+	if err != nil {
+		return nil, fmt.Errorf("prepareSignerKey: for sender DID doc resolution %w", err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	if len(doc.Authentication) == 0 {
+		return nil, fmt.Errorf("prepareSignerKey: DID '%s' has no authentication key", doc.ID)
+	}
+
+	vm := &doc.Authentication[0].VerificationMethod
+
+	for i := range doc.Authentication {
+		if absoluteVerificationMethodID(doc, &doc.Authentication[i].VerificationMethod) == fromKey {
+			vm = &doc.Authentication[i].VerificationMethod
+
+			break
+		}
+	}
+
+	senderKMSKID, err := signingKeyKMSKID(vm)
+	if err != nil {
+		return nil, fmt.Errorf("prepareSignerKey: %w", err)
+	}
+
+	return []byte(senderKMSKID + "." + absoluteVerificationMethodID(doc, vm)), nil
+}
+
+func absoluteVerificationMethodID(doc *did.Doc, vm *did.VerificationMethod) string {
+	if strings.HasPrefix(vm.ID, "#") {
+		return doc.ID + vm.ID
+	}
+
+	return vm.ID
+}
+
+func signingKeyKMSKID(vm *did.VerificationMethod) (string, error) {
+	var (
+		pubKey  []byte
+		keyType kms.KeyType
+		err     error
+	)
+
+	switch vm.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		pubKey, keyType = vm.Value, kms.ED25519Type
+	case jsonWebKey2020:
+		jwkKey := vm.JSONWebKey()
+		if jwkKey == nil {
+			return "", fmt.Errorf("verification method '%s' has no JWK", vm.ID)
+		}
+
+		keyType, err = jwkKey.KeyType()
+		if err != nil {
+			return "", fmt.Errorf("verification method '%s' key type: %w", vm.ID, err)
+		}
+
+		pubKey, err = jwkKey.PublicKeyBytes()
+		if err != nil {
+			return "", fmt.Errorf("verification method '%s' public key: %w", vm.ID, err)
+		}
+	default:
+		return "", fmt.Errorf("unsupported authentication verification method type: %s", vm.Type)
+	}
+
+	kid, err := jwkkid.CreateKID(pubKey, keyType)
+	if err != nil {
+		return "", fmt.Errorf("for sender KMS KID: %w", err)
+	}
+
+	return kid, nil
+}
+
 //nolint:funlen,gocyclo,gocognit
 func (bp *Packager) prepareSenderAndRecipientKeys(cty string, envelope *transport.Envelope) ([]byte, [][]byte, error) {
 	var recipients [][]byte
@@ -278,6 +416,8 @@ func buildSenderKID(senderPubKey *crypto.PublicKey, envelopeSenderKey *transport
 
 type envelopeStub struct {
 	Protected string `json:"protected,omitempty"`
+	// Signatures of a JWS envelope in JWS JSON Serialization (general syntax).
+	Signatures []envelopeStub `json:"signatures,omitempty"`
 }
 
 type headerStub struct {
@@ -334,6 +474,10 @@ func getEncodingType(encMessage []byte) (string, []byte, error) {
 		}
 	}
 
+	if env.Protected == "" && len(env.Signatures) > 0 {
+		env.Protected = env.Signatures[0].Protected
+	}
+
 	var protBytes []byte
 
 	protBytes1, err1 := base64.URLEncoding.DecodeString(env.Protected)
@@ -382,14 +526,51 @@ func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error
 		encMessage = b64DecodedMessage
 	}
 
+	start := time.Now()
 	envelope, err := p.Unpack(encMessage)
+
+	bp.metrics.UnpackTime(p.EncodingType(), time.Since(start))
+
 	if err != nil {
 		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
+	if encType == transport.MediaTypeV2EncryptedEnvelope {
+		return bp.unpackNestedSignedEnvelope(envelope)
+	}
+
 	return envelope, nil
 }
 
+// unpackNestedSignedEnvelope unpacks the signed envelope nested in an anoncrypt envelope (sign-then-encrypt), if any.
+// The sender of the returned envelope is the signer of the nested envelope.
+func (bp *Packager) unpackNestedSignedEnvelope(envelope *transport.Envelope) (*transport.Envelope, error) {
+	encType, _, err := getEncodingType(envelope.Message)
+	if err != nil || encType != transport.MediaTypeV2SignedEnvelope {
+		return envelope, nil
+	}
+
+	p, ok := bp.packers[encType]
+	if !ok {
+		return nil, errors.New("unpack: no signed packer found for the nested signed envelope")
+	}
+
+	start := time.Now()
+	signedEnvelope, err := p.Unpack(envelope.Message)
+
+	bp.metrics.UnpackTime(p.EncodingType(), time.Since(start))
+
+	if err != nil {
+		return nil, fmt.Errorf("unpack nested signed envelope: %w", err)
+	}
+
+	return &transport.Envelope{
+		Message: signedEnvelope.Message,
+		FromKey: signedEnvelope.FromKey,
+		ToKey:   envelope.ToKey,
+	}, nil
+}
+
 func (bp *Packager) getCTYAndPacker(envelope *transport.Envelope) (string, packer.Packer, error) {
 	switch envelope.MediaTypeProfile {
 	case transport.MediaTypeAIP2RFC0019Profile, transport.MediaTypeProfileDIDCommAIP1:
@@ -409,6 +590,14 @@ func (bp *Packager) getCTYAndPacker(envelope *transport.Envelope) (string, packe
 		packerName := addAuthcryptSuffix(envelope.FromKey, transport.MediaTypeV2EncryptedEnvelope)
 
 		return transport.MediaTypeV1PlaintextPayload, bp.packers[packerName], nil
+	case transport.MediaTypeV2SignedEnvelope, transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload:
+		// the signed envelope is encrypted afterwards for MediaTypeV2EncryptedEnvelopeV2SignedPayload.
+		p, ok := bp.packers[transport.MediaTypeV2SignedEnvelope]
+		if !ok {
+			return "", nil, fmt.Errorf("no signed packer found for mediatype profile: '%v'", envelope.MediaTypeProfile)
+		}
+
+		return transport.MediaTypeV2PlaintextPayload, p, nil
 	default:
 		// use primaryPacker if mediaProfile not registered.
 		if bp.primaryPacker != nil {
@@ -489,7 +678,7 @@ func marshalKeyFromVerificationMethod(keyAgrID string, vm *did.VerificationMetho
 			Curve: "X25519",
 			Type:  "OKP",
 		}
-	case "Ed25519VerificationKey2018":
+	case ed25519VerificationKey2018:
 		recKey = &crypto.PublicKey{
 			KID:   keyAgrID,
 			X:     vm.Value,
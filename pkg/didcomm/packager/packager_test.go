@@ -8,18 +8,22 @@ package packager_test
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/signed"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -30,6 +34,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -525,6 +530,117 @@ func packUnPackSuccess(keyType kms.KeyType, customKMS kms.KeyManager, cryptoSvc
 	}
 }
 
+func TestPackager_SignedEnvelopes(t *testing.T) {
+	customKMS, err := localkms.New(localKeyURI, newMockKMSProvider(mockstorage.NewMockStoreProvider(), t))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	resolveDIDFunc, _, _, fromDID, toDID := newDIDsAndDIDDocResolverFunc(customKMS, kms.X25519ECDHKWType, t)
+
+	_, authKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	authVM := did.NewVerificationMethodFromBytes(fromDID.ID+"#auth-1", "Ed25519VerificationKey2018", fromDID.ID,
+		authKey)
+	fromDID.Authentication = []did.Verification{*did.NewReferencedVerification(authVM, did.Authentication)}
+
+	mockedProviders := &mockProvider{
+		kms:    customKMS,
+		crypto: cryptoSvc,
+		vdr: &mockvdr.MockVDRegistry{
+			ResolveFunc: resolveDIDFunc,
+		},
+	}
+
+	anonPacker, err := anoncrypt.New(mockedProviders, jose.A256GCM)
+	require.NoError(t, err)
+
+	signedPacker, err := signed.New(mockedProviders)
+	require.NoError(t, err)
+
+	mockedProviders.primaryPacker = anonPacker
+	mockedProviders.packers = []packer.Packer{anonPacker, signedPacker}
+
+	packager, err := New(mockedProviders)
+	require.NoError(t, err)
+
+	t.Run("success - signed envelope", func(t *testing.T) {
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV2SignedEnvelope,
+			Message:          []byte("msg"),
+			FromKey:          []byte(fromDID.KeyAgreement[0].VerificationMethod.ID),
+		})
+		require.NoError(t, err)
+		require.Contains(t, string(packMsg), `"signatures"`)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg"), unpackedMsg.Message)
+
+		senderKey := &cryptoapi.PublicKey{}
+		require.NoError(t, json.Unmarshal(unpackedMsg.FromKey, senderKey))
+		require.Equal(t, authVM.ID, senderKey.KID)
+		require.Equal(t, authKey, senderKey.X)
+	})
+
+	t.Run("success - signed then encrypted envelope", func(t *testing.T) {
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload,
+			Message:          []byte("msg"),
+			FromKey:          []byte(fromDID.ID),
+			ToKeys:           []string{toDID.KeyAgreement[0].VerificationMethod.ID},
+		})
+		require.NoError(t, err)
+		require.NotContains(t, string(packMsg), `"signatures"`)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg"), unpackedMsg.Message)
+		require.NotEmpty(t, unpackedMsg.ToKey)
+
+		senderKey := &cryptoapi.PublicKey{}
+		require.NoError(t, json.Unmarshal(unpackedMsg.FromKey, senderKey))
+		require.Equal(t, authVM.ID, senderKey.KID)
+	})
+
+	t.Run("fail - sender without authentication key", func(t *testing.T) {
+		_, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV2SignedEnvelope,
+			Message:          []byte("msg"),
+			FromKey:          []byte(toDID.KeyAgreement[0].VerificationMethod.ID),
+		})
+		require.EqualError(t, err, "packMessage: prepareSignerKey: DID '"+toDID.ID+"' has no authentication key")
+	})
+
+	t.Run("fail - sender key is not a DID", func(t *testing.T) {
+		_, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV2SignedEnvelope,
+			Message:          []byte("msg"),
+			FromKey:          []byte("key-1"),
+		})
+		require.EqualError(t, err, "packMessage: prepareSignerKey: sender key 'key-1' is not a DID")
+	})
+
+	t.Run("fail - no signed packer", func(t *testing.T) {
+		mockedProviders.packers = []packer.Packer{anonPacker}
+
+		defer func() { mockedProviders.packers = []packer.Packer{anonPacker, signedPacker} }()
+
+		p, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		_, err = p.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV2SignedEnvelope,
+			Message:          []byte("msg"),
+			FromKey:          []byte(fromDID.ID),
+		})
+		require.EqualError(t, err, "packMessage: no signed packer found for mediatype profile: '"+
+			transport.MediaTypeV2SignedEnvelope+"'")
+	})
+}
+
 func TestPackagerLegacyInterop(t *testing.T) {
 	customKMS, err := localkms.New(localKeyURI, newMockKMSProvider(mockstorage.NewMockStoreProvider(), t))
 	require.NoError(t, err)
@@ -535,6 +651,8 @@ func TestPackagerLegacyInterop(t *testing.T) {
 
 	resolveLegacyDIDFunc, legacyFromDID, legacyToDID := newLegacyDIDsAndDIDDocResolverFunc(t, customKMS)
 
+	packagerMetrics := &mockmetrics.MockMetrics{}
+
 	mockedProviders := &mockProvider{
 		kms:           customKMS,
 		primaryPacker: nil,
@@ -544,6 +662,7 @@ func TestPackagerLegacyInterop(t *testing.T) {
 		vdr: &mockvdr.MockVDRegistry{
 			ResolveFunc: resolveLegacyDIDFunc,
 		},
+		metrics: packagerMetrics,
 	}
 
 	legacyPacker := legacy.New(mockedProviders)
@@ -596,6 +715,9 @@ func TestPackagerLegacyInterop(t *testing.T) {
 			require.Equal(t, unpackedMsg.Message, []byte("msg"))
 		})
 	}
+
+	require.Equal(t, len(tests), packagerMetrics.PackTimes(legacyPacker.EncodingType()))
+	require.Equal(t, len(tests), packagerMetrics.UnpackTimes(legacyPacker.EncodingType()))
 }
 
 func TestPackager_PackMessage_DIDKey_Failures(t *testing.T) {
@@ -889,6 +1011,7 @@ type mockProvider struct {
 	packers       []packer.Packer
 	primaryPacker packer.Packer
 	vdr           vdrapi.Registry
+	metrics       metrics.Metrics
 }
 
 func (m *mockProvider) Packers() []packer.Packer {
@@ -919,3 +1042,7 @@ func (m *mockProvider) VDRegistry() vdrapi.Registry {
 func (m *mockProvider) Crypto() cryptoapi.Crypto {
 	return m.crypto
 }
+
+func (m *mockProvider) Metrics() metrics.Metrics {
+	return m.metrics
+}
@@ -0,0 +1,284 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// Package signed includes a Packer implementation to build and parse DIDComm V2 signed messages, ie JWS envelopes
+// in JWS JSON Serialization. Signed messages are not encrypted, they provide non-repudiation of the message by its
+// signers. The signature 'kid' headers reference authentication verification methods of the signers' DID docs.
+
+// Packer represents a signed Pack/Unpacker that outputs/reads DIDComm V2 signed envelopes.
+type Packer struct {
+	kms           kms.KeyManager
+	cryptoService cryptoapi.Crypto
+	vdr           vdrapi.Registry
+	verifiers     []verifier.SignatureVerifier
+}
+
+// New will create a Packer instance to sign payloads with a sender key from the KMS and to verify signed envelopes
+// with the signers keys found in their DID docs resolved through the VDR registry.
+func New(ctx packer.Provider) (*Packer, error) {
+	k := ctx.KMS()
+	if k == nil {
+		return nil, errors.New("signed: failed to create packer because KMS is empty")
+	}
+
+	c := ctx.Crypto()
+	if c == nil {
+		return nil, errors.New("signed: failed to create packer because crypto service is empty")
+	}
+
+	vdrReg := ctx.VDRegistry()
+	if vdrReg == nil {
+		return nil, errors.New("signed: failed to create packer because vdr registry is empty")
+	}
+
+	return &Packer{
+		kms:           k,
+		cryptoService: c,
+		vdr:           vdrReg,
+		verifiers: []verifier.SignatureVerifier{
+			verifier.NewEd25519SignatureVerifier(),
+			verifier.NewECDSAES256SignatureVerifier(),
+			verifier.NewECDSAES384SignatureVerifier(),
+			verifier.NewECDSAES521SignatureVerifier(),
+			verifier.NewECDSASecp256k1SignatureVerifier(),
+		},
+	}, nil
+}
+
+// Pack will sign the payload argument with contentType argument as a JWS envelope in JWS JSON Serialization
+// with the following arguments:
+// payload: the payload message that will be signed
+// senderID: the kms kid of the sender signing key followed by '.' and the ID of the verification method of the key
+// in the sender's DID doc authentication (eg: 'kmsKID.did:example:123#key-1'), which is set as the signature 'kid'.
+// recipientsPubKeys: not used as signed envelopes are not encrypted.
+func (p *Packer) Pack(contentType string, payload, senderID []byte, _ [][]byte) ([]byte, error) {
+	idx := strings.Index(string(senderID), ".")
+	if idx <= 0 {
+		return nil, errors.New("signed Pack: sender ID must be the sender kms kid and verification method ID " +
+			"separated by '.'")
+	}
+
+	kmsKID, kid := string(senderID[:idx]), string(senderID[idx+1:])
+
+	_, keyType, err := p.kms.ExportPubKeyBytes(kmsKID)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to export sender public key: %w", err)
+	}
+
+	alg, err := jwsAlgorithm(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: %w", err)
+	}
+
+	kh, err := p.kms.Get(kmsKID)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to get sender key from KMS: %w", err)
+	}
+
+	protectedHeaders := jose.Headers{jose.HeaderType: p.EncodingType()}
+
+	if contentType != "" {
+		protectedHeaders[jose.HeaderContentType] = contentType
+	}
+
+	jws, err := jose.NewMultiSignatureJWS(payload, jose.JWSSigner{
+		Signer: &kmsSigner{
+			cryptoService: p.cryptoService,
+			kh:            kh,
+			headers:       jose.Headers{jose.HeaderAlgorithm: alg, jose.HeaderKeyID: kid},
+		},
+		ProtectedHeaders: protectedHeaders,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to sign payload: %w", err)
+	}
+
+	s, err := jws.SerializeJSON(false)
+	if err != nil {
+		return nil, fmt.Errorf("signed Pack: failed to serialize JWS message: %w", err)
+	}
+
+	return []byte(s), nil
+}
+
+func jwsAlgorithm(keyType kms.KeyType) (string, error) {
+	switch keyType {
+	case kms.ED25519Type:
+		return "EdDSA", nil
+	case kms.ECDSAP256TypeIEEEP1363:
+		return "ES256", nil
+	case kms.ECDSAP384TypeIEEEP1363:
+		return "ES384", nil
+	case kms.ECDSAP521TypeIEEEP1363:
+		return "ES521", nil
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		return "ES256K", nil
+	default:
+		return "", fmt.Errorf("unsupported signing key type: %s", keyType)
+	}
+}
+
+// Unpack will verify the signatures of the JWS envelope, all of them must be valid. The signers keys are resolved
+// from the 'kid' headers through the VDR registry. The returned envelope FromKey is the marshalled public key of the
+// first signer with its KID set as the signature 'kid' (ie the verification method ID in the signer's DID doc).
+func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
+	var signerKey *verifier.PublicKey
+
+	jws, err := jose.ParseJWS(string(envelope), jose.SignatureVerifierFunc(
+		func(joseHeaders jose.Headers, _, signingInput, signature []byte) error {
+			pubKey, e := p.verify(joseHeaders, signingInput, signature)
+			if e == nil && signerKey == nil {
+				signerKey = pubKey
+			}
+
+			return e
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("signed Unpack: failed to parse JWS envelope: %w", err)
+	}
+
+	kid, _ := jws.Signatures()[0].Headers().KeyID()
+
+	senderKey, err := marshalSenderKey(kid, signerKey)
+	if err != nil {
+		return nil, fmt.Errorf("signed Unpack: %w", err)
+	}
+
+	return &transport.Envelope{
+		Message: jws.Payload,
+		FromKey: senderKey,
+	}, nil
+}
+
+func marshalSenderKey(kid string, pubKey *verifier.PublicKey) ([]byte, error) {
+	var (
+		senderKey = &cryptoapi.PublicKey{}
+		err       error
+	)
+
+	switch {
+	case pubKey.JWK != nil:
+		senderKey, err = jwksupport.PublicKeyFromJWK(pubKey.JWK)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build sender public key: %w", err)
+		}
+	case strings.HasPrefix(pubKey.Type, "Ed25519VerificationKey"):
+		senderKey.X = pubKey.Value
+		senderKey.Curve = "Ed25519"
+		senderKey.Type = "OKP"
+	}
+
+	senderKey.KID = kid
+
+	mSenderKey, err := json.Marshal(senderKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sender public key: %w", err)
+	}
+
+	return mSenderKey, nil
+}
+
+// verify verifies the signature with the key resolved from the 'kid' header and returns the key.
+func (p *Packer) verify(joseHeaders jose.Headers, signingInput, signature []byte) (*verifier.PublicKey, error) {
+	alg, ok := joseHeaders.Algorithm()
+	if !ok {
+		return nil, errors.New("'alg' JOSE header is not present")
+	}
+
+	kid, ok := joseHeaders.KeyID()
+	if !ok {
+		return nil, errors.New("'kid' JOSE header is not present")
+	}
+
+	pubKey, err := p.resolveKey(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range p.verifiers {
+		if v.Algorithm() == alg {
+			return pubKey, v.Verify(pubKey, signingInput, signature)
+		}
+	}
+
+	return nil, fmt.Errorf("no verifier found for %s algorithm", alg)
+}
+
+// resolveKey resolves kid into the public key of the authentication verification method with ID kid.
+func (p *Packer) resolveKey(kid string) (*verifier.PublicKey, error) {
+	i := strings.Index(kid, "#")
+	if i <= 0 {
+		return nil, fmt.Errorf("kid '%s' is not a DID URL", kid)
+	}
+
+	docResolution, err := p.vdr.Resolve(kid[:i])
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve signer DID: %w", err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	vm, found := authenticationMethod(doc, kid)
+	if !found {
+		return nil, fmt.Errorf("kid '%s' is not an authentication verification method of %s", kid, doc.ID)
+	}
+
+	return &verifier.PublicKey{
+		Type:  vm.Type,
+		Value: vm.Value,
+		JWK:   vm.JSONWebKey(),
+	}, nil
+}
+
+func authenticationMethod(doc *did.Doc, kid string) (*did.VerificationMethod, bool) {
+	for i := range doc.Authentication {
+		vm := &doc.Authentication[i].VerificationMethod
+
+		if vm.ID == kid || strings.HasPrefix(vm.ID, "#") && doc.ID+vm.ID == kid {
+			return vm, true
+		}
+	}
+
+	return nil, false
+}
+
+// EncodingType for didcomm.
+func (p *Packer) EncodingType() string {
+	return transport.MediaTypeV2SignedEnvelope
+}
+
+type kmsSigner struct {
+	cryptoService cryptoapi.Crypto
+	kh            interface{}
+	headers       jose.Headers
+}
+
+func (s *kmsSigner) Sign(data []byte) ([]byte, error) {
+	return s.cryptoService.Sign(data, s.kh)
+}
+
+func (s *kmsSigner) Headers() jose.Headers {
+	return s.headers
+}
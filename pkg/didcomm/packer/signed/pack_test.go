@@ -0,0 +1,258 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signed
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const (
+	senderDID = "did:example:sender"
+	payload   = `{"id":"1234","type":"https://didcomm.org/test/1.0/signed","body":{}}`
+)
+
+func TestSignedPackerSuccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyType kms.KeyType
+		vmType  string
+	}{
+		{
+			name:    "signed using Ed25519",
+			keyType: kms.ED25519Type,
+			vmType:  "Ed25519VerificationKey2018",
+		},
+		{
+			name:    "signed using ECDSA P-256",
+			keyType: kms.ECDSAP256TypeIEEEP1363,
+			vmType:  "EcdsaSecp256r1VerificationKey2019",
+		},
+		{
+			name:    "signed using ECDSA P-384",
+			keyType: kms.ECDSAP384TypeIEEEP1363,
+			vmType:  "EcdsaSecp384r1VerificationKey2019",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			k := createKMS(t)
+
+			kid, pubKey, err := k.CreateAndExportPubKeyBytes(tc.keyType)
+			require.NoError(t, err)
+
+			doc := createDIDDoc(tc.vmType, pubKey)
+			packer := newPacker(t, k, doc)
+
+			envelope, err := packer.Pack(transport.MediaTypeV2PlaintextPayload, []byte(payload),
+				[]byte(kid+"."+senderDID+"#key-1"), nil)
+			require.NoError(t, err)
+
+			jwsJSON := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(envelope, &jwsJSON))
+			require.Contains(t, jwsJSON, "signatures")
+
+			env, err := packer.Unpack(envelope)
+			require.NoError(t, err)
+			require.Equal(t, payload, string(env.Message))
+
+			senderKey := &cryptoapi.PublicKey{}
+			require.NoError(t, json.Unmarshal(env.FromKey, senderKey))
+			require.Equal(t, senderDID+"#key-1", senderKey.KID)
+
+			if tc.keyType == kms.ED25519Type {
+				require.Equal(t, pubKey, senderKey.X)
+				require.Equal(t, "Ed25519", senderKey.Curve)
+			}
+
+			jws, err := jose.ParseJWS(string(envelope), jose.SignatureVerifierFunc(
+				func(joseHeaders jose.Headers, _, signingInput, signature []byte) error {
+					_, e := packer.verify(joseHeaders, signingInput, signature)
+
+					return e
+				}))
+			require.NoError(t, err)
+
+			typ, _ := jws.ProtectedHeaders.Type()
+			require.Equal(t, transport.MediaTypeV2SignedEnvelope, typ)
+
+			cty, _ := jws.ProtectedHeaders.ContentType()
+			require.Equal(t, transport.MediaTypeV2PlaintextPayload, cty)
+		})
+	}
+}
+
+func TestSignedPackerFail(t *testing.T) {
+	k := createKMS(t)
+
+	kid, pubKey, err := k.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	doc := createDIDDoc("Ed25519VerificationKey2018", pubKey)
+	senderID := []byte(kid + "." + senderDID + "#key-1")
+
+	t.Run("new packer with missing providers", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{})
+		require.EqualError(t, err, "signed: failed to create packer because KMS is empty")
+
+		_, err = New(&mockprovider.Provider{KMSValue: k})
+		require.EqualError(t, err, "signed: failed to create packer because crypto service is empty")
+
+		_, err = New(&mockprovider.Provider{KMSValue: k, CryptoValue: &tinkcrypto.Crypto{}})
+		require.EqualError(t, err, "signed: failed to create packer because vdr registry is empty")
+	})
+
+	t.Run("pack with invalid sender", func(t *testing.T) {
+		packer := newPacker(t, k, doc)
+
+		_, err := packer.Pack("", []byte(payload), []byte(kid), nil)
+		require.EqualError(t, err, "signed Pack: sender ID must be the sender kms kid and verification method ID "+
+			"separated by '.'")
+
+		_, err = packer.Pack("", []byte(payload), []byte("unknown."+senderDID+"#key-1"), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed Pack: failed to export sender public key")
+
+		ecdhKID, _, err := k.Create(kms.X25519ECDHKWType)
+		require.NoError(t, err)
+
+		_, err = packer.Pack("", []byte(payload), []byte(ecdhKID+"."+senderDID+"#key-1"), nil)
+		require.EqualError(t, err, "signed Pack: unsupported signing key type: X25519ECDHKW")
+	})
+
+	t.Run("unpack with unresolvable signer", func(t *testing.T) {
+		packer := newPacker(t, k, doc)
+
+		envelope, err := packer.Pack("", []byte(payload), senderID, nil)
+		require.NoError(t, err)
+
+		packer.vdr = &mockvdr.MockVDRegistry{ResolveErr: errors.New("resolve error")}
+
+		_, err = packer.Unpack(envelope)
+		require.EqualError(t, err, "signed Unpack: failed to parse JWS envelope: verify JWS signature 0: "+
+			"failed to resolve signer DID: resolve error")
+
+		envelope, err = packer.Pack("", []byte(payload), []byte(kid+".key-1"), nil)
+		require.NoError(t, err)
+
+		_, err = packer.Unpack(envelope)
+		require.EqualError(t, err, "signed Unpack: failed to parse JWS envelope: verify JWS signature 0: "+
+			"kid 'key-1' is not a DID URL")
+	})
+
+	t.Run("unpack with key not in authentication", func(t *testing.T) {
+		packer := newPacker(t, k, doc)
+
+		envelope, err := packer.Pack("", []byte(payload), []byte(kid+"."+senderDID+"#key-2"), nil)
+		require.NoError(t, err)
+
+		_, err = packer.Unpack(envelope)
+		require.EqualError(t, err, "signed Unpack: failed to parse JWS envelope: verify JWS signature 0: "+
+			"kid '"+senderDID+"#key-2' is not an authentication verification method of "+senderDID)
+	})
+
+	t.Run("unpack with invalid signature", func(t *testing.T) {
+		_, otherPubKey, err := k.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+
+		packer := newPacker(t, k, doc)
+
+		envelope, err := packer.Pack("", []byte(payload), senderID, nil)
+		require.NoError(t, err)
+
+		packer.vdr = &mockvdr.MockVDRegistry{ResolveValue: createDIDDoc("Ed25519VerificationKey2018", otherPubKey)}
+
+		_, err = packer.Unpack(envelope)
+		require.EqualError(t, err, "signed Unpack: failed to parse JWS envelope: verify JWS signature 0: "+
+			"ed25519: invalid signature")
+	})
+
+	t.Run("unpack with invalid headers", func(t *testing.T) {
+		packer := newPacker(t, k, doc)
+
+		_, err := packer.verify(jose.Headers{}, nil, nil)
+		require.EqualError(t, err, "'alg' JOSE header is not present")
+
+		_, err = packer.verify(jose.Headers{"alg": "EdDSA"}, nil, nil)
+		require.EqualError(t, err, "'kid' JOSE header is not present")
+
+		_, err = packer.verify(jose.Headers{"alg": "RS256", "kid": senderDID + "#key-1"}, nil, nil)
+		require.EqualError(t, err, "no verifier found for RS256 algorithm")
+
+		_, err = packer.Unpack([]byte("not a JWS"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed Unpack: failed to parse JWS envelope")
+	})
+}
+
+func createKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	k, err := localkms.New("local-lock://test/key/uri", p)
+	require.NoError(t, err)
+
+	return k
+}
+
+func createDIDDoc(vmType string, pubKey []byte) *did.Doc {
+	authVM := did.NewVerificationMethodFromBytes("#key-1", vmType, senderDID, pubKey)
+	otherVM := did.NewVerificationMethodFromBytes(senderDID+"#key-2", vmType, senderDID, pubKey)
+
+	doc := did.BuildDoc(
+		did.WithVerificationMethod([]did.VerificationMethod{*authVM, *otherVM}),
+		did.WithAuthentication([]did.Verification{*did.NewReferencedVerification(authVM, did.Authentication)}))
+	doc.ID = senderDID
+
+	return doc
+}
+
+func newPacker(t *testing.T, k kms.KeyManager, doc *did.Doc) *Packer {
+	t.Helper()
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	packer, err := New(&mockprovider.Provider{
+		KMSValue:    k,
+		CryptoValue: c,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdr.DIDMethodOption) (*did.DocResolution, error) {
+				if didID != doc.ID {
+					return nil, vdr.ErrNotFound
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return packer
+}
@@ -346,7 +346,7 @@ func TestSignVerify(t *testing.T) {
 		require.Contains(t, err.Error(), "parsing jwk")
 	})
 
-	t.Run("fail to verify with unsupported JWK type", func(t *testing.T) {
+	t.Run("fail to verify with a JWK type not matching the did:key", func(t *testing.T) {
 		data := mockAttachmentData()
 
 		err = data.Sign(c, kh, pubKey, pubKeyBytes)
@@ -369,13 +369,13 @@ func TestSignVerify(t *testing.T) {
 					`JWQD9ZnVcYqScgHpQRhxMBi86PIvXR01D_PWXZZjvTRakpvQxUT5bVBdWnaBHQoxDBt0YIVi5a7x-gXB1aDlts4RTMpfS9BPmEjX`+
 					`4lciozwS6Ow_wTO3C2YGa_Our0ptIxr-x_3sMbPCN8Fe_iaBDezeDAm39xCNjFa1E735ipXA4eUW_6SzFJ5-bM2UKba2WE6xUaEa5G1`+
 					`MDDHCG5LKKd6Mhy7SSAzPOR2FTKYj89ch2asCPlbjHTu8jS6Iy8"
-		}`, // not a supported key type
+		}`, // not the key type of the did:key
 			))),
 		))
 
 		err = data.Verify(c, k)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "invalid key type")
+		require.Contains(t, err.Error(), "creating key handle")
 	})
 
 	validProtectedHeader := `{
@@ -15,6 +15,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -104,6 +105,7 @@ type Service struct {
 	callbackChannel    chan *message
 	connectionRecorder *connection.Recorder
 	connectionStore    didstore.ConnectionStore
+	metrics            metrics.Metrics
 	initialized        bool
 }
 
@@ -206,6 +208,7 @@ func (s *Service) Initialize(p interface{}) error { // nolint: funlen
 	s.callbackChannel = make(chan *message, callbackChannelSize)
 	s.connectionRecorder = connRecorder
 	s.connectionStore = prov.DIDConnectionStore()
+	s.metrics = metrics.FromProvider(p)
 
 	// start the listener
 	go s.startInternalListener()
@@ -288,6 +291,11 @@ func (s *Service) Name() string {
 	return DIDExchange
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
 func findNamespace(msgType string) string {
 	namespace := theirNSPrefix
 	if msgType == InvitationMsgType || msgType == ResponseMsgType || msgType == oobMsgType {
@@ -348,6 +356,11 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 		return fmt.Errorf("invalid state name: %w", err)
 	}
 
+	current := stateNameNull
+	if msg.ConnRecord != nil && msg.ConnRecord.State != "" {
+		current = msg.ConnRecord.State
+	}
+
 	for !isNoOp(next) {
 		s.sendMsgEvents(&service.StateMsg{
 			ProtocolName: DIDExchange,
@@ -384,6 +397,9 @@ func (s *Service) handle(msg *message, aEvent chan<- service.DIDCommAction) erro
 			return fmt.Errorf("failed to persist state '%s': %w", next.Name(), err)
 		}
 
+		s.metrics.ProtocolStateTransition(DIDExchange, current, next.Name())
+		current = next.Name()
+
 		if connectionRecord.State == StateIDCompleted {
 			err = s.connectionStore.SaveDIDByResolving(connectionRecord.TheirDID, connectionRecord.RecipientKeys...)
 			if err != nil {
@@ -416,7 +416,8 @@ func serviceTypeByMediaProfile(mediaTypeProfiles []string) string {
 		switch mtp {
 		case transport.MediaTypeDIDCommV2Profile, transport.MediaTypeAIP2RFC0587Profile,
 			transport.MediaTypeV2EncryptedEnvelope, transport.MediaTypeV2EncryptedEnvelopeV1PlaintextPayload,
-			transport.MediaTypeV1EncryptedEnvelope:
+			transport.MediaTypeV1EncryptedEnvelope, transport.MediaTypeV2SignedEnvelope,
+			transport.MediaTypeV2EncryptedEnvelopeV2SignedPayload:
 			serviceType = didCommV2ServiceType
 
 			breakFor = true
@@ -692,8 +693,9 @@ func (ctx *context) getMyDIDDoc(pubDID string, routerConnections []string, servi
 	logger.Debugf("creating new '%s' did for connection", didMethod)
 
 	var (
-		services   []did.Service
-		newService bool
+		services    []did.Service
+		v2Endpoints []model.DIDCommV2Endpoint
+		newService  bool
 	)
 
 	for _, connID := range routerConnections {
@@ -703,25 +705,24 @@ func (ctx *context) getMyDIDDoc(pubDID string, routerConnections []string, servi
 			return nil, fmt.Errorf("did doc - fetch router config: %w", err)
 		}
 
-		var svc did.Service
-
 		switch serviceType {
 		case didCommServiceType, legacyDIDCommServiceType:
-			svc = did.Service{
+			services = append(services, did.Service{
 				Type:            didCommServiceType,
 				ServiceEndpoint: model.NewDIDCommV1Endpoint(serviceEndpoint),
 				RoutingKeys:     routingKeys,
-			}
+			})
 		case didCommV2ServiceType:
-			svc = did.Service{
-				Type: didCommV2ServiceType,
-				ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{
-					{URI: serviceEndpoint, RoutingKeys: routingKeys},
-				}),
-			}
+			// DIDComm V2 lists the endpoints of all the mediators in a single service, senders pick among them.
+			v2Endpoints = append(v2Endpoints, model.DIDCommV2Endpoint{URI: serviceEndpoint, RoutingKeys: routingKeys})
 		}
+	}
 
-		services = append(services, svc)
+	if len(v2Endpoints) > 0 {
+		services = append(services, did.Service{
+			Type:            didCommV2ServiceType,
+			ServiceEndpoint: model.NewDIDCommV2Endpoint(v2Endpoints),
+		})
 	}
 
 	if len(services) == 0 {
@@ -1872,6 +1872,41 @@ func TestGetDIDDocAndConnection(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, didDoc)
 	})
+
+	t.Run("successfully created peer did with didcomm V2 service bloc for several mediators", func(t *testing.T) {
+		connRec, err := connection.NewRecorder(&protocol.MockProvider{})
+		require.NoError(t, err)
+		didConnStore, err := didstore.NewConnectionStore(&protocol.MockProvider{})
+		require.NoError(t, err)
+		customKMS := newKMS(t, mockstorage.NewMockStoreProvider())
+
+		var created *diddoc.Doc
+
+		ctx := context{
+			kms: customKMS,
+			vdRegistry: &mockvdr.MockVDRegistry{
+				CreateFunc: func(_ string, doc *diddoc.Doc, _ ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+					created = doc
+
+					return &diddoc.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDocWithDIDCommV2Bloc(t, "bob")}, nil
+				},
+			},
+			connectionRecorder: connRec,
+			connectionStore:    didConnStore,
+			routeSvc: &mockroute.MockMediatorSvc{
+				RouterEndpoint: "http://router.example.com",
+				RoutingKeys:    []string{"did:key:router"},
+			},
+			keyType:          kms.ED25519Type,
+			keyAgreementType: kms.X25519ECDHKWType,
+		}
+		didDoc, err := ctx.getMyDIDDoc("", []string{"router-1", "router-2"}, didCommV2ServiceType)
+		require.NoError(t, err)
+		require.NotNil(t, didDoc)
+
+		require.Len(t, created.Service, 1)
+		require.Len(t, created.Service[0].ServiceEndpoint.DIDCommV2Endpoints(), 2)
+	})
 	t.Run("test create did doc - router service config error", func(t *testing.T) {
 		connRec, err := connection.NewRecorder(&protocol.MockProvider{})
 		require.NoError(t, err)
@@ -1906,7 +1941,7 @@ func TestGetDIDDocAndConnection(t *testing.T) {
 			keyType:          kms.ED25519Type,
 			keyAgreementType: kms.X25519ECDHKWType,
 		}
-		didDoc, err := ctx.getMyDIDDoc("", []string{"xyz"}, "")
+		didDoc, err := ctx.getMyDIDDoc("", []string{"xyz"}, didCommServiceType)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "did doc - add key to the router")
 		require.Nil(t, didDoc)
@@ -1931,6 +1966,14 @@ func TestGetDIDDocAndConnection(t *testing.T) {
 		require.Error(t, err)
 		require.Nil(t, didDoc)
 		require.Contains(t, err.Error(), "getMyDIDDoc: invalid DID Doc service type: ''")
+
+		// router connections don't add an empty service for an invalid service type.
+		ctx.routeSvc = &mockroute.MockMediatorSvc{Connections: []string{"xyz"}}
+
+		didDoc, err = ctx.getMyDIDDoc("", []string{"xyz"}, "invalid")
+		require.Error(t, err)
+		require.Nil(t, didDoc)
+		require.Contains(t, err.Error(), "getMyDIDDoc: invalid DID Doc service type: 'invalid'")
 	})
 }
 
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Query is the DIDComm V1 discover-features query message.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0031-discover-features#query-message-type
+type Query struct {
+	Type    string `json:"@type,omitempty"`
+	ID      string `json:"@id,omitempty"`
+	Query   string `json:"query"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Disclose is the DIDComm V1 discover-features disclose message.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0031-discover-features#disclose-message-type
+type Disclose struct {
+	Type      string            `json:"@type,omitempty"`
+	ID        string            `json:"@id,omitempty"`
+	Protocols []*Protocol       `json:"protocols"`
+	Thread    *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Protocol is a protocol disclosed by DIDComm V1 disclose message.
+type Protocol struct {
+	PID   string   `json:"pid"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// QueriesV2 is the DIDComm V2 discover-features queries message.
+// https://identity.foundation/didcomm-messaging/spec/#discover-features-protocol-20
+type QueriesV2 struct {
+	ID   string         `json:"id,omitempty"`
+	Type string         `json:"type,omitempty"`
+	Body *QueriesBodyV2 `json:"body"`
+}
+
+// QueriesBodyV2 is the body of DIDComm V2 queries message.
+type QueriesBodyV2 struct {
+	Queries []*FeatureQuery `json:"queries"`
+}
+
+// FeatureQuery is a query for features of given type matching given pattern,
+// '*' can be used as a wildcard in the match pattern.
+type FeatureQuery struct {
+	FeatureType string `json:"feature-type"`
+	Match       string `json:"match"`
+}
+
+// DiscloseV2 is the DIDComm V2 discover-features disclose message.
+// https://identity.foundation/didcomm-messaging/spec/#discover-features-protocol-20
+type DiscloseV2 struct {
+	ID       string          `json:"id,omitempty"`
+	Type     string          `json:"type,omitempty"`
+	ThreadID string          `json:"thid,omitempty"`
+	Body     *DiscloseBodyV2 `json:"body"`
+}
+
+// DiscloseBodyV2 is the body of DIDComm V2 disclose message.
+type DiscloseBodyV2 struct {
+	Disclosures []*Disclosure `json:"disclosures"`
+}
+
+// Disclosure is a feature disclosed by the agent.
+type Disclosure struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
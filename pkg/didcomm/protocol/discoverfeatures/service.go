@@ -0,0 +1,526 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// DiscoverFeatures defines the protocol name.
+	DiscoverFeatures = "discoverfeatures"
+	// SpecV1 defines the DIDComm V1 protocol spec.
+	SpecV1 = "https://didcomm.org/discover-features/1.0/"
+	// QueryMsgTypeV1 defines the DIDComm V1 query message type.
+	QueryMsgTypeV1 = SpecV1 + "query"
+	// DiscloseMsgTypeV1 defines the DIDComm V1 disclose message type.
+	DiscloseMsgTypeV1 = SpecV1 + "disclose"
+	// SpecV2 defines the DIDComm V2 protocol spec.
+	SpecV2 = "https://didcomm.org/discover-features/2.0/"
+	// QueriesMsgTypeV2 defines the DIDComm V2 queries message type.
+	QueriesMsgTypeV2 = SpecV2 + "queries"
+	// DiscloseMsgTypeV2 defines the DIDComm V2 disclose message type.
+	DiscloseMsgTypeV2 = SpecV2 + "disclose"
+)
+
+const (
+	// FeatureTypeProtocol is the feature type of DIDComm protocols.
+	FeatureTypeProtocol = "protocol"
+	// FeatureTypeGoalCode is the feature type of goal codes.
+	FeatureTypeGoalCode = "goal-code"
+	// FeatureTypeMediaTypeProfile is the feature type of DIDComm media type profiles.
+	FeatureTypeMediaTypeProfile = "media-type-profile"
+)
+
+const queryTimeout = 30 * time.Second
+
+// ErrConnectionNotFound connection not found error.
+var (
+	ErrConnectionNotFound = errors.New("connection not found")
+	logger                = log.New("aries-framework/discoverfeatures")
+)
+
+type provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	AllServices() []dispatcher.ProtocolService
+	ServiceMsgTypeTargets() []dispatcher.MessageTypeTarget
+	MediaTypeProfiles() []string
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	GetConnectionRecordByDIDs(myDID, theirDID string) (*connection.Record, error)
+	SaveConnectionRecord(record *connection.Record) error
+}
+
+// QueryOption configures the query.
+type QueryOption func(opts *QueryOptions)
+
+// QueryOptions holds options for the query.
+type QueryOptions struct {
+	// Timeout for waiting for the disclose message.
+	Timeout time.Duration
+	// Comment to be sent along with the query, only supported by DIDComm V1.
+	Comment string
+}
+
+// Service for the discover features protocol.
+type Service struct {
+	service.Action
+	service.Message
+	messenger         service.Messenger
+	connections       connections
+	allServices       []dispatcher.ProtocolService
+	msgTypeTargets    []dispatcher.MessageTypeTarget
+	mediaTypeProfiles []string
+	discloseMap       map[string]chan []*Disclosure
+	discloseMapLock   sync.RWMutex
+	connectionLock    sync.Mutex
+	initialized       bool
+}
+
+// New returns the discover features service.
+func New(prov provider) (*Service, error) {
+	svc := Service{}
+
+	err := svc.Initialize(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+// Initialize initializes the Service. If Initialize succeeds, any further call is a no-op.
+func (s *Service) Initialize(p interface{}) error {
+	if s.initialized {
+		return nil
+	}
+
+	prov, ok := p.(provider)
+	if !ok {
+		return fmt.Errorf("expected provider of type `%T`, got type `%T`", provider(nil), p)
+	}
+
+	connectionRecorder, err := connection.NewRecorder(prov)
+	if err != nil {
+		return err
+	}
+
+	s.messenger = prov.Messenger()
+	s.connections = connectionRecorder
+	s.allServices = prov.AllServices()
+	s.msgTypeTargets = prov.ServiceMsgTypeTargets()
+	s.mediaTypeProfiles = prov.MediaTypeProfiles()
+	s.discloseMap = make(map[string]chan []*Disclosure)
+
+	s.initialized = true
+
+	return nil
+}
+
+// HandleInbound handles inbound discover features messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	var err error
+
+	switch msg.Type() {
+	case QueryMsgTypeV1:
+		err = s.handleQuery(msg, ctx.MyDID(), ctx.TheirDID())
+	case QueriesMsgTypeV2:
+		err = s.handleQueriesV2(msg, ctx.MyDID(), ctx.TheirDID())
+	case DiscloseMsgTypeV1:
+		err = s.handleDisclose(msg, ctx.MyDID(), ctx.TheirDID())
+	case DiscloseMsgTypeV2:
+		err = s.handleDiscloseV2(msg, ctx.MyDID(), ctx.TheirDID())
+	default:
+		err = fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case QueryMsgTypeV1, DiscloseMsgTypeV1, QueriesMsgTypeV2, DiscloseMsgTypeV2:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return DiscoverFeatures
+}
+
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{SpecV1, SpecV2}
+}
+
+// Features returns all the features supported by this agent, ie. protocols of the registered services,
+// goal codes of the registered message type targets and supported media type profiles.
+func (s *Service) Features() []*Disclosure {
+	var features []*Disclosure
+
+	seen := make(map[string]struct{})
+
+	add := func(featureType, id string) {
+		key := featureType + " " + id
+		if _, ok := seen[key]; ok || id == "" {
+			return
+		}
+
+		seen[key] = struct{}{}
+
+		features = append(features, &Disclosure{FeatureType: featureType, ID: id})
+	}
+
+	for _, svc := range s.allServices {
+		if d, ok := svc.(dispatcher.ProtocolDisclosure); ok {
+			for _, pid := range d.Protocols() {
+				add(FeatureTypeProtocol, strings.TrimSuffix(pid, "/"))
+			}
+		}
+	}
+
+	for _, target := range s.msgTypeTargets {
+		add(FeatureTypeGoalCode, target.Target)
+	}
+
+	for _, profile := range s.mediaTypeProfiles {
+		add(FeatureTypeMediaTypeProfile, profile)
+	}
+
+	return features
+}
+
+// Query sends given feature queries to the other party of the given connection and waits for the
+// disclosed features. Disclosed features are also cached in the connection record (see TheirFeatures).
+// DIDComm V1 connections support only a single query of 'protocol' feature type.
+func (s *Service) Query(connectionID string, queries []*FeatureQuery, options ...QueryOption) ([]*Disclosure, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("no queries provided")
+	}
+
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := parseQueryOpts(options...)
+
+	// generate message ID, it will also be the thread ID of the disclose message.
+	msgID := uuid.New().String()
+
+	var (
+		msg     service.DIDCommMsgMap
+		version = service.V1
+	)
+
+	if conn.DIDCommVersion == service.V2 {
+		version = service.V2
+		msg = service.NewDIDCommMsgMap(&QueriesV2{
+			ID:   msgID,
+			Type: QueriesMsgTypeV2,
+			Body: &QueriesBodyV2{Queries: queries},
+		})
+	} else {
+		if len(queries) != 1 || queries[0].FeatureType != FeatureTypeProtocol {
+			return nil, errors.New("DIDComm V1 query supports only a single query of protocol feature type")
+		}
+
+		msg = service.NewDIDCommMsgMap(&Query{
+			Type:    QueryMsgTypeV1,
+			ID:      msgID,
+			Query:   queries[0].Match,
+			Comment: opts.Comment,
+		})
+	}
+
+	// register chan for callback processing
+	discloseCh := make(chan []*Disclosure, 1)
+	s.setDiscloseCh(msgID, discloseCh)
+
+	defer s.setDiscloseCh(msgID, nil)
+
+	if err = s.messenger.Send(msg, conn.MyDID, conn.TheirDID, service.WithVersion(version)); err != nil {
+		return nil, fmt.Errorf("send query: %w", err)
+	}
+
+	// callback processing (to make this function look like a sync function)
+	select {
+	case disclosures := <-discloseCh:
+		return disclosures, nil
+	case <-time.After(opts.Timeout):
+		return nil, errors.New("timeout waiting for disclose")
+	}
+}
+
+// TheirFeatures returns the features disclosed by the other party of the given connection so far.
+func (s *Service) TheirFeatures(connectionID string) ([]*connection.Feature, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.TheirFeatures, nil
+}
+
+func (s *Service) handleQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	query := &Query{}
+
+	err := msg.Decode(query)
+	if err != nil {
+		return fmt.Errorf("query message unmarshal: %w", err)
+	}
+
+	disclosures := match(s.Features(), []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: query.Query}})
+
+	protocols := make([]*Protocol, 0, len(disclosures))
+
+	for _, d := range disclosures {
+		protocols = append(protocols, &Protocol{PID: d.ID, Roles: d.Roles})
+	}
+
+	resp := service.NewDIDCommMsgMap(&Disclose{
+		Type:      DiscloseMsgTypeV1,
+		ID:        uuid.New().String(),
+		Protocols: protocols,
+	})
+
+	err = s.messenger.ReplyToMsg(msg.Clone(), resp, myDID, theirDID, service.WithVersion(service.V1))
+	if err != nil {
+		return fmt.Errorf("send disclose: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleQueriesV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	queries := &QueriesV2{}
+
+	err := msg.Decode(queries)
+	if err != nil {
+		return fmt.Errorf("queries message unmarshal: %w", err)
+	}
+
+	if queries.Body == nil {
+		return errors.New("queries message has no body")
+	}
+
+	disclosures := match(s.Features(), queries.Body.Queries)
+
+	resp := service.NewDIDCommMsgMap(&DiscloseV2{
+		ID:   uuid.New().String(),
+		Type: DiscloseMsgTypeV2,
+		Body: &DiscloseBodyV2{Disclosures: disclosures},
+	})
+
+	err = s.messenger.ReplyToMsg(msg.Clone(), resp, myDID, theirDID, service.WithVersion(service.V2))
+	if err != nil {
+		return fmt.Errorf("send disclose: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleDisclose(msg service.DIDCommMsg, myDID, theirDID string) error {
+	disclose := &Disclose{}
+
+	err := msg.Decode(disclose)
+	if err != nil {
+		return fmt.Errorf("disclose message unmarshal: %w", err)
+	}
+
+	disclosures := make([]*Disclosure, 0, len(disclose.Protocols))
+
+	for _, p := range disclose.Protocols {
+		disclosures = append(disclosures, &Disclosure{FeatureType: FeatureTypeProtocol, ID: p.PID, Roles: p.Roles})
+	}
+
+	return s.disclosed(msg, myDID, theirDID, disclosures)
+}
+
+func (s *Service) handleDiscloseV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	disclose := &DiscloseV2{}
+
+	err := msg.Decode(disclose)
+	if err != nil {
+		return fmt.Errorf("disclose message unmarshal: %w", err)
+	}
+
+	var disclosures []*Disclosure
+
+	if disclose.Body != nil {
+		disclosures = disclose.Body.Disclosures
+	}
+
+	return s.disclosed(msg, myDID, theirDID, disclosures)
+}
+
+func (s *Service) disclosed(msg service.DIDCommMsg, myDID, theirDID string, disclosures []*Disclosure) error {
+	err := s.saveTheirFeatures(myDID, theirDID, disclosures)
+	if err != nil {
+		return err
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("disclose thread ID: %w", err)
+	}
+
+	// check if there are any channels registered for the query ID
+	discloseCh := s.getDiscloseCh(thID)
+	if discloseCh == nil {
+		logger.Debugf("no pending query found for disclose with thread ID %s", thID)
+
+		return nil
+	}
+
+	select {
+	case discloseCh <- disclosures:
+	default:
+	}
+
+	return nil
+}
+
+// saveTheirFeatures merges given disclosures into the features cached in the connection record.
+func (s *Service) saveTheirFeatures(myDID, theirDID string, disclosures []*Disclosure) error {
+	s.connectionLock.Lock()
+	defer s.connectionLock.Unlock()
+
+	conn, err := s.connections.GetConnectionRecordByDIDs(myDID, theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		logger.Debugf("no connection found for disclose between %s and %s", myDID, theirDID)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	for _, d := range disclosures {
+		feature := &connection.Feature{FeatureType: d.FeatureType, ID: d.ID, Roles: d.Roles}
+
+		replaced := false
+
+		for i, f := range conn.TheirFeatures {
+			if f.FeatureType == feature.FeatureType && f.ID == feature.ID {
+				conn.TheirFeatures[i] = feature
+				replaced = true
+
+				break
+			}
+		}
+
+		if !replaced {
+			conn.TheirFeatures = append(conn.TheirFeatures, feature)
+		}
+	}
+
+	err = s.connections.SaveConnectionRecord(conn)
+	if err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) setDiscloseCh(msgID string, discloseCh chan []*Disclosure) {
+	s.discloseMapLock.Lock()
+	defer s.discloseMapLock.Unlock()
+
+	if discloseCh == nil {
+		delete(s.discloseMap, msgID)
+	} else {
+		s.discloseMap[msgID] = discloseCh
+	}
+}
+
+func (s *Service) getDiscloseCh(msgID string) chan []*Disclosure {
+	s.discloseMapLock.RLock()
+	defer s.discloseMapLock.RUnlock()
+
+	return s.discloseMap[msgID]
+}
+
+// match returns the features matching any of the given queries, '*' in query match acts as a wildcard.
+func match(features []*Disclosure, queries []*FeatureQuery) []*Disclosure {
+	matched := []*Disclosure{}
+
+	patterns := make([]*regexp.Regexp, len(queries))
+
+	for i, q := range queries {
+		// quoted pattern is always a valid regular expression.
+		patterns[i] = regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(q.Match), `\*`, ".*") + "$")
+	}
+
+	for _, f := range features {
+		for i, q := range queries {
+			if q.FeatureType == f.FeatureType && patterns[i].MatchString(f.ID) {
+				matched = append(matched, f)
+
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+func parseQueryOpts(options ...QueryOption) *QueryOptions {
+	opts := &QueryOptions{
+		Timeout: queryTimeout,
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
@@ -0,0 +1,410 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+)
+
+func TestServiceNew(t *testing.T) {
+	t.Run("test new service - success", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+		require.Equal(t, DiscoverFeatures, svc.Name())
+		require.Equal(t, []string{SpecV1, SpecV2}, svc.Protocols())
+	})
+
+	t.Run("test new service - connection recorder error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("error opening the store"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error opening the store")
+		require.Nil(t, svc)
+	})
+}
+
+func TestService_Initialize(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		prov := newProvider(nil)
+
+		svc := Service{}
+
+		err := svc.Initialize(prov)
+		require.NoError(t, err)
+
+		// second init is no-op
+		err = svc.Initialize(prov)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure, not given a valid provider", func(t *testing.T) {
+		svc := Service{}
+
+		err := svc.Initialize("not a provider")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected provider of type")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc, err := New(newProvider(nil))
+	require.NoError(t, err)
+
+	require.True(t, svc.Accept(QueryMsgTypeV1))
+	require.True(t, svc.Accept(DiscloseMsgTypeV1))
+	require.True(t, svc.Accept(QueriesMsgTypeV2))
+	require.True(t, svc.Accept(DiscloseMsgTypeV2))
+	require.False(t, svc.Accept("unsupported msg type"))
+
+	_, err = svc.HandleOutbound(nil, MYDID, THEIRDID)
+	require.EqualError(t, err, "not implemented")
+}
+
+func TestService_Features(t *testing.T) {
+	prov := newProvider(nil)
+
+	svc, err := New(prov)
+	require.NoError(t, err)
+
+	features := svc.Features()
+	require.Contains(t, features, &Disclosure{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust_ping/1.0"})
+	require.Contains(t, features, &Disclosure{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"})
+	require.Contains(t, features, &Disclosure{FeatureType: FeatureTypeGoalCode, ID: "goal.code"})
+	require.Contains(t, features, &Disclosure{FeatureType: FeatureTypeMediaTypeProfile, ID: "didcomm/v2"})
+	// duplicate goal code is disclosed once
+	require.Len(t, features, 4)
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("query V1", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, "query-1", in.ID())
+				require.Equal(t, DiscloseMsgTypeV1, out.Type())
+
+				disclose := &Disclose{}
+				require.NoError(t, out.Decode(disclose))
+				require.Equal(t, []*Protocol{{PID: "https://didcomm.org/trust_ping/1.0"}}, disclose.Protocols)
+
+				return nil
+			})
+
+		svc, err := New(newProvider(messenger))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Query{ID: "query-1", Type: QueryMsgTypeV1, Query: "https://didcomm.org/trust_ping/*"})
+
+		id, err := svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+		require.Equal(t, "query-1", id)
+	})
+
+	t.Run("queries V2", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, DiscloseMsgTypeV2, out.Type())
+
+				disclose := &DiscloseV2{}
+				require.NoError(t, out.Decode(disclose))
+				require.Equal(t, []*Disclosure{
+					{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/trust-ping/2.0"},
+					{FeatureType: FeatureTypeGoalCode, ID: "goal.code"},
+				}, disclose.Body.Disclosures)
+
+				return nil
+			})
+
+		svc, err := New(newProvider(messenger))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&QueriesV2{
+			ID:   "queries-1",
+			Type: QueriesMsgTypeV2,
+			Body: &QueriesBodyV2{Queries: []*FeatureQuery{
+				{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/trust-ping/2.*"},
+				{FeatureType: FeatureTypeGoalCode, Match: "*"},
+			}},
+		})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("queries V2 - no body", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{"id": "queries-1", "type": QueriesMsgTypeV2}
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.EqualError(t, err, "queries message has no body")
+	})
+
+	t.Run("query V1 - reply error", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			Return(errors.New("reply error"))
+
+		svc, err := New(newProvider(messenger))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Query{ID: "query-1", Type: QueryMsgTypeV1, Query: "*"})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send disclose: reply error")
+	})
+
+	t.Run("disclose - cached in connection record", func(t *testing.T) {
+		prov := newProvider(nil)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+			TheirFeatures: []*connection.Feature{{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/a/1.0"}},
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Disclose{
+			ID:   "disclose",
+			Type: DiscloseMsgTypeV1,
+			Protocols: []*Protocol{
+				{PID: "https://didcomm.org/a/1.0", Roles: []string{"responder"}},
+				{PID: "https://didcomm.org/b/1.0"},
+			},
+		})
+		msg.SetThread("query", "")
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		features, err := svc.TheirFeatures("conn")
+		require.NoError(t, err)
+		require.Equal(t, []*connection.Feature{
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/a/1.0", Roles: []string{"responder"}},
+			{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/b/1.0"},
+		}, features)
+	})
+
+	t.Run("disclose - no connection", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		msg := service.DIDCommMsgMap{"id": "disclose", "type": DiscloseMsgTypeV2, "thid": "queries"}
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Query{ID: "query", Type: "unknown"})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported message type")
+	})
+}
+
+func TestService_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("query V1 connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, QueryMsgTypeV1, msg.Type())
+
+				resp := service.NewDIDCommMsgMap(&Disclose{
+					ID: "disclose", Type: DiscloseMsgTypeV1,
+					Protocols: []*Protocol{{PID: "https://didcomm.org/a/1.0"}},
+				})
+				resp.SetThread(msg.ID(), "")
+
+				go func() {
+					_, e := svc.HandleInbound(resp, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+					require.NoError(t, e)
+				}()
+
+				return nil
+			})
+
+		disclosures, err := svc.Query("conn", []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: "*"}})
+		require.NoError(t, err)
+		require.Equal(t, []*Disclosure{{FeatureType: FeatureTypeProtocol, ID: "https://didcomm.org/a/1.0"}}, disclosures)
+
+		features, err := svc.TheirFeatures("conn")
+		require.NoError(t, err)
+		require.Len(t, features, 1)
+	})
+
+	t.Run("query V2 connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+			DIDCommVersion: service.V2,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, QueriesMsgTypeV2, msg.Type())
+
+				resp := service.NewDIDCommMsgMap(&DiscloseV2{
+					ID: "disclose", Type: DiscloseMsgTypeV2, ThreadID: msg.ID(),
+					Body: &DiscloseBodyV2{Disclosures: []*Disclosure{{FeatureType: FeatureTypeGoalCode, ID: "goal"}}},
+				})
+
+				go func() {
+					_, e := svc.HandleInbound(resp, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+					require.NoError(t, e)
+				}()
+
+				return nil
+			})
+
+		disclosures, err := svc.Query("conn", []*FeatureQuery{{FeatureType: FeatureTypeGoalCode, Match: "*"}})
+		require.NoError(t, err)
+		require.Equal(t, []*Disclosure{{FeatureType: FeatureTypeGoalCode, ID: "goal"}}, disclosures)
+	})
+
+	t.Run("no queries", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn", nil)
+		require.EqualError(t, err, "no queries provided")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn", []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: "*"}})
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+
+		_, err = svc.TheirFeatures("conn")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("unsupported V1 query", func(t *testing.T) {
+		prov := newProvider(nil)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn", []*FeatureQuery{{FeatureType: FeatureTypeGoalCode, Match: "*"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DIDComm V1 query supports only a single query of protocol feature type")
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).Return(errors.New("send error"))
+
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn", []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: "*"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send query: send error")
+	})
+
+	t.Run("timeout waiting for disclose", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).Return(nil)
+
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Query("conn", []*FeatureQuery{{FeatureType: FeatureTypeProtocol, Match: "*"}},
+			func(opts *QueryOptions) {
+				opts.Timeout = 10 * time.Millisecond
+			})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timeout waiting for disclose")
+	})
+}
+
+func newProvider(messenger service.Messenger) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		MessengerValue:                    messenger,
+		ServiceMap: map[string]interface{}{
+			trustping.TrustPing: &trustping.Service{},
+		},
+		ServiceMsgTypeTargetsValue: []dispatcher.MessageTypeTarget{
+			{MsgType: "msg-type-1", Target: "goal.code"},
+			{MsgType: "msg-type-2", Target: "goal.code"},
+		},
+		MediaTypeProfilesValue: []string{"didcomm/v2"},
+	}
+}
+
+func saveConnection(t *testing.T, prov *mockprovider.Provider, record *connection.Record) {
+	t.Helper()
+
+	r, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveConnectionRecord(record))
+}
@@ -873,6 +873,11 @@ func (s *Service) Name() string {
 	return Introduce
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{IntroduceSpec}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
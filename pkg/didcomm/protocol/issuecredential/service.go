@@ -15,6 +15,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -327,6 +328,7 @@ type Service struct {
 	callbacks   chan *MetaData
 	messenger   service.Messenger
 	middleware  Handler
+	metrics     metrics.Metrics
 	initialized bool
 }
 
@@ -364,6 +366,7 @@ func (s *Service) Initialize(prov interface{}) error {
 	}
 
 	s.messenger = p.Messenger()
+	s.metrics = metrics.FromProvider(prov)
 	s.store = store
 	s.callbacks = make(chan *MetaData)
 	s.middleware = initialHandler
@@ -491,6 +494,8 @@ func (s *Service) doHandle(msg service.DIDCommMsg, outbound bool) (*MetaData, er
 		return nil, fmt.Errorf("invalid state transition: %s -> %s", current.Name(), next.Name())
 	}
 
+	s.metrics.ProtocolStateTransition(Name, current.Name(), next.Name())
+
 	return &MetaData{
 		transitionalPayload: transitionalPayload{
 			StateName: next.Name(),
@@ -551,8 +556,12 @@ func (s *Service) handle(md *MetaData) error {
 
 		actions = append(actions, action)
 
-		if !isNoOp(next) && !current.CanTransitionTo(next) {
-			return fmt.Errorf("invalid state transition: %s --> %s", current.Name(), next.Name())
+		if !isNoOp(next) {
+			if !current.CanTransitionTo(next) {
+				return fmt.Errorf("invalid state transition: %s --> %s", current.Name(), next.Name())
+			}
+
+			s.metrics.ProtocolStateTransition(Name, current.Name(), next.Name())
 		}
 
 		current = next
@@ -891,6 +900,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{SpecV2, SpecV3}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
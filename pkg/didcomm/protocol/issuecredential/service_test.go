@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -25,6 +26,7 @@ import (
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	issuecredentialMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/issuecredential"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -2174,6 +2176,41 @@ func TestService_HandleOutbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Send Propose Credential (metrics)", func(t *testing.T) {
+		store, messenger, provider := initMocks(ctrl)
+
+		done := make(chan struct{})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+
+		m := &mockmetrics.MockMetrics{}
+
+		svc, err := New(&metricsProvider{MockProvider: provider, metrics: m})
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(ProposeCredentialV2{
+			Type: ProposeCredentialMsgTypeV2,
+		})
+
+		messenger.EXPECT().Send(msg, Alice, Bob, gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, myDID, theirDID string, opts ...service.Opt) error {
+				defer close(done)
+
+				return nil
+			})
+
+		_, err = svc.HandleOutbound(msg, Alice, Bob)
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+			require.Equal(t, 1, m.ProtocolStateTransitions(Name, stateNameStart, stateNameProposalSent))
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
 	t.Run("Send Propose Credential with error", func(t *testing.T) {
 		store, messenger, provider := initMocks(ctrl)
 
@@ -2725,3 +2762,12 @@ func TestService_canTriggerActionEvents(t *testing.T) {
 
 	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(struct{}{})))
 }
+
+type metricsProvider struct {
+	*issuecredentialMocks.MockProvider
+	metrics metrics.Metrics
+}
+
+func (p *metricsProvider) Metrics() metrics.Metrics {
+	return p.metrics
+}
@@ -281,6 +281,11 @@ func (s *Service) Name() string {
 	return LegacyConnection
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
 func findNamespace(msgType string) string {
 	namespace := theirNSPrefix
 	if msgType == InvitationMsgType || msgType == ResponseMsgType {
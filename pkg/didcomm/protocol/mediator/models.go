@@ -56,3 +56,118 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// RequestV2 mediate request message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-request
+type RequestV2 struct {
+	ID          string         `json:"id,omitempty"`
+	Type        string         `json:"type,omitempty"`
+	ExpiresTime int64          `json:"expires_time,omitempty"`
+	Body        *RequestBodyV2 `json:"body"`
+}
+
+// RequestBodyV2 is the body of the mediate request message (Coordinate Mediation 2.0).
+type RequestBodyV2 struct{}
+
+// GrantV2 mediate grant message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#mediation-grant
+type GrantV2 struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	ThreadID string       `json:"thid,omitempty"`
+	Body     *GrantBodyV2 `json:"body"`
+}
+
+// GrantBodyV2 is the body of the mediate grant message (Coordinate Mediation 2.0). RoutingDID lists the DIDs (or
+// keys) the recipient has to use as routing keys, Endpoint is the mediator endpoint to publish in the recipient's
+// DID doc services.
+type GrantBodyV2 struct {
+	RoutingDID []string `json:"routing_did,omitempty"`
+	Endpoint   string   `json:"endpoint,omitempty"`
+}
+
+// RecipientUpdateV2 recipient update message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update
+type RecipientUpdateV2 struct {
+	ID   string                 `json:"id,omitempty"`
+	Type string                 `json:"type,omitempty"`
+	Body *RecipientUpdateBodyV2 `json:"body"`
+}
+
+// RecipientUpdateBodyV2 is the body of the recipient update message (Coordinate Mediation 2.0).
+type RecipientUpdateBodyV2 struct {
+	Updates []RecipientUpdate `json:"updates"`
+}
+
+// RecipientUpdate adds or removes a recipient DID (or keyAgreement key ID) routed by the mediator.
+type RecipientUpdate struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+}
+
+// RecipientUpdateResponseV2 recipient update response message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#keylist-update-response
+type RecipientUpdateResponseV2 struct {
+	ID       string                         `json:"id,omitempty"`
+	Type     string                         `json:"type,omitempty"`
+	ThreadID string                         `json:"thid,omitempty"`
+	Body     *RecipientUpdateResponseBodyV2 `json:"body"`
+}
+
+// RecipientUpdateResponseBodyV2 is the body of the recipient update response message (Coordinate Mediation 2.0).
+type RecipientUpdateResponseBodyV2 struct {
+	Updated []RecipientUpdateResult `json:"updated"`
+}
+
+// RecipientUpdateResult is the result of a recipient update.
+type RecipientUpdateResult struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Result       string `json:"result,omitempty"`
+}
+
+// RecipientQueryV2 recipient query message (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#key-list-query
+type RecipientQueryV2 struct {
+	ID   string                `json:"id,omitempty"`
+	Type string                `json:"type,omitempty"`
+	Body *RecipientQueryBodyV2 `json:"body"`
+}
+
+// RecipientQueryBodyV2 is the body of the recipient query message (Coordinate Mediation 2.0).
+type RecipientQueryBodyV2 struct {
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// Paginate limits the recipients returned by a recipient query.
+type Paginate struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// RecipientV2 recipient message, the response to a recipient query (Coordinate Mediation 2.0).
+// https://didcomm.org/coordinate-mediation/2.0/#key-list
+type RecipientV2 struct {
+	ID       string           `json:"id,omitempty"`
+	Type     string           `json:"type,omitempty"`
+	ThreadID string           `json:"thid,omitempty"`
+	Body     *RecipientBodyV2 `json:"body"`
+}
+
+// RecipientBodyV2 is the body of the recipient message (Coordinate Mediation 2.0).
+type RecipientBodyV2 struct {
+	DIDs       []RecipientDID `json:"dids"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+// RecipientDID is a recipient DID (or keyAgreement key ID) routed by the mediator.
+type RecipientDID struct {
+	RecipientDID string `json:"recipient_did"`
+}
+
+// Pagination details of the recipient message.
+type Pagination struct {
+	Count     int `json:"count"`
+	Offset    int `json:"offset"`
+	Remaining int `json:"remaining"`
+}
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package mediator
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -53,6 +56,31 @@ const (
 
 	// KeyListUpdateResponseMsgType defines the route coordination key list update message response type.
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
+
+	// CoordinationSpecV2 defines the coordinate mediation 2.0 spec, used over DIDComm V2 connections.
+	CoordinationSpecV2 = "https://didcomm.org/coordinate-mediation/2.0/"
+
+	// RequestMsgTypeV2 defines the coordinate mediation 2.0 request message type.
+	RequestMsgTypeV2 = CoordinationSpecV2 + "mediate-request"
+
+	// GrantMsgTypeV2 defines the coordinate mediation 2.0 grant message type.
+	GrantMsgTypeV2 = CoordinationSpecV2 + "mediate-grant"
+
+	// RecipientUpdateMsgTypeV2 defines the coordinate mediation 2.0 recipient update message type.
+	RecipientUpdateMsgTypeV2 = CoordinationSpecV2 + "recipient-update"
+
+	// RecipientUpdateResponseMsgTypeV2 defines the coordinate mediation 2.0 recipient update response message type.
+	RecipientUpdateResponseMsgTypeV2 = CoordinationSpecV2 + "recipient-update-response"
+
+	// RecipientQueryMsgTypeV2 defines the coordinate mediation 2.0 recipient query message type.
+	RecipientQueryMsgTypeV2 = CoordinationSpecV2 + "recipient-query"
+
+	// RecipientMsgTypeV2 defines the coordinate mediation 2.0 recipient (query response) message type.
+	RecipientMsgTypeV2 = CoordinationSpecV2 + "recipient"
+
+	// routing protocol specs of the forward messages handled by the service.
+	routingSpecV1 = "https://didcomm.org/routing/1.0/"
+	routingSpecV2 = "https://didcomm.org/routing/2.0/"
 )
 
 // constants for key list update processing
@@ -67,6 +95,12 @@ const (
 	// server error while storing the key.
 	serverError = "server_error"
 
+	// client error, eg. unknown action or removing a key of another agent.
+	clientError = "client_error"
+
+	// the key to remove isn't registered.
+	noChange = "no_change"
+
 	// key save success.
 	success = "success"
 )
@@ -79,6 +113,9 @@ const (
 	routeConfigDataKey = "route_config_%s"
 
 	routeGrantKey = "grant_%s"
+
+	// tag of the recipient keys, the value identifies the agent (their DID) the keys are routed to.
+	routeRecipientTag = "route_recipient"
 )
 
 const (
@@ -150,6 +187,8 @@ type Service struct {
 	vdRegistry           vdr.Registry
 	keylistUpdateMap     map[string]chan *KeylistUpdateResponse
 	keylistUpdateMapLock sync.RWMutex
+	recipientMap         map[string]chan *RecipientV2
+	recipientMapLock     sync.RWMutex
 	callbacks            chan *callback
 	messagePickupSvc     messagepickup.ProtocolService
 	keyAgreementType     kms.KeyType
@@ -186,7 +225,7 @@ func (s *Service) Initialize(p interface{}) error {
 	}
 
 	err = prov.StorageProvider().SetStoreConfig(Coordination,
-		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey}})
+		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey, routeRecipientTag}})
 	if err != nil {
 		return fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -213,6 +252,7 @@ func (s *Service) Initialize(p interface{}) error {
 	s.vdRegistry = prov.VDRegistry()
 	s.connectionLookup = connectionLookup
 	s.keylistUpdateMap = make(map[string]chan *KeylistUpdateResponse)
+	s.recipientMap = make(map[string]chan *RecipientV2)
 	s.callbacks = make(chan *callback)
 	s.messagePickupSvc = messagePickupSvc
 	s.keyAgreementType = prov.KeyAgreementType()
@@ -243,6 +283,11 @@ func (s *Service) listenForCallbacks() {
 			if err != nil {
 				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
 			}
+		case RequestMsgTypeV2:
+			err := s.handleInboundRequestV2(c)
+			if err != nil {
+				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
+			}
 		default:
 			logger.Warnf("ignoring unsupported message type %s", c.msg.Type())
 		}
@@ -254,7 +299,7 @@ func (s *Service) handleUserRejection(c *callback) {
 }
 
 func triggersActionEvent(msgType string) bool {
-	return msgType == RequestMsgType
+	return msgType == RequestMsgType || msgType == RequestMsgTypeV2
 }
 
 func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string) error {
@@ -311,12 +356,20 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		var err error
 
 		switch msg.Type() {
-		case GrantMsgType:
+		case GrantMsgType, GrantMsgTypeV2:
 			err = s.saveGrant(msg)
 		case KeylistUpdateMsgType:
 			err = s.handleKeylistUpdate(msg, ctx.MyDID(), ctx.TheirDID())
 		case KeylistUpdateResponseMsgType:
 			err = s.handleKeylistUpdateResponse(msg)
+		case RecipientUpdateMsgTypeV2:
+			err = s.handleRecipientUpdate(msg, ctx.MyDID(), ctx.TheirDID())
+		case RecipientUpdateResponseMsgTypeV2:
+			err = s.handleRecipientUpdateResponse(msg)
+		case RecipientQueryMsgTypeV2:
+			err = s.handleRecipientQuery(msg, ctx.MyDID(), ctx.TheirDID())
+		case RecipientMsgTypeV2:
+			err = s.handleRecipient(msg)
 		case service.ForwardMsgType, service.ForwardMsgTypeV2:
 			err = s.handleForward(msg)
 		}
@@ -358,7 +411,7 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 	}
 
 	switch msg.Type() {
-	case RequestMsgType:
+	case RequestMsgType, RequestMsgTypeV2:
 		return "", s.handleOutboundRequest(msg, myDID, theirDID)
 	default:
 		return "", fmt.Errorf("invalid or unsupported outbound message type %s", msg.Type())
@@ -369,7 +422,8 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
 	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType,
-		service.ForwardMsgTypeV2:
+		service.ForwardMsgTypeV2, RequestMsgTypeV2, GrantMsgTypeV2, RecipientUpdateMsgTypeV2,
+		RecipientUpdateResponseMsgTypeV2, RecipientQueryMsgTypeV2, RecipientMsgTypeV2:
 		return true
 	}
 
@@ -381,6 +435,11 @@ func (s *Service) Name() string {
 	return Coordination
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{CoordinationSpec, CoordinationSpecV2, routingSpecV1, routingSpecV2}
+}
+
 func (s *Service) handleInboundRequest(c *callback) error {
 	logger.Debugf("handling callback: %+v", c)
 	logger.Debugf("options: %+v", c.options)
@@ -404,13 +463,7 @@ func (s *Service) handleInboundRequest(c *callback) error {
 		s.endpoint,
 		func() (string, error) {
 			if request.DIDCommV2 {
-				_, pubKeyBytes, e := s.kms.CreateAndExportPubKeyBytes(s.keyAgreementType)
-				if e != nil {
-					return "", fmt.Errorf("outboundGrant from handleInboundRequest: kms failed to create "+
-						"and export %v key: %w", s.keyAgreementType, e)
-				}
-
-				return kmsdidkey.BuildDIDKeyByKeyType(pubKeyBytes, s.keyAgreementType)
+				return s.createRoutingDIDKey()
 			}
 
 			_, pubKeyBytes, er := s.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
@@ -431,6 +484,46 @@ func (s *Service) handleInboundRequest(c *callback) error {
 	return s.outbound.SendToDID(service.NewDIDCommMsgMap(grant), c.myDID, c.theirDID)
 }
 
+func (s *Service) handleInboundRequestV2(c *callback) error {
+	request := &RequestV2{}
+
+	err := c.msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("handleInboundRequestV2: route request message unmarshal : %w", err)
+	}
+
+	err = validateRequestVersion(s.mediaTypeProfiles, true)
+	if err != nil {
+		return err
+	}
+
+	grant, err := outboundGrant(c.msg.ID(), c.options, s.endpoint, s.createRoutingDIDKey)
+	if err != nil {
+		return fmt.Errorf("handleInboundRequestV2: failed to handle inbound request : %w", err)
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(&GrantV2{
+		ID:       uuid.New().String(),
+		Type:     GrantMsgTypeV2,
+		ThreadID: c.msg.ID(),
+		Body: &GrantBodyV2{
+			RoutingDID: grant.RoutingKeys,
+			Endpoint:   grant.Endpoint,
+		},
+	}), c.myDID, c.theirDID)
+}
+
+// createRoutingDIDKey creates a DIDComm V2 routing key and returns it as a did:key.
+func (s *Service) createRoutingDIDKey() (string, error) {
+	_, pubKeyBytes, err := s.kms.CreateAndExportPubKeyBytes(s.keyAgreementType)
+	if err != nil {
+		return "", fmt.Errorf("outboundGrant from handleInboundRequest: kms failed to create "+
+			"and export %v key: %w", s.keyAgreementType, err)
+	}
+
+	return kmsdidkey.BuildDIDKeyByKeyType(pubKeyBytes, s.keyAgreementType)
+}
+
 func validateRequestVersion(mtps []string, requestedV2 bool) error {
 	if requestedV2 {
 		for _, mtp := range mtps {
@@ -494,47 +587,253 @@ func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID st
 
 	// update the db
 	for _, v := range keyUpdate.Updates {
-		if v.Action == add {
-			val := theirDID
-			result := success
+		if v.Action != add && v.Action != remove {
+			continue
+		}
 
-			toKey := dataKey(v.RecipientKey)
+		// construct the response doc
+		updates = append(updates, UpdateResponse{
+			RecipientKey: v.RecipientKey,
+			Action:       v.Action,
+			Result:       s.updateRecipient(v.Action, v.RecipientKey, theirDID),
+		})
+	}
 
-			err = s.routeStore.Put(toKey, []byte(val))
-			if err != nil {
-				logger.Errorf("failed to add the route key to store : %s", err)
+	// send the key update response
+	updateResponse := &KeylistUpdateResponse{
+		Type:    KeylistUpdateResponseMsgType,
+		ID:      msg.ID(),
+		Updated: updates,
+	}
 
-				result = serverError
-			}
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(updateResponse), myDID, theirDID)
+}
 
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
-				Action:       v.Action,
-				Result:       result,
-			})
-		} else if v.Action == remove {
-			// TODO remove from the store
+func (s *Service) handleRecipientUpdate(msg service.DIDCommMsg, myDID, theirDID string) error {
+	recipientUpdate := &RecipientUpdateV2{}
 
-			// construct the response doc
-			updates = append(updates, UpdateResponse{
-				RecipientKey: v.RecipientKey,
+	err := msg.Decode(recipientUpdate)
+	if err != nil {
+		return fmt.Errorf("recipient update message unmarshal : %w", err)
+	}
+
+	updated := []RecipientUpdateResult{}
+
+	if recipientUpdate.Body != nil {
+		for _, v := range recipientUpdate.Body.Updates {
+			updated = append(updated, RecipientUpdateResult{
+				RecipientDID: v.RecipientDID,
 				Action:       v.Action,
-				Result:       serverError,
+				Result:       s.updateRecipient(v.Action, v.RecipientDID, theirDID),
 			})
 		}
 	}
 
-	// send the key update response
-	updateResponse := &KeylistUpdateResponse{
-		Type:    KeylistUpdateResponseMsgType,
-		ID:      msg.ID(),
-		Updated: updates,
+	updateResponse := &RecipientUpdateResponseV2{
+		ID:       uuid.New().String(),
+		Type:     RecipientUpdateResponseMsgTypeV2,
+		ThreadID: msg.ID(),
+		Body:     &RecipientUpdateResponseBodyV2{Updated: updated},
 	}
 
 	return s.outbound.SendToDID(service.NewDIDCommMsgMap(updateResponse), myDID, theirDID)
 }
 
+// updateRecipient adds or removes the recipient key (or DID) routed to theirDID and returns the update result.
+func (s *Service) updateRecipient(action, recKey, theirDID string) string {
+	switch action {
+	case add:
+		val, err := s.routeStore.Get(dataKey(recKey))
+		if err != nil && !errors.Is(err, storage.ErrDataNotFound) {
+			logger.Errorf("failed to get the route key from store : %s", err)
+
+			return serverError
+		}
+
+		// agents can't take over keys routed to other agents
+		if err == nil && string(val) != theirDID {
+			return clientError
+		}
+
+		err = s.routeStore.Put(dataKey(recKey), []byte(theirDID),
+			storage.Tag{Name: routeRecipientTag, Value: recipientTagValue(theirDID)})
+		if err != nil {
+			logger.Errorf("failed to add the route key to store : %s", err)
+
+			return serverError
+		}
+
+		return success
+	case remove:
+		val, err := s.routeStore.Get(dataKey(recKey))
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return noChange
+		}
+
+		if err != nil {
+			logger.Errorf("failed to get the route key from store : %s", err)
+
+			return serverError
+		}
+
+		// agents can remove their own keys only
+		if string(val) != theirDID {
+			return clientError
+		}
+
+		err = s.routeStore.Delete(dataKey(recKey))
+		if err != nil {
+			logger.Errorf("failed to remove the route key from store : %s", err)
+
+			return serverError
+		}
+
+		return success
+	default:
+		return clientError
+	}
+}
+
+func (s *Service) handleRecipientQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	query := &RecipientQueryV2{}
+
+	err := msg.Decode(query)
+	if err != nil {
+		return fmt.Errorf("recipient query message unmarshal : %w", err)
+	}
+
+	recipients, err := s.recipientKeys(theirDID)
+	if err != nil {
+		return fmt.Errorf("recipient query : %w", err)
+	}
+
+	body := &RecipientBodyV2{DIDs: []RecipientDID{}}
+
+	if query.Body != nil && query.Body.Paginate != nil {
+		offset, limit := query.Body.Paginate.Offset, query.Body.Paginate.Limit
+
+		if offset > len(recipients) {
+			offset = len(recipients)
+		}
+
+		end := len(recipients)
+		if limit > 0 && offset+limit < end {
+			end = offset + limit
+		}
+
+		body.Pagination = &Pagination{Count: end - offset, Offset: offset, Remaining: len(recipients) - end}
+		recipients = recipients[offset:end]
+	}
+
+	for _, r := range recipients {
+		body.DIDs = append(body.DIDs, RecipientDID{RecipientDID: r})
+	}
+
+	return s.outbound.SendToDID(service.NewDIDCommMsgMap(&RecipientV2{
+		ID:       uuid.New().String(),
+		Type:     RecipientMsgTypeV2,
+		ThreadID: msg.ID(),
+		Body:     body,
+	}), myDID, theirDID)
+}
+
+// recipientKeys returns the sorted recipient keys (or DIDs) routed to theirDID.
+func (s *Service) recipientKeys(theirDID string) ([]string, error) {
+	records, err := s.routeStore.Query(fmt.Sprintf("%s:%s", routeRecipientTag, recipientTagValue(theirDID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query route store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	var keys []string
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		key, err := records.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key from records: %w", err)
+		}
+
+		keys = append(keys, strings.TrimPrefix(key, dataKey("")))
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *Service) handleRecipientUpdateResponse(msg service.DIDCommMsg) error {
+	respMsg := &RecipientUpdateResponseV2{}
+
+	err := msg.Decode(respMsg)
+	if err != nil {
+		return fmt.Errorf("recipient update response message unmarshal : %w", err)
+	}
+
+	// the pending update waits for a keylist update response, regardless of the protocol version
+	keylistUpdateCh := s.getKeyUpdateResponseCh(respMsg.ThreadID)
+	if keylistUpdateCh == nil {
+		return nil
+	}
+
+	resp := &KeylistUpdateResponse{
+		Type: respMsg.Type,
+		ID:   respMsg.ThreadID,
+	}
+
+	if respMsg.Body != nil {
+		for _, u := range respMsg.Body.Updated {
+			resp.Updated = append(resp.Updated, UpdateResponse{
+				RecipientKey: u.RecipientDID,
+				Action:       u.Action,
+				Result:       u.Result,
+			})
+		}
+	}
+
+	// the channel is buffered for the one expected response, duplicate or late responses are dropped.
+	select {
+	case keylistUpdateCh <- resp:
+	default:
+		logger.Warnf("dropped recipient update response of thread %s", respMsg.ThreadID)
+	}
+
+	return nil
+}
+
+func (s *Service) handleRecipient(msg service.DIDCommMsg) error {
+	respMsg := &RecipientV2{}
+
+	err := msg.Decode(respMsg)
+	if err != nil {
+		return fmt.Errorf("recipient message unmarshal : %w", err)
+	}
+
+	recipientCh := s.getRecipientCh(respMsg.ThreadID)
+	if recipientCh == nil {
+		return nil
+	}
+
+	// the channel is buffered for the one expected response, duplicate or late responses are dropped.
+	select {
+	case recipientCh <- respMsg:
+	default:
+		logger.Warnf("dropped recipient response of thread %s", respMsg.ThreadID)
+	}
+
+	return nil
+}
+
 func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	respMsg := &KeylistUpdateResponse{}
@@ -567,9 +866,12 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 	// TODO Open question - https://github.com/hyperledger/aries-framework-go/issues/965 Mismatch between Route
 	//  Coordination and Forward RFC. For now assume, the TO field contains the recipient key (DIDComm V2 uses
 	//  keyAgreement.ID, double check if this to do comment is still needed).
-	toKey := dataKey(forward.To)
+	theirDID, err := s.routeStore.Get(dataKey(forward.To))
+	if errors.Is(err, storage.ErrDataNotFound) && strings.Contains(forward.To, "#") {
+		// Coordinate Mediation 2.0 registers recipient DIDs, forward messages are addressed to their keys.
+		theirDID, err = s.routeStore.Get(dataKey(strings.Split(forward.To, "#")[0]))
+	}
 
-	theirDID, err := s.routeStore.Get(toKey)
 	if err != nil {
 		return fmt.Errorf("route key fetch : %w", err)
 	}
@@ -581,7 +883,7 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 
 	err = s.outbound.Forward(forward.Msg, dest)
 	if err != nil && s.messagePickupSvc != nil {
-		return s.messagePickupSvc.AddMessage(forward.Msg, string(theirDID))
+		return s.messagePickupSvc.AddRecipientMessage(forward.Msg, string(theirDID), forward.To)
 	}
 
 	return err
@@ -625,12 +927,20 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 	// demonstrates? additionally `ExpiresTime` would need to be migrated to int64
 	req.ExpiresTime = time.Now().UTC().Add(timeout)
 
+	msg := service.NewDIDCommMsgMap(req)
+
+	// DIDComm V2 connections use coordinate mediation 2.0
 	if record.DIDCommVersion == service.V2 {
-		req.DIDCommV2 = true
+		msg = service.NewDIDCommMsgMap(&RequestV2{
+			ID:          req.ID,
+			Type:        RequestMsgTypeV2,
+			ExpiresTime: req.ExpiresTime.Unix(),
+			Body:        &RequestBodyV2{},
+		})
 	}
 
 	// send message to the router
-	if err = s.outbound.SendToDID(service.NewDIDCommMsgMap(req), record.MyDID, record.TheirDID); err != nil {
+	if err = s.outbound.SendToDID(msg, record.MyDID, record.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
 
@@ -677,6 +987,29 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 		return nil, fmt.Errorf("unmarshal grant: %w", err)
 	}
 
+	if grant == nil || grant.Type != "" {
+		return grant, nil
+	}
+
+	// DIDComm V2 messages have no @type, try coordinate mediation 2.0 grant
+	grantV2 := &GrantV2{}
+
+	err = json.Unmarshal(src, grantV2)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal grant: %w", err)
+	}
+
+	if grantV2.Type != GrantMsgTypeV2 {
+		return grant, nil
+	}
+
+	grant = &Grant{Type: grantV2.Type, ID: grantV2.ThreadID}
+
+	if grantV2.Body != nil {
+		grant.Endpoint = grantV2.Body.Endpoint
+		grant.RoutingKeys = grantV2.Body.RoutingDID
+	}
+
 	return grant, nil
 }
 
@@ -686,7 +1019,13 @@ func (s *Service) saveGrant(grant service.DIDCommMsg) error {
 		return fmt.Errorf("marshal grant: %w", err)
 	}
 
-	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID()), src)
+	// grants are stored by the ID of the request they answer
+	thID, err := grant.ThreadID()
+	if err != nil {
+		return fmt.Errorf("grant thread ID: %w", err)
+	}
+
+	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, thID), src)
 }
 
 // Unregister unregisters the agent with the router.
@@ -753,11 +1092,21 @@ func (s *Service) GetConnections(options ...ConnectionOption) ([]string, error)
 }
 
 // AddKey adds a recKey of the agent to the registered router. This method blocks until a response is
-// received from the router or it times out.
-// TODO https://github.com/hyperledger/aries-framework-go/issues/1076 Support for multiple routers
+// received from the router or it times out. Coordinate mediation 2.0 is used for DIDComm V2 router connections,
+// recKey is then a DID or a keyAgreement key ID.
 // TODO https://github.com/hyperledger/aries-framework-go/issues/1105 Support to Add multiple
 //  recKeys to the Router
 func (s *Service) AddKey(connID, recKey string) error {
+	return s.updateKey(connID, recKey, add)
+}
+
+// RemoveKey removes a recKey of the agent from the registered router. This method blocks until a response is
+// received from the router or it times out.
+func (s *Service) RemoveKey(connID, recKey string) error {
+	return s.updateKey(connID, recKey, remove)
+}
+
+func (s *Service) updateKey(connID, recKey, action string) error {
 	// check if router is already registered
 	err := s.ensureConnectionExists(connID)
 	if err != nil {
@@ -774,37 +1123,95 @@ func (s *Service) AddKey(connID, recKey string) error {
 	msgID := uuid.New().String()
 
 	// register chan for callback processing
-	keyUpdateCh := make(chan *KeylistUpdateResponse)
+	keyUpdateCh := make(chan *KeylistUpdateResponse, 1)
 	s.setKeyUpdateResponseCh(msgID, keyUpdateCh)
 
-	keyUpdate := &KeylistUpdate{
+	// remove the channel once its been processed
+	defer s.setKeyUpdateResponseCh(msgID, nil)
+
+	var keyUpdate interface{} = &KeylistUpdate{
 		ID:   msgID,
 		Type: KeylistUpdateMsgType,
 		Updates: []Update{
 			{
 				RecipientKey: recKey,
-				Action:       add,
+				Action:       action,
 			},
 		},
 	}
 
+	if conn.DIDCommVersion == service.V2 {
+		keyUpdate = &RecipientUpdateV2{
+			ID:   msgID,
+			Type: RecipientUpdateMsgTypeV2,
+			Body: &RecipientUpdateBodyV2{
+				Updates: []RecipientUpdate{{RecipientDID: recKey, Action: action}},
+			},
+		}
+	}
+
 	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(keyUpdate), conn.MyDID, conn.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
 
 	select {
 	case keyUpdateResp := <-keyUpdateCh:
-		if err := processKeylistUpdateResp(recKey, keyUpdateResp); err != nil {
-			return err
-		}
+		return processKeylistUpdateResp(recKey, action, keyUpdateResp)
 	case <-time.After(updateTimeout):
 		return errors.New("timeout waiting for keylist update response from the router")
 	}
+}
 
-	// remove the channel once its been processed
-	s.setKeyUpdateResponseCh(msgID, nil)
+// QueryRecipients returns the recipient DIDs (or keys) of the agent registered with the router. This method
+// blocks until a response is received from the router or it times out. Requires coordinate mediation 2.0,
+// ie. a DIDComm V2 router connection.
+func (s *Service) QueryRecipients(connID string) ([]string, error) {
+	// check if router is already registered
+	err := s.ensureConnectionExists(connID)
+	if err != nil {
+		return nil, fmt.Errorf("ensure connection exists: %w", err)
+	}
 
-	return nil
+	conn, err := s.getConnection(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	if conn.DIDCommVersion != service.V2 {
+		return nil, errors.New("recipient query requires a DIDComm V2 router connection")
+	}
+
+	msgID := uuid.New().String()
+
+	recipientCh := make(chan *RecipientV2, 1)
+	s.setRecipientCh(msgID, recipientCh)
+
+	defer s.setRecipientCh(msgID, nil)
+
+	query := &RecipientQueryV2{
+		ID:   msgID,
+		Type: RecipientQueryMsgTypeV2,
+		Body: &RecipientQueryBodyV2{},
+	}
+
+	if err := s.outbound.SendToDID(service.NewDIDCommMsgMap(query), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send recipient query: %w", err)
+	}
+
+	select {
+	case recipient := <-recipientCh:
+		var recipients []string
+
+		if recipient.Body != nil {
+			for _, r := range recipient.Body.DIDs {
+				recipients = append(recipients, r.RecipientDID)
+			}
+		}
+
+		return recipients, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for recipient query response from the router")
+	}
 }
 
 // Config fetches the router config - endpoint and routingKeys.
@@ -817,9 +1224,14 @@ func (s *Service) Config(connID string) (*Config, error) {
 	return s.getRouterConfig(connID)
 }
 
-func processKeylistUpdateResp(recKey string, keyUpdateResp *KeylistUpdateResponse) error {
+func processKeylistUpdateResp(recKey, action string, keyUpdateResp *KeylistUpdateResponse) error {
 	for _, result := range keyUpdateResp.Updated {
-		if result.RecipientKey == recKey && result.Action == add && result.Result != success {
+		if result.RecipientKey != recKey || result.Action != action {
+			continue
+		}
+
+		// removing a key the router doesn't know about is not an error
+		if result.Result != success && !(action == remove && result.Result == noChange) {
 			return errors.New("failed to update the recipient key with the router")
 		}
 	}
@@ -845,6 +1257,24 @@ func (s *Service) setKeyUpdateResponseCh(msgID string, keyUpdateCh chan *Keylist
 	}
 }
 
+func (s *Service) getRecipientCh(msgID string) chan *RecipientV2 {
+	s.recipientMapLock.RLock()
+	defer s.recipientMapLock.RUnlock()
+
+	return s.recipientMap[msgID]
+}
+
+func (s *Service) setRecipientCh(msgID string, recipientCh chan *RecipientV2) {
+	s.recipientMapLock.Lock()
+	defer s.recipientMapLock.Unlock()
+
+	if recipientCh == nil {
+		delete(s.recipientMap, msgID)
+	} else {
+		s.recipientMap[msgID] = recipientCh
+	}
+}
+
 func (s *Service) ensureConnectionExists(connID string) error {
 	_, err := s.routeStore.Get(fmt.Sprintf(routeConnIDDataKey, connID))
 	if errors.Is(err, storage.ErrDataNotFound) {
@@ -918,8 +1348,9 @@ func (s *Service) getConnection(routerConnID string) (*connection.Record, error)
 func (s *Service) handleOutboundRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
 	req := &Request{}
 
-	err := msg.Decode(req)
-	if err != nil {
+	if msg.Type() == RequestMsgTypeV2 {
+		req = &Request{ID: msg.ID(), Type: RequestMsgType}
+	} else if err := msg.Decode(req); err != nil {
 		return fmt.Errorf("failed to decode request : %w", err)
 	}
 
@@ -936,6 +1367,11 @@ func dataKey(id string) string {
 	return "route-" + id
 }
 
+// recipientTagValue encodes theirDID for use as tag value, tag values can't contain colons.
+func recipientTagValue(theirDID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(theirDID))
+}
+
 func parseClientOpts(options ...ClientOption) *ClientOptions {
 	opts := &ClientOptions{
 		Timeout: updateTimeout,
@@ -653,7 +653,7 @@ func TestServiceUpdateKeyListMsg(t *testing.T) {
 	t.Run("test service handle request msg - verify outbound message", func(t *testing.T) {
 		update := make(map[string]updateResult)
 		update["ABC"] = updateResult{action: add, result: success}
-		update["XYZ"] = updateResult{action: remove, result: serverError}
+		update["XYZ"] = updateResult{action: remove, result: noChange}
 		update[""] = updateResult{action: add, result: success}
 
 		svc, err := New(&mockprovider.Provider{
@@ -700,6 +700,180 @@ func TestServiceUpdateKeyListMsg(t *testing.T) {
 	})
 }
 
+func TestServiceRecipientUpdateMsgV2(t *testing.T) {
+	newRouter := func(t *testing.T, sent chan *RecipientUpdateResponseV2) *Service {
+		t.Helper()
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					reqMsgMap, ok := msg.(service.DIDCommMsgMap)
+					require.True(t, ok)
+
+					resp := &RecipientUpdateResponseV2{}
+					require.NoError(t, reqMsgMap.Decode(resp))
+
+					sent <- resp
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	t.Run("test service handle recipient update msg - add and remove", func(t *testing.T) {
+		sent := make(chan *RecipientUpdateResponseV2, 1)
+		svc := newRouter(t, sent)
+
+		msgID := randomID()
+
+		err := svc.handleRecipientUpdate(generateRecipientUpdateMsgPayload(t, msgID, []RecipientUpdate{
+			{RecipientDID: "did:example:alice", Action: add},
+			{RecipientDID: "did:example:bob#key-1", Action: add},
+			{RecipientDID: "did:example:carol", Action: remove},
+			{RecipientDID: "did:example:dave", Action: "invalid"},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		resp := <-sent
+		require.Equal(t, RecipientUpdateResponseMsgTypeV2, resp.Type)
+		require.Equal(t, msgID, resp.ThreadID)
+		require.Equal(t, []RecipientUpdateResult{
+			{RecipientDID: "did:example:alice", Action: add, Result: success},
+			{RecipientDID: "did:example:bob#key-1", Action: add, Result: success},
+			{RecipientDID: "did:example:carol", Action: remove, Result: noChange},
+			{RecipientDID: "did:example:dave", Action: "invalid", Result: clientError},
+		}, resp.Body.Updated)
+
+		// other agents can't remove the recipient
+		err = svc.handleRecipientUpdate(generateRecipientUpdateMsgPayload(t, randomID(), []RecipientUpdate{
+			{RecipientDID: "did:example:alice", Action: remove},
+		}), MYDID, "did:example:other")
+		require.NoError(t, err)
+		require.Equal(t, clientError, (<-sent).Body.Updated[0].Result)
+
+		// other agents can't take over the recipient
+		err = svc.handleRecipientUpdate(generateRecipientUpdateMsgPayload(t, randomID(), []RecipientUpdate{
+			{RecipientDID: "did:example:alice", Action: add},
+		}), MYDID, "did:example:other")
+		require.NoError(t, err)
+		require.Equal(t, clientError, (<-sent).Body.Updated[0].Result)
+
+		otherKeys, err := svc.recipientKeys("did:example:other")
+		require.NoError(t, err)
+		require.Empty(t, otherKeys)
+
+		// adding the recipient again is allowed
+		err = svc.handleRecipientUpdate(generateRecipientUpdateMsgPayload(t, randomID(), []RecipientUpdate{
+			{RecipientDID: "did:example:alice", Action: add},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, success, (<-sent).Body.Updated[0].Result)
+
+		err = svc.handleRecipientUpdate(generateRecipientUpdateMsgPayload(t, randomID(), []RecipientUpdate{
+			{RecipientDID: "did:example:alice", Action: remove},
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, success, (<-sent).Body.Updated[0].Result)
+
+		keys, err := svc.recipientKeys(THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:bob#key-1"}, keys)
+	})
+
+	t.Run("test service handle recipient update msg - unmarshal error", func(t *testing.T) {
+		svc := newRouter(t, make(chan *RecipientUpdateResponseV2, 1))
+
+		err := svc.handleRecipientUpdate(&service.DIDCommMsgMap{"id": map[int]int{}}, MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "recipient update message unmarshal")
+	})
+}
+
+func TestServiceRecipientQueryMsgV2(t *testing.T) {
+	sent := make(chan *RecipientV2, 1)
+
+	svc, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		KMSValue:                          &mockkms.KeyManager{},
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				reqMsgMap, ok := msg.(service.DIDCommMsgMap)
+				require.True(t, ok)
+
+				resp := &RecipientV2{}
+				require.NoError(t, reqMsgMap.Decode(resp))
+
+				sent <- resp
+
+				return nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, r := range []string{"did:example:c", "did:example:a", "did:example:b"} {
+		require.Equal(t, success, svc.updateRecipient(add, r, THEIRDID))
+	}
+
+	require.Equal(t, success, svc.updateRecipient(add, "did:example:other", "did:example:other"))
+
+	t.Run("test service handle recipient query msg - all recipients", func(t *testing.T) {
+		msgID := randomID()
+
+		err := svc.handleRecipientQuery(generateRecipientQueryMsgPayload(t, msgID, nil), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		resp := <-sent
+		require.Equal(t, RecipientMsgTypeV2, resp.Type)
+		require.Equal(t, msgID, resp.ThreadID)
+		require.Nil(t, resp.Body.Pagination)
+		require.Equal(t, []RecipientDID{
+			{RecipientDID: "did:example:a"}, {RecipientDID: "did:example:b"}, {RecipientDID: "did:example:c"},
+		}, resp.Body.DIDs)
+	})
+
+	t.Run("test service handle recipient query msg - paginate", func(t *testing.T) {
+		err := svc.handleRecipientQuery(generateRecipientQueryMsgPayload(t, randomID(), &Paginate{
+			Limit:  1,
+			Offset: 1,
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		resp := <-sent
+		require.Equal(t, []RecipientDID{{RecipientDID: "did:example:b"}}, resp.Body.DIDs)
+		require.Equal(t, &Pagination{Count: 1, Offset: 1, Remaining: 1}, resp.Body.Pagination)
+
+		err = svc.handleRecipientQuery(generateRecipientQueryMsgPayload(t, randomID(), &Paginate{
+			Offset: 5,
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		resp = <-sent
+		require.Empty(t, resp.Body.DIDs)
+		require.Equal(t, &Pagination{Count: 0, Offset: 3, Remaining: 0}, resp.Body.Pagination)
+	})
+
+	t.Run("test service handle recipient query msg - unmarshal error", func(t *testing.T) {
+		err := svc.handleRecipientQuery(&service.DIDCommMsgMap{"id": map[int]int{}}, MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "recipient query message unmarshal")
+	})
+}
+
 func TestServiceKeylistUpdateResponseMsg(t *testing.T) {
 	t.Run("test service handle inbound key list update response msg - success", func(t *testing.T) {
 		svc, err := New(&mockprovider.Provider{
@@ -860,6 +1034,39 @@ func TestServiceForwardMsg(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "get destination")
 	})
+
+	t.Run("test service handle forward msg - route to recipient DID of the key", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateForward: func(msg interface{}, des *service.Destination) error {
+					return nil
+				},
+			},
+			VDRegistryValue: &mockvdr.MockVDRegistry{
+				ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+					require.Equal(t, "did:example:123", didID)
+
+					return &did.DocResolution{DIDDocument: mockdiddoc.GetMockDIDDoc(t, false)}, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, success, svc.updateRecipient(add, "did:example:alice", "did:example:123"))
+
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), "did:example:alice#key-1", nil))
+		require.NoError(t, err)
+
+		err = svc.handleForward(generateForwardMsgPayload(t, randomID(), "did:example:bob#key-1", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "route key fetch")
+	})
 }
 
 func TestMessagePickup(t *testing.T) {
@@ -1079,6 +1286,75 @@ func TestRegister(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "fetch connection record from store")
 	})
+
+	t.Run("test register route - coordinate mediation 2.0", func(t *testing.T) {
+		requests := make(chan RequestV2)
+
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					reqMsgMap, ok := msg.(service.DIDCommMsgMap)
+					require.True(t, ok)
+					require.Equal(t, RequestMsgTypeV2, reqMsgMap.Type())
+
+					request := &RequestV2{}
+
+					err := reqMsgMap.Decode(request)
+					require.NoError(t, err)
+
+					requests <- *request
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete", DIDCommVersion: service.V2,
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		go func() {
+			request := <-requests
+
+			grantBytes, err := json.Marshal(&GrantV2{
+				ID:       randomID(),
+				Type:     GrantMsgTypeV2,
+				ThreadID: request.ID,
+				Body: &GrantBodyV2{
+					RoutingDID: []string{"did:example:router"},
+					Endpoint:   ENDPOINT,
+				},
+			})
+			require.NoError(t, err)
+
+			grant, err := service.ParseDIDCommMsgMap(grantBytes)
+			require.NoError(t, err)
+
+			require.NoError(t, svc.saveGrant(grant))
+		}()
+
+		err = svc.Register("conn")
+		require.NoError(t, err)
+
+		conf, err := svc.Config("conn")
+		require.NoError(t, err)
+		require.Equal(t, ENDPOINT, conf.Endpoint())
+		require.Equal(t, []string{"did:example:router"}, conf.Keys())
+
+		connIDs, err := svc.GetConnections(ConnectionByVersion(service.V2))
+		require.NoError(t, err)
+		require.Equal(t, []string{"conn"}, connIDs)
+	})
 }
 
 func TestUnregister(t *testing.T) {
@@ -1325,6 +1601,230 @@ func TestKeylistUpdate(t *testing.T) {
 		require.Error(t, err)
 		require.EqualError(t, err, "ensure connection exists: get error")
 	})
+
+	t.Run("test keylist update - coordinate mediation 2.0 add and remove key", func(t *testing.T) {
+		recipientUpdateMsg := make(chan RecipientUpdateV2)
+		recKey := "did:example:alice"
+
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					reqMsgMap, ok := msg.(service.DIDCommMsgMap)
+					require.True(t, ok)
+					require.Equal(t, RecipientUpdateMsgTypeV2, reqMsgMap.Type())
+
+					request := &RecipientUpdateV2{}
+
+					err := reqMsgMap.Decode(request)
+					require.NoError(t, err)
+
+					recipientUpdateMsg <- *request
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.saveRouterConnectionID("conn", service.V2))
+
+		connRec := &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete", DIDCommVersion: service.V2,
+		}
+		connBytes, err := json.Marshal(connRec)
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+		respond := func(result string) {
+			updateMsg := <-recipientUpdateMsg
+
+			respBytes, err := json.Marshal(&RecipientUpdateResponseV2{
+				ID:       randomID(),
+				Type:     RecipientUpdateResponseMsgTypeV2,
+				ThreadID: updateMsg.ID,
+				Body: &RecipientUpdateResponseBodyV2{Updated: []RecipientUpdateResult{{
+					RecipientDID: updateMsg.Body.Updates[0].RecipientDID,
+					Action:       updateMsg.Body.Updates[0].Action,
+					Result:       result,
+				}}},
+			})
+			require.NoError(t, err)
+
+			msg, err := service.ParseDIDCommMsgMap(respBytes)
+			require.NoError(t, err)
+
+			require.NoError(t, svc.handleRecipientUpdateResponse(msg))
+		}
+
+		go respond(success)
+
+		require.NoError(t, svc.AddKey("conn", recKey))
+
+		// the router doesn't know about the key anymore
+		go respond(noChange)
+
+		require.NoError(t, svc.RemoveKey("conn", recKey))
+
+		go respond(clientError)
+
+		err = svc.RemoveKey("conn", recKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to update the recipient key with the router")
+	})
+}
+
+func TestQueryRecipients(t *testing.T) {
+	newService := func(t *testing.T, s map[string]mockstore.DBEntry, queries chan RecipientQueryV2) *Service {
+		t.Helper()
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					reqMsgMap, ok := msg.(service.DIDCommMsgMap)
+					require.True(t, ok)
+
+					query := &RecipientQueryV2{}
+
+					err := reqMsgMap.Decode(query)
+					require.NoError(t, err)
+
+					queries <- *query
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	saveConnection := func(t *testing.T, svc *Service, s map[string]mockstore.DBEntry, v service.Version) {
+		t.Helper()
+
+		require.NoError(t, svc.saveRouterConnectionID("conn", v))
+
+		connBytes, err := json.Marshal(&connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete", DIDCommVersion: v,
+		})
+		require.NoError(t, err)
+		s["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+	}
+
+	t.Run("test query recipients - success", func(t *testing.T) {
+		s := make(map[string]mockstore.DBEntry)
+		queries := make(chan RecipientQueryV2)
+		svc := newService(t, s, queries)
+		saveConnection(t, svc, s, service.V2)
+
+		go func() {
+			query := <-queries
+
+			respBytes, err := json.Marshal(&RecipientV2{
+				ID:       randomID(),
+				Type:     RecipientMsgTypeV2,
+				ThreadID: query.ID,
+				Body: &RecipientBodyV2{DIDs: []RecipientDID{
+					{RecipientDID: "did:example:alice"}, {RecipientDID: "did:example:bob"},
+				}},
+			})
+			require.NoError(t, err)
+
+			msg, err := service.ParseDIDCommMsgMap(respBytes)
+			require.NoError(t, err)
+
+			require.NoError(t, svc.handleRecipient(msg))
+		}()
+
+		recipients, err := svc.QueryRecipients("conn")
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:alice", "did:example:bob"}, recipients)
+	})
+
+	t.Run("test query recipients - DIDComm V1 router", func(t *testing.T) {
+		s := make(map[string]mockstore.DBEntry)
+		svc := newService(t, s, make(chan RecipientQueryV2))
+		saveConnection(t, svc, s, service.V1)
+
+		_, err := svc.QueryRecipients("conn")
+		require.EqualError(t, err, "recipient query requires a DIDComm V2 router connection")
+	})
+
+	t.Run("test query recipients - router not registered", func(t *testing.T) {
+		svc := newService(t, make(map[string]mockstore.DBEntry), make(chan RecipientQueryV2))
+
+		_, err := svc.QueryRecipients("conn")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "router not registered")
+	})
+
+	t.Run("test duplicate responses don't block the inbound handler", func(t *testing.T) {
+		svc := newService(t, make(map[string]mockstore.DBEntry), make(chan RecipientQueryV2))
+
+		recipientCh := make(chan *RecipientV2, 1)
+		svc.setRecipientCh("thread", recipientCh)
+
+		keyUpdateCh := make(chan *KeylistUpdateResponse, 1)
+		svc.setKeyUpdateResponseCh("thread", keyUpdateCh)
+
+		recipientMsg := service.NewDIDCommMsgMap(&RecipientV2{
+			ID: randomID(), Type: RecipientMsgTypeV2, ThreadID: "thread", Body: &RecipientBodyV2{},
+		})
+		updateResponseMsg := service.NewDIDCommMsgMap(&RecipientUpdateResponseV2{
+			ID: randomID(), Type: RecipientUpdateResponseMsgTypeV2, ThreadID: "thread",
+			Body: &RecipientUpdateResponseBodyV2{},
+		})
+
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 2; i++ {
+				require.NoError(t, svc.handleRecipient(recipientMsg))
+				require.NoError(t, svc.handleRecipientUpdateResponse(updateResponseMsg))
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			require.Fail(t, "inbound handler blocked on a duplicate response")
+		}
+
+		require.Len(t, recipientCh, 1)
+		require.Len(t, keyUpdateCh, 1)
+	})
+
+	t.Run("test query recipients - timeout error", func(t *testing.T) {
+		s := make(map[string]mockstore.DBEntry)
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: s}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+		saveConnection(t, svc, s, service.V2)
+
+		_, err = svc.QueryRecipients("conn")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timeout waiting for recipient query response from the router")
+	})
 }
 
 func TestConfig(t *testing.T) {
@@ -1616,6 +2116,34 @@ func generateForwardMsgPayload(t *testing.T, id, to string, msg []byte) service.
 	return didMsg
 }
 
+func generateRecipientUpdateMsgPayload(t *testing.T, id string, updates []RecipientUpdate) service.DIDCommMsg {
+	requestBytes, err := json.Marshal(&RecipientUpdateV2{
+		ID:   id,
+		Type: RecipientUpdateMsgTypeV2,
+		Body: &RecipientUpdateBodyV2{Updates: updates},
+	})
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(requestBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
+func generateRecipientQueryMsgPayload(t *testing.T, id string, paginate *Paginate) service.DIDCommMsg {
+	requestBytes, err := json.Marshal(&RecipientQueryV2{
+		ID:   id,
+		Type: RecipientQueryMsgTypeV2,
+		Body: &RecipientQueryBodyV2{Paginate: paginate},
+	})
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(requestBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
 func randomID() string {
 	return uuid.New().String()
 }
@@ -9,4 +9,5 @@ package messagepickup
 // ProtocolService service interface for message pickup.
 type ProtocolService interface {
 	AddMessage(message []byte, theirDID string) error
+	AddRecipientMessage(message []byte, theirDID, recipientKey string) error
 }
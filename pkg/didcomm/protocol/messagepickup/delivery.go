@@ -0,0 +1,822 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const defaultDeliveryLimit = 10
+
+// PickupOption configures the Pickup 2.0/3.0 requests.
+type PickupOption func(opts *PickupOptions)
+
+// PickupOptions holds options for the Pickup 2.0/3.0 requests.
+type PickupOptions struct {
+	// Timeout for waiting for the mediator response.
+	Timeout time.Duration
+	// Limit is the max number of messages to be delivered by a delivery request.
+	Limit int
+	// RecipientKey filters messages by recipient key (Pickup 2.0) or recipient DID (Pickup 3.0).
+	RecipientKey string
+}
+
+// liveDelivery holds live delivery settings of an inbox.
+type liveDelivery struct {
+	Spec  string `json:"spec"`
+	MyDID string `json:"my_did"`
+}
+
+// PickupStatus requests status of the messages waiting at the mediator of the given connection.
+// Pickup 3.0 is used for DIDComm V2 connections, Pickup 2.0 otherwise.
+func (s *Service) PickupStatus(connectionID string, options ...PickupOption) (*PickupStatus, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := parsePickupOpts(options...)
+
+	var req service.DIDCommMsgMap
+
+	if conn.DIDCommVersion == service.V2 {
+		req = service.NewDIDCommMsgMap(&StatusRequestV3{
+			ID:   uuid.New().String(),
+			Type: StatusRequestMsgTypeV3,
+			Body: &StatusRequestBodyV3{RecipientDID: opts.RecipientKey},
+		})
+	} else {
+		req = service.NewDIDCommMsgMap(&StatusRequestV2{
+			Type:         StatusRequestMsgTypeV2,
+			ID:           uuid.New().String(),
+			RecipientKey: opts.RecipientKey,
+		})
+	}
+
+	resp, err := s.sendPickupRequest(conn, req, opts.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("status request: %w", err)
+	}
+
+	return decodePickupStatus(resp)
+}
+
+// DeliveryRequest requests delivery of the messages waiting at the mediator of the given connection.
+// Delivered messages are handed over to the inbound message handler and acknowledged, so that the mediator
+// deletes them. Returns the number of processed messages.
+func (s *Service) DeliveryRequest(connectionID string, options ...PickupOption) (int, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return -1, err
+	}
+
+	opts := parsePickupOpts(options...)
+
+	var req service.DIDCommMsgMap
+
+	if conn.DIDCommVersion == service.V2 {
+		req = service.NewDIDCommMsgMap(&DeliveryRequestV3{
+			ID:   uuid.New().String(),
+			Type: DeliveryRequestMsgTypeV3,
+			Body: &DeliveryRequestBodyV3{Limit: opts.Limit, RecipientDID: opts.RecipientKey},
+		})
+	} else {
+		req = service.NewDIDCommMsgMap(&DeliveryRequestV2{
+			Type:         DeliveryRequestMsgTypeV2,
+			ID:           uuid.New().String(),
+			Limit:        opts.Limit,
+			RecipientKey: opts.RecipientKey,
+		})
+	}
+
+	resp, err := s.sendPickupRequest(conn, req, opts.Timeout)
+	if err != nil {
+		return -1, fmt.Errorf("delivery request: %w", err)
+	}
+
+	switch resp.Type() {
+	case DeliveryMsgTypeV2, DeliveryMsgTypeV3:
+		return s.processDelivery(resp, conn.MyDID, conn.TheirDID)
+	default:
+		// no messages waiting, mediator responded with status.
+		return 0, nil
+	}
+}
+
+// LiveDeliveryChange enables or disables live delivery of messages by the mediator of the given connection.
+// Live delivery requires a duplex transport (eg. websocket) with the mediator.
+func (s *Service) LiveDeliveryChange(connectionID string, liveDelivery bool,
+	options ...PickupOption) (*PickupStatus, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := parsePickupOpts(options...)
+
+	var req service.DIDCommMsgMap
+
+	if conn.DIDCommVersion == service.V2 {
+		req = service.NewDIDCommMsgMap(&LiveDeliveryChangeV3{
+			ID:   uuid.New().String(),
+			Type: LiveDeliveryChangeMsgTypeV3,
+			Body: &LiveDeliveryChangeBodyV3{LiveDelivery: liveDelivery},
+		})
+	} else {
+		req = service.NewDIDCommMsgMap(&LiveDeliveryChangeV2{
+			Type:         LiveDeliveryChangeMsgTypeV2,
+			ID:           uuid.New().String(),
+			LiveDelivery: liveDelivery,
+		})
+	}
+
+	resp, err := s.sendPickupRequest(conn, req, opts.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("live delivery change: %w", err)
+	}
+
+	return decodePickupStatus(resp)
+}
+
+func (s *Service) sendPickupRequest(conn *connection.Record, req service.DIDCommMsgMap,
+	timeout time.Duration) (service.DIDCommMsg, error) {
+	// register chan for callback processing
+	respCh := make(chan service.DIDCommMsg, 1)
+	s.setPickupCh(req.ID(), respCh)
+
+	defer s.setPickupCh(req.ID(), nil)
+
+	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send %s: %w", req.Type(), err)
+	}
+
+	// callback processing (to make this function look like a sync function)
+	select {
+	case resp := <-respCh:
+		return resp, nil
+	case <-time.After(timeout):
+		return nil, errors.New("timeout waiting for mediator response")
+	}
+}
+
+// notifyPickupCh passes the response to the pending request, returns false if there is no such request.
+func (s *Service) notifyPickupCh(msg service.DIDCommMsg) (bool, error) {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return false, fmt.Errorf("%s thread ID: %w", msg.Type(), err)
+	}
+
+	respCh := s.getPickupCh(thID)
+	if respCh == nil {
+		return false, nil
+	}
+
+	select {
+	case respCh <- msg:
+	default:
+	}
+
+	return true, nil
+}
+
+func (s *Service) handlePickupStatus(msg service.DIDCommMsg) error {
+	notified, err := s.notifyPickupCh(msg)
+	if err != nil {
+		return err
+	}
+
+	if !notified {
+		logger.Debugf("no pending request found for status %s", msg.ID())
+	}
+
+	return nil
+}
+
+func (s *Service) handleDelivery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	notified, err := s.notifyPickupCh(msg)
+	if err != nil {
+		return err
+	}
+
+	if notified {
+		return nil
+	}
+
+	// delivery not requested, it was sent by the mediator in live mode.
+	_, err = s.processDelivery(msg, myDID, theirDID)
+
+	return err
+}
+
+// processDelivery hands over delivered messages to the inbound message handler and acknowledges the handled ones.
+// Messages which failed to be handled are not acknowledged, so that the mediator keeps them for a later delivery.
+func (s *Service) processDelivery(msg service.DIDCommMsg, myDID, theirDID string) (int, error) {
+	spec := specOf(msg.Type())
+
+	msgs, err := decodeDelivery(msg)
+	if err != nil {
+		return -1, err
+	}
+
+	received := make([]string, 0, len(msgs))
+
+	for _, m := range msgs {
+		if e := s.handle(m); e != nil {
+			logger.Errorf("error handling delivered message %s: %s", m.ID, e)
+
+			continue
+		}
+
+		received = append(received, m.ID)
+	}
+
+	if len(received) == 0 {
+		return 0, nil
+	}
+
+	var ack service.DIDCommMsgMap
+
+	if spec == SpecV3 {
+		ack = service.NewDIDCommMsgMap(&MessagesReceivedV3{
+			ID:   uuid.New().String(),
+			Type: MessagesReceivedMsgTypeV3,
+			Body: &MessagesReceivedBodyV3{MessageIDList: received},
+		})
+	} else {
+		ack = service.NewDIDCommMsgMap(&MessagesReceivedV2{
+			Type:          MessagesReceivedMsgTypeV2,
+			ID:            uuid.New().String(),
+			MessageIDList: received,
+		})
+	}
+
+	if err = s.outbound.SendToDID(ack, myDID, theirDID); err != nil {
+		return -1, fmt.Errorf("send messages received: %w", err)
+	}
+
+	return len(received), nil
+}
+
+func (s *Service) handlePickupStatusRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
+	var recipient string
+
+	if specOf(msg.Type()) == SpecV3 {
+		req := &StatusRequestV3{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("status request message unmarshal: %w", err)
+		}
+
+		if req.Body != nil {
+			recipient = req.Body.RecipientDID
+		}
+	} else {
+		req := &StatusRequestV2{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("status request message unmarshal: %w", err)
+		}
+
+		recipient = req.RecipientKey
+	}
+
+	status, err := s.pendingStatus(theirDID, recipient)
+	if err != nil {
+		return fmt.Errorf("status request get inbox: %w", err)
+	}
+
+	return s.reply(msg, newStatusMsg(specOf(msg.Type()), status), myDID, theirDID)
+}
+
+func (s *Service) pendingStatus(theirDID, recipient string) (*PickupStatus, error) {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	ibx, msgs, err := s.pendingMessages(theirDID, recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPickupStatus(ibx, msgs, recipient), nil
+}
+
+func (s *Service) handleDeliveryRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
+	var (
+		limit     int
+		recipient string
+		spec      = specOf(msg.Type())
+	)
+
+	if spec == SpecV3 {
+		req := &DeliveryRequestV3{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("delivery request message unmarshal: %w", err)
+		}
+
+		if req.Body != nil {
+			limit, recipient = req.Body.Limit, req.Body.RecipientDID
+		}
+	} else {
+		req := &DeliveryRequestV2{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("delivery request message unmarshal: %w", err)
+		}
+
+		limit, recipient = req.Limit, req.RecipientKey
+	}
+
+	status, msgs, err := s.deliverableMessages(theirDID, recipient, limit)
+	if err != nil {
+		return fmt.Errorf("delivery request: %w", err)
+	}
+
+	if len(msgs) == 0 {
+		return s.reply(msg, newStatusMsg(spec, status), myDID, theirDID)
+	}
+
+	return s.reply(msg, newDeliveryMsg(spec, recipient, msgs), myDID, theirDID)
+}
+
+// deliverableMessages returns up to limit (all if not positive) messages of theirDID for the given recipient, or the
+// status of the inbox if there are no such messages.
+func (s *Service) deliverableMessages(theirDID, recipient string, limit int) (*PickupStatus, []*Message, error) {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	ibx, msgs, err := s.pendingMessages(theirDID, recipient)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get inbox: %w", err)
+	}
+
+	if len(msgs) == 0 {
+		return newPickupStatus(ibx, msgs, recipient), nil, nil
+	}
+
+	if limit > 0 && limit < len(msgs) {
+		msgs = msgs[:limit]
+	}
+
+	// delivered messages stay in the inbox until the recipient acknowledges them.
+	ibx.LastDeliveredTime = time.Now()
+
+	err = s.putInbox(theirDID, ibx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("put inbox: %w", err)
+	}
+
+	return nil, msgs, nil
+}
+
+func (s *Service) handleMessagesReceived(msg service.DIDCommMsg, myDID, theirDID string) error {
+	var received []string
+
+	if specOf(msg.Type()) == SpecV3 {
+		req := &MessagesReceivedV3{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("messages received message unmarshal: %w", err)
+		}
+
+		if req.Body != nil {
+			received = req.Body.MessageIDList
+		}
+	} else {
+		req := &MessagesReceivedV2{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("messages received message unmarshal: %w", err)
+		}
+
+		received = req.MessageIDList
+	}
+
+	status, err := s.removeMessages(theirDID, received)
+	if err != nil {
+		return fmt.Errorf("messages received: %w", err)
+	}
+
+	return s.reply(msg, newStatusMsg(specOf(msg.Type()), status), myDID, theirDID)
+}
+
+// removeMessages removes the received messages from the inbox of theirDID and returns the status of the inbox.
+func (s *Service) removeMessages(theirDID string, received []string) (*PickupStatus, error) {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	ibx, msgs, err := s.pendingMessages(theirDID, "")
+	if err != nil {
+		return nil, fmt.Errorf("get inbox: %w", err)
+	}
+
+	ids := make(map[string]struct{}, len(received))
+	for _, id := range received {
+		ids[id] = struct{}{}
+	}
+
+	remaining := make([]*Message, 0, len(msgs))
+
+	for _, m := range msgs {
+		if _, ok := ids[m.ID]; !ok {
+			remaining = append(remaining, m)
+		}
+	}
+
+	if len(remaining) != len(msgs) {
+		ibx.LastRemovedTime = time.Now()
+
+		err = ibx.EncodeMessages(remaining)
+		if err != nil {
+			return nil, fmt.Errorf("encode: %w", err)
+		}
+
+		err = s.putInbox(theirDID, ibx)
+		if err != nil {
+			return nil, fmt.Errorf("put inbox: %w", err)
+		}
+	}
+
+	return newPickupStatus(ibx, remaining, ""), nil
+}
+
+func (s *Service) handleLiveDeliveryChange(msg service.DIDCommMsg, myDID, theirDID string) error {
+	var (
+		live bool
+		spec = specOf(msg.Type())
+	)
+
+	if spec == SpecV3 {
+		req := &LiveDeliveryChangeV3{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("live delivery change message unmarshal: %w", err)
+		}
+
+		live = req.Body != nil && req.Body.LiveDelivery
+	} else {
+		req := &LiveDeliveryChangeV2{}
+		if err := msg.Decode(req); err != nil {
+			return fmt.Errorf("live delivery change message unmarshal: %w", err)
+		}
+
+		live = req.LiveDelivery
+	}
+
+	var settings *liveDelivery
+	if live {
+		settings = &liveDelivery{Spec: spec, MyDID: myDID}
+	}
+
+	status, msgs, err := s.setLiveDelivery(settings, theirDID)
+	if err != nil {
+		return err
+	}
+
+	err = s.reply(msg, newStatusMsg(spec, status), myDID, theirDID)
+	if err != nil {
+		return err
+	}
+
+	// deliver messages which were waiting for the recipient before live mode was turned on.
+	if settings != nil && len(msgs) > 0 {
+		s.deliverLive(settings, theirDID, msgs)
+	}
+
+	return nil
+}
+
+// setLiveDelivery saves live delivery settings of the inbox of theirDID and returns the status and the messages of
+// the inbox.
+func (s *Service) setLiveDelivery(settings *liveDelivery, theirDID string) (*PickupStatus, []*Message, error) {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	ibx, err := s.createInbox(theirDID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("live delivery change get inbox: %w", err)
+	}
+
+	ibx.LiveDelivery = settings
+
+	err = s.putInbox(theirDID, ibx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("live delivery change put inbox: %w", err)
+	}
+
+	msgs, err := ibx.DecodeMessages()
+	if err != nil {
+		return nil, nil, fmt.Errorf("live delivery change decode: %w", err)
+	}
+
+	return newPickupStatus(ibx, msgs, ""), msgs, nil
+}
+
+// deliverLive sends given messages to theirDID in live mode, messages are kept in the inbox until the recipient
+// acknowledges them so a failed delivery can be retried with a delivery request.
+func (s *Service) deliverLive(settings *liveDelivery, theirDID string, msgs []*Message) {
+	delivery := newDeliveryMsg(settings.Spec, "", msgs)
+
+	if err := s.outbound.SendToDID(delivery, settings.MyDID, theirDID); err != nil {
+		logger.Warnf("live delivery to %s failed, messages are kept in the inbox: %s", theirDID, err)
+	}
+}
+
+// pendingMessages returns the inbox of theirDID and its messages for the given recipient (all if empty).
+func (s *Service) pendingMessages(theirDID, recipient string) (*inbox, []*Message, error) {
+	ibx, err := s.getInbox(theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &inbox{DID: theirDID}, nil, nil
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msgs, err := ibx.DecodeMessages()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if recipient == "" {
+		return ibx, msgs, nil
+	}
+
+	var filtered []*Message
+
+	for _, m := range msgs {
+		// Pickup 3.0 filters by recipient DID, which matches all the keys of that DID.
+		if m.Recipient == recipient || strings.HasPrefix(m.Recipient, recipient+"#") {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return ibx, filtered, nil
+}
+
+func (s *Service) reply(in service.DIDCommMsg, out service.DIDCommMsgMap, myDID, theirDID string) error {
+	thID, err := in.ThreadID()
+	if err != nil {
+		return fmt.Errorf("%s thread ID: %w", in.Type(), err)
+	}
+
+	version := service.V1
+	if specOf(in.Type()) == SpecV3 {
+		version = service.V2
+	}
+
+	out.SetThread(thID, "", service.WithVersion(version))
+
+	if err = s.outbound.SendToDID(out, myDID, theirDID); err != nil {
+		return fmt.Errorf("send %s: %w", out.Type(), err)
+	}
+
+	return nil
+}
+
+func (s *Service) getPickupCh(thID string) chan service.DIDCommMsg {
+	s.pickupMapLock.RLock()
+	defer s.pickupMapLock.RUnlock()
+
+	return s.pickupMap[thID]
+}
+
+func (s *Service) setPickupCh(msgID string, respCh chan service.DIDCommMsg) {
+	s.pickupMapLock.Lock()
+	defer s.pickupMapLock.Unlock()
+
+	if respCh == nil {
+		delete(s.pickupMap, msgID)
+	} else {
+		s.pickupMap[msgID] = respCh
+	}
+}
+
+func newPickupStatus(ibx *inbox, msgs []*Message, recipient string) *PickupStatus {
+	status := &PickupStatus{
+		RecipientKey: recipient,
+		MessageCount: len(msgs),
+		LiveDelivery: ibx.LiveDelivery != nil,
+	}
+
+	for _, m := range msgs {
+		status.TotalBytes += len(m.Message)
+
+		if status.OldestReceivedTime.IsZero() || m.AddedTime.Before(status.OldestReceivedTime) {
+			status.OldestReceivedTime = m.AddedTime
+		}
+
+		if m.AddedTime.After(status.NewestReceivedTime) {
+			status.NewestReceivedTime = m.AddedTime
+		}
+	}
+
+	if !status.OldestReceivedTime.IsZero() {
+		status.LongestWaitedSeconds = int(time.Since(status.OldestReceivedTime).Seconds())
+	}
+
+	return status
+}
+
+func newStatusMsg(spec string, status *PickupStatus) service.DIDCommMsgMap {
+	if spec == SpecV3 {
+		body := &StatusBodyV3{
+			RecipientDID:         status.RecipientKey,
+			MessageCount:         status.MessageCount,
+			LongestWaitedSeconds: status.LongestWaitedSeconds,
+			TotalBytes:           status.TotalBytes,
+			LiveDelivery:         status.LiveDelivery,
+		}
+
+		if status.MessageCount > 0 {
+			body.NewestReceivedTime = status.NewestReceivedTime.Unix()
+			body.OldestReceivedTime = status.OldestReceivedTime.Unix()
+		}
+
+		return service.NewDIDCommMsgMap(&StatusV3{
+			ID:   uuid.New().String(),
+			Type: StatusMsgTypeV3,
+			Body: body,
+		})
+	}
+
+	msg := &StatusV2{
+		Type:                 StatusMsgTypeV2,
+		ID:                   uuid.New().String(),
+		RecipientKey:         status.RecipientKey,
+		MessageCount:         status.MessageCount,
+		LongestWaitedSeconds: status.LongestWaitedSeconds,
+		TotalBytes:           status.TotalBytes,
+		LiveDelivery:         status.LiveDelivery,
+	}
+
+	if status.MessageCount > 0 {
+		newest, oldest := status.NewestReceivedTime, status.OldestReceivedTime
+		msg.NewestReceivedTime, msg.OldestReceivedTime = &newest, &oldest
+	}
+
+	return service.NewDIDCommMsgMap(msg)
+}
+
+func newDeliveryMsg(spec, recipient string, msgs []*Message) service.DIDCommMsgMap {
+	if spec == SpecV3 {
+		attachments := make([]decorator.AttachmentV2, len(msgs))
+
+		for i, m := range msgs {
+			attachments[i] = decorator.AttachmentV2{
+				ID:   m.ID,
+				Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(m.Message)},
+			}
+		}
+
+		return service.NewDIDCommMsgMap(&DeliveryV3{
+			ID:          uuid.New().String(),
+			Type:        DeliveryMsgTypeV3,
+			Body:        &DeliveryBodyV3{RecipientDID: recipient},
+			Attachments: attachments,
+		})
+	}
+
+	attachments := make([]decorator.Attachment, len(msgs))
+
+	for i, m := range msgs {
+		attachments[i] = decorator.Attachment{
+			ID:   m.ID,
+			Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString(m.Message)},
+		}
+	}
+
+	return service.NewDIDCommMsgMap(&DeliveryV2{
+		Type:         DeliveryMsgTypeV2,
+		ID:           uuid.New().String(),
+		RecipientKey: recipient,
+		Attachments:  attachments,
+	})
+}
+
+func decodeDelivery(msg service.DIDCommMsg) ([]*Message, error) {
+	var attachments []decorator.AttachmentData
+
+	ids := []string{}
+
+	if specOf(msg.Type()) == SpecV3 {
+		delivery := &DeliveryV3{}
+		if err := msg.Decode(delivery); err != nil {
+			return nil, fmt.Errorf("delivery message unmarshal: %w", err)
+		}
+
+		for _, a := range delivery.Attachments {
+			ids = append(ids, a.ID)
+			attachments = append(attachments, a.Data)
+		}
+	} else {
+		delivery := &DeliveryV2{}
+		if err := msg.Decode(delivery); err != nil {
+			return nil, fmt.Errorf("delivery message unmarshal: %w", err)
+		}
+
+		for _, a := range delivery.Attachments {
+			ids = append(ids, a.ID)
+			attachments = append(attachments, a.Data)
+		}
+	}
+
+	msgs := make([]*Message, len(attachments))
+
+	for i := range attachments {
+		data, err := attachments[i].Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("delivered message %s: %w", ids[i], err)
+		}
+
+		msgs[i] = &Message{ID: ids[i], Message: data}
+	}
+
+	return msgs, nil
+}
+
+func decodePickupStatus(msg service.DIDCommMsg) (*PickupStatus, error) {
+	switch msg.Type() {
+	case StatusMsgTypeV3:
+		status := &StatusV3{}
+		if err := msg.Decode(status); err != nil {
+			return nil, fmt.Errorf("status message unmarshal: %w", err)
+		}
+
+		if status.Body == nil {
+			return nil, errors.New("status message has no body")
+		}
+
+		result := &PickupStatus{
+			RecipientKey:         status.Body.RecipientDID,
+			MessageCount:         status.Body.MessageCount,
+			LongestWaitedSeconds: status.Body.LongestWaitedSeconds,
+			TotalBytes:           status.Body.TotalBytes,
+			LiveDelivery:         status.Body.LiveDelivery,
+		}
+
+		if status.Body.NewestReceivedTime != 0 {
+			result.NewestReceivedTime = time.Unix(status.Body.NewestReceivedTime, 0)
+		}
+
+		if status.Body.OldestReceivedTime != 0 {
+			result.OldestReceivedTime = time.Unix(status.Body.OldestReceivedTime, 0)
+		}
+
+		return result, nil
+	case StatusMsgTypeV2:
+		status := &StatusV2{}
+		if err := msg.Decode(status); err != nil {
+			return nil, fmt.Errorf("status message unmarshal: %w", err)
+		}
+
+		result := &PickupStatus{
+			RecipientKey:         status.RecipientKey,
+			MessageCount:         status.MessageCount,
+			LongestWaitedSeconds: status.LongestWaitedSeconds,
+			TotalBytes:           status.TotalBytes,
+			LiveDelivery:         status.LiveDelivery,
+		}
+
+		if status.NewestReceivedTime != nil {
+			result.NewestReceivedTime = *status.NewestReceivedTime
+		}
+
+		if status.OldestReceivedTime != nil {
+			result.OldestReceivedTime = *status.OldestReceivedTime
+		}
+
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unexpected response message type %s", msg.Type())
+	}
+}
+
+func specOf(msgType string) string {
+	if strings.HasPrefix(msgType, SpecV3) {
+		return SpecV3
+	}
+
+	return SpecV2
+}
+
+func parsePickupOpts(options ...PickupOption) *PickupOptions {
+	opts := &PickupOptions{
+		Timeout: updateTimeout,
+		Limit:   defaultDeliveryLimit,
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
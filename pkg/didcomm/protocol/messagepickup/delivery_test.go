@@ -0,0 +1,480 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messagepickup
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+func TestPickupMediator(t *testing.T) {
+	t.Run("test Pickup 2.0 - status, delivery and messages received", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+
+		require.NoError(t, svc.AddRecipientMessage([]byte("msg-1"), THEIRDID, "key-1"))
+		require.NoError(t, svc.AddRecipientMessage([]byte("msg-2"), THEIRDID, "key-1"))
+		require.NoError(t, svc.AddRecipientMessage([]byte("msg-3"), THEIRDID, "key-2"))
+
+		statusReq := service.NewDIDCommMsgMap(&StatusRequestV2{
+			ID: "status-1", Type: StatusRequestMsgTypeV2, RecipientKey: "key-1",
+		})
+
+		_, err := svc.HandleInbound(statusReq, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		status := &StatusV2{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, StatusMsgTypeV2, status.Type)
+		require.Equal(t, "status-1", status.Thread.ID)
+		require.Equal(t, "key-1", status.RecipientKey)
+		require.Equal(t, 2, status.MessageCount)
+		require.Equal(t, 10, status.TotalBytes)
+		require.NotNil(t, status.OldestReceivedTime)
+		require.False(t, status.LiveDelivery)
+
+		deliveryReq := service.NewDIDCommMsgMap(&DeliveryRequestV2{
+			ID: "delivery-1", Type: DeliveryRequestMsgTypeV2, Limit: 2,
+		})
+
+		_, err = svc.HandleInbound(deliveryReq, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		delivery := &DeliveryV2{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Equal(t, DeliveryMsgTypeV2, delivery.Type)
+		require.Len(t, delivery.Attachments, 2)
+
+		data, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "msg-1", string(data))
+
+		received := service.NewDIDCommMsgMap(&MessagesReceivedV2{
+			ID:            "received-1",
+			Type:          MessagesReceivedMsgTypeV2,
+			MessageIDList: []string{delivery.Attachments[0].ID, delivery.Attachments[1].ID},
+		})
+
+		_, err = svc.HandleInbound(received, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		status = &StatusV2{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, 1, status.MessageCount)
+
+		ibx, msgs, err := svc.pendingMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.False(t, ibx.LastRemovedTime.IsZero())
+		require.Len(t, msgs, 1)
+		require.Equal(t, "key-2", msgs[0].Recipient)
+	})
+
+	t.Run("test Pickup 2.0 - delivery request without messages", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+
+		deliveryReq := service.NewDIDCommMsgMap(&DeliveryRequestV2{
+			ID: "delivery-1", Type: DeliveryRequestMsgTypeV2, Limit: 10,
+		})
+
+		_, err := svc.HandleInbound(deliveryReq, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		status := &StatusV2{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, StatusMsgTypeV2, status.Type)
+		require.Equal(t, 0, status.MessageCount)
+	})
+
+	t.Run("test Pickup 3.0 - status filtered by recipient DID", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+
+		require.NoError(t, svc.AddRecipientMessage([]byte("msg-1"), THEIRDID, "did:example:123#key-1"))
+		require.NoError(t, svc.AddRecipientMessage([]byte("msg-2"), THEIRDID, "did:example:456#key-1"))
+
+		statusReq := service.NewDIDCommMsgMap(&StatusRequestV3{
+			ID: "status-1", Type: StatusRequestMsgTypeV3, Body: &StatusRequestBodyV3{RecipientDID: "did:example:123"},
+		})
+
+		_, err := svc.HandleInbound(statusReq, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		status := &StatusV3{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.Equal(t, StatusMsgTypeV3, status.Type)
+		require.Equal(t, "status-1", status.ThreadID)
+		require.Equal(t, 1, status.Body.MessageCount)
+		require.NotZero(t, status.Body.OldestReceivedTime)
+	})
+
+	t.Run("test Pickup 3.0 - live delivery", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+
+		require.NoError(t, svc.AddMessage([]byte("msg-1"), THEIRDID))
+
+		liveReq := service.NewDIDCommMsgMap(&LiveDeliveryChangeV3{
+			ID: "live-1", Type: LiveDeliveryChangeMsgTypeV3, Body: &LiveDeliveryChangeBodyV3{LiveDelivery: true},
+		})
+
+		_, err := svc.HandleInbound(liveReq, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		status := &StatusV3{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.True(t, status.Body.LiveDelivery)
+		require.Equal(t, 1, status.Body.MessageCount)
+
+		// pending messages are delivered once live mode is on.
+		delivery := &DeliveryV3{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Equal(t, DeliveryMsgTypeV3, delivery.Type)
+		require.Len(t, delivery.Attachments, 1)
+
+		// new messages are delivered right away.
+		require.NoError(t, svc.AddMessage([]byte("msg-2"), THEIRDID))
+
+		delivery = &DeliveryV3{}
+		require.NoError(t, (<-sent).Decode(delivery))
+		require.Len(t, delivery.Attachments, 1)
+
+		data, err := delivery.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "msg-2", string(data))
+
+		// messages stay in the inbox until acknowledged.
+		_, msgs, err := svc.pendingMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+
+		liveReq = service.NewDIDCommMsgMap(&LiveDeliveryChangeV3{
+			ID: "live-2", Type: LiveDeliveryChangeMsgTypeV3, Body: &LiveDeliveryChangeBodyV3{LiveDelivery: false},
+		})
+
+		_, err = svc.HandleInbound(liveReq, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		status = &StatusV3{}
+		require.NoError(t, (<-sent).Decode(status))
+		require.False(t, status.Body.LiveDelivery)
+
+		require.NoError(t, svc.AddMessage([]byte("msg-3"), THEIRDID))
+
+		select {
+		case msg := <-sent:
+			require.Fail(t, "unexpected message sent", msg.Type())
+		default:
+		}
+	})
+
+	t.Run("test Pickup 2.0 - live delivery send error keeps messages", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+			PackagerValue:                     &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		liveReq := service.NewDIDCommMsgMap(&LiveDeliveryChangeV2{
+			ID: "live-1", Type: LiveDeliveryChangeMsgTypeV2, LiveDelivery: true,
+		})
+
+		_, err = svc.HandleInbound(liveReq, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		svc.outbound = &mockdispatcher.MockOutbound{SendErr: errors.New("send error")}
+
+		require.NoError(t, svc.AddMessage([]byte("msg-1"), THEIRDID))
+
+		_, msgs, err := svc.pendingMessages(THEIRDID, "")
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+	})
+
+	t.Run("test Pickup 2.0 - inbox is not locked while sending", func(t *testing.T) {
+		var svc *Service
+
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					// inbox operations don't wait for sending to complete.
+					svc.inboxLock.Lock()
+					defer svc.inboxLock.Unlock()
+
+					return nil
+				},
+			},
+			PackagerValue:              &mockPackager{},
+			InboundMessageHandlerValue: (&mockTransportProvider{}).InboundMessageHandler(),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.AddRecipientMessage([]byte("msg-1"), THEIRDID, "key-1"))
+
+		err = svc.handlePickupStatusRequest(service.NewDIDCommMsgMap(&StatusRequestV2{
+			ID: "status-1", Type: StatusRequestMsgTypeV2,
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		err = svc.handleDeliveryRequest(service.NewDIDCommMsgMap(&DeliveryRequestV2{
+			ID: "delivery-1", Type: DeliveryRequestMsgTypeV2,
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		err = svc.handleMessagesReceived(service.NewDIDCommMsgMap(&MessagesReceivedV2{
+			ID: "received-1", Type: MessagesReceivedMsgTypeV2,
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+
+		err = svc.handleLiveDeliveryChange(service.NewDIDCommMsgMap(&LiveDeliveryChangeV2{
+			ID: "live-1", Type: LiveDeliveryChangeMsgTypeV2, LiveDelivery: true,
+		}), MYDID, THEIRDID)
+		require.NoError(t, err)
+	})
+
+	t.Run("test Pickup 2.0 - invalid message", func(t *testing.T) {
+		svc := newPickupService(t, make(chan service.DIDCommMsgMap, 10))
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(`{
+			"@id": "123",
+			"@type": "https://didcomm.org/messagepickup/2.0/delivery-request",
+			"limit": "invalid"
+		}`))
+		require.NoError(t, err)
+
+		err = svc.handleDeliveryRequest(msg, MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "delivery request message unmarshal")
+	})
+}
+
+func TestPickupRecipient(t *testing.T) {
+	t.Run("test Service.PickupStatus() - success", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+		saveConnection(t, svc, service.V1)
+
+		go func() {
+			req := <-sent
+			require.Equal(t, StatusRequestMsgTypeV2, req.Type())
+
+			status := service.NewDIDCommMsgMap(&StatusV2{
+				ID: "status-1", Type: StatusMsgTypeV2, MessageCount: 4, LiveDelivery: true,
+			})
+			status.SetThread(req.ID(), "")
+
+			_, err := svc.HandleInbound(status, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+		}()
+
+		status, err := svc.PickupStatus("conn", func(opts *PickupOptions) { opts.Timeout = time.Second })
+		require.NoError(t, err)
+		require.Equal(t, 4, status.MessageCount)
+		require.True(t, status.LiveDelivery)
+	})
+
+	t.Run("test Service.PickupStatus() - timeout", func(t *testing.T) {
+		svc := newPickupService(t, make(chan service.DIDCommMsgMap, 10))
+		saveConnection(t, svc, service.V1)
+
+		_, err := svc.PickupStatus("conn", func(opts *PickupOptions) { opts.Timeout = time.Millisecond })
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timeout waiting for mediator response")
+	})
+
+	t.Run("test Service.PickupStatus() - connection error", func(t *testing.T) {
+		svc := newPickupService(t, make(chan service.DIDCommMsgMap, 10))
+
+		_, err := svc.PickupStatus("conn")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("test Service.DeliveryRequest() - success", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+		saveConnection(t, svc, service.V2)
+
+		go func() {
+			req := <-sent
+			require.Equal(t, DeliveryRequestMsgTypeV3, req.Type())
+
+			deliveryReq := &DeliveryRequestV3{}
+			require.NoError(t, req.Decode(deliveryReq))
+			require.Equal(t, 5, deliveryReq.Body.Limit)
+
+			delivery := newDeliveryMsg(SpecV3, "", []*Message{
+				{ID: "msg-1", Message: []byte(`{"id": "1"}`)},
+				{ID: "msg-2", Message: []byte(`{"id": "2"}`)},
+			})
+			delivery.SetThread(req.ID(), "", service.WithVersion(service.V2))
+
+			_, err := svc.HandleInbound(delivery, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+		}()
+
+		count, err := svc.DeliveryRequest("conn", func(opts *PickupOptions) { opts.Limit = 5 })
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		ack := &MessagesReceivedV3{}
+		require.NoError(t, (<-sent).Decode(ack))
+		require.Equal(t, MessagesReceivedMsgTypeV3, ack.Type)
+		require.Equal(t, []string{"msg-1", "msg-2"}, ack.Body.MessageIDList)
+	})
+
+	t.Run("test Service.DeliveryRequest() - no messages", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+		saveConnection(t, svc, service.V1)
+
+		go func() {
+			req := <-sent
+
+			status := service.NewDIDCommMsgMap(&StatusV2{ID: "status-1", Type: StatusMsgTypeV2})
+			status.SetThread(req.ID(), "")
+
+			_, err := svc.HandleInbound(status, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+		}()
+
+		count, err := svc.DeliveryRequest("conn")
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("test Service.LiveDeliveryChange() - success", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+		saveConnection(t, svc, service.V1)
+
+		go func() {
+			req := <-sent
+
+			liveReq := &LiveDeliveryChangeV2{}
+			require.NoError(t, req.Decode(liveReq))
+			require.True(t, liveReq.LiveDelivery)
+
+			status := service.NewDIDCommMsgMap(&StatusV2{ID: "status-1", Type: StatusMsgTypeV2, LiveDelivery: true})
+			status.SetThread(req.ID(), "")
+
+			_, err := svc.HandleInbound(status, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+			require.NoError(t, err)
+		}()
+
+		status, err := svc.LiveDeliveryChange("conn", true)
+		require.NoError(t, err)
+		require.True(t, status.LiveDelivery)
+	})
+
+	t.Run("test Service.HandleInbound() - live delivery without pending request", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+
+		delivery := newDeliveryMsg(SpecV2, "", []*Message{{ID: "msg-1", Message: []byte(`{"id": "1"}`)}})
+		delivery.SetThread("unknown", "")
+
+		_, err := svc.HandleInbound(delivery, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		ack := &MessagesReceivedV2{}
+		require.NoError(t, (<-sent).Decode(ack))
+		require.Equal(t, []string{"msg-1"}, ack.MessageIDList)
+	})
+
+	t.Run("test Service.HandleInbound() - messages failed to be handled are not acknowledged", func(t *testing.T) {
+		sent := make(chan service.DIDCommMsgMap, 10)
+		svc := newPickupService(t, sent)
+		svc.packager = &invalidMsgPackager{}
+
+		delivery := newDeliveryMsg(SpecV2, "", []*Message{
+			{ID: "msg-1", Message: []byte(`{"id": "1"}`)},
+			{ID: "msg-2", Message: []byte("invalid")},
+		})
+		delivery.SetThread("unknown", "")
+
+		_, err := svc.HandleInbound(delivery, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+
+		ack := &MessagesReceivedV2{}
+		require.NoError(t, (<-sent).Decode(ack))
+		require.Equal(t, []string{"msg-1"}, ack.MessageIDList)
+
+		// nothing is acknowledged if no message is handled.
+		delivery = newDeliveryMsg(SpecV2, "", []*Message{{ID: "msg-3", Message: []byte("invalid")}})
+		delivery.SetThread("unknown", "")
+
+		_, err = svc.HandleInbound(delivery, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+		require.Empty(t, sent)
+	})
+}
+
+// invalidMsgPackager fails to unpack "invalid" messages.
+type invalidMsgPackager struct {
+	mockPackager
+}
+
+func (m *invalidMsgPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	if string(encMessage) == "invalid" {
+		return nil, errors.New("unpack error")
+	}
+
+	return m.mockPackager.UnpackMessage(encMessage)
+}
+
+func newPickupService(t *testing.T, sent chan service.DIDCommMsgMap) *Service {
+	t.Helper()
+
+	svc, err := New(&mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				msgMap, ok := msg.(service.DIDCommMsgMap)
+				if !ok {
+					return errors.New("unexpected message")
+				}
+
+				sent <- msgMap
+
+				return nil
+			},
+		},
+		PackagerValue:              &mockPackager{},
+		InboundMessageHandlerValue: (&mockTransportProvider{}).InboundMessageHandler(),
+	})
+	require.NoError(t, err)
+
+	return svc
+}
+
+func saveConnection(t *testing.T, svc *Service, version service.Version) {
+	t.Helper()
+
+	svc.connectionLookup = &connectionsStub{
+		getConnRecord: func(string) (*connection.Record, error) {
+			return &connection.Record{
+				ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "completed", DIDCommVersion: version,
+			}, nil
+		},
+	}
+}
@@ -57,6 +57,7 @@ type Message struct {
 	ID        string    `json:"id"`
 	AddedTime time.Time `json:"added_time"`
 	Message   []byte    `json:"msg,omitempty"`
+	Recipient string    `json:"recipient,omitempty"`
 }
 
 // Noop message
@@ -65,3 +66,161 @@ type Noop struct {
 	Type string `json:"@type,omitempty"`
 	ID   string `json:"@id,omitempty"`
 }
+
+// StatusRequestV2 sent by the recipient to the mediator to request a status message (Pickup 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0685-pickup-v2#status-request
+type StatusRequestV2 struct {
+	Type         string            `json:"@type,omitempty"`
+	ID           string            `json:"@id,omitempty"`
+	RecipientKey string            `json:"recipient_key,omitempty"`
+	Thread       *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// StatusV2 details about pending messages (Pickup 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0685-pickup-v2#status
+type StatusV2 struct {
+	Type                 string            `json:"@type,omitempty"`
+	ID                   string            `json:"@id,omitempty"`
+	RecipientKey         string            `json:"recipient_key,omitempty"`
+	MessageCount         int               `json:"message_count"`
+	LongestWaitedSeconds int               `json:"longest_waited_seconds,omitempty"`
+	NewestReceivedTime   *time.Time        `json:"newest_received_time,omitempty"`
+	OldestReceivedTime   *time.Time        `json:"oldest_received_time,omitempty"`
+	TotalBytes           int               `json:"total_bytes,omitempty"`
+	LiveDelivery         bool              `json:"live_delivery"`
+	Thread               *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// DeliveryRequestV2 a request to have waiting messages delivered (Pickup 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0685-pickup-v2#delivery-request
+type DeliveryRequestV2 struct {
+	Type         string            `json:"@type,omitempty"`
+	ID           string            `json:"@id,omitempty"`
+	Limit        int               `json:"limit"`
+	RecipientKey string            `json:"recipient_key,omitempty"`
+	Thread       *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// DeliveryV2 a message that contains waiting messages as attachments (Pickup 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0685-pickup-v2#message-delivery
+type DeliveryV2 struct {
+	Type         string                 `json:"@type,omitempty"`
+	ID           string                 `json:"@id,omitempty"`
+	RecipientKey string                 `json:"recipient_key,omitempty"`
+	Attachments  []decorator.Attachment `json:"~attach"`
+	Thread       *decorator.Thread      `json:"~thread,omitempty"`
+}
+
+// MessagesReceivedV2 acknowledges delivered messages, so that the mediator can delete them (Pickup 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0685-pickup-v2#messages-received
+type MessagesReceivedV2 struct {
+	Type          string            `json:"@type,omitempty"`
+	ID            string            `json:"@id,omitempty"`
+	MessageIDList []string          `json:"message_id_list"`
+	Thread        *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// LiveDeliveryChangeV2 enables or disables live delivery of messages (Pickup 2.0).
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0685-pickup-v2#live-mode
+type LiveDeliveryChangeV2 struct {
+	Type         string            `json:"@type,omitempty"`
+	ID           string            `json:"@id,omitempty"`
+	LiveDelivery bool              `json:"live_delivery"`
+	Thread       *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// StatusRequestV3 sent by the recipient to the mediator to request a status message (Pickup 3.0).
+// https://didcomm.org/pickup/3.0/
+type StatusRequestV3 struct {
+	ID   string               `json:"id,omitempty"`
+	Type string               `json:"type,omitempty"`
+	Body *StatusRequestBodyV3 `json:"body"`
+}
+
+// StatusRequestBodyV3 is the body of Pickup 3.0 status-request message.
+type StatusRequestBodyV3 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
+
+// StatusV3 details about pending messages (Pickup 3.0).
+type StatusV3 struct {
+	ID       string        `json:"id,omitempty"`
+	Type     string        `json:"type,omitempty"`
+	ThreadID string        `json:"thid,omitempty"`
+	Body     *StatusBodyV3 `json:"body"`
+}
+
+// StatusBodyV3 is the body of Pickup 3.0 status message, times are in unix epoch seconds.
+type StatusBodyV3 struct {
+	RecipientDID         string `json:"recipient_did,omitempty"`
+	MessageCount         int    `json:"message_count"`
+	LongestWaitedSeconds int    `json:"longest_waited_seconds,omitempty"`
+	NewestReceivedTime   int64  `json:"newest_received_time,omitempty"`
+	OldestReceivedTime   int64  `json:"oldest_received_time,omitempty"`
+	TotalBytes           int    `json:"total_bytes,omitempty"`
+	LiveDelivery         bool   `json:"live_delivery"`
+}
+
+// DeliveryRequestV3 a request to have waiting messages delivered (Pickup 3.0).
+type DeliveryRequestV3 struct {
+	ID   string                 `json:"id,omitempty"`
+	Type string                 `json:"type,omitempty"`
+	Body *DeliveryRequestBodyV3 `json:"body"`
+}
+
+// DeliveryRequestBodyV3 is the body of Pickup 3.0 delivery-request message.
+type DeliveryRequestBodyV3 struct {
+	Limit        int    `json:"limit"`
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
+
+// DeliveryV3 a message that contains waiting messages as attachments (Pickup 3.0).
+type DeliveryV3 struct {
+	ID          string                   `json:"id,omitempty"`
+	Type        string                   `json:"type,omitempty"`
+	ThreadID    string                   `json:"thid,omitempty"`
+	Body        *DeliveryBodyV3          `json:"body"`
+	Attachments []decorator.AttachmentV2 `json:"attachments"`
+}
+
+// DeliveryBodyV3 is the body of Pickup 3.0 delivery message.
+type DeliveryBodyV3 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
+
+// MessagesReceivedV3 acknowledges delivered messages, so that the mediator can delete them (Pickup 3.0).
+type MessagesReceivedV3 struct {
+	ID       string                  `json:"id,omitempty"`
+	Type     string                  `json:"type,omitempty"`
+	ThreadID string                  `json:"thid,omitempty"`
+	Body     *MessagesReceivedBodyV3 `json:"body"`
+}
+
+// MessagesReceivedBodyV3 is the body of Pickup 3.0 messages-received message.
+type MessagesReceivedBodyV3 struct {
+	MessageIDList []string `json:"message_id_list"`
+}
+
+// LiveDeliveryChangeV3 enables or disables live delivery of messages (Pickup 3.0).
+type LiveDeliveryChangeV3 struct {
+	ID   string                    `json:"id,omitempty"`
+	Type string                    `json:"type,omitempty"`
+	Body *LiveDeliveryChangeBodyV3 `json:"body"`
+}
+
+// LiveDeliveryChangeBodyV3 is the body of Pickup 3.0 live-delivery-change message.
+type LiveDeliveryChangeBodyV3 struct {
+	LiveDelivery bool `json:"live_delivery"`
+}
+
+// PickupStatus is the status of the messages waiting at the mediator, independent of the protocol version.
+type PickupStatus struct {
+	// RecipientKey is the recipient key (Pickup 2.0) or DID (Pickup 3.0) the status is filtered for.
+	RecipientKey         string    `json:"recipient_key,omitempty"`
+	MessageCount         int       `json:"message_count"`
+	LongestWaitedSeconds int       `json:"longest_waited_seconds,omitempty"`
+	NewestReceivedTime   time.Time `json:"newest_received_time,omitempty"`
+	OldestReceivedTime   time.Time `json:"oldest_received_time,omitempty"`
+	TotalBytes           int       `json:"total_bytes,omitempty"`
+	LiveDelivery         bool      `json:"live_delivery"`
+}
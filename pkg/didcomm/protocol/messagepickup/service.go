@@ -41,9 +41,45 @@ const (
 	NoopMsgType = Spec + "noop"
 )
 
+const (
+	// SpecV2 defines the Pickup 2.0 protocol spec (DIDComm V1).
+	SpecV2 = "https://didcomm.org/messagepickup/2.0/"
+	// StatusRequestMsgTypeV2 defines the Pickup 2.0 status-request message type.
+	StatusRequestMsgTypeV2 = SpecV2 + "status-request"
+	// StatusMsgTypeV2 defines the Pickup 2.0 status message type.
+	StatusMsgTypeV2 = SpecV2 + "status"
+	// DeliveryRequestMsgTypeV2 defines the Pickup 2.0 delivery-request message type.
+	DeliveryRequestMsgTypeV2 = SpecV2 + "delivery-request"
+	// DeliveryMsgTypeV2 defines the Pickup 2.0 delivery message type.
+	DeliveryMsgTypeV2 = SpecV2 + "delivery"
+	// MessagesReceivedMsgTypeV2 defines the Pickup 2.0 messages-received message type.
+	MessagesReceivedMsgTypeV2 = SpecV2 + "messages-received"
+	// LiveDeliveryChangeMsgTypeV2 defines the Pickup 2.0 live-delivery-change message type.
+	LiveDeliveryChangeMsgTypeV2 = SpecV2 + "live-delivery-change"
+
+	// SpecV3 defines the Pickup 3.0 protocol spec (DIDComm V2).
+	SpecV3 = "https://didcomm.org/messagepickup/3.0/"
+	// StatusRequestMsgTypeV3 defines the Pickup 3.0 status-request message type.
+	StatusRequestMsgTypeV3 = SpecV3 + "status-request"
+	// StatusMsgTypeV3 defines the Pickup 3.0 status message type.
+	StatusMsgTypeV3 = SpecV3 + "status"
+	// DeliveryRequestMsgTypeV3 defines the Pickup 3.0 delivery-request message type.
+	DeliveryRequestMsgTypeV3 = SpecV3 + "delivery-request"
+	// DeliveryMsgTypeV3 defines the Pickup 3.0 delivery message type.
+	DeliveryMsgTypeV3 = SpecV3 + "delivery"
+	// MessagesReceivedMsgTypeV3 defines the Pickup 3.0 messages-received message type.
+	MessagesReceivedMsgTypeV3 = SpecV3 + "messages-received"
+	// LiveDeliveryChangeMsgTypeV3 defines the Pickup 3.0 live-delivery-change message type.
+	LiveDeliveryChangeMsgTypeV3 = SpecV3 + "live-delivery-change"
+)
+
 const (
 	updateTimeout = 50 * time.Second
 
+	// inboxTTL is how long an inbox is kept after its last update, so that the messages of recipients who never pick
+	// them up don't pile up forever.
+	inboxTTL = 30 * 24 * time.Hour
+
 	// Namespace is namespace of messagepickup store name.
 	Namespace = "mailbox"
 )
@@ -79,6 +115,8 @@ type Service struct {
 	batchMapLock     sync.RWMutex
 	statusMap        map[string]chan Status
 	statusMapLock    sync.RWMutex
+	pickupMap        map[string]chan service.DIDCommMsg
+	pickupMapLock    sync.RWMutex
 	inboxLock        sync.Mutex
 	initialized      bool
 }
@@ -123,6 +161,7 @@ func (s *Service) Initialize(p interface{}) error {
 	s.msgHandler = prov.InboundMessageHandler()
 	s.batchMap = make(map[string]chan Batch)
 	s.statusMap = make(map[string]chan Status)
+	s.pickupMap = make(map[string]chan service.DIDCommMsg)
 
 	s.initialized = true
 
@@ -146,6 +185,18 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 			err = s.handleBatch(msg)
 		case NoopMsgType:
 			err = s.handleNoop(msg)
+		case StatusRequestMsgTypeV2, StatusRequestMsgTypeV3:
+			err = s.handlePickupStatusRequest(msg, ctx.MyDID(), ctx.TheirDID())
+		case DeliveryRequestMsgTypeV2, DeliveryRequestMsgTypeV3:
+			err = s.handleDeliveryRequest(msg, ctx.MyDID(), ctx.TheirDID())
+		case MessagesReceivedMsgTypeV2, MessagesReceivedMsgTypeV3:
+			err = s.handleMessagesReceived(msg, ctx.MyDID(), ctx.TheirDID())
+		case LiveDeliveryChangeMsgTypeV2, LiveDeliveryChangeMsgTypeV3:
+			err = s.handleLiveDeliveryChange(msg, ctx.MyDID(), ctx.TheirDID())
+		case StatusMsgTypeV2, StatusMsgTypeV3:
+			err = s.handlePickupStatus(msg)
+		case DeliveryMsgTypeV2, DeliveryMsgTypeV3:
+			err = s.handleDelivery(msg, ctx.MyDID(), ctx.TheirDID())
 		}
 
 		if err != nil {
@@ -156,15 +207,27 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	return msg.ID(), nil
 }
 
-// HandleOutbound adherence to dispatcher.ProtocolService.
-func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
-	return "", errors.New("not implemented")
+// HandleOutbound sends the given message pickup message from myDID to theirDID.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if msg == nil || !s.Accept(msg.Type()) {
+		return "", errors.New("unsupported message type")
+	}
+
+	if err := s.outbound.SendToDID(msg, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("send %s: %w", msg.Type(), err)
+	}
+
+	return msg.ID(), nil
 }
 
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType:
+	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType,
+		StatusRequestMsgTypeV2, StatusMsgTypeV2, DeliveryRequestMsgTypeV2, DeliveryMsgTypeV2,
+		MessagesReceivedMsgTypeV2, LiveDeliveryChangeMsgTypeV2,
+		StatusRequestMsgTypeV3, StatusMsgTypeV3, DeliveryRequestMsgTypeV3, DeliveryMsgTypeV3,
+		MessagesReceivedMsgTypeV3, LiveDeliveryChangeMsgTypeV3:
 		return true
 	}
 
@@ -176,6 +239,11 @@ func (s *Service) Name() string {
 	return MessagePickup
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{Spec, SpecV2, SpecV3}
+}
+
 func (s *Service) handleStatus(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	statusMsg := &Status{}
@@ -342,6 +410,7 @@ type inbox struct {
 	LastRemovedTime   time.Time       `json:"last_removed_time,omitempty"`
 	TotalSize         int             `json:"total_size,omitempty"`
 	Messages          json.RawMessage `json:"messages"`
+	LiveDelivery      *liveDelivery   `json:"live_delivery,omitempty"`
 }
 
 // DecodeMessages Messages.
@@ -373,23 +442,44 @@ func (r *inbox) EncodeMessages(msg []*Message) error {
 
 // AddMessage add message to inbox.
 func (s *Service) AddMessage(message []byte, theirDID string) error {
+	return s.AddRecipientMessage(message, theirDID, "")
+}
+
+// AddRecipientMessage adds message for the given recipient key to the inbox of theirDID. The recipient key
+// allows to pick up messages of a particular key only (Pickup 2.0/3.0). If live delivery is enabled for the inbox,
+// the message is delivered right away, it stays in the inbox until the recipient acknowledges it.
+func (s *Service) AddRecipientMessage(message []byte, theirDID, recipientKey string) error {
+	m, live, err := s.addMessage(message, theirDID, recipientKey)
+	if err != nil {
+		return err
+	}
+
+	if live != nil {
+		s.deliverLive(live, theirDID, []*Message{m})
+	}
+
+	return nil
+}
+
+func (s *Service) addMessage(message []byte, theirDID, recipientKey string) (*Message, *liveDelivery, error) {
 	s.inboxLock.Lock()
 	defer s.inboxLock.Unlock()
 
 	outbox, err := s.createInbox(theirDID)
 	if err != nil {
-		return fmt.Errorf("unable to pull messages: %w", err)
+		return nil, nil, fmt.Errorf("unable to pull messages: %w", err)
 	}
 
 	msgs, err := outbox.DecodeMessages()
 	if err != nil {
-		return fmt.Errorf("unable to decode messages: %w", err)
+		return nil, nil, fmt.Errorf("unable to decode messages: %w", err)
 	}
 
 	m := Message{
 		ID:        uuid.New().String(),
 		AddedTime: time.Now(),
 		Message:   message,
+		Recipient: recipientKey,
 	}
 
 	msgs = append(msgs, &m)
@@ -399,15 +489,15 @@ func (s *Service) AddMessage(message []byte, theirDID string) error {
 
 	err = outbox.EncodeMessages(msgs)
 	if err != nil {
-		return fmt.Errorf("unable to encode messages: %w", err)
+		return nil, nil, fmt.Errorf("unable to encode messages: %w", err)
 	}
 
 	err = s.putInbox(theirDID, outbox)
 	if err != nil {
-		return fmt.Errorf("unable to put messages: %w", err)
+		return nil, nil, fmt.Errorf("unable to put messages: %w", err)
 	}
 
-	return nil
+	return &m, outbox.LiveDelivery, nil
 }
 
 func (s *Service) createInbox(theirDID string) (*inbox, error) {
@@ -420,7 +510,7 @@ func (s *Service) createInbox(theirDID string) (*inbox, error) {
 			return nil, e
 		}
 
-		e = s.msgStore.Put(theirDID, msgBytes)
+		e = s.saveInbox(theirDID, msgBytes)
 		if e != nil {
 			return nil, e
 		}
@@ -453,7 +543,16 @@ func (s *Service) putInbox(theirDID string, o *inbox) error {
 		return err
 	}
 
-	return s.msgStore.Put(theirDID, b)
+	return s.saveInbox(theirDID, b)
+}
+
+// saveInbox stores the inbox, which expires after inboxTTL unless it's updated again.
+func (s *Service) saveInbox(theirDID string, inboxBytes []byte) error {
+	return s.msgStore.Batch([]storage.Operation{{
+		Key:        theirDID,
+		Value:      inboxBytes,
+		PutOptions: &storage.PutOptions{ExpiresAt: time.Now().Add(inboxTTL)},
+	}})
 }
 
 // StatusRequest request a status message.
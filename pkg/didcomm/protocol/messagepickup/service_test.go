@@ -377,7 +377,7 @@ func TestHandleInbound(t *testing.T) {
 		err = mockStore.Store.Put(THEIRDID, b)
 		require.NoError(t, err)
 
-		mockStore.Store.ErrPut = errors.New("error put inbox")
+		mockStore.Store.ErrBatch = errors.New("error put inbox")
 
 		msg, err := service.ParseDIDCommMsgMap([]byte(`{
 			"@id": "123456781",
@@ -581,7 +581,7 @@ func TestAddMessage(t *testing.T) {
 		err = mockStore.Store.Put(THEIRDID, b)
 		require.NoError(t, err)
 
-		mockStore.Store.ErrPut = errors.New("error put")
+		mockStore.Store.ErrBatch = errors.New("error put")
 
 		message := []byte("")
 
@@ -875,19 +875,50 @@ func TestDecodeMessages(t *testing.T) {
 }
 
 func TestHandleOutbound(t *testing.T) {
-	t.Run("test MessagePickupService.HandleOutbound() - not implemented", func(t *testing.T) {
+	t.Run("test MessagePickupService.HandleOutbound() - success", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					require.Equal(t, MYDID, myDID)
+					require.Equal(t, THEIRDID, theirDID)
+
+					return nil
+				},
+			},
+			PackagerValue: &mockPackager{},
+		})
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&StatusRequestV2{ID: "123", Type: StatusRequestMsgTypeV2})
+
+		id, err := svc.HandleOutbound(msg, MYDID, THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, "123", id)
+	})
+
+	t.Run("test MessagePickupService.HandleOutbound() - unsupported message type", func(t *testing.T) {
 		svc, err := getService()
 		require.NoError(t, err)
 
-		svc.connectionLookup = &connectionsStub{
-			getConnRecord: func(string) (*connection.Record, error) {
-				return nil, storage.ErrDataNotFound
-			},
-		}
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Noop{ID: "123", Type: "unknown"}), MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported message type")
+	})
+
+	t.Run("test MessagePickupService.HandleOutbound() - send error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{SendErr: errors.New("send error")},
+			PackagerValue:                     &mockPackager{},
+		})
+		require.NoError(t, err)
 
-		_, err = svc.HandleOutbound(nil, "not", "implemented")
+		_, err = svc.HandleOutbound(service.NewDIDCommMsgMap(&Noop{ID: "123", Type: NoopMsgType}), MYDID, THEIRDID)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "not implemented")
+		require.Contains(t, err.Error(), "send error")
 	})
 }
 
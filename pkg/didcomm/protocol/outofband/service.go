@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
@@ -49,6 +50,9 @@ const (
 	callbackChannelSize = 10
 
 	contextKey = "context_%s"
+
+	// transientDataTTL is how long received invitations awaiting an action and attachment handling states are kept.
+	transientDataTTL = 7 * 24 * time.Hour
 )
 
 var logger = log.New(fmt.Sprintf("aries-framework/%s/service", Name))
@@ -226,6 +230,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
 // Accept determines whether this service can handle the given type of message.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
@@ -499,7 +508,17 @@ func (s *Service) saveContext(id string, data *context) error {
 		return fmt.Errorf("marshal transitional payload: %w", err)
 	}
 
-	return s.transientStore.Put(fmt.Sprintf(contextKey, id), src, storage.Tag{Name: contextKey})
+	return s.putTransient(fmt.Sprintf(contextKey, id), src, storage.Tag{Name: contextKey})
+}
+
+// putTransient stores data in the transient store, which expires after transientDataTTL.
+func (s *Service) putTransient(key string, value []byte, tags ...storage.Tag) error {
+	return s.transientStore.Batch([]storage.Operation{{
+		Key:        key,
+		Value:      value,
+		Tags:       tags,
+		PutOptions: &storage.PutOptions{ExpiresAt: time.Now().Add(transientDataTTL)},
+	}})
 }
 
 func (s *Service) deleteContext(id string) error {
@@ -766,7 +785,7 @@ func (s *Service) save(state *attachmentHandlingState) error {
 		return fmt.Errorf("failed to save state=%+v : %w", state, err)
 	}
 
-	err = s.transientStore.Put(state.ID, bytes)
+	err = s.putTransient(state.ID, bytes)
 	if err != nil {
 		return fmt.Errorf("failed to save state : %w", err)
 	}
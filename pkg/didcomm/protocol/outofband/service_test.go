@@ -219,8 +219,8 @@ func TestHandleInbound(t *testing.T) {
 		expected := service.NewDIDCommMsgMap(newInvitation())
 		s := &Service{
 			transientStore: &mockstore.MockStore{
-				Store:  make(map[string]mockstore.DBEntry),
-				ErrPut: fmt.Errorf("db error"),
+				Store:    make(map[string]mockstore.DBEntry),
+				ErrBatch: fmt.Errorf("db error"),
 			},
 		}
 		events := make(chan service.DIDCommAction)
@@ -546,7 +546,7 @@ func TestHandleRequestCallback(t *testing.T) {
 		provider := testProvider()
 		provider.ProtocolStateStoreProvider = &mockstore.MockStoreProvider{
 			Store: &mockstore.MockStore{
-				ErrPut: expected,
+				ErrBatch: expected,
 			},
 		}
 		s := newAutoService(t, provider)
@@ -741,8 +741,8 @@ func TestHandleDIDEvent(t *testing.T) {
 			))
 
 		s.transientStore = &mockstore.MockStore{
-			Store:  protocolStateStoreProvider.Store.Store,
-			ErrPut: expected,
+			Store:    protocolStateStoreProvider.Store.Store,
+			ErrBatch: expected,
 		}
 
 		err = s.handleDIDEvent(service.StateMsg{
@@ -180,6 +180,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{PIURI}
+}
+
 // Accept determines whether this service can handle the given type of message.
 func (s *Service) Accept(msgType string) bool {
 	return msgType == InvitationMsgType
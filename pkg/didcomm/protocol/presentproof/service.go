@@ -15,6 +15,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
@@ -311,6 +312,7 @@ type Service struct {
 	callbacks   chan *metaData
 	messenger   service.Messenger
 	middleware  Handler
+	metrics     metrics.Metrics
 	initialized bool
 }
 
@@ -348,6 +350,7 @@ func (s *Service) Initialize(prov interface{}) error {
 	}
 
 	s.messenger = p.Messenger()
+	s.metrics = metrics.FromProvider(prov)
 	s.store = store
 	s.callbacks = make(chan *metaData)
 	s.middleware = initialHandler
@@ -484,6 +487,8 @@ func (s *Service) buildMetaData(msg service.DIDCommMsgMap, direction messageDire
 		return nil, fmt.Errorf("invalid state transition: %s -> %s", current.Name(), next.Name())
 	}
 
+	s.metrics.ProtocolStateTransition(Name, current.Name(), next.Name())
+
 	return &metaData{
 		transitionalPayload: transitionalPayload{
 			StateName:   next.Name(),
@@ -539,8 +544,12 @@ func (s *Service) handle(md *metaData) error {
 			return fmt.Errorf("execute: %w", err)
 		}
 
-		if !isNoOp(next) && !current.CanTransitionTo(next) {
-			return fmt.Errorf("invalid state transition: %s --> %s", current.Name(), next.Name())
+		if !isNoOp(next) {
+			if !current.CanTransitionTo(next) {
+				return fmt.Errorf("invalid state transition: %s --> %s", current.Name(), next.Name())
+			}
+
+			s.metrics.ProtocolStateTransition(Name, current.Name(), next.Name())
 		}
 
 		// WARN: md.ackRequired is being modified by requestSent state
@@ -900,6 +909,11 @@ func (s *Service) Name() string {
 	return Name
 }
 
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{SpecV2, SpecV3}
+}
+
 // Accept msg checks the msg type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
@@ -0,0 +1,51 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Ping is the DIDComm V1 trust ping message.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0048-trust-ping#messages
+type Ping struct {
+	Type              string            `json:"@type,omitempty"`
+	ID                string            `json:"@id,omitempty"`
+	Comment           string            `json:"comment,omitempty"`
+	ResponseRequested *bool             `json:"response_requested,omitempty"`
+	Thread            *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// PingResponse is the DIDComm V1 trust ping response message.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0048-trust-ping#messages
+type PingResponse struct {
+	Type    string            `json:"@type,omitempty"`
+	ID      string            `json:"@id,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+	Thread  *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// PingV2 is the DIDComm V2 trust ping message.
+// https://identity.foundation/didcomm-messaging/spec/#trust-ping-protocol-20
+type PingV2 struct {
+	ID   string      `json:"id,omitempty"`
+	Type string      `json:"type,omitempty"`
+	From string      `json:"from,omitempty"`
+	Body *PingBodyV2 `json:"body"`
+}
+
+// PingBodyV2 is the body of DIDComm V2 trust ping message.
+type PingBodyV2 struct {
+	ResponseRequested *bool `json:"response_requested,omitempty"`
+}
+
+// PingResponseV2 is the DIDComm V2 trust ping response message.
+// https://identity.foundation/didcomm-messaging/spec/#trust-ping-protocol-20
+type PingResponseV2 struct {
+	ID       string                 `json:"id,omitempty"`
+	Type     string                 `json:"type,omitempty"`
+	ThreadID string                 `json:"thid,omitempty"`
+	Body     map[string]interface{} `json:"body"`
+}
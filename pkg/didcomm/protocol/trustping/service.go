@@ -0,0 +1,345 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// TrustPing defines the protocol name.
+	TrustPing = "trustping"
+	// SpecV1 defines the DIDComm V1 protocol spec.
+	SpecV1 = "https://didcomm.org/trust_ping/1.0/"
+	// PingMsgTypeV1 defines the DIDComm V1 ping message type.
+	PingMsgTypeV1 = SpecV1 + "ping"
+	// PingResponseMsgTypeV1 defines the DIDComm V1 ping response message type.
+	PingResponseMsgTypeV1 = SpecV1 + "ping_response"
+	// SpecV2 defines the DIDComm V2 protocol spec.
+	SpecV2 = "https://didcomm.org/trust-ping/2.0/"
+	// PingMsgTypeV2 defines the DIDComm V2 ping message type.
+	PingMsgTypeV2 = SpecV2 + "ping"
+	// PingResponseMsgTypeV2 defines the DIDComm V2 ping response message type.
+	PingResponseMsgTypeV2 = SpecV2 + "ping-response"
+)
+
+const pingTimeout = 30 * time.Second
+
+// ErrConnectionNotFound connection not found error.
+var (
+	ErrConnectionNotFound = errors.New("connection not found")
+	logger                = log.New("aries-framework/trustping")
+)
+
+type provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// PingOption configures the ping.
+type PingOption func(opts *PingOptions)
+
+// PingOptions holds options for the ping.
+type PingOptions struct {
+	// Timeout for waiting for the ping response.
+	Timeout time.Duration
+	// Comment to be sent along with the ping, only supported by DIDComm V1.
+	Comment string
+}
+
+// Service for the trust ping protocol.
+type Service struct {
+	service.Action
+	service.Message
+	messenger        service.Messenger
+	connectionLookup connections
+	responseMap      map[string]chan struct{}
+	responseMapLock  sync.RWMutex
+	initialized      bool
+}
+
+// New returns the trust ping service.
+func New(prov provider) (*Service, error) {
+	svc := Service{}
+
+	err := svc.Initialize(prov)
+	if err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+// Initialize initializes the Service. If Initialize succeeds, any further call is a no-op.
+func (s *Service) Initialize(p interface{}) error {
+	if s.initialized {
+		return nil
+	}
+
+	prov, ok := p.(provider)
+	if !ok {
+		return fmt.Errorf("expected provider of type `%T`, got type `%T`", provider(nil), p)
+	}
+
+	connectionLookup, err := connection.NewLookup(prov)
+	if err != nil {
+		return err
+	}
+
+	s.messenger = prov.Messenger()
+	s.connectionLookup = connectionLookup
+	s.responseMap = make(map[string]chan struct{})
+
+	s.initialized = true
+
+	return nil
+}
+
+// HandleInbound handles inbound trust ping messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	var err error
+
+	switch msg.Type() {
+	case PingMsgTypeV1:
+		err = s.handlePing(msg, ctx.MyDID(), ctx.TheirDID())
+	case PingMsgTypeV2:
+		err = s.handlePingV2(msg, ctx.MyDID(), ctx.TheirDID())
+	case PingResponseMsgTypeV1, PingResponseMsgTypeV2:
+		err = s.handlePingResponse(msg)
+	default:
+		err = fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return msg.ID(), nil
+}
+
+// HandleOutbound adherence to dispatcher.ProtocolService.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+// Accept checks whether the service can handle the message type.
+func (s *Service) Accept(msgType string) bool {
+	switch msgType {
+	case PingMsgTypeV1, PingResponseMsgTypeV1, PingMsgTypeV2, PingResponseMsgTypeV2:
+		return true
+	}
+
+	return false
+}
+
+// Name of the service.
+func (s *Service) Name() string {
+	return TrustPing
+}
+
+// Protocols returns the protocols supported by the service.
+func (s *Service) Protocols() []string {
+	return []string{SpecV1, SpecV2}
+}
+
+// Ping sends a trust ping to the other party of the given connection and waits for the response.
+// Returns round-trip time between sending the ping and receiving its response.
+func (s *Service) Ping(connectionID string, options ...PingOption) (time.Duration, error) {
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := parsePingOpts(options...)
+
+	// generate message ID, it will also be the thread ID of the response.
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	responseCh := make(chan struct{}, 1)
+	s.setResponseCh(msgID, responseCh)
+
+	defer s.setResponseCh(msgID, nil)
+
+	responseRequested := true
+
+	var (
+		msg     service.DIDCommMsgMap
+		version = service.V1
+	)
+
+	if conn.DIDCommVersion == service.V2 {
+		version = service.V2
+		msg = service.NewDIDCommMsgMap(&PingV2{
+			ID:   msgID,
+			Type: PingMsgTypeV2,
+			From: conn.MyDID,
+			Body: &PingBodyV2{ResponseRequested: &responseRequested},
+		})
+	} else {
+		msg = service.NewDIDCommMsgMap(&Ping{
+			Type:              PingMsgTypeV1,
+			ID:                msgID,
+			Comment:           opts.Comment,
+			ResponseRequested: &responseRequested,
+		})
+	}
+
+	start := time.Now()
+
+	if err = s.messenger.Send(msg, conn.MyDID, conn.TheirDID, service.WithVersion(version)); err != nil {
+		return 0, fmt.Errorf("send ping: %w", err)
+	}
+
+	// callback processing (to make this function look like a sync function)
+	select {
+	case <-responseCh:
+		return time.Since(start), nil
+	case <-time.After(opts.Timeout):
+		return 0, errors.New("timeout waiting for ping response")
+	}
+}
+
+func (s *Service) handlePing(msg service.DIDCommMsg, myDID, theirDID string) error {
+	ping := &Ping{}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return fmt.Errorf("ping message unmarshal: %w", err)
+	}
+
+	if !isResponseRequested(ping.ResponseRequested) {
+		logger.Debugf("ping %s received, response not requested", msg.ID())
+
+		return nil
+	}
+
+	resp := service.NewDIDCommMsgMap(&PingResponse{
+		Type: PingResponseMsgTypeV1,
+		ID:   uuid.New().String(),
+	})
+
+	err = s.messenger.ReplyToMsg(msg.Clone(), resp, myDID, theirDID, service.WithVersion(service.V1))
+	if err != nil {
+		return fmt.Errorf("send ping response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handlePingV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	ping := &PingV2{}
+
+	err := msg.Decode(ping)
+	if err != nil {
+		return fmt.Errorf("ping message unmarshal: %w", err)
+	}
+
+	if ping.Body != nil && !isResponseRequested(ping.Body.ResponseRequested) {
+		logger.Debugf("ping %s received, response not requested", msg.ID())
+
+		return nil
+	}
+
+	resp := service.NewDIDCommMsgMap(&PingResponseV2{
+		ID:   uuid.New().String(),
+		Type: PingResponseMsgTypeV2,
+		Body: map[string]interface{}{},
+	})
+
+	err = s.messenger.ReplyToMsg(msg.Clone(), resp, myDID, theirDID, service.WithVersion(service.V2))
+	if err != nil {
+		return fmt.Errorf("send ping response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handlePingResponse(msg service.DIDCommMsg) error {
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("ping response thread ID: %w", err)
+	}
+
+	// check if there are any channels registered for the ping ID
+	responseCh := s.getResponseCh(thID)
+	if responseCh == nil {
+		logger.Debugf("no pending ping found for ping response with thread ID %s", thID)
+
+		return nil
+	}
+
+	select {
+	case responseCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (s *Service) getConnection(connectionID string) (*connection.Record, error) {
+	conn, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDataNotFound) {
+			return nil, ErrConnectionNotFound
+		}
+
+		return nil, fmt.Errorf("fetch connection record from store : %w", err)
+	}
+
+	return conn, nil
+}
+
+func (s *Service) setResponseCh(msgID string, responseCh chan struct{}) {
+	s.responseMapLock.Lock()
+	defer s.responseMapLock.Unlock()
+
+	if responseCh == nil {
+		delete(s.responseMap, msgID)
+	} else {
+		s.responseMap[msgID] = responseCh
+	}
+}
+
+func (s *Service) getResponseCh(msgID string) chan struct{} {
+	s.responseMapLock.RLock()
+	defer s.responseMapLock.RUnlock()
+
+	return s.responseMap[msgID]
+}
+
+// isResponseRequested returns true if response is requested, response_requested defaults to true.
+func isResponseRequested(requested *bool) bool {
+	return requested == nil || *requested
+}
+
+func parsePingOpts(options ...PingOption) *PingOptions {
+	opts := &PingOptions{
+		Timeout: pingTimeout,
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
@@ -0,0 +1,326 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+const (
+	MYDID    = "sample-my-did"
+	THEIRDID = "sample-their-did"
+)
+
+func TestServiceNew(t *testing.T) {
+	t.Run("test new service - success", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+		require.Equal(t, TrustPing, svc.Name())
+	})
+
+	t.Run("test new service - connection lookup error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			StorageProviderValue: &mockstore.MockStoreProvider{
+				ErrOpenStoreHandle: errors.New("error opening the store"),
+			},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error opening the store")
+		require.Nil(t, svc)
+	})
+}
+
+func TestService_Initialize(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		prov := newProvider(nil)
+
+		svc := Service{}
+
+		err := svc.Initialize(prov)
+		require.NoError(t, err)
+
+		// second init is no-op
+		err = svc.Initialize(prov)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure, not given a valid provider", func(t *testing.T) {
+		svc := Service{}
+
+		err := svc.Initialize("not a provider")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expected provider of type")
+	})
+}
+
+func TestService_Accept(t *testing.T) {
+	svc, err := New(newProvider(nil))
+	require.NoError(t, err)
+
+	require.True(t, svc.Accept(PingMsgTypeV1))
+	require.True(t, svc.Accept(PingResponseMsgTypeV1))
+	require.True(t, svc.Accept(PingMsgTypeV2))
+	require.True(t, svc.Accept(PingResponseMsgTypeV2))
+	require.False(t, svc.Accept("unsupported msg type"))
+
+	_, err = svc.HandleOutbound(nil, MYDID, THEIRDID)
+	require.EqualError(t, err, "not implemented")
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("ping V1 - response requested", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, "ping-1", in.ID())
+				require.Equal(t, PingResponseMsgTypeV1, out.Type())
+
+				return nil
+			})
+
+		svc, err := New(newProvider(messenger))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Ping{ID: "ping-1", Type: PingMsgTypeV1})
+
+		id, err := svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+		require.Equal(t, "ping-1", id)
+	})
+
+	t.Run("ping V1 - response not requested", func(t *testing.T) {
+		svc, err := New(newProvider(serviceMocks.NewMockMessenger(ctrl)))
+		require.NoError(t, err)
+
+		responseRequested := false
+
+		msg := service.NewDIDCommMsgMap(&Ping{ID: "ping-1", Type: PingMsgTypeV1, ResponseRequested: &responseRequested})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("ping V1 - reply error", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			Return(errors.New("reply error"))
+
+		svc, err := New(newProvider(messenger))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Ping{ID: "ping-1", Type: PingMsgTypeV1})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send ping response: reply error")
+	})
+
+	t.Run("ping V2 - response requested", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, "ping-2", in.ID())
+				require.Equal(t, PingResponseMsgTypeV2, out.Type())
+
+				return nil
+			})
+
+		svc, err := New(newProvider(messenger))
+		require.NoError(t, err)
+
+		responseRequested := true
+
+		msg := service.NewDIDCommMsgMap(&PingV2{
+			ID:   "ping-2",
+			Type: PingMsgTypeV2,
+			Body: &PingBodyV2{ResponseRequested: &responseRequested},
+		})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("ping V2 - response not requested", func(t *testing.T) {
+		svc, err := New(newProvider(serviceMocks.NewMockMessenger(ctrl)))
+		require.NoError(t, err)
+
+		responseRequested := false
+
+		msg := service.NewDIDCommMsgMap(&PingV2{
+			ID:   "ping-2",
+			Type: PingMsgTypeV2,
+			Body: &PingBodyV2{ResponseRequested: &responseRequested},
+		})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("ping response without pending ping", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&PingResponseV2{ID: "response", Type: PingResponseMsgTypeV2, ThreadID: "ping"})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(&Ping{ID: "ping", Type: "unknown"})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported message type")
+	})
+}
+
+func TestService_Ping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("ping V1 connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, PingMsgTypeV1, msg.Type())
+
+				resp := service.NewDIDCommMsgMap(&PingResponse{ID: "response", Type: PingResponseMsgTypeV1})
+				resp.SetThread(msg.ID(), "")
+
+				go func() {
+					_, e := svc.HandleInbound(resp, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+					require.NoError(t, e)
+				}()
+
+				return nil
+			})
+
+		latency, err := svc.Ping("conn")
+		require.NoError(t, err)
+		require.True(t, latency > 0)
+	})
+
+	t.Run("ping V2 connection", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+			DIDCommVersion: service.V2,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).
+			DoAndReturn(func(msg service.DIDCommMsgMap, _, _ string, _ ...service.Opt) error {
+				require.Equal(t, PingMsgTypeV2, msg.Type())
+
+				resp := service.NewDIDCommMsgMap(&PingResponseV2{
+					ID: "response", Type: PingResponseMsgTypeV2, ThreadID: msg.ID(),
+				})
+
+				go func() {
+					_, e := svc.HandleInbound(resp, service.NewDIDCommContext(MYDID, THEIRDID, nil))
+					require.NoError(t, e)
+				}()
+
+				return nil
+			})
+
+		latency, err := svc.Ping("conn")
+		require.NoError(t, err)
+		require.True(t, latency > 0)
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		svc, err := New(newProvider(nil))
+		require.NoError(t, err)
+
+		_, err = svc.Ping("conn")
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).Return(errors.New("send error"))
+
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Ping("conn")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send ping: send error")
+	})
+
+	t.Run("timeout waiting for response", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), MYDID, THEIRDID, gomock.Any()).Return(nil)
+
+		prov := newProvider(messenger)
+		saveConnection(t, prov, &connection.Record{
+			ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: connection.StateNameCompleted,
+		})
+
+		svc, err := New(prov)
+		require.NoError(t, err)
+
+		_, err = svc.Ping("conn", func(opts *PingOptions) {
+			opts.Timeout = 10 * time.Millisecond
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "timeout waiting for ping response")
+	})
+}
+
+func newProvider(messenger service.Messenger) *mockprovider.Provider {
+	return &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		MessengerValue:                    messenger,
+	}
+}
+
+func saveConnection(t *testing.T, prov *mockprovider.Provider, record *connection.Record) {
+	t.Helper()
+
+	r, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SaveConnectionRecord(record))
+}
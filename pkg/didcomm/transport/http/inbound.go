@@ -16,6 +16,7 @@ import (
 	"github.com/rs/cors"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/internal"
 )
@@ -68,6 +69,8 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
+	metrics.FromProvider(prov).InboundMessage(internal.MessageTypeLabel(unpackMsg.Message, prov), "http")
+
 	messageHandler := prov.InboundMessageHandler()
 
 	err = messageHandler(unpackMsg)
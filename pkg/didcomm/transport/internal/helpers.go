@@ -9,14 +9,24 @@ package internal
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
 var logger = log.New("didcomm/transport/internal")
 
+// unknownMessageType is reported when the type of the DIDComm message can't be read.
+const unknownMessageType = "unknown"
+
+// servicesProvider is implemented by framework providers exposing the registered protocol services.
+type servicesProvider interface {
+	AllServices() []dispatcher.ProtocolService
+}
+
 // UnpackMessage using 'pack' with a 'source' of either 'ws' or 'http'.
 func UnpackMessage(message []byte, pack transport.Packager, source string) (*transport.Envelope, error) {
 	doubleQuote := []byte("\"")
@@ -53,3 +63,44 @@ func UnpackMessage(message []byte, pack transport.Packager, source string) (*tra
 
 	return unpackMsg, nil
 }
+
+// MessageType returns the type of the unpacked DIDComm V1 ('@type') or V2 ('type') message,
+// "unknown" is returned if the message has no type.
+func MessageType(message []byte) string {
+	msg := struct {
+		TypeV1 string `json:"@type"`
+		TypeV2 string `json:"type"`
+	}{}
+
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return unknownMessageType
+	}
+
+	switch {
+	case msg.TypeV1 != "":
+		return msg.TypeV1
+	case msg.TypeV2 != "":
+		return msg.TypeV2
+	default:
+		return unknownMessageType
+	}
+}
+
+// MessageTypeLabel returns the type of the unpacked DIDComm message to be used as a metrics label. The type is
+// controlled by the sender, so "unknown" is returned for types not accepted by any protocol service of the provider.
+func MessageTypeLabel(message []byte, prov interface{}) string {
+	msgType := MessageType(message)
+
+	sp, ok := prov.(servicesProvider)
+	if !ok {
+		return unknownMessageType
+	}
+
+	for _, svc := range sp.AllServices() {
+		if svc.Accept(msgType) {
+			return msgType
+		}
+	}
+
+	return unknownMessageType
+}
@@ -17,6 +17,8 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+	mocktrustping "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/trustping"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 )
 
 func TestUnpackMessage(t *testing.T) {
@@ -92,3 +94,29 @@ func TestUnpackMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageType(t *testing.T) {
+	require.Equal(t, "https://didcomm.org/trust_ping/1.0/ping",
+		MessageType([]byte(`{"@type":"https://didcomm.org/trust_ping/1.0/ping"}`)))
+	require.Equal(t, "https://didcomm.org/trust-ping/2.0/ping",
+		MessageType([]byte(`{"type":"https://didcomm.org/trust-ping/2.0/ping"}`)))
+	require.Equal(t, "unknown", MessageType([]byte(`{"id":"123"}`)))
+	require.Equal(t, "unknown", MessageType([]byte("not json")))
+}
+
+func TestMessageTypeLabel(t *testing.T) {
+	prov := &mockprovider.Provider{
+		ServiceValue: &mocktrustping.MockTrustPingSvc{
+			AcceptFunc: func(msgType string) bool {
+				return msgType == "https://didcomm.org/trust_ping/1.0/ping"
+			},
+		},
+	}
+
+	require.Equal(t, "https://didcomm.org/trust_ping/1.0/ping",
+		MessageTypeLabel([]byte(`{"@type":"https://didcomm.org/trust_ping/1.0/ping"}`), prov))
+	require.Equal(t, "unknown", MessageTypeLabel([]byte(`{"@type":"https://example.com/random/1.0/123"}`), prov))
+	require.Equal(t, "unknown", MessageTypeLabel([]byte(`{"id":"123"}`), prov))
+	require.Equal(t, "unknown",
+		MessageTypeLabel([]byte(`{"@type":"https://didcomm.org/trust_ping/1.0/ping"}`), struct{}{}))
+}
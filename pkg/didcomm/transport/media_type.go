@@ -24,6 +24,11 @@ const (
 	MediaTypeV2EncryptedEnvelopeV1PlaintextPayload = MediaTypeV2EncryptedEnvelope + ";cty=" + MediaTypeV1PlaintextPayload
 	// MediaTypeV2PlaintextPayload is the media type for DIDComm V1 JWE payloads as per Aries 044.
 	MediaTypeV2PlaintextPayload = "application/didcomm-plain+json"
+	// MediaTypeV2SignedEnvelope is the media type for DIDComm V2 signed envelopes (JWS) as per the DIF DIDComm spec.
+	MediaTypeV2SignedEnvelope = "application/didcomm-signed+json"
+	// MediaTypeV2EncryptedEnvelopeV2SignedPayload is the media type for DIDComm V2 encrypted envelopes of a signed
+	// envelope (sign-then-encrypt) as per the DIF DIDComm spec.
+	MediaTypeV2EncryptedEnvelopeV2SignedPayload = MediaTypeV2EncryptedEnvelope + ";cty=" + MediaTypeV2SignedEnvelope
 
 	// below are pre-defined profiles supported by the framework as per
 	// https://github.com/hyperledger/aries-rfcs/tree/master/features/0044-didcomm-file-and-mime-types#defined-profiles.
@@ -57,7 +62,8 @@ func MediaTypeProfiles() []string {
 
 // IsDIDCommV2 returns true iff mtp is one of:
 // MediaTypeV2EncryptedEnvelope, MediaTypeV2EncryptedEnvelopeV1PlaintextPayload, MediaTypeAIP2RFC0587Profile,
-// MediaTypeDIDCommV2Profile, or MediaTypeV2PlaintextPayload.
+// MediaTypeDIDCommV2Profile, MediaTypeV2PlaintextPayload, MediaTypeV2SignedEnvelope or
+// MediaTypeV2EncryptedEnvelopeV2SignedPayload.
 func IsDIDCommV2(mtp string) bool {
 	v2MTPs := map[string]struct{}{
 		MediaTypeV2EncryptedEnvelope:                   {},
@@ -65,6 +71,8 @@ func IsDIDCommV2(mtp string) bool {
 		MediaTypeAIP2RFC0587Profile:                    {},
 		MediaTypeDIDCommV2Profile:                      {},
 		MediaTypeV2PlaintextPayload:                    {},
+		MediaTypeV2SignedEnvelope:                      {},
+		MediaTypeV2EncryptedEnvelopeV2SignedPayload:    {},
 	}
 
 	_, ok := v2MTPs[mtp]
@@ -15,6 +15,7 @@ import (
 
 	"nhooyr.io/websocket"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
@@ -38,6 +39,8 @@ type connPool struct {
 	sync.RWMutex
 	packager   transport.Packager
 	msgHandler transport.InboundMessageHandler
+	metrics    metrics.Metrics
+	prov       transport.Provider
 }
 
 // nolint: gochecknoglobals
@@ -51,6 +54,8 @@ func getConnPool(prov transport.Provider) *connPool {
 			connMap:    make(map[string]*websocket.Conn),
 			packager:   prov.Packager(),
 			msgHandler: prov.InboundMessageHandler(),
+			metrics:    metrics.FromProvider(prov),
+			prov:       prov,
 		}
 	}
 
@@ -102,6 +107,8 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 			continue
 		}
 
+		d.metrics.InboundMessage(internal.MessageTypeLabel(unpackMsg.Message, d.prov), "ws")
+
 		trans := &decorator.Transport{}
 
 		err = json.Unmarshal(unpackMsg.Message, trans)
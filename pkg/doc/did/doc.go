@@ -331,7 +331,7 @@ func NewVerificationMethodFromBytes(id, keyType, controller string, value []byte
 		relativeURL = true
 	}
 
-	if keyType == "Ed25519VerificationKey2020" {
+	if keyType == "Ed25519VerificationKey2020" || keyType == "Multikey" {
 		return NewVerificationMethodFromBytesWithMultibase(id, keyType, controller, value, multibase.Base58BTC)
 	}
 
@@ -1473,7 +1473,7 @@ func populateRawVerificationMethod(context, didID, baseURI string,
 		}
 
 		rawVM[jsonldPublicKeyjwk] = json.RawMessage(jwkBytes)
-	} else if vm.Type == "Ed25519VerificationKey2020" {
+	} else if vm.Type == "Ed25519VerificationKey2020" || vm.Type == "Multikey" {
 		var err error
 
 		rawVM[jsonldPublicKeyMultibase], err = multibase.Encode(vm.multibaseEncoding, vm.Value)
@@ -1323,6 +1323,23 @@ func TestNewPublicKeyFromJWK(t *testing.T) {
 	require.Nil(t, signingKey)
 }
 
+func TestMultikeyVerificationMethod(t *testing.T) {
+	// multicodec encoded Ed25519 public key.
+	const multikey = "z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+	vm := NewVerificationMethodFromBytes(creator, "Multikey", did, base58.Decode(multikey[1:]))
+	doc := &Doc{Context: []string{ContextV1}, ID: did, VerificationMethod: []VerificationMethod{*vm}}
+
+	docBytes, err := doc.JSONBytes()
+	require.NoError(t, err)
+	require.Contains(t, string(docBytes), `"publicKeyMultibase":"`+multikey+`"`)
+
+	parsed, err := ParseDocument(docBytes)
+	require.NoError(t, err)
+	require.Equal(t, "Multikey", parsed.VerificationMethod[0].Type)
+	require.Equal(t, vm.Value, parsed.VerificationMethod[0].Value)
+}
+
 func TestJSONWebKey(t *testing.T) {
 	const didContext = "https://w3id.org/did/v1"
 
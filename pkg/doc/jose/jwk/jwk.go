@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package jwk
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rsa"
@@ -22,6 +23,7 @@ import (
 	"github.com/square/go-jose/v3"
 	"golang.org/x/crypto/ed25519"
 
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -30,6 +32,7 @@ import (
 const (
 	secp256k1Alg   = "ES256K"
 	secp256k1Crv   = "secp256k1"
+	p256KCrv       = "P-256K"
 	secp256k1Size  = 32
 	bitsPerByte    = 8
 	ecKty          = "EC"
@@ -39,6 +42,7 @@ const (
 	bls12381G2Crv  = "BLS12381_G2"
 	bls12381G2Size = 96
 	blsComprPrivSz = 32
+	rs256Alg       = "RS256"
 )
 
 // JWK (JSON Web Key) is a JSON data structure that represents a cryptographic key.
@@ -63,12 +67,14 @@ func (j *JWK) PublicKeyBytes() ([]byte, error) { //nolint:gocyclo
 	}
 
 	if j.isX25519() {
-		x25519Key, ok := j.Key.([]byte)
-		if !ok {
+		switch x25519Key := j.Key.(type) {
+		case []byte:
+			return x25519Key, nil
+		case *cryptoapi.PrivateKey:
+			return x25519Key.PublicKey.X, nil
+		default:
 			return nil, fmt.Errorf("invalid public key in kid '%s'", j.KeyID)
 		}
-
-		return x25519Key, nil
 	}
 
 	if j.isSecp256k1() {
@@ -177,6 +183,10 @@ func (j *JWK) KeyType() (kms.KeyType, error) {
 	case *ecdsa.PrivateKey:
 		return ecdsaPubKeyType(&(key.PublicKey))
 	case *rsa.PublicKey, *rsa.PrivateKey:
+		if j.Algorithm == rs256Alg {
+			return kms.RSARS256Type, nil
+		}
+
 		return kms.RSAPS256Type, nil
 	}
 
@@ -192,6 +202,82 @@ func (j *JWK) KeyType() (kms.KeyType, error) {
 	}
 }
 
+// Thumbprint computes the JWK Thumbprint of the key as per RFC 7638 using hash. It is computed manually for the key
+// types not supported by go-jose (secp256k1, X25519 and BLS12381G2) and for Ed25519 as go-jose's input is not valid
+// JSON for this curve.
+func (j *JWK) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	input, err := j.thumbprintInput()
+	if err != nil {
+		return nil, fmt.Errorf("thumbprint: %w", err)
+	}
+
+	if input == "" {
+		return j.JSONWebKey.Thumbprint(hash)
+	}
+
+	h := hash.New()
+	_, _ = h.Write([]byte(input)) // hash Write() never returns an error
+
+	return h.Sum(nil), nil
+}
+
+// thumbprintInput returns the RFC 7638 thumbprint input of keys not supported by go-jose's Thumbprint(), or an empty
+// string for all other keys.
+func (j *JWK) thumbprintInput() (string, error) {
+	const (
+		ecThumbprintTemplate  = `{"crv":"%s","kty":"EC","x":"%s","y":"%s"}`
+		crvThumbprintTemplate = `{"crv":"%s","kty":"%s","x":"%s"}`
+	)
+
+	switch key := j.Key.(type) {
+	case ed25519.PublicKey, ed25519.PrivateKey:
+		pubKey, err := j.PublicKeyBytes()
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(crvThumbprintTemplate, ed25519Crv, okpKty,
+			newFixedSizeBuffer(pubKey, ed25519.PublicKeySize).base64()), nil
+	case *bbs12381g2pub.PublicKey, *bbs12381g2pub.PrivateKey:
+		pubKey, err := j.PublicKeyBytes()
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(crvThumbprintTemplate, bls12381G2Crv, ecKty,
+			newFixedSizeBuffer(pubKey, bls12381G2Size).base64()), nil
+	case *ecdsa.PublicKey, *ecdsa.PrivateKey:
+		if !isSecp256k1Key(key) {
+			return "", nil
+		}
+
+		ecPubKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			ecPubKey = &key.(*ecdsa.PrivateKey).PublicKey
+		}
+
+		return fmt.Sprintf(ecThumbprintTemplate, secp256k1Crv,
+			newFixedSizeBuffer(ecPubKey.X.Bytes(), secp256k1Size).base64(),
+			newFixedSizeBuffer(ecPubKey.Y.Bytes(), secp256k1Size).base64()), nil
+	}
+
+	if j.isX25519() {
+		pubKey, err := j.PublicKeyBytes()
+		if err != nil {
+			return "", err
+		}
+
+		if len(pubKey) != cryptoutil.Curve25519KeySize {
+			return "", errors.New("invalid X25519 key")
+		}
+
+		return fmt.Sprintf(crvThumbprintTemplate, x25519Crv, okpKty,
+			newFixedSizeBuffer(pubKey, cryptoutil.Curve25519KeySize).base64()), nil
+	}
+
+	return "", nil
+}
+
 func ecdsaPubKeyType(pub *ecdsa.PublicKey) (kms.KeyType, error) {
 	switch pub.Curve {
 	case btcec.S256():
@@ -208,9 +294,11 @@ func ecdsaPubKeyType(pub *ecdsa.PublicKey) (kms.KeyType, error) {
 }
 
 func (j *JWK) isX25519() bool {
-	switch j.Key.(type) {
+	switch key := j.Key.(type) {
 	case []byte:
 		return isX25519(j.Kty, j.Crv)
+	case *cryptoapi.PrivateKey:
+		return isX25519(j.Kty, j.Crv) || isX25519(key.PublicKey.Type, key.PublicKey.Curve)
 	default:
 		return false
 	}
@@ -254,7 +342,7 @@ func isBLS12381G2(kty, crv string) bool {
 
 func isSecp256k1(alg, kty, crv string) bool {
 	return strings.EqualFold(alg, secp256k1Alg) ||
-		(strings.EqualFold(kty, ecKty) && strings.EqualFold(crv, secp256k1Crv))
+		(strings.EqualFold(kty, ecKty) && (strings.EqualFold(crv, secp256k1Crv) || strings.EqualFold(crv, p256KCrv)))
 }
 
 func unmarshalSecp256k1(jwk *jsonWebKey) (*JWK, error) {
@@ -322,9 +410,26 @@ func unmarshalX25519(jwk *jsonWebKey) (*JWK, error) {
 		return nil, ErrInvalidKey
 	}
 
+	var key interface{} = jwk.X.data
+
+	if jwk.D != nil {
+		if len(jwk.D.data) != cryptoutil.Curve25519KeySize {
+			return nil, ErrInvalidKey
+		}
+
+		key = &cryptoapi.PrivateKey{
+			PublicKey: cryptoapi.PublicKey{
+				X:     jwk.X.data,
+				Curve: x25519Crv,
+				Type:  okpKty,
+			},
+			D: jwk.D.data,
+		}
+	}
+
 	return &JWK{
 		JSONWebKey: jose.JSONWebKey{
-			Key: jwk.X.data, KeyID: jwk.Kid, Algorithm: jwk.Alg, Use: jwk.Use,
+			Key: key, KeyID: jwk.Kid, Algorithm: jwk.Alg, Use: jwk.Use,
 		},
 		Crv: jwk.Crv,
 		Kty: jwk.Kty,
@@ -332,10 +437,22 @@ func unmarshalX25519(jwk *jsonWebKey) (*JWK, error) {
 }
 
 func marshalX25519(jwk *JWK) ([]byte, error) {
-	var raw jsonWebKey
+	var (
+		raw     jsonWebKey
+		key     []byte
+		privKey []byte
+	)
+
+	switch x25519Key := jwk.Key.(type) {
+	case []byte:
+		key = x25519Key
+	case *cryptoapi.PrivateKey:
+		key, privKey = x25519Key.PublicKey.X, x25519Key.D
 
-	key, ok := jwk.Key.([]byte)
-	if !ok {
+		if len(privKey) != cryptoutil.Curve25519KeySize {
+			return nil, errors.New("marshalX25519: invalid private key")
+		}
+	default:
 		return nil, errors.New("marshalX25519: invalid key")
 	}
 
@@ -349,6 +466,10 @@ func marshalX25519(jwk *JWK) ([]byte, error) {
 		X:   newFixedSizeBuffer(key, cryptoutil.Curve25519KeySize),
 	}
 
+	if privKey != nil {
+		raw.D = newFixedSizeBuffer(privKey, cryptoutil.Curve25519KeySize)
+	}
+
 	raw.Kid = jwk.KeyID
 	raw.Alg = jwk.Algorithm
 	raw.Use = jwk.Use
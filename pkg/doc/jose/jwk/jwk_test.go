@@ -7,10 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package jwk
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"testing"
 
@@ -19,6 +23,7 @@ import (
 	"github.com/square/go-jose/v3/json"
 	"github.com/stretchr/testify/require"
 
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
@@ -456,6 +461,19 @@ func TestJWK_KeyType(t *testing.T) {
 					`4lciozwS6Ow_wTO3C2YGa_Our0ptIxr-x_3sMbPCN8Fe_iaBDezeDAm39xCNjFa1E735ipXA4eUW_6SzFJ5-bM2UKba2WE6xUaEa5G1` +
 					`MDDHCG5LKKd6Mhy7SSAzPOR2FTKYj89ch2asCPlbjHTu8jS6Iy8"
 				}`,
+				keyType: kms.RSARS256Type,
+			},
+			{
+				jwk: `{
+					"kty": "RSA",
+					"e": "AQAB",
+					"kid": "sample@sample.id",
+					"alg": "PS256",
+					"n": "1hOl09BUnwY7jFBqoZKa4XDmIuc0YFb4y_5ThiHhLRW68aNG5Vo23n3ugND2GK3PsguZqJ_HrWCGVuVlKTmFg` +
+					`JWQD9ZnVcYqScgHpQRhxMBi86PIvXR01D_PWXZZjvTRakpvQxUT5bVBdWnaBHQoxDBt0YIVi5a7x-gXB1aDlts4RTMpfS9BPmEjX` +
+					`4lciozwS6Ow_wTO3C2YGa_Our0ptIxr-x_3sMbPCN8Fe_iaBDezeDAm39xCNjFa1E735ipXA4eUW_6SzFJ5-bM2UKba2WE6xUaEa5G1` +
+					`MDDHCG5LKKd6Mhy7SSAzPOR2FTKYj89ch2asCPlbjHTu8jS6Iy8"
+				}`,
 				keyType: kms.RSAPS256Type,
 			},
 		}
@@ -542,3 +560,253 @@ func TestJWK_KeyType(t *testing.T) {
 		require.Equal(t, kms.KeyType(""), kt)
 	})
 }
+
+func TestJWK_Thumbprint(t *testing.T) {
+	t.Run("success: RFC 7638 and RFC 8037 test vectors", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			jwk        string
+			thumbprint string
+		}{
+			{
+				// https://tools.ietf.org/html/rfc7638#section-3.1
+				name: "RSA public key",
+				jwk: `{
+					"kty": "RSA",
+					"n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECP` +
+					`ebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQ` +
+					`MicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr` +
+					`3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+					"e": "AQAB",
+					"alg": "RS256",
+					"kid": "2011-04-29"
+				}`,
+				thumbprint: "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs",
+			},
+			{
+				// https://tools.ietf.org/html/rfc8037#appendix-A.3
+				name: "Ed25519 public key",
+				jwk: `{
+					"kty": "OKP",
+					"crv": "Ed25519",
+					"x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+				}`,
+				thumbprint: "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k",
+			},
+			{
+				// https://tools.ietf.org/html/rfc8037#appendix-A.1
+				name: "Ed25519 private key",
+				jwk: `{
+					"kty": "OKP",
+					"crv": "Ed25519",
+					"d": "nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A",
+					"x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+				}`,
+				thumbprint: "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k",
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				j := &JWK{}
+				require.NoError(t, j.UnmarshalJSON([]byte(tc.jwk)))
+
+				tp, err := j.Thumbprint(crypto.SHA256)
+				require.NoError(t, err)
+				require.Equal(t, tc.thumbprint, base64.RawURLEncoding.EncodeToString(tp))
+			})
+		}
+	})
+
+	t.Run("success: thumbprint of keys not supported by go-jose", func(t *testing.T) {
+		secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		x25519Key := make([]byte, 32)
+		_, err = rand.Read(x25519Key)
+		require.NoError(t, err)
+
+		x25519PrivKey := make([]byte, 32)
+		_, err = rand.Read(x25519PrivKey)
+		require.NoError(t, err)
+
+		bbsPubKey, _, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+		require.NoError(t, err)
+
+		bbsPubKeyBytes, err := bbsPubKey.Marshal()
+		require.NoError(t, err)
+
+		testCases := []struct {
+			name  string
+			jwk   *JWK
+			input string
+		}{
+			{
+				name: "secp256k1 private key",
+				jwk:  &JWK{JSONWebKey: jose.JSONWebKey{Key: secp256k1Key}},
+				input: fmt.Sprintf(`{"crv":"secp256k1","kty":"EC","x":"%s","y":"%s"}`,
+					newFixedSizeBuffer(secp256k1Key.X.Bytes(), 32).base64(),
+					newFixedSizeBuffer(secp256k1Key.Y.Bytes(), 32).base64()),
+			},
+			{
+				name:  "X25519 public key",
+				jwk:   &JWK{JSONWebKey: jose.JSONWebKey{Key: x25519Key}, Kty: "OKP", Crv: "X25519"},
+				input: fmt.Sprintf(`{"crv":"X25519","kty":"OKP","x":"%s"}`, base64.RawURLEncoding.EncodeToString(x25519Key)),
+			},
+			{
+				name: "X25519 private key",
+				jwk: &JWK{JSONWebKey: jose.JSONWebKey{Key: &cryptoapi.PrivateKey{
+					PublicKey: cryptoapi.PublicKey{X: x25519Key, Curve: "X25519", Type: "OKP"},
+					D:         x25519PrivKey,
+				}}},
+				input: fmt.Sprintf(`{"crv":"X25519","kty":"OKP","x":"%s"}`, base64.RawURLEncoding.EncodeToString(x25519Key)),
+			},
+			{
+				name: "BLS12381G2 public key",
+				jwk:  &JWK{JSONWebKey: jose.JSONWebKey{Key: bbsPubKey}},
+				input: fmt.Sprintf(`{"crv":"BLS12381_G2","kty":"EC","x":"%s"}`,
+					base64.RawURLEncoding.EncodeToString(bbsPubKeyBytes)),
+			},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				tp, err := tc.jwk.Thumbprint(crypto.SHA256)
+				require.NoError(t, err)
+
+				expected := sha256.Sum256([]byte(tc.input))
+				require.Equal(t, expected[:], tp)
+			})
+		}
+	})
+
+	t.Run("success: NIST P-256 key thumbprint is go-jose's thumbprint", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		j := &JWK{JSONWebKey: jose.JSONWebKey{Key: &ecKey.PublicKey}}
+
+		tp, err := j.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+
+		goJoseTP, err := j.JSONWebKey.Thumbprint(crypto.SHA256)
+		require.NoError(t, err)
+		require.Equal(t, goJoseTP, tp)
+	})
+
+	t.Run("fail: invalid X25519 key", func(t *testing.T) {
+		j := &JWK{JSONWebKey: jose.JSONWebKey{Key: []byte("invalid")}, Kty: "OKP", Crv: "X25519"}
+
+		_, err := j.Thumbprint(crypto.SHA256)
+		require.EqualError(t, err, "thumbprint: invalid X25519 key")
+	})
+}
+
+func TestJWK_PrivateKeys(t *testing.T) {
+	t.Run("success: X25519 private key", func(t *testing.T) {
+		jwkJSON := `{
+			"kty": "OKP",
+			"crv": "X25519",
+			"kid": "sample@sample.id",
+			"x": "hSDwCYkwp1R0i33ctD73Wg2_Og0mOBr066SpjqqbTmo",
+			"d": "dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo"
+		}`
+
+		j := &JWK{}
+		require.NoError(t, j.UnmarshalJSON([]byte(jwkJSON)))
+
+		privKey, ok := j.Key.(*cryptoapi.PrivateKey)
+		require.True(t, ok)
+		require.Len(t, privKey.D, 32)
+
+		kt, err := j.KeyType()
+		require.NoError(t, err)
+		require.Equal(t, kms.X25519ECDHKWType, kt)
+
+		pubKey, err := j.PublicKeyBytes()
+		require.NoError(t, err)
+		require.Equal(t, privKey.PublicKey.X, pubKey)
+
+		mJWK, err := j.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, jwkJSON, string(mJWK))
+	})
+
+	t.Run("fail: X25519 private key with invalid size", func(t *testing.T) {
+		jwkJSON := `{
+			"kty": "OKP",
+			"crv": "X25519",
+			"x": "hSDwCYkwp1R0i33ctD73Wg2_Og0mOBr066SpjqqbTmo",
+			"d": "dwdtCnMYpX08FsFyUbJmRd9ML4frwJkq"
+		}`
+
+		j := &JWK{}
+		require.EqualError(t, j.UnmarshalJSON([]byte(jwkJSON)), "unable to read X25519 JWE: invalid JWK")
+
+		j = &JWK{JSONWebKey: jose.JSONWebKey{Key: &cryptoapi.PrivateKey{
+			PublicKey: cryptoapi.PublicKey{X: make([]byte, 32), Curve: "X25519", Type: "OKP"},
+			D:         []byte("invalid"),
+		}}}
+
+		_, err := j.MarshalJSON()
+		require.EqualError(t, err, "marshalX25519: invalid private key")
+	})
+
+	t.Run("success: secp256k1 private key with P-256K curve name", func(t *testing.T) {
+		jwkJSON := `{
+			"kty": "EC",
+			"crv": "P-256K",
+			"x": "YRrvJocKf39GpdTnd-zBFE0msGDqawR-Cmtc6yKoFsM",
+			"y": "kE-dMH9S3mxnTXo0JFEhraCU_tVYFDfpu9tpP1LfVKQ"
+		}`
+
+		j := &JWK{}
+		require.NoError(t, j.UnmarshalJSON([]byte(jwkJSON)))
+
+		kt, err := j.KeyType()
+		require.NoError(t, err)
+		require.Equal(t, kms.ECDSASecp256k1TypeIEEEP1363, kt)
+
+		secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		j = &JWK{JSONWebKey: jose.JSONWebKey{Key: secp256k1Key}}
+
+		mJWK, err := j.MarshalJSON()
+		require.NoError(t, err)
+
+		parsed := &JWK{}
+		require.NoError(t, parsed.UnmarshalJSON(mJWK))
+		require.Equal(t, secp256k1Key, parsed.Key)
+	})
+
+	t.Run("success: Ed25519 and RSA private keys", func(t *testing.T) {
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		for _, key := range []interface{}{edKey, rsaKey} {
+			j := &JWK{JSONWebKey: jose.JSONWebKey{Key: key}}
+
+			mJWK, err := j.MarshalJSON()
+			require.NoError(t, err)
+
+			parsed := &JWK{}
+			require.NoError(t, parsed.UnmarshalJSON(mJWK))
+			require.False(t, parsed.IsPublic())
+
+			tp, err := j.Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+
+			parsedTP, err := parsed.Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+			require.Equal(t, tp, parsedTP)
+		}
+	})
+}
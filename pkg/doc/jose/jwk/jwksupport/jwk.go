@@ -10,17 +10,20 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -30,11 +33,14 @@ const (
 	x25519Crv      = "X25519"
 	bls12381G2Crv  = "BLS12381_G2"
 	bls12381G2Size = 96
+	rs256Alg       = "RS256"
+	ps256Alg       = "PS256"
+	encUse         = "enc"
 )
 
 // JWKFromKey creates a JWK from an opaque key struct.
-// It's e.g. *ecdsa.PublicKey, *ecdsa.PrivateKey, ed25519.VerificationMethod, *bbs12381g2pub.PrivateKey or
-// *bbs12381g2pub.PublicKey.
+// It's e.g. *ecdsa.PublicKey, *ecdsa.PrivateKey, ed25519.VerificationMethod, *bbs12381g2pub.PrivateKey,
+// *bbs12381g2pub.PublicKey, *rsa.PublicKey, *rsa.PrivateKey or an X25519 *cryptoapi.PrivateKey.
 func JWKFromKey(opaqueKey interface{}) (*jwk.JWK, error) {
 	key := &jwk.JWK{
 		JSONWebKey: jose.JSONWebKey{
@@ -127,13 +133,71 @@ func PubKeyBytesToJWK(bytes []byte, keyType kms.KeyType) (*jwk.JWK, error) { //
 		}
 
 		return JWKFromKey(ecdsaKey)
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, err := btcec.ParsePubKey(bytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		return JWKFromKey(pubKey.ToECDSA())
+	case kms.RSARS256Type, kms.RSAPS256Type:
+		return rsaPubKeyBytesToJWK(bytes, keyType)
 	case kms.X25519ECDHKWType:
-		return JWKFromX25519Key(bytes)
+		return x25519PubKeyBytesToJWK(bytes)
 	default:
 		return nil, fmt.Errorf("convertPubKeyJWK: invalid key type: %s", keyType)
 	}
 }
 
+// x25519PubKeyBytesToJWK converts either a raw X25519 public key or a marshalled *cryptoapi.PublicKey as exported by
+// the KMS into a JWK.
+func x25519PubKeyBytesToJWK(bytes []byte) (*jwk.JWK, error) {
+	if len(bytes) == cryptoutil.Curve25519KeySize {
+		return JWKFromX25519Key(bytes)
+	}
+
+	pubKey := &cryptoapi.PublicKey{}
+
+	err := json.Unmarshal(bytes, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return JWKFromX25519Key(pubKey.X)
+}
+
+// rsaPubKeyBytesToJWK converts a PKCS #1 (or PKIX) DER RSA public key into a JWK. The JWK 'alg' is set from keyType
+// so that JWK.KeyType() returns keyType.
+func rsaPubKeyBytesToJWK(bytes []byte, keyType kms.KeyType) (*jwk.JWK, error) {
+	rsaKey, err := x509.ParsePKCS1PublicKey(bytes)
+	if err != nil {
+		pubKey, e := x509.ParsePKIXPublicKey(bytes)
+		if e != nil {
+			return nil, fmt.Errorf("invalid RSA key: %w", err)
+		}
+
+		var ok bool
+
+		rsaKey, ok = pubKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, errors.New("invalid RSA key")
+		}
+	}
+
+	j, err := JWKFromKey(rsaKey)
+	if err != nil {
+		return nil, err
+	}
+
+	j.Algorithm = ps256Alg
+
+	if keyType == kms.RSARS256Type {
+		j.Algorithm = rs256Alg
+	}
+
+	return j, nil
+}
+
 func getECDSACurve(keyType kms.KeyType) elliptic.Curve {
 	switch keyType {
 	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP256TypeDER, kms.NISTP256ECDHKWType:
@@ -182,6 +246,10 @@ func PublicKeyFromJWK(jwkKey *jwk.JWK) (*cryptoapi.PublicKey, error) {
 			}
 
 			pubKey.X = pubEdKey
+		case []byte: // X25519 public key.
+			pubKey.X = key
+		case *cryptoapi.PrivateKey: // X25519 private key.
+			pubKey.X = key.PublicKey.X
 		default:
 			return nil, fmt.Errorf("publicKeyFromJWK: unsupported jwk key type %T", jwkKey.Key)
 		}
@@ -191,3 +259,54 @@ func PublicKeyFromJWK(jwkKey *jwk.JWK) (*cryptoapi.PublicKey, error) {
 
 	return nil, errors.New("publicKeyFromJWK: jwk is empty")
 }
+
+// ImportJWK imports the private key of jwkKey into keyManager and returns the new key id and the key handle. The kms
+// key type of the imported key is the JWK key type (see jwk.JWK.KeyType()), except for EC keys of NIST curves with
+// an 'enc' use which are imported as ECDH-KW keys (NISTP256ECDHKWType, NISTP384ECDHKWType or NISTP521ECDHKWType).
+func ImportJWK(keyManager kms.KeyManager, jwkKey *jwk.JWK, opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	if jwkKey == nil {
+		return "", nil, errors.New("importJWK: jwk is empty")
+	}
+
+	if !isPrivateKey(jwkKey.Key) {
+		return "", nil, errors.New("importJWK: jwk is not a private key")
+	}
+
+	kt, err := jwkKey.KeyType()
+	if err != nil {
+		return "", nil, fmt.Errorf("importJWK: %w", err)
+	}
+
+	if jwkKey.Use == encUse {
+		kt = ecdhKWKeyType(kt)
+	}
+
+	kid, kh, err := keyManager.ImportPrivateKey(jwkKey.Key, kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("importJWK: %w", err)
+	}
+
+	return kid, kh, nil
+}
+
+func isPrivateKey(key interface{}) bool {
+	switch key.(type) {
+	case ed25519.PrivateKey, *ecdsa.PrivateKey, *rsa.PrivateKey, *bbs12381g2pub.PrivateKey, *cryptoapi.PrivateKey:
+		return true
+	default:
+		return false
+	}
+}
+
+func ecdhKWKeyType(kt kms.KeyType) kms.KeyType {
+	switch kt {
+	case kms.ECDSAP256TypeIEEEP1363:
+		return kms.NISTP256ECDHKWType
+	case kms.ECDSAP384TypeIEEEP1363:
+		return kms.NISTP384ECDHKWType
+	case kms.ECDSAP521TypeIEEEP1363:
+		return kms.NISTP521ECDHKWType
+	default:
+		return kt
+	}
+}
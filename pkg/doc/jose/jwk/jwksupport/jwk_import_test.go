@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwksupport_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestImportJWK_LocalKMS(t *testing.T) {
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://test/key-uri/", p)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	rs256Key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ps256Key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		key     interface{}
+		alg     string
+		use     string
+		keyType kms.KeyType
+	}{
+		{name: "Ed25519 key", key: edKey, keyType: kms.ED25519Type},
+		{name: "P-256 signing key", key: p256Key, use: "sig", keyType: kms.ECDSAP256TypeIEEEP1363},
+		{name: "P-384 encryption key", key: p384Key, use: "enc", keyType: kms.NISTP384ECDHKWType},
+		{name: "secp256k1 key", key: secp256k1Key, keyType: kms.ECDSASecp256k1TypeIEEEP1363},
+		{name: "RS256 key", key: rs256Key, alg: "RS256", keyType: kms.RSARS256Type},
+		{name: "PS256 key", key: ps256Key, alg: "PS256", keyType: kms.RSAPS256Type},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+
+		t.Run(tc.name, func(t *testing.T) {
+			privJWK, err := jwksupport.JWKFromKey(tc.key)
+			require.NoError(t, err)
+
+			privJWK.Algorithm = tc.alg
+			privJWK.Use = tc.use
+
+			thumbprint, err := privJWK.Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+
+			// the JWK thumbprint is the ID localkms creates for the key (see localkms.CreateKID).
+			expectedKID := base64.RawURLEncoding.EncodeToString(thumbprint)
+
+			kid, _, err := jwksupport.ImportJWK(km, privJWK, kms.WithKeyID(expectedKID))
+			require.NoError(t, err)
+			require.Equal(t, expectedKID, kid)
+
+			pubKeyBytes, kt, err := km.ExportPubKeyBytes(kid)
+			require.NoError(t, err)
+			require.Equal(t, tc.keyType, kt)
+
+			createdKID, err := localkms.CreateKID(pubKeyBytes, kt)
+			require.NoError(t, err)
+			require.Equal(t, expectedKID, createdKID)
+
+			pubJWK, err := jwksupport.PubKeyBytesToJWK(pubKeyBytes, kt)
+			require.NoError(t, err)
+			requireSameThumbprint(t, privJWK, pubJWK)
+		})
+	}
+}
+
+func requireSameThumbprint(t *testing.T, expected, actual *jwk.JWK) {
+	t.Helper()
+
+	expectedThumbprint, err := expected.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	actualThumbprint, err := actual.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	require.Equal(t, expectedThumbprint, actualThumbprint)
+}
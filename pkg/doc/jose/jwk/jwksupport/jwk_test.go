@@ -11,6 +11,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
@@ -19,6 +20,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 )
 
 func TestDecodeJWK(t *testing.T) {
@@ -385,6 +388,18 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 			name:    "P-521 KW test",
 			keyType: kms.NISTP521ECDHKWType,
 		},
+		{
+			name:    "secp256k1 IEEE1363 test",
+			keyType: kms.ECDSASecp256k1TypeIEEEP1363,
+		},
+		{
+			name:    "RSA RS256 test",
+			keyType: kms.RSARS256Type,
+		},
+		{
+			name:    "RSA PS256 test",
+			keyType: kms.RSAPS256Type,
+		},
 		{
 			name:    "undefined type test",
 			keyType: "undefined",
@@ -492,6 +507,63 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 				require.NotEmpty(t, jwkKey)
 				require.Equal(t, okpKty, jwkKey.Kty)
 				require.Equal(t, x25519Crv, jwkKey.Crv)
+
+				// marshalled public key as exported by the KMS.
+				mPubKey, err := json.Marshal(&cryptoapi.PublicKey{X: pubKeyBytes, Curve: x25519Crv, Type: okpKty})
+				require.NoError(t, err)
+
+				jwkKey, err = PubKeyBytesToJWK(mPubKey, tc.keyType)
+				require.NoError(t, err)
+				require.Equal(t, pubKeyBytes, jwkKey.Key)
+
+				_, err = PubKeyBytesToJWK([]byte("invalid X25519 Key"), tc.keyType)
+				require.Error(t, err)
+			case kms.ECDSASecp256k1TypeIEEEP1363:
+				privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+				require.NoError(t, err)
+
+				for _, keyBytes := range [][]byte{
+					elliptic.Marshal(privKey.Curve, privKey.X, privKey.Y),
+					(*btcec.PublicKey)(&privKey.PublicKey).SerializeCompressed(),
+				} {
+					jwkKey, err := PubKeyBytesToJWK(keyBytes, tc.keyType)
+					require.NoError(t, err)
+					require.Equal(t, ecKty, jwkKey.Kty)
+					require.Equal(t, "secp256k1", jwkKey.Crv)
+					require.Equal(t, &privKey.PublicKey, jwkKey.Key)
+				}
+
+				_, err = PubKeyBytesToJWK([]byte("invalid EC Key"), tc.keyType)
+				require.Error(t, err)
+			case kms.RSARS256Type, kms.RSAPS256Type:
+				privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				require.NoError(t, err)
+
+				pkixKey, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+				require.NoError(t, err)
+
+				for _, keyBytes := range [][]byte{x509.MarshalPKCS1PublicKey(&privKey.PublicKey), pkixKey} {
+					jwkKey, err := PubKeyBytesToJWK(keyBytes, tc.keyType)
+					require.NoError(t, err)
+					require.Equal(t, "RSA", jwkKey.Kty)
+
+					kt, err := jwkKey.KeyType()
+					require.NoError(t, err)
+					require.Equal(t, tc.keyType, kt)
+				}
+
+				_, err = PubKeyBytesToJWK([]byte("invalid RSA Key"), tc.keyType)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid RSA key")
+
+				pubEdKey, _, err := ed25519.GenerateKey(rand.Reader)
+				require.NoError(t, err)
+
+				pubEdKeyBytes, err := x509.MarshalPKIXPublicKey(pubEdKey)
+				require.NoError(t, err)
+
+				_, err = PubKeyBytesToJWK(pubEdKeyBytes, tc.keyType)
+				require.EqualError(t, err, "invalid RSA key")
 			default:
 				_, err := PubKeyBytesToJWK([]byte{}, tc.keyType)
 				require.EqualError(t, err, "convertPubKeyJWK: invalid key type: undefined")
@@ -628,3 +700,106 @@ func TestPublicKeyFromJWK(t *testing.T) {
 		require.EqualError(t, err, "publicKeyFromJWK: jwk is empty")
 	})
 }
+
+func TestPublicKeyFromJWK_X25519(t *testing.T) {
+	x25519Key := make([]byte, cryptoutil.Curve25519KeySize)
+	_, err := rand.Read(x25519Key)
+	require.NoError(t, err)
+
+	pubJWK, err := JWKFromX25519Key(x25519Key)
+	require.NoError(t, err)
+
+	privJWK, err := JWKFromKey(&cryptoapi.PrivateKey{
+		PublicKey: cryptoapi.PublicKey{X: x25519Key, Curve: x25519Crv, Type: okpKty},
+		D:         make([]byte, cryptoutil.Curve25519KeySize),
+	})
+	require.NoError(t, err)
+
+	for _, j := range []*jwk.JWK{pubJWK, privJWK} {
+		pubKey, err := PublicKeyFromJWK(j)
+		require.NoError(t, err)
+		require.Equal(t, x25519Key, pubKey.X)
+		require.Equal(t, x25519Crv, pubKey.Curve)
+		require.Equal(t, okpKty, pubKey.Type)
+	}
+}
+
+type keyTypeRecorder struct {
+	mockkms.KeyManager
+	keyType kms.KeyType
+}
+
+func (k *keyTypeRecorder) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	k.keyType = kt
+
+	return k.KeyManager.ImportPrivateKey(privKey, kt, opts...)
+}
+
+func TestImportJWK(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			jwk     *jwk.JWK
+			keyType kms.KeyType
+		}{
+			{
+				name:    "Ed25519 key",
+				jwk:     &jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: edKey}},
+				keyType: kms.ED25519Type,
+			},
+			{
+				name:    "P-384 signing key",
+				jwk:     &jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: ecKey, Use: "sig"}},
+				keyType: kms.ECDSAP384TypeIEEEP1363,
+			},
+			{
+				name:    "P-384 encryption key",
+				jwk:     &jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: ecKey, Use: "enc"}},
+				keyType: kms.NISTP384ECDHKWType,
+			},
+			{
+				name: "X25519 key",
+				jwk: &jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: &cryptoapi.PrivateKey{
+					PublicKey: cryptoapi.PublicKey{Curve: x25519Crv, Type: okpKty},
+				}}, Kty: okpKty, Crv: x25519Crv},
+				keyType: kms.X25519ECDHKWType,
+			},
+		}
+
+		for _, tt := range tests {
+			tc := tt
+
+			t.Run(tc.name, func(t *testing.T) {
+				km := &keyTypeRecorder{KeyManager: mockkms.KeyManager{ImportPrivateKeyID: "kid"}}
+
+				kid, _, err := ImportJWK(km, tc.jwk)
+				require.NoError(t, err)
+				require.Equal(t, "kid", kid)
+				require.Equal(t, tc.keyType, km.keyType)
+			})
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		km := &mockkms.KeyManager{ImportPrivateKeyErr: fmt.Errorf("import error")}
+
+		_, _, err := ImportJWK(km, nil)
+		require.EqualError(t, err, "importJWK: jwk is empty")
+
+		_, _, err = ImportJWK(km, &jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: edKey.Public()}})
+		require.EqualError(t, err, "importJWK: jwk is not a private key")
+
+		_, _, err = ImportJWK(km, &jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: &cryptoapi.PrivateKey{}}})
+		require.EqualError(t, err, "importJWK: no keytype recognized for jwk")
+
+		_, _, err = ImportJWK(km, &jwk.JWK{JSONWebKey: jose.JSONWebKey{Key: edKey}})
+		require.EqualError(t, err, "importJWK: import error")
+	})
+}
@@ -30,6 +30,10 @@ type JSONWebSignature struct {
 
 	signature   []byte
 	joseHeaders Headers
+
+	// signatures are set for a JWS with several signatures or parsed from JWS JSON Serialization, the fields above
+	// then hold the first signature.
+	signatures []*JWSSignature
 }
 
 // SignatureVerifier makes verification of JSON Web Signature.
@@ -132,12 +136,22 @@ func NewJWS(protectedHeaders, unprotectedHeaders Headers, payload []byte, signer
 
 // SerializeCompact makes JWS Compact Serialization (https://tools.ietf.org/html/rfc7515#section-7.1)
 func (s JSONWebSignature) SerializeCompact(detached bool) (string, error) {
-	byteHeaders, err := json.Marshal(s.joseHeaders)
-	if err != nil {
-		return "", fmt.Errorf("marshal JWS JOSE Headers: %w", err)
+	if len(s.signatures) > 1 {
+		return "", errors.New("JWS compact serialization supports a single signature only")
 	}
 
-	b64Headers := base64.RawURLEncoding.EncodeToString(byteHeaders)
+	var b64Headers string
+
+	if len(s.signatures) == 1 && s.signatures[0].b64Protected != "" {
+		b64Headers = s.signatures[0].b64Protected
+	} else {
+		byteHeaders, err := json.Marshal(s.joseHeaders)
+		if err != nil {
+			return "", fmt.Errorf("marshal JWS JOSE Headers: %w", err)
+		}
+
+		b64Headers = base64.RawURLEncoding.EncodeToString(byteHeaders)
+	}
 
 	b64Payload := ""
 	if !detached {
@@ -197,9 +211,20 @@ func sign(joseHeaders Headers, payload []byte, signer Signer) ([]byte, error) {
 	return signature, nil
 }
 
+// JWSVerificationPolicy defines which signatures of a JWS JSON Serialization must be valid for the JWS to be valid.
+type JWSVerificationPolicy int
+
+const (
+	// VerifyAllSignatures requires all the signatures to be valid. It is the default policy.
+	VerifyAllSignatures JWSVerificationPolicy = iota
+	// VerifyAnySignature requires at least one valid signature. Invalid signatures are left out of the parsed JWS.
+	VerifyAnySignature
+)
+
 // jwsParseOpts holds options for the JWS Parsing.
 type jwsParseOpts struct {
 	detachedPayload []byte
+	policy          JWSVerificationPolicy
 }
 
 // JWSParseOpt is the JWS Parser option.
@@ -212,7 +237,15 @@ func WithJWSDetachedPayload(payload []byte) JWSParseOpt {
 	}
 }
 
-// ParseJWS parses serialized JWS. Currently only JWS Compact Serialization parsing is supported.
+// WithJWSVerificationPolicy option sets the policy used to verify the signatures of a JWS JSON Serialization.
+func WithJWSVerificationPolicy(policy JWSVerificationPolicy) JWSParseOpt {
+	return func(opts *jwsParseOpts) {
+		opts.policy = policy
+	}
+}
+
+// ParseJWS parses serialized JWS, either in JWS Compact Serialization or in JWS JSON Serialization (general or
+// flattened syntax).
 func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSONWebSignature, error) {
 	pOpts := &jwsParseOpts{}
 
@@ -221,9 +254,7 @@ func ParseJWS(jws string, verifier SignatureVerifier, opts ...JWSParseOpt) (*JSO
 	}
 
 	if strings.HasPrefix(jws, "{") {
-		// TODO support JWS JSON serialization format
-		//  https://github.com/hyperledger/aries-framework-go/issues/1331
-		return nil, errors.New("JWS JSON serialization is not supported")
+		return parseJSON(jws, verifier, pOpts)
 	}
 
 	return parseCompacted(jws, verifier, pOpts)
@@ -315,25 +346,43 @@ func signingInput(headers Headers, payload []byte) ([]byte, error) {
 		return nil, fmt.Errorf("serialize JWS headers: %w", err)
 	}
 
-	hBase64 := true
+	return encodedSigningInput(base64.RawURLEncoding.EncodeToString(headersBytes), headers, payload)
+}
 
-	if b64, ok := headers[HeaderB64Payload]; ok {
-		if hBase64, ok = b64.(bool); !ok {
-			return nil, errors.New("invalid b64 header")
-		}
+// encodedSigningInput builds the signing input from the encoded protected headers, payload is encoded as set by the
+// b64 header of the protected headers.
+func encodedSigningInput(b64Headers string, headers Headers, payload []byte) ([]byte, error) {
+	payloadStr, err := encodePayload(headers, payload)
+	if err != nil {
+		return nil, err
 	}
 
-	headersStr := base64.RawURLEncoding.EncodeToString(headersBytes)
+	return []byte(fmt.Sprintf("%s.%s", b64Headers, payloadStr)), nil
+}
 
-	var payloadStr string
+func encodePayload(headers Headers, payload []byte) (string, error) {
+	hBase64, err := isPayloadEncoded(headers)
+	if err != nil {
+		return "", err
+	}
 
 	if hBase64 {
-		payloadStr = base64.RawURLEncoding.EncodeToString(payload)
-	} else {
-		payloadStr = string(payload)
+		return base64.RawURLEncoding.EncodeToString(payload), nil
+	}
+
+	return string(payload), nil
+}
+
+func isPayloadEncoded(headers Headers) (bool, error) {
+	hBase64 := true
+
+	if b64, ok := headers[HeaderB64Payload]; ok {
+		if hBase64, ok = b64.(bool); !ok {
+			return false, errors.New("invalid b64 header")
+		}
 	}
 
-	return []byte(fmt.Sprintf("%s.%s", headersStr, payloadStr)), nil
+	return hBase64, nil
 }
 
 func checkJWSHeaders(headers Headers) error {
@@ -0,0 +1,412 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/square/go-jose/v3/json"
+)
+
+// JWSSignature is a signature of a JWS JSON Serialization, with its own protected and unprotected headers
+// (https://tools.ietf.org/html/rfc7515#section-7.2.1).
+type JWSSignature struct {
+	ProtectedHeaders   Headers
+	UnprotectedHeaders Headers
+
+	signature []byte
+	// b64Protected are the protected headers as encoded in the signing input.
+	b64Protected string
+}
+
+// Signature returns a copy of the signature.
+func (s *JWSSignature) Signature() []byte {
+	if s.signature == nil {
+		return nil
+	}
+
+	sCopy := make([]byte, len(s.signature))
+	copy(sCopy, s.signature)
+
+	return sCopy
+}
+
+// Headers returns the JOSE headers of the signature, i.e. the union of its protected and unprotected headers.
+func (s *JWSSignature) Headers() Headers {
+	return mergeHeaders(s.ProtectedHeaders, s.UnprotectedHeaders)
+}
+
+func (s *JWSSignature) encodedProtectedHeaders() (string, error) {
+	if s.b64Protected != "" || len(s.ProtectedHeaders) == 0 {
+		return s.b64Protected, nil
+	}
+
+	headersBytes, err := json.Marshal(s.ProtectedHeaders)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(headersBytes), nil
+}
+
+func (s *JWSSignature) verify(payload []byte, verifier SignatureVerifier) error {
+	sInput, err := encodedSigningInput(s.b64Protected, s.ProtectedHeaders, payload)
+	if err != nil {
+		return fmt.Errorf("build signing input: %w", err)
+	}
+
+	return verifier.Verify(s.Headers(), payload, sInput, s.signature)
+}
+
+// JWSSigner is a signer of a JWS with several signatures, along with the headers of its signature.
+type JWSSigner struct {
+	Signer Signer
+	// ProtectedHeaders are merged with the headers of the signer (e.g. "alg" and "kid").
+	ProtectedHeaders   Headers
+	UnprotectedHeaders Headers
+}
+
+func (s JWSSigner) sign(payload []byte) (*JWSSignature, error) {
+	protectedHeaders := mergeHeaders(s.ProtectedHeaders, s.Signer.Headers())
+
+	err := checkSignatureHeaders(protectedHeaders, s.UnprotectedHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("check JOSE headers: %w", err)
+	}
+
+	headersBytes, err := json.Marshal(protectedHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("serialize JWS headers: %w", err)
+	}
+
+	b64Protected := base64.RawURLEncoding.EncodeToString(headersBytes)
+
+	sigInput, err := encodedSigningInput(b64Protected, protectedHeaders, payload)
+	if err != nil {
+		return nil, fmt.Errorf("prepare JWS verification data: %w", err)
+	}
+
+	signature, err := s.Signer.Sign(sigInput)
+	if err != nil {
+		return nil, fmt.Errorf("sign JWS verification data: %w", err)
+	}
+
+	return &JWSSignature{
+		ProtectedHeaders:   protectedHeaders,
+		UnprotectedHeaders: s.UnprotectedHeaders,
+		signature:          signature,
+		b64Protected:       b64Protected,
+	}, nil
+}
+
+// NewMultiSignatureJWS creates JSON Web Signature with a signature of the payload by each of the signers.
+// Unless there is a single signer, it can only be serialized in JWS JSON Serialization (see SerializeJSON).
+func NewMultiSignatureJWS(payload []byte, signers ...JWSSigner) (*JSONWebSignature, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no JWS signer")
+	}
+
+	signatures := make([]*JWSSignature, 0, len(signers))
+
+	for i, signer := range signers {
+		signature, err := signer.sign(payload)
+		if err != nil {
+			return nil, fmt.Errorf("sign JWS with signer %d: %w", i, err)
+		}
+
+		signatures = append(signatures, signature)
+	}
+
+	_, err := isPayloadEncodedBySignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMultiSignatureJWS(payload, signatures), nil
+}
+
+func newMultiSignatureJWS(payload []byte, signatures []*JWSSignature) *JSONWebSignature {
+	first := signatures[0]
+
+	return &JSONWebSignature{
+		ProtectedHeaders:   first.ProtectedHeaders,
+		UnprotectedHeaders: first.UnprotectedHeaders,
+		Payload:            payload,
+		signature:          first.signature,
+		joseHeaders:        first.ProtectedHeaders,
+		signatures:         signatures,
+	}
+}
+
+// Signatures returns the signatures of the JWS.
+func (s JSONWebSignature) Signatures() []*JWSSignature {
+	if len(s.signatures) > 0 {
+		signatures := make([]*JWSSignature, len(s.signatures))
+		copy(signatures, s.signatures)
+
+		return signatures
+	}
+
+	return []*JWSSignature{{
+		ProtectedHeaders:   s.ProtectedHeaders,
+		UnprotectedHeaders: s.UnprotectedHeaders,
+		signature:          s.signature,
+	}}
+}
+
+// rawJSONWebSignature is a JWS JSON Serialization, in either general or flattened syntax.
+type rawJSONWebSignature struct {
+	Payload    string             `json:"payload,omitempty"`
+	Signatures []*rawJWSSignature `json:"signatures,omitempty"`
+
+	// Flattened syntax members.
+	Protected string  `json:"protected,omitempty"`
+	Header    Headers `json:"header,omitempty"`
+	Signature string  `json:"signature,omitempty"`
+}
+
+type rawJWSSignature struct {
+	Protected string  `json:"protected,omitempty"`
+	Header    Headers `json:"header,omitempty"`
+	Signature string  `json:"signature"`
+}
+
+// SerializeJSON makes JWS JSON Serialization with the general syntax
+// (https://tools.ietf.org/html/rfc7515#section-7.2.1).
+func (s JSONWebSignature) SerializeJSON(detached bool) (string, error) {
+	payload, signatures, err := s.rawSignatures(detached)
+	if err != nil {
+		return "", err
+	}
+
+	return marshalRawJWS(&rawJSONWebSignature{
+		Payload:    payload,
+		Signatures: signatures,
+	})
+}
+
+// SerializeFlattenedJSON makes JWS JSON Serialization with the flattened syntax
+// (https://tools.ietf.org/html/rfc7515#section-7.2.2). It supports a single signature only.
+func (s JSONWebSignature) SerializeFlattenedJSON(detached bool) (string, error) {
+	if len(s.signatures) > 1 {
+		return "", errors.New("JWS flattened JSON serialization supports a single signature only")
+	}
+
+	payload, signatures, err := s.rawSignatures(detached)
+	if err != nil {
+		return "", err
+	}
+
+	return marshalRawJWS(&rawJSONWebSignature{
+		Payload:   payload,
+		Protected: signatures[0].Protected,
+		Header:    signatures[0].Header,
+		Signature: signatures[0].Signature,
+	})
+}
+
+func (s JSONWebSignature) rawSignatures(detached bool) (string, []*rawJWSSignature, error) {
+	signatures := s.Signatures()
+	rawSignatures := make([]*rawJWSSignature, 0, len(signatures))
+
+	for _, signature := range signatures {
+		b64Protected, err := signature.encodedProtectedHeaders()
+		if err != nil {
+			return "", nil, fmt.Errorf("marshal JWS protected headers: %w", err)
+		}
+
+		rawSignatures = append(rawSignatures, &rawJWSSignature{
+			Protected: b64Protected,
+			Header:    signature.UnprotectedHeaders,
+			Signature: base64.RawURLEncoding.EncodeToString(signature.signature),
+		})
+	}
+
+	if detached {
+		return "", rawSignatures, nil
+	}
+
+	payload, err := encodePayload(signatures[0].ProtectedHeaders, s.Payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return payload, rawSignatures, nil
+}
+
+func marshalRawJWS(rawJWS *rawJSONWebSignature) (string, error) {
+	jwsBytes, err := json.Marshal(rawJWS)
+	if err != nil {
+		return "", fmt.Errorf("marshal JWS JSON: %w", err)
+	}
+
+	return string(jwsBytes), nil
+}
+
+func (r *rawJSONWebSignature) signatures() ([]*rawJWSSignature, error) {
+	flattened := r.Protected != "" || r.Header != nil || r.Signature != ""
+
+	switch {
+	case len(r.Signatures) > 0 && flattened:
+		return nil, errors.New("invalid JWS JSON serialization: mixed general and flattened syntax")
+	case len(r.Signatures) > 0:
+		return r.Signatures, nil
+	case flattened:
+		return []*rawJWSSignature{{
+			Protected: r.Protected,
+			Header:    r.Header,
+			Signature: r.Signature,
+		}}, nil
+	default:
+		return nil, errors.New("invalid JWS JSON serialization: no signature")
+	}
+}
+
+func parseJSON(jws string, verifier SignatureVerifier, opts *jwsParseOpts) (*JSONWebSignature, error) {
+	rawJWS := &rawJSONWebSignature{}
+
+	err := json.Unmarshal([]byte(jws), rawJWS)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal JWS JSON: %w", err)
+	}
+
+	rawSignatures, err := rawJWS.signatures()
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]*JWSSignature, 0, len(rawSignatures))
+
+	for i, rawSignature := range rawSignatures {
+		signature, e := parseJSONSignature(rawSignature)
+		if e != nil {
+			return nil, fmt.Errorf("parse JWS signature %d: %w", i, e)
+		}
+
+		signatures = append(signatures, signature)
+	}
+
+	encoded, err := isPayloadEncodedBySignatures(signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := []byte(rawJWS.Payload)
+
+	if encoded || len(opts.detachedPayload) > 0 {
+		payload, err = parseCompactedPayload(rawJWS.Payload, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	signatures, err = verifySignatures(signatures, payload, verifier, opts.policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return newMultiSignatureJWS(payload, signatures), nil
+}
+
+func parseJSONSignature(rawSignature *rawJWSSignature) (*JWSSignature, error) {
+	var protectedHeaders Headers
+
+	if rawSignature.Protected != "" {
+		headersBytes, err := base64.RawURLEncoding.DecodeString(rawSignature.Protected)
+		if err != nil {
+			return nil, fmt.Errorf("decode base64 header: %w", err)
+		}
+
+		err = json.Unmarshal(headersBytes, &protectedHeaders)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal JSON headers: %w", err)
+		}
+	}
+
+	err := checkSignatureHeaders(protectedHeaders, rawSignature.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(rawSignature.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 signature: %w", err)
+	}
+
+	return &JWSSignature{
+		ProtectedHeaders:   protectedHeaders,
+		UnprotectedHeaders: rawSignature.Header,
+		signature:          signature,
+		b64Protected:       rawSignature.Protected,
+	}, nil
+}
+
+func verifySignatures(signatures []*JWSSignature, payload []byte, verifier SignatureVerifier,
+	policy JWSVerificationPolicy) ([]*JWSSignature, error) {
+	var (
+		verified []*JWSSignature
+		errs     []string
+	)
+
+	for i, signature := range signatures {
+		err := signature.verify(payload, verifier)
+		if err == nil {
+			verified = append(verified, signature)
+
+			continue
+		}
+
+		if policy == VerifyAllSignatures {
+			return nil, fmt.Errorf("verify JWS signature %d: %w", i, err)
+		}
+
+		errs = append(errs, fmt.Sprintf("signature %d: %s", i, err.Error()))
+	}
+
+	if len(verified) == 0 {
+		return nil, fmt.Errorf("no valid JWS signature: %s", strings.Join(errs, "; "))
+	}
+
+	return verified, nil
+}
+
+// checkSignatureHeaders checks that the JOSE headers of a signature have an "alg" header and that its protected and
+// unprotected headers are disjoint (https://tools.ietf.org/html/rfc7515#section-7.2.1).
+func checkSignatureHeaders(protectedHeaders, unprotectedHeaders Headers) error {
+	for name := range unprotectedHeaders {
+		if _, ok := protectedHeaders[name]; ok {
+			return fmt.Errorf("%s JWS header is both protected and unprotected", name)
+		}
+	}
+
+	return checkJWSHeaders(mergeHeaders(protectedHeaders, unprotectedHeaders))
+}
+
+// isPayloadEncodedBySignatures checks that all signatures encode the payload the same way, as set by their b64 header
+// (https://tools.ietf.org/html/rfc7797#section-3).
+func isPayloadEncodedBySignatures(signatures []*JWSSignature) (bool, error) {
+	encoded, err := isPayloadEncoded(signatures[0].ProtectedHeaders)
+	if err != nil {
+		return false, err
+	}
+
+	for _, signature := range signatures[1:] {
+		e, err := isPayloadEncoded(signature.ProtectedHeaders)
+		if err != nil {
+			return false, err
+		}
+
+		if e != encoded {
+			return false, errors.New("JWS signatures have different b64 header values")
+		}
+	}
+
+	return encoded, nil
+}
@@ -0,0 +1,353 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/square/go-jose/v3/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiSignatureJWS(t *testing.T) {
+	payload := []byte(`{"id":"1234","body":{}}`)
+
+	t.Run("success - general JSON serialization", func(t *testing.T) {
+		alice, bob := newEd25519TestSigner(t, "alice"), newEd25519TestSigner(t, "bob")
+
+		jws, err := NewMultiSignatureJWS(payload,
+			JWSSigner{Signer: alice, ProtectedHeaders: Headers{"typ": "application/didcomm-signed+json"}},
+			JWSSigner{Signer: bob, UnprotectedHeaders: Headers{"note": "co-signer"}})
+		require.NoError(t, err)
+		require.Len(t, jws.Signatures(), 2)
+		require.Equal(t, "alice", jws.ProtectedHeaders["kid"])
+
+		_, err = jws.SerializeCompact(false)
+		require.EqualError(t, err, "JWS compact serialization supports a single signature only")
+
+		_, err = jws.SerializeFlattenedJSON(false)
+		require.EqualError(t, err, "JWS flattened JSON serialization supports a single signature only")
+
+		jwsJSON, err := jws.SerializeJSON(false)
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(jwsJSON), &raw))
+		require.Equal(t, base64.RawURLEncoding.EncodeToString(payload), raw["payload"])
+		require.Len(t, raw["signatures"], 2)
+
+		verifier := newEd25519TestVerifier(alice, bob)
+
+		parsedJWS, err := ParseJWS(jwsJSON, verifier)
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+
+		signatures := parsedJWS.Signatures()
+		require.Len(t, signatures, 2)
+		require.Equal(t, Headers{"alg": "EdDSA", "kid": "alice", "typ": "application/didcomm-signed+json"},
+			signatures[0].Headers())
+		require.Equal(t, Headers{"alg": "EdDSA", "kid": "bob", "note": "co-signer"}, signatures[1].Headers())
+		require.Equal(t, jws.Signatures()[1].Signature(), signatures[1].Signature())
+
+		// detached payload.
+		jwsJSON, err = jws.SerializeJSON(true)
+		require.NoError(t, err)
+		require.NotContains(t, jwsJSON, `"payload"`)
+
+		parsedJWS, err = ParseJWS(jwsJSON, verifier, WithJWSDetachedPayload(payload))
+		require.NoError(t, err)
+		require.Len(t, parsedJWS.Signatures(), 2)
+	})
+
+	t.Run("success - flattened JSON serialization", func(t *testing.T) {
+		alice := newEd25519TestSigner(t, "alice")
+
+		jws, err := NewMultiSignatureJWS(payload, JWSSigner{Signer: alice, UnprotectedHeaders: Headers{"x": "y"}})
+		require.NoError(t, err)
+
+		jwsJSON, err := jws.SerializeFlattenedJSON(false)
+		require.NoError(t, err)
+		require.NotContains(t, jwsJSON, `"signatures"`)
+
+		parsedJWS, err := ParseJWS(jwsJSON, newEd25519TestVerifier(alice))
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+		require.Equal(t, Headers{"x": "y"}, parsedJWS.UnprotectedHeaders)
+
+		// a single signature JWS can be serialized in compact form too.
+		jwsCompact, err := parsedJWS.SerializeCompact(false)
+		require.NoError(t, err)
+
+		parsedJWS, err = ParseJWS(jwsCompact, newEd25519TestVerifier(alice))
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+	})
+
+	t.Run("success - JWS created with NewJWS in JSON serialization", func(t *testing.T) {
+		alice := newEd25519TestSigner(t, "alice")
+
+		jws, err := NewJWS(Headers{"typ": "JWT"}, Headers{"x": "y"}, payload, alice)
+		require.NoError(t, err)
+
+		for _, serialize := range []func(bool) (string, error){jws.SerializeJSON, jws.SerializeFlattenedJSON} {
+			jwsJSON, err := serialize(false)
+			require.NoError(t, err)
+
+			parsedJWS, err := ParseJWS(jwsJSON, newEd25519TestVerifier(alice))
+			require.NoError(t, err)
+			require.Equal(t, jws.ProtectedHeaders, parsedJWS.ProtectedHeaders)
+			require.Equal(t, jws.UnprotectedHeaders, parsedJWS.UnprotectedHeaders)
+			require.Equal(t, jws.Signature(), parsedJWS.Signature())
+		}
+	})
+
+	t.Run("success - unencoded payload", func(t *testing.T) {
+		alice, bob := newEd25519TestSigner(t, "alice"), newEd25519TestSigner(t, "bob")
+
+		jws, err := NewMultiSignatureJWS(payload,
+			JWSSigner{Signer: alice, ProtectedHeaders: Headers{"b64": false, "crit": []string{"b64"}}},
+			JWSSigner{Signer: bob, ProtectedHeaders: Headers{"b64": false, "crit": []string{"b64"}}})
+		require.NoError(t, err)
+
+		jwsJSON, err := jws.SerializeJSON(false)
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(jwsJSON), &raw))
+		require.Equal(t, string(payload), raw["payload"])
+
+		parsedJWS, err := ParseJWS(jwsJSON, newEd25519TestVerifier(alice, bob))
+		require.NoError(t, err)
+		require.Equal(t, payload, parsedJWS.Payload)
+	})
+
+	t.Run("error - invalid signers", func(t *testing.T) {
+		jws, err := NewMultiSignatureJWS(payload)
+		require.EqualError(t, err, "no JWS signer")
+		require.Nil(t, jws)
+
+		jws, err = NewMultiSignatureJWS(payload, JWSSigner{Signer: &testSigner{headers: Headers{}}})
+		require.EqualError(t, err, "sign JWS with signer 0: check JOSE headers: alg JWS header is not defined")
+		require.Nil(t, jws)
+
+		jws, err = NewMultiSignatureJWS(payload, JWSSigner{
+			Signer:             &testSigner{headers: Headers{"alg": "dummy"}},
+			UnprotectedHeaders: Headers{"alg": "other"},
+		})
+		require.EqualError(t, err, "sign JWS with signer 0: check JOSE headers: "+
+			"alg JWS header is both protected and unprotected")
+		require.Nil(t, jws)
+
+		jws, err = NewMultiSignatureJWS(payload, JWSSigner{Signer: &testSigner{headers: getUnmarshallableMap()}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "serialize JWS headers")
+		require.Nil(t, jws)
+
+		jws, err = NewMultiSignatureJWS(payload, JWSSigner{
+			Signer: &testSigner{headers: Headers{"alg": "dummy", "b64": "invalid"}},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid b64 header")
+		require.Nil(t, jws)
+
+		jws, err = NewMultiSignatureJWS(payload,
+			JWSSigner{Signer: &testSigner{headers: Headers{"alg": "dummy"}}},
+			JWSSigner{Signer: &testSigner{headers: Headers{"alg": "dummy"}, err: errors.New("signer error")}})
+		require.EqualError(t, err, "sign JWS with signer 1: sign JWS verification data: signer error")
+		require.Nil(t, jws)
+
+		jws, err = NewMultiSignatureJWS(payload,
+			JWSSigner{Signer: &testSigner{headers: Headers{"alg": "dummy"}}},
+			JWSSigner{Signer: &testSigner{headers: Headers{"alg": "dummy", "b64": false}}})
+		require.EqualError(t, err, "JWS signatures have different b64 header values")
+		require.Nil(t, jws)
+	})
+}
+
+func TestParseJWS_JSONVerificationPolicy(t *testing.T) {
+	payload := []byte("payload")
+	alice, bob, eve := newEd25519TestSigner(t, "alice"), newEd25519TestSigner(t, "bob"), newEd25519TestSigner(t, "eve")
+
+	jws, err := NewMultiSignatureJWS(payload, JWSSigner{Signer: alice}, JWSSigner{Signer: eve}, JWSSigner{Signer: bob})
+	require.NoError(t, err)
+
+	jwsJSON, err := jws.SerializeJSON(false)
+	require.NoError(t, err)
+
+	// eve's key is unknown to the verifier.
+	verifier := newEd25519TestVerifier(alice, bob)
+
+	t.Run("all signatures must be valid by default", func(t *testing.T) {
+		parsedJWS, err := ParseJWS(jwsJSON, verifier)
+		require.EqualError(t, err, "verify JWS signature 1: unknown key eve")
+		require.Nil(t, parsedJWS)
+
+		parsedJWS, err = ParseJWS(jwsJSON, verifier, WithJWSVerificationPolicy(VerifyAllSignatures))
+		require.EqualError(t, err, "verify JWS signature 1: unknown key eve")
+		require.Nil(t, parsedJWS)
+	})
+
+	t.Run("any valid signature", func(t *testing.T) {
+		parsedJWS, err := ParseJWS(jwsJSON, verifier, WithJWSVerificationPolicy(VerifyAnySignature))
+		require.NoError(t, err)
+
+		signatures := parsedJWS.Signatures()
+		require.Len(t, signatures, 2)
+		require.Equal(t, "alice", signatures[0].ProtectedHeaders["kid"])
+		require.Equal(t, "bob", signatures[1].ProtectedHeaders["kid"])
+
+		parsedJWS, err = ParseJWS(jwsJSON, newEd25519TestVerifier(), WithJWSVerificationPolicy(VerifyAnySignature))
+		require.EqualError(t, err, "no valid JWS signature: signature 0: unknown key alice; "+
+			"signature 1: unknown key eve; signature 2: unknown key bob")
+		require.Nil(t, parsedJWS)
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		tampered := strings.Replace(jwsJSON, base64.RawURLEncoding.EncodeToString(payload),
+			base64.RawURLEncoding.EncodeToString([]byte("tampered")), 1)
+
+		parsedJWS, err := ParseJWS(tampered, newEd25519TestVerifier(alice, bob, eve),
+			WithJWSVerificationPolicy(VerifyAnySignature))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no valid JWS signature")
+		require.Nil(t, parsedJWS)
+	})
+}
+
+func TestParseJWS_JSON(t *testing.T) {
+	validHeaders := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"dummy"}`))
+	emptyHeaders := base64.RawURLEncoding.EncodeToString([]byte("{}"))
+	b64FalseHeaders := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"dummy","b64":false}`))
+	corruptedBased64 := "XXXXXaGVsbG8="
+
+	tests := []struct {
+		name string
+		jws  string
+		err  string
+	}{
+		{
+			name: "invalid JSON",
+			jws:  "{",
+			err:  "unmarshal JWS JSON",
+		},
+		{
+			name: "mixed general and flattened syntax",
+			jws:  fmt.Sprintf(`{"signatures":[{"protected":"%s","signature":""}],"signature":"c2ln"}`, validHeaders),
+			err:  "invalid JWS JSON serialization: mixed general and flattened syntax",
+		},
+		{
+			name: "corrupted protected headers",
+			jws:  fmt.Sprintf(`{"payload":"","protected":"%s","signature":"c2ln"}`, corruptedBased64),
+			err:  "parse JWS signature 0: decode base64 header",
+		},
+		{
+			name: "invalid protected headers",
+			jws:  `{"payload":"","protected":"aW52YWxpZA","signature":"c2ln"}`,
+			err:  "parse JWS signature 0: unmarshal JSON headers",
+		},
+		{
+			name: "no alg",
+			jws:  fmt.Sprintf(`{"signatures":[{"protected":"%s","signature":"c2ln"}]}`, emptyHeaders),
+			err:  "parse JWS signature 0: alg JWS header is not defined",
+		},
+		{
+			name: "alg both protected and unprotected",
+			jws:  fmt.Sprintf(`{"protected":"%s","header":{"alg":"dummy"},"signature":"c2ln"}`, validHeaders),
+			err:  "parse JWS signature 0: alg JWS header is both protected and unprotected",
+		},
+		{
+			name: "corrupted signature",
+			jws:  fmt.Sprintf(`{"header":{"alg":"dummy"},"signature":"%s"}`, corruptedBased64),
+			err:  "parse JWS signature 0: decode base64 signature",
+		},
+		{
+			name: "corrupted payload",
+			jws:  fmt.Sprintf(`{"payload":"%s","protected":"%s","signature":"c2ln"}`, corruptedBased64, validHeaders),
+			err:  "decode base64 payload",
+		},
+		{
+			name: "different payload encodings",
+			jws: fmt.Sprintf(`{"signatures":[{"protected":"%s","signature":"c2ln"},{"protected":"%s","signature":"c2ln"}]}`,
+				validHeaders, b64FalseHeaders),
+			err: "JWS signatures have different b64 header values",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			jws, err := ParseJWS(tc.jws, &testVerifier{})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+			require.Nil(t, jws)
+		})
+	}
+
+	t.Run("unprotected headers only", func(t *testing.T) {
+		jws, err := ParseJWS(`{"payload":"cGF5bG9hZA","header":{"alg":"dummy"},"signature":"c2ln"}`, &testVerifier{})
+		require.NoError(t, err)
+		require.Equal(t, []byte("payload"), jws.Payload)
+		require.Empty(t, jws.ProtectedHeaders)
+		require.Equal(t, Headers{"alg": "dummy"}, jws.UnprotectedHeaders)
+
+		jwsJSON, err := jws.SerializeFlattenedJSON(false)
+		require.NoError(t, err)
+		require.Equal(t, `{"payload":"cGF5bG9hZA","header":{"alg":"dummy"},"signature":"c2ln"}`, jwsJSON)
+	})
+}
+
+type ed25519TestSigner struct {
+	kid     string
+	privKey ed25519.PrivateKey
+}
+
+func newEd25519TestSigner(t *testing.T, kid string) *ed25519TestSigner {
+	t.Helper()
+
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return &ed25519TestSigner{kid: kid, privKey: privKey}
+}
+
+func (s *ed25519TestSigner) Sign(data []byte) ([]byte, error) {
+	return ed25519.Sign(s.privKey, data), nil
+}
+
+func (s *ed25519TestSigner) Headers() Headers {
+	return Headers{"alg": "EdDSA", "kid": s.kid}
+}
+
+func newEd25519TestVerifier(signers ...*ed25519TestSigner) SignatureVerifier {
+	pubKeys := make(map[string]ed25519.PublicKey, len(signers))
+
+	for _, s := range signers {
+		pubKeys[s.kid] = s.privKey.Public().(ed25519.PublicKey)
+	}
+
+	return SignatureVerifierFunc(func(joseHeaders Headers, _, signingInput, signature []byte) error {
+		kid, _ := joseHeaders.KeyID()
+
+		pubKey, ok := pubKeys[kid]
+		if !ok {
+			return fmt.Errorf("unknown key %s", kid)
+		}
+
+		if !ed25519.Verify(pubKey, signingInput, signature) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	})
+}
@@ -191,10 +191,10 @@ func TestParseJWS(t *testing.T) {
 	require.NotNil(t, parsedJWS)
 	require.Equal(t, jws, parsedJWS)
 
-	// Parse not compact JWS format
+	// Parse invalid JWS JSON format
 	parsedJWS, err = ParseJWS(`{"some": "JSON"}`, &testVerifier{})
 	require.Error(t, err)
-	require.EqualError(t, err, "JWS JSON serialization is not supported")
+	require.EqualError(t, err, "invalid JWS JSON serialization: no signature")
 	require.Nil(t, parsedJWS)
 
 	// Parse invalid compact JWS format
@@ -52,6 +52,8 @@ var (
 	ed255192020 []byte
 	//go:embed third_party/w3c-ccg.github.io/revocationList2021.jsonld
 	revocationList2021 []byte
+	//go:embed third_party/w3c.github.io/data-integrity-v1.jsonld
+	dataIntegrity []byte
 )
 
 // Contexts contains JSON-LD contexts embedded into a Go binary.
@@ -161,4 +163,9 @@ var Contexts = []ldcontext.Document{ //nolint:gochecknoglobals
 		DocumentURL: "https://digitalbazaar.github.io/ed25519-signature-2020-context/contexts/ed25519-signature-2020-v1.jsonld", //nolint: lll
 		Content:     ed255192020,
 	},
+	{
+		URL:         "https://w3id.org/security/data-integrity/v1",
+		DocumentURL: "https://w3c.github.io/vc-data-integrity/contexts/data-integrity/v1",
+		Content:     dataIntegrity,
+	},
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 )
 
@@ -711,8 +712,10 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 	credential *verifiable.Credential, opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
 	var (
 		BBSSupport          = hasBBS(credential)
+		SDJWTSupport        = credential.SDJWTHashAlg != ""
 		modifiedByPredicate bool
 		explicitPaths       = make(map[string]bool)
+		disclosedPaths      []string
 	)
 
 	for _, f := range constraints.Fields {
@@ -760,6 +763,10 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 				explicitPaths[explicitPath] = true
 			}
 
+			if constraints.LimitDisclosure.isRequired() && SDJWTSupport {
+				disclosedPaths = append(disclosedPaths, path[1])
+			}
+
 			limitedCred, err = sjson.SetBytes(limitedCred, path[0], val)
 			if err != nil {
 				return nil, err
@@ -767,6 +774,10 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 		}
 	}
 
+	if constraints.LimitDisclosure.isRequired() && SDJWTSupport && !modifiedByPredicate {
+		return limitSDJWTDisclosures(credential, disclosedPaths, opts...)
+	}
+
 	if !constraints.LimitDisclosure.isRequired() || !BBSSupport || modifiedByPredicate {
 		opts = append(opts, verifiable.WithDisabledProofCheck())
 		return verifiable.ParseCredential(limitedCred, opts...)
@@ -785,6 +796,19 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 	return credential.GenerateBBSSelectiveDisclosure(doc, []byte(uuid.New().String()), opts...)
 }
 
+// limitSDJWTDisclosures creates a credential from the SD-JWT credential with the disclosures of the given paths only.
+func limitSDJWTDisclosures(credential *verifiable.Credential, paths []string,
+	opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
+	limitedCred, err := credential.MarshalWithDisclosure(verifiable.DisclosePaths(paths))
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, verifiable.WithDisabledProofCheck())
+
+	return verifiable.ParseCredential([]byte(limitedCred), opts...)
+}
+
 func enhanceRevealDoc(explicitPaths map[string]bool, limitedCred, vcBytes []byte) ([]byte, error) {
 	var err error
 
@@ -1018,7 +1042,9 @@ func filterFormat(format *Format, credentials []*verifiable.Credential) (string,
 		)
 
 		if credential.JWT != "" {
-			pJWT, err := jwt.Parse(credential.JWT, jwt.WithSignatureVerifier(&noVerifier{}))
+			// for SD-JWT credentials, the algorithm is taken from the issuer-signed JWT.
+			pJWT, err := jwt.Parse(sdjwt.ParseCombinedFormat(credential.JWT).SDJWT,
+				jwt.WithSignatureVerifier(&noVerifier{}))
 			if err != nil {
 				logger.Warnf("unmarshal credential error: %w", err)
 
@@ -583,6 +583,90 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 		checkVP(t, vp)
 	})
 
+	t.Run("Limit disclosure SD-JWT", func(t *testing.T) {
+		required := Required
+
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					LimitDisclosure: &required,
+					Fields: []*Field{{
+						Path:   []string{"$.credentialSubject.degree.degreeSchool"},
+						Filter: &Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		vc := &verifiable.Credential{
+			ID: "https://issuer.oidp.uscis.gov/credentials/83627465",
+			Context: []string{
+				verifiable.ContextURI,
+				"https://www.w3.org/2018/credentials/examples/v1",
+			},
+			Types: []string{
+				"VerifiableCredential",
+				"UniversityDegreeCredential",
+			},
+			Subject: verifiable.Subject{
+				ID: "did:example:b34ca6cd37bbf23",
+				CustomFields: map[string]interface{}{
+					"name":   "Jayden Doe",
+					"spouse": "did:example:c276e12ec21ebfeb1f712ebc6f1",
+					"degree": map[string]interface{}{
+						"degree":       "MIT",
+						"degreeSchool": "MIT school",
+						"type":         "BachelorDegree",
+					},
+				},
+			},
+			Issued: &util.TimeWrapper{
+				Time: time.Now(),
+			},
+			Issuer: verifiable.Issuer{
+				ID: "did:example:489398593",
+			},
+		}
+
+		signer, err := signature.NewSigner(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		combined, err := vc.MakeSDJWT(verifiable.ECDSASecp256r1, signer, "did:example:489398593#key1",
+			verifiable.MakeSDJWTWithStructuredClaims(true))
+		require.NoError(t, err)
+
+		sdVC, err := verifiable.ParseCredential([]byte(combined), verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{sdVC}, lddl,
+			verifiable.WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+		require.Equal(t, 1, len(vp.Credentials()))
+
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+
+		// degree and degree.degreeSchool.
+		require.Len(t, vc.SDJWTDisclosures, 2)
+
+		subject := vc.Subject.([]verifiable.Subject)[0]
+		require.Equal(t, "did:example:b34ca6cd37bbf23", subject.ID)
+		require.Equal(t, map[string]interface{}{"degreeSchool": "MIT school"}, subject.CustomFields["degree"])
+		require.Empty(t, subject.CustomFields["name"])
+		require.Empty(t, subject.CustomFields["spouse"])
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+	})
+
 	t.Run("Predicate and limit disclosure BBS+ (no proof)", func(t *testing.T) {
 		required := Required
 
@@ -0,0 +1,395 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"crypto"
+	_ "crypto/sha256" // register sha-256 for disclosure digests
+	_ "crypto/sha512" // register sha-384 and sha-512 for disclosure digests
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+// Selective Disclosure for JWTs.
+// Reference: https://datatracker.ietf.org/doc/html/draft-ietf-oauth-selective-disclosure-jwt-08
+const (
+	// SDKey is the claim holding the digests of the selectively disclosable claims of an object.
+	SDKey = "_sd"
+	// SDAlgorithmKey is the claim holding the hash algorithm of the digests.
+	SDAlgorithmKey = "_sd_alg"
+	// ArrayElementDigestKey is the key of the object replacing a selectively disclosable array element.
+	ArrayElementDigestKey = "..."
+	// CNFKey is the confirmation claim holding the holder public key (as "jwk") used for holder binding.
+	CNFKey = "cnf"
+	// JWKKey is the key of the holder public key in the confirmation claim.
+	JWKKey = "jwk"
+
+	// SHA256 is the default hash algorithm of the digests.
+	SHA256 = "sha-256"
+
+	// CombinedFormatSeparator separates the SD-JWT, the disclosures and the key binding JWT.
+	CombinedFormatSeparator = "~"
+	// KeyBindingJWTType is the typ header of the key binding JWT.
+	KeyBindingJWTType = "kb+jwt"
+
+	// NonceKey is the key binding JWT claim holding the nonce of the verifier.
+	NonceKey = "nonce"
+	// AudienceKey is the key binding JWT claim holding the verifier.
+	AudienceKey = "aud"
+	// IssuedAtKey is the key binding JWT claim holding its issuance time.
+	IssuedAtKey = "iat"
+	// SDHashKey is the key binding JWT claim holding the digest of the presented SD-JWT and disclosures.
+	SDHashKey = "sd_hash"
+)
+
+// DisclosureClaim is a decoded disclosure.
+type DisclosureClaim struct {
+	// Disclosure is the base64url encoded disclosure.
+	Disclosure string
+	// Digest is the digest of the disclosure referenced by the SD-JWT.
+	Digest string
+	Salt   string
+	// Name is the name of the disclosed claim, empty for array elements.
+	Name  string
+	Value interface{}
+	// IsArrayElement is true for disclosures of array elements.
+	IsArrayElement bool
+}
+
+// CombinedFormat is an SD-JWT with its disclosures and, when presented, an optional key binding JWT:
+// <SD-JWT>~<Disclosure 1>~...~<Disclosure N>~<optional key binding JWT>.
+type CombinedFormat struct {
+	SDJWT         string
+	Disclosures   []string
+	KeyBindingJWT string
+}
+
+// ParseCombinedFormat splits an SD-JWT in combined format.
+func ParseCombinedFormat(combined string) *CombinedFormat {
+	parts := strings.Split(combined, CombinedFormatSeparator)
+
+	cf := &CombinedFormat{SDJWT: parts[0]}
+
+	if len(parts) == 1 {
+		return cf
+	}
+
+	// Disclosures are never JWS, so the last part is either a key binding JWT, empty or a disclosure of an
+	// SD-JWT serialized without trailing separator.
+	last := parts[len(parts)-1]
+	if jose.IsCompactJWS(last) {
+		cf.KeyBindingJWT = last
+		parts = parts[:len(parts)-1]
+	}
+
+	for _, disclosure := range parts[1:] {
+		if disclosure != "" {
+			cf.Disclosures = append(cf.Disclosures, disclosure)
+		}
+	}
+
+	return cf
+}
+
+// Serialize serializes the SD-JWT in combined format.
+func (cf *CombinedFormat) Serialize() string {
+	parts := append([]string{cf.SDJWT}, cf.Disclosures...)
+
+	return strings.Join(append(parts, cf.KeyBindingJWT), CombinedFormatSeparator)
+}
+
+// IsCombinedFormat checks if s is an SD-JWT in combined format.
+func IsCombinedFormat(s string) bool {
+	return strings.Contains(s, CombinedFormatSeparator) && jose.IsCompactJWS(ParseCombinedFormat(s).SDJWT)
+}
+
+// DecodeDisclosures decodes disclosures and computes their digests with hashAlg (e.g. SHA256).
+func DecodeDisclosures(disclosures []string, hashAlg string) ([]*DisclosureClaim, error) {
+	hash, err := hashFromAlg(hashAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make([]*DisclosureClaim, 0, len(disclosures))
+
+	for _, disclosure := range disclosures {
+		claim, err := decodeDisclosure(disclosure, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+func decodeDisclosure(disclosure string, hash crypto.Hash) (*DisclosureClaim, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(disclosure)
+	if err != nil {
+		return nil, fmt.Errorf("decode disclosure: %w", err)
+	}
+
+	var elements []interface{}
+
+	err = json.Unmarshal(decoded, &elements)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal disclosure: %w", err)
+	}
+
+	claim := &DisclosureClaim{Disclosure: disclosure, Digest: digest(disclosure, hash)}
+
+	var ok bool
+
+	switch len(elements) {
+	case 2: // nolint:gomnd // [salt, value] of an array element
+		claim.IsArrayElement = true
+		claim.Value = elements[1]
+	case 3: // nolint:gomnd // [salt, name, value] of an object property
+		claim.Name, ok = elements[1].(string)
+		if !ok {
+			return nil, errors.New("disclosure claim name is not a string")
+		}
+
+		if claim.Name == SDKey || claim.Name == ArrayElementDigestKey {
+			return nil, fmt.Errorf("disclosure claim name %s is reserved", claim.Name)
+		}
+
+		claim.Value = elements[2]
+	default:
+		return nil, errors.New("disclosure must have 2 or 3 elements")
+	}
+
+	claim.Salt, ok = elements[0].(string)
+	if !ok {
+		return nil, errors.New("disclosure salt is not a string")
+	}
+
+	return claim, nil
+}
+
+func digest(disclosure string, hash crypto.Hash) string {
+	h := hash.New()
+	h.Write([]byte(disclosure)) // nolint:errcheck // hash writes never fail
+
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+func hashFromAlg(alg string) (crypto.Hash, error) {
+	switch alg {
+	case SHA256:
+		return crypto.SHA256, nil
+	case "sha-384":
+		return crypto.SHA384, nil
+	case "sha-512":
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported %s %s", SDAlgorithmKey, alg)
+	}
+}
+
+func hashAlgFromClaims(claims map[string]interface{}) (string, error) {
+	alg, ok := claims[SDAlgorithmKey]
+	if !ok {
+		return SHA256, nil
+	}
+
+	algStr, ok := alg.(string)
+	if !ok {
+		return "", fmt.Errorf("%s is not a string", SDAlgorithmKey)
+	}
+
+	return algStr, nil
+}
+
+// DisclosedClaims returns claims, an SD-JWT payload, where the digests of the given disclosures are replaced by
+// the disclosed claims and the other digests are removed. Every disclosure must be referenced once by the claims or
+// by another disclosure.
+func DisclosedClaims(claims map[string]interface{}, disclosures []*DisclosureClaim) (map[string]interface{}, error) {
+	disclosed, _, err := disclose(claims, disclosures)
+
+	return disclosed, err
+}
+
+// DisclosurePaths returns the path of each disclosure in the disclosed claims, keyed by disclosure digest. Paths
+// are keys and array indexes separated by dots, e.g. "vc.credentialSubject.degree.type".
+func DisclosurePaths(claims map[string]interface{}, disclosures []*DisclosureClaim) (map[string]string, error) {
+	_, paths, err := disclose(claims, disclosures)
+
+	return paths, err
+}
+
+type discloser struct {
+	byDigest map[string]*DisclosureClaim
+	paths    map[string]string
+}
+
+func disclose(claims map[string]interface{},
+	disclosures []*DisclosureClaim) (map[string]interface{}, map[string]string, error) {
+	d := &discloser{byDigest: map[string]*DisclosureClaim{}, paths: map[string]string{}}
+
+	for _, disclosure := range disclosures {
+		if _, ok := d.byDigest[disclosure.Digest]; ok {
+			return nil, nil, fmt.Errorf("duplicate disclosure %s", disclosure.Disclosure)
+		}
+
+		d.byDigest[disclosure.Digest] = disclosure
+	}
+
+	result, err := d.discloseObject(claims, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	delete(result, SDAlgorithmKey)
+
+	for _, disclosure := range disclosures {
+		if _, ok := d.paths[disclosure.Digest]; !ok {
+			return nil, nil, fmt.Errorf("disclosure %s is not referenced by the SD-JWT", disclosure.Disclosure)
+		}
+	}
+
+	return result, d.paths, nil
+}
+
+func (d *discloser) discloseObject(obj map[string]interface{}, path string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(obj))
+
+	for key, value := range obj {
+		if key == SDKey {
+			continue
+		}
+
+		disclosed, err := d.discloseValue(value, joinPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = disclosed
+	}
+
+	digests, ok := obj[SDKey]
+	if !ok {
+		return result, nil
+	}
+
+	digestList, ok := digests.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an array", SDKey)
+	}
+
+	for _, dig := range digestList {
+		digStr, ok := dig.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s contains a digest that is not a string", SDKey)
+		}
+
+		disclosure, ok := d.byDigest[digStr]
+		if !ok {
+			continue // not disclosed or decoy
+		}
+
+		if disclosure.IsArrayElement {
+			return nil, fmt.Errorf("array element disclosure %s is referenced as an object property",
+				disclosure.Disclosure)
+		}
+
+		if _, exists := result[disclosure.Name]; exists {
+			return nil, fmt.Errorf("disclosed claim %s already exists", disclosure.Name)
+		}
+
+		value, err := d.reference(disclosure, joinPath(path, disclosure.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		result[disclosure.Name] = value
+	}
+
+	return result, nil
+}
+
+func (d *discloser) discloseArray(arr []interface{}, path string) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(arr))
+
+	for _, element := range arr {
+		elementPath := joinPath(path, strconv.Itoa(len(result)))
+
+		obj, ok := element.(map[string]interface{})
+		if !ok || len(obj) != 1 || obj[ArrayElementDigestKey] == nil {
+			disclosed, err := d.discloseValue(element, elementPath)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, disclosed)
+
+			continue
+		}
+
+		digStr, ok := obj[ArrayElementDigestKey].(string)
+		if !ok {
+			return nil, errors.New("array element digest is not a string")
+		}
+
+		disclosure, ok := d.byDigest[digStr]
+		if !ok {
+			continue // not disclosed or decoy
+		}
+
+		if !disclosure.IsArrayElement {
+			return nil, fmt.Errorf("object property disclosure %s is referenced as an array element",
+				disclosure.Disclosure)
+		}
+
+		value, err := d.reference(disclosure, elementPath)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+func (d *discloser) discloseValue(value interface{}, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return d.discloseObject(v, path)
+	case []interface{}:
+		return d.discloseArray(v, path)
+	default:
+		return value, nil
+	}
+}
+
+// reference marks disclosure as referenced at path and returns its disclosed value, which may contain digests of
+// nested disclosures.
+func (d *discloser) reference(disclosure *DisclosureClaim, path string) (interface{}, error) {
+	if _, ok := d.paths[disclosure.Digest]; ok {
+		return nil, fmt.Errorf("disclosure %s is referenced more than once", disclosure.Disclosure)
+	}
+
+	d.paths[disclosure.Digest] = path
+
+	return d.discloseValue(disclosure.Value, path)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
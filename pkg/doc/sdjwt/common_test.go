@@ -0,0 +1,187 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Example disclosure of the specification.
+const (
+	givenNameDisclosure = "WyIyR0xDNDJzS1F2ZUNmR2ZyeU5STjl3IiwgImdpdmVuX25hbWUiLCAiSm9obiJd"
+	givenNameDigest     = "jsu9yVulwQQlhFlM_3JlzMaSFzglhQG0DpfayQwLUK4"
+)
+
+func TestParseCombinedFormat(t *testing.T) {
+	const sdJWT = "eyJhbGciOiJFUzI1NiJ9.eyJfc2QiOltdfQ.c2ln"
+
+	t.Run("test issuance", func(t *testing.T) {
+		cf := ParseCombinedFormat(sdJWT + "~d1~d2~")
+		require.Equal(t, sdJWT, cf.SDJWT)
+		require.Equal(t, []string{"d1", "d2"}, cf.Disclosures)
+		require.Empty(t, cf.KeyBindingJWT)
+		require.Equal(t, sdJWT+"~d1~d2~", cf.Serialize())
+
+		cf = ParseCombinedFormat(sdJWT + "~d1~d2")
+		require.Equal(t, []string{"d1", "d2"}, cf.Disclosures)
+
+		cf = ParseCombinedFormat(sdJWT)
+		require.Equal(t, sdJWT, cf.SDJWT)
+		require.Empty(t, cf.Disclosures)
+	})
+
+	t.Run("test presentation with key binding JWT", func(t *testing.T) {
+		cf := ParseCombinedFormat(sdJWT + "~d1~" + sdJWT)
+		require.Equal(t, []string{"d1"}, cf.Disclosures)
+		require.Equal(t, sdJWT, cf.KeyBindingJWT)
+		require.Equal(t, sdJWT+"~d1~"+sdJWT, cf.Serialize())
+	})
+
+	t.Run("test is combined format", func(t *testing.T) {
+		require.True(t, IsCombinedFormat(sdJWT+"~"))
+		require.False(t, IsCombinedFormat(sdJWT))
+		require.False(t, IsCombinedFormat("abc~d1~"))
+	})
+}
+
+func TestDecodeDisclosures(t *testing.T) {
+	t.Run("test object property", func(t *testing.T) {
+		claims, err := DecodeDisclosures([]string{givenNameDisclosure}, SHA256)
+		require.NoError(t, err)
+		require.Len(t, claims, 1)
+		require.Equal(t, givenNameDigest, claims[0].Digest)
+		require.Equal(t, "2GLC42sKQveCfGfryNRN9w", claims[0].Salt)
+		require.Equal(t, "given_name", claims[0].Name)
+		require.Equal(t, "John", claims[0].Value)
+		require.False(t, claims[0].IsArrayElement)
+	})
+
+	t.Run("test array element", func(t *testing.T) {
+		claims, err := DecodeDisclosures([]string{encode(`["salt", "DE"]`)}, SHA256)
+		require.NoError(t, err)
+		require.True(t, claims[0].IsArrayElement)
+		require.Equal(t, "DE", claims[0].Value)
+	})
+
+	t.Run("error - invalid disclosures", func(t *testing.T) {
+		for _, tc := range []struct {
+			disclosure string
+			err        string
+		}{
+			{disclosure: "!", err: "decode disclosure"},
+			{disclosure: encode(`{}`), err: "unmarshal disclosure"},
+			{disclosure: encode(`["salt"]`), err: "disclosure must have 2 or 3 elements"},
+			{disclosure: encode(`[1, "name", "value"]`), err: "disclosure salt is not a string"},
+			{disclosure: encode(`["salt", 1, "value"]`), err: "disclosure claim name is not a string"},
+			{disclosure: encode(`["salt", "_sd", "value"]`), err: "disclosure claim name _sd is reserved"},
+		} {
+			_, err := DecodeDisclosures([]string{tc.disclosure}, SHA256)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+
+		_, err := DecodeDisclosures([]string{givenNameDisclosure}, "md5")
+		require.EqualError(t, err, "unsupported _sd_alg md5")
+	})
+}
+
+func TestDisclosedClaims(t *testing.T) {
+	nationality := encode(`["salt", "DE"]`)
+	address := encode(`["salt", "address", {"_sd": ["` + digestOf(t, encode(`["salt", "country", "DE"]`)) + `"]}]`)
+	country := encode(`["salt", "country", "DE"]`)
+
+	claims := map[string]interface{}{
+		"iss":          "https://example.com/issuer",
+		SDAlgorithmKey: SHA256,
+		SDKey:          []interface{}{givenNameDigest, digestOf(t, address), "decoy"},
+		"nationalities": []interface{}{
+			map[string]interface{}{ArrayElementDigestKey: digestOf(t, nationality)},
+			map[string]interface{}{ArrayElementDigestKey: "undisclosed"},
+			"US",
+		},
+	}
+
+	t.Run("test all disclosed", func(t *testing.T) {
+		disclosures, err := DecodeDisclosures([]string{givenNameDisclosure, nationality, address, country}, SHA256)
+		require.NoError(t, err)
+
+		disclosed, err := DisclosedClaims(claims, disclosures)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"iss":           "https://example.com/issuer",
+			"given_name":    "John",
+			"nationalities": []interface{}{"DE", "US"},
+			"address":       map[string]interface{}{"country": "DE"},
+		}, disclosed)
+
+		paths, err := DisclosurePaths(claims, disclosures)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			givenNameDigest:          "given_name",
+			digestOf(t, nationality): "nationalities.0",
+			digestOf(t, address):     "address",
+			digestOf(t, country):     "address.country",
+		}, paths)
+	})
+
+	t.Run("test none disclosed", func(t *testing.T) {
+		disclosed, err := DisclosedClaims(claims, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"iss":           "https://example.com/issuer",
+			"nationalities": []interface{}{"US"},
+		}, disclosed)
+	})
+
+	t.Run("error - invalid disclosures", func(t *testing.T) {
+		disclosures, err := DecodeDisclosures([]string{givenNameDisclosure, givenNameDisclosure}, SHA256)
+		require.NoError(t, err)
+
+		_, err = DisclosedClaims(claims, disclosures)
+		require.EqualError(t, err, "duplicate disclosure "+givenNameDisclosure)
+
+		disclosures, err = DecodeDisclosures([]string{country}, SHA256)
+		require.NoError(t, err)
+
+		_, err = DisclosedClaims(claims, disclosures)
+		require.EqualError(t, err, "disclosure "+country+" is not referenced by the SD-JWT")
+
+		disclosures, err = DecodeDisclosures([]string{givenNameDisclosure}, SHA256)
+		require.NoError(t, err)
+
+		_, err = DisclosedClaims(map[string]interface{}{
+			"given_name": "Jane",
+			SDKey:        []interface{}{givenNameDigest},
+		}, disclosures)
+		require.EqualError(t, err, "disclosed claim given_name already exists")
+
+		_, err = DisclosedClaims(map[string]interface{}{
+			"names": []interface{}{map[string]interface{}{ArrayElementDigestKey: givenNameDigest}},
+		}, disclosures)
+		require.EqualError(t, err, "object property disclosure "+givenNameDisclosure+
+			" is referenced as an array element")
+
+		_, err = DisclosedClaims(map[string]interface{}{SDKey: "digest"}, disclosures)
+		require.EqualError(t, err, "_sd is not an array")
+	})
+}
+
+func encode(disclosure string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(disclosure))
+}
+
+func digestOf(t *testing.T, disclosure string) string {
+	t.Helper()
+
+	claims, err := DecodeDisclosures([]string{disclosure}, SHA256)
+	require.NoError(t, err)
+
+	return claims[0].Digest
+}
@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/square/go-jose/v3/json"
+	josejwt "github.com/square/go-jose/v3/jwt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+// BindingPayload is the payload of the key binding JWT.
+type BindingPayload struct {
+	Nonce    string               `json:"nonce,omitempty"`
+	Audience string               `json:"aud,omitempty"`
+	IssuedAt *josejwt.NumericDate `json:"iat,omitempty"`
+}
+
+// BindingInfo defines the key binding JWT of a presentation, signed by the holder.
+type BindingInfo struct {
+	Payload BindingPayload
+	Signer  jose.Signer
+	Headers jose.Headers
+}
+
+// presentOpts holds options for creating SD-JWT presentations.
+type presentOpts struct {
+	holderBinding *BindingInfo
+}
+
+// PresentOpt is the SD-JWT presentation option.
+type PresentOpt func(opts *presentOpts)
+
+// WithHolderBinding option adds a key binding JWT to the presentation.
+func WithHolderBinding(info *BindingInfo) PresentOpt {
+	return func(opts *presentOpts) {
+		opts.holderBinding = info
+	}
+}
+
+// Parse parses an SD-JWT in combined format and returns its decoded disclosures, so that the holder can choose
+// the disclosures of a presentation. The signature of the SD-JWT is checked by verifier.
+func Parse(combinedFormatForIssuance string, verifier jose.SignatureVerifier) ([]*DisclosureClaim, error) {
+	cf := ParseCombinedFormat(combinedFormatForIssuance)
+
+	token, err := jwt.Parse(cf.SDJWT, jwt.WithSignatureVerifier(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	hashAlg, err := hashAlgFromClaims(token.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	disclosures, err := DecodeDisclosures(cf.Disclosures, hashAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = DisclosedClaims(token.Payload, disclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	return disclosures, nil
+}
+
+// CreatePresentation creates a presentation of an SD-JWT in combined format with the given disclosures only.
+func CreatePresentation(combinedFormatForIssuance string, disclosures []string, opts ...PresentOpt) (string, error) {
+	pOpts := &presentOpts{}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	issued := ParseCombinedFormat(combinedFormatForIssuance)
+
+	for _, disclosure := range disclosures {
+		if !contains(issued.Disclosures, disclosure) {
+			return "", errors.New("disclosure is not issued with the SD-JWT")
+		}
+	}
+
+	cf := &CombinedFormat{SDJWT: issued.SDJWT, Disclosures: disclosures}
+
+	if pOpts.holderBinding == nil {
+		return cf.Serialize(), nil
+	}
+
+	keyBindingJWT, err := createKeyBindingJWT(cf, pOpts.holderBinding)
+	if err != nil {
+		return "", err
+	}
+
+	cf.KeyBindingJWT = keyBindingJWT
+
+	return cf.Serialize(), nil
+}
+
+func createKeyBindingJWT(cf *CombinedFormat, info *BindingInfo) (string, error) {
+	if info.Signer == nil {
+		return "", errors.New("holder binding signer is not defined")
+	}
+
+	sdHash, err := presentationDigest(cf)
+	if err != nil {
+		return "", err
+	}
+
+	bindingPayload := info.Payload
+
+	if bindingPayload.IssuedAt == nil {
+		bindingPayload.IssuedAt = josejwt.NewNumericDate(time.Now())
+	}
+
+	payload, err := toMap(bindingPayload)
+	if err != nil {
+		return "", fmt.Errorf("convert key binding JWT payload to map: %w", err)
+	}
+
+	payload[SDHashKey] = sdHash
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshal key binding JWT payload: %w", err)
+	}
+
+	headers := jose.Headers{}
+
+	for k, v := range info.Headers {
+		headers[k] = v
+	}
+
+	headers[jose.HeaderType] = KeyBindingJWTType
+
+	jws, err := jose.NewJWS(headers, nil, payloadBytes, info.Signer)
+	if err != nil {
+		return "", fmt.Errorf("create key binding JWT: %w", err)
+	}
+
+	return jws.SerializeCompact(false)
+}
+
+// presentationDigest returns the digest of the SD-JWT and disclosures of a presentation, i.e. of the combined
+// format without key binding JWT, using the hash algorithm of the SD-JWT.
+func presentationDigest(cf *CombinedFormat) (string, error) {
+	token, err := jwt.Parse(cf.SDJWT, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return "", fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	hashAlg, err := hashAlgFromClaims(token.Payload)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := hashFromAlg(hashAlg)
+	if err != nil {
+		return "", err
+	}
+
+	presented := &CombinedFormat{SDJWT: cf.SDJWT, Disclosures: cf.Disclosures}
+
+	return digest(presented.Serialize(), hash), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// noVerifier is used to read SD-JWT claims that were already verified or are verified later on.
+type noVerifier struct{}
+
+func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
+	return nil
+}
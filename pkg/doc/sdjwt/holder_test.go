@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCreatePresentation(t *testing.T) {
+	issuerSigner, _ := newTestSigner(t, kms.ED25519Type)
+
+	sdJWT, err := New(issuerID, map[string]interface{}{"given_name": "John"}, nil, issuerSigner)
+	require.NoError(t, err)
+
+	combined, err := sdJWT.Serialize(false)
+	require.NoError(t, err)
+
+	_, err = CreatePresentation(combined, []string{givenNameDisclosure})
+	require.EqualError(t, err, "disclosure is not issued with the SD-JWT")
+
+	_, err = CreatePresentation(combined, nil, WithHolderBinding(&BindingInfo{}))
+	require.EqualError(t, err, "holder binding signer is not defined")
+}
+
+func TestParse(t *testing.T) {
+	issuerSigner, issuerVerifier := newTestSigner(t, kms.ED25519Type)
+
+	sdJWT, err := New(issuerID, map[string]interface{}{"given_name": "John"}, nil, issuerSigner)
+	require.NoError(t, err)
+
+	combined, err := sdJWT.Serialize(false)
+	require.NoError(t, err)
+
+	disclosures, err := Parse(combined, issuerVerifier)
+	require.NoError(t, err)
+	require.Len(t, disclosures, 1)
+	require.Equal(t, "given_name", disclosures[0].Name)
+
+	_, err = Parse(combined+givenNameDisclosure, issuerVerifier)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not referenced by the SD-JWT")
+
+	_, otherVerifier := newTestSigner(t, kms.ED25519Type)
+
+	_, err = Parse(combined, otherVerifier)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parse SD-JWT")
+}
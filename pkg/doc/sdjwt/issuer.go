@@ -0,0 +1,341 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/square/go-jose/v3/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const saltSize = 16
+
+// registeredClaims are never selectively disclosable, as they are needed to validate the SD-JWT.
+var registeredClaims = []string{"iss", "iat", "nbf", "exp", CNFKey} // nolint:gochecknoglobals
+
+// newOpts holds options for creating SD-JWTs and disclosures.
+type newOpts struct {
+	getSalt         func() (string, error)
+	hashAlg         string
+	structured      bool
+	nonSDClaims     map[string]bool
+	holderPublicKey *jwk.JWK
+}
+
+// NewOpt is the SD-JWT creation option.
+type NewOpt func(opts *newOpts)
+
+// WithSaltFnc option is for definition of the salt generator of the disclosures.
+func WithSaltFnc(fnc func() (string, error)) NewOpt {
+	return func(opts *newOpts) {
+		opts.getSalt = fnc
+	}
+}
+
+// WithHashAlgorithm option is for definition of the hash algorithm of the digests (SHA256 by default).
+func WithHashAlgorithm(alg string) NewOpt {
+	return func(opts *newOpts) {
+		opts.hashAlg = alg
+	}
+}
+
+// WithStructuredClaims option makes the properties of nested objects and the elements of arrays selectively
+// disclosable one by one. By default, a nested object or an array is disclosed as a whole.
+func WithStructuredClaims(structured bool) NewOpt {
+	return func(opts *newOpts) {
+		opts.structured = structured
+	}
+}
+
+// WithNonSelectivelyDisclosableClaims option is for definition of the claims which are always disclosed,
+// given as paths of keys separated by dots (e.g. "vc.credentialSubject.id").
+func WithNonSelectivelyDisclosableClaims(paths []string) NewOpt {
+	return func(opts *newOpts) {
+		for _, path := range paths {
+			opts.nonSDClaims[path] = true
+		}
+	}
+}
+
+// WithHolderPublicKey option adds the holder public key to the "cnf" claim, so that presentations must be bound to
+// the holder by a key binding JWT.
+func WithHolderPublicKey(key *jwk.JWK) NewOpt {
+	return func(opts *newOpts) {
+		opts.holderPublicKey = key
+	}
+}
+
+func getNewOpts(opts []NewOpt) *newOpts {
+	nOpts := &newOpts{
+		getSalt:     generateSalt,
+		hashAlg:     SHA256,
+		nonSDClaims: map[string]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(nOpts)
+	}
+
+	return nOpts
+}
+
+// SelectiveDisclosureJWT is an issued SD-JWT with all its disclosures.
+type SelectiveDisclosureJWT struct {
+	SignedJWT   *jwt.JSONWebToken
+	Disclosures []string
+}
+
+// Serialize serializes the SD-JWT with its disclosures in combined format.
+func (j *SelectiveDisclosureJWT) Serialize(detached bool) (string, error) {
+	signedJWT, err := j.SignedJWT.Serialize(detached)
+	if err != nil {
+		return "", fmt.Errorf("serialize SD-JWT: %w", err)
+	}
+
+	cf := &CombinedFormat{SDJWT: signedJWT, Disclosures: j.Disclosures}
+
+	return cf.Serialize(), nil
+}
+
+// New creates an SD-JWT of issuer where the claims, except for "iss", "iat", "nbf", "exp" and "cnf", are
+// selectively disclosable.
+func New(issuer string, claims interface{}, headers jose.Headers, signer jose.Signer,
+	opts ...NewOpt) (*SelectiveDisclosureJWT, error) {
+	nOpts := getNewOpts(append([]NewOpt{WithNonSelectivelyDisclosableClaims(registeredClaims)}, opts...))
+
+	claimsMap, err := toMap(claims)
+	if err != nil {
+		return nil, fmt.Errorf("convert claims to map: %w", err)
+	}
+
+	payload, disclosures, err := createDisclosures(claimsMap, nOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	payload[SDAlgorithmKey] = nOpts.hashAlg
+
+	if issuer != "" {
+		payload["iss"] = issuer
+	}
+
+	if nOpts.holderPublicKey != nil {
+		payload[CNFKey] = map[string]interface{}{JWKKey: nOpts.holderPublicKey}
+	}
+
+	signedJWT, err := jwt.NewSigned(payload, headers, signer)
+	if err != nil {
+		return nil, fmt.Errorf("create SD-JWT: %w", err)
+	}
+
+	sdJWT := &SelectiveDisclosureJWT{SignedJWT: signedJWT}
+
+	for _, disclosure := range disclosures {
+		sdJWT.Disclosures = append(sdJWT.Disclosures, disclosure.Disclosure)
+	}
+
+	return sdJWT, nil
+}
+
+// CreateDisclosures makes the claims selectively disclosable. It returns the claims where the selectively
+// disclosable claims are replaced by digests, and the disclosures of the replaced claims. The "_sd_alg" claim is
+// not added.
+func CreateDisclosures(claims map[string]interface{}, opts ...NewOpt) (map[string]interface{},
+	[]*DisclosureClaim, error) {
+	return createDisclosures(claims, getNewOpts(opts))
+}
+
+func createDisclosures(claims map[string]interface{}, nOpts *newOpts) (map[string]interface{},
+	[]*DisclosureClaim, error) {
+	hash, err := hashFromAlg(nOpts.hashAlg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &disclosureCreator{opts: nOpts, hash: hash}
+
+	result, err := c.object(claims, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, c.disclosures, nil
+}
+
+type disclosureCreator struct {
+	opts        *newOpts
+	hash        crypto.Hash
+	disclosures []*DisclosureClaim
+}
+
+func (c *disclosureCreator) object(obj map[string]interface{}, path string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+
+	var digests []string
+
+	for key, value := range obj {
+		if key == SDKey || key == ArrayElementDigestKey {
+			return nil, fmt.Errorf("claim name %s is reserved", key)
+		}
+
+		valuePath := joinPath(path, key)
+
+		value, err := c.structure(value, valuePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.opts.nonSDClaims[valuePath] {
+			result[key] = value
+
+			continue
+		}
+
+		disclosure, err := c.disclosure([]interface{}{key, value})
+		if err != nil {
+			return nil, err
+		}
+
+		digests = append(digests, disclosure.Digest)
+	}
+
+	if len(digests) > 0 {
+		// Sorted digests don't reveal the original order of the claims.
+		sort.Strings(digests)
+
+		sd := make([]interface{}, len(digests))
+		for i, d := range digests {
+			sd[i] = d
+		}
+
+		result[SDKey] = sd
+	}
+
+	return result, nil
+}
+
+func (c *disclosureCreator) array(arr []interface{}, path string) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(arr))
+
+	for i, element := range arr {
+		elementPath := joinPath(path, strconv.Itoa(i))
+
+		element, err := c.structure(element, elementPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.opts.nonSDClaims[elementPath] {
+			result = append(result, element)
+
+			continue
+		}
+
+		disclosure, err := c.disclosure([]interface{}{element})
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, map[string]interface{}{ArrayElementDigestKey: disclosure.Digest})
+	}
+
+	return result, nil
+}
+
+// structure makes the properties of an object or the elements of an array selectively disclosable in case of
+// structured claims.
+func (c *disclosureCreator) structure(value interface{}, path string) (interface{}, error) {
+	if !c.opts.structured {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return c.object(v, path)
+	case []interface{}:
+		return c.array(v, path)
+	default:
+		return value, nil
+	}
+}
+
+// disclosure creates a disclosure of [salt, name, value] or [salt, value] given the name and value.
+func (c *disclosureCreator) disclosure(elements []interface{}) (*DisclosureClaim, error) {
+	salt, err := c.opts.getSalt()
+	if err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+
+	disclosure, err := newDisclosure(append([]interface{}{salt}, elements...), c.hash)
+	if err != nil {
+		return nil, err
+	}
+
+	c.disclosures = append(c.disclosures, disclosure)
+
+	return disclosure, nil
+}
+
+func newDisclosure(elements []interface{}, hash crypto.Hash) (*DisclosureClaim, error) {
+	disclosureBytes, err := json.Marshal(elements)
+	if err != nil {
+		return nil, fmt.Errorf("marshal disclosure: %w", err)
+	}
+
+	disclosure := base64.RawURLEncoding.EncodeToString(disclosureBytes)
+
+	return decodeDisclosure(disclosure, hash)
+}
+
+func generateSalt() (string, error) {
+	salt := make([]byte, saltSize)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(salt), nil
+}
+
+func toMap(claims interface{}) (map[string]interface{}, error) {
+	if m, ok := claims.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	claimsBytes, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+
+	d := json.NewDecoder(bytes.NewReader(claimsBytes))
+	d.UseNumber()
+
+	err = d.Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return nil, errors.New("claims are not an object")
+	}
+
+	return m, nil
+}
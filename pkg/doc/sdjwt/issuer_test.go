@@ -0,0 +1,133 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const issuerID = "https://example.com/issuer"
+
+func TestNew(t *testing.T) {
+	signer, sigVerifier := newTestSigner(t, kms.ECDSAP256TypeIEEEP1363)
+
+	claims := map[string]interface{}{
+		"given_name":    "John",
+		"nationalities": []interface{}{"US", "DE"},
+		"address": map[string]interface{}{
+			"street_address": "123 Main St",
+			"country":        "US",
+		},
+	}
+
+	t.Run("test flat claims", func(t *testing.T) {
+		sdJWT, err := New(issuerID, claims, nil, signer)
+		require.NoError(t, err)
+		require.Len(t, sdJWT.Disclosures, 3)
+		require.Equal(t, issuerID, sdJWT.SignedJWT.Payload["iss"])
+		require.Equal(t, SHA256, sdJWT.SignedJWT.Payload[SDAlgorithmKey])
+		require.Len(t, sdJWT.SignedJWT.Payload[SDKey], 3)
+		require.NotContains(t, sdJWT.SignedJWT.Payload, "given_name")
+
+		combined, err := sdJWT.Serialize(false)
+		require.NoError(t, err)
+
+		disclosures, err := Parse(combined, sigVerifier)
+		require.NoError(t, err)
+		require.Len(t, disclosures, 3)
+
+		disclosed, err := Verify(combined, WithSignatureVerifier(sigVerifier))
+		require.NoError(t, err)
+		require.Equal(t, issuerID, disclosed["iss"])
+		require.Equal(t, "John", disclosed["given_name"])
+		require.Equal(t, []interface{}{"US", "DE"}, disclosed["nationalities"])
+		require.Equal(t, "US", disclosed["address"].(map[string]interface{})["country"])
+	})
+
+	t.Run("test structured claims", func(t *testing.T) {
+		sdJWT, err := New(issuerID, claims, nil, signer, WithStructuredClaims(true), WithHashAlgorithm("sha-384"),
+			WithNonSelectivelyDisclosableClaims([]string{"address", "address.country"}))
+		require.NoError(t, err)
+		// given_name, nationalities and its 2 elements, address.street_address.
+		require.Len(t, sdJWT.Disclosures, 5)
+
+		address, ok := sdJWT.SignedJWT.Payload["address"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "US", address["country"])
+		require.Len(t, address[SDKey], 1)
+
+		combined, err := sdJWT.Serialize(false)
+		require.NoError(t, err)
+
+		disclosed, err := Verify(combined, WithSignatureVerifier(sigVerifier))
+		require.NoError(t, err)
+		require.Equal(t, claims["address"], disclosed["address"])
+		require.Equal(t, claims["nationalities"], disclosed["nationalities"])
+	})
+
+	t.Run("test claims struct", func(t *testing.T) {
+		sdJWT, err := New("", &struct {
+			Name string `json:"name"`
+		}{Name: "John"}, jose.Headers{jose.HeaderKeyID: "key-1"}, signer, WithSaltFnc(func() (string, error) {
+			return "salt", nil
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []string{encode(`["salt","name","John"]`)}, sdJWT.Disclosures)
+		require.Equal(t, "key-1", sdJWT.SignedJWT.LookupStringHeader(jose.HeaderKeyID))
+		require.NotContains(t, sdJWT.SignedJWT.Payload, "iss")
+	})
+
+	t.Run("error - create SD-JWT", func(t *testing.T) {
+		_, err := New(issuerID, claims, nil, signer, WithHashAlgorithm("md5"))
+		require.EqualError(t, err, "unsupported _sd_alg md5")
+
+		_, err = New(issuerID, claims, nil, signer, WithSaltFnc(func() (string, error) {
+			return "", errors.New("salt error")
+		}))
+		require.EqualError(t, err, "generate salt: salt error")
+
+		_, err = New(issuerID, map[string]interface{}{SDKey: "digest"}, nil, signer)
+		require.EqualError(t, err, "claim name _sd is reserved")
+
+		_, err = New(issuerID, "claims", nil, signer)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "convert claims to map")
+
+		_, err = New(issuerID, claims, nil, &failingSigner{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create SD-JWT")
+	})
+}
+
+func TestCreateDisclosures(t *testing.T) {
+	sdClaims, disclosures, err := CreateDisclosures(map[string]interface{}{
+		"id":     "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"degree": map[string]interface{}{"type": "BachelorDegree"},
+	}, WithNonSelectivelyDisclosableClaims([]string{"id"}))
+	require.NoError(t, err)
+	require.Len(t, disclosures, 1)
+	require.Equal(t, "degree", disclosures[0].Name)
+	require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", sdClaims["id"])
+	require.Equal(t, []interface{}{disclosures[0].Digest}, sdClaims[SDKey])
+	require.NotContains(t, sdClaims, SDAlgorithmKey)
+}
+
+type failingSigner struct{}
+
+func (s *failingSigner) Sign([]byte) ([]byte, error) {
+	return nil, errors.New("sign error")
+}
+
+func (s *failingSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: "ES256"}
+}
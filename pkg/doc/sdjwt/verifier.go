@@ -0,0 +1,223 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	josejwt "github.com/square/go-jose/v3/jwt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// verifyOpts holds options for the SD-JWT verification.
+type verifyOpts struct {
+	sigVerifier           jose.SignatureVerifier
+	holderBindingRequired bool
+	expectedNonce         string
+	expectedAudience      string
+	leeway                time.Duration
+}
+
+// VerifyOpt is the SD-JWT verifier option.
+type VerifyOpt func(opts *verifyOpts)
+
+// WithSignatureVerifier option is for definition of the verifier of the SD-JWT signature.
+func WithSignatureVerifier(signatureVerifier jose.SignatureVerifier) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.sigVerifier = signatureVerifier
+	}
+}
+
+// WithHolderBindingRequired option requires the presentation to be bound to the holder by a key binding JWT.
+func WithHolderBindingRequired(required bool) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.holderBindingRequired = required
+	}
+}
+
+// WithExpectedNonceForHolderBinding option is for definition of the nonce expected in the key binding JWT.
+func WithExpectedNonceForHolderBinding(nonce string) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.expectedNonce = nonce
+	}
+}
+
+// WithExpectedAudienceForHolderBinding option is for definition of the audience expected in the key binding JWT.
+func WithExpectedAudienceForHolderBinding(audience string) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.expectedAudience = audience
+	}
+}
+
+// WithLeewayForClaimsValidation option is for definition of the leeway of the time claims validation.
+func WithLeewayForClaimsValidation(leeway time.Duration) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.leeway = leeway
+	}
+}
+
+// Verify verifies a presentation of an SD-JWT in combined format: the SD-JWT signature, its time claims, the
+// digests of the presented disclosures and the key binding JWT. It returns the disclosed claims.
+func Verify(combinedFormatForPresentation string, opts ...VerifyOpt) (map[string]interface{}, error) {
+	vOpts := &verifyOpts{leeway: josejwt.DefaultLeeway}
+
+	for _, opt := range opts {
+		opt(vOpts)
+	}
+
+	if vOpts.sigVerifier == nil {
+		return nil, errors.New("signature verifier is not defined")
+	}
+
+	cf := ParseCombinedFormat(combinedFormatForPresentation)
+
+	token, err := jwt.Parse(cf.SDJWT, jwt.WithSignatureVerifier(vOpts.sigVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	err = verifyTimeClaims(token, vOpts.leeway)
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifyHolderBinding(token, cf, vOpts)
+	if err != nil {
+		return nil, fmt.Errorf("verify holder binding: %w", err)
+	}
+
+	hashAlg, err := hashAlgFromClaims(token.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	disclosures, err := DecodeDisclosures(cf.Disclosures, hashAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	return DisclosedClaims(token.Payload, disclosures)
+}
+
+func verifyTimeClaims(token *jwt.JSONWebToken, leeway time.Duration) error {
+	var claims jwt.Claims
+
+	err := token.DecodeClaims(&claims)
+	if err != nil {
+		return fmt.Errorf("decode SD-JWT claims: %w", err)
+	}
+
+	err = josejwt.Claims(claims).ValidateWithLeeway(josejwt.Expected{Time: time.Now()}, leeway)
+	if err != nil {
+		return fmt.Errorf("invalid SD-JWT time claims: %w", err)
+	}
+
+	return nil
+}
+
+func verifyHolderBinding(token *jwt.JSONWebToken, cf *CombinedFormat, vOpts *verifyOpts) error {
+	if cf.KeyBindingJWT == "" {
+		if vOpts.holderBindingRequired {
+			return errors.New("key binding JWT is required")
+		}
+
+		return nil
+	}
+
+	holderKey, err := holderPublicKey(token.Payload)
+	if err != nil {
+		return err
+	}
+
+	holderVerifier, err := jwt.GetVerifier(&verifier.PublicKey{JWK: holderKey})
+	if err != nil {
+		return fmt.Errorf("holder public key verifier: %w", err)
+	}
+
+	jws, err := jose.ParseJWS(cf.KeyBindingJWT, holderVerifier)
+	if err != nil {
+		return fmt.Errorf("parse key binding JWT: %w", err)
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != KeyBindingJWTType {
+		return fmt.Errorf("key binding JWT typ must be %s", KeyBindingJWTType)
+	}
+
+	var payload struct {
+		BindingPayload
+		SDHash string `json:"sd_hash"`
+	}
+
+	err = json.Unmarshal(jws.Payload, &payload)
+	if err != nil {
+		return fmt.Errorf("unmarshal key binding JWT payload: %w", err)
+	}
+
+	return checkBindingPayload(&payload.BindingPayload, payload.SDHash, cf, vOpts)
+}
+
+func checkBindingPayload(payload *BindingPayload, sdHash string, cf *CombinedFormat, vOpts *verifyOpts) error {
+	if payload.IssuedAt == nil {
+		return fmt.Errorf("%s is missing in key binding JWT", IssuedAtKey)
+	}
+
+	if payload.IssuedAt.Time().After(time.Now().Add(vOpts.leeway)) {
+		return errors.New("key binding JWT is issued in the future")
+	}
+
+	if vOpts.expectedNonce != "" && payload.Nonce != vOpts.expectedNonce {
+		return errors.New("nonce value doesn't match")
+	}
+
+	if vOpts.expectedAudience != "" && payload.Audience != vOpts.expectedAudience {
+		return errors.New("audience value doesn't match")
+	}
+
+	expectedSDHash, err := presentationDigest(cf)
+	if err != nil {
+		return err
+	}
+
+	if sdHash != expectedSDHash {
+		return fmt.Errorf("%s doesn't match the presented SD-JWT and disclosures", SDHashKey)
+	}
+
+	return nil
+}
+
+func holderPublicKey(claims map[string]interface{}) (*jwk.JWK, error) {
+	cnf, ok := claims[CNFKey].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s claim is missing in SD-JWT", CNFKey)
+	}
+
+	jwkObj, ok := cnf[JWKKey]
+	if !ok {
+		return nil, fmt.Errorf("%s is missing in %s claim", JWKKey, CNFKey)
+	}
+
+	jwkBytes, err := json.Marshal(jwkObj)
+	if err != nil {
+		return nil, fmt.Errorf("marshal holder public key: %w", err)
+	}
+
+	key := &jwk.JWK{}
+
+	err = key.UnmarshalJSON(jwkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal holder public key: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"testing"
+	"time"
+
+	josejwt "github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	testNonce    = "nonce"
+	testAudience = "https://example.com/verifier"
+)
+
+func TestVerify(t *testing.T) {
+	issuerSigner, issuerVerifier := newTestSigner(t, kms.ECDSAP384TypeIEEEP1363)
+	holderSigner, holderKey := newTestHolder(t)
+
+	sdJWT, err := New(issuerID, map[string]interface{}{
+		"given_name": "John",
+		"last_name":  "Doe",
+	}, nil, issuerSigner, WithHolderPublicKey(holderKey))
+	require.NoError(t, err)
+
+	combined, err := sdJWT.Serialize(false)
+	require.NoError(t, err)
+
+	disclosures, err := Parse(combined, issuerVerifier)
+	require.NoError(t, err)
+
+	givenName := disclosureOf(t, disclosures, "given_name")
+
+	binding := &BindingInfo{
+		Payload: BindingPayload{Nonce: testNonce, Audience: testAudience},
+		Signer:  holderSigner,
+	}
+
+	t.Run("test presentation with holder binding", func(t *testing.T) {
+		presentation, err := CreatePresentation(combined, []string{givenName}, WithHolderBinding(binding))
+		require.NoError(t, err)
+
+		claims, err := Verify(presentation,
+			WithSignatureVerifier(issuerVerifier),
+			WithHolderBindingRequired(true),
+			WithExpectedNonceForHolderBinding(testNonce),
+			WithExpectedAudienceForHolderBinding(testAudience))
+		require.NoError(t, err)
+		require.Equal(t, "John", claims["given_name"])
+		require.NotContains(t, claims, "last_name")
+		require.NotContains(t, claims, SDKey)
+		require.Contains(t, claims, CNFKey)
+	})
+
+	t.Run("test presentation without holder binding", func(t *testing.T) {
+		presentation, err := CreatePresentation(combined, nil)
+		require.NoError(t, err)
+
+		claims, err := Verify(presentation, WithSignatureVerifier(issuerVerifier))
+		require.NoError(t, err)
+		require.NotContains(t, claims, "given_name")
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier), WithHolderBindingRequired(true))
+		require.EqualError(t, err, "verify holder binding: key binding JWT is required")
+	})
+
+	t.Run("error - invalid holder binding", func(t *testing.T) {
+		presentation, err := CreatePresentation(combined, []string{givenName}, WithHolderBinding(binding))
+		require.NoError(t, err)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedNonceForHolderBinding("other"))
+		require.EqualError(t, err, "verify holder binding: nonce value doesn't match")
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedAudienceForHolderBinding("other"))
+		require.EqualError(t, err, "verify holder binding: audience value doesn't match")
+
+		// Disclosures removed after the key binding JWT was signed.
+		cf := ParseCombinedFormat(presentation)
+		cf.Disclosures = nil
+
+		_, err = Verify(cf.Serialize(), WithSignatureVerifier(issuerVerifier))
+		require.EqualError(t, err,
+			"verify holder binding: sd_hash doesn't match the presented SD-JWT and disclosures")
+
+		// Key binding JWT signed by another key.
+		otherSigner, _ := newTestHolder(t)
+
+		other, err := CreatePresentation(combined, []string{givenName}, WithHolderBinding(&BindingInfo{
+			Payload: binding.Payload,
+			Signer:  otherSigner,
+		}))
+		require.NoError(t, err)
+
+		_, err = Verify(other, WithSignatureVerifier(issuerVerifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse key binding JWT")
+
+		future, err := CreatePresentation(combined, nil, WithHolderBinding(&BindingInfo{
+			Payload: BindingPayload{IssuedAt: josejwt.NewNumericDate(time.Now().Add(time.Hour))},
+			Signer:  holderSigner,
+		}))
+		require.NoError(t, err)
+
+		_, err = Verify(future, WithSignatureVerifier(issuerVerifier))
+		require.EqualError(t, err, "verify holder binding: key binding JWT is issued in the future")
+	})
+
+	t.Run("error - key binding JWT without holder public key", func(t *testing.T) {
+		unbound, err := New(issuerID, map[string]interface{}{"given_name": "John"}, nil, issuerSigner)
+		require.NoError(t, err)
+
+		unboundCombined, err := unbound.Serialize(false)
+		require.NoError(t, err)
+
+		presentation, err := CreatePresentation(unboundCombined, nil, WithHolderBinding(binding))
+		require.NoError(t, err)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier))
+		require.EqualError(t, err, "verify holder binding: cnf claim is missing in SD-JWT")
+	})
+
+	t.Run("error - invalid SD-JWT", func(t *testing.T) {
+		_, err := Verify(combined)
+		require.EqualError(t, err, "signature verifier is not defined")
+
+		_, otherVerifier := newTestSigner(t, kms.ECDSAP384TypeIEEEP1363)
+
+		_, err = Verify(combined, WithSignatureVerifier(otherVerifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse SD-JWT")
+
+		_, err = Verify(combined+encode(`["salt", "given_name", "Jane"]`)+"~",
+			WithSignatureVerifier(issuerVerifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not referenced by the SD-JWT")
+
+		expired, err := New(issuerID, &jwt.Claims{Expiry: josejwt.NewNumericDate(time.Now().Add(-time.Hour))},
+			nil, issuerSigner)
+		require.NoError(t, err)
+
+		expiredCombined, err := expired.Serialize(false)
+		require.NoError(t, err)
+
+		_, err = Verify(expiredCombined, WithSignatureVerifier(issuerVerifier), WithLeewayForClaimsValidation(0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid SD-JWT time claims")
+	})
+}
+
+// testSigner is a jose.Signer of SD-JWTs and key binding JWTs.
+type testSigner struct {
+	signer signature.Signer
+}
+
+func (s *testSigner) Sign(data []byte) ([]byte, error) {
+	return s.signer.Sign(data)
+}
+
+func (s *testSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: s.signer.Alg()}
+}
+
+func newTestSigner(t *testing.T, keyType kms.KeyType) (jose.Signer, jose.SignatureVerifier) {
+	t.Helper()
+
+	signer, err := signature.NewSigner(keyType)
+	require.NoError(t, err)
+
+	key, err := jwksupport.JWKFromKey(signer.PublicKey())
+	require.NoError(t, err)
+
+	v, err := jwt.GetVerifier(&sigverifier.PublicKey{JWK: key})
+	require.NoError(t, err)
+
+	return &testSigner{signer: signer}, v
+}
+
+func newTestHolder(t *testing.T) (jose.Signer, *jwk.JWK) {
+	t.Helper()
+
+	signer, err := signature.NewSigner(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	key, err := jwksupport.JWKFromKey(signer.PublicKey())
+	require.NoError(t, err)
+
+	return &testSigner{signer: signer}, key
+}
+
+func disclosureOf(t *testing.T, disclosures []*DisclosureClaim, name string) string {
+	t.Helper()
+
+	for _, disclosure := range disclosures {
+		if disclosure.Name == name {
+			return disclosure.Disclosure
+		}
+	}
+
+	require.Failf(t, "disclosure not found", name)
+
+	return ""
+}
@@ -38,8 +38,13 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldCryptosuite is a key for cryptosuite of Data Integrity proof.
+	jsonldCryptosuite = "cryptosuite"
 
 	ed25519Signature2020 = "Ed25519Signature2020"
+
+	// DataIntegrityProof is a type of W3C Data Integrity proof, its algorithms are defined by the cryptosuite.
+	DataIntegrityProof = "DataIntegrityProof"
 )
 
 // Proof is cryptographic proof of the integrity of the DID Document.
@@ -55,6 +60,8 @@ type Proof struct {
 	Nonce                   []byte
 	Challenge               string
 	SignatureRepresentation SignatureRepresentation
+	// Cryptosuite is an identifier of the cryptographic suite of DataIntegrityProof (e.g. eddsa-2022).
+	Cryptosuite string
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
 }
@@ -112,6 +119,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Domain:                  stringEntry(emap[jsonldDomain]),
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
+		Cryptosuite:             stringEntry(emap[jsonldCryptosuite]),
 		CapabilityChain:         capabilityChain,
 	}, nil
 }
@@ -149,7 +157,7 @@ func decodeBase64(s string) ([]byte, error) {
 
 // DecodeProofValue decodes proofValue basing on proof type.
 func DecodeProofValue(s, proofType string) ([]byte, error) {
-	if proofType == ed25519Signature2020 {
+	if isMultibaseProofValue(proofType) {
 		_, value, err := multibase.Decode(s)
 		if err == nil {
 			return value, nil
@@ -215,6 +223,10 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldChallenge] = p.Challenge
 	}
 
+	if p.Cryptosuite != "" {
+		emap[jsonldCryptosuite] = p.Cryptosuite
+	}
+
 	if p.CapabilityChain != nil {
 		emap[jsonldCapabilityChain] = p.CapabilityChain
 	}
@@ -224,7 +236,7 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 
 // EncodeProofValue decodes proofValue basing on proof type.
 func EncodeProofValue(proofValue []byte, proofType string) string {
-	if proofType == ed25519Signature2020 {
+	if isMultibaseProofValue(proofType) {
 		encoded, _ := multibase.Encode(multibase.Base58BTC, proofValue) //nolint: errcheck
 		return encoded
 	}
@@ -232,6 +244,10 @@ func EncodeProofValue(proofValue []byte, proofType string) string {
 	return base64.RawURLEncoding.EncodeToString(proofValue)
 }
 
+func isMultibaseProofValue(proofType string) bool {
+	return proofType == ed25519Signature2020 || proofType == DataIntegrityProof
+}
+
 // PublicKeyID provides ID of public key to be used to independently verify the proof.
 // "verificationMethod" field is checked first. If not empty, its value is returned.
 // Otherwise, "creator" field is returned if not empty. Otherwise, error is returned.
@@ -463,6 +463,41 @@ func TestProof_JSONLdObject(t *testing.T) {
 	})
 }
 
+func TestProof_DataIntegrityProof(t *testing.T) {
+	r := require.New(t)
+
+	p, err := NewProof(map[string]interface{}{
+		"type":               DataIntegrityProof,
+		"cryptosuite":        "eddsa-2022",
+		"verificationMethod": "did:example:123456#key1",
+		"created":            "2018-03-15T00:00:00Z",
+		"proofPurpose":       "assertionMethod",
+		"proofValue":         proofValueMultibase,
+	})
+	r.NoError(err)
+
+	_, proofValueBytes, err := multibase.Decode(proofValueMultibase)
+	r.NoError(err)
+
+	r.Equal(DataIntegrityProof, p.Type)
+	r.Equal("eddsa-2022", p.Cryptosuite)
+	r.Equal(proofValueBytes, p.ProofValue)
+
+	pJSONLd := p.JSONLdObject()
+	r.Equal(DataIntegrityProof, pJSONLd["type"])
+	r.Equal("eddsa-2022", pJSONLd["cryptosuite"])
+	r.Equal(proofValueMultibase, pJSONLd["proofValue"])
+
+	_, err = NewProof(map[string]interface{}{
+		"type":        DataIntegrityProof,
+		"cryptosuite": "eddsa-2022",
+		"created":     "2018-03-15T00:00:00Z",
+		"proofValue":  proofValueBase64,
+	})
+	r.Error(err)
+	r.Contains(err.Error(), "unsupported encoding")
+}
+
 func TestProof_PublicKeyID(t *testing.T) {
 	p := Proof{
 		Creator:            "creator",
@@ -39,6 +39,13 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuiteProvider is implemented by the signature suites of DataIntegrityProof type
+// which are distinguished by cryptosuite.
+type cryptosuiteProvider interface {
+	// Cryptosuite returns identifier of the cryptosuite (e.g. eddsa-2022)
+	Cryptosuite() string
+}
+
 // DocumentSigner implements signing of JSONLD documents.
 type DocumentSigner struct {
 	signatureSuites []SignatureSuite
@@ -56,6 +63,7 @@ type Context struct {
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	Cryptosuite             string                        // optional
 }
 
 // New returns new instance of document verifier.
@@ -92,7 +100,7 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		return err
 	}
 
-	suite, err := signer.getSignatureSuite(context.SignatureType)
+	suite, err := signer.getSignatureSuite(context.SignatureType, context.Cryptosuite)
 	if err != nil {
 		return err
 	}
@@ -116,6 +124,10 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		CapabilityChain:         context.CapabilityChain,
 	}
 
+	if cs, ok := suite.(cryptosuiteProvider); ok {
+		p.Cryptosuite = cs.Cryptosuite()
+	}
+
 	// TODO support custom proof purpose
 	//  (https://github.com/hyperledger/aries-framework-go/issues/1586)
 	if p.ProofPurpose == "" {
@@ -150,17 +162,31 @@ func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Pro
 	}
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (signer *DocumentSigner) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type and optional cryptosuite.
+func (signer *DocumentSigner) getSignatureSuite(signatureType, cryptosuite string) (SignatureSuite, error) {
 	for _, s := range signer.signatureSuites {
-		if s.Accept(signatureType) {
+		if s.Accept(signatureType) && acceptCryptosuite(s, cryptosuite) {
 			return s, nil
 		}
 	}
 
+	if cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", signatureType, cryptosuite)
+	}
+
 	return nil, fmt.Errorf("signature type %s not supported", signatureType)
 }
 
+func acceptCryptosuite(s SignatureSuite, cryptosuite string) bool {
+	if cryptosuite == "" {
+		return true
+	}
+
+	cs, ok := s.(cryptosuiteProvider)
+
+	return ok && cs.Cryptosuite() == cryptosuite
+}
+
 // isValidContext checks required parameters (for signing).
 func isValidContext(context *Context) error {
 	if context.SignatureType == "" {
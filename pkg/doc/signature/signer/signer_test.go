@@ -15,7 +15,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsa2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsa2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -114,6 +116,51 @@ func TestDocumentSigner_SignErrors(t *testing.T) {
 	require.Contains(t, err.Error(), "bad private key length")
 }
 
+func TestDocumentSigner_SignDataIntegrityProof(t *testing.T) {
+	var docMap map[string]interface{}
+
+	err := json.Unmarshal([]byte(validDoc), &docMap)
+	require.NoError(t, err)
+
+	docMap["@context"] = append(docMap["@context"].([]interface{}), "https://w3id.org/security/data-integrity/v1")
+	doc, err := json.Marshal(docMap)
+	require.NoError(t, err)
+
+	ed25519Signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	s := New(
+		ecdsa2019.New(),
+		eddsa2022.New(suite.WithSigner(ed25519Signer)))
+
+	context := &Context{
+		SignatureType:           proof.DataIntegrityProof,
+		SignatureRepresentation: proof.SignatureProofValue,
+		VerificationMethod:      "did:example:123456#key1",
+		Cryptosuite:             eddsa2022.Cryptosuite,
+	}
+
+	signedDoc, err := s.Sign(context, doc, ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var signedMap map[string]interface{}
+	err = json.Unmarshal(signedDoc, &signedMap)
+	require.NoError(t, err)
+
+	proofs, err := proof.GetProofs(signedMap)
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+	require.Equal(t, proof.DataIntegrityProof, proofs[0].Type)
+	require.Equal(t, eddsa2022.Cryptosuite, proofs[0].Cryptosuite)
+	require.NotEmpty(t, proofs[0].ProofValue)
+
+	context.Cryptosuite = "bbs-2023"
+	signedDoc, err = s.Sign(context, doc, ldtestutil.WithDocumentLoader(t))
+	require.Error(t, err)
+	require.Nil(t, signedDoc)
+	require.Contains(t, err.Error(), "signature type DataIntegrityProof with cryptosuite bbs-2023 not supported")
+}
+
 func TestDocumentSigner_isValidContext(t *testing.T) {
 	s := New()
 
@@ -0,0 +1,23 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsa2019
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// NewPublicKeyVerifier creates a signature verifier that verifies a ECDSA P-256 signature
+// taking P-256 public key bytes or JSON Web Key as input.
+func NewPublicKeyVerifier() *verifier.PublicKeyVerifier {
+	return verifier.NewPublicKeyVerifier(verifier.NewECDSAES256SignatureVerifier())
+}
+
+// NewP384PublicKeyVerifier creates a signature verifier that verifies a ECDSA P-384 signature
+// taking P-384 public key bytes or JSON Web Key as input.
+func NewP384PublicKeyVerifier() *verifier.PublicKeyVerifier {
+	return verifier.NewPublicKeyVerifier(verifier.NewECDSAES384SignatureVerifier())
+}
@@ -0,0 +1,61 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsa2019
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyType  kmsapi.KeyType
+		verifier *verifier.PublicKeyVerifier
+	}{
+		{
+			name:     "P-256",
+			keyType:  kmsapi.ECDSAP256TypeIEEEP1363,
+			verifier: NewPublicKeyVerifier(),
+		},
+		{
+			name:     "P-384",
+			keyType:  kmsapi.ECDSAP384TypeIEEEP1363,
+			verifier: NewP384PublicKeyVerifier(),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := signature.NewSigner(tc.keyType)
+			require.NoError(t, err)
+
+			msg := []byte("test message")
+
+			msgSig, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			pubKey := &verifier.PublicKey{
+				Type:  "Multikey",
+				Value: signer.PublicKeyBytes(),
+			}
+
+			err = tc.verifier.Verify(pubKey, msg, msgSig)
+			require.NoError(t, err)
+
+			err = tc.verifier.Verify(pubKey, []byte("other message"), msgSig)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdsa2019 implements the ecdsa-2019 cryptosuite of the DataIntegrityProof type
+// for the Verifiable Credential Data Integrity [VC-DATA-INTEGRITY] specification.
+// It uses the RDF Dataset Normalization Algorithm [RDF-DATASET-NORMALIZATION]
+// to transform the input document into its canonical form.
+// It uses ECDSA [FIPS-186-4] over P-256 curve with SHA-256 [RFC6234] message digest algorithm
+// or over P-384 curve with SHA-384 message digest algorithm.
+package ecdsa2019
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Suite implements ecdsa-2019 cryptosuite for a single elliptic curve.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+	curve           elliptic.Curve
+	hash            crypto.Hash
+}
+
+const (
+	// SignatureType is the signature type of ecdsa-2019 cryptosuite.
+	SignatureType = proof.DataIntegrityProof
+	// Cryptosuite is the identifier of ecdsa-2019 cryptosuite.
+	Cryptosuite   = "ecdsa-2019"
+	rdfDataSetAlg = "URDNA2015"
+)
+
+// New an instance of ecdsa-2019 cryptosuite for P-256 keys.
+func New(opts ...suite.Opt) *Suite {
+	return newSuite(elliptic.P256(), crypto.SHA256, opts...)
+}
+
+// NewP384 an instance of ecdsa-2019 cryptosuite for P-384 keys.
+func NewP384(opts ...suite.Opt) *Suite {
+	return newSuite(elliptic.P384(), crypto.SHA384, opts...)
+}
+
+func newSuite(curve elliptic.Curve, hash crypto.Hash, opts ...suite.Opt) *Suite {
+	s := &Suite{
+		jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg),
+		curve:           curve,
+		hash:            hash,
+	}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document
+// ecdsa-2019 cryptosuite uses RDF Dataset Normalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest using SHA-256 for P-256 and SHA-384 for P-384 curve.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	if s.hash == crypto.SHA384 {
+		digest := sha512.Sum384(doc)
+		return digest[:]
+	}
+
+	digest := sha256.Sum256(doc)
+
+	return digest[:]
+}
+
+// Accept will accept only DataIntegrityProof signature type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns ecdsa-2019 cryptosuite identifier.
+func (s *Suite) Cryptosuite() string {
+	return Cryptosuite
+}
+
+// AcceptPublicKey accepts only public keys on the elliptic curve of the suite.
+func (s *Suite) AcceptPublicKey(pubKey *verifier.PublicKey) bool {
+	if pubKey.JWK != nil {
+		return pubKey.JWK.Crv == s.curve.Params().Name
+	}
+
+	x, _ := elliptic.Unmarshal(s.curve, pubKey.Value)
+
+	return x != nil
+}
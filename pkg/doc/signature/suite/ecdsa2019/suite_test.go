@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsa2019
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc, err := New().GetCanonicalDocument(map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n", string(doc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	require.Len(t, digest, 32)
+
+	digest = NewP384().GetDigest([]byte("test doc"))
+	require.Len(t, digest, 48)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	accepted := ss.Accept("DataIntegrityProof")
+	require.True(t, accepted)
+
+	accepted = ss.Accept("EcdsaSecp256k1Signature2019")
+	require.False(t, accepted)
+
+	require.Equal(t, "ecdsa-2019", ss.Cryptosuite())
+}
+
+func TestSignatureSuite_AcceptPublicKey(t *testing.T) {
+	p256Signer, err := signature.NewSigner(kmsapi.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	p384Signer, err := signature.NewSigner(kmsapi.ECDSAP384TypeIEEEP1363)
+	require.NoError(t, err)
+
+	p256Key := &verifier.PublicKey{Type: "Multikey", Value: p256Signer.PublicKeyBytes()}
+	p384Key := &verifier.PublicKey{Type: "Multikey", Value: p384Signer.PublicKeyBytes()}
+
+	require.True(t, New().AcceptPublicKey(p256Key))
+	require.False(t, New().AcceptPublicKey(p384Key))
+	require.True(t, NewP384().AcceptPublicKey(p384Key))
+	require.False(t, NewP384().AcceptPublicKey(p256Key))
+
+	p384JWK := &verifier.PublicKey{Type: "JsonWebKey2020", JWK: &jwk.JWK{Kty: "EC", Crv: "P-384"}}
+
+	require.False(t, New().AcceptPublicKey(p384JWK))
+	require.True(t, NewP384().AcceptPublicKey(p384JWK))
+}
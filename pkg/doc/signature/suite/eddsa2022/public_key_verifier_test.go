@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsa2022
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	signer, err := signature.NewSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	msgSig, err := signer.Sign(msg)
+	require.NoError(t, err)
+
+	pubKey := &verifier.PublicKey{
+		Type:  kmsapi.ED25519,
+		Value: signer.PublicKeyBytes(),
+	}
+	v := NewPublicKeyVerifier()
+
+	err = v.Verify(pubKey, msg, msgSig)
+	require.NoError(t, err)
+
+	err = v.Verify(pubKey, []byte("other message"), msgSig)
+	require.Error(t, err)
+}
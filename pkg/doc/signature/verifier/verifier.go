@@ -34,6 +34,20 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// cryptosuiteProvider is implemented by the signature suites of DataIntegrityProof type
+// which are distinguished by cryptosuite.
+type cryptosuiteProvider interface {
+	// Cryptosuite returns identifier of the cryptosuite (e.g. eddsa-2022)
+	Cryptosuite() string
+}
+
+// publicKeyAcceptor is implemented by the signature suites which support only some of the public keys
+// (e.g. ecdsa-2019 cryptosuite uses different digest algorithms for P-256 and P-384 keys).
+type publicKeyAcceptor interface {
+	// AcceptPublicKey checks if public key can be used with this signature suite
+	AcceptPublicKey(pubKey *PublicKey) bool
+}
+
 // PublicKey contains a result of public key resolution.
 type PublicKey struct {
 	Type  string
@@ -96,7 +110,7 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 			return err
 		}
 
-		suite, err := dv.getSignatureSuite(p.Type)
+		suite, err := dv.getSignatureSuite(p, publicKey)
 		if err != nil {
 			return err
 		}
@@ -120,15 +134,34 @@ func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, op
 	return nil
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (SignatureSuite, error) {
+// getSignatureSuite returns signature suite based on signature type, cryptosuite and public key of the proof.
+func (dv *DocumentVerifier) getSignatureSuite(p *proof.Proof, publicKey *PublicKey) (SignatureSuite, error) {
 	for _, s := range dv.signatureSuites {
-		if s.Accept(signatureType) {
+		if s.Accept(p.Type) && acceptCryptosuite(s, p.Cryptosuite) && acceptPublicKey(s, publicKey) {
 			return s, nil
 		}
 	}
 
-	return nil, fmt.Errorf("signature type %s not supported", signatureType)
+	if p.Cryptosuite != "" {
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", p.Type, p.Cryptosuite)
+	}
+
+	return nil, fmt.Errorf("signature type %s not supported", p.Type)
+}
+
+func acceptCryptosuite(s SignatureSuite, cryptosuite string) bool {
+	cs, ok := s.(cryptosuiteProvider)
+	if !ok {
+		return cryptosuite == ""
+	}
+
+	return cs.Cryptosuite() == cryptosuite
+}
+
+func acceptPublicKey(s SignatureSuite, publicKey *PublicKey) bool {
+	pka, ok := s.(publicKeyAcceptor)
+
+	return !ok || pka.AcceptPublicKey(publicKey)
 }
 
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+var logger = log.New("aries-framework/doc/statuslist")
+
+// maxResponseSize is the maximum size of a status list credential, it fits the base64 encoding of a bitstring of
+// bitstring.MaxLength bits.
+const maxResponseSize = 32 * 1024 * 1024
+
+// NewHTTPFetcher returns a verifiable.StatusListFetcher downloading status list credentials with client. They are
+// parsed with opts, which should check their proof. Responses with a non-2xx status or a body larger than 32 MiB are
+// rejected.
+func NewHTTPFetcher(client *http.Client, opts ...verifiable.CredentialOpt) verifiable.StatusListFetcher {
+	return func(statusListCredential string) (*verifiable.Credential, error) {
+		resp, err := client.Get(statusListCredential)
+		if err != nil {
+			return nil, fmt.Errorf("load status list credential: %w", err)
+		}
+
+		defer func() {
+			e := resp.Body.Close()
+			if e != nil {
+				logger.Errorf("closing response body failed [%v]", e)
+			}
+		}()
+
+		if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return nil, fmt.Errorf("status list credential endpoint HTTP failure [%v]", resp.StatusCode)
+		}
+
+		// one more byte than the maximum is read to detect larger bodies.
+		vcBytes, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("status list credential: read response body: %w", err)
+		}
+
+		if len(vcBytes) > maxResponseSize {
+			return nil, fmt.Errorf("status list credential: response body larger than %d bytes", maxResponseSize)
+		}
+
+		return verifiable.ParseCredential(vcBytes, opts...)
+	}
+}
+
+type cacheEntry struct {
+	vc      *verifiable.Credential
+	expires time.Time
+}
+
+// NewCachingFetcher returns a verifiable.StatusListFetcher caching the status list credentials fetched by fetcher
+// for ttl. Expired credentials are fetched again and dropped from the cache.
+func NewCachingFetcher(fetcher verifiable.StatusListFetcher, ttl time.Duration) verifiable.StatusListFetcher {
+	var (
+		mutex sync.Mutex
+		cache = map[string]*cacheEntry{}
+	)
+
+	return func(statusListCredential string) (*verifiable.Credential, error) {
+		mutex.Lock()
+		entry, ok := cache[statusListCredential]
+		mutex.Unlock()
+
+		if ok && time.Now().Before(entry.expires) {
+			return entry.vc, nil
+		}
+
+		vc, err := fetcher(statusListCredential)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+
+		mutex.Lock()
+
+		for url, e := range cache {
+			if !now.Before(e.expires) {
+				delete(cache, url)
+			}
+		}
+
+		cache[statusListCredential] = &cacheEntry{vc: vc, expires: now.Add(ttl)}
+		mutex.Unlock()
+
+		return vc, nil
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestNewHTTPFetcher(t *testing.T) {
+	issuer, err := NewIssuer(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	require.NoError(t, issuer.CreateList(listURL, issuerID))
+
+	listVC, err := issuer.Credential(listURL)
+	require.NoError(t, err)
+
+	listVCBytes, err := listVC.MarshalJSON()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status/3":
+		case "/status/large":
+			_, e := w.Write(make([]byte, maxResponseSize+1))
+			require.NoError(t, e)
+
+			return
+		case "/status/redirect":
+			w.WriteHeader(http.StatusMultipleChoices)
+
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, e := w.Write(listVCBytes)
+		require.NoError(t, e)
+	}))
+	defer server.Close()
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	fetcher := NewHTTPFetcher(http.DefaultClient,
+		verifiable.WithJSONLDDocumentLoader(loader), verifiable.WithDisabledProofCheck())
+
+	t.Run("test fetch", func(t *testing.T) {
+		vc, err := fetcher(server.URL + "/status/3")
+		require.NoError(t, err)
+		require.Equal(t, listURL, vc.ID)
+		require.Equal(t, issuerID, vc.Issuer.ID)
+	})
+
+	t.Run("error - HTTP failure", func(t *testing.T) {
+		_, err := fetcher(server.URL + "/status/4")
+		require.EqualError(t, err, "status list credential endpoint HTTP failure [404]")
+
+		_, err = fetcher(server.URL + "/status/redirect")
+		require.EqualError(t, err, "status list credential endpoint HTTP failure [300]")
+
+		_, err = fetcher(server.URL + "/status/large")
+		require.EqualError(t, err, fmt.Sprintf("status list credential: response body larger than %d bytes",
+			maxResponseSize))
+
+		_, err = fetcher("http://[::1]:namedport")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "load status list credential")
+	})
+}
+
+func TestNewCachingFetcher(t *testing.T) {
+	calls := 0
+
+	fetcher := NewCachingFetcher(func(statusListCredential string) (*verifiable.Credential, error) {
+		calls++
+
+		if statusListCredential == "" {
+			return nil, errors.New("fetch error")
+		}
+
+		return &verifiable.Credential{ID: statusListCredential}, nil
+	}, time.Hour)
+
+	vc, err := fetcher(listURL)
+	require.NoError(t, err)
+	require.Equal(t, listURL, vc.ID)
+
+	vc, err = fetcher(listURL)
+	require.NoError(t, err)
+	require.Equal(t, listURL, vc.ID)
+	require.Equal(t, 1, calls)
+
+	_, err = fetcher("")
+	require.EqualError(t, err, "fetch error")
+
+	_, err = fetcher("")
+	require.EqualError(t, err, "fetch error")
+	require.Equal(t, 3, calls)
+
+	t.Run("test expired entries are fetched again", func(t *testing.T) {
+		calls = 0
+
+		expiring := NewCachingFetcher(func(statusListCredential string) (*verifiable.Credential, error) {
+			calls++
+
+			return &verifiable.Credential{ID: statusListCredential}, nil
+		}, 0)
+
+		_, err = expiring(listURL)
+		require.NoError(t, err)
+
+		_, err = expiring(listURL)
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("test expired entries are dropped", func(t *testing.T) {
+		calls = 0
+
+		expiring := NewCachingFetcher(func(statusListCredential string) (*verifiable.Credential, error) {
+			calls++
+
+			return &verifiable.Credential{ID: statusListCredential}, nil
+		}, 50*time.Millisecond)
+
+		_, err = expiring(listURL)
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		// fetching another list drops the expired one.
+		_, err = expiring(listURL + "/other")
+		require.NoError(t, err)
+
+		_, err = expiring(listURL + "/other")
+		require.NoError(t, err)
+
+		_, err = expiring(listURL)
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+}
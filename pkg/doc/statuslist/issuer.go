@@ -0,0 +1,258 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/bitstring"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// NameSpace for status list store.
+	NameSpace = "statuslist"
+
+	// DefaultListSize is the number of entries of a status list, 16KB as recommended for herd privacy.
+	DefaultListSize = 131072
+
+	baseContext = "https://www.w3.org/2018/credentials/v1"
+	vcType      = "VerifiableCredential"
+)
+
+// ListOpt is the status list creation option.
+type ListOpt func(opts *listOpts)
+
+type listOpts struct {
+	purpose string
+	size    int
+}
+
+// WithPurpose sets the status purpose of the list, verifiable.StatusPurposeRevocation by default.
+func WithPurpose(purpose string) ListOpt {
+	return func(opts *listOpts) {
+		opts.purpose = purpose
+	}
+}
+
+// WithSize sets the number of entries of the list, DefaultListSize by default.
+func WithSize(size int) ListOpt {
+	return func(opts *listOpts) {
+		opts.size = size
+	}
+}
+
+// listRecord is the persisted state of a status list.
+type listRecord struct {
+	Issuer      string `json:"issuer"`
+	Purpose     string `json:"purpose"`
+	NextIndex   int    `json:"nextIndex"`
+	EncodedList string `json:"encodedList"`
+}
+
+// Issuer manages StatusList2021 status lists: it allocates the credentialStatus of issued credentials, sets their
+// status and produces the status list credentials to be signed and published.
+type Issuer struct {
+	store storage.Store
+	mutex sync.Mutex
+}
+
+// NewIssuer returns a new status list issuer persisting status lists in provider.
+func NewIssuer(provider storage.Provider) (*Issuer, error) {
+	store, err := provider.OpenStore(NameSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open status list store: %w", err)
+	}
+
+	return &Issuer{store: store}, nil
+}
+
+// CreateList creates the status list published at listURL, its credential is issued by issuerID.
+func (i *Issuer) CreateList(listURL, issuerID string, opts ...ListOpt) error {
+	if listURL == "" || issuerID == "" {
+		return errors.New("status list URL and issuer are mandatory")
+	}
+
+	o := &listOpts{purpose: verifiable.StatusPurposeRevocation, size: DefaultListSize}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.size <= 0 {
+		return fmt.Errorf("invalid status list size %d", o.size)
+	}
+
+	encodedList, err := bitstring.New(o.size).Encode()
+	if err != nil {
+		return err
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	_, err = i.store.Get(listURL)
+	if err == nil {
+		return fmt.Errorf("status list %s already exists", listURL)
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get status list %s: %w", listURL, err)
+	}
+
+	return i.putList(listURL, &listRecord{Issuer: issuerID, Purpose: o.purpose, EncodedList: encodedList})
+}
+
+// AllocateStatus allocates the next free index of the status list at listURL and returns the StatusList2021Entry
+// to be set as credentialStatus of the issued credential.
+func (i *Issuer) AllocateStatus(listURL string) (*verifiable.TypedID, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	rec, bits, err := i.getList(listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.NextIndex >= bits.Len() {
+		return nil, fmt.Errorf("status list %s is full", listURL)
+	}
+
+	index := rec.NextIndex
+	rec.NextIndex++
+
+	err = i.putList(listURL, rec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verifiable.TypedID{
+		ID:   listURL + "#" + strconv.Itoa(index),
+		Type: verifiable.StatusList2021EntryType,
+		CustomFields: verifiable.CustomFields{
+			verifiable.StatusPurpose:        rec.Purpose,
+			verifiable.StatusListIndex:      strconv.Itoa(index),
+			verifiable.StatusListCredential: listURL,
+		},
+	}, nil
+}
+
+// SetStatus sets the status of the credential with the credentialStatus status, e.g. revokes it in a revocation
+// list when value is true.
+func (i *Issuer) SetStatus(status *verifiable.TypedID, value bool) error {
+	if status == nil || status.Type != verifiable.StatusList2021EntryType {
+		return errors.New("not a StatusList2021Entry credential status")
+	}
+
+	listURL, ok := status.CustomFields[verifiable.StatusListCredential].(string)
+	if !ok {
+		return fmt.Errorf("%s is missing", verifiable.StatusListCredential)
+	}
+
+	indexStr, ok := status.CustomFields[verifiable.StatusListIndex].(string)
+	if !ok {
+		return fmt.Errorf("%s is missing", verifiable.StatusListIndex)
+	}
+
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", verifiable.StatusListIndex, err)
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	rec, bits, err := i.getList(listURL)
+	if err != nil {
+		return err
+	}
+
+	if index >= rec.NextIndex {
+		return fmt.Errorf("index %d of status list %s is not allocated", index, listURL)
+	}
+
+	err = bits.Set(index, value)
+	if err != nil {
+		return err
+	}
+
+	rec.EncodedList, err = bits.Encode()
+	if err != nil {
+		return err
+	}
+
+	return i.putList(listURL, rec)
+}
+
+// Credential returns the unsigned StatusList2021Credential of the status list at listURL in its current state.
+func (i *Issuer) Credential(listURL string) (*verifiable.Credential, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	rec, _, err := i.getList(listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verifiable.Credential{
+		Context: []string{baseContext, verifiable.StatusList2021Context},
+		ID:      listURL,
+		Types:   []string{vcType, verifiable.StatusList2021CredentialType},
+		Issuer:  verifiable.Issuer{ID: rec.Issuer},
+		Issued:  util.NewTime(time.Now()),
+		Subject: []verifiable.Subject{{
+			ID: listURL + "#list",
+			CustomFields: verifiable.CustomFields{
+				"type":                   verifiable.StatusList2021Type,
+				verifiable.StatusPurpose: rec.Purpose,
+				verifiable.EncodedList:   rec.EncodedList,
+			},
+		}},
+	}, nil
+}
+
+func (i *Issuer) getList(listURL string) (*listRecord, *bitstring.BitString, error) {
+	recBytes, err := i.store.Get(listURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get status list %s: %w", listURL, err)
+	}
+
+	rec := &listRecord{}
+
+	err = json.Unmarshal(recBytes, rec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unmarshal status list %s: %w", listURL, err)
+	}
+
+	bits, err := bitstring.Decode(rec.EncodedList)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rec, bits, nil
+}
+
+func (i *Issuer) putList(listURL string, rec *listRecord) error {
+	recBytes, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal status list %s: %w", listURL, err)
+	}
+
+	err = i.store.Put(listURL, recBytes)
+	if err != nil {
+		return fmt.Errorf("put status list %s: %w", listURL, err)
+	}
+
+	return nil
+}
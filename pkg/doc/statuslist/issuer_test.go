@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+const (
+	listURL  = "https://example.com/credentials/status/3"
+	issuerID = "did:example:12345"
+)
+
+func TestIssuer(t *testing.T) {
+	t.Run("test revoke issued credential", func(t *testing.T) {
+		issuer, err := NewIssuer(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		require.NoError(t, issuer.CreateList(listURL, issuerID))
+
+		status, err := issuer.AllocateStatus(listURL)
+		require.NoError(t, err)
+		require.Equal(t, listURL+"#0", status.ID)
+		require.Equal(t, verifiable.StatusList2021EntryType, status.Type)
+		require.Equal(t, verifiable.StatusPurposeRevocation, status.CustomFields[verifiable.StatusPurpose])
+		require.Equal(t, "0", status.CustomFields[verifiable.StatusListIndex])
+		require.Equal(t, listURL, status.CustomFields[verifiable.StatusListCredential])
+
+		other, err := issuer.AllocateStatus(listURL)
+		require.NoError(t, err)
+		require.Equal(t, "1", other.CustomFields[verifiable.StatusListIndex])
+
+		vc := &verifiable.Credential{Issuer: verifiable.Issuer{ID: issuerID}, Status: status}
+		otherVC := &verifiable.Credential{Issuer: verifiable.Issuer{ID: issuerID}, Status: other}
+
+		require.NoError(t, vc.CheckStatus(issuer.Credential))
+
+		require.NoError(t, issuer.SetStatus(status, true))
+		require.True(t, errors.Is(vc.CheckStatus(issuer.Credential), verifiable.ErrCredentialRevoked))
+		require.NoError(t, otherVC.CheckStatus(issuer.Credential))
+
+		require.NoError(t, issuer.SetStatus(status, false))
+		require.NoError(t, vc.CheckStatus(issuer.Credential))
+
+		listVC, err := issuer.Credential(listURL)
+		require.NoError(t, err)
+		require.Equal(t, listURL, listVC.ID)
+		require.Equal(t, []string{"VerifiableCredential", verifiable.StatusList2021CredentialType}, listVC.Types)
+		require.Contains(t, listVC.Context, verifiable.StatusList2021Context)
+	})
+
+	t.Run("test suspension list of custom size", func(t *testing.T) {
+		issuer, err := NewIssuer(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		require.NoError(t, issuer.CreateList(listURL, issuerID,
+			WithPurpose(verifiable.StatusPurposeSuspension), WithSize(8)))
+
+		for i := 0; i < 8; i++ {
+			_, err = issuer.AllocateStatus(listURL)
+			require.NoError(t, err)
+		}
+
+		_, err = issuer.AllocateStatus(listURL)
+		require.EqualError(t, err, fmt.Sprintf("status list %s is full", listURL))
+
+		listVC, err := issuer.Credential(listURL)
+		require.NoError(t, err)
+
+		subjects, ok := listVC.Subject.([]verifiable.Subject)
+		require.True(t, ok)
+		require.Equal(t, verifiable.StatusPurposeSuspension, subjects[0].CustomFields[verifiable.StatusPurpose])
+	})
+
+	t.Run("error - create list", func(t *testing.T) {
+		_, err := NewIssuer(&storage.MockStoreProvider{ErrOpenStoreHandle: errors.New("open error")})
+		require.EqualError(t, err, "failed to open status list store: open error")
+
+		issuer, err := NewIssuer(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		require.EqualError(t, issuer.CreateList("", issuerID), "status list URL and issuer are mandatory")
+		require.EqualError(t, issuer.CreateList(listURL, issuerID, WithSize(0)), "invalid status list size 0")
+
+		require.NoError(t, issuer.CreateList(listURL, issuerID))
+		require.EqualError(t, issuer.CreateList(listURL, issuerID),
+			fmt.Sprintf("status list %s already exists", listURL))
+	})
+
+	t.Run("error - unknown list", func(t *testing.T) {
+		issuer, err := NewIssuer(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = issuer.AllocateStatus(listURL)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get status list")
+
+		_, err = issuer.Credential(listURL)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get status list")
+	})
+
+	t.Run("error - set status", func(t *testing.T) {
+		issuer, err := NewIssuer(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		require.NoError(t, issuer.CreateList(listURL, issuerID))
+
+		require.EqualError(t, issuer.SetStatus(&verifiable.TypedID{Type: "CredentialStatusList2017"}, true),
+			"not a StatusList2021Entry credential status")
+
+		require.EqualError(t, issuer.SetStatus(&verifiable.TypedID{
+			Type:         verifiable.StatusList2021EntryType,
+			CustomFields: verifiable.CustomFields{},
+		}, true), "statusListCredential is missing")
+
+		require.EqualError(t, issuer.SetStatus(&verifiable.TypedID{
+			Type:         verifiable.StatusList2021EntryType,
+			CustomFields: verifiable.CustomFields{verifiable.StatusListCredential: listURL},
+		}, true), "statusListIndex is missing")
+
+		require.EqualError(t, issuer.SetStatus(&verifiable.TypedID{
+			Type: verifiable.StatusList2021EntryType,
+			CustomFields: verifiable.CustomFields{
+				verifiable.StatusListCredential: listURL,
+				verifiable.StatusListIndex:      "5",
+			},
+		}, true), fmt.Sprintf("index 5 of status list %s is not allocated", listURL))
+	})
+}
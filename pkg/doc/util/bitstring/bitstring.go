@@ -0,0 +1,124 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bitstring
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+const bitsPerByte = 8
+
+// MaxLength is the maximum length in bits of a decoded bitstring (16 MiB), it bounds the memory used to decompress
+// untrusted bitstrings.
+const MaxLength = 16 * 1024 * 1024 * bitsPerByte
+
+// BitString is a bitstring as used by status lists, the bit at index 0 is the left-most bit of the first byte.
+type BitString struct {
+	bits []byte
+}
+
+// New creates a bitstring of length bits, all set to false. The length is rounded up to a multiple of 8.
+func New(length int) *BitString {
+	return &BitString{bits: make([]byte, (length+bitsPerByte-1)/bitsPerByte)}
+}
+
+// Decode decodes a base64url encoded GZIP compressed bitstring of at most MaxLength bits.
+func Decode(encoded string) (*BitString, error) {
+	compressed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		// padded or standard base64 encoding produced by other implementations
+		compressed, err = base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode bitstring: %w", err)
+		}
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress bitstring: %w", err)
+	}
+
+	// one more byte than the maximum is read to detect longer bitstrings.
+	bits, err := ioutil.ReadAll(io.LimitReader(reader, MaxLength/bitsPerByte+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress bitstring: %w", err)
+	}
+
+	if len(bits) > MaxLength/bitsPerByte {
+		return nil, fmt.Errorf("decompress bitstring: longer than %d bits", MaxLength)
+	}
+
+	return &BitString{bits: bits}, nil
+}
+
+// Encode returns the base64url encoded GZIP compressed bitstring.
+func (b *BitString) Encode() (string, error) {
+	var buf bytes.Buffer
+
+	writer := gzip.NewWriter(&buf)
+
+	if _, err := writer.Write(b.bits); err != nil {
+		return "", fmt.Errorf("compress bitstring: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("compress bitstring: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Len returns the number of bits.
+func (b *BitString) Len() int {
+	return len(b.bits) * bitsPerByte
+}
+
+// Get returns the bit at index.
+func (b *BitString) Get(index int) (bool, error) {
+	if err := b.checkIndex(index); err != nil {
+		return false, err
+	}
+
+	return b.bits[index/bitsPerByte]&mask(index) != 0, nil
+}
+
+// Set sets the bit at index to value.
+func (b *BitString) Set(index int, value bool) error {
+	if err := b.checkIndex(index); err != nil {
+		return err
+	}
+
+	if value {
+		b.bits[index/bitsPerByte] |= mask(index)
+	} else {
+		b.bits[index/bitsPerByte] &^= mask(index)
+	}
+
+	return nil
+}
+
+func (b *BitString) checkIndex(index int) error {
+	if index < 0 {
+		return errors.New("negative bitstring index")
+	}
+
+	if index >= b.Len() {
+		return fmt.Errorf("bitstring index %d out of range [0, %d)", index, b.Len())
+	}
+
+	return nil
+}
+
+func mask(index int) byte {
+	return 1 << (bitsPerByte - 1 - index%bitsPerByte)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bitstring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitString(t *testing.T) {
+	t.Run("test set and get", func(t *testing.T) {
+		b := New(12)
+		require.Equal(t, 16, b.Len())
+
+		require.NoError(t, b.Set(0, true))
+		require.NoError(t, b.Set(9, true))
+		require.Equal(t, []byte{0x80, 0x40}, b.bits)
+
+		for i := 0; i < b.Len(); i++ {
+			value, err := b.Get(i)
+			require.NoError(t, err)
+			require.Equal(t, i == 0 || i == 9, value)
+		}
+
+		require.NoError(t, b.Set(0, false))
+		value, err := b.Get(0)
+		require.NoError(t, err)
+		require.False(t, value)
+	})
+
+	t.Run("test encode and decode", func(t *testing.T) {
+		b := New(131072)
+		require.NoError(t, b.Set(94567, true))
+
+		encoded, err := b.Encode()
+		require.NoError(t, err)
+
+		decoded, err := Decode(encoded)
+		require.NoError(t, err)
+		require.Equal(t, b.Len(), decoded.Len())
+
+		value, err := decoded.Get(94567)
+		require.NoError(t, err)
+		require.True(t, value)
+	})
+
+	t.Run("test decode status list 2021 example", func(t *testing.T) {
+		// empty 16KB list from https://w3c-ccg.github.io/vc-status-list-2021/#example-example-statuslist2021credential
+		b, err := Decode("H4sIAAAAAAAAA-3BMQEAAADCoPVPbQwfoAAAAAAAAAAAAAAAAAAAAIC3AYbSVKsAQAAA")
+		require.NoError(t, err)
+		require.Equal(t, 131072, b.Len())
+
+		value, err := b.Get(94567)
+		require.NoError(t, err)
+		require.False(t, value)
+	})
+
+	t.Run("error - index out of range", func(t *testing.T) {
+		b := New(8)
+
+		_, err := b.Get(8)
+		require.EqualError(t, err, "bitstring index 8 out of range [0, 8)")
+
+		err = b.Set(-1, true)
+		require.EqualError(t, err, "negative bitstring index")
+	})
+
+	t.Run("test decode maximum length", func(t *testing.T) {
+		encoded, err := New(MaxLength).Encode()
+		require.NoError(t, err)
+
+		b, err := Decode(encoded)
+		require.NoError(t, err)
+		require.Equal(t, MaxLength, b.Len())
+	})
+
+	t.Run("error - decompressed bitstring too long", func(t *testing.T) {
+		// a few KB of compressed zeros decompress to more than the maximum length.
+		encoded, err := New(MaxLength + bitsPerByte).Encode()
+		require.NoError(t, err)
+
+		_, err = Decode(encoded)
+		require.EqualError(t, err, fmt.Sprintf("decompress bitstring: longer than %d bits", MaxLength))
+	})
+
+	t.Run("error - invalid encoding", func(t *testing.T) {
+		_, err := Decode("!!!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode bitstring")
+
+		_, err = Decode("YWJj")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decompress bitstring")
+	})
+}
@@ -30,7 +30,7 @@ import (
 var errInvalidKeyType = errors.New("key type is not supported")
 
 // CreateKID creates a KID value based on the marshalled keyBytes of type kt. This function should be called for
-// asymmetric public keys only (ECDSA DER or IEEE-P1363, ED25519, X25519, BLS12381G2).
+// asymmetric public keys only (ECDSA DER or IEEE-P1363, secp256k1, ED25519, X25519, BLS12381G2, RSA PKCS #1 DER).
 // returns:
 //  - base64 raw (no padding) URL encoded KID
 //  - error in case of error
@@ -54,7 +54,7 @@ func CreateKID(keyBytes []byte, kt kms.KeyType) (string, error) {
 		}
 
 		return ed25519KID, nil
-	case kms.BLS12381G2Type: // BBS+ as JWK thumbprint.
+	case kms.BLS12381G2Type: // BBS+ as the RFC 7638 thumbprint of its JWK (see jwk.JWK.Thumbprint()).
 		bbsKID, err := createBLS12381G2KID(keyBytes)
 		if err != nil {
 			return "", fmt.Errorf("createKID: %w", err)
@@ -89,13 +89,16 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jwk.JWK, error) {
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdsa DER key: %w", err)
 		}
-		// TODO remove `case kms.ED25519Type` in CreateKID() and uncomment below case when go-jose fixes Ed25519
-		//      JWK thumbprint. Also remove `createED25519KID(keyBytes []byte)` function further below.
-	// case kms.ED25519Type:
-	//	j, err = jwksupport.JWKFromKey(ed25519.PublicKey(keyBytes))
-	//	if err != nil {
-	//		return nil, fmt.Errorf("buildJWK: failed to build JWK from ed25519 key: %w", err)
-	//	}
+	case kms.ED25519Type:
+		j, err = jwksupport.JWKFromKey(ed25519.PublicKey(keyBytes))
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from ed25519 key: %w", err)
+		}
+	case kms.BLS12381G2Type, kms.ECDSASecp256k1TypeIEEEP1363, kms.RSARS256Type, kms.RSAPS256Type:
+		j, err = jwksupport.PubKeyBytesToJWK(keyBytes, kt)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from %s key: %w", kt, err)
+		}
 	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
 		c := getCurveByKMSKeyType(kt)
 		x, y := elliptic.Unmarshal(c, keyBytes)
@@ -239,7 +242,7 @@ func buildX25519JWK(keyBytes []byte) (string, error) {
 
 func createBLS12381G2KID(keyBytes []byte) (string, error) {
 	const (
-		bls12381g2ThumbprintTemplate = `{"crv":"Bls12381g2","kty":"OKP","x":"%s"}`
+		bls12381g2ThumbprintTemplate = `{"crv":"BLS12381_G2","kty":"EC","x":"%s"}`
 		// Default BLS 12-381 public key length in G2 field.
 		bls12381G2PublicKeyLen = 96
 	)
@@ -12,6 +12,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
@@ -20,12 +21,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -39,12 +43,17 @@ func TestCreateKID(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, kid)
 
-	// now try building go-jose thumbprint and compare its base64URL with kid above
-	// they should not match since go-jose's thumbprint is built from a wrong Ed25519 JWK.
+	// the JWK thumbprint matches the kid above.
 	j, err := jwksupport.JWKFromKey(pubKey)
 	require.NoError(t, err)
 
-	goJoseTP, err := j.Thumbprint(crypto.SHA256)
+	tp, err := j.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	require.Equal(t, kid, base64.RawURLEncoding.EncodeToString(tp))
+
+	// now try building go-jose thumbprint and compare its base64URL with kid above
+	// they should not match since go-jose's thumbprint is built from a wrong Ed25519 JWK.
+	goJoseTP, err := j.JSONWebKey.Thumbprint(crypto.SHA256)
 	require.NoError(t, err)
 
 	goJoseKID := base64.RawURLEncoding.EncodeToString(goJoseTP)
@@ -126,6 +135,28 @@ func TestCreateKID(t *testing.T) {
 	kid, err = CreateKID(ecdhKeyMarshalled, kms.X25519ECDHKWType)
 	require.NoError(t, err)
 	require.NotEmpty(t, kid)
+
+	secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	kid, err = CreateKID(elliptic.Marshal(secp256k1Key.Curve, secp256k1Key.X, secp256k1Key.Y),
+		kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+	require.NotEmpty(t, kid)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	kid, err = CreateKID(x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey), kms.RSAPS256Type)
+	require.NoError(t, err)
+
+	rsaTP, err := (&jose.JSONWebKey{Key: &rsaKey.PublicKey}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(rsaTP), kid)
+
+	_, err = CreateKID(badPubKey, kms.RSARS256Type)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "createKID: failed to build jwk: buildJWK: failed to build JWK from RSARS256 key")
 }
 
 func TestCreateKIDFromFixedKey(t *testing.T) {
@@ -272,3 +303,102 @@ func TestCreateBLS12381G2KID(t *testing.T) {
 	_, err = CreateKID(append(pubKeyBytes, []byte("larger key")...), kms.BLS12381G2Type)
 	require.EqualError(t, err, "createKID: invalid BBS+ key")
 }
+
+func TestCreateKID_JWKThumbprint(t *testing.T) {
+	edPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	bbsPubKey, _, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	bbsPubKeyBytes, err := bbsPubKey.Marshal()
+	require.NoError(t, err)
+
+	x25519PubKey := make([]byte, cryptoutil.Curve25519KeySize)
+	_, err = rand.Read(x25519PubKey)
+	require.NoError(t, err)
+
+	x25519PubKeyBytes, err := json.Marshal(&cryptoapi.PublicKey{Curve: "X25519", Type: "OKP", X: x25519PubKey})
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	type testCase struct {
+		keyType  kms.KeyType
+		keyBytes []byte
+		key      interface{}
+	}
+
+	testCases := []testCase{
+		{keyType: kms.ED25519Type, keyBytes: edPubKey, key: edPubKey},
+		{keyType: kms.BLS12381G2Type, keyBytes: bbsPubKeyBytes, key: bbsPubKey},
+		{keyType: kms.X25519ECDHKWType, keyBytes: x25519PubKeyBytes},
+		{keyType: kms.RSARS256Type, keyBytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey), key: &rsaKey.PublicKey},
+		{keyType: kms.RSAPS256Type, keyBytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey), key: &rsaKey.PublicKey},
+	}
+
+	ecKeyTypes := []struct {
+		curve                      elliptic.Curve
+		derType, p1363Type, kwType kms.KeyType
+	}{
+		{elliptic.P256(), kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363, kms.NISTP256ECDHKWType},
+		{elliptic.P384(), kms.ECDSAP384TypeDER, kms.ECDSAP384TypeIEEEP1363, kms.NISTP384ECDHKWType},
+		{elliptic.P521(), kms.ECDSAP521TypeDER, kms.ECDSAP521TypeIEEEP1363, kms.NISTP521ECDHKWType},
+		{curve: btcec.S256(), p1363Type: kms.ECDSASecp256k1TypeIEEEP1363},
+	}
+
+	for _, ec := range ecKeyTypes {
+		ecKey, e := ecdsa.GenerateKey(ec.curve, rand.Reader)
+		require.NoError(t, e)
+
+		testCases = append(testCases, testCase{
+			keyType:  ec.p1363Type,
+			keyBytes: elliptic.Marshal(ec.curve, ecKey.X, ecKey.Y),
+			key:      &ecKey.PublicKey,
+		})
+
+		if ec.derType == "" {
+			continue
+		}
+
+		derKeyBytes, e := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+		require.NoError(t, e)
+
+		kwKeyBytes, e := json.Marshal(&cryptoapi.PublicKey{
+			Curve: ec.curve.Params().Name,
+			Type:  "EC",
+			X:     ecKey.X.Bytes(),
+			Y:     ecKey.Y.Bytes(),
+		})
+		require.NoError(t, e)
+
+		testCases = append(testCases,
+			testCase{keyType: ec.derType, keyBytes: derKeyBytes, key: &ecKey.PublicKey},
+			testCase{keyType: ec.kwType, keyBytes: kwKeyBytes, key: &ecKey.PublicKey})
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(string(tc.keyType), func(t *testing.T) {
+			kid, err := CreateKID(tc.keyBytes, tc.keyType)
+			require.NoError(t, err)
+
+			// the JWK is built from the key itself rather than from the KMS key bytes.
+			var j *jwk.JWK
+
+			if tc.keyType == kms.X25519ECDHKWType {
+				j, err = jwksupport.JWKFromX25519Key(x25519PubKey)
+			} else {
+				j, err = jwksupport.JWKFromKey(tc.key)
+			}
+
+			require.NoError(t, err)
+
+			tp, err := j.Thumbprint(crypto.SHA256)
+			require.NoError(t, err)
+			require.Equal(t, kid, base64.RawURLEncoding.EncodeToString(tp))
+		})
+	}
+}
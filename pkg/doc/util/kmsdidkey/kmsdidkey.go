@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcutil/base58"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 
@@ -35,6 +36,8 @@ var keyTypeCodecs = map[kms.KeyType]uint64{
 	kms.ECDSAP521TypeIEEEP1363: fingerprint.P521PubKeyMultiCodec,
 	kms.ECDSAP521TypeDER:       fingerprint.P521PubKeyMultiCodec,
 
+	kms.ECDSASecp256k1TypeIEEEP1363: fingerprint.Secp256k1PubKeyMultiCodec,
+
 	// encryption keys
 	kms.X25519ECDHKWType:   fingerprint.X25519PubKeyMultiCodec,
 	kms.NISTP256ECDHKWType: fingerprint.P256PubKeyMultiCodec,
@@ -70,6 +73,13 @@ func BuildDIDKeyByKeyType(pubKeyBytes []byte, keyType kms.KeyType) (string, erro
 
 		// used Compressed EC format for did:key, the same way as vdr key creator.
 		pubKeyBytes = elliptic.MarshalCompressed(ecKey.Curve, ecKey.X, ecKey.Y)
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		if err != nil {
+			return "", fmt.Errorf("buildDIDkeyByKMSKeyType failed to unmarshal key type %v: %w", keyType, err)
+		}
+
+		pubKeyBytes = pubKey.SerializeCompressed()
 	}
 
 	if codec, ok := keyTypeCodecs[keyType]; ok {
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
@@ -71,6 +72,9 @@ func TestBuildDIDKeyByKMSKeyType(t *testing.T) {
 	_, p521KWKey, err := k.CreateAndExportPubKeyBytes(kms.NISTP521ECDHKWType)
 	require.NoError(t, err)
 
+	secp256k1Key, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
 	tests := []struct {
 		name     string
 		keyBytes []byte
@@ -136,6 +140,16 @@ func TestBuildDIDKeyByKMSKeyType(t *testing.T) {
 			keyBytes: p521KWKey,
 			keyType:  kms.NISTP521ECDHKW,
 		},
+		{
+			name:     "test ECDSASecp256k1TypeIEEEP1363 key",
+			keyBytes: secp256k1Key.PubKey().SerializeUncompressed(),
+			keyType:  kms.ECDSASecp256k1TypeIEEEP1363,
+		},
+		{
+			name:     "test invalid ECDSASecp256k1TypeIEEEP1363 key",
+			keyBytes: []byte("wrong-key"),
+			keyType:  kms.ECDSASecp256k1TypeIEEEP1363,
+		},
 		{
 			name:     "test invalid key",
 			keyBytes: []byte{},
@@ -189,8 +203,20 @@ func TestBuildDIDKeyByKMSKeyType(t *testing.T) {
 				return
 			}
 
+			if tc.name == "test invalid ECDSASecp256k1TypeIEEEP1363 key" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "buildDIDkeyByKMSKeyType failed to unmarshal key type "+
+					"ECDSASecp256k1IEEEP1363")
+
+				return
+			}
+
 			require.NoError(t, err)
 			require.Contains(t, didKey, "did:key:z")
+
+			if tc.keyType == kms.ECDSASecp256k1TypeIEEEP1363 {
+				require.Contains(t, didKey, "did:key:zQ3s")
+			}
 		})
 	}
 }
@@ -80,6 +80,26 @@ func NewCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, keyType kms
 		return nil, err
 	}
 
+	return newCryptoSigner(crypto, kid, kh, pubKeyBytes, keyType)
+}
+
+// GetCryptoSigner creates a CryptoSigner of the existing key kid of the KMS.
+func GetCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, kid string) (*CryptoSigner, error) {
+	kh, err := kms.Get(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyBytes, keyType, err := kms.ExportPubKeyBytes(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCryptoSigner(crypto, kid, kh, pubKeyBytes, keyType)
+}
+
+func newCryptoSigner(crypto cryptoapi.Crypto, kid string, kh interface{}, pubKeyBytes []byte,
+	keyType kmsapi.KeyType) (*CryptoSigner, error) {
 	pubKey, err := getPublicKey(keyType, pubKeyBytes)
 	if err != nil {
 		return nil, err
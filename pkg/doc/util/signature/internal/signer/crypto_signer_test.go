@@ -116,6 +116,52 @@ func TestNewCryptoSigner(t *testing.T) {
 	})
 }
 
+func TestGetCryptoSigner(t *testing.T) {
+	localKMS, err := createKMS()
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		for _, keyType := range []kmsapi.KeyType{kmsapi.ED25519Type, kmsapi.ECDSAP384TypeIEEEP1363} {
+			kid, pubKeyBytes, err := localKMS.CreateAndExportPubKeyBytes(keyType)
+			require.NoError(t, err)
+
+			signer, err := GetCryptoSigner(tinkCrypto, localKMS, kid)
+			require.NoError(t, err)
+			require.Equal(t, kid, signer.KID())
+			require.Equal(t, pubKeyBytes, signer.PublicKeyBytes())
+
+			msg := []byte("test message")
+			sigMsg, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			publicKeyHandle, err := localKMS.PubKeyBytesToHandle(pubKeyBytes, keyType)
+			require.NoError(t, err)
+			require.NoError(t, tinkCrypto.Verify(sigMsg, msg, publicKeyHandle))
+		}
+	})
+
+	t.Run("error corner cases", func(t *testing.T) {
+		signer, err := GetCryptoSigner(tinkCrypto, &mockkms.KeyManager{GetKeyErr: errors.New("get key error")}, "kid")
+		require.EqualError(t, err, "get key error")
+		require.Nil(t, signer)
+
+		signer, err = GetCryptoSigner(tinkCrypto, &mockkms.KeyManager{
+			ExportPubKeyBytesErr: errors.New("export public key bytes error"),
+		}, "kid")
+		require.EqualError(t, err, "export public key bytes error")
+		require.Nil(t, signer)
+
+		signer, err = GetCryptoSigner(tinkCrypto, &mockkms.KeyManager{
+			ExportPubKeyTypeValue: kmsapi.ChaCha20Poly1305Type,
+		}, "kid")
+		require.EqualError(t, err, "unsupported key type")
+		require.Nil(t, signer)
+	})
+}
+
 func createKMS() (*localkms.LocalKMS, error) {
 	p, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
 	if err != nil {
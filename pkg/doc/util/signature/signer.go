@@ -42,6 +42,11 @@ func NewCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, keyType kms
 	}
 }
 
+// GetCryptoSigner returns a signer based on crypto of the existing key kid of the KMS.
+func GetCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, kid string) (Signer, error) {
+	return signer.GetCryptoSigner(crypto, kms, kid)
+}
+
 // NewSigner creates a new signer.
 func NewSigner(keyType kmsapi.KeyType) (Signer, error) {
 	switch keyType {
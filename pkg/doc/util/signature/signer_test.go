@@ -58,6 +58,31 @@ func TestNewCryptoSigner(t *testing.T) {
 	require.Nil(t, newSigner)
 }
 
+func TestGetCryptoSigner(t *testing.T) {
+	p, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	localKMS, err := localkms.New("local-lock://custom/master/key/", p)
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kid, _, err := localKMS.Create(kmsapi.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	cryptoSigner, err := GetCryptoSigner(tinkCrypto, localKMS, kid)
+	require.NoError(t, err)
+	require.Equal(t, "ES256", cryptoSigner.Alg())
+
+	msgSig, err := cryptoSigner.Sign([]byte("test message"))
+	require.NoError(t, err)
+	require.NotEmpty(t, msgSig)
+
+	_, err = GetCryptoSigner(tinkCrypto, localKMS, "unknown")
+	require.Error(t, err)
+}
+
 func TestNewSigner(t *testing.T) {
 	for _, keyType := range [...]kmsapi.KeyType{
 		kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP521TypeDER,
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	docjsonld "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
@@ -512,6 +513,11 @@ type Credential struct {
 	RefreshService []TypedID
 	JWT            string
 
+	// SDJWTHashAlg, SDJWTDisclosures and SDHolderBinding are set for SD-JWT credentials (JWT is the SD-JWT).
+	SDJWTHashAlg     string
+	SDJWTDisclosures []*sdjwt.DisclosureClaim
+	SDHolderBinding  string
+
 	CustomFields CustomFields
 }
 
@@ -579,6 +585,8 @@ type credentialOpts struct {
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
 	defaultSchema         string
+	statusListFetcher     StatusListFetcher
+	sdJWTHolderBinding    *sdJWTHolderBinding
 
 	jsonldCredentialOpts
 }
@@ -820,8 +828,22 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, err
 	}
 
+	if vcOpts.statusListFetcher != nil {
+		err = vc.CheckStatus(vcOpts.statusListFetcher)
+		if err != nil {
+			return nil, fmt.Errorf("check credential status: %w", err)
+		}
+	}
+
 	vc.JWT = externalJWT
 
+	if sdjwt.IsCombinedFormat(externalJWT) {
+		err = vc.setSDJWT(externalJWT)
+		if err != nil {
+			return nil, fmt.Errorf("decode SD-JWT credential: %w", err)
+		}
+	}
+
 	return vc, nil
 }
 
@@ -1048,6 +1070,19 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, string, error) {
 		externalVCStr = jwtHolder.JWT
 	}
 
+	if sdjwt.IsCombinedFormat(externalVCStr) { // External proof of SD-JWT, disclosures are checked against it.
+		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
+			return nil, "", errors.New("public key fetcher is not defined")
+		}
+
+		vcDecodedBytes, err := decodeCredSDJWT(externalVCStr, vcOpts)
+		if err != nil {
+			return nil, "", fmt.Errorf("SD-JWT decoding: %w", err)
+		}
+
+		return vcDecodedBytes, externalVCStr, nil
+	}
+
 	if jwt.IsJWS(externalVCStr) { // External proof, is checked by JWS.
 		if vcOpts.publicKeyFetcher == nil && !vcOpts.disabledProofCheck {
 			return nil, "", errors.New("public key fetcher is not defined")
@@ -1515,6 +1550,11 @@ func typedIDsToRaw(typedIDs []TypedID) ([]byte, error) {
 // MarshalJSON converts Verifiable Credential to JSON bytes.
 func (vc *Credential) MarshalJSON() ([]byte, error) {
 	if vc.JWT != "" {
+		if vc.SDJWTHashAlg != "" {
+			// SD-JWT credentials are marshalled with their disclosures in combined format.
+			return []byte("\"" + vc.combinedFormat().Serialize() + "\""), nil
+		}
+
 		// If vc.JWT exists, marshal only the JWT, since all other values should be unchanged
 		// from when the JWT was parsed.
 		return []byte("\"" + vc.JWT + "\""), nil
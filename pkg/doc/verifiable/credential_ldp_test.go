@@ -25,12 +25,15 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsa2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsa2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
@@ -104,6 +107,88 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2020(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_DataIntegrityProof(t *testing.T) {
+	tests := []struct {
+		name        string
+		keyType     kms.KeyType
+		cryptosuite string
+		newSuite    func(opts ...suite.Opt) signer.SignatureSuite
+	}{
+		{
+			name:        "eddsa-2022",
+			keyType:     kms.ED25519Type,
+			cryptosuite: "eddsa-2022",
+			newSuite: func(opts ...suite.Opt) signer.SignatureSuite {
+				return eddsa2022.New(opts...)
+			},
+		},
+		{
+			name:        "ecdsa-2019 P-256",
+			keyType:     kms.ECDSAP256TypeIEEEP1363,
+			cryptosuite: "ecdsa-2019",
+			newSuite: func(opts ...suite.Opt) signer.SignatureSuite {
+				return ecdsa2019.New(opts...)
+			},
+		},
+		{
+			name:        "ecdsa-2019 P-384",
+			keyType:     kms.ECDSAP384TypeIEEEP1363,
+			cryptosuite: "ecdsa-2019",
+			newSuite: func(opts ...suite.Opt) signer.SignatureSuite {
+				return ecdsa2019.NewP384(opts...)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			keySigner, err := newCryptoSigner(tc.keyType)
+			r.NoError(err)
+
+			ldpContext := &LinkedDataProofContext{
+				SignatureType:           "DataIntegrityProof",
+				SignatureRepresentation: SignatureProofValue,
+				Suite:                   tc.newSuite(suite.WithSigner(keySigner)),
+				VerificationMethod:      "did:example:123456#key1",
+			}
+
+			vc, err := parseTestCredential(t, []byte(validCredential))
+			r.NoError(err)
+
+			vc.Context = append(vc.Context, "https://w3id.org/security/data-integrity/v1")
+
+			err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+			r.NoError(err)
+			r.Len(vc.Proofs, 1)
+			r.Equal("DataIntegrityProof", vc.Proofs[0]["type"])
+			r.Equal(tc.cryptosuite, vc.Proofs[0]["cryptosuite"])
+			r.True(strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+
+			vcBytes, err := json.Marshal(vc)
+			r.NoError(err)
+
+			// default signature suites are selected by cryptosuite of the proof
+			vcWithLdp, err := parseTestCredential(t, vcBytes,
+				WithPublicKeyFetcher(SingleKey(keySigner.PublicKeyBytes(), "Multikey")))
+			r.NoError(err)
+			r.Equal(vc, vcWithLdp)
+
+			vc.Proofs[0]["cryptosuite"] = "unknown"
+
+			vcBytes, err = json.Marshal(vc)
+			r.NoError(err)
+
+			_, err = parseTestCredential(t, vcBytes,
+				WithPublicKeyFetcher(SingleKey(keySigner.PublicKeyBytes(), "Multikey")))
+			r.Error(err)
+		})
+	}
+}
+
 //nolint:lll
 func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 	r := require.New(t)
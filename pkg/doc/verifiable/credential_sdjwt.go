@@ -0,0 +1,405 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/square/go-jose/v3/json"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt"
+	jsonutil "github.com/hyperledger/aries-framework-go/pkg/doc/util/json"
+)
+
+const (
+	vcClaim      = "vc"
+	subjectClaim = "credentialSubject"
+)
+
+type makeSDJWTOpts struct {
+	hashAlg         string
+	structured      bool
+	nonSDClaims     []string
+	holderPublicKey *jwk.JWK
+	getSalt         func() (string, error)
+}
+
+// MakeSDJWTOption provides an option for creating an SD-JWT from a VC.
+type MakeSDJWTOption func(opts *makeSDJWTOpts)
+
+// MakeSDJWTWithHashAlgorithm sets the hash algorithm of the disclosure digests (sdjwt.SHA256 by default).
+func MakeSDJWTWithHashAlgorithm(alg string) MakeSDJWTOption {
+	return func(opts *makeSDJWTOpts) {
+		opts.hashAlg = alg
+	}
+}
+
+// MakeSDJWTWithStructuredClaims makes the properties of nested objects and the elements of arrays of the subject
+// selectively disclosable one by one.
+func MakeSDJWTWithStructuredClaims(structured bool) MakeSDJWTOption {
+	return func(opts *makeSDJWTOpts) {
+		opts.structured = structured
+	}
+}
+
+// MakeSDJWTWithNonSelectivelyDisclosableClaims sets the subject claims which are always disclosed, given as paths
+// relative to the subject (e.g. "degree.type"). The subject ID is always disclosed.
+func MakeSDJWTWithNonSelectivelyDisclosableClaims(paths []string) MakeSDJWTOption {
+	return func(opts *makeSDJWTOpts) {
+		opts.nonSDClaims = append(opts.nonSDClaims, paths...)
+	}
+}
+
+// MakeSDJWTWithHolderPublicKey binds the SD-JWT to the holder public key, so that its presentations must include
+// a key binding JWT signed by the holder.
+func MakeSDJWTWithHolderPublicKey(key *jwk.JWK) MakeSDJWTOption {
+	return func(opts *makeSDJWTOpts) {
+		opts.holderPublicKey = key
+	}
+}
+
+// MakeSDJWTWithSaltFnc sets the salt generator of the disclosures.
+func MakeSDJWTWithSaltFnc(fnc func() (string, error)) MakeSDJWTOption {
+	return func(opts *makeSDJWTOpts) {
+		opts.getSalt = fnc
+	}
+}
+
+// MakeSDJWT creates an SD-JWT in combined format from the VC, where the claims of the credential subject are
+// selectively disclosable.
+func (vc *Credential) MakeSDJWT(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	options ...MakeSDJWTOption) (string, error) {
+	opts := &makeSDJWTOpts{hashAlg: sdjwt.SHA256}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	claims, err := vc.JWTClaims(false)
+	if err != nil {
+		return "", fmt.Errorf("convert VC to JWT claims: %w", err)
+	}
+
+	claimsMap, err := jsonutil.ToMap(claims)
+	if err != nil {
+		return "", fmt.Errorf("convert JWT claims to map: %w", err)
+	}
+
+	vcMap, ok := claimsMap[vcClaim].(map[string]interface{})
+	if !ok {
+		return "", errors.New("vc claim is missing")
+	}
+
+	var disclosures []string
+
+	vcMap[subjectClaim], disclosures, err = makeSubjectSelectivelyDisclosable(vcMap[subjectClaim], opts)
+	if err != nil {
+		return "", err
+	}
+
+	claimsMap[sdjwt.SDAlgorithmKey] = opts.hashAlg
+
+	if opts.holderPublicKey != nil {
+		claimsMap[sdjwt.CNFKey] = map[string]interface{}{sdjwt.JWKKey: opts.holderPublicKey}
+	}
+
+	sdJWT, err := marshalJWS(claimsMap, signatureAlg, signer, keyID)
+	if err != nil {
+		return "", fmt.Errorf("sign SD-JWT: %w", err)
+	}
+
+	cf := &sdjwt.CombinedFormat{SDJWT: sdJWT, Disclosures: disclosures}
+
+	return cf.Serialize(), nil
+}
+
+func makeSubjectSelectivelyDisclosable(subject interface{}, opts *makeSDJWTOpts) (interface{}, []string, error) {
+	sdOpts := []sdjwt.NewOpt{
+		sdjwt.WithHashAlgorithm(opts.hashAlg),
+		sdjwt.WithStructuredClaims(opts.structured),
+		sdjwt.WithNonSelectivelyDisclosableClaims(append([]string{"id"}, opts.nonSDClaims...)),
+	}
+
+	if opts.getSalt != nil {
+		sdOpts = append(sdOpts, sdjwt.WithSaltFnc(opts.getSalt))
+	}
+
+	var disclosures []string
+
+	makeSD := func(s interface{}) (interface{}, error) {
+		subjectMap, ok := s.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("credential subject must be an object to be selectively disclosable")
+		}
+
+		sdSubject, subjectDisclosures, err := sdjwt.CreateDisclosures(subjectMap, sdOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("create disclosures of credential subject: %w", err)
+		}
+
+		for _, disclosure := range subjectDisclosures {
+			disclosures = append(disclosures, disclosure.Disclosure)
+		}
+
+		return sdSubject, nil
+	}
+
+	subjects, ok := subject.([]interface{})
+	if !ok {
+		sdSubject, err := makeSD(subject)
+
+		return sdSubject, disclosures, err
+	}
+
+	sdSubjects := make([]interface{}, len(subjects))
+
+	for i, s := range subjects {
+		sdSubject, err := makeSD(s)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sdSubjects[i] = sdSubject
+	}
+
+	return sdSubjects, disclosures, nil
+}
+
+// WithExpectedSDJWTHolderBinding option requires an SD-JWT credential to be presented with a key binding JWT of
+// the given nonce and audience.
+func WithExpectedSDJWTHolderBinding(nonce, audience string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.sdJWTHolderBinding = &sdJWTHolderBinding{nonce: nonce, audience: audience}
+	}
+}
+
+type sdJWTHolderBinding struct {
+	nonce    string
+	audience string
+}
+
+// decodeCredSDJWT verifies an SD-JWT credential in combined format and returns the VC of its disclosed claims.
+func decodeCredSDJWT(combined string, vcOpts *credentialOpts) ([]byte, error) {
+	var signatureVerifier jose.SignatureVerifier = &noVerifier{}
+
+	if !vcOpts.disabledProofCheck {
+		signatureVerifier = jwt.NewVerifier(jwt.KeyResolverFunc(vcOpts.publicKeyFetcher))
+	}
+
+	verifyOpts := []sdjwt.VerifyOpt{sdjwt.WithSignatureVerifier(signatureVerifier)}
+
+	if vcOpts.sdJWTHolderBinding != nil {
+		verifyOpts = append(verifyOpts,
+			sdjwt.WithHolderBindingRequired(true),
+			sdjwt.WithExpectedNonceForHolderBinding(vcOpts.sdJWTHolderBinding.nonce),
+			sdjwt.WithExpectedAudienceForHolderBinding(vcOpts.sdJWTHolderBinding.audience))
+	}
+
+	claims, err := sdjwt.Verify(combined, verifyOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeCredJWT(combined, func(string) (*JWTCredClaims, error) {
+		claimsBytes, e := json.Marshal(claims)
+		if e != nil {
+			return nil, e
+		}
+
+		var credClaims JWTCredClaims
+
+		e = json.Unmarshal(claimsBytes, &credClaims)
+		if e != nil {
+			return nil, e
+		}
+
+		return &credClaims, nil
+	})
+}
+
+// setSDJWT splits the SD-JWT credential in combined format into the signed JWT and its disclosures.
+func (vc *Credential) setSDJWT(combined string) error {
+	cf := sdjwt.ParseCombinedFormat(combined)
+
+	hashAlg, err := sdJWTHashAlg(cf.SDJWT)
+	if err != nil {
+		return err
+	}
+
+	disclosures, err := sdjwt.DecodeDisclosures(cf.Disclosures, hashAlg)
+	if err != nil {
+		return err
+	}
+
+	vc.JWT = cf.SDJWT
+	vc.SDJWTHashAlg = hashAlg
+	vc.SDJWTDisclosures = disclosures
+	vc.SDHolderBinding = cf.KeyBindingJWT
+
+	return nil
+}
+
+func sdJWTHashAlg(sdJWT string) (string, error) {
+	token, err := jwt.Parse(sdJWT, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return "", fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	hashAlg, ok := token.Payload[sdjwt.SDAlgorithmKey]
+	if !ok {
+		return sdjwt.SHA256, nil
+	}
+
+	hashAlgStr, ok := hashAlg.(string)
+	if !ok {
+		return "", fmt.Errorf("%s is not a string", sdjwt.SDAlgorithmKey)
+	}
+
+	return hashAlgStr, nil
+}
+
+func (vc *Credential) combinedFormat() *sdjwt.CombinedFormat {
+	cf := &sdjwt.CombinedFormat{SDJWT: vc.JWT, KeyBindingJWT: vc.SDHolderBinding}
+
+	for _, disclosure := range vc.SDJWTDisclosures {
+		cf.Disclosures = append(cf.Disclosures, disclosure.Disclosure)
+	}
+
+	return cf
+}
+
+type marshalDisclosureOpts struct {
+	discloseAll   bool
+	claimNames    []string
+	paths         []string
+	holderBinding *sdjwt.BindingInfo
+}
+
+// MarshalDisclosureOption provides an option for MarshalWithDisclosure.
+type MarshalDisclosureOption func(opts *marshalDisclosureOpts)
+
+// DiscloseAll discloses all claims of the SD-JWT credential.
+func DiscloseAll() MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.discloseAll = true
+	}
+}
+
+// DiscloseGiven discloses the claims of the given names, and the claims they are nested in. All the names must
+// be selectively disclosable claims of the credential.
+func DiscloseGiven(claimNames []string) MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.claimNames = append(opts.claimNames, claimNames...)
+	}
+}
+
+// DisclosePaths discloses the claims at the given paths of the VC (e.g. "credentialSubject.degree.type"), the
+// claims they are nested in and the claims nested in them.
+func DisclosePaths(paths []string) MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.paths = append(opts.paths, paths...)
+	}
+}
+
+// DisclosureHolderBinding adds a key binding JWT to the presentation of the SD-JWT credential.
+func DisclosureHolderBinding(binding *sdjwt.BindingInfo) MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.holderBinding = binding
+	}
+}
+
+// MarshalWithDisclosure marshals an SD-JWT credential in combined format, with the chosen disclosures only.
+func (vc *Credential) MarshalWithDisclosure(options ...MarshalDisclosureOption) (string, error) {
+	if vc.JWT == "" || vc.SDJWTHashAlg == "" {
+		return "", errors.New("credential is not an SD-JWT")
+	}
+
+	opts := &marshalDisclosureOpts{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	disclosures, err := vc.chooseDisclosures(opts)
+	if err != nil {
+		return "", err
+	}
+
+	var presentOpts []sdjwt.PresentOpt
+
+	if opts.holderBinding != nil {
+		presentOpts = append(presentOpts, sdjwt.WithHolderBinding(opts.holderBinding))
+	}
+
+	issued := vc.combinedFormat()
+	issued.KeyBindingJWT = ""
+
+	return sdjwt.CreatePresentation(issued.Serialize(), disclosures, presentOpts...)
+}
+
+func (vc *Credential) chooseDisclosures(opts *marshalDisclosureOpts) ([]string, error) {
+	var disclosures []string
+
+	if opts.discloseAll {
+		for _, disclosure := range vc.SDJWTDisclosures {
+			disclosures = append(disclosures, disclosure.Disclosure)
+		}
+
+		return disclosures, nil
+	}
+
+	token, err := jwt.Parse(vc.JWT, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return nil, fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	// Paths of the disclosures in the JWT claims, where the VC is the "vc" claim.
+	claimPaths, err := sdjwt.DisclosurePaths(token.Payload, vc.SDJWTDisclosures)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := opts.paths
+
+	for _, name := range opts.claimNames {
+		found := false
+
+		for _, disclosure := range vc.SDJWTDisclosures {
+			if disclosure.Name == name {
+				paths = append(paths, strings.TrimPrefix(claimPaths[disclosure.Digest], vcClaim+"."))
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("claim %s is not selectively disclosable", name)
+		}
+	}
+
+	for _, disclosure := range vc.SDJWTDisclosures {
+		disclosurePath := strings.TrimPrefix(claimPaths[disclosure.Digest], vcClaim+".")
+
+		for _, path := range paths {
+			if isSamePathOrNested(disclosurePath, path) || isSamePathOrNested(path, disclosurePath) {
+				disclosures = append(disclosures, disclosure.Disclosure)
+
+				break
+			}
+		}
+	}
+
+	return disclosures, nil
+}
+
+// isSamePathOrNested checks if path is parent or is nested in parent.
+func isSamePathOrNested(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, parent+".")
+}
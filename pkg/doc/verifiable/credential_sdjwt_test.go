@@ -0,0 +1,169 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/sdjwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const sdJWTTestCredential = `
+{
+	"@context": [
+	  "https://www.w3.org/2018/credentials/v1",
+	  "https://www.w3.org/2018/credentials/examples/v1"
+	],
+	"id": "http://example.edu/credentials/1872",
+	"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+	"credentialSubject": {
+	  "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+	  "degree": {
+		"type": "BachelorDegree",
+		"university": "MIT"
+	  }
+	},
+	"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+	"issuanceDate": "2010-01-01T19:23:24Z"
+}
+`
+
+func TestCredential_MakeSDJWT(t *testing.T) {
+	issuerSigner, err := signature.NewSigner(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	holderSigner, err := signature.NewSigner(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	holderKey, err := jwksupport.JWKFromKey(holderSigner.PublicKey())
+	require.NoError(t, err)
+
+	fetcher := ecdsaKeyFetcher(t, issuerSigner)
+
+	vc, err := parseTestCredential(t, []byte(sdJWTTestCredential))
+	require.NoError(t, err)
+
+	combined, err := vc.MakeSDJWT(ECDSASecp256r1, issuerSigner, vc.Issuer.ID+"#keys-"+keyID,
+		MakeSDJWTWithStructuredClaims(true), MakeSDJWTWithHolderPublicKey(holderKey))
+	require.NoError(t, err)
+
+	t.Run("test parse issued SD-JWT credential", func(t *testing.T) {
+		sdVC, err := parseTestCredential(t, []byte(combined), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+		require.Equal(t, sdjwt.SHA256, sdVC.SDJWTHashAlg)
+		// degree, degree.type and degree.university.
+		require.Len(t, sdVC.SDJWTDisclosures, 3)
+		require.Equal(t, vc.Subject, sdVC.Subject)
+		require.Equal(t, vc.ID, sdVC.ID)
+		require.Equal(t, vc.Issuer.ID, sdVC.Issuer.ID)
+
+		vcBytes, err := sdVC.MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, `"`+combined+`"`, string(vcBytes))
+	})
+
+	t.Run("test present chosen disclosures with holder binding", func(t *testing.T) {
+		sdVC, err := parseTestCredential(t, []byte(combined), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		presented, err := sdVC.MarshalWithDisclosure(DiscloseGiven([]string{"university"}),
+			DisclosureHolderBinding(&sdjwt.BindingInfo{
+				Payload: sdjwt.BindingPayload{Nonce: "nonce", Audience: "did:example:verifier"},
+				Signer:  getJWTSigner(holderSigner, "ES256"),
+			}))
+		require.NoError(t, err)
+
+		presentedVC, err := parseTestCredential(t, []byte(presented), WithPublicKeyFetcher(fetcher),
+			WithExpectedSDJWTHolderBinding("nonce", "did:example:verifier"))
+		require.NoError(t, err)
+		require.Len(t, presentedVC.SDJWTDisclosures, 2)
+		require.NotEmpty(t, presentedVC.SDHolderBinding)
+
+		subjects, ok := presentedVC.Subject.([]Subject)
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"university": "MIT"}, subjects[0].CustomFields["degree"])
+
+		_, err = parseTestCredential(t, []byte(presented), WithPublicKeyFetcher(fetcher),
+			WithExpectedSDJWTHolderBinding("other", "did:example:verifier"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "nonce value doesn't match")
+	})
+
+	t.Run("test present disclosures of paths", func(t *testing.T) {
+		sdVC, err := parseTestCredential(t, []byte(combined), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		presented, err := sdVC.MarshalWithDisclosure(DisclosePaths([]string{"credentialSubject.degree"}))
+		require.NoError(t, err)
+
+		presentedVC, err := parseTestCredential(t, []byte(presented), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+		require.Len(t, presentedVC.SDJWTDisclosures, 3)
+
+		presented, err = sdVC.MarshalWithDisclosure()
+		require.NoError(t, err)
+
+		presentedVC, err = parseTestCredential(t, []byte(presented), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+		require.Empty(t, presentedVC.SDJWTDisclosures)
+
+		_, err = parseTestCredential(t, []byte(presented), WithPublicKeyFetcher(fetcher),
+			WithExpectedSDJWTHolderBinding("nonce", "did:example:verifier"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding JWT is required")
+
+		presented, err = sdVC.MarshalWithDisclosure(DiscloseAll())
+		require.NoError(t, err)
+		require.Equal(t, combined, presented)
+	})
+
+	t.Run("error - invalid SD-JWT credential", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(combined))
+		require.EqualError(t, err, "decode new credential: public key fetcher is not defined")
+
+		otherSigner, err := signature.NewSigner(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(combined), WithPublicKeyFetcher(ecdsaKeyFetcher(t, otherSigner)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "SD-JWT decoding")
+
+		sdVC, err := parseTestCredential(t, []byte(combined), WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		_, err = sdVC.MarshalWithDisclosure(DiscloseGiven([]string{"name"}))
+		require.EqualError(t, err, "claim name is not selectively disclosable")
+
+		_, err = vc.MarshalWithDisclosure(DiscloseAll())
+		require.EqualError(t, err, "credential is not an SD-JWT")
+
+		invalidSubject := *vc
+		invalidSubject.Subject = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+		_, err = invalidSubject.MakeSDJWT(ECDSASecp256r1, issuerSigner, keyID)
+		require.EqualError(t, err, "credential subject must be an object to be selectively disclosable")
+
+		_, err = vc.MakeSDJWT(ECDSASecp256r1, issuerSigner, keyID, MakeSDJWTWithHashAlgorithm("md5"))
+		require.EqualError(t, err, "create disclosures of credential subject: unsupported _sd_alg md5")
+	})
+}
+
+func ecdsaKeyFetcher(t *testing.T, signer signature.Signer) PublicKeyFetcher {
+	t.Helper()
+
+	pubKey, err := jwksupport.JWKFromKey(signer.PublicKey())
+	require.NoError(t, err)
+
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		return &verifier.PublicKey{Type: "JsonWebKey2020", JWK: pubKey}, nil
+	}
+}
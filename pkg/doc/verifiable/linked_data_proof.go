@@ -62,6 +62,8 @@ type LinkedDataProofContext struct {
 	Challenge               string                  // optional
 	Domain                  string                  // optional
 	Purpose                 string                  // optional
+	// Cryptosuite selects the cryptosuite of DataIntegrityProof (e.g. eddsa-2022), defaults to the one of Suite.
+	Cryptosuite string
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
 }
@@ -140,5 +142,6 @@ func mapContext(context *LinkedDataProofContext) *signer.Context {
 		Domain:                  context.Domain,
 		Purpose:                 context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		Cryptosuite:             context.Cryptosuite,
 	}
 }
@@ -0,0 +1,189 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/bitstring"
+)
+
+// StatusList2021 credential status.
+// Reference: https://w3c-ccg.github.io/vc-status-list-2021/
+const (
+	// StatusList2021Context is the JSON-LD context of StatusList2021 credentials and entries.
+	StatusList2021Context = "https://w3id.org/vc/status-list/2021/v1"
+	// StatusList2021EntryType is the type of the credentialStatus of credentials in a status list.
+	StatusList2021EntryType = "StatusList2021Entry"
+	// StatusList2021CredentialType is the type of status list credentials.
+	StatusList2021CredentialType = "StatusList2021Credential"
+	// StatusList2021Type is the type of the credentialSubject of status list credentials.
+	StatusList2021Type = "StatusList2021"
+
+	// StatusPurpose is the field of the status entry and of the status list subject holding the status purpose.
+	StatusPurpose = "statusPurpose"
+	// StatusListIndex is the field of the status entry holding the index of the credential in the status list.
+	StatusListIndex = "statusListIndex"
+	// StatusListCredential is the field of the status entry holding the URL of the status list credential.
+	StatusListCredential = "statusListCredential"
+	// EncodedList is the field of the status list subject holding the GZIP compressed base64url encoded bitstring.
+	EncodedList = "encodedList"
+
+	// StatusPurposeRevocation is the purpose of status lists of revoked credentials.
+	StatusPurposeRevocation = "revocation"
+	// StatusPurposeSuspension is the purpose of status lists of suspended credentials.
+	StatusPurposeSuspension = "suspension"
+)
+
+var (
+	// ErrCredentialRevoked is returned by the status check of a revoked credential.
+	ErrCredentialRevoked = errors.New("credential is revoked")
+	// ErrCredentialSuspended is returned by the status check of a suspended credential.
+	ErrCredentialSuspended = errors.New("credential is suspended")
+)
+
+// StatusListFetcher fetches the status list credential at statusListCredential. The fetcher is responsible for
+// checking the proof of the status list credential.
+type StatusListFetcher func(statusListCredential string) (*Credential, error)
+
+// WithStatusCheck option checks the StatusList2021 credentialStatus of the credential using fetcher to get the
+// status list credential.
+func WithStatusCheck(fetcher StatusListFetcher) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusListFetcher = fetcher
+	}
+}
+
+// CheckStatus checks the StatusList2021 credentialStatus of the credential. ErrCredentialRevoked or
+// ErrCredentialSuspended is returned if the bit of the credential is set in the status list. Credentials without
+// credentialStatus are valid.
+func (vc *Credential) CheckStatus(fetcher StatusListFetcher) error {
+	if vc.Status == nil {
+		return nil
+	}
+
+	if vc.Status.Type != StatusList2021EntryType {
+		return fmt.Errorf("unsupported credential status type: %s", vc.Status.Type)
+	}
+
+	purpose, index, listURL, err := parseStatusList2021Entry(vc.Status)
+	if err != nil {
+		return err
+	}
+
+	listVC, err := fetcher(listURL)
+	if err != nil {
+		return fmt.Errorf("fetch status list credential %s: %w", listURL, err)
+	}
+
+	bits, err := validateStatusListCredential(listVC, vc.Issuer.ID, purpose)
+	if err != nil {
+		return fmt.Errorf("status list credential %s: %w", listURL, err)
+	}
+
+	set, err := bits.Get(index)
+	if err != nil {
+		return fmt.Errorf("status list credential %s: %w", listURL, err)
+	}
+
+	if !set {
+		return nil
+	}
+
+	switch purpose {
+	case StatusPurposeRevocation:
+		return ErrCredentialRevoked
+	case StatusPurposeSuspension:
+		return ErrCredentialSuspended
+	default:
+		return fmt.Errorf("credential status %s is set", purpose)
+	}
+}
+
+func parseStatusList2021Entry(status *TypedID) (string, int, string, error) {
+	purpose, ok := status.CustomFields[StatusPurpose].(string)
+	if !ok || purpose == "" {
+		return "", 0, "", fmt.Errorf("credential status: %s is missing", StatusPurpose)
+	}
+
+	listURL, ok := status.CustomFields[StatusListCredential].(string)
+	if !ok || listURL == "" {
+		return "", 0, "", fmt.Errorf("credential status: %s is missing", StatusListCredential)
+	}
+
+	var index int
+
+	switch v := status.CustomFields[StatusListIndex].(type) {
+	case string:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("credential status: invalid %s: %w", StatusListIndex, err)
+		}
+
+		index = i
+	case float64:
+		index = int(v)
+	default:
+		return "", 0, "", fmt.Errorf("credential status: %s is missing", StatusListIndex)
+	}
+
+	return purpose, index, listURL, nil
+}
+
+func validateStatusListCredential(listVC *Credential, issuerID, purpose string) (*bitstring.BitString, error) {
+	if !isStatusListCredential(listVC) {
+		return nil, fmt.Errorf("not a %s", StatusList2021CredentialType)
+	}
+
+	if listVC.Issuer.ID != issuerID {
+		return nil, fmt.Errorf("issuer %s doesn't match the issuer of the credential", listVC.Issuer.ID)
+	}
+
+	if listVC.Expired != nil && listVC.Expired.Time.Before(time.Now()) {
+		return nil, errors.New("expired")
+	}
+
+	var subject Subject
+
+	switch s := listVC.Subject.(type) {
+	case Subject:
+		subject = s
+	case []Subject:
+		if len(s) != 1 {
+			return nil, errors.New("a single credentialSubject is expected")
+		}
+
+		subject = s[0]
+	default:
+		return nil, errors.New("a single credentialSubject is expected")
+	}
+
+	if subject.CustomFields[StatusPurpose] != purpose {
+		return nil, fmt.Errorf("%s %v doesn't match the credential status", StatusPurpose,
+			subject.CustomFields[StatusPurpose])
+	}
+
+	encodedList, ok := subject.CustomFields[EncodedList].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is missing", EncodedList)
+	}
+
+	return bitstring.Decode(encodedList)
+}
+
+func isStatusListCredential(vc *Credential) bool {
+	for _, t := range vc.Types {
+		if t == StatusList2021CredentialType {
+			return true
+		}
+	}
+
+	return false
+}
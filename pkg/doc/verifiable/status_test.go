@@ -0,0 +1,242 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/bitstring"
+)
+
+const credentialWithStatusList = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/vc/status-list/2021/v1"
+  ],
+  "id": "https://example.com/credentials/23894672394",
+  "type": ["VerifiableCredential"],
+  "issuer": "did:example:12345",
+  "issuanceDate": "2021-04-05T14:27:42Z",
+  "credentialStatus": {
+    "id": "https://example.com/credentials/status/3#94567",
+    "type": "StatusList2021Entry",
+    "statusPurpose": "revocation",
+    "statusListIndex": "94567",
+    "statusListCredential": "https://example.com/credentials/status/3"
+  },
+  "credentialSubject": {
+    "id": "did:example:6789"
+  }
+}`
+
+func TestWithStatusCheck(t *testing.T) {
+	t.Run("test valid credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(credentialWithStatusList),
+			WithStatusCheck(statusListFetcher(t, StatusPurposeRevocation)))
+		require.NoError(t, err)
+		require.Equal(t, StatusList2021EntryType, vc.Status.Type)
+	})
+
+	t.Run("test revoked credential", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(credentialWithStatusList),
+			WithStatusCheck(statusListFetcher(t, StatusPurposeRevocation, 94567)))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrCredentialRevoked))
+	})
+
+	t.Run("test credential without status", func(t *testing.T) {
+		vc := &Credential{Issuer: Issuer{ID: "did:example:12345"}}
+
+		err := vc.CheckStatus(func(string) (*Credential, error) {
+			return nil, errors.New("unexpected fetch")
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("error - status type can't be checked", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential),
+			WithStatusCheck(statusListFetcher(t, StatusPurposeRevocation)))
+		require.EqualError(t, err,
+			"check credential status: unsupported credential status type: CredentialStatusList2017")
+	})
+
+	t.Run("error - fetch failure", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(credentialWithStatusList),
+			WithStatusCheck(func(string) (*Credential, error) {
+				return nil, errors.New("fetch error")
+			}))
+		require.EqualError(t, err, "check credential status: fetch status list credential "+
+			"https://example.com/credentials/status/3: fetch error")
+	})
+}
+
+func TestCredential_CheckStatus(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(credentialWithStatusList))
+	require.NoError(t, err)
+
+	t.Run("test suspended credential", func(t *testing.T) {
+		status := *vc.Status
+		status.CustomFields = copyCustomFields(vc.Status.CustomFields)
+		status.CustomFields[StatusPurpose] = StatusPurposeSuspension
+		status.CustomFields[StatusListIndex] = float64(3)
+
+		suspended := *vc
+		suspended.Status = &status
+
+		err = suspended.CheckStatus(statusListFetcher(t, StatusPurposeSuspension, 3))
+		require.True(t, errors.Is(err, ErrCredentialSuspended))
+	})
+
+	t.Run("error - invalid status list credential", func(t *testing.T) {
+		fetcher := statusListFetcher(t, StatusPurposeRevocation)
+
+		listVC, err := fetcher("")
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			name   string
+			modify func(listVC *Credential)
+			err    string
+		}{
+			{
+				name:   "type",
+				modify: func(listVC *Credential) { listVC.Types = []string{vcType} },
+				err:    "not a StatusList2021Credential",
+			},
+			{
+				name:   "issuer",
+				modify: func(listVC *Credential) { listVC.Issuer.ID = "did:example:other" },
+				err:    "issuer did:example:other doesn't match the issuer of the credential",
+			},
+			{
+				name: "expired",
+				modify: func(listVC *Credential) {
+					listVC.Expired = util.NewTime(time.Now().Add(-time.Hour))
+				},
+				err: "expired",
+			},
+			{
+				name: "purpose",
+				modify: func(listVC *Credential) {
+					listVC.Subject = []Subject{{CustomFields: CustomFields{StatusPurpose: StatusPurposeSuspension}}}
+				},
+				err: "statusPurpose suspension doesn't match the credential status",
+			},
+			{
+				name:   "subject",
+				modify: func(listVC *Credential) { listVC.Subject = "did:example:6789" },
+				err:    "a single credentialSubject is expected",
+			},
+			{
+				name: "encoded list",
+				modify: func(listVC *Credential) {
+					listVC.Subject = Subject{CustomFields: CustomFields{StatusPurpose: StatusPurposeRevocation}}
+				},
+				err: "encodedList is missing",
+			},
+		} {
+			invalid := *listVC
+			tc.modify(&invalid)
+
+			err = vc.CheckStatus(func(string) (*Credential, error) {
+				return &invalid, nil
+			})
+			require.EqualError(t, err, "status list credential https://example.com/credentials/status/3: "+tc.err,
+				tc.name)
+		}
+	})
+
+	t.Run("error - invalid credential status", func(t *testing.T) {
+		for _, tc := range []struct {
+			status *TypedID
+			err    string
+		}{
+			{
+				status: &TypedID{Type: "RevocationList2020Status"},
+				err:    "unsupported credential status type: RevocationList2020Status",
+			},
+			{
+				status: &TypedID{Type: StatusList2021EntryType},
+				err:    "credential status: statusPurpose is missing",
+			},
+			{
+				status: &TypedID{Type: StatusList2021EntryType, CustomFields: CustomFields{
+					StatusPurpose: StatusPurposeRevocation,
+				}},
+				err: "credential status: statusListCredential is missing",
+			},
+			{
+				status: &TypedID{Type: StatusList2021EntryType, CustomFields: CustomFields{
+					StatusPurpose:        StatusPurposeRevocation,
+					StatusListCredential: "https://example.com/credentials/status/3",
+				}},
+				err: "credential status: statusListIndex is missing",
+			},
+			{
+				status: &TypedID{Type: StatusList2021EntryType, CustomFields: CustomFields{
+					StatusPurpose:        StatusPurposeRevocation,
+					StatusListCredential: "https://example.com/credentials/status/3",
+					StatusListIndex:      "abc",
+				}},
+				err: "credential status: invalid statusListIndex",
+			},
+		} {
+			invalid := *vc
+			invalid.Status = tc.status
+
+			err = invalid.CheckStatus(statusListFetcher(t, StatusPurposeRevocation))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		}
+	})
+}
+
+// statusListFetcher returns a fetcher of a status list credential of did:example:12345 with the bits at indexes set.
+func statusListFetcher(t *testing.T, purpose string, indexes ...int) StatusListFetcher {
+	t.Helper()
+
+	bits := bitstring.New(131072)
+
+	for _, index := range indexes {
+		require.NoError(t, bits.Set(index, true))
+	}
+
+	encodedList, err := bits.Encode()
+	require.NoError(t, err)
+
+	return func(string) (*Credential, error) {
+		return &Credential{
+			Context: []string{baseContext, StatusList2021Context},
+			ID:      "https://example.com/credentials/status/3",
+			Types:   []string{vcType, StatusList2021CredentialType},
+			Issuer:  Issuer{ID: "did:example:12345"},
+			Subject: []Subject{{
+				ID: "https://example.com/credentials/status/3#list",
+				CustomFields: CustomFields{
+					"type":        StatusList2021Type,
+					StatusPurpose: purpose,
+					EncodedList:   encodedList,
+				},
+			}},
+		}, nil
+	}
+}
+
+func copyCustomFields(fields CustomFields) CustomFields {
+	c := CustomFields{}
+
+	for k, v := range fields {
+		c[k] = v
+	}
+
+	return c
+}
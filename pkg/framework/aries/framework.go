@@ -8,11 +8,15 @@ package aries
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	jsonld "github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/middleware"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -35,6 +39,7 @@ import (
 	ldstore "github.com/hyperledger/aries-framework-go/pkg/store/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -82,6 +87,11 @@ type Aries struct {
 	mediaTypeProfiles          []string
 	inboundEnvelopeHandler     inbound.MessageHandler
 	didRotator                 middleware.DIDCommMessageMiddleware
+	metrics                    metrics.Metrics
+	outboundOpts               []outbound.Opt
+	done                       chan struct{}
+	closeDone                  sync.Once
+	recordsWatch               context.RecordsWatch
 }
 
 // Option configures the framework.
@@ -90,7 +100,7 @@ type Option func(opts *Aries) error
 // New initializes the Aries framework based on the set of options provided. This function returns a framework
 // which can be used to manage Aries clients by getting the framework context.
 func New(opts ...Option) (*Aries, error) {
-	frameworkOpts := &Aries{}
+	frameworkOpts := &Aries{done: make(chan struct{})}
 
 	// generate framework configs from options
 	for _, option := range opts {
@@ -218,6 +228,26 @@ func WithStoreProvider(prov storage.Provider) Option {
 	}
 }
 
+// WithMetrics injects metrics to the Aries framework. Framework metrics are discarded if this option is not set.
+func WithMetrics(m metrics.Metrics) Option {
+	return func(opts *Aries) error {
+		opts.metrics = m
+		return nil
+	}
+}
+
+// WithOutbox enables the durable outbox of the outbound dispatcher. Messages which couldn't be sent are queued
+// in the store provider and their delivery is retried with exponential backoff starting at initialInterval and
+// capped at maxInterval. After maxAttempts the message is moved to the dead-letter store. Delivery status events
+// are sent to channels registered with RegisterMsgEvent of the outbound dispatcher (see outbound.WithOutbox).
+// The delivery retries are stopped by Close.
+func WithOutbox(maxAttempts uint64, initialInterval, maxInterval time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.outboundOpts = append(opts.outboundOpts, outbound.WithOutbox(maxAttempts, initialInterval, maxInterval))
+		return nil
+	}
+}
+
 // WithProtocolStateStoreProvider injects a protocol state storage provider to the Aries framework.
 func WithProtocolStateStoreProvider(prov storage.Provider) Option {
 	return func(opts *Aries) error {
@@ -401,6 +431,9 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithServiceMsgTypeTargets(a.servicesMsgTypeTargets...),
 		context.WithDIDRotator(&a.didRotator),
 		context.WithInboundEnvelopeHandler(&a.inboundEnvelopeHandler),
+		context.WithMetrics(a.metrics),
+		context.WithDone(a.done),
+		context.WithRecordsWatch(&a.recordsWatch),
 	)
 }
 
@@ -412,6 +445,18 @@ func (a *Aries) Messenger() service.Messenger {
 
 // Close frees resources being maintained by the framework.
 func (a *Aries) Close() error {
+	// stop the background operations bound to the framework (see context.Provider.Done).
+	if a.done != nil {
+		a.closeDone.Do(func() { close(a.done) })
+	}
+
+	// the outbound dispatcher is closed first as its outbox keeps using the store until it's stopped.
+	if c, ok := a.outboundDispatcher.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			return fmt.Errorf("failed to close the outbound dispatcher: %w", err)
+		}
+	}
+
 	if a.storeProvider != nil {
 		err := a.storeProvider.Close()
 		if err != nil {
@@ -474,6 +519,10 @@ func createKMS(frameworkOpts *Aries) error {
 		return fmt.Errorf("create KMS failed: %w", err)
 	}
 
+	if frameworkOpts.metrics != nil {
+		frameworkOpts.kms = newMetricsKMS(frameworkOpts.kms, frameworkOpts.metrics)
+	}
+
 	return nil
 }
 
@@ -511,10 +560,11 @@ func createVDR(frameworkOpts *Aries) error {
 		vdr.WithVDR(p),
 		vdr.WithDefaultServiceType(dst),
 		vdr.WithDefaultServiceEndpoint(ctx.ServiceEndpoint()),
+		vdr.WithMetrics(frameworkOpts.metrics),
 	)
 
 	k := key.New()
-	opts = append(opts, vdr.WithVDR(k))
+	opts = append(opts, vdr.WithVDR(k), vdr.WithVDR(jwk.New()))
 
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
@@ -575,12 +625,13 @@ func createOutboundDispatcher(frameworkOpts *Aries) error {
 		context.WithMediaTypeProfiles(frameworkOpts.mediaTypeProfiles),
 		context.WithKeyAgreementType(frameworkOpts.keyAgreementType),
 		context.WithDIDRotator(&frameworkOpts.didRotator),
+		context.WithMetrics(frameworkOpts.metrics),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
 	}
 
-	frameworkOpts.outboundDispatcher, err = outbound.NewOutbound(ctx)
+	frameworkOpts.outboundDispatcher, err = outbound.NewOutbound(ctx, frameworkOpts.outboundOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to init outbound dispatcher: %w", err)
 	}
@@ -680,6 +731,7 @@ func startTransports(frameworkOpts *Aries) error {
 		context.WithKeyAgreementType(frameworkOpts.keyAgreementType),
 		context.WithMediaTypeProfiles(frameworkOpts.mediaTypeProfiles),
 		context.WithInboundEnvelopeHandler(&frameworkOpts.inboundEnvelopeHandler),
+		context.WithMetrics(frameworkOpts.metrics),
 	)
 	if err != nil {
 		return fmt.Errorf("context creation failed: %w", err)
@@ -727,6 +779,7 @@ func loadServices(frameworkOpts *Aries) error { // nolint:funlen
 		context.WithInboundEnvelopeHandler(&frameworkOpts.inboundEnvelopeHandler),
 		context.WithServiceMsgTypeTargets(frameworkOpts.servicesMsgTypeTargets...),
 		context.WithDIDRotator(&frameworkOpts.didRotator),
+		context.WithMetrics(frameworkOpts.metrics),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
@@ -796,7 +849,8 @@ func createPackersAndPackager(frameworkOpts *Aries) error {
 	}
 
 	ctx, err = context.New(context.WithPacker(frameworkOpts.primaryPacker, frameworkOpts.packers...),
-		context.WithStorageProvider(frameworkOpts.storeProvider), context.WithVDRegistry(frameworkOpts.vdrRegistry))
+		context.WithStorageProvider(frameworkOpts.storeProvider), context.WithVDRegistry(frameworkOpts.vdrRegistry),
+		context.WithMetrics(frameworkOpts.metrics))
 	if err != nil {
 		return fmt.Errorf("create packager context failed: %w", err)
 	}
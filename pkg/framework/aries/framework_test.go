@@ -18,6 +18,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher/outbound"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
@@ -47,6 +49,7 @@ import (
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockldstore "github.com/hyperledger/aries-framework-go/pkg/mock/ld"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -714,6 +717,77 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, transport.MediaTypeV1EncryptedEnvelope, aries.mediaTypeProfiles[1])
 	})
 
+	t.Run("test new with metrics", func(t *testing.T) {
+		m := &mockmetrics.MockMetrics{}
+
+		aries, err := New(WithMetrics(m), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.Equal(t, m, aries.metrics)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, m, ctx.Metrics())
+
+		_, _, err = ctx.KMS().CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, err)
+		require.Equal(t, 1, m.KMSOperations("create_and_export", string(kms.ED25519Type)))
+
+		_, err = ctx.VDRegistry().Resolve("did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH")
+		require.NoError(t, err)
+		require.Equal(t, 1, m.VDRResolveTimes("key"))
+
+		_, err = ctx.VDRegistry().Resolve("did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3p" +
+			"FakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9")
+		require.NoError(t, err)
+		require.Equal(t, 1, m.VDRResolveTimes("jwk"))
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test context done on close", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		select {
+		case <-ctx.Done():
+			require.Fail(t, "context is done before the framework is closed")
+		default:
+		}
+
+		require.NoError(t, aries.Close())
+		require.NoError(t, aries.Close())
+
+		select {
+		case <-ctx.Done():
+		default:
+			require.Fail(t, "context isn't done after the framework is closed")
+		}
+	})
+
+	t.Run("test new with outbox", func(t *testing.T) {
+		aries, err := New(WithOutbox(5, time.Millisecond, time.Second), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.Len(t, aries.outboundOpts, 1)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg)
+
+		dispatcher, ok := ctx.OutboundDispatcher().(*outbound.Dispatcher)
+		require.True(t, ok)
+		require.NoError(t, dispatcher.RegisterMsgEvent(events))
+
+		deadLetters, err := dispatcher.DeadLetterMessages()
+		require.NoError(t, err)
+		require.Empty(t, deadLetters)
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("failure while creating KMS Aries provider wrapper", func(t *testing.T) {
 		mockStoreProvider := &storage.MockStoreProvider{
 			FailNamespace: kms.AriesWrapperStoreName,
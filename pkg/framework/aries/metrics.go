@@ -0,0 +1,133 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const unknownKeyType = "unknown"
+
+// metricsKMS counts operations of the framework KMS by key type.
+type metricsKMS struct {
+	kms.KeyManager
+	metrics metrics.Metrics
+}
+
+// metricsKeyDeleter counts the deletions of keys of a KMS implementing kms.KeyDeleter.
+type metricsKeyDeleter struct {
+	deleter kms.KeyDeleter
+	metrics metrics.Metrics
+}
+
+type metricsKeyListerKMS struct {
+	*metricsKMS
+	kms.KeyLister
+}
+
+type metricsKeyDeleterKMS struct {
+	*metricsKMS
+	*metricsKeyDeleter
+}
+
+type metricsKeyListerDeleterKMS struct {
+	*metricsKMS
+	kms.KeyLister
+	*metricsKeyDeleter
+}
+
+// newMetricsKMS wraps the KMS to count its operations. The wrapper implements the same optional interfaces
+// (kms.KeyLister and kms.KeyDeleter) as the wrapped KMS, so that type assertions on the framework KMS still work.
+func newMetricsKMS(km kms.KeyManager, m metrics.Metrics) kms.KeyManager {
+	mkms := &metricsKMS{KeyManager: km, metrics: m}
+
+	lister, isLister := km.(kms.KeyLister)
+	deleter, isDeleter := km.(kms.KeyDeleter)
+
+	switch {
+	case isLister && isDeleter:
+		return &metricsKeyListerDeleterKMS{
+			metricsKMS:        mkms,
+			KeyLister:         lister,
+			metricsKeyDeleter: &metricsKeyDeleter{deleter: deleter, metrics: m},
+		}
+	case isLister:
+		return &metricsKeyListerKMS{metricsKMS: mkms, KeyLister: lister}
+	case isDeleter:
+		return &metricsKeyDeleterKMS{
+			metricsKMS:        mkms,
+			metricsKeyDeleter: &metricsKeyDeleter{deleter: deleter, metrics: m},
+		}
+	default:
+		return mkms
+	}
+}
+
+// Create counts and creates a new key.
+func (m *metricsKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interface{}, error) {
+	m.metrics.KMSOperation("create", string(kt))
+
+	return m.KeyManager.Create(kt, opts...)
+}
+
+// Get counts and gets the key handle, key type is unknown before the key is read.
+func (m *metricsKMS) Get(keyID string) (interface{}, error) {
+	m.metrics.KMSOperation("get", unknownKeyType)
+
+	return m.KeyManager.Get(keyID)
+}
+
+// Rotate counts and rotates the key.
+func (m *metricsKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (string, interface{}, error) {
+	m.metrics.KMSOperation("rotate", string(kt))
+
+	return m.KeyManager.Rotate(kt, keyID, opts...)
+}
+
+// ExportPubKeyBytes counts and exports the public key, key type is taken from the exported key.
+func (m *metricsKMS) ExportPubKeyBytes(keyID string) ([]byte, kms.KeyType, error) {
+	pubKey, kt, err := m.KeyManager.ExportPubKeyBytes(keyID)
+
+	keyType := string(kt)
+	if keyType == "" {
+		keyType = unknownKeyType
+	}
+
+	m.metrics.KMSOperation("export", keyType)
+
+	return pubKey, kt, err
+}
+
+// CreateAndExportPubKeyBytes counts, creates the key and exports its public key.
+func (m *metricsKMS) CreateAndExportPubKeyBytes(kt kms.KeyType, opts ...kms.KeyOpts) (string, []byte, error) {
+	m.metrics.KMSOperation("create_and_export", string(kt))
+
+	return m.KeyManager.CreateAndExportPubKeyBytes(kt, opts...)
+}
+
+// PubKeyBytesToHandle counts and converts the public key to a key handle.
+func (m *metricsKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...kms.KeyOpts) (interface{}, error) {
+	m.metrics.KMSOperation("pub_key_to_handle", string(kt))
+
+	return m.KeyManager.PubKeyBytesToHandle(pubKey, kt, opts...)
+}
+
+// ImportPrivateKey counts and imports the private key.
+func (m *metricsKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	m.metrics.KMSOperation("import", string(kt))
+
+	return m.KeyManager.ImportPrivateKey(privKey, kt, opts...)
+}
+
+// Delete counts and deletes the key. Key type is unknown as the key isn't read.
+func (m *metricsKeyDeleter) Delete(keyID string) error {
+	m.metrics.KMSOperation("delete", unknownKeyType)
+
+	return m.deleter.Delete(keyID)
+}
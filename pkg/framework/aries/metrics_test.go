@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
+)
+
+func TestMetricsKMS(t *testing.T) {
+	m := &mockmetrics.MockMetrics{}
+	km := newMetricsKMS(&mockkms.KeyManager{ExportPubKeyTypeValue: kms.ECDSAP256TypeIEEEP1363}, m)
+
+	_, _, err := km.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, err = km.Get("kid")
+	require.NoError(t, err)
+
+	_, _, err = km.Rotate(kms.ED25519Type, "kid")
+	require.NoError(t, err)
+
+	_, kt, err := km.ExportPubKeyBytes("kid")
+	require.NoError(t, err)
+	require.Equal(t, kms.ECDSAP256TypeIEEEP1363, kt)
+
+	_, _, err = km.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+	require.NoError(t, err)
+
+	_, err = km.PubKeyBytesToHandle([]byte("key"), kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, _, err = km.ImportPrivateKey(nil, kms.ED25519Type)
+	require.NoError(t, err)
+
+	lister, ok := km.(kms.KeyLister)
+	require.True(t, ok)
+
+	_, err = lister.List()
+	require.NoError(t, err)
+
+	_, err = lister.GetKeyInfo("kid")
+	require.NoError(t, err)
+
+	require.NoError(t, lister.SetMetadata("kid", map[string]string{"label": "test"}))
+
+	deleter, ok := km.(kms.KeyDeleter)
+	require.True(t, ok)
+
+	require.NoError(t, deleter.Delete("kid"))
+
+	require.Equal(t, 1, m.KMSOperations("create", string(kms.ED25519Type)))
+	require.Equal(t, 1, m.KMSOperations("get", unknownKeyType))
+	require.Equal(t, 1, m.KMSOperations("rotate", string(kms.ED25519Type)))
+	require.Equal(t, 1, m.KMSOperations("export", string(kms.ECDSAP256TypeIEEEP1363)))
+	require.Equal(t, 1, m.KMSOperations("create_and_export", string(kms.X25519ECDHKWType)))
+	require.Equal(t, 1, m.KMSOperations("pub_key_to_handle", string(kms.ED25519Type)))
+	require.Equal(t, 1, m.KMSOperations("import", string(kms.ED25519Type)))
+	require.Equal(t, 1, m.KMSOperations("delete", unknownKeyType))
+
+	t.Run("optional interfaces of the wrapped KMS", func(t *testing.T) {
+		km := newMetricsKMS(&struct{ kms.KeyManager }{&mockkms.KeyManager{}}, m)
+
+		_, isLister := km.(kms.KeyLister)
+		require.False(t, isLister)
+
+		_, isDeleter := km.(kms.KeyDeleter)
+		require.False(t, isDeleter)
+
+		km = newMetricsKMS(&struct {
+			kms.KeyManager
+			kms.KeyLister
+		}{&mockkms.KeyManager{}, &mockkms.KeyManager{}}, m)
+
+		_, isLister = km.(kms.KeyLister)
+		require.True(t, isLister)
+
+		_, isDeleter = km.(kms.KeyDeleter)
+		require.False(t, isDeleter)
+
+		km = newMetricsKMS(&struct {
+			kms.KeyManager
+			kms.KeyDeleter
+		}{&mockkms.KeyManager{}, &mockkms.KeyManager{}}, m)
+
+		_, isLister = km.(kms.KeyLister)
+		require.False(t, isLister)
+
+		deleter, isDeleter := km.(kms.KeyDeleter)
+		require.True(t, isDeleter)
+
+		require.NoError(t, deleter.Delete("kid"))
+		require.Equal(t, 2, m.KMSOperations("delete", unknownKeyType))
+	})
+}
@@ -10,10 +10,12 @@ package context
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	jsonld "github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/middleware"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -70,6 +72,16 @@ type Provider struct {
 	inboundEnvelopeHandler     InboundEnvelopeHandler
 	didRotator                 *middleware.DIDCommMessageMiddleware
 	connectionRecorder         *connection.Recorder
+	metrics                    metrics.Metrics
+	done                       <-chan struct{}
+	recordsWatch               *RecordsWatch
+}
+
+// RecordsWatch is the state, shared by the contexts of a framework, of the watch pushing the record changes of the
+// framework to a notifier. See Provider.StartRecordsWatch.
+type RecordsWatch struct {
+	mutex   sync.Mutex
+	started bool
 }
 
 // InboundEnvelopeHandler handles inbound envelopes, processing then dispatching to a protocol service based on the
@@ -100,6 +112,7 @@ func New(opts ...ProviderOption) (*Provider, error) {
 	ctxProvider := Provider{
 		getDIDsMaxRetries:      defaultGetDIDsMaxRetries,
 		getDIDsBackOffDuration: time.Second,
+		metrics:                metrics.NewNoop(),
 	}
 
 	for _, opt := range opts {
@@ -317,6 +330,41 @@ func (p *Provider) GetDIDsBackOffDuration() time.Duration {
 	return p.getDIDsBackOffDuration
 }
 
+// Metrics returns the framework metrics.
+func (p *Provider) Metrics() metrics.Metrics {
+	return p.metrics
+}
+
+// Done returns a channel closed when the framework of the context is closed, it's nil if the context isn't bound
+// to a framework.
+func (p *Provider) Done() <-chan struct{} {
+	return p.done
+}
+
+// StartRecordsWatch calls start to watch the record changes of the framework of the context, unless they're already
+// watched: the record changes of a framework are watched once, by the first successful call. It returns nil without
+// calling start if the context isn't bound to a framework.
+func (p *Provider) StartRecordsWatch(start func() error) error {
+	if p.recordsWatch == nil {
+		return nil
+	}
+
+	p.recordsWatch.mutex.Lock()
+	defer p.recordsWatch.mutex.Unlock()
+
+	if p.recordsWatch.started {
+		return nil
+	}
+
+	if err := start(); err != nil {
+		return err
+	}
+
+	p.recordsWatch.started = true
+
+	return nil
+}
+
 // InboundMessenger returns inbound messenger.
 func (p *Provider) InboundMessenger() service.InboundMessenger {
 	return p.messenger
@@ -583,3 +631,30 @@ func WithInboundEnvelopeHandler(handler InboundEnvelopeHandler) ProviderOption {
 		return nil
 	}
 }
+
+// WithMetrics injects the framework metrics into the context.
+func WithMetrics(m metrics.Metrics) ProviderOption {
+	return func(opts *Provider) error {
+		if m != nil {
+			opts.metrics = m
+		}
+
+		return nil
+	}
+}
+
+// WithDone injects the channel closed when the framework is closed into the context.
+func WithDone(done <-chan struct{}) ProviderOption {
+	return func(opts *Provider) error {
+		opts.done = done
+		return nil
+	}
+}
+
+// WithRecordsWatch injects the state of the record changes watch of the framework into the context.
+func WithRecordsWatch(w *RecordsWatch) ProviderOption {
+	return func(opts *Provider) error {
+		opts.recordsWatch = w
+		return nil
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	"github.com/hyperledger/aries-framework-go/pkg/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/middleware"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -36,6 +37,7 @@ import (
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/generic"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	mocklock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
@@ -731,6 +733,63 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, mKMS, prov.KMS())
 	})
 
+	t.Run("test new with metrics", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.Equal(t, metrics.NewNoop(), prov.Metrics())
+
+		m := &mockmetrics.MockMetrics{}
+		prov, err = New(WithMetrics(m))
+		require.NoError(t, err)
+		require.Equal(t, m, prov.Metrics())
+	})
+
+	t.Run("test new with done channel", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.Nil(t, prov.Done())
+
+		done := make(chan struct{})
+		prov, err = New(WithDone(done))
+		require.NoError(t, err)
+		require.Equal(t, (<-chan struct{})(done), prov.Done())
+	})
+
+	t.Run("test new with records watch", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+
+		// contexts which aren't bound to a framework don't watch records.
+		require.NoError(t, prov.StartRecordsWatch(func() error {
+			require.Fail(t, "records watch started without a framework")
+
+			return nil
+		}))
+
+		w := &RecordsWatch{}
+
+		prov, err = New(WithRecordsWatch(w))
+		require.NoError(t, err)
+
+		other, err := New(WithRecordsWatch(w))
+		require.NoError(t, err)
+
+		require.EqualError(t, prov.StartRecordsWatch(func() error { return errors.New("watch error") }),
+			"watch error")
+
+		starts := 0
+		start := func() error {
+			starts++
+
+			return nil
+		}
+
+		require.NoError(t, prov.StartRecordsWatch(start))
+		require.NoError(t, other.StartRecordsWatch(start))
+		require.NoError(t, prov.StartRecordsWatch(start))
+		require.Equal(t, 1, starts)
+	})
+
 	t.Run("test new with inbound transport endpoint", func(t *testing.T) {
 		prov, err := New(WithServiceEndpoint("endpoint"))
 		require.NoError(t, err)
@@ -128,6 +128,20 @@ func (mr *MockKeyManagerMockRecorder) CreateAndExportPubKeyBytes(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndExportPubKeyBytes", reflect.TypeOf((*MockKeyManager)(nil).CreateAndExportPubKeyBytes), varargs...)
 }
 
+// Delete mocks base method.
+func (m *MockKeyManager) Delete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKeyManagerMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKeyManager)(nil).Delete), arg0)
+}
+
 // ExportPubKeyBytes mocks base method.
 func (m *MockKeyManager) ExportPubKeyBytes(arg0 string) ([]byte, kms.KeyType, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +173,21 @@ func (mr *MockKeyManagerMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeyManager)(nil).Get), arg0)
 }
 
+// GetKeyInfo mocks base method.
+func (m *MockKeyManager) GetKeyInfo(arg0 string) (*kms.KeyInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyInfo", arg0)
+	ret0, _ := ret[0].(*kms.KeyInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyInfo indicates an expected call of GetKeyInfo.
+func (mr *MockKeyManagerMockRecorder) GetKeyInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyInfo", reflect.TypeOf((*MockKeyManager)(nil).GetKeyInfo), arg0)
+}
+
 // ImportPrivateKey mocks base method.
 func (m *MockKeyManager) ImportPrivateKey(arg0 interface{}, arg1 kms.KeyType, arg2 ...kms.PrivateKeyOpts) (string, interface{}, error) {
 	m.ctrl.T.Helper()
@@ -180,6 +209,21 @@ func (mr *MockKeyManagerMockRecorder) ImportPrivateKey(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPrivateKey", reflect.TypeOf((*MockKeyManager)(nil).ImportPrivateKey), varargs...)
 }
 
+// List mocks base method.
+func (m *MockKeyManager) List() ([]kms.KeyInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]kms.KeyInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockKeyManagerMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeyManager)(nil).List))
+}
+
 // PubKeyBytesToHandle mocks base method.
 func (m *MockKeyManager) PubKeyBytesToHandle(arg0 []byte, arg1 kms.KeyType, arg2 ...kms.KeyOpts) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -220,3 +264,17 @@ func (mr *MockKeyManagerMockRecorder) Rotate(arg0, arg1 interface{}, arg2 ...int
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockKeyManager)(nil).Rotate), varargs...)
 }
+
+// SetMetadata mocks base method.
+func (m *MockKeyManager) SetMetadata(arg0 string, arg1 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMetadata", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMetadata indicates an expected call of SetMetadata.
+func (mr *MockKeyManagerMockRecorder) SetMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadata", reflect.TypeOf((*MockKeyManager)(nil).SetMetadata), arg0, arg1)
+}
@@ -9,6 +9,7 @@ package kms
 import (
 	"errors"
 	"io"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 )
@@ -72,6 +73,47 @@ type KeyManager interface {
 	ImportPrivateKey(privKey interface{}, kt KeyType, opts ...PrivateKeyOpts) (string, interface{}, error)
 }
 
+// KeyLister is an optional interface of a KeyManager able to list its keys and manage their information.
+// Users of a KeyManager type assert it to find out whether it's supported.
+type KeyLister interface {
+	// List returns the information of the keys managed by the KMS.
+	// Returns:
+	//  - information of the keys, including their user-defined metadata tags
+	//  - error if failure
+	List() ([]KeyInfo, error)
+	// GetKeyInfo returns the information of the key referenced by keyID.
+	// Returns:
+	//  - information of the key, including its user-defined metadata tags
+	//  - error wrapping ErrKeyNotFound if there is no information for keyID, or another error if failure
+	GetKeyInfo(keyID string) (*KeyInfo, error)
+	// SetMetadata replaces the user-defined metadata tags of the key referenced by keyID. An empty metadata removes
+	// all the tags of the key.
+	// Returns:
+	//  - error wrapping ErrKeyNotFound if there is no information for keyID, or another error if failure
+	SetMetadata(keyID string, metadata map[string]string) error
+}
+
+// KeyDeleter is an optional interface of a KeyManager able to delete its keys.
+// Users of a KeyManager type assert it to find out whether it's supported.
+type KeyDeleter interface {
+	// Delete securely deletes the key referenced by keyID and its information. The key can't be used afterwards.
+	// Returns:
+	//  - error wrapping ErrKeyNotFound if there is no key for keyID, or another error if failure
+	Delete(keyID string) error
+}
+
+// KeyInfo is the information of a key managed by a KeyManager.
+type KeyInfo struct {
+	// KeyID is the ID of the key (keyset).
+	KeyID string `json:"keyID"`
+	// KeyType is the type the key was created or imported with.
+	KeyType KeyType `json:"keyType"`
+	// CreatedAt is the time the key was created or imported.
+	CreatedAt time.Time `json:"createdAt"`
+	// Metadata are the user-defined metadata tags of the key (e.g. labels).
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
 // ErrKeyNotFound is an error type that a KMS expects from the Store.Get method if no key stored under the given
 // key ID could be found.
 var ErrKeyNotFound = errors.New("key not found")
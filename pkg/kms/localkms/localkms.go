@@ -10,9 +10,11 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/google/tink/go/aead"
 	"github.com/google/tink/go/keyset"
@@ -49,10 +51,16 @@ type LocalKMS struct {
 	primaryKeyURI     string
 	store             kms.Store
 	primaryKeyEnvAEAD *aead.KMSEnvelopeAEAD
+	keyInfosMutex     sync.Mutex
 }
 
 // New will create a new (local) KMS service.
 func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
+	err := registerKeyManagers()
+	if err != nil {
+		return nil, fmt.Errorf("new: failed to register key managers: %w", err)
+	}
+
 	secretLock := p.SecretLock()
 
 	kw, err := keywrapper.New(secretLock, primaryKeyURI)
@@ -102,6 +110,11 @@ func (l *LocalKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interfac
 		return "", nil, fmt.Errorf("create: failed to store keyset: %w", err)
 	}
 
+	err = l.addKeyInfo(keyID, kt, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to store key info: %w", err)
+	}
+
 	return keyID, kh, nil
 }
 
@@ -152,6 +165,11 @@ func (l *LocalKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (st
 		return "", nil, fmt.Errorf("rotate: failed to store keySet: %w", err)
 	}
 
+	err = l.replaceKeyInfo(keyID, newID, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: failed to store key info: %w", err)
+	}
+
 	return newID, updatedKH, nil
 }
 
@@ -307,8 +325,10 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...km
 
 // ImportPrivateKey will import privKey into the KMS storage for the given keyType then returns the new key id and
 // the newly persisted Handle.
-// 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey
-// 'keyType' possible types are signing key types only (ECDSA keys or Ed25519)
+// 'privKey' possible types are: *ecdsa.PrivateKey, ed25519.PrivateKey, *rsa.PrivateKey, *bbs12381g2pub.PrivateKey and
+// *cryptoapi.PrivateKey (X25519 key)
+// 'keyType' possible types are signing key types (ECDSA keys including secp256k1, Ed25519, RSARS256, RSAPS256 or
+// BLS12381G2) and ECDH-KW key types (NIST P curves with an *ecdsa.PrivateKey or X25519 with a *cryptoapi.PrivateKey)
 // 'opts' allows setting the keysetID of the imported key using WithKeyID() option. If the ID is already used,
 // then an error is returned.
 // Returns:
@@ -317,16 +337,37 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...km
 //  - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
 func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	var (
+		keyID string
+		kh    *keyset.Handle
+		err   error
+	)
+
 	switch pk := privKey.(type) {
 	case *ecdsa.PrivateKey:
-		return l.importECDSAKey(pk, kt, opts...)
+		keyID, kh, err = l.importECDSAKey(pk, kt, opts...)
 	case ed25519.PrivateKey:
-		return l.importEd25519Key(pk, kt, opts...)
+		keyID, kh, err = l.importEd25519Key(pk, kt, opts...)
+	case *rsa.PrivateKey:
+		keyID, kh, err = l.importRSAKey(pk, kt, opts...)
 	case *bbs12381g2pub.PrivateKey:
-		return l.importBBSKey(pk, kt, opts...)
+		keyID, kh, err = l.importBBSKey(pk, kt, opts...)
+	case *cryptoapi.PrivateKey:
+		keyID, kh, err = l.importX25519Key(pk, kt, opts...)
 	default:
 		return "", nil, fmt.Errorf("import private key does not support this key type or key is public")
 	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = l.addKeyInfo(keyID, kt, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private key: failed to store key info: %w", err)
+	}
+
+	return keyID, kh, nil
 }
 
 func (l *LocalKMS) generateKID(kh *keyset.Handle, kt kms.KeyType) (string, error) {
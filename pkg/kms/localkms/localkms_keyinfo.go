@@ -0,0 +1,319 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/tink/go/subtle/random"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	// reservedKeyIDPrefix prefixes the IDs of the records stored by LocalKMS along with the keys in the KMS store.
+	// Generated key IDs are base64URL encoded, which has no ':', and key IDs requested on import can't start with this
+	// prefix (see storeWriter), so these records never collide with keys.
+	reservedKeyIDPrefix = "localkms:"
+	// keyInfoPrefix prefixes the ID under which the information of a key is stored in the KMS store, one record per
+	// key: localkms:keyinfo:<keyID>.
+	keyInfoPrefix = reservedKeyIDPrefix + "keyinfo:"
+	// keyIDsID is the ID under which the IDs of the keys having information are stored in the KMS store, so that
+	// they can be listed.
+	keyIDsID = reservedKeyIDPrefix + "keyids"
+)
+
+// List returns the information of the keys managed by this KMS, ordered by creation time.
+// The information of a key is recorded when it's created, rotated or imported. Keys stored without information (e.g. by
+// a former version of LocalKMS) aren't listed.
+// Returns:
+//  - information of the keys, including their user-defined metadata tags
+//  - error if failure
+func (l *LocalKMS) List() ([]kms.KeyInfo, error) {
+	keyIDs, err := l.readKeyIDs()
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	list := make([]kms.KeyInfo, 0, len(keyIDs))
+
+	for _, keyID := range keyIDs {
+		info, e := l.readKeyInfo(keyID)
+		if e != nil {
+			return nil, fmt.Errorf("list: %w", e)
+		}
+
+		list = append(list, *info)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+
+		return list[i].KeyID < list[j].KeyID
+	})
+
+	return list, nil
+}
+
+// GetKeyInfo returns the information of the key referenced by keyID.
+// Returns:
+//  - information of the key, including its user-defined metadata tags
+//  - error wrapping kms.ErrKeyNotFound if there is no information for keyID, or another error if failure
+func (l *LocalKMS) GetKeyInfo(keyID string) (*kms.KeyInfo, error) {
+	info, err := l.readKeyInfo(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("getKeyInfo: %w", err)
+	}
+
+	return info, nil
+}
+
+// SetMetadata replaces the user-defined metadata tags of the key referenced by keyID. An empty metadata removes all
+// the tags of the key. Metadata tags are stored unencrypted.
+// Returns:
+//  - error wrapping kms.ErrKeyNotFound if there is no information for keyID, or another error if failure
+func (l *LocalKMS) SetMetadata(keyID string, metadata map[string]string) error {
+	l.keyInfosMutex.Lock()
+	defer l.keyInfosMutex.Unlock()
+
+	info, err := l.readKeyInfo(keyID)
+	if err != nil {
+		return fmt.Errorf("setMetadata: %w", err)
+	}
+
+	info.Metadata = copyMetadata(metadata)
+
+	err = l.putKeyInfo(info)
+	if err != nil {
+		return fmt.Errorf("setMetadata: %w", err)
+	}
+
+	return nil
+}
+
+// Delete securely deletes the key referenced by keyID and its information. The stored (encrypted) keyset is first
+// overwritten with random bytes, so that the key can't be recovered from storage keeping former values of the data.
+// Returns:
+//  - error wrapping kms.ErrKeyNotFound if there is no key for keyID, or another error if failure
+func (l *LocalKMS) Delete(keyID string) error {
+	if isReservedKeyID(keyID) {
+		return fmt.Errorf("delete: key '%s' is reserved: %w", keyID, kms.ErrKeyNotFound)
+	}
+
+	key, err := l.store.Get(keyID)
+	if err != nil {
+		return fmt.Errorf("delete: failed to get key '%s': %w", keyID, err)
+	}
+
+	err = l.store.Put(keyID, random.GetRandomBytes(uint32(len(key))))
+	if err != nil {
+		return fmt.Errorf("delete: failed to overwrite key '%s': %w", keyID, err)
+	}
+
+	err = l.store.Delete(keyID)
+	if err != nil {
+		return fmt.Errorf("delete: failed to delete key '%s': %w", keyID, err)
+	}
+
+	err = l.removeKeyInfo(keyID)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// addKeyInfo records the information of a new key.
+func (l *LocalKMS) addKeyInfo(keyID string, kt kms.KeyType, metadata map[string]string) error {
+	l.keyInfosMutex.Lock()
+	defer l.keyInfosMutex.Unlock()
+
+	err := l.putKeyInfo(&kms.KeyInfo{
+		KeyID:     keyID,
+		KeyType:   kt,
+		CreatedAt: time.Now().UTC(),
+		Metadata:  copyMetadata(metadata),
+	})
+	if err != nil {
+		return err
+	}
+
+	return l.updateKeyIDs(func(keyIDs []string) []string {
+		return append(removeKeyID(keyIDs, keyID), keyID)
+	})
+}
+
+// replaceKeyInfo records the information of the key replacing oldKeyID (i.e. rotating it), the user-defined metadata
+// tags of the old key are kept.
+func (l *LocalKMS) replaceKeyInfo(oldKeyID, keyID string, kt kms.KeyType) error {
+	l.keyInfosMutex.Lock()
+	defer l.keyInfosMutex.Unlock()
+
+	var metadata map[string]string
+
+	oldInfo, err := l.readKeyInfo(oldKeyID)
+	if err == nil {
+		metadata = oldInfo.Metadata
+	} else if !errors.Is(err, kms.ErrKeyNotFound) {
+		return err
+	}
+
+	err = l.putKeyInfo(&kms.KeyInfo{
+		KeyID:     keyID,
+		KeyType:   kt,
+		CreatedAt: time.Now().UTC(),
+		Metadata:  metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = l.updateKeyIDs(func(keyIDs []string) []string {
+		return append(removeKeyID(removeKeyID(keyIDs, oldKeyID), keyID), keyID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return l.deleteKeyInfo(oldKeyID)
+}
+
+// removeKeyInfo deletes the information of a deleted key.
+func (l *LocalKMS) removeKeyInfo(keyID string) error {
+	l.keyInfosMutex.Lock()
+	defer l.keyInfosMutex.Unlock()
+
+	err := l.updateKeyIDs(func(keyIDs []string) []string {
+		return removeKeyID(keyIDs, keyID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return l.deleteKeyInfo(keyID)
+}
+
+func (l *LocalKMS) readKeyInfo(keyID string) (*kms.KeyInfo, error) {
+	data, err := l.store.Get(keyInfoPrefix + keyID)
+	if err != nil {
+		if errors.Is(err, kms.ErrKeyNotFound) {
+			return nil, fmt.Errorf("no information for key '%s': %w", keyID, kms.ErrKeyNotFound)
+		}
+
+		return nil, fmt.Errorf("failed to get key info: %w", err)
+	}
+
+	info := &kms.KeyInfo{}
+
+	err = json.Unmarshal(data, info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key info: %w", err)
+	}
+
+	return info, nil
+}
+
+func (l *LocalKMS) putKeyInfo(info *kms.KeyInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key info: %w", err)
+	}
+
+	err = l.store.Put(keyInfoPrefix+info.KeyID, data)
+	if err != nil {
+		return fmt.Errorf("failed to store key info: %w", err)
+	}
+
+	return nil
+}
+
+func (l *LocalKMS) deleteKeyInfo(keyID string) error {
+	err := l.store.Delete(keyInfoPrefix + keyID)
+	if err != nil {
+		return fmt.Errorf("failed to delete key info: %w", err)
+	}
+
+	return nil
+}
+
+func (l *LocalKMS) updateKeyIDs(update func(keyIDs []string) []string) error {
+	keyIDs, err := l.readKeyIDs()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(update(keyIDs))
+	if err != nil {
+		return fmt.Errorf("failed to marshal key IDs: %w", err)
+	}
+
+	err = l.store.Put(keyIDsID, data)
+	if err != nil {
+		return fmt.Errorf("failed to store key IDs: %w", err)
+	}
+
+	return nil
+}
+
+func (l *LocalKMS) readKeyIDs() ([]string, error) {
+	data, err := l.store.Get(keyIDsID)
+	if err != nil {
+		if errors.Is(err, kms.ErrKeyNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get key IDs: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var keyIDs []string
+
+	err = json.Unmarshal(data, &keyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key IDs: %w", err)
+	}
+
+	return keyIDs, nil
+}
+
+func isReservedKeyID(keyID string) bool {
+	return strings.HasPrefix(keyID, reservedKeyIDPrefix)
+}
+
+func removeKeyID(keyIDs []string, keyID string) []string {
+	for i, id := range keyIDs {
+		if id == keyID {
+			return append(keyIDs[:i], keyIDs[i+1:]...)
+		}
+	}
+
+	return keyIDs
+}
+
+func copyMetadata(metadata map[string]string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	c := make(map[string]string, len(metadata))
+
+	for k, v := range metadata {
+		c[k] = v
+	}
+
+	return c
+}
@@ -0,0 +1,267 @@
+/*
+ Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+ SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestLocalKMS_KeyInfo(t *testing.T) {
+	t.Run("success - keys are listed with their information", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+
+		kmsService, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		infos, err := kmsService.List()
+		require.NoError(t, err)
+		require.Empty(t, infos)
+
+		aesKID, _, err := kmsService.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		importedKID, _, err := kmsService.ImportPrivateKey(privKey, kms.ED25519Type)
+		require.NoError(t, err)
+
+		infos, err = kmsService.List()
+		require.NoError(t, err)
+		require.Len(t, infos, 2)
+
+		kids := map[kms.KeyType]string{}
+
+		for _, info := range infos {
+			require.False(t, info.CreatedAt.IsZero())
+			require.Empty(t, info.Metadata)
+
+			kids[info.KeyType] = info.KeyID
+		}
+
+		require.Equal(t, map[kms.KeyType]string{kms.AES256GCMType: aesKID, kms.ED25519Type: importedKID}, kids)
+
+		// a new instance over the same store lists the same keys.
+		kmsService2, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		infos2, err := kmsService2.List()
+		require.NoError(t, err)
+		require.Equal(t, infos, infos2)
+	})
+
+	t.Run("success - metadata tags are set and kept on rotation", func(t *testing.T) {
+		kmsService, err := New(testMasterKeyURI, &mockProvider{storage: newInMemoryKMSStore(), secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		kid, _, err := kmsService.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		metadata := map[string]string{"label": "signing", "owner": "alice"}
+
+		require.NoError(t, kmsService.SetMetadata(kid, metadata))
+
+		// metadata is copied.
+		metadata["label"] = "updated"
+
+		info, err := kmsService.GetKeyInfo(kid)
+		require.NoError(t, err)
+		require.Equal(t, kid, info.KeyID)
+		require.Equal(t, kms.ED25519Type, info.KeyType)
+		require.Equal(t, map[string]string{"label": "signing", "owner": "alice"}, info.Metadata)
+
+		newKID, _, err := kmsService.Rotate(kms.ED25519Type, kid)
+		require.NoError(t, err)
+
+		_, err = kmsService.GetKeyInfo(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		info, err = kmsService.GetKeyInfo(newKID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"label": "signing", "owner": "alice"}, info.Metadata)
+
+		require.NoError(t, kmsService.SetMetadata(newKID, nil))
+
+		info, err = kmsService.GetKeyInfo(newKID)
+		require.NoError(t, err)
+		require.Empty(t, info.Metadata)
+	})
+
+	t.Run("success - delete key", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+
+		kmsService, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		kid, _, err := kmsService.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		require.NoError(t, kmsService.Delete(kid))
+
+		_, err = kmsService.Get(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		_, err = kmsService.GetKeyInfo(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		infos, err := kmsService.List()
+		require.NoError(t, err)
+		require.Empty(t, infos)
+
+		err = kmsService.Delete(kid)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		err = kmsService.Delete(keyIDsID)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		err = kmsService.Delete(keyInfoPrefix + kid)
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+	})
+
+	t.Run("error - unknown key", func(t *testing.T) {
+		kmsService, err := New(testMasterKeyURI, &mockProvider{storage: newInMemoryKMSStore(), secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		_, err = kmsService.GetKeyInfo("unknown")
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		err = kmsService.SetMetadata("unknown", map[string]string{"label": "value"})
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+	})
+
+	t.Run("error - store failures", func(t *testing.T) {
+		errGet := errors.New("get error")
+
+		kmsService, err := New(testMasterKeyURI, &mockProvider{
+			storage:    &mockStore{errGet: errGet},
+			secretLock: &noop.NoLock{},
+		})
+		require.NoError(t, err)
+
+		_, err = kmsService.List()
+		require.True(t, errors.Is(err, errGet))
+
+		_, err = kmsService.GetKeyInfo("kid")
+		require.True(t, errors.Is(err, errGet))
+
+		err = kmsService.SetMetadata("kid", nil)
+		require.True(t, errors.Is(err, errGet))
+
+		err = kmsService.Delete("kid")
+		require.True(t, errors.Is(err, errGet))
+
+		errPut := errors.New("put error")
+
+		kmsService, err = New(testMasterKeyURI, &mockProvider{
+			storage:    &mockStore{errPut: errPut},
+			secretLock: &noop.NoLock{},
+		})
+		require.NoError(t, err)
+
+		err = kmsService.Delete("kid")
+		require.True(t, errors.Is(err, errPut))
+	})
+
+	t.Run("error - corrupted key infos", func(t *testing.T) {
+		store := newInMemoryKMSStore()
+		require.NoError(t, store.Put(keyIDsID, []byte("not json")))
+
+		kmsService, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		_, err = kmsService.List()
+		require.Contains(t, err.Error(), "failed to unmarshal key IDs")
+
+		_, _, err = kmsService.Create(kms.AES256GCMType)
+		require.Contains(t, err.Error(), "create: failed to store key info")
+
+		store = newInMemoryKMSStore()
+
+		kmsService, err = New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		kid, _, err := kmsService.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		require.NoError(t, store.Put(keyInfoPrefix+kid, []byte("not json")))
+
+		_, err = kmsService.List()
+		require.Contains(t, err.Error(), "failed to unmarshal key info")
+
+		_, err = kmsService.GetKeyInfo(kid)
+		require.Contains(t, err.Error(), "failed to unmarshal key info")
+	})
+}
+
+func TestLocalKMS_KeyInfoRecords(t *testing.T) {
+	store := newInMemoryKMSStore()
+
+	kmsService, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+	require.NoError(t, err)
+
+	kid1, _, err := kmsService.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	kid2, _, err := kmsService.Create(kms.AES256GCMType)
+	require.NoError(t, err)
+
+	// the information of each key is stored in its own record.
+	require.Contains(t, store.keys, keyInfoPrefix+kid1)
+	require.Contains(t, store.keys, keyInfoPrefix+kid2)
+
+	// setting metadata only rewrites the record of the key.
+	keyIDs := store.keys[keyIDsID]
+	info2 := store.keys[keyInfoPrefix+kid2]
+
+	require.NoError(t, kmsService.SetMetadata(kid1, map[string]string{"label": "signing"}))
+	require.Equal(t, keyIDs, store.keys[keyIDsID])
+	require.Equal(t, info2, store.keys[keyInfoPrefix+kid2])
+
+	newKID1, _, err := kmsService.Rotate(kms.ED25519Type, kid1)
+	require.NoError(t, err)
+	require.NotContains(t, store.keys, keyInfoPrefix+kid1)
+	require.Contains(t, store.keys, keyInfoPrefix+newKID1)
+
+	require.NoError(t, kmsService.Delete(kid2))
+	require.NotContains(t, store.keys, keyInfoPrefix+kid2)
+
+	infos, err := kmsService.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, newKID1, infos[0].KeyID)
+	require.Equal(t, map[string]string{"label": "signing"}, infos[0].Metadata)
+
+	// key IDs requested on import can't collide with the records of the KMS.
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	importedKID, _, err := kmsService.ImportPrivateKey(privKey, kms.ED25519Type, kms.WithKeyID("keyinfo_"+newKID1))
+	require.NoError(t, err)
+	require.Equal(t, "keyinfo_"+newKID1, importedKID)
+
+	info, err := kmsService.GetKeyInfo(newKID1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"label": "signing"}, info.Metadata)
+
+	for _, reservedID := range []string{keyIDsID, keyInfoPrefix + newKID1} {
+		_, _, err = kmsService.ImportPrivateKey(privKey, kms.ED25519Type, kms.WithKeyID(reservedID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is reserved")
+	}
+
+	infos, err = kmsService.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+}
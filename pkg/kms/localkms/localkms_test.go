@@ -11,6 +11,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
@@ -22,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/subtle/random"
 	"github.com/stretchr/testify/require"
@@ -29,6 +31,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	mocksecretlock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
@@ -478,6 +481,10 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) { // nolint:gocyclo
 			tcName:  "import private key using BLS12381G2Type type",
 			keyType: kms.BLS12381G2Type,
 		},
+		{
+			tcName:  "import private key using X25519ECDHKWType type",
+			keyType: kms.X25519ECDHKWType,
+		},
 		{
 			tcName:  "import private key using ECDSAP256DER type and a set empty KeyID",
 			keyType: kms.ECDSAP256TypeDER,
@@ -520,6 +527,52 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) { // nolint:gocyclo
 				return
 			}
 
+			if tt.keyType == kms.X25519ECDHKWType {
+				edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+				require.NoError(t, err)
+
+				pubKey, err := cryptoutil.PublicEd25519toCurve25519(edPubKey)
+				require.NoError(t, err)
+
+				privKey, err := cryptoutil.SecretEd25519toCurve25519(edPrivKey)
+				require.NoError(t, err)
+
+				ksID, kh, err := kmsService.ImportPrivateKey(&crypto.PrivateKey{
+					PublicKey: crypto.PublicKey{X: pubKey, Curve: "X25519", Type: "OKP"},
+					D:         privKey,
+				}, tt.keyType)
+				require.NoError(t, err)
+
+				pubKeyBytes, kt, err := kmsService.ExportPubKeyBytes(ksID)
+				require.NoError(t, err)
+				require.Equal(t, tt.keyType, kt)
+
+				exportedKey := &crypto.PublicKey{}
+				require.NoError(t, json.Unmarshal(pubKeyBytes, exportedKey))
+				require.EqualValues(t, pubKey, exportedKey.X)
+
+				// the imported key unwraps keys wrapped for its public key.
+				c, err := tinkcrypto.New()
+				require.NoError(t, err)
+
+				cek := random.GetRandomBytes(32)
+
+				wrappedKey, err := c.WrapKey(cek, nil, nil, exportedKey)
+				require.NoError(t, err)
+
+				unwrappedKey, err := c.UnwrapKey(wrappedKey, kh)
+				require.NoError(t, err)
+				require.Equal(t, cek, unwrappedKey)
+
+				_, _, err = kmsService.ImportPrivateKey(&crypto.PrivateKey{D: privKey}, tt.keyType)
+				require.EqualError(t, err, "import private X25519 key failed: invalid key size")
+
+				_, _, err = kmsService.ImportPrivateKey(&crypto.PrivateKey{}, kms.ED25519Type)
+				require.EqualError(t, err, "import private X25519 key failed: invalid key type")
+
+				return
+			}
+
 			if tt.keyType == kms.BLS12381G2Type {
 				seed := make([]byte, 32)
 
@@ -604,6 +657,67 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) { // nolint:gocyclo
 	}
 }
 
+func TestLocalKMS_ImportRSAAndSecp256k1Keys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	tests := []struct {
+		keyType     kms.KeyType
+		privKey     interface{}
+		pubKeyBytes []byte
+	}{
+		{
+			keyType:     kms.RSARS256Type,
+			privKey:     rsaKey,
+			pubKeyBytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey),
+		},
+		{
+			keyType:     kms.RSAPS256Type,
+			privKey:     rsaKey,
+			pubKeyBytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey),
+		},
+		{
+			keyType:     kms.ECDSASecp256k1TypeIEEEP1363,
+			privKey:     secp256k1Key,
+			pubKeyBytes: (*btcec.PublicKey)(&secp256k1Key.PublicKey).SerializeUncompressed(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.keyType), func(t *testing.T) {
+			kmsService := createKMS(t)
+
+			ksID, kh, err := kmsService.ImportPrivateKey(tt.privKey, tt.keyType)
+			require.NoError(t, err)
+
+			pubKeyBytes, kt, err := kmsService.ExportPubKeyBytes(ksID)
+			require.NoError(t, err)
+			require.Equal(t, tt.keyType, kt)
+			require.Equal(t, tt.pubKeyBytes, pubKeyBytes)
+
+			// the imported key signs messages, its exported public key verifies the signatures.
+			msg := []byte("test message")
+
+			sig, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			pubKH, err := kmsService.PubKeyBytesToHandle(pubKeyBytes, kt)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Verify(sig, msg, pubKH))
+			require.Error(t, c.Verify(sig, []byte("other message"), pubKH))
+
+		})
+	}
+}
+
 func TestLocalKMS_getKeyTemplate(t *testing.T) {
 	keyTemplate, err := getKeyTemplate(kms.HMACSHA256Tag256Type)
 	require.NoError(t, err)
@@ -109,4 +109,13 @@ func TestLocalKMSWriter(t *testing.T) {
 		require.EqualError(t, err, fmt.Sprintf("requested ID '%s' already exists, cannot write keyset",
 			l.KeysetID))
 	})
+	t.Run("error case - import with a reserved keysetID", func(t *testing.T) {
+		mockStore := newInMemoryKMSStore()
+
+		l := newWriter(mockStore, kms.WithKeyID(keyIDsID))
+
+		_, err := l.Write(random.GetRandomBytes(uint32(32)))
+		require.EqualError(t, err, fmt.Sprintf("requested ID '%s' is reserved, cannot write keyset", keyIDsID))
+		require.Empty(t, mockStore.keys)
+	})
 }
@@ -10,20 +10,30 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
 	"fmt"
+	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/keyset"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
 	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	rsassapkcs1pb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	rsassapsspb "github.com/google/tink/go/proto/rsa_ssa_pss_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
 	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 	clpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/cl_go_proto"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/rsassa"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -32,13 +42,31 @@ const (
 	ed25519SignerTypeURL = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
 	bbsSignerKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
 
-	nistpECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey"
+	secp256k1SignerTypeURL   = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
+	rsaSSAPKCS1SignerTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPkcs1PrivateKey"
+	rsaSSAPSSSignerTypeURL   = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPssPrivateKey"
+
+	nistpECDHKWPrivateKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey"
+	x25519ECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPrivateKey"
 )
 
+// registerKeyManagers registers the key managers of the RSA and secp256k1 keys in the Tink registry, Tink doesn't
+// support them.
+func registerKeyManagers() error {
+	if err := rsassa.Register(); err != nil {
+		return err
+	}
+
+	return secp256k1.Register()
+}
+
+// nolint:funlen,gocyclo
 func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
 	var params *ecdsapb.EcdsaParams
 
+	typeURL := ecdsaSignerTypeURL
+
 	err := validECPrivateKey(privKey)
 	if err != nil {
 		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
@@ -83,6 +111,17 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 			Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
 			HashType: commonpb.HashType_SHA512,
 		}
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		if !btcec.S256().IsOnCurve(privKey.X, privKey.Y) {
+			return "", nil, fmt.Errorf("import private EC key failed: key is not on the secp256k1 curve")
+		}
+
+		// the curve of secp256k1 keys is set by their type URL, Tink has no value for it.
+		typeURL = secp256k1SignerTypeURL
+		params = &ecdsapb.EcdsaParams{
+			Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+			HashType: commonpb.HashType_SHA256,
+		}
 	default:
 		return "", nil, fmt.Errorf("import private EC key failed: invalid ECDSA key type")
 	}
@@ -92,7 +131,7 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
 	}
 
-	ks := newKeySet(ecdsaSignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+	ks := newKeySet(typeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
 
 	return l.importKeySet(ks, opts...)
 }
@@ -138,6 +177,47 @@ func (l *LocalKMS) buildAndImportECDSAPrivateKeyAsECDHKW(privKey *ecdsa.PrivateK
 	return l.importKeySet(ks, opts...)
 }
 
+func (l *LocalKMS) importX25519Key(privKey *cryptoapi.PrivateKey, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	if privKey == nil {
+		return "", nil, fmt.Errorf("import private X25519 key failed: private key is nil")
+	}
+
+	if kt != kms.X25519ECDHKWType {
+		return "", nil, fmt.Errorf("import private X25519 key failed: invalid key type")
+	}
+
+	if len(privKey.D) != cryptoutil.Curve25519KeySize || len(privKey.PublicKey.X) != cryptoutil.Curve25519KeySize {
+		return "", nil, fmt.Errorf("import private X25519 key failed: invalid key size")
+	}
+
+	keyFormat := new(ecdhpb.EcdhAeadKeyFormat)
+
+	err := proto.Unmarshal(ecdh.X25519ECDHKWKeyTemplate().Value, keyFormat)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid key format")
+	}
+
+	priv := &ecdhpb.EcdhAeadPrivateKey{
+		Version:  0,
+		KeyValue: privKey.D,
+		PublicKey: &ecdhpb.EcdhAeadPublicKey{
+			Version: 0,
+			Params:  keyFormat.Params,
+			X:       privKey.PublicKey.X,
+		},
+	}
+
+	privBytes, err := proto.Marshal(priv)
+	if err != nil {
+		return "", nil, fmt.Errorf("marshal protobuf: %w", err)
+	}
+
+	ks := newKeySet(x25519ECDHKWPrivateKeyTypeURL, privBytes, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+
+	return l.importKeySet(ks, opts...)
+}
+
 func (l *LocalKMS) importKeySet(ks *tinkpb.Keyset, opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
 	ksID, err := l.writeImportedKey(ks, opts...)
 	if err != nil {
@@ -207,6 +287,46 @@ func (l *LocalKMS) importBBSKey(privKey *bbs12381g2pub.PrivateKey, kt kms.KeyTyp
 	return l.importKeySet(ks, opts...)
 }
 
+func (l *LocalKMS) importRSAKey(privKey *rsa.PrivateKey, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	if privKey == nil {
+		return "", nil, fmt.Errorf("import private RSA key failed: private key is nil")
+	}
+
+	err := privKey.Validate()
+	if err != nil {
+		return "", nil, fmt.Errorf("import private RSA key failed: %w", err)
+	}
+
+	if len(privKey.Primes) != 2 { // nolint:gomnd
+		return "", nil, fmt.Errorf("import private RSA key failed: multi-prime keys are not supported")
+	}
+
+	var (
+		typeURL   string
+		mKeyValue []byte
+	)
+
+	switch kt {
+	case kms.RSARS256Type:
+		typeURL = rsaSSAPKCS1SignerTypeURL
+		mKeyValue, err = proto.Marshal(newProtoRSASSAPKCS1PrivateKey(privKey))
+	case kms.RSAPS256Type:
+		typeURL = rsaSSAPSSSignerTypeURL
+		mKeyValue, err = proto.Marshal(newProtoRSASSAPSSPrivateKey(privKey))
+	default:
+		return "", nil, fmt.Errorf("import private RSA key failed: invalid key type")
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("import private RSA key failed: %w", err)
+	}
+
+	ks := newKeySet(typeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+
+	return l.importKeySet(ks, opts...)
+}
+
 func validECPrivateKey(privateKey *ecdsa.PrivateKey) error {
 	if privateKey == nil {
 		return fmt.Errorf("private key is nil")
@@ -254,6 +374,75 @@ func newProtoEd25519PrivateKey(privateKey ed25519.PrivateKey) (*ed25519pb.Ed2551
 	}, nil
 }
 
+// newProtoRSASSAPKCS1PrivateKey creates a RsaSsaPkcs1PrivateKey of an RS256 key.
+func newProtoRSASSAPKCS1PrivateKey(privKey *rsa.PrivateKey) *rsassapkcs1pb.RsaSsaPkcs1PrivateKey {
+	v := newRSAPrivateKeyValues(privKey)
+
+	return &rsassapkcs1pb.RsaSsaPkcs1PrivateKey{
+		Version: 0,
+		PublicKey: &rsassapkcs1pb.RsaSsaPkcs1PublicKey{
+			Version: 0,
+			Params:  &rsassapkcs1pb.RsaSsaPkcs1Params{HashType: commonpb.HashType_SHA256},
+			N:       v.n,
+			E:       v.e,
+		},
+		D:   v.d,
+		P:   v.p,
+		Q:   v.q,
+		Dp:  v.dp,
+		Dq:  v.dq,
+		Crt: v.crt,
+	}
+}
+
+// newProtoRSASSAPSSPrivateKey creates a RsaSsaPssPrivateKey of a PS256 key.
+func newProtoRSASSAPSSPrivateKey(privKey *rsa.PrivateKey) *rsassapsspb.RsaSsaPssPrivateKey {
+	v := newRSAPrivateKeyValues(privKey)
+
+	return &rsassapsspb.RsaSsaPssPrivateKey{
+		Version: 0,
+		PublicKey: &rsassapsspb.RsaSsaPssPublicKey{
+			Version: 0,
+			Params: &rsassapsspb.RsaSsaPssParams{
+				SigHash:    commonpb.HashType_SHA256,
+				Mgf1Hash:   commonpb.HashType_SHA256,
+				SaltLength: sha256.Size,
+			},
+			N: v.n,
+			E: v.e,
+		},
+		D:   v.d,
+		P:   v.p,
+		Q:   v.q,
+		Dp:  v.dp,
+		Dq:  v.dq,
+		Crt: v.crt,
+	}
+}
+
+// rsaPrivateKeyValues are the big-endian values of an RSA private key, as serialized in Tink's RSA protos.
+type rsaPrivateKeyValues struct {
+	n, e, d, p, q, dp, dq, crt []byte
+}
+
+// newRSAPrivateKeyValues computes the CRT values of privKey rather than reading privKey.Precomputed, which is only
+// set once privKey.Precompute() is called.
+func newRSAPrivateKeyValues(privKey *rsa.PrivateKey) *rsaPrivateKeyValues {
+	p, q := privKey.Primes[0], privKey.Primes[1]
+	one := big.NewInt(1)
+
+	return &rsaPrivateKeyValues{
+		n:   privKey.N.Bytes(),
+		e:   big.NewInt(int64(privKey.E)).Bytes(),
+		d:   privKey.D.Bytes(),
+		p:   p.Bytes(),
+		q:   q.Bytes(),
+		dp:  new(big.Int).Mod(privKey.D, new(big.Int).Sub(p, one)).Bytes(),
+		dq:  new(big.Int).Mod(privKey.D, new(big.Int).Sub(q, one)).Bytes(),
+		crt: new(big.Int).ModInverse(q, p).Bytes(),
+	}
+}
+
 func newProtoBBSPrivateKey(privateKey *bbs12381g2pub.PrivateKey, kt kms.KeyType) (*bbspb.BBSPrivateKey, error) {
 	publicKey := privateKey.PublicKey()
 
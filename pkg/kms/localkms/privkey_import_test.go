@@ -11,6 +11,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
 	"math/big"
 	"testing"
@@ -54,6 +55,34 @@ func TestImportEd25519KeyWitnInvalidKey(t *testing.T) {
 	require.EqualError(t, err, errPrefix+"private key is nil")
 }
 
+func TestImportRSAKeyWithInvalidKey(t *testing.T) {
+	k := createKMS(t)
+	errPrefix := "import private RSA key failed: "
+
+	_, _, err := k.importRSAKey(nil, kms.RSARS256Type)
+	require.EqualError(t, err, errPrefix+"private key is nil")
+
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, _, err = k.importRSAKey(privKey, kms.ECDSAP256TypeDER)
+	require.EqualError(t, err, errPrefix+"invalid key type")
+
+	_, _, err = k.importRSAKey(&rsa.PrivateKey{PublicKey: privKey.PublicKey, D: privKey.D}, kms.RSAPS256Type)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), errPrefix)
+}
+
+func TestImportSecp256k1KeyWithInvalidKey(t *testing.T) {
+	k := createKMS(t)
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, _, err = k.importECDSAKey(privKey, kms.ECDSASecp256k1TypeIEEEP1363)
+	require.EqualError(t, err, "import private EC key failed: key is not on the secp256k1 curve")
+}
+
 func TestImportKeySetInvalid(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -9,15 +9,20 @@ package localkms
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/sha256"
 	"crypto/x509"
 	"fmt"
+	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/insecurecleartextkeyset"
 	"github.com/google/tink/go/keyset"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
 	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	rsassapkcs1pb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	rsassapsspb "github.com/google/tink/go/proto/rsa_ssa_pss_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/subtle"
 
@@ -31,6 +36,11 @@ func publicKeyBytesToHandle(pubKey []byte, kt kms.KeyType, opts ...kms.KeyOpts)
 		return nil, fmt.Errorf("pubKey is empty")
 	}
 
+	err := registerKeyManagers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to register key managers: %w", err)
+	}
+
 	marshalledKey, tURL, err := getMarshalledProtoKeyAndKeyURL(pubKey, kt, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error getting marshalled proto key: %w", err)
@@ -145,6 +155,18 @@ func getMarshalledProtoKeyAndKeyURL(pubKey []byte, kt kms.KeyType,
 		if err != nil {
 			return nil, "", err
 		}
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		tURL = secp256k1VerifierTypeURL
+
+		keyValue, err = getMarshalledSecp256k1Key(pubKey)
+		if err != nil {
+			return nil, "", err
+		}
+	case kms.RSARS256Type, kms.RSAPS256Type:
+		tURL, keyValue, err = getMarshalledRSAKey(pubKey, kt)
+		if err != nil {
+			return nil, "", err
+		}
 	case kms.ED25519Type:
 		tURL = ed25519VerifierTypeURL
 		pubKeyProto := new(ed25519pb.Ed25519PublicKey)
@@ -235,6 +257,53 @@ func getMarshalledECDSAIEEEP1363Key(marshaledPubKey []byte, curveName string, c
 	return getMarshalledECDSAKey(&ecdsa.PublicKey{X: x, Y: y, Curve: curve}, params)
 }
 
+func getMarshalledSecp256k1Key(marshaledPubKey []byte) ([]byte, error) {
+	pubKey, err := btcec.ParsePubKey(marshaledPubKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal public secp256k1 key: %w", err)
+	}
+
+	params := &ecdsapb.EcdsaParams{
+		Encoding: ecdsapb.EcdsaSignatureEncoding_IEEE_P1363,
+		HashType: commonpb.HashType_SHA256,
+	}
+
+	return getMarshalledECDSAKey(pubKey.ToECDSA(), params)
+}
+
+func getMarshalledRSAKey(marshaledPubKey []byte, kt kms.KeyType) (string, []byte, error) {
+	pubKey, err := x509.ParsePKCS1PublicKey(marshaledPubKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to unmarshal public RSA key: %w", err)
+	}
+
+	n, e := pubKey.N.Bytes(), big.NewInt(int64(pubKey.E)).Bytes()
+
+	if kt == kms.RSARS256Type {
+		keyValue, err := proto.Marshal(&rsassapkcs1pb.RsaSsaPkcs1PublicKey{
+			Version: 0,
+			Params:  &rsassapkcs1pb.RsaSsaPkcs1Params{HashType: commonpb.HashType_SHA256},
+			N:       n,
+			E:       e,
+		})
+
+		return rsaSSAPKCS1VerifierTypeURL, keyValue, err
+	}
+
+	keyValue, err := proto.Marshal(&rsassapsspb.RsaSsaPssPublicKey{
+		Version: 0,
+		Params: &rsassapsspb.RsaSsaPssParams{
+			SigHash:    commonpb.HashType_SHA256,
+			Mgf1Hash:   commonpb.HashType_SHA256,
+			SaltLength: sha256.Size,
+		},
+		N: n,
+		E: e,
+	})
+
+	return rsaSSAPSSVerifierTypeURL, keyValue, err
+}
+
 func getMarshalledECDSAKey(ecPubKey *ecdsa.PublicKey, params *ecdsapb.EcdsaParams) ([]byte, error) {
 	return proto.Marshal(newProtoECDSAPublicKey(ecPubKey, params))
 }
@@ -9,15 +9,19 @@ package localkms
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
 	"io"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
 	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	rsassapkcs1pb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	rsassapsspb "github.com/google/tink/go/proto/rsa_ssa_pss_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/subtle"
 
@@ -34,6 +38,9 @@ const (
 	x25519ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey"
 	bbsVerifierKeyTypeURL        = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
 	clCredDefKeyTypeURL          = "type.hyperledger.org/hyperledger.aries.crypto.tink.CLCredDefKey"
+	secp256k1VerifierTypeURL     = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PublicKey"
+	rsaSSAPKCS1VerifierTypeURL   = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPkcs1PublicKey"
+	rsaSSAPSSVerifierTypeURL     = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaSsaPssPublicKey"
 	derPrefix                    = "der-"
 	p13163Prefix                 = "p1363-"
 )
@@ -95,7 +102,8 @@ func write(w io.Writer, msg *tinkpb.Keyset) (kms.KeyType, error) {
 	for _, key := range ks {
 		if key.KeyId == primaryKID && key.Status == tinkpb.KeyStatusType_ENABLED {
 			switch key.KeyData.TypeUrl {
-			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL, clCredDefKeyTypeURL:
+			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL, clCredDefKeyTypeURL,
+				secp256k1VerifierTypeURL, rsaSSAPKCS1VerifierTypeURL, rsaSSAPSSVerifierTypeURL:
 				created, kt, err = writePubKey(w, key)
 				if err != nil {
 					return "", err
@@ -133,7 +141,7 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, kms.KeyType, error)
 	)
 
 	// TODO add other key types than the ones below and other than nistPECDHKWPublicKeyTypeURL and
-	// TODO x25519ECDHKWPublicKeyTypeURL(eg: secp256k1 when introduced in KMS).
+	// TODO x25519ECDHKWPublicKeyTypeURL.
 	switch key.KeyData.TypeUrl {
 	case ecdsaVerifierTypeURL:
 		pubKeyProto := new(ecdsapb.EcdsaPublicKey)
@@ -183,6 +191,41 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, kms.KeyType, error)
 		copy(marshaledRawPubKey, pubKeyProto.KeyValue)
 
 		kt = kms.CLCredDefType
+	case secp256k1VerifierTypeURL:
+		pubKeyProto := new(ecdsapb.EcdsaPublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, "", err
+		}
+
+		marshaledRawPubKey = (&btcec.PublicKey{
+			Curve: btcec.S256(),
+			X:     new(big.Int).SetBytes(pubKeyProto.X),
+			Y:     new(big.Int).SetBytes(pubKeyProto.Y),
+		}).SerializeUncompressed()
+
+		kt = kms.ECDSASecp256k1TypeIEEEP1363
+	case rsaSSAPKCS1VerifierTypeURL:
+		pubKeyProto := new(rsassapkcs1pb.RsaSsaPkcs1PublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, "", err
+		}
+
+		marshaledRawPubKey = marshalRSAPublicKey(pubKeyProto.N, pubKeyProto.E)
+		kt = kms.RSARS256Type
+	case rsaSSAPSSVerifierTypeURL:
+		pubKeyProto := new(rsassapsspb.RsaSsaPssPublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, "", err
+		}
+
+		marshaledRawPubKey = marshalRSAPublicKey(pubKeyProto.N, pubKeyProto.E)
+		kt = kms.RSAPS256Type
 	default:
 		return false, "", fmt.Errorf("can't export key with keyURL:%s", key.KeyData.TypeUrl)
 	}
@@ -195,6 +238,14 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, kms.KeyType, error)
 	return n > 0, kt, nil
 }
 
+// marshalRSAPublicKey marshals the modulus and public exponent of an RSA public key in PKCS #1 DER form.
+func marshalRSAPublicKey(n, e []byte) []byte {
+	return x509.MarshalPKCS1PublicKey(&rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	})
+}
+
 func getMarshalledECDSAKeyValueFromProto(pubKeyProto *ecdsapb.EcdsaPublicKey) ([]byte, kms.KeyType, error) {
 	var (
 		marshaledRawPubKey []byte
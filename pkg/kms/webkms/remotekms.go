@@ -83,6 +83,21 @@ type importKeyResp struct {
 	KeyURL string `json:"key_url"`
 }
 
+type keyInfo struct {
+	KeyURL    string            `json:"key_url"`
+	KeyType   kms.KeyType       `json:"key_type"`
+	CreatedAt time.Time         `json:"created_at"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type listKeysResp struct {
+	Keys []keyInfo `json:"keys"`
+}
+
+type setMetadataReq struct {
+	Metadata map[string]string `json:"metadata"`
+}
+
 type marshalFunc func(interface{}) ([]byte, error)
 
 type unmarshalFunc func([]byte, interface{}) error
@@ -215,6 +230,10 @@ func (r *RemoteKMS) getHTTPRequest(destination string) (*http.Response, error) {
 	return r.doHTTPRequest(http.MethodGet, destination, nil)
 }
 
+func (r *RemoteKMS) deleteHTTPRequest(destination string) (*http.Response, error) {
+	return r.doHTTPRequest(http.MethodDelete, destination, nil)
+}
+
 func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*http.Response, error) {
 	start := time.Now()
 
@@ -473,6 +492,122 @@ func (r *RemoteKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 	return kid, keyURL, nil
 }
 
+// List remotely fetches the information of the keys of the keystore.
+// Returns:
+//  - information of the keys, including their user-defined metadata tags
+//  - error if failure
+func (r *RemoteKMS) List() ([]kms.KeyInfo, error) {
+	destination := r.keystoreURL + "/keys"
+
+	resp, err := r.getHTTPRequest(destination)
+	if err != nil {
+		return nil, fmt.Errorf("posting GET List keys failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "List")
+
+	var httpResp listKeysResp
+
+	err = readResponse(resp, &httpResp, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("list keys failed [%s, %w]", destination, err)
+	}
+
+	infos := make([]kms.KeyInfo, len(httpResp.Keys))
+
+	for i, info := range httpResp.Keys {
+		infos[i] = info.toKeyInfo()
+	}
+
+	return infos, nil
+}
+
+// GetKeyInfo remotely fetches the information of the key referenced by keyID.
+// Returns:
+//  - information of the key, including its user-defined metadata tags
+//  - error if failure
+func (r *RemoteKMS) GetKeyInfo(keyID string) (*kms.KeyInfo, error) {
+	destination := r.buildKIDURL(keyID)
+
+	resp, err := r.getHTTPRequest(destination)
+	if err != nil {
+		return nil, fmt.Errorf("posting GET GetKeyInfo failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "GetKeyInfo")
+
+	var httpResp keyInfo
+
+	err = readResponse(resp, &httpResp, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("get key info failed [%s, %w]", destination, err)
+	}
+
+	info := httpResp.toKeyInfo()
+
+	return &info, nil
+}
+
+// SetMetadata remotely replaces the user-defined metadata tags of the key referenced by keyID.
+// Returns:
+//  - error if failure
+func (r *RemoteKMS) SetMetadata(keyID string, metadata map[string]string) error {
+	destination := r.buildKIDURL(keyID) + "/metadata"
+
+	marshaledReq, err := r.marshalFunc(&setMetadataReq{Metadata: metadata})
+	if err != nil {
+		return fmt.Errorf("failed to marshal SetMetadata request [%s, %w]", destination, err)
+	}
+
+	resp, err := r.putHTTPRequest(destination, marshaledReq)
+	if err != nil {
+		return fmt.Errorf("failed to put SetMetadata request [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "SetMetadata")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("set metadata failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
+// Delete remotely deletes the key referenced by keyID.
+// Returns:
+//  - error if failure
+func (r *RemoteKMS) Delete(keyID string) error {
+	destination := r.buildKIDURL(keyID)
+
+	resp, err := r.deleteHTTPRequest(destination)
+	if err != nil {
+		return fmt.Errorf("posting DELETE key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "Delete")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("delete key failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
+func (i *keyInfo) toKeyInfo() kms.KeyInfo {
+	return kms.KeyInfo{
+		KeyID:     i.KeyURL[strings.LastIndex(i.KeyURL, "/")+1:],
+		KeyType:   i.KeyType,
+		CreatedAt: i.CreatedAt,
+		Metadata:  i.Metadata,
+	}
+}
+
 // closeResponseBody closes the response body.
 func closeResponseBody(respBody io.Closer, logger spi.Logger, action string) {
 	err := respBody.Close()
@@ -424,6 +424,123 @@ func TestImportPrivateKey(t *testing.T) {
 	})
 }
 
+func TestKeyInfo(t *testing.T) {
+	createdAt := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	metadata := map[string]string{"label": "signing"}
+
+	var receivedMetadata map[string]string
+
+	hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyURL := "https://" + r.Host + "/v1/keystores/" + defaultKeyStoreID + "/keys/" + defaultKID
+		info := keyInfo{KeyURL: keyURL, KeyType: kms.ED25519Type, CreatedAt: createdAt, Metadata: metadata}
+
+		var resp interface{}
+
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/keys"):
+			resp = &listKeysResp{Keys: []keyInfo{info}}
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/keys/"+defaultKID):
+			resp = &info
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/keys/"+defaultKID+"/metadata"):
+			var req setMetadataReq
+
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			receivedMetadata = req.Metadata
+		case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/keys/"+defaultKID):
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errMessage": "key not found"}`))
+			require.NoError(t, err)
+
+			return
+		}
+
+		if resp != nil {
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+		}
+	})
+
+	server, url, client := CreateMockHTTPServerAndClient(t, hf)
+	defaultKeystoreURL := fmt.Sprintf("%s/%s", strings.ReplaceAll(KeystoreEndpoint,
+		"{serverEndpoint}", url), defaultKeyStoreID)
+
+	defer func() {
+		e := server.Close()
+		require.NoError(t, e)
+	}()
+
+	remoteKMS := New(defaultKeystoreURL, client)
+
+	expected := kms.KeyInfo{KeyID: defaultKID, KeyType: kms.ED25519Type, CreatedAt: createdAt, Metadata: metadata}
+
+	t.Run("List success", func(t *testing.T) {
+		infos, err := remoteKMS.List()
+		require.NoError(t, err)
+		require.Equal(t, []kms.KeyInfo{expected}, infos)
+	})
+
+	t.Run("GetKeyInfo success", func(t *testing.T) {
+		info, err := remoteKMS.GetKeyInfo(defaultKID)
+		require.NoError(t, err)
+		require.Equal(t, &expected, info)
+	})
+
+	t.Run("SetMetadata success", func(t *testing.T) {
+		require.NoError(t, remoteKMS.SetMetadata(defaultKID, map[string]string{"label": "encryption"}))
+		require.Equal(t, map[string]string{"label": "encryption"}, receivedMetadata)
+	})
+
+	t.Run("Delete success", func(t *testing.T) {
+		require.NoError(t, remoteKMS.Delete(defaultKID))
+	})
+
+	t.Run("API errors", func(t *testing.T) {
+		_, err := remoteKMS.GetKeyInfo("unknown")
+		require.Contains(t, err.Error(), "key not found")
+
+		err = remoteKMS.SetMetadata("unknown", nil)
+		require.Contains(t, err.Error(), "key not found")
+
+		err = remoteKMS.Delete("unknown")
+		require.Contains(t, err.Error(), "key not found")
+	})
+
+	t.Run("json failures", func(t *testing.T) {
+		remoteKMS.marshalFunc = failingMarshal
+
+		err := remoteKMS.SetMetadata(defaultKID, metadata)
+		require.Contains(t, err.Error(), "failingMarshal always fails")
+
+		remoteKMS.marshalFunc = json.Marshal
+		remoteKMS.unmarshalFunc = failingUnmarshal
+
+		_, err = remoteKMS.List()
+		require.Contains(t, err.Error(), "failingUnmarshal always fails")
+
+		_, err = remoteKMS.GetKeyInfo(defaultKID)
+		require.Contains(t, err.Error(), "failingUnmarshal always fails")
+
+		remoteKMS.unmarshalFunc = json.Unmarshal
+	})
+
+	t.Run("bad http client", func(t *testing.T) {
+		tmpKMS := New(defaultKeystoreURL, &http.Client{})
+
+		_, err := tmpKMS.List()
+		require.Contains(t, err.Error(), "posting GET List keys failed")
+
+		_, err = tmpKMS.GetKeyInfo(defaultKID)
+		require.Contains(t, err.Error(), "posting GET GetKeyInfo failed")
+
+		err = tmpKMS.SetMetadata(defaultKID, metadata)
+		require.Contains(t, err.Error(), "failed to put SetMetadata request")
+
+		err = tmpKMS.Delete(defaultKID)
+		require.Contains(t, err.Error(), "posting DELETE key failed")
+	})
+}
+
 func TestCloseResponseBody(t *testing.T) {
 	closeResponseBody(&errFailingCloser{}, logger, "testing close fail should log: errFailingCloser always fails")
 }
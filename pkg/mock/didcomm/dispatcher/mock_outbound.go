@@ -11,10 +11,11 @@ import (
 
 // MockOutbound mock outbound dispatcher.
 type MockOutbound struct {
-	ValidateSend      func(msg interface{}, senderVerKey string, des *service.Destination) error
-	ValidateSendToDID func(msg interface{}, myDID, theirDID string) error
-	ValidateForward   func(msg interface{}, des *service.Destination) error
-	SendErr           error
+	ValidateSend       func(msg interface{}, senderVerKey string, des *service.Destination) error
+	ValidateSendToDID  func(msg interface{}, myDID, theirDID string) error
+	ValidateSendToDIDs func(msg interface{}, myDID string, theirDIDs []string) error
+	ValidateForward    func(msg interface{}, des *service.Destination) error
+	SendErr            error
 }
 
 // Send msg.
@@ -35,6 +36,15 @@ func (m *MockOutbound) SendToDID(msg interface{}, myDID, theirDID string) error
 	return m.SendErr
 }
 
+// SendToDIDs msg.
+func (m *MockOutbound) SendToDIDs(msg interface{}, myDID string, theirDIDs []string) error {
+	if m.ValidateSendToDIDs != nil {
+		return m.ValidateSendToDIDs(msg, myDID, theirDIDs)
+	}
+
+	return m.SendErr
+}
+
 // Forward msg.
 func (m *MockOutbound) Forward(msg interface{}, des *service.Destination) error {
 	if m.ValidateForward != nil {
@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+)
+
+// MockDiscoverFeaturesSvc mock discover features service.
+type MockDiscoverFeaturesSvc struct {
+	service.DIDComm
+	ProtocolName       string
+	QueryErr           error
+	QueryFunc          func(string, []*discoverfeatures.FeatureQuery, ...discoverfeatures.QueryOption) ([]*discoverfeatures.Disclosure, error) // nolint: lll
+	FeaturesValue      []*discoverfeatures.Disclosure
+	TheirFeaturesValue []*connection.Feature
+	TheirFeaturesErr   error
+	HandleInboundFunc  func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
+	HandleOutboundFunc func(_ service.DIDCommMsg, _, _ string) (string, error)
+	AcceptFunc         func(msgType string) bool
+}
+
+// Initialize service.
+func (m *MockDiscoverFeaturesSvc) Initialize(interface{}) error {
+	return nil
+}
+
+// Name return service name.
+func (m *MockDiscoverFeaturesSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return discoverfeatures.DiscoverFeatures
+}
+
+// Query perform Query.
+func (m *MockDiscoverFeaturesSvc) Query(connectionID string, queries []*discoverfeatures.FeatureQuery,
+	options ...discoverfeatures.QueryOption) ([]*discoverfeatures.Disclosure, error) {
+	if m.QueryErr != nil {
+		return nil, m.QueryErr
+	}
+
+	if m.QueryFunc != nil {
+		return m.QueryFunc(connectionID, queries, options...)
+	}
+
+	return nil, nil
+}
+
+// Features returns features supported by the agent.
+func (m *MockDiscoverFeaturesSvc) Features() []*discoverfeatures.Disclosure {
+	return m.FeaturesValue
+}
+
+// TheirFeatures returns features disclosed by the other party of the connection.
+func (m *MockDiscoverFeaturesSvc) TheirFeatures(string) ([]*connection.Feature, error) {
+	if m.TheirFeaturesErr != nil {
+		return nil, m.TheirFeaturesErr
+	}
+
+	return m.TheirFeaturesValue, nil
+}
+
+// HandleInbound msg.
+func (m *MockDiscoverFeaturesSvc) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if m.HandleInboundFunc != nil {
+		return m.HandleInboundFunc(msg, ctx)
+	}
+
+	return "", nil
+}
+
+// HandleOutbound msg.
+func (m *MockDiscoverFeaturesSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if m.HandleOutboundFunc != nil {
+		return m.HandleOutboundFunc(msg, myDID, theirDID)
+	}
+
+	return "", nil
+}
+
+// Accept msg checks the msg type.
+func (m *MockDiscoverFeaturesSvc) Accept(msgType string) bool {
+	if m.AcceptFunc != nil {
+		return m.AcceptFunc(msgType)
+	}
+
+	return true
+}
@@ -30,6 +30,9 @@ type MockMediatorSvc struct {
 	Connections        []string
 	GetConnectionsErr  error
 	AddKeyFunc         func(string) error
+	RemoveKeyErr       error
+	Recipients         []string
+	QueryRecipientsErr error
 }
 
 // Initialize service.
@@ -100,6 +103,20 @@ func (m *MockMediatorSvc) AddKey(connID, recKey string) error {
 	return nil
 }
 
+// RemoveKey removes agents recKey from the router.
+func (m *MockMediatorSvc) RemoveKey(connID, recKey string) error {
+	return m.RemoveKeyErr
+}
+
+// QueryRecipients returns the recipients registered with the router.
+func (m *MockMediatorSvc) QueryRecipients(connID string) ([]string, error) {
+	if m.QueryRecipientsErr != nil {
+		return nil, m.QueryRecipientsErr
+	}
+
+	return m.Recipients, nil
+}
+
 // Config gives back the router configuration.
 func (m *MockMediatorSvc) Config(connID string) (*mediator.Config, error) {
 	if m.ConfigErr != nil {
@@ -14,18 +14,25 @@ import (
 // MockMessagePickupSvc mock messagepickup service.
 type MockMessagePickupSvc struct {
 	service.DIDComm
-	ProtocolName       string
-	StatusRequestErr   error
-	StatusRequestFunc  func(connectionID string) (*messagepickup.Status, error)
-	BatchPickupErr     error
-	BatchPickupFunc    func(connectionID string, size int) (int, error)
-	HandleInboundFunc  func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
-	HandleOutboundFunc func(_ service.DIDCommMsg, _, _ string) (string, error)
-	AddMessageFunc     func(message []byte, theirDID string) error
-	AddMessageErr      error
-	AcceptFunc         func(msgType string) bool
-	NoopErr            error
-	NoopFunc           func(connectionID string) error
+	ProtocolName           string
+	StatusRequestErr       error
+	StatusRequestFunc      func(connectionID string) (*messagepickup.Status, error)
+	BatchPickupErr         error
+	BatchPickupFunc        func(connectionID string, size int) (int, error)
+	HandleInboundFunc      func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
+	HandleOutboundFunc     func(_ service.DIDCommMsg, _, _ string) (string, error)
+	AddMessageFunc         func(message []byte, theirDID string) error
+	AddMessageErr          error
+	DeliveryRequestErr     error
+	DeliveryRequestFunc    func(connectionID string, options ...messagepickup.PickupOption) (int, error)
+	PickupStatusErr        error
+	PickupStatusFunc       func(connectionID string, options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error)
+	AcceptFunc             func(msgType string) bool
+	NoopErr                error
+	NoopFunc               func(connectionID string) error
+	LiveDeliveryChangeErr  error
+	LiveDeliveryChangeFunc func(connectionID string, liveDelivery bool,
+		options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error)
 }
 
 // Initialize service.
@@ -99,6 +106,52 @@ func (m *MockMessagePickupSvc) AddMessage(message []byte, theirDID string) error
 	return nil
 }
 
+// AddRecipientMessage perform AddRecipientMessage.
+func (m *MockMessagePickupSvc) AddRecipientMessage(message []byte, theirDID, _ string) error {
+	return m.AddMessage(message, theirDID)
+}
+
+// PickupStatus perform PickupStatus.
+func (m *MockMessagePickupSvc) PickupStatus(connectionID string,
+	options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error) {
+	if m.PickupStatusErr != nil {
+		return nil, m.PickupStatusErr
+	}
+
+	if m.PickupStatusFunc != nil {
+		return m.PickupStatusFunc(connectionID, options...)
+	}
+
+	return &messagepickup.PickupStatus{}, nil
+}
+
+// DeliveryRequest perform DeliveryRequest.
+func (m *MockMessagePickupSvc) DeliveryRequest(connectionID string, options ...messagepickup.PickupOption) (int, error) {
+	if m.DeliveryRequestErr != nil {
+		return 0, m.DeliveryRequestErr
+	}
+
+	if m.DeliveryRequestFunc != nil {
+		return m.DeliveryRequestFunc(connectionID, options...)
+	}
+
+	return 0, nil
+}
+
+// LiveDeliveryChange perform LiveDeliveryChange.
+func (m *MockMessagePickupSvc) LiveDeliveryChange(connectionID string, liveDelivery bool,
+	options ...messagepickup.PickupOption) (*messagepickup.PickupStatus, error) {
+	if m.LiveDeliveryChangeErr != nil {
+		return nil, m.LiveDeliveryChangeErr
+	}
+
+	if m.LiveDeliveryChangeFunc != nil {
+		return m.LiveDeliveryChangeFunc(connectionID, liveDelivery, options...)
+	}
+
+	return &messagepickup.PickupStatus{LiveDelivery: liveDelivery}, nil
+}
+
 // Noop perform Noop.
 func (m *MockMessagePickupSvc) Noop(connectionID string) error {
 	if m.NoopErr != nil {
@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package trustping
+
+import (
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/trustping"
+)
+
+// MockTrustPingSvc mock trust ping service.
+type MockTrustPingSvc struct {
+	service.DIDComm
+	ProtocolName       string
+	PingErr            error
+	PingFunc           func(connectionID string, options ...trustping.PingOption) (time.Duration, error)
+	HandleInboundFunc  func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
+	HandleOutboundFunc func(_ service.DIDCommMsg, _, _ string) (string, error)
+	AcceptFunc         func(msgType string) bool
+}
+
+// Initialize service.
+func (m *MockTrustPingSvc) Initialize(interface{}) error {
+	return nil
+}
+
+// Name return service name.
+func (m *MockTrustPingSvc) Name() string {
+	if m.ProtocolName != "" {
+		return m.ProtocolName
+	}
+
+	return trustping.TrustPing
+}
+
+// Ping perform Ping.
+func (m *MockTrustPingSvc) Ping(connectionID string, options ...trustping.PingOption) (time.Duration, error) {
+	if m.PingErr != nil {
+		return 0, m.PingErr
+	}
+
+	if m.PingFunc != nil {
+		return m.PingFunc(connectionID, options...)
+	}
+
+	return 0, nil
+}
+
+// HandleInbound msg.
+func (m *MockTrustPingSvc) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if m.HandleInboundFunc != nil {
+		return m.HandleInboundFunc(msg, ctx)
+	}
+
+	return "", nil
+}
+
+// HandleOutbound msg.
+func (m *MockTrustPingSvc) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if m.HandleOutboundFunc != nil {
+		return m.HandleOutboundFunc(msg, myDID, theirDID)
+	}
+
+	return "", nil
+}
+
+// Accept msg checks the msg type.
+func (m *MockTrustPingSvc) Accept(msgType string) bool {
+	if m.AcceptFunc != nil {
+		return m.AcceptFunc(msgType)
+	}
+
+	return true
+}
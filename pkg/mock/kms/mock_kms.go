@@ -42,6 +42,12 @@ type KeyManager struct {
 	ImportPrivateKeyErr      error
 	ImportPrivateKeyID       string
 	ImportPrivateKeyValue    *keyset.Handle
+	ListValue                []kmsservice.KeyInfo
+	ListErr                  error
+	GetKeyInfoValue          *kmsservice.KeyInfo
+	GetKeyInfoErr            error
+	SetMetadataErr           error
+	DeleteErr                error
 }
 
 // Create a new mock ey/keyset/key handle for the type kt.
@@ -115,6 +121,34 @@ func (k *KeyManager) ImportPrivateKey(privKey interface{}, keyType kmsservice.Ke
 	return k.ImportPrivateKeyID, k.ImportPrivateKeyValue, nil
 }
 
+// List returns mocked key information.
+func (k *KeyManager) List() ([]kmsservice.KeyInfo, error) {
+	if k.ListErr != nil {
+		return nil, k.ListErr
+	}
+
+	return k.ListValue, nil
+}
+
+// GetKeyInfo returns mocked key information.
+func (k *KeyManager) GetKeyInfo(keyID string) (*kmsservice.KeyInfo, error) {
+	if k.GetKeyInfoErr != nil {
+		return nil, k.GetKeyInfoErr
+	}
+
+	return k.GetKeyInfoValue, nil
+}
+
+// SetMetadata emulates setting the metadata tags of a key.
+func (k *KeyManager) SetMetadata(keyID string, metadata map[string]string) error {
+	return k.SetMetadataErr
+}
+
+// Delete emulates deleting a key.
+func (k *KeyManager) Delete(keyID string) error {
+	return k.DeleteErr
+}
+
 func createMockKeyHandle(ks *tinkpb.Keyset) (*keyset.Handle, error) {
 	primaryKey := ks.Key[0]
 
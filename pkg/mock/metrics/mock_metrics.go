@@ -0,0 +1,114 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockMetrics mock implementation of metrics which records the number of calls per label values
+// to be used only for unit tests.
+type MockMetrics struct {
+	mutex            sync.Mutex
+	inboundMessages  map[string]int
+	outboundMessages map[string]int
+	packTimes        map[string]int
+	unpackTimes      map[string]int
+	resolveTimes     map[string]int
+	kmsOperations    map[string]int
+	stateTransitions map[string]int
+}
+
+// InboundMessage records inbound message.
+func (m *MockMetrics) InboundMessage(msgType, transport string) {
+	m.record(&m.inboundMessages, msgType, transport)
+}
+
+// OutboundMessage records outbound message.
+func (m *MockMetrics) OutboundMessage(msgType, transport string) {
+	m.record(&m.outboundMessages, msgType, transport)
+}
+
+// PackTime records pack time.
+func (m *MockMetrics) PackTime(packer string, _ time.Duration) {
+	m.record(&m.packTimes, packer)
+}
+
+// UnpackTime records unpack time.
+func (m *MockMetrics) UnpackTime(packer string, _ time.Duration) {
+	m.record(&m.unpackTimes, packer)
+}
+
+// VDRResolveTime records DID resolution time.
+func (m *MockMetrics) VDRResolveTime(method string, _ time.Duration) {
+	m.record(&m.resolveTimes, method)
+}
+
+// KMSOperation records KMS operation.
+func (m *MockMetrics) KMSOperation(operation, keyType string) {
+	m.record(&m.kmsOperations, operation, keyType)
+}
+
+// ProtocolStateTransition records protocol state transition.
+func (m *MockMetrics) ProtocolStateTransition(protocol, from, to string) {
+	m.record(&m.stateTransitions, protocol, from, to)
+}
+
+// InboundMessages returns the number of recorded inbound messages with the given type and transport.
+func (m *MockMetrics) InboundMessages(msgType, transport string) int {
+	return m.count(m.inboundMessages, msgType, transport)
+}
+
+// OutboundMessages returns the number of recorded outbound messages with the given type and transport.
+func (m *MockMetrics) OutboundMessages(msgType, transport string) int {
+	return m.count(m.outboundMessages, msgType, transport)
+}
+
+// PackTimes returns the number of recorded pack times of the packer.
+func (m *MockMetrics) PackTimes(packer string) int {
+	return m.count(m.packTimes, packer)
+}
+
+// UnpackTimes returns the number of recorded unpack times of the packer.
+func (m *MockMetrics) UnpackTimes(packer string) int {
+	return m.count(m.unpackTimes, packer)
+}
+
+// VDRResolveTimes returns the number of recorded resolution times of the DID method.
+func (m *MockMetrics) VDRResolveTimes(method string) int {
+	return m.count(m.resolveTimes, method)
+}
+
+// KMSOperations returns the number of recorded KMS operations with the given key type.
+func (m *MockMetrics) KMSOperations(operation, keyType string) int {
+	return m.count(m.kmsOperations, operation, keyType)
+}
+
+// ProtocolStateTransitions returns the number of recorded transitions between the protocol states.
+func (m *MockMetrics) ProtocolStateTransitions(protocol, from, to string) int {
+	return m.count(m.stateTransitions, protocol, from, to)
+}
+
+func (m *MockMetrics) record(values *map[string]int, labelValues ...string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if *values == nil {
+		*values = map[string]int{}
+	}
+
+	(*values)[strings.Join(labelValues, "|")]++
+}
+
+func (m *MockMetrics) count(values map[string]int, labelValues ...string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return values[strings.Join(labelValues, "|")]
+}
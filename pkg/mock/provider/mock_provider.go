@@ -53,6 +53,7 @@ type Provider struct {
 	GetDIDsMaxRetriesValue            uint64
 	DIDRotatorValue                   middleware.DIDCommMessageMiddleware
 	MessengerValue                    service.Messenger
+	ServiceMsgTypeTargetsValue        []dispatcher.MessageTypeTarget
 }
 
 // Messenger return messenger.
@@ -80,6 +81,11 @@ func (p *Provider) AllServices() []dispatcher.ProtocolService {
 	return out
 }
 
+// ServiceMsgTypeTargets return service message type targets.
+func (p *Provider) ServiceMsgTypeTargets() []dispatcher.MessageTypeTarget {
+	return p.ServiceMsgTypeTargetsValue
+}
+
 // GetDIDsBackOffDuration return backoff duration for getting DIDs.
 func (p *Provider) GetDIDsBackOffDuration() time.Duration {
 	return p.GetDIDsBackoffDurationValue
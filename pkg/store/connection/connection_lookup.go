@@ -10,6 +10,7 @@
 package connection
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -75,6 +76,22 @@ type Record struct {
 	DIDCommVersion          didcomm.Version
 	PeerDIDInitialState     string
 	MyDIDRotation           *DIDRotationRecord `json:"myDIDRotation,omitempty"`
+	TheirFeatures           []*Feature         `json:"theirFeatures,omitempty"` // TheirFeatures holds features disclosed by 'their' agent through discover-features protocol.
+}
+
+// Feature holds a feature (protocol, goal code, etc.) disclosed by the other party of the connection.
+type Feature struct {
+	FeatureType string   `json:"feature-type"`
+	ID          string   `json:"id"`
+	Roles       []string `json:"roles,omitempty"`
+}
+
+// RecordEvent describes a connection record that was saved or removed.
+type RecordEvent struct {
+	ConnectionID string `json:"connectionID"`
+	Removed      bool   `json:"removed,omitempty"`
+	// Record is the saved record. It's nil for removed records.
+	Record *Record `json:"record,omitempty"`
 }
 
 // NewLookup returns new connection lookup instance.
@@ -184,6 +201,51 @@ func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
 	return records, nil
 }
 
+// WatchConnectionRecords returns a channel of events for the connection records that are saved or removed after this
+// call. The channel is closed once ctx is done. If the underlying protocol state store doesn't implement
+// storage.Watchable, then an error wrapping storage.ErrWatchNotSupported is returned.
+func (c *Lookup) WatchConnectionRecords(ctx context.Context) (<-chan *RecordEvent, error) {
+	watchable, ok := c.protocolStateStore.(storage.Watchable)
+	if !ok {
+		return nil, fmt.Errorf("watch protocol state store: %w", storage.ErrWatchNotSupported)
+	}
+
+	// Every connection record is saved in the protocol state store, tagged with the connection key prefix.
+	events, err := watchable.Watch(ctx, storage.WithTag(storage.Tag{Name: getConnectionKeyPrefix()("")}))
+	if err != nil {
+		return nil, fmt.Errorf("watch protocol state store: %w", err)
+	}
+
+	recordEvents := make(chan *RecordEvent)
+
+	go func() {
+		defer close(recordEvents)
+
+		for event := range events {
+			recordEvent := &RecordEvent{
+				ConnectionID: strings.TrimPrefix(event.Key, getConnectionKeyPrefix()("")),
+				Removed:      event.Type == storage.EventDelete,
+			}
+
+			if !recordEvent.Removed {
+				if err := json.Unmarshal(event.Value, &recordEvent.Record); err != nil {
+					logger.Errorf("failed to unmarshal connection record %s: %s", recordEvent.ConnectionID, err)
+
+					continue
+				}
+			}
+
+			select {
+			case recordEvents <- recordEvent:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return recordEvents, nil
+}
+
 func queryRecordsFromStore(searchKey string, store storage.Store, usedKeys map[string]struct{}, appendTo []*Record) (
 	[]*Record, error) {
 	if usedKeys == nil {
@@ -10,6 +10,7 @@
 package connection
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -443,6 +444,53 @@ func TestGetConnectionIDByDIDs(t *testing.T) {
 	})
 }
 
+func TestLookup_WatchConnectionRecords(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		protocolStateStore, err := mem.NewProvider().OpenStore(Namespace)
+		require.NoError(t, err)
+
+		recorder, err := NewRecorder(&mockProvider{protocolStateStore: protocolStateStore})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := recorder.WatchConnectionRecords(ctx)
+		require.NoError(t, err)
+
+		record := &Record{
+			ThreadID:     fmt.Sprintf(threadIDFmt, 1),
+			ConnectionID: fmt.Sprintf(connIDFmt, 1),
+			State:        StateNameCompleted,
+			Namespace:    MyNSPrefix,
+		}
+
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(record))
+		require.NoError(t, recorder.RemoveConnection(record.ConnectionID))
+
+		event := <-events
+		require.Equal(t, record.ConnectionID, event.ConnectionID)
+		require.False(t, event.Removed)
+		require.Equal(t, record, event.Record)
+
+		event = <-events
+		require.Equal(t, &RecordEvent{ConnectionID: record.ConnectionID, Removed: true}, event)
+
+		cancel()
+
+		_, open := <-events
+		require.False(t, open)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		lookup, err := NewLookup(&mockProvider{})
+		require.NoError(t, err)
+
+		events, err := lookup.WatchConnectionRecords(context.Background())
+		require.ErrorIs(t, err, storage.ErrWatchNotSupported)
+		require.Nil(t, events)
+	})
+}
+
 // mockProvider for connection recorder.
 type mockProvider struct {
 	protocolStateStoreError error
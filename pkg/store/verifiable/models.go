@@ -18,3 +18,12 @@ type Record struct {
 	MyDID    string `json:"my_did,omitempty"`
 	TheirDID string `json:"their_did,omitempty"`
 }
+
+// RecordEvent describes a credential or presentation record that was saved or removed.
+type RecordEvent struct {
+	// Presentation is true if the record is of a presentation rather than a credential.
+	Presentation bool `json:"presentation,omitempty"`
+	Removed      bool `json:"removed,omitempty"`
+	// Record is the saved record. Only its name is set for removed records.
+	Record *Record `json:"record"`
+}
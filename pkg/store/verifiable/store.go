@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
@@ -370,6 +372,73 @@ func (s *StoreImplementation) getAllRecords(searchKey string) ([]*Record, error)
 	return records, nil
 }
 
+// WatchRecords returns a channel of events for the credential and presentation records that are saved or removed
+// after this call. The channel is closed once ctx is done. If the underlying store doesn't implement
+// storage.Watchable, then an error wrapping storage.ErrWatchNotSupported is returned.
+func (s *StoreImplementation) WatchRecords(ctx context.Context) (<-chan *RecordEvent, error) {
+	watchable, ok := s.store.(storage.Watchable)
+	if !ok {
+		return nil, fmt.Errorf("watch vc store: %w", storage.ErrWatchNotSupported)
+	}
+
+	events, err := watchable.Watch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("watch vc store: %w", err)
+	}
+
+	recordEvents := make(chan *RecordEvent)
+
+	go func() {
+		defer close(recordEvents)
+
+		for event := range events {
+			recordEvent, ok := toRecordEvent(event)
+			if !ok {
+				continue
+			}
+
+			select {
+			case recordEvents <- recordEvent:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return recordEvents, nil
+}
+
+func toRecordEvent(event storage.Event) (*RecordEvent, bool) {
+	recordEvent := &RecordEvent{Removed: event.Type == storage.EventDelete}
+
+	var name string
+
+	switch {
+	case strings.HasPrefix(event.Key, internal.CredentialNameKey):
+		name = strings.TrimPrefix(event.Key, internal.CredentialNameKey)
+	case strings.HasPrefix(event.Key, internal.PresentationNameKey):
+		name = strings.TrimPrefix(event.Key, internal.PresentationNameKey)
+		recordEvent.Presentation = true
+	default: // The credential or presentation itself.
+		return nil, false
+	}
+
+	if recordEvent.Removed {
+		recordEvent.Record = &Record{Name: name}
+
+		return recordEvent, true
+	}
+
+	err := json.Unmarshal(event.Value, &recordEvent.Record)
+	if err != nil {
+		logger.Errorf("failed to unmarshal record %s : %s", name, err)
+
+		return nil, false
+	}
+
+	return recordEvent, true
+}
+
 func getVCSubjectID(vc *verifiable.Credential) string {
 	if subjectID, err := verifiable.SubjectID(vc.Subject); err == nil {
 		return subjectID
@@ -7,19 +7,23 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	. "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable/internal"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -937,3 +941,56 @@ func TestRemoveVP(t *testing.T) {
 		require.Contains(t, err.Error(), "get presentation id using name")
 	})
 }
+
+func TestWatchRecords(t *testing.T) {
+	t.Run("test watch records - success", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mem.NewProvider(),
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := s.WatchRecords(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: sampleCredentialID},
+			WithMyDID("myDID")))
+		require.NoError(t, s.SavePresentation(samplePresentationName, &verifiable.Presentation{ID: samplePresentationID}))
+		require.NoError(t, s.RemoveCredentialByName(sampleCredentialName))
+
+		event := <-events
+		require.False(t, event.Presentation)
+		require.False(t, event.Removed)
+		require.Equal(t, sampleCredentialName, event.Record.Name)
+		require.Equal(t, sampleCredentialID, event.Record.ID)
+		require.Equal(t, "myDID", event.Record.MyDID)
+
+		event = <-events
+		require.True(t, event.Presentation)
+		require.False(t, event.Removed)
+		require.Equal(t, samplePresentationName, event.Record.Name)
+		require.Equal(t, samplePresentationID, event.Record.ID)
+
+		event = <-events
+		require.False(t, event.Presentation)
+		require.True(t, event.Removed)
+		require.Equal(t, &Record{Name: sampleCredentialName}, event.Record)
+
+		cancel()
+
+		_, open := <-events
+		require.False(t, open)
+	})
+	t.Run("test watch records - not supported", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		events, err := s.WatchRecords(context.Background())
+		require.True(t, errors.Is(err, storage.ErrWatchNotSupported))
+		require.Nil(t, events)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcutil/base58"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
@@ -36,6 +37,8 @@ const (
 	P384PubKeyMultiCodec = 0x1201
 	// P521PubKeyMultiCodec for NIST P-521 public key in multicodec table.
 	P521PubKeyMultiCodec = 0x1202
+	// Secp256k1PubKeyMultiCodec for secp256k1 public key in multicodec table.
+	Secp256k1PubKeyMultiCodec = 0xe7
 
 	// Default BLS 12-381 public key length in G2 field.
 	bls12381G2PublicKeyLen = 96
@@ -126,6 +129,9 @@ func ecCodeAndCurve(ecCurve string) (uint64, elliptic.Curve, error) {
 	case elliptic.P521().Params().Name, "NIST_P521":
 		curve = elliptic.P521()
 		code = P521PubKeyMultiCodec
+	case "secp256k1", "P-256K":
+		curve = btcec.S256()
+		code = Secp256k1PubKeyMultiCodec
 	default:
 		return 0, nil, fmt.Errorf("unsupported crv %s", ecCurve)
 	}
@@ -136,13 +142,19 @@ func ecCodeAndCurve(ecCurve string) (uint64, elliptic.Curve, error) {
 // KeyFingerprint generates a multicode fingerprint for pubKeyValue (raw key []byte).
 // It is mainly used as the controller ID (methodSpecification ID) of a did key.
 func KeyFingerprint(code uint64, pubKeyValue []byte) string {
+	return fmt.Sprintf("z%s", base58.Encode(MulticodecKey(code, pubKeyValue)))
+}
+
+// MulticodecKey prefixes pubKeyValue (raw key []byte) with the multicodec code. It is the value of the
+// publicKeyMultibase field of Multikey verification methods.
+func MulticodecKey(code uint64, pubKeyValue []byte) []byte {
 	multicodecValue := multicodec(code)
 	mcLength := len(multicodecValue)
 	buf := make([]uint8, mcLength+len(pubKeyValue))
 	copy(buf, multicodecValue)
 	copy(buf[mcLength:], pubKeyValue)
 
-	return fmt.Sprintf("z%s", base58.Encode(buf))
+	return buf
 }
 
 func multicodec(code uint64) []byte {
@@ -202,7 +214,7 @@ func PubKeyFromDIDKey(didKey string) ([]byte, error) {
 
 	switch code {
 	case X25519PubKeyMultiCodec, ED25519PubKeyMultiCodec, BLS12381g2PubKeyMultiCodec, BLS12381g1g2PubKeyMultiCodec,
-		P256PubKeyMultiCodec, P384PubKeyMultiCodec, P521PubKeyMultiCodec:
+		P256PubKeyMultiCodec, P384PubKeyMultiCodec, P521PubKeyMultiCodec, Secp256k1PubKeyMultiCodec:
 		break
 	default:
 		return nil, fmt.Errorf("pubKeyFromDIDKey: unsupported key multicodec code [0x%x]", code)
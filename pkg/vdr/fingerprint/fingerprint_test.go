@@ -10,11 +10,13 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/base64"
 	"math/big"
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/square/go-jose/v3"
 	"github.com/stretchr/testify/require"
@@ -343,3 +345,24 @@ func TestPubKeyFromDIDKeyFailure(t *testing.T) {
 		" invalid did: did:key:****. Make sure it conforms to the DID syntax: "+
 		"https://w3c.github.io/did-core/#did-syntax")
 }
+
+func TestDIDKeySecp256k1(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwkKey, err := jwksupport.JWKFromKey(&privKey.PublicKey)
+	require.NoError(t, err)
+
+	didKey, keyID, err := CreateDIDKeyByJwk(jwkKey)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(didKey, "did:key:zQ3s"))
+	require.Equal(t, didKey+"#"+strings.TrimPrefix(didKey, "did:key:"), keyID)
+
+	pubKey, err := PubKeyFromDIDKey(didKey)
+	require.NoError(t, err)
+	require.Equal(t, MulticodecKey(Secp256k1PubKeyMultiCodec, pubKey), base58.Decode(didKey[len("did:key:z"):]))
+
+	parsedKey, err := btcec.ParsePubKey(pubKey, btcec.S256())
+	require.NoError(t, err)
+	require.Equal(t, &privKey.PublicKey, parsedKey.ToECDSA())
+}
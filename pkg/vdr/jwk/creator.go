@@ -0,0 +1,210 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	josejwk "github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	schemaResV1                = "https://w3id.org/did-resolution/v1"
+	schemaDIDV1                = "https://www.w3.org/ns/did/v1"
+	schemaJWS2020V1            = "https://w3id.org/security/suites/jws-2020/v1"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+	bls12381G2Key2020          = "Bls12381G2Key2020"
+	jsonWebKey2020             = "JsonWebKey2020"
+
+	useSig = "sig"
+	useEnc = "enc"
+)
+
+// Create new did:jwk DID document for the public key of didDoc's first VerificationMethod.
+// The verification method must either have a JWK, have an Ed25519VerificationKey2018, X25519KeyAgreementKey2019 or
+// Bls12381G2Key2020 type, or opts must contain the KeyType value of kms.KeyType of the public key bytes as exported by
+// the KMS.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	createDIDOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(createDIDOpts)
+	}
+
+	if len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	j, err := verificationMethodJWK(&didDoc.VerificationMethod[0], createDIDOpts.Values[KeyType])
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Create: %w", err)
+	}
+
+	didJWK, err := CreateDID(j)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Create: %w", err)
+	}
+
+	doc, err := createDoc(didJWK, j)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Create: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// CreateDID returns the did:jwk DID of the public key j. The JWK members are sorted to build the same DID for the
+// same key.
+func CreateDID(j *josejwk.JWK) (string, error) {
+	jwkBytes, err := j.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWK: %w", err)
+	}
+
+	members, err := publicJWKMembers(jwkBytes)
+	if err != nil {
+		return "", err
+	}
+
+	jwkBytes, err = json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWK: %w", err)
+	}
+
+	return fmt.Sprintf("did:%s:%s", DIDMethod, base64.RawURLEncoding.EncodeToString(jwkBytes)), nil
+}
+
+func publicJWKMembers(jwkBytes []byte) (map[string]interface{}, error) {
+	members := map[string]interface{}{}
+
+	err := json.Unmarshal(jwkBytes, &members)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JWK: %w", err)
+	}
+
+	if _, ok := members["d"]; ok {
+		return nil, errors.New("JWK must be a public key")
+	}
+
+	return members, nil
+}
+
+func verificationMethodJWK(vm *did.VerificationMethod, keyTypeOpt interface{}) (*josejwk.JWK, error) {
+	if vm.JSONWebKey() != nil {
+		return vm.JSONWebKey(), nil
+	}
+
+	var keyType kms.KeyType
+
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		keyType = kms.ED25519Type
+	case x25519KeyAgreementKey2019:
+		keyType = kms.X25519ECDHKWType
+	case bls12381G2Key2020:
+		keyType = kms.BLS12381G2Type
+	case jsonWebKey2020:
+		return nil, errors.New("jsonWebKey is required for JsonWebKey2020 verification method")
+	}
+
+	if keyTypeOpt != nil {
+		kt, ok := keyTypeOpt.(kms.KeyType)
+		if !ok {
+			return nil, errors.New("keyType option is not a kms.KeyType")
+		}
+
+		keyType = kt
+	}
+
+	if keyType == "" {
+		return nil, fmt.Errorf("not supported public key type: %s", vm.Type)
+	}
+
+	j, err := jwksupport.PubKeyBytesToJWK(vm.Value, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JWK: %w", err)
+	}
+
+	// NIST P curves keys can't be told apart from signing keys once converted to JWK.
+	if isKeyAgreementType(keyType) {
+		j.Use = useEnc
+	}
+
+	return j, nil
+}
+
+func isKeyAgreementType(keyType kms.KeyType) bool {
+	switch keyType {
+	case kms.X25519ECDHKWType, kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
+		return true
+	default:
+		return false
+	}
+}
+
+// createDoc creates the DID document of didJWK with j as its single verification method '#0'. The verification
+// relationships follow the JWK 'use': signing keys are referenced by authentication, assertionMethod,
+// capabilityInvocation and capabilityDelegation and encryption keys by keyAgreement. Keys without 'use' are referenced
+// by all of them, except for Ed25519 and BLS12381G2 keys which can only sign and X25519 keys which can't sign.
+func createDoc(didJWK string, j *josejwk.JWK) (*did.Doc, error) {
+	vm, err := did.NewVerificationMethodFromJWK(didJWK+"#0", jsonWebKey2020, didJWK, j)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create verification method: %w", err)
+	}
+
+	sign, agree := keyUsage(j)
+
+	doc := &did.Doc{
+		Context:            []string{schemaDIDV1, schemaJWS2020V1},
+		ID:                 didJWK,
+		VerificationMethod: []did.VerificationMethod{*vm},
+	}
+
+	if sign {
+		doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+		doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+		doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+		doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+	}
+
+	if agree {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+	}
+
+	return doc, nil
+}
+
+func keyUsage(j *josejwk.JWK) (bool, bool) {
+	switch j.Use {
+	case useSig:
+		return true, false
+	case useEnc:
+		return false, true
+	}
+
+	keyType, err := j.KeyType()
+	if err != nil {
+		return true, true
+	}
+
+	switch keyType {
+	case kms.ED25519Type, kms.BLS12381G2Type:
+		return true, false
+	case kms.X25519ECDHKWType:
+		return false, true
+	default:
+		return true, true
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	josejwk "github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const (
+	// did:jwk specification P-256 example.
+	didJWKP256 = "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9" //nolint:lll
+	jwkP256    = `{"crv":"P-256","kty":"EC","x":"acbIQiuMs3i8_uszEjJ2tpTtRM4EU3yz91PH6CdH2V0","y":"_KcyLj9vWMptnmKtm46GqDz8wf74I5LKgrl2GzH3nSE"}`                                                   //nolint:lll
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("create from JsonWebKey2020 verification method", func(t *testing.T) {
+		j := &josejwk.JWK{}
+		require.NoError(t, j.UnmarshalJSON([]byte(jwkP256)))
+
+		vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didJWKP256, doc.ID)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, didJWKP256+"#0", doc.VerificationMethod[0].ID)
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.Equal(t, didJWKP256, doc.VerificationMethod[0].Controller)
+		require.Equal(t, vm.Value, doc.VerificationMethod[0].Value)
+		assertRelationships(t, doc, true, true)
+
+		_, err = did.Parse(doc.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("create from KMS public keys", func(t *testing.T) {
+		k := createKMS(t)
+
+		tests := []struct {
+			keyType kms.KeyType
+			sign    bool
+			agree   bool
+		}{
+			{keyType: kms.ED25519Type, sign: true},
+			{keyType: kms.BLS12381G2Type, sign: true},
+			{keyType: kms.ECDSAP256TypeIEEEP1363, sign: true, agree: true},
+			{keyType: kms.ECDSAP384TypeDER, sign: true, agree: true},
+			{keyType: kms.NISTP521ECDHKWType, agree: true},
+			{keyType: kms.X25519ECDHKWType, agree: true},
+		}
+
+		for _, tc := range tests {
+			_, pubKey, err := k.CreateAndExportPubKeyBytes(tc.keyType)
+			require.NoError(t, err)
+
+			docResolution, err := New().Create(
+				&did.Doc{VerificationMethod: []did.VerificationMethod{{Type: "Multikey", Value: pubKey}}},
+				vdrapi.WithOption(KeyType, tc.keyType))
+			require.NoError(t, err, tc.keyType)
+
+			doc := docResolution.DIDDocument
+			assertRelationships(t, doc, tc.sign, tc.agree)
+
+			keyType, err := doc.VerificationMethod[0].JSONWebKey().KeyType()
+			require.NoError(t, err)
+
+			expectedJWK, err := jwksupport.PubKeyBytesToJWK(pubKey, tc.keyType)
+			require.NoError(t, err)
+
+			expectedKeyType, err := expectedJWK.KeyType()
+			require.NoError(t, err)
+			require.Equal(t, expectedKeyType, keyType)
+
+			// the DID resolves to the same document.
+			resolved, err := New().Read(doc.ID)
+			require.NoError(t, err)
+			require.Equal(t, doc.ID, resolved.DIDDocument.ID)
+			require.Equal(t, doc.VerificationMethod[0].Value, resolved.DIDDocument.VerificationMethod[0].Value)
+			assertRelationships(t, resolved.DIDDocument, tc.sign, tc.agree)
+		}
+	})
+
+	t.Run("create from typed verification methods", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			{Type: ed25519VerificationKey2018, Value: pubKey},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, ed25519.PublicKey(docResolution.DIDDocument.VerificationMethod[0].Value), pubKey)
+		assertRelationships(t, docResolution.DIDDocument, true, false)
+
+		x25519Key := make([]byte, 32)
+		_, err = rand.Read(x25519Key)
+		require.NoError(t, err)
+
+		docResolution, err = New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			{Type: x25519KeyAgreementKey2019, Value: x25519Key},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, x25519Key, docResolution.DIDDocument.VerificationMethod[0].Value)
+		assertRelationships(t, docResolution.DIDDocument, false, true)
+	})
+
+	t.Run("create with JWK 'use'", func(t *testing.T) {
+		j := &josejwk.JWK{}
+		require.NoError(t, j.UnmarshalJSON([]byte(jwkP256)))
+
+		j.Use = useSig
+
+		vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+		require.NotEqual(t, didJWKP256, docResolution.DIDDocument.ID)
+		assertRelationships(t, docResolution.DIDDocument, true, false)
+	})
+
+	t.Run("create errors", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Create(&did.Doc{})
+		require.EqualError(t, err, "verification method is empty")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{{Type: jsonWebKey2020}}})
+		require.EqualError(t, err, "jwk vdr Create: jsonWebKey is required for JsonWebKey2020 verification method")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{{Type: "invalid"}}})
+		require.EqualError(t, err, "jwk vdr Create: not supported public key type: invalid")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{{Type: "invalid"}}},
+			vdrapi.WithOption(KeyType, "ED25519"))
+		require.EqualError(t, err, "jwk vdr Create: keyType option is not a kms.KeyType")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{{Type: "invalid"}}},
+			vdrapi.WithOption(KeyType, kms.HMACSHA256Tag256Type))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwk vdr Create: failed to build JWK")
+	})
+
+	t.Run("create with private key", func(t *testing.T) {
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		j, err := jwksupport.JWKFromKey(privKey)
+		require.NoError(t, err)
+
+		_, err = CreateDID(j)
+		require.EqualError(t, err, "JWK must be a public key")
+	})
+}
+
+func TestCreateDID(t *testing.T) {
+	j := &josejwk.JWK{}
+	require.NoError(t, j.UnmarshalJSON([]byte(jwkP256)))
+
+	didJWK, err := CreateDID(j)
+	require.NoError(t, err)
+	require.Equal(t, didJWKP256, didJWK)
+
+	jwkBytes, err := base64.RawURLEncoding.DecodeString(didJWK[len("did:jwk:"):])
+	require.NoError(t, err)
+	require.True(t, json.Valid(jwkBytes))
+	require.JSONEq(t, jwkP256, string(jwkBytes))
+}
+
+func assertRelationships(t *testing.T, doc *did.Doc, sign, agree bool) {
+	t.Helper()
+
+	relationships := map[string][]did.Verification{
+		"authentication":       doc.Authentication,
+		"assertionMethod":      doc.AssertionMethod,
+		"capabilityDelegation": doc.CapabilityDelegation,
+		"capabilityInvocation": doc.CapabilityInvocation,
+	}
+
+	for name, verifications := range relationships {
+		if !sign {
+			require.Empty(t, verifications, name)
+
+			continue
+		}
+
+		require.Len(t, verifications, 1, name)
+		require.Equal(t, doc.ID+"#0", verifications[0].VerificationMethod.ID, name)
+	}
+
+	if !agree {
+		require.Empty(t, doc.KeyAgreement)
+
+		return
+	}
+
+	require.Len(t, doc.KeyAgreement, 1)
+	require.Equal(t, doc.ID+"#0", doc.KeyAgreement[0].VerificationMethod.ID)
+}
+
+func createKMS(t *testing.T) *localkms.LocalKMS {
+	t.Helper()
+
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	k, err := localkms.New("local-lock://test/key/uri", p)
+	require.NoError(t, err)
+
+	return k
+}
@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	josejwk "github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// Read expands did:jwk value to a DID document. The DID document is built offline from the JWK encoded in the DID.
+func (v *VDR) Read(didJWK string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didJWK)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: failed to parse DID document: %w", err)
+	}
+
+	if parsed.Method != DIDMethod {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method: %s", parsed.Method)
+	}
+
+	jwkBytes, err := base64.RawURLEncoding.DecodeString(parsed.MethodSpecificID)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: invalid did:jwk method ID: %w", err)
+	}
+
+	_, err = publicJWKMembers(jwkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	j := &josejwk.JWK{}
+
+	err = j.UnmarshalJSON(jwkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: failed to unmarshal JWK: %w", err)
+	}
+
+	didDoc, err := createDoc(didJWK, j)
+	if err != nil {
+		return nil, fmt.Errorf("jwk vdr Read: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
+}
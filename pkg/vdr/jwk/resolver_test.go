@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	t.Run("resolve P-256 key", func(t *testing.T) {
+		docResolution, err := New().Read(didJWKP256)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didJWKP256, doc.ID)
+		require.Equal(t, []string{schemaDIDV1, schemaJWS2020V1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, didJWKP256+"#0", doc.VerificationMethod[0].ID)
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		assertRelationships(t, doc, true, true)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		raw := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(docBytes, &raw))
+
+		vms, ok := raw["verificationMethod"].([]interface{})
+		require.True(t, ok)
+
+		pubKeyJWK, err := json.Marshal(vms[0].(map[string]interface{})["publicKeyJwk"])
+		require.NoError(t, err)
+		require.JSONEq(t, jwkP256, string(pubKeyJWK))
+	})
+
+	t.Run("resolve X25519 encryption key", func(t *testing.T) {
+		// did:jwk specification X25519 example.
+		const didJWK = "did:jwk:eyJrdHkiOiJPS1AiLCJjcnYiOiJYMjU1MTkiLCJ1c2UiOiJlbmMiLCJ4IjoiM3A3YmZYdDl3YlRUVzJIQzdPUTFOei1EUThoYmVHZE5yZngtRkctSUswOCJ9" //nolint:lll
+
+		docResolution, err := New().Read(didJWK)
+		require.NoError(t, err)
+		require.Equal(t, didJWK, docResolution.DIDDocument.ID)
+		assertRelationships(t, docResolution.DIDDocument, false, true)
+	})
+
+	t.Run("resolve signing key", func(t *testing.T) {
+		didJWK := "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+			`{"kty":"OKP","crv":"Ed25519","use":"sig","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`))
+
+		docResolution, err := New().Read(didJWK)
+		require.NoError(t, err)
+		assertRelationships(t, docResolution.DIDDocument, true, false)
+	})
+
+	t.Run("resolve errors", func(t *testing.T) {
+		v := New()
+
+		_, err := v.Read("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwk vdr Read: failed to parse DID document")
+
+		_, err = v.Read("did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH")
+		require.EqualError(t, err, "jwk vdr Read: invalid did:jwk method: key")
+
+		_, err = v.Read("did:jwk:abc.def")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwk vdr Read: invalid did:jwk method ID")
+
+		_, err = v.Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte("not a JWK")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwk vdr Read: failed to unmarshal JWK")
+
+		_, err = v.Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(`{"kty":"unknown"}`)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwk vdr Read: failed to unmarshal JWK")
+
+		// RFC 8037 Ed25519 private key.
+		_, err = v.Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+			`{"kty":"OKP","crv":"Ed25519","d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A",`+
+				`"x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`)))
+		require.EqualError(t, err, "jwk vdr Read: JWK must be a public key")
+	})
+}
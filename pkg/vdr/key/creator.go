@@ -8,8 +8,11 @@ package key
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/btcsuite/btcutil/base58"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
@@ -19,15 +22,20 @@ import (
 const (
 	schemaResV1                = "https://w3id.org/did-resolution/v1"
 	schemaDIDV1                = "https://w3id.org/did/v1"
+	schemaMultikeyV1           = "https://w3id.org/security/multikey/v1"
+	schemaJWS2020V1            = "https://w3id.org/security/suites/jws-2020/v1"
 	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
 	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
 	bls12381G2Key2020          = "Bls12381G2Key2020"
 	jsonWebKey2020             = "JsonWebKey2020"
+	multikey                   = "Multikey"
 )
 
 // Create new DID document for didDoc.
 // Either didDoc must contain non-empty VerificationMethod[] or opts must contain KeyType value of kms.KeyType to create
 // a new key and a corresponding *VerificationMethod entry.
+// JsonWebKey2020 and Multikey verification methods (of which the value is the multicodec encoded public key) create
+// a DID document with verification methods in the same format, as per the did:key spec.
 func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
 	createDIDOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
 	// Apply options
@@ -36,11 +44,8 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 	}
 
 	var (
-		publicKey, keyAgr *did.VerificationMethod
-		err               error
-		didKey            string
-		keyID             string
-		keyCode           uint64
+		doc *did.Doc
+		err error
 	)
 
 	if len(didDoc.VerificationMethod) == 0 {
@@ -48,42 +53,79 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 	}
 
 	switch didDoc.VerificationMethod[0].Type {
-	case jsonWebKey2020:
-		didKey, keyID, err = fingerprint.CreateDIDKeyByJwk(didDoc.VerificationMethod[0].JSONWebKey())
-		if err != nil {
-			return nil, err
-		}
+	case jsonWebKey2020, multikey, x25519KeyAgreementKey2019:
+		doc, err = createFormattedDIDDocFromVM(&didDoc.VerificationMethod[0])
 	default:
-		keyCode, err = getKeyCode(&didDoc.VerificationMethod[0])
-		if err != nil {
-			return nil, err
+		doc, err = createDIDDocFromVM(&didDoc.VerificationMethod[0])
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// retrieve encryption key as keyAgreement from opts if available.
+	k := createDIDOpts.Values[EncryptionKey]
+	if k != nil {
+		keyAgr, ok := k.(*did.VerificationMethod)
+
+		if !ok {
+			return nil, fmt.Errorf("encryptionKey not VerificationMethod")
 		}
 
-		didKey, keyID = fingerprint.CreateDIDKeyByCode(keyCode, didDoc.VerificationMethod[0].Value)
+		doc.KeyAgreement = []did.Verification{*did.NewEmbeddedVerification(keyAgr, did.KeyAgreement)}
 	}
 
-	publicKey = did.NewVerificationMethodFromBytes(keyID, didDoc.VerificationMethod[0].Type, didKey,
-		didDoc.VerificationMethod[0].Value)
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+func createDIDDocFromVM(vm *did.VerificationMethod) (*did.Doc, error) {
+	var keyAgr *did.VerificationMethod
 
-	if didDoc.VerificationMethod[0].Type == ed25519VerificationKey2018 {
-		keyAgr, err = keyAgreementFromEd25519(didKey, didDoc.VerificationMethod[0].Value)
+	keyCode, err := getKeyCode(vm)
+	if err != nil {
+		return nil, err
+	}
+
+	didKey, keyID := fingerprint.CreateDIDKeyByCode(keyCode, vm.Value)
+
+	publicKey := did.NewVerificationMethodFromBytes(keyID, vm.Type, didKey, vm.Value)
+
+	if vm.Type == ed25519VerificationKey2018 {
+		keyAgr, err = keyAgreementFromEd25519(didKey, vm.Value)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// retrieve encryption key as keyAgreement from opts if available.
-	k := createDIDOpts.Values[EncryptionKey]
-	if k != nil {
-		var ok bool
-		keyAgr, ok = k.(*did.VerificationMethod)
+	return createDoc(publicKey, keyAgr, didKey), nil
+}
 
-		if !ok {
-			return nil, fmt.Errorf("encryptionKey not VerificationMethod")
+func createFormattedDIDDocFromVM(vm *did.VerificationMethod) (*did.Doc, error) {
+	var (
+		methodID string
+		format   string
+	)
+
+	switch vm.Type {
+	case jsonWebKey2020:
+		didKey, _, err := fingerprint.CreateDIDKeyByJwk(vm.JSONWebKey())
+		if err != nil {
+			return nil, err
 		}
+
+		methodID, format = strings.TrimPrefix(didKey, "did:key:"), JSONWebKey2020Format
+	case multikey:
+		methodID, format = "z"+base58.Encode(vm.Value), MultikeyFormat
+	default:
+		methodID = fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, vm.Value)
 	}
 
-	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: createDoc(publicKey, keyAgr, didKey)}, nil
+	pubKeyBytes, code, err := fingerprint.PubKeyFromFingerprint(methodID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s public key: %w", vm.Type, err)
+	}
+
+	return createFormattedDIDDoc(methodID, code, pubKeyBytes, format)
 }
 
 func getKeyCode(verificationMethod *did.VerificationMethod) (uint64, error) {
@@ -125,6 +167,45 @@ func createDoc(pubKey, keyAgreement *did.VerificationMethod, didKey string) *did
 	}
 }
 
+// createFormattedDoc creates a did:key DID document with verification methods in format. pubKey is nil for keys that
+// can only be used for key agreement and keyAgreement is added to the verification methods if it isn't pubKey.
+func createFormattedDoc(pubKey, keyAgreement *did.VerificationMethod, didKey, format string) *did.Doc {
+	// Created/Updated time
+	t := time.Now()
+
+	doc := &did.Doc{
+		Context: []string{schemaDIDV1},
+		ID:      didKey,
+		Created: &t,
+		Updated: &t,
+	}
+
+	switch format {
+	case MultikeyFormat:
+		doc.Context = append(doc.Context.([]string), schemaMultikeyV1)
+	case JSONWebKey2020Format:
+		doc.Context = append(doc.Context.([]string), schemaJWS2020V1)
+	}
+
+	if pubKey != nil {
+		doc.VerificationMethod = append(doc.VerificationMethod, *pubKey)
+		doc.Authentication = []did.Verification{*did.NewReferencedVerification(pubKey, did.Authentication)}
+		doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(pubKey, did.AssertionMethod)}
+		doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(pubKey, did.CapabilityDelegation)}
+		doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(pubKey, did.CapabilityInvocation)}
+	}
+
+	if keyAgreement != nil {
+		if pubKey == nil || keyAgreement.ID != pubKey.ID {
+			doc.VerificationMethod = append(doc.VerificationMethod, *keyAgreement)
+		}
+
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(keyAgreement, did.KeyAgreement)}
+	}
+
+	return doc
+}
+
 func keyAgreementFromEd25519(didKey string, ed25519PubKey []byte) (*did.VerificationMethod, error) {
 	curve25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(ed25519PubKey)
 	if err != nil {
@@ -10,14 +10,19 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rand"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 func TestBuild(t *testing.T) {
@@ -190,6 +195,130 @@ func TestBuild(t *testing.T) {
 	})
 }
 
+func TestBuildFormatted(t *testing.T) {
+	const ed25519Key = "z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+
+	t.Run("build with Multikey", func(t *testing.T) {
+		v := New()
+
+		for _, key := range []string{
+			ed25519Key,
+			"z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc",
+			"zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169",
+			"zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme",
+		} {
+			pubKey := did.VerificationMethod{
+				Type:  multikey,
+				Value: base58.Decode(key[1:]),
+			}
+
+			docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}})
+			require.NoError(t, err)
+			require.Equal(t, "did:key:"+key, docResolution.DIDDocument.ID)
+
+			resolved, err := v.Read("did:key:"+key, vdrapi.WithOption(PublicKeyFormat, MultikeyFormat))
+			require.NoError(t, err)
+			require.Equal(t, resolved.DIDDocument.VerificationMethod, docResolution.DIDDocument.VerificationMethod)
+			require.Equal(t, resolved.DIDDocument.KeyAgreement, docResolution.DIDDocument.KeyAgreement)
+			require.Equal(t, resolved.DIDDocument.Authentication, docResolution.DIDDocument.Authentication)
+		}
+	})
+
+	t.Run("build with Ed25519 JWK derives X25519 key agreement", func(t *testing.T) {
+		j, err := jwksupport.JWKFromKey(ed25519.PublicKey(base58.Decode("B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u")))
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("id", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, "did:key:"+ed25519Key, doc.ID)
+		require.Equal(t, []string{schemaDIDV1, schemaJWS2020V1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Equal(t, "X25519", doc.KeyAgreement[0].VerificationMethod.JSONWebKey().Crv)
+		require.Equal(t, base58.Decode("JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"),
+			doc.KeyAgreement[0].VerificationMethod.Value)
+	})
+
+	t.Run("build with secp256k1 JWK", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+		require.NoError(t, err)
+
+		j, err := jwksupport.JWKFromKey(&privKey.PublicKey)
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("id", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:key:zQ3s"))
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, &privKey.PublicKey, doc.Authentication[0].VerificationMethod.JSONWebKey().Key)
+		require.Equal(t, doc.VerificationMethod[0].ID, doc.KeyAgreement[0].VerificationMethod.ID)
+	})
+
+	t.Run("build with X25519 keys", func(t *testing.T) {
+		x25519Key := base58.Decode("JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr")
+
+		j, err := jwksupport.JWKFromX25519Key(x25519Key)
+		require.NoError(t, err)
+
+		jwkVM, err := did.NewVerificationMethodFromJWK("id", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		for _, vm := range []*did.VerificationMethod{
+			jwkVM,
+			{Type: x25519KeyAgreementKey2019, Value: x25519Key},
+		} {
+			docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+			require.NoError(t, err)
+
+			doc := docResolution.DIDDocument
+			require.Equal(t, "did:key:z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc", doc.ID)
+			require.Len(t, doc.VerificationMethod, 1)
+			require.Equal(t, vm.Type, doc.VerificationMethod[0].Type)
+			require.Empty(t, doc.Authentication)
+			require.Len(t, doc.KeyAgreement, 1)
+			require.Equal(t, x25519Key, doc.KeyAgreement[0].VerificationMethod.Value)
+		}
+	})
+
+	t.Run("build with encryption key option", func(t *testing.T) {
+		encKey := did.NewVerificationMethodFromBytes("#enc", x25519KeyAgreementKey2019, "", []byte("key"))
+
+		docResolution, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			{Type: multikey, Value: base58.Decode(ed25519Key[1:])},
+		}}, vdrapi.WithOption(EncryptionKey, encKey))
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.KeyAgreement, 1)
+		require.True(t, docResolution.DIDDocument.KeyAgreement[0].Embedded)
+		require.Equal(t, encKey.ID, docResolution.DIDDocument.KeyAgreement[0].VerificationMethod.ID)
+
+		_, err = New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			{Type: multikey, Value: base58.Decode(ed25519Key[1:])},
+		}}, vdrapi.WithOption(EncryptionKey, "invalid"))
+		require.EqualError(t, err, "encryptionKey not VerificationMethod")
+	})
+
+	t.Run("build with invalid Multikey", func(t *testing.T) {
+		_, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+			{Type: multikey, Value: fingerprint.MulticodecKey(fingerprint.P256PubKeyMultiCodec, []byte{0x01})},
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error unmarshalling key bytes")
+
+		_, err = New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{{Type: multikey}}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid Multikey public key")
+	})
+}
+
 func assertEd25519Doc(t *testing.T, doc *did.Doc) {
 	const (
 		didKey         = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
@@ -224,8 +353,7 @@ func assertP256Doc(t *testing.T, doc *did.Doc) {
 		pubKeyBase58 = "Q1sFNywhsHf5Wds93YN1b97jrFiUQchN3nDgboS64kqzbTrPNN6ESCibhyNEidDMHa6M1V43dVeiFpBaUa4RXxMa"
 	)
 
-	assertDualBase58Doc(t, doc, didKey, didKeyID, jsonWebKey2020, pubKeyBase58,
-		"", "", "")
+	assertBase58Doc(t, doc, didKey, didKeyID, jsonWebKey2020, pubKeyBase58)
 }
 
 func assertP384Doc(t *testing.T, doc *did.Doc) {
@@ -236,8 +364,7 @@ func assertP384Doc(t *testing.T, doc *did.Doc) {
 		pubKeyBase58 = "7xunFyusHxhJS3tbNWcX7xHCLRPnsScaBJJQUWw8KPpTTPfUSw9RbdyQYCBaLopw6eVQJv1G4ZD4EWgnE3zmkuiGHTq5y1KAwPAUv9Q4XXBricnzAxKamSHJiX29uQqGtbux"                    //nolint:lll
 	)
 
-	assertDualBase58Doc(t, doc, didKey, didKeyID, jsonWebKey2020, pubKeyBase58,
-		"", "", "")
+	assertBase58Doc(t, doc, didKey, didKeyID, jsonWebKey2020, pubKeyBase58)
 }
 
 func assertP521Doc(t *testing.T, doc *did.Doc) {
@@ -248,8 +375,7 @@ func assertP521Doc(t *testing.T, doc *did.Doc) {
 		pubKeyBase58 = "CqTBHvN1FwpkcrhNddXM3zSZRF7rUNSCCBuPWRxBmNAGBMa91by5XebadFwGJ2d1AVJMbUUKmUiBGXaCDDVEDn5fthbSBosoFG4anpQextGkuHHJohZxeLrGuyHc4JZYGyWFbAXVRKTMFRxuF8eQ88zqvjEV6k8oNbQ6vELYFp9CjQudG7cqP"                     //nolint:lll
 	)
 
-	assertDualBase58Doc(t, doc, didKey, didKeyID, jsonWebKey2020, pubKeyBase58,
-		"", "", "")
+	assertBase58Doc(t, doc, didKey, didKeyID, jsonWebKey2020, pubKeyBase58)
 }
 
 func assertBase58Doc(t *testing.T, doc *did.Doc, didKey, didKeyID, didKeyType, pubKeyBase58 string) {
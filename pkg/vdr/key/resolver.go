@@ -8,18 +8,31 @@ package key
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"fmt"
 	"regexp"
 
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
-// Read expands did:key value to a DID document.
-func (v *VDR) Read(didKey string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+// Read expands did:key value to a DID document. The PublicKeyFormat option sets the format of the verification
+// methods.
+func (v *VDR) Read(didKey string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	didMethodOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(didMethodOpts)
+	}
+
 	parsed, err := did.Parse(didKey)
 	if err != nil {
 		return nil, fmt.Errorf("pub:key vdr Read: failed to parse DID document: %w", err)
@@ -33,12 +46,17 @@ func (v *VDR) Read(didKey string, _ ...vdrapi.DIDMethodOption) (*did.DocResoluti
 		return nil, fmt.Errorf("vdr Read: invalid did:key method ID: %s", parsed.MethodSpecificID)
 	}
 
+	format, err := publicKeyFormat(didMethodOpts.Values[PublicKeyFormat])
+	if err != nil {
+		return nil, fmt.Errorf("vdr Read: %w", err)
+	}
+
 	pubKeyBytes, code, err := fingerprint.PubKeyFromFingerprint(parsed.MethodSpecificID)
 	if err != nil {
 		return nil, fmt.Errorf("pub:key vdr Read: failed to get key fingerPrint: %w", err)
 	}
 
-	didDoc, err := createDIDDocFromPubKey(parsed.MethodSpecificID, code, pubKeyBytes)
+	didDoc, err := createDIDDocFromPubKey(parsed.MethodSpecificID, code, pubKeyBytes, format)
 	if err != nil {
 		return nil, fmt.Errorf("creating did document from public key failed: %w", err)
 	}
@@ -46,13 +64,33 @@ func (v *VDR) Read(didKey string, _ ...vdrapi.DIDMethodOption) (*did.DocResoluti
 	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
 }
 
-func createDIDDocFromPubKey(kid string, code uint64, pubKeyBytes []byte) (*did.Doc, error) {
+func publicKeyFormat(option interface{}) (string, error) {
+	if option == nil {
+		return "", nil
+	}
+
+	format, ok := option.(string)
+	if !ok || format != MultikeyFormat && format != JSONWebKey2020Format {
+		return "", fmt.Errorf("unsupported public key format: %v", option)
+	}
+
+	return format, nil
+}
+
+func createDIDDocFromPubKey(kid string, code uint64, pubKeyBytes []byte, format string) (*did.Doc, error) {
+	if format != "" {
+		return createFormattedDIDDoc(kid, code, pubKeyBytes, format)
+	}
+
 	switch code {
 	case fingerprint.ED25519PubKeyMultiCodec:
 		return createEd25519DIDDoc(kid, pubKeyBytes)
+	case fingerprint.X25519PubKeyMultiCodec:
+		return createFormattedDIDDoc(kid, code, pubKeyBytes, "")
 	case fingerprint.BLS12381g2PubKeyMultiCodec, fingerprint.BLS12381g1g2PubKeyMultiCodec:
 		return createBase58DIDDoc(kid, bls12381G2Key2020, pubKeyBytes)
-	case fingerprint.P256PubKeyMultiCodec, fingerprint.P384PubKeyMultiCodec, fingerprint.P521PubKeyMultiCodec:
+	case fingerprint.P256PubKeyMultiCodec, fingerprint.P384PubKeyMultiCodec, fingerprint.P521PubKeyMultiCodec,
+		fingerprint.Secp256k1PubKeyMultiCodec:
 		return createJSONWebKey2020DIDDoc(kid, code, pubKeyBytes)
 	}
 
@@ -75,6 +113,28 @@ func createJSONWebKey2020DIDDoc(kid string, code uint64, pubKeyBytes []byte) (*d
 
 	keyID := fmt.Sprintf("%s#%s", didKey, kid)
 
+	publicKey, err := ecdsaPubKey(code, pubKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := jwksupport.JWKFromKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating JWK %w", err)
+	}
+
+	vm, err := did.NewVerificationMethodFromJWK(keyID, jsonWebKey2020, didKey, j)
+	if err != nil {
+		return nil, fmt.Errorf("error creating verification method %w", err)
+	}
+
+	didDoc := createDoc(vm, vm, didKey)
+
+	return didDoc, nil
+}
+
+// ecdsaPubKey parses the compressed point pubKeyBytes of a NIST P curve or secp256k1 key.
+func ecdsaPubKey(code uint64, pubKeyBytes []byte) (*ecdsa.PublicKey, error) {
 	var curve elliptic.Curve
 
 	switch code {
@@ -84,6 +144,14 @@ func createJSONWebKey2020DIDDoc(kid string, code uint64, pubKeyBytes []byte) (*d
 		curve = elliptic.P384()
 	case fingerprint.P521PubKeyMultiCodec:
 		curve = elliptic.P521()
+	case fingerprint.Secp256k1PubKeyMultiCodec:
+		// elliptic.UnmarshalCompressed only supports curves with a = -3.
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling key bytes: %w", err)
+		}
+
+		return pubKey.ToECDSA(), nil
 	default:
 		return nil, fmt.Errorf("unsupported key multicodec code for JsonWebKey2020 [0x%x]", code)
 	}
@@ -93,25 +161,11 @@ func createJSONWebKey2020DIDDoc(kid string, code uint64, pubKeyBytes []byte) (*d
 		return nil, fmt.Errorf("error unmarshalling key bytes")
 	}
 
-	publicKey := ecdsa.PublicKey{
+	return &ecdsa.PublicKey{
 		Curve: curve,
 		X:     x,
 		Y:     y,
-	}
-
-	j, err := jwksupport.JWKFromKey(&publicKey)
-	if err != nil {
-		return nil, fmt.Errorf("error creating JWK %w", err)
-	}
-
-	vm, err := did.NewVerificationMethodFromJWK(keyID, jsonWebKey2020, didKey, j)
-	if err != nil {
-		return nil, fmt.Errorf("error creating verification method %w", err)
-	}
-
-	didDoc := createDoc(vm, vm, didKey)
-
-	return didDoc, nil
+	}, nil
 }
 
 func createEd25519DIDDoc(kid string, pubKeyBytes []byte) (*did.Doc, error) {
@@ -134,6 +188,90 @@ func createEd25519DIDDoc(kid string, pubKeyBytes []byte) (*did.Doc, error) {
 	return didDoc, nil
 }
 
+// createFormattedDIDDoc creates the DID document of a did:key as per the did:key spec, with verification methods in
+// format. An X25519 key agreement verification method is derived from Ed25519 keys and X25519 keys can only be used
+// for key agreement. An empty format creates a X25519KeyAgreementKey2019 verification method for X25519 keys.
+func createFormattedDIDDoc(kid string, code uint64, pubKeyBytes []byte, format string) (*did.Doc, error) {
+	didKey := fmt.Sprintf("did:key:%s", kid)
+
+	vm, err := formattedVerificationMethod(didKey, code, pubKeyBytes, format)
+	if err != nil {
+		return nil, err
+	}
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		curve25519PubKey, e := cryptoutil.PublicEd25519toCurve25519(pubKeyBytes)
+		if e != nil {
+			return nil, fmt.Errorf("failed to derive key agreement key: %w", e)
+		}
+
+		keyAgr, e := formattedVerificationMethod(didKey, fingerprint.X25519PubKeyMultiCodec, curve25519PubKey, format)
+		if e != nil {
+			return nil, e
+		}
+
+		return createFormattedDoc(vm, keyAgr, didKey, format), nil
+	case fingerprint.X25519PubKeyMultiCodec:
+		return createFormattedDoc(nil, vm, didKey, format), nil
+	case fingerprint.BLS12381g2PubKeyMultiCodec, fingerprint.BLS12381g1g2PubKeyMultiCodec:
+		return createFormattedDoc(vm, nil, didKey, format), nil
+	default:
+		return createFormattedDoc(vm, vm, didKey, format), nil
+	}
+}
+
+// formattedVerificationMethod creates the verification method of the pubKeyBytes key of the multicodec code in format.
+func formattedVerificationMethod(didKey string, code uint64, pubKeyBytes []byte,
+	format string) (*did.VerificationMethod, error) {
+	j, err := jwkFromPubKey(code, pubKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := fmt.Sprintf("%s#%s", didKey, fingerprint.KeyFingerprint(code, pubKeyBytes))
+
+	switch format {
+	case MultikeyFormat:
+		return did.NewVerificationMethodFromBytes(keyID, multikey, didKey,
+			fingerprint.MulticodecKey(code, pubKeyBytes)), nil
+	case JSONWebKey2020Format:
+		return did.NewVerificationMethodFromJWK(keyID, jsonWebKey2020, didKey, j)
+	default:
+		return did.NewVerificationMethodFromBytes(keyID, x25519KeyAgreementKey2019, didKey, pubKeyBytes), nil
+	}
+}
+
+func jwkFromPubKey(code uint64, pubKeyBytes []byte) (*jwk.JWK, error) {
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		if len(pubKeyBytes) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("error unmarshalling key bytes")
+		}
+
+		return jwksupport.JWKFromKey(ed25519.PublicKey(pubKeyBytes))
+	case fingerprint.X25519PubKeyMultiCodec:
+		return jwksupport.JWKFromX25519Key(pubKeyBytes)
+	case fingerprint.BLS12381g2PubKeyMultiCodec, fingerprint.BLS12381g1g2PubKeyMultiCodec:
+		pubKey, err := bbs12381g2pub.UnmarshalPublicKey(pubKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling key bytes: %w", err)
+		}
+
+		return jwksupport.JWKFromKey(pubKey)
+	case fingerprint.P256PubKeyMultiCodec, fingerprint.P384PubKeyMultiCodec, fingerprint.P521PubKeyMultiCodec,
+		fingerprint.Secp256k1PubKeyMultiCodec:
+		pubKey, err := ecdsaPubKey(code, pubKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return jwksupport.JWKFromKey(pubKey)
+	}
+
+	return nil, fmt.Errorf("unsupported key multicodec code [0x%x]", code)
+}
+
 func isValidMethodID(id string) bool {
 	r := regexp.MustCompile(`(z)([1-9a-km-zA-HJ-NP-Z]{46})`)
 	return r.MatchString(id)
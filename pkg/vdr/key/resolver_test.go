@@ -9,11 +9,17 @@ package key
 import (
 	"crypto/elliptic"
 	"encoding/base64"
+	"encoding/json"
 	"math/big"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
@@ -30,9 +36,10 @@ func TestReadInvalid(t *testing.T) {
 	t.Run("validate not supported public key", func(t *testing.T) {
 		v := New()
 
-		doc, err := v.Read("did:key:z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc")
+		// Ed448 public key.
+		doc, err := v.Read("did:key:" + fingerprint.KeyFingerprint(0x1203, make([]byte, 57)))
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "unsupported key multicodec code [0xec]") // Curve25519 public key
+		require.Contains(t, err.Error(), "unsupported key multicodec code [0x1203]")
 		require.Nil(t, doc)
 	})
 
@@ -232,3 +239,140 @@ func TestCreateJsonWeKey(t *testing.T) {
 		require.Contains(t, err.Error(), "error unmarshalling key bytes")
 	})
 }
+
+func TestReadX25519(t *testing.T) {
+	const (
+		didKey       = "did:key:z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+		didKeyID     = "did:key:z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc#z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc" //nolint:lll
+		pubKeyBase58 = "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"
+	)
+
+	docResolution, err := New().Read(didKey)
+	require.NoError(t, err)
+
+	doc := docResolution.DIDDocument
+	require.Equal(t, didKey, doc.ID)
+	require.Len(t, doc.VerificationMethod, 1)
+	require.Empty(t, doc.Authentication)
+	require.Empty(t, doc.AssertionMethod)
+	require.Len(t, doc.KeyAgreement, 1)
+	require.False(t, doc.KeyAgreement[0].Embedded)
+
+	assertPubKey(t, &did.VerificationMethod{
+		ID:         didKeyID,
+		Type:       x25519KeyAgreementKey2019,
+		Controller: didKey,
+		Value:      base58.Decode(pubKeyBase58),
+	}, &doc.KeyAgreement[0].VerificationMethod)
+}
+
+func TestReadSecp256k1(t *testing.T) {
+	// did key from https://w3c-ccg.github.io/did-method-key/#secp256k1
+	const (
+		didKey   = "did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme"
+		didKeyID = "did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme#zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme" //nolint:lll
+		x        = "h0wVx_2iDlOcblulc8E5iEw1EYh5n1RYtLQfeSTyNc0"
+		y        = "O2EATIGbu6DezKFptj5scAIRntgfecanVNXxat1rnwE"
+	)
+
+	docResolution, err := New().Read(didKey)
+	require.NoError(t, err)
+	assertJSONWebKeyDoc(t, docResolution.DIDDocument, didKey, didKeyID, btcec.S256(),
+		readBigInt(t, x), readBigInt(t, y))
+
+	j := docResolution.DIDDocument.VerificationMethod[0].JSONWebKey()
+	require.Equal(t, "secp256k1", j.Crv)
+
+	keyType, err := j.KeyType()
+	require.NoError(t, err)
+	require.Equal(t, kms.ECDSASecp256k1TypeIEEEP1363, keyType)
+}
+
+func TestReadPublicKeyFormat(t *testing.T) {
+	const (
+		ed25519Key = "z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		x25519Key  = "z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+		p256Key    = "zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"
+	)
+
+	v := New()
+
+	t.Run("Ed25519 Multikey", func(t *testing.T) {
+		docResolution, err := v.Read("did:key:"+ed25519Key, vdrapi.WithOption(PublicKeyFormat, MultikeyFormat))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, []string{schemaDIDV1, schemaMultikeyV1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Equal(t, doc.ID+"#"+ed25519Key, doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, doc.ID+"#"+x25519Key, doc.KeyAgreement[0].VerificationMethod.ID)
+		require.False(t, doc.KeyAgreement[0].Embedded)
+
+		raw := resolvedVerificationMethods(t, doc)
+		require.Equal(t, multikey, raw[0]["type"])
+		require.Equal(t, ed25519Key, raw[0]["publicKeyMultibase"])
+		require.Equal(t, multikey, raw[1]["type"])
+		require.Equal(t, x25519Key, raw[1]["publicKeyMultibase"])
+	})
+
+	t.Run("Ed25519 JsonWebKey2020", func(t *testing.T) {
+		docResolution, err := v.Read("did:key:"+ed25519Key, vdrapi.WithOption(PublicKeyFormat, JSONWebKey2020Format))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, []string{schemaDIDV1, schemaJWS2020V1}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Equal(t, "Ed25519", doc.VerificationMethod[0].JSONWebKey().Crv)
+		require.Equal(t, base58.Decode("B12NYF8RrR3h41TDCTJojY59usg3mbtbjnFs7Eud1Y6u"), doc.VerificationMethod[0].Value)
+		require.Equal(t, "X25519", doc.KeyAgreement[0].VerificationMethod.JSONWebKey().Crv)
+		require.Equal(t, base58.Decode("JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"),
+			doc.KeyAgreement[0].VerificationMethod.Value)
+	})
+
+	t.Run("X25519 Multikey", func(t *testing.T) {
+		docResolution, err := v.Read("did:key:"+x25519Key, vdrapi.WithOption(PublicKeyFormat, MultikeyFormat))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Empty(t, doc.Authentication)
+		require.Equal(t, doc.ID+"#"+x25519Key, doc.KeyAgreement[0].VerificationMethod.ID)
+	})
+
+	t.Run("P-256 Multikey", func(t *testing.T) {
+		docResolution, err := v.Read("did:key:"+p256Key, vdrapi.WithOption(PublicKeyFormat, MultikeyFormat))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, doc.ID+"#"+p256Key, doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, doc.ID+"#"+p256Key, doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, p256Key, resolvedVerificationMethods(t, doc)[0]["publicKeyMultibase"])
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := v.Read("did:key:"+p256Key, vdrapi.WithOption(PublicKeyFormat, "Ed25519VerificationKey2020"))
+		require.EqualError(t, err, "vdr Read: unsupported public key format: Ed25519VerificationKey2020")
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := v.Read("did:key:"+fingerprint.KeyFingerprint(fingerprint.Secp256k1PubKeyMultiCodec, make([]byte, 33)),
+			vdrapi.WithOption(PublicKeyFormat, MultikeyFormat))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error unmarshalling key bytes")
+	})
+}
+
+func resolvedVerificationMethods(t *testing.T, doc *did.Doc) []map[string]interface{} {
+	t.Helper()
+
+	docBytes, err := doc.JSONBytes()
+	require.NoError(t, err)
+
+	raw := struct {
+		VerificationMethod []map[string]interface{} `json:"verificationMethod"`
+	}{}
+	require.NoError(t, json.Unmarshal(docBytes, &raw))
+
+	return raw.VerificationMethod
+}
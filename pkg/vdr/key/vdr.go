@@ -20,6 +20,14 @@ const (
 	EncryptionKey = "encryptionKey"
 	// KeyType option to create a new kms key for DIDDocs with empty VerificationMethod.
 	KeyType = "keyType"
+	// PublicKeyFormat option to resolve the verification methods of a did:key DID as MultikeyFormat or
+	// JSONWebKey2020Format, as per the did:key spec. Key type specific formats are resolved if it is not set.
+	PublicKeyFormat = "publicKeyFormat"
+	// MultikeyFormat public key format of Multikey verification methods. Their value is the multicodec encoded
+	// public key.
+	MultikeyFormat = "Multikey"
+	// JSONWebKey2020Format public key format of JsonWebKey2020 verification methods.
+	JSONWebKey2020Format = "JsonWebKey2020"
 )
 
 // VDR implements did:key method support.
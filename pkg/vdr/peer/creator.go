@@ -50,12 +50,20 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocR
 	}
 
 	if !store {
+		numAlgo, err := numAlgoOpt(docOpts)
+		if err != nil {
+			return nil, err
+		}
+
 		docResolution, err := build(didDoc, docOpts)
 		if err != nil {
 			return nil, fmt.Errorf("create peer DID : %w", err)
 		}
 
-		didDoc = docResolution.DIDDocument
+		didDoc, err = fromNumAlgo(docResolution.DIDDocument, numAlgo)
+		if err != nil {
+			return nil, fmt.Errorf("create peer DID : %w", err)
+		}
 	}
 
 	if err := v.storeDID(didDoc, nil); err != nil {
@@ -184,6 +192,84 @@ func build(didDoc *did.Doc, docOpts *vdrapi.DIDMethodOpts) (*did.DocResolution,
 	return &did.DocResolution{DIDDocument: didDoc}, nil
 }
 
+func numAlgoOpt(docOpts *vdrapi.DIDMethodOpts) (int, error) {
+	switch v := docOpts.Values[NumAlgoOption].(type) {
+	case nil:
+		return NumAlgo1, nil
+	case int:
+		return v, nil
+	case float64: // options unmarshalled from JSON
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("numalgo opt not a number")
+	}
+}
+
+// fromNumAlgo returns the document of the peer DID computed from doc with numAlgo.
+func fromNumAlgo(doc *did.Doc, numAlgo int) (*did.Doc, error) {
+	switch numAlgo {
+	case NumAlgo1:
+		return doc, nil
+	case NumAlgo2:
+		didID, err := computeDidMethod2(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolveDidMethod2(didID)
+	case NumAlgo4:
+		// the input document is immutable, timestamps are meaningless
+		doc.Created, doc.Updated = nil, nil
+
+		identifyVerificationMethods(doc)
+
+		didID, err := computeDidMethod4(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolveDidMethod4(didID)
+	default:
+		return nil, fmt.Errorf("unsupported numalgo %d", numAlgo)
+	}
+}
+
+// identifyVerificationMethods sets #key-1, #key-2... as ID of the verification methods of doc that have none, so
+// that verification relationships can reference them.
+func identifyVerificationMethods(doc *did.Doc) {
+	ids := map[string]string{}
+
+	for i := range doc.VerificationMethod {
+		vm := &doc.VerificationMethod[i]
+		if vm.ID == "" {
+			vm.ID = fmt.Sprintf("#key-%d", i+1)
+		}
+
+		ids[string(vm.Value)] = vm.ID
+	}
+
+	for _, verifications := range [][]did.Verification{
+		doc.Authentication, doc.AssertionMethod, doc.KeyAgreement, doc.CapabilityInvocation,
+		doc.CapabilityDelegation,
+	} {
+		for i := range verifications {
+			vm := &verifications[i].VerificationMethod
+			if vm.ID != "" {
+				continue
+			}
+
+			if id, ok := ids[string(vm.Value)]; ok {
+				vm.ID = id
+
+				continue
+			}
+
+			vm.ID = fmt.Sprintf("#key-%d", len(ids)+1)
+			ids[string(vm.Value)] = vm.ID
+		}
+	}
+}
+
 // stringEntry.
 func stringEntry(entry interface{}) string {
 	if entry == nil {
@@ -0,0 +1,264 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	// numalgo of the only peer DIDs that can be updated, their genesis doc is not encoded in the DID.
+	numAlgo1 = "1"
+
+	// UpdateSignerOption is the Signer of the delta created by Update and Deactivate.
+	UpdateSignerOption = "updateSigner"
+	// UpdateKeyIDOption is the ID of the verification method of the signer, the current document must authorize it
+	// through its authentication or capabilityInvocation verification relationships.
+	UpdateKeyIDOption = "updateKeyID"
+	// VersionIDOpt selects the version of the document to resolve, versions are numbered from "0" (genesis).
+	VersionIDOpt = "versionID"
+)
+
+// Signer signs peer DID document deltas.
+type Signer interface {
+	// Sign signs data and returns the signature.
+	Sign(data []byte) ([]byte, error)
+}
+
+// Update appends a delta replacing the document of a did:peer:1 with didDoc. The delta must be signed by a key of the
+// current document, see UpdateSignerOption and UpdateKeyIDOption.
+func (v *VDR) Update(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+	if didDoc == nil || didDoc.ID == "" {
+		return errors.New("DID and document are mandatory")
+	}
+
+	jsonDoc, err := didDoc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("JSON marshalling of document failed: %w", err)
+	}
+
+	return v.appendDelta(didDoc.ID, &docDelta{
+		Change:     base64.URLEncoding.EncodeToString(jsonDoc),
+		ModifiedAt: time.Now(),
+	}, opts...)
+}
+
+// Deactivate appends a delta deactivating a did:peer:1, no further delta is accepted afterwards. The delta must be
+// signed by a key of the current document, see UpdateSignerOption and UpdateKeyIDOption.
+func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
+	return v.appendDelta(didID, &docDelta{Deactivated: true, ModifiedAt: time.Now()}, opts...)
+}
+
+func (v *VDR) appendDelta(didID string, delta *docDelta, opts ...vdrapi.DIDMethodOption) error {
+	if !strings.HasPrefix(didID, peerPrefix+numAlgo1) {
+		return fmt.Errorf("not supported: %s is not a numalgo 1 peer DID", didID)
+	}
+
+	docOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(docOpts)
+	}
+
+	signer, ok := docOpts.Values[UpdateSignerOption].(Signer)
+	if !ok {
+		return errors.New("updateSigner opt is mandatory")
+	}
+
+	keyID, ok := docOpts.Values[UpdateKeyIDOption].(string)
+	if !ok || keyID == "" {
+		return errors.New("updateKeyID opt is mandatory")
+	}
+
+	deltas, err := v.getDeltas(didID)
+	if err != nil {
+		return fmt.Errorf("delta data fetch from store for did [%s] failed: %w", didID, err)
+	}
+
+	signingInput, err := deltaSigningInput(delta)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.Sign(signingInput)
+	if err != nil {
+		return fmt.Errorf("sign delta: %w", err)
+	}
+
+	delta.ModifiedBy = &[]modifiedBy{{Key: keyID, Sig: base64.RawURLEncoding.EncodeToString(sig)}}
+	deltas = append(deltas, *delta)
+
+	// verifies the new delta against the current state before storing it
+	_, _, err = assembleDocVersion(deltas, len(deltas)-1)
+	if err != nil {
+		return err
+	}
+
+	val, err := json.Marshal(deltas)
+	if err != nil {
+		return fmt.Errorf("JSON marshalling of document deltas failed: %w", err)
+	}
+
+	return v.store.Put(didID, val)
+}
+
+// assembleDocVersion applies deltas up to version, each delta following the genesis one must be signed by keys
+// authorized by the document it modifies.
+func assembleDocVersion(deltas []docDelta, version int) (*did.Doc, *did.DocumentMetadata, error) {
+	if version < 0 || version >= len(deltas) {
+		return nil, nil, fmt.Errorf("version %d not found", version)
+	}
+
+	doc, err := parseDeltaChange(&deltas[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := &did.DocumentMetadata{VersionID: strconv.Itoa(version)}
+
+	for i := 1; i <= version; i++ {
+		if metadata.Deactivated {
+			return nil, nil, fmt.Errorf("delta %d follows the deactivation of the document", i)
+		}
+
+		err = verifyDelta(doc, &deltas[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("delta %d: %w", i, err)
+		}
+
+		if deltas[i].Deactivated {
+			metadata.Deactivated = true
+
+			continue
+		}
+
+		next, err := parseDeltaChange(&deltas[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("delta %d: %w", i, err)
+		}
+
+		if next.ID != doc.ID {
+			return nil, nil, fmt.Errorf("delta %d: document ID changed to %s", i, next.ID)
+		}
+
+		doc = next
+	}
+
+	return doc, metadata, nil
+}
+
+func parseDeltaChange(delta *docDelta) (*did.Doc, error) {
+	doc, err := base64.URLEncoding.DecodeString(delta.Change)
+	if err != nil {
+		return nil, fmt.Errorf("decoding of document delta failed: %w", err)
+	}
+
+	document, err := did.ParseDocument(doc)
+	if err != nil {
+		return nil, fmt.Errorf("document ParseDocument() failed: %w", err)
+	}
+
+	return document, nil
+}
+
+// verifyDelta checks that every signature of delta was made by a key authorized by doc.
+func verifyDelta(doc *did.Doc, delta *docDelta) error {
+	if delta.ModifiedBy == nil || len(*delta.ModifiedBy) == 0 {
+		return errors.New("delta is not signed")
+	}
+
+	signingInput, err := deltaSigningInput(delta)
+	if err != nil {
+		return err
+	}
+
+	for _, by := range *delta.ModifiedBy {
+		vm := authorizedKey(doc, by.Key)
+		if vm == nil {
+			return fmt.Errorf("key %s is not authorized to modify the document", by.Key)
+		}
+
+		sig, err := base64.RawURLEncoding.DecodeString(by.Sig)
+		if err != nil {
+			return fmt.Errorf("decode signature of key %s: %w", by.Key, err)
+		}
+
+		err = verifySignature(vm, signingInput, sig)
+		if err != nil {
+			return fmt.Errorf("verify signature of key %s: %w", by.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// deltaSigningInput is the JSON of the delta without its signatures.
+func deltaSigningInput(delta *docDelta) ([]byte, error) {
+	signingInput, err := json.Marshal(&docDelta{
+		Change:      delta.Change,
+		ModifiedAt:  delta.ModifiedAt,
+		Deactivated: delta.Deactivated,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("JSON marshalling of delta signing input failed: %w", err)
+	}
+
+	return signingInput, nil
+}
+
+// authorizedKey returns the authentication or capabilityInvocation verification method of doc identified by keyID.
+func authorizedKey(doc *did.Doc, keyID string) *did.VerificationMethod {
+	for _, verifications := range [][]did.Verification{doc.Authentication, doc.CapabilityInvocation} {
+		for i := range verifications {
+			vm := &verifications[i].VerificationMethod
+			if absoluteKeyID(doc.ID, vm.ID) == absoluteKeyID(doc.ID, keyID) {
+				return vm
+			}
+		}
+	}
+
+	return nil
+}
+
+func absoluteKeyID(didID, keyID string) string {
+	if strings.HasPrefix(keyID, "#") {
+		return didID + keyID
+	}
+
+	return keyID
+}
+
+func verifySignature(vm *did.VerificationMethod, msg, sig []byte) error {
+	pubKey := &verifier.PublicKey{Type: vm.Type, Value: vm.Value}
+
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return verifier.NewEd25519SignatureVerifier().Verify(pubKey, msg, sig)
+	case jsonWebKey2020:
+		pubKey.JWK = vm.JSONWebKey()
+
+		return verifier.NewCompositePublicKeyVerifier([]verifier.SignatureVerifier{
+			verifier.NewEd25519SignatureVerifier(),
+			verifier.NewECDSAES256SignatureVerifier(),
+			verifier.NewECDSAES384SignatureVerifier(),
+			verifier.NewECDSAES521SignatureVerifier(),
+			verifier.NewECDSASecp256k1SignatureVerifier(),
+		}).Verify(pubKey, msg, sig)
+	default:
+		return fmt.Errorf("not supported public key type: %s", vm.Type)
+	}
+}
@@ -0,0 +1,331 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+// Reference: https://identity.foundation/peer-did-method-spec/#method-2-multiple-inception-key-without-doc
+const (
+	numAlgo2 = "2"
+
+	purposeAssertion            = 'A'
+	purposeEncryption           = 'E'
+	purposeVerification         = 'V'
+	purposeCapabilityInvocation = 'I'
+	purposeCapabilityDelegation = 'D'
+	purposeService              = 'S'
+
+	bls12381G2Key2020 = "Bls12381G2Key2020"
+	// abbreviation of the DIDCommMessaging service type in encoded services.
+	didCommMessagingAbbr = "dm"
+)
+
+// peerService is the abbreviated form of a service encoded in a did:peer:2.
+type peerService struct {
+	ID              string          `json:"id,omitempty"`
+	Type            string          `json:"t"`
+	ServiceEndpoint json.RawMessage `json:"s"`
+	RoutingKeys     []string        `json:"r,omitempty"`
+	Accept          []string        `json:"a,omitempty"`
+}
+
+// peerServiceEndpoint is the abbreviated form of a DIDComm V2 service endpoint.
+type peerServiceEndpoint struct {
+	URI         string   `json:"uri"`
+	RoutingKeys []string `json:"r,omitempty"`
+	Accept      []string `json:"a,omitempty"`
+}
+
+// computeDidMethod2 creates the peer DID with inlined keys and services of doc.
+// For example:
+// did:peer:2.Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V.Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc
+func computeDidMethod2(doc *did.Doc) (string, error) {
+	if len(doc.Authentication) == 0 && len(doc.KeyAgreement) == 0 {
+		return "", errors.New("numalgo 2 requires authentication or key agreement keys")
+	}
+
+	elements := []string{peerPrefix + numAlgo2}
+
+	for _, rel := range []struct {
+		purpose       byte
+		verifications []did.Verification
+	}{
+		{purposeVerification, doc.Authentication},
+		{purposeEncryption, doc.KeyAgreement},
+		{purposeAssertion, doc.AssertionMethod},
+		{purposeCapabilityInvocation, doc.CapabilityInvocation},
+		{purposeCapabilityDelegation, doc.CapabilityDelegation},
+	} {
+		for i := range rel.verifications {
+			key, err := multikey(&rel.verifications[i].VerificationMethod)
+			if err != nil {
+				return "", err
+			}
+
+			elements = append(elements, string(rel.purpose)+key)
+		}
+	}
+
+	for i := range doc.Service {
+		svc, err := encodeService(&doc.Service[i])
+		if err != nil {
+			return "", err
+		}
+
+		elements = append(elements, string(purposeService)+svc)
+	}
+
+	return strings.Join(elements, "."), nil
+}
+
+// resolveDidMethod2 expands a did:peer:2 into its DID document, keys are identified as #key-1, #key-2... and
+// services as #service, #service-1... in their order of appearance.
+func resolveDidMethod2(didID string) (*did.Doc, error) {
+	if !strings.HasPrefix(didID, peerPrefix+numAlgo2+".") {
+		return nil, fmt.Errorf("invalid did:peer:2 %s", didID)
+	}
+
+	doc := &did.Doc{Context: []string{did.ContextV1}, ID: didID}
+
+	for _, element := range strings.Split(strings.TrimPrefix(didID, peerPrefix+numAlgo2+"."), ".") {
+		if len(element) < 2 {
+			return nil, fmt.Errorf("invalid did:peer:2 element '%s'", element)
+		}
+
+		purpose, value := element[0], element[1:]
+
+		if purpose == purposeService {
+			svc, err := decodeService(value, len(doc.Service))
+			if err != nil {
+				return nil, err
+			}
+
+			doc.Service = append(doc.Service, *svc)
+
+			continue
+		}
+
+		vm, err := verificationMethodFromMultikey(fmt.Sprintf("#key-%d", len(doc.VerificationMethod)+1), didID, value)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.VerificationMethod = append(doc.VerificationMethod, *vm)
+
+		switch purpose {
+		case purposeVerification:
+			doc.Authentication = append(doc.Authentication, *did.NewReferencedVerification(vm, did.Authentication))
+		case purposeEncryption:
+			doc.KeyAgreement = append(doc.KeyAgreement, *did.NewReferencedVerification(vm, did.KeyAgreement))
+		case purposeAssertion:
+			doc.AssertionMethod = append(doc.AssertionMethod, *did.NewReferencedVerification(vm, did.AssertionMethod))
+		case purposeCapabilityInvocation:
+			doc.CapabilityInvocation = append(doc.CapabilityInvocation,
+				*did.NewReferencedVerification(vm, did.CapabilityInvocation))
+		case purposeCapabilityDelegation:
+			doc.CapabilityDelegation = append(doc.CapabilityDelegation,
+				*did.NewReferencedVerification(vm, did.CapabilityDelegation))
+		default:
+			return nil, fmt.Errorf("unsupported did:peer:2 purpose code '%c'", purpose)
+		}
+	}
+
+	for i := range doc.Service {
+		applyDIDCommKeys(i, doc)
+		applyDIDCommV2Keys(i, doc)
+	}
+
+	return doc, nil
+}
+
+// multikey returns the multibase encoded multicodec public key of vm.
+func multikey(vm *did.VerificationMethod) (string, error) {
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, vm.Value), nil
+	case x25519KeyAgreementKey2019:
+		return fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, vm.Value), nil
+	case bls12381G2Key2020:
+		return fingerprint.KeyFingerprint(fingerprint.BLS12381g2PubKeyMultiCodec, vm.Value), nil
+	case jsonWebKey2020:
+		didKey, _, err := fingerprint.CreateDIDKeyByJwk(vm.JSONWebKey())
+		if err != nil {
+			return "", fmt.Errorf("encode key %s: %w", vm.ID, err)
+		}
+
+		return strings.TrimPrefix(didKey, "did:key:"), nil
+	default:
+		return "", fmt.Errorf("not supported public key type: %s", vm.Type)
+	}
+}
+
+func verificationMethodFromMultikey(id, controller, key string) (*did.VerificationMethod, error) {
+	pubKey, code, err := fingerprint.PubKeyFromFingerprint(key)
+	if err != nil {
+		return nil, fmt.Errorf("decode key %s: %w", key, err)
+	}
+
+	var curve elliptic.Curve
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, ed25519VerificationKey2018, controller, pubKey), nil
+	case fingerprint.X25519PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, x25519KeyAgreementKey2019, controller, pubKey), nil
+	case fingerprint.BLS12381g2PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, bls12381G2Key2020, controller, pubKey), nil
+	case fingerprint.BLS12381g1g2PubKeyMultiCodec:
+		// a G1G2 key isn't a single verification method, only BLS12-381 G2 keys are supported.
+		return nil, fmt.Errorf("decode key %s: BLS12-381 G1G2 keys are not supported", key)
+	case fingerprint.P256PubKeyMultiCodec:
+		curve = elliptic.P256()
+	case fingerprint.P384PubKeyMultiCodec:
+		curve = elliptic.P384()
+	case fingerprint.P521PubKeyMultiCodec:
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported key multicodec code [0x%x]", code)
+	}
+
+	x, y := elliptic.UnmarshalCompressed(curve, pubKey)
+	if x == nil {
+		return nil, fmt.Errorf("decode key %s: invalid compressed point", key)
+	}
+
+	j, err := jwksupport.JWKFromKey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+	if err != nil {
+		return nil, fmt.Errorf("decode key %s: %w", key, err)
+	}
+
+	return did.NewVerificationMethodFromJWK(id, jsonWebKey2020, controller, j)
+}
+
+func encodeService(svc *did.Service) (string, error) {
+	svcType, ok := svc.Type.(string)
+	if !ok {
+		return "", fmt.Errorf("not supported service type: %v", svc.Type)
+	}
+
+	if svcType == vdrapi.DIDCommV2ServiceType {
+		svcType = didCommMessagingAbbr
+	}
+
+	ps := &peerService{Type: svcType, RoutingKeys: svc.RoutingKeys, Accept: svc.Accept}
+
+	var (
+		endpoint interface{}
+		err      error
+	)
+
+	switch svc.ServiceEndpoint.Type() {
+	case model.DIDCommV2:
+		var endpoints []peerServiceEndpoint
+
+		for _, ep := range svc.ServiceEndpoint.DIDCommV2Endpoints() {
+			endpoints = append(endpoints, peerServiceEndpoint{URI: ep.URI, RoutingKeys: ep.RoutingKeys, Accept: ep.Accept})
+		}
+
+		endpoint = endpoints
+		if len(endpoints) == 1 {
+			endpoint = endpoints[0]
+		}
+	default:
+		endpoint, err = svc.ServiceEndpoint.URI()
+		if err != nil {
+			return "", fmt.Errorf("encode service endpoint: %w", err)
+		}
+	}
+
+	ps.ServiceEndpoint, err = json.Marshal(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("encode service endpoint: %w", err)
+	}
+
+	svcBytes, err := json.Marshal(ps)
+	if err != nil {
+		return "", fmt.Errorf("encode service: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(svcBytes), nil
+}
+
+func decodeService(value string, index int) (*did.Service, error) {
+	svcBytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode service: %w", err)
+	}
+
+	ps := &peerService{}
+
+	err = json.Unmarshal(svcBytes, ps)
+	if err != nil {
+		return nil, fmt.Errorf("decode service: %w", err)
+	}
+
+	svc := &did.Service{ID: ps.ID, Type: ps.Type, RoutingKeys: ps.RoutingKeys, Accept: ps.Accept}
+
+	if svc.ID == "" {
+		svc.ID = "#service"
+		if index > 0 {
+			svc.ID = fmt.Sprintf("#service-%d", index)
+		}
+	}
+
+	if ps.Type == didCommMessagingAbbr {
+		svc.Type = vdrapi.DIDCommV2ServiceType
+	}
+
+	var (
+		uri       string
+		endpoint  peerServiceEndpoint
+		endpoints []peerServiceEndpoint
+	)
+
+	switch {
+	case json.Unmarshal(ps.ServiceEndpoint, &uri) == nil:
+		if svc.Type == vdrapi.DIDCommV2ServiceType {
+			endpoints = []peerServiceEndpoint{{URI: uri, RoutingKeys: ps.RoutingKeys, Accept: ps.Accept}}
+		} else {
+			svc.ServiceEndpoint = model.NewDIDCommV1Endpoint(uri)
+		}
+	case json.Unmarshal(ps.ServiceEndpoint, &endpoint) == nil:
+		endpoints = []peerServiceEndpoint{endpoint}
+	case json.Unmarshal(ps.ServiceEndpoint, &endpoints) == nil:
+	default:
+		return nil, fmt.Errorf("decode service: unsupported service endpoint %s", ps.ServiceEndpoint)
+	}
+
+	if len(endpoints) > 0 {
+		var v2Endpoints []model.DIDCommV2Endpoint
+
+		for _, ep := range endpoints {
+			v2Endpoints = append(v2Endpoints, model.DIDCommV2Endpoint{
+				URI:         ep.URI,
+				Accept:      ep.Accept,
+				RoutingKeys: ep.RoutingKeys,
+			})
+		}
+
+		svc.ServiceEndpoint = model.NewDIDCommV2Endpoint(v2Endpoints)
+	}
+
+	return svc, nil
+}
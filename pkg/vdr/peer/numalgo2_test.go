@@ -0,0 +1,161 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+// did:peer:2 example of the peer DID method spec.
+const specNumAlgo2DID = "did:peer:2.Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc" +
+	".Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V.Vz6MkgoLTnTypo3tDRwCkZXSccTPHRLhF4ZnjhueYAFpEX6vg" +
+	".SeyJ0IjoiZG0iLCJzIjoiaHR0cHM6Ly9leGFtcGxlLmNvbS9lbmRwb2ludCIsInIiOlsiZGlkOmV4YW1wbGU6c29tZW1lZGlhdG9yI3N" +
+	"vbWVrZXkiXSwiYSI6WyJkaWRjb21tL3YyIiwiZGlkY29tbS9haXAyO2Vudj1yZmM1ODciXX0"
+
+func TestResolveDidMethod2(t *testing.T) {
+	t.Run("resolve spec example", func(t *testing.T) {
+		doc, err := resolveDidMethod2(specNumAlgo2DID)
+		require.NoError(t, err)
+
+		require.Equal(t, specNumAlgo2DID, doc.ID)
+		require.Len(t, doc.VerificationMethod, 3)
+		require.Equal(t, "#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, x25519KeyAgreementKey2019, doc.VerificationMethod[0].Type)
+		require.Equal(t, ed25519VerificationKey2018, doc.VerificationMethod[1].Type)
+		require.Equal(t, specNumAlgo2DID, doc.VerificationMethod[1].Controller)
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, "#key-1", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Len(t, doc.Authentication, 2)
+		require.Equal(t, "#key-3", doc.Authentication[1].VerificationMethod.ID)
+
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, "#service", doc.Service[0].ID)
+		require.Equal(t, vdr.DIDCommV2ServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{"#key-1"}, doc.Service[0].RecipientKeys)
+
+		uri, err := doc.Service[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/endpoint", uri)
+
+		routingKeys, err := doc.Service[0].ServiceEndpoint.RoutingKeys()
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:somemediator#somekey"}, routingKeys)
+
+		accept, err := doc.Service[0].ServiceEndpoint.Accept()
+		require.NoError(t, err)
+		require.Equal(t, []string{"didcomm/v2", "didcomm/aip2;env=rfc587"}, accept)
+	})
+
+	t.Run("error - invalid DIDs", func(t *testing.T) {
+		_, err := resolveDidMethod2("did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa")
+		require.EqualError(t, err, "invalid did:peer:2 did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa")
+
+		_, err = resolveDidMethod2("did:peer:2.V")
+		require.EqualError(t, err, "invalid did:peer:2 element 'V'")
+
+		_, err = resolveDidMethod2("did:peer:2.Xz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V")
+		require.EqualError(t, err, "unsupported did:peer:2 purpose code 'X'")
+
+		_, err = resolveDidMethod2("did:peer:2.Vabc")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode key abc")
+
+		// BLS12-381 G1G2 key of the did:key test suite.
+		const g1g2 = "z5TcDLDFhBEndYdwFKkQMgVTgtRHx2sniQisVxdiXZ96pcrRy2ehWvcHfhSrfDmozq8dQNxhu2u7y9FUKJ8R3VPZNPjEgsozTSx47WysNM9GESUMmyniFxbdbpxNdocx6SbRyf6nBTFzoXojbWjSsDN4LhNz1sAMzTXgh5HvLYtYzJXo1JtLZBwHgmvtWyEQqtxtjV2eo" //nolint:lll
+
+		_, err = resolveDidMethod2("did:peer:2.V" + g1g2)
+		require.EqualError(t, err, "decode key "+g1g2+": BLS12-381 G1G2 keys are not supported")
+
+		_, err = resolveDidMethod2("did:peer:2.S!!!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode service")
+
+		_, err = resolveDidMethod2("did:peer:2.S" + base64.RawURLEncoding.EncodeToString([]byte(`{"t":"dm","s":1}`)))
+		require.EqualError(t, err, "decode service: unsupported service endpoint 1")
+	})
+}
+
+func TestCreateNumAlgo2(t *testing.T) {
+	sProvider := storage.NewMockStoreProvider()
+	km := newKMS(t, sProvider)
+
+	v, err := New(sProvider)
+	require.NoError(t, err)
+
+	for _, useJWK := range []bool{false, true} {
+		sVM, eVM := getSigningAndKeyAgreementKey(t, useJWK, km)
+
+		docResolution, err := v.Create(&did.Doc{
+			VerificationMethod: []did.VerificationMethod{sVM},
+			KeyAgreement:       []did.Verification{eVM},
+			Service: []did.Service{
+				{
+					Type: vdr.DIDCommV2ServiceType,
+					ServiceEndpoint: model.NewDIDCommV2Endpoint([]model.DIDCommV2Endpoint{{
+						URI:         "https://example.com/endpoint",
+						Accept:      []string{"didcomm/v2"},
+						RoutingKeys: []string{"did:example:mediator"},
+					}}),
+				},
+				{
+					Type:            vdr.DIDCommServiceType,
+					ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com/v1"),
+				},
+			},
+		}, vdr.WithOption(NumAlgoOption, NumAlgo2))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:2."))
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, "#key-2", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, eVM.VerificationMethod.Value, doc.KeyAgreement[0].VerificationMethod.Value)
+		require.Equal(t, sVM.Value, doc.Authentication[0].VerificationMethod.Value)
+
+		require.Len(t, doc.Service, 2)
+		require.Equal(t, "#service", doc.Service[0].ID)
+		require.Equal(t, []string{"#key-2"}, doc.Service[0].RecipientKeys)
+		require.Equal(t, "#service-1", doc.Service[1].ID)
+
+		uri, err := doc.Service[1].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/v1", uri)
+
+		// resolves the same document without stored deltas
+		resolved, err := (&VDR{store: storage.NewMockStoreProvider().Store}).Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc, resolved.DIDDocument)
+	}
+
+	t.Run("error - unsupported numalgo", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdr.WithOption(NumAlgoOption, 3))
+		require.EqualError(t, err, "create peer DID : unsupported numalgo 3")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdr.WithOption(NumAlgoOption, "2"))
+		require.EqualError(t, err, "numalgo opt not a number")
+	})
+
+	t.Run("numalgo from JSON options", func(t *testing.T) {
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdr.WithOption(NumAlgoOption, float64(NumAlgo2)))
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(docResolution.DIDDocument.ID, "did:peer:2.V"))
+	})
+}
@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+)
+
+// Reference: https://identity.foundation/peer-did-method-spec/#method-4-short-form-and-long-form
+const (
+	numAlgo4 = "4"
+
+	// jsonMultiCodec is the multicodec of JSON encoded input documents.
+	jsonMultiCodec = 0x0200
+)
+
+// computeDidMethod4 creates the long form peer DID of doc, the input document is doc without its ID.
+// For example: did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd:z2M1k7h4psgp4CmJcnQn2Ljp7Pz7ktsd7oBhMU3...
+func computeDidMethod4(doc *did.Doc) (string, error) {
+	rawDoc, err := docToRaw(doc)
+	if err != nil {
+		return "", err
+	}
+
+	// the input document has no ID, resolution contextualizes it with the DID.
+	delete(rawDoc, "id")
+	delete(rawDoc, "alsoKnownAs")
+
+	for _, rawVM := range rawVerificationMethods(rawDoc) {
+		delete(rawVM, "controller")
+	}
+
+	docBytes, err := json.Marshal(rawDoc)
+	if err != nil {
+		return "", fmt.Errorf("marshal input document: %w", err)
+	}
+
+	encodedDoc, err := multibase.Encode(transform, append(multicodecPrefix(jsonMultiCodec), docBytes...))
+	if err != nil {
+		return "", fmt.Errorf("encode input document: %w", err)
+	}
+
+	hash, err := hashDidMethod4(encodedDoc)
+	if err != nil {
+		return "", err
+	}
+
+	return peerPrefix + numAlgo4 + hash + ":" + encodedDoc, nil
+}
+
+// resolveDidMethod4 expands a long form did:peer:4 into its DID document. The short form of the DID is set as
+// alsoKnownAs of the document.
+func resolveDidMethod4(didID string) (*did.Doc, error) {
+	hash, encodedDoc, ok := splitDidMethod4(didID)
+	if !ok {
+		return nil, fmt.Errorf("invalid did:peer:4 long form %s", didID)
+	}
+
+	computed, err := hashDidMethod4(encodedDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	if computed != hash {
+		return nil, errors.New("hash of the did:peer:4 input document doesn't match the DID")
+	}
+
+	_, docBytes, err := multibase.Decode(encodedDoc)
+	if err != nil {
+		return nil, fmt.Errorf("decode input document: %w", err)
+	}
+
+	code, n := binary.Uvarint(docBytes)
+	if n <= 0 || code != jsonMultiCodec {
+		return nil, errors.New("decode input document: not a JSON multicodec")
+	}
+
+	rawDoc := map[string]interface{}{}
+
+	err = json.Unmarshal(docBytes[n:], &rawDoc)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal input document: %w", err)
+	}
+
+	rawDoc["id"] = didID
+	rawDoc["alsoKnownAs"] = []string{ShortFormDID(didID)}
+
+	for _, rawVM := range rawVerificationMethods(rawDoc) {
+		if _, ok := rawVM["controller"]; !ok {
+			rawVM["controller"] = didID
+		}
+	}
+
+	docBytes, err = json.Marshal(rawDoc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal contextualized document: %w", err)
+	}
+
+	doc, err := did.ParseDocument(docBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse contextualized document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// ShortFormDID returns the short form of a long form did:peer:4, other DIDs are returned as is.
+func ShortFormDID(didID string) string {
+	hash, _, ok := splitDidMethod4(didID)
+	if !ok {
+		return didID
+	}
+
+	return peerPrefix + numAlgo4 + hash
+}
+
+func isLongFormDidMethod4(didID string) bool {
+	_, _, ok := splitDidMethod4(didID)
+
+	return ok
+}
+
+func splitDidMethod4(didID string) (string, string, bool) {
+	if !strings.HasPrefix(didID, peerPrefix+numAlgo4) {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(didID, peerPrefix+numAlgo4), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+func hashDidMethod4(encodedDoc string) (string, error) {
+	hash, err := multihash.Sum([]byte(encodedDoc), multihash.SHA2_256, -1)
+	if err != nil {
+		return "", fmt.Errorf("hash input document: %w", err)
+	}
+
+	return string(transform) + hash.B58String(), nil
+}
+
+func multicodecPrefix(code uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	return buf[:binary.PutUvarint(buf, code)]
+}
+
+func docToRaw(doc *did.Doc) (map[string]interface{}, error) {
+	docBytes, err := doc.JSONBytes()
+	if err != nil {
+		return nil, fmt.Errorf("marshal document: %w", err)
+	}
+
+	rawDoc := map[string]interface{}{}
+
+	err = json.Unmarshal(docBytes, &rawDoc)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal document: %w", err)
+	}
+
+	return rawDoc, nil
+}
+
+// rawVerificationMethods returns the verification methods of rawDoc, including the ones embedded in verification
+// relationships.
+func rawVerificationMethods(rawDoc map[string]interface{}) []map[string]interface{} {
+	var vms []map[string]interface{}
+
+	for _, key := range []string{
+		"verificationMethod", "authentication", "assertionMethod", "keyAgreement",
+		"capabilityInvocation", "capabilityDelegation",
+	} {
+		entries, ok := rawDoc[key].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, entry := range entries {
+			if vm, ok := entry.(map[string]interface{}); ok {
+				vms = append(vms, vm)
+			}
+		}
+	}
+
+	return vms
+}
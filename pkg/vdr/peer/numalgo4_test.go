@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+)
+
+func TestCreateNumAlgo4(t *testing.T) {
+	sProvider := storage.NewMockStoreProvider()
+	km := newKMS(t, sProvider)
+
+	v, err := New(sProvider)
+	require.NoError(t, err)
+
+	sVM, eVM := getSigningAndKeyAgreementKey(t, false, km)
+
+	docResolution, err := v.Create(&did.Doc{
+		VerificationMethod: []did.VerificationMethod{sVM},
+		KeyAgreement:       []did.Verification{eVM},
+		Service: []did.Service{{
+			ID:              "#didcomm",
+			Type:            vdr.DIDCommServiceType,
+			ServiceEndpoint: model.NewDIDCommV1Endpoint("https://example.com/endpoint"),
+		}},
+	}, vdr.WithOption(NumAlgoOption, NumAlgo4))
+	require.NoError(t, err)
+
+	doc := docResolution.DIDDocument
+	require.True(t, isLongFormDidMethod4(doc.ID))
+
+	shortForm := ShortFormDID(doc.ID)
+	require.True(t, strings.HasPrefix(shortForm, "did:peer:4z"))
+	require.NotContains(t, strings.TrimPrefix(shortForm, "did:peer:"), ":")
+	require.Equal(t, []string{shortForm}, doc.AlsoKnownAs)
+
+	require.Len(t, doc.KeyAgreement, 1)
+	require.Equal(t, eVM.VerificationMethod.Value, doc.KeyAgreement[0].VerificationMethod.Value)
+	require.Equal(t, doc.ID, doc.KeyAgreement[0].VerificationMethod.Controller)
+
+	t.Run("resolve long form without stored deltas", func(t *testing.T) {
+		resolved, err := (&VDR{store: storage.NewMockStoreProvider().Store}).Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc, resolved.DIDDocument)
+	})
+
+	t.Run("resolve short form of created DID", func(t *testing.T) {
+		resolved, err := v.Read(shortForm)
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, resolved.DIDDocument.ID)
+	})
+
+	t.Run("error - unknown short form", func(t *testing.T) {
+		_, err := (&VDR{store: storage.NewMockStoreProvider().Store}).Read(shortForm)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdr.ErrNotFound))
+	})
+
+	t.Run("error - tampered input document", func(t *testing.T) {
+		hash, encodedDoc, ok := splitDidMethod4(doc.ID)
+		require.True(t, ok)
+
+		otherDoc, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdr.WithOption(NumAlgoOption, NumAlgo4))
+		require.NoError(t, err)
+
+		_, otherEncodedDoc, ok := splitDidMethod4(otherDoc.DIDDocument.ID)
+		require.True(t, ok)
+		require.NotEqual(t, encodedDoc, otherEncodedDoc)
+
+		_, err = v.Read("did:peer:4" + hash + ":" + otherEncodedDoc)
+		require.EqualError(t, err,
+			"resolve did:peer:4: hash of the did:peer:4 input document doesn't match the DID")
+	})
+}
+
+func TestShortFormDID(t *testing.T) {
+	for _, didID := range []string{
+		"did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa",
+		"did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd",
+		"did:example:123",
+	} {
+		require.Equal(t, didID, ShortFormDID(didID))
+	}
+
+	require.Equal(t, "did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd",
+		ShortFormDID("did:peer:4zQmd8CpeFPci817KDsbSAKWcXAE2mjvCQSasRewvbSF54Bd:z2M1k7h4psgp4CmJcnQn2Ljp7Pz7ktsd7oBhMU3"))
+}
@@ -8,22 +8,54 @@ package peer
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
-func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
-	// get the document from the store
-	doc, err := v.Get(didID)
-	if err != nil {
-		return nil, fmt.Errorf("fetching data from store failed: %w", err)
+func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	didMethodOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+	// Apply options
+	for _, opt := range opts {
+		opt(didMethodOpts)
+	}
+
+	var (
+		doc      *did.Doc
+		metadata *did.DocumentMetadata
+		err      error
+	)
+
+	switch {
+	case strings.HasPrefix(didID, peerPrefix+numAlgo2):
+		// no stored deltas needed, the document is encoded in the DID
+		doc, err = resolveDidMethod2(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve did:peer:2: %w", err)
+		}
+	case isLongFormDidMethod4(didID):
+		doc, err = resolveDidMethod4(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve did:peer:4: %w", err)
+		}
+	default:
+		versionID, ok := didMethodOpts.Values[VersionIDOpt].(string)
+		if !ok && didMethodOpts.Values[VersionIDOpt] != nil {
+			return nil, fmt.Errorf("versionID opt not string")
+		}
+
+		// get the document from the store
+		doc, metadata, err = v.getVersion(didID, versionID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching data from store failed: %w", err)
+		}
 	}
 
 	if doc == nil {
 		return nil, vdrapi.ErrNotFound
 	}
 
-	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc, DocumentMetadata: metadata}, nil
 }
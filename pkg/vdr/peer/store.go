@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -25,9 +26,10 @@ type modifiedBy struct {
 }
 
 type docDelta struct {
-	Change     string        `json:"change,omitempty"`
-	ModifiedBy *[]modifiedBy `json:"by,omitempty"`
-	ModifiedAt time.Time     `json:"when,omitempty"`
+	Change      string        `json:"change,omitempty"`
+	ModifiedBy  *[]modifiedBy `json:"by,omitempty"`
+	ModifiedAt  time.Time     `json:"when,omitempty"`
+	Deactivated bool          `json:"deactivated,omitempty"`
 }
 
 func genesisDeltaBytes(doc *did.Doc, by *[]modifiedBy) ([]byte, error) {
@@ -66,6 +68,13 @@ func (v *VDR) storeDID(doc *did.Doc, by *[]modifiedBy) error { //nolint: unparam
 		return err
 	}
 
+	// short form did:peer:4 DIDs can only be resolved once their long form was seen
+	if shortForm := ShortFormDID(doc.ID); shortForm != doc.ID {
+		if err = v.store.Put(shortForm, val); err != nil {
+			return err
+		}
+	}
+
 	return v.store.Put(doc.ID, val)
 }
 
@@ -118,20 +127,32 @@ func (v *VDR) Get(id string) (*did.Doc, error) {
 }
 
 func assembleDocFromDeltas(deltas []docDelta) (*did.Doc, error) {
-	// For now, assume storage contains only one delta(genesis document)
-	delta := deltas[0]
+	doc, _, err := assembleDocVersion(deltas, len(deltas)-1)
 
-	doc, err := base64.URLEncoding.DecodeString(delta.Change)
-	if err != nil {
-		return nil, fmt.Errorf("decoding of document delta failed: %w", err)
+	return doc, err
+}
+
+// getVersion returns the Peer DID Document at versionID, the latest one if versionID is empty.
+func (v *VDR) getVersion(id, versionID string) (*did.Doc, *did.DocumentMetadata, error) {
+	if id == "" {
+		return nil, nil, errors.New("ID is mandatory")
 	}
 
-	document, err := did.ParseDocument(doc)
+	deltas, err := v.getDeltas(id)
 	if err != nil {
-		return nil, fmt.Errorf("document ParseDocument() failed: %w", err)
+		return nil, nil, fmt.Errorf("delta data fetch from store for did [%s] failed: %w", id, err)
+	}
+
+	version := len(deltas) - 1
+
+	if versionID != "" {
+		version, err = strconv.Atoi(versionID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid version ID %s: %w", versionID, err)
+		}
 	}
 
-	return document, nil
+	return assembleDocVersion(deltas, version)
 }
 
 // Close frees resources being maintained by vdr.
@@ -9,8 +9,6 @@ package peer
 import (
 	"fmt"
 
-	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
-	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -21,6 +19,14 @@ const (
 	DefaultServiceType = "defaultServiceType"
 	// DefaultServiceEndpoint default service endpoint.
 	DefaultServiceEndpoint = "defaultServiceEndpoint"
+	// NumAlgoOption selects the numalgo of created peer DIDs: NumAlgo1 (default), NumAlgo2 or NumAlgo4.
+	NumAlgoOption = "numalgo"
+	// NumAlgo1 creates did:peer:1 DIDs (genesis doc hash), their document is stored by the VDR.
+	NumAlgo1 = 1
+	// NumAlgo2 creates did:peer:2 DIDs, keys and services are inlined in the DID.
+	NumAlgo2 = 2
+	// NumAlgo4 creates long form did:peer:4 DIDs, the whole document is encoded in the DID.
+	NumAlgo4 = 4
 )
 
 // VDR implements building new peer dids.
@@ -38,16 +44,6 @@ func New(s storage.Provider) (*VDR, error) {
 	return &VDR{store: didDBStore}, nil
 }
 
-// Update did doc.
-func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
-}
-
-// Deactivate did doc.
-func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
-}
-
 // Accept did method.
 func (v *VDR) Accept(method string) bool {
 	return method == DIDMethod
@@ -7,31 +7,217 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 )
 
 func TestUpdate(t *testing.T) {
+	signer, err := signature.NewSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	newSigner, err := signature.NewSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	v, doc := createUpdatableDID(t, signer)
+
 	t.Run("test update", func(t *testing.T) {
-		v, err := New(&storage.MockStoreProvider{})
+		updated := rotatedDoc(doc, newSigner)
+
+		err = v.Update(updated,
+			vdrapi.WithOption(UpdateSignerOption, signer),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-1"))
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc.ID+"#key-2", docResolution.DIDDocument.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, "1", docResolution.DocumentMetadata.VersionID)
+		require.False(t, docResolution.DocumentMetadata.Deactivated)
+
+		// history
+		docResolution, err = v.Read(doc.ID, vdrapi.WithOption(VersionIDOpt, "0"))
+		require.NoError(t, err)
+		require.Equal(t, doc.ID+"#key-1", docResolution.DIDDocument.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, "0", docResolution.DocumentMetadata.VersionID)
+
+		_, err = v.Read(doc.ID, vdrapi.WithOption(VersionIDOpt, "2"))
+		require.EqualError(t, err, "fetching data from store failed: version 2 not found")
+
+		_, err = v.Read(doc.ID, vdrapi.WithOption(VersionIDOpt, "latest"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid version ID latest")
+
+		_, err = v.Read(doc.ID, vdrapi.WithOption(VersionIDOpt, 1))
+		require.EqualError(t, err, "versionID opt not string")
+
+		// the rotated key is no longer authorized
+		err = v.Update(updated,
+			vdrapi.WithOption(UpdateSignerOption, signer),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-1"))
+		require.EqualError(t, err, "delta 2: key #key-1 is not authorized to modify the document")
+
+		err = v.Update(updated,
+			vdrapi.WithOption(UpdateSignerOption, newSigner),
+			vdrapi.WithOption(UpdateKeyIDOption, doc.ID+"#key-2"))
+		require.NoError(t, err)
+	})
+
+	t.Run("test update with JWK key", func(t *testing.T) {
+		ecSigner, err := signature.NewSigner(kms.ECDSAP256TypeIEEEP1363)
 		require.NoError(t, err)
 
+		j, err := jwksupport.JWKFromKey(ecSigner.PublicKey())
+		require.NoError(t, err)
+
+		vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", j)
+		require.NoError(t, err)
+
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.NoError(t, err)
+
+		err = v.Update(docResolution.DIDDocument,
+			vdrapi.WithOption(UpdateSignerOption, ecSigner),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-1"))
+		require.NoError(t, err)
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		err = v.Update(rotatedDoc(doc, newSigner),
+			vdrapi.WithOption(UpdateSignerOption, newSigner),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-3"))
+		require.EqualError(t, err, "delta 3: key #key-3 is not authorized to modify the document")
+
+		otherSigner, err := signature.NewSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		err = v.Update(rotatedDoc(doc, newSigner),
+			vdrapi.WithOption(UpdateSignerOption, otherSigner),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-2"))
+		require.EqualError(t, err, "delta 3: verify signature of key #key-2: ed25519: invalid signature")
+	})
+
+	t.Run("error - invalid options", func(t *testing.T) {
+		err = v.Update(doc)
+		require.EqualError(t, err, "updateSigner opt is mandatory")
+
+		err = v.Update(doc, vdrapi.WithOption(UpdateSignerOption, signer))
+		require.EqualError(t, err, "updateKeyID opt is mandatory")
+	})
+
+	t.Run("error - invalid DIDs", func(t *testing.T) {
 		err = v.Update(nil)
+		require.EqualError(t, err, "DID and document are mandatory")
+
+		err = v.Update(&did.Doc{ID: specNumAlgo2DID})
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "not supported")
+		require.Contains(t, err.Error(), "is not a numalgo 1 peer DID")
+
+		err = v.Update(&did.Doc{ID: "did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa"},
+			vdrapi.WithOption(UpdateSignerOption, signer),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-1"))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("error - document ID changed", func(t *testing.T) {
+		changed := rotatedDoc(doc, newSigner)
+		changed.ID = "did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa"
+
+		jsonDoc, err := changed.JSONBytes()
+		require.NoError(t, err)
+
+		// the store is keyed by the updated ID, forge the delta of another DID
+		err = v.appendDelta(doc.ID, &docDelta{Change: base64.URLEncoding.EncodeToString(jsonDoc)},
+			vdrapi.WithOption(UpdateSignerOption, newSigner),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-2"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "document ID changed")
 	})
 }
 
 func TestDeactivate(t *testing.T) {
+	signer, err := signature.NewSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	v, doc := createUpdatableDID(t, signer)
+
 	t.Run("test deactivate", func(t *testing.T) {
-		v, err := New(&storage.MockStoreProvider{})
+		err = v.Deactivate(doc.ID,
+			vdrapi.WithOption(UpdateSignerOption, signer),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-1"))
 		require.NoError(t, err)
 
+		docResolution, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc.ID, docResolution.DIDDocument.ID)
+		require.Equal(t, "1", docResolution.DocumentMetadata.VersionID)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+	})
+
+	t.Run("error - deactivated DID can't be modified", func(t *testing.T) {
+		err = v.Update(doc,
+			vdrapi.WithOption(UpdateSignerOption, signer),
+			vdrapi.WithOption(UpdateKeyIDOption, "#key-1"))
+		require.EqualError(t, err, "delta 2 follows the deactivation of the document")
+	})
+
+	t.Run("error - unsigned delta in store", func(t *testing.T) {
+		deltas, err := v.getDeltas(doc.ID)
+		require.NoError(t, err)
+
+		deltas[1].ModifiedBy = nil
+
+		val, err := json.Marshal(deltas)
+		require.NoError(t, err)
+		require.NoError(t, v.store.Put(doc.ID, val))
+
+		_, err = v.Read(doc.ID)
+		require.EqualError(t, err, "fetching data from store failed: delta 1: delta is not signed")
+	})
+
+	t.Run("error - not supported", func(t *testing.T) {
 		err = v.Deactivate("")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "not supported")
 	})
 }
+
+func createUpdatableDID(t *testing.T, signer signature.Signer) (*VDR, *did.Doc) {
+	t.Helper()
+
+	v, err := New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{
+		*did.NewVerificationMethodFromBytes("#key-1", ed25519VerificationKey2018, "", signer.PublicKeyBytes()),
+	}})
+	require.NoError(t, err)
+
+	return v, docResolution.DIDDocument
+}
+
+// rotatedDoc returns a copy of doc authenticated by signer as #key-2.
+func rotatedDoc(doc *did.Doc, signer signature.Signer) *did.Doc {
+	vm := did.NewVerificationMethodFromBytes("#key-2", ed25519VerificationKey2018, doc.ID, signer.PublicKeyBytes())
+
+	rotated := *doc
+	rotated.VerificationMethod = []did.VerificationMethod{*vm}
+	rotated.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+	rotated.AssertionMethod = nil
+
+	return &rotated
+}
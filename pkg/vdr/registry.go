@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/metrics"
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
@@ -24,11 +26,12 @@ type Registry struct {
 	vdr                []vdrapi.VDR
 	defServiceEndpoint string
 	defServiceType     string
+	metrics            metrics.Metrics
 }
 
 // New return new instance of vdr.
 func New(opts ...Option) *Registry {
-	baseVDR := &Registry{}
+	baseVDR := &Registry{metrics: metrics.NewNoop()}
 
 	// Apply options
 	for _, opt := range opts {
@@ -52,7 +55,11 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 	}
 
 	// Obtain the DID Document
+	start := time.Now()
 	didDocResolution, err := method.Read(did, opts...)
+
+	r.metrics.VDRResolveTime(didMethod, time.Since(start))
+
 	if err != nil {
 		if errors.Is(err, vdrapi.ErrNotFound) {
 			return nil, err
@@ -167,6 +174,15 @@ func WithDefaultServiceType(serviceType string) Option {
 	}
 }
 
+// WithMetrics sets the metrics used to record DID resolution times.
+func WithMetrics(m metrics.Metrics) Option {
+	return func(opts *Registry) {
+		if m != nil {
+			opts.metrics = m
+		}
+	}
+}
+
 // WithDefaultServiceEndpoint allows for setting default service endpoint.
 func WithDefaultServiceEndpoint(serviceEndpoint string) Option {
 	return func(opts *Registry) {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockmetrics "github.com/hyperledger/aries-framework-go/pkg/mock/metrics"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 )
 
@@ -108,6 +109,15 @@ func TestRegistry_Resolve(t *testing.T) {
 		_, err := registry.Resolve("1:id:123")
 		require.NoError(t, err)
 	})
+
+	t.Run("test resolve time recorded", func(t *testing.T) {
+		m := &mockmetrics.MockMetrics{}
+
+		registry := New(WithVDR(&mockvdr.MockVDR{AcceptValue: true}), WithMetrics(m))
+		_, err := registry.Resolve("did:id:123")
+		require.NoError(t, err)
+		require.Equal(t, 1, m.VDRResolveTimes("id"))
+	})
 }
 
 func TestRegistry_Update(t *testing.T) {
@@ -20,7 +20,6 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
-	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
@@ -247,10 +246,7 @@ func TestContentStores(t *testing.T) {
 			},
 		}
 
-		kmsStore, err := kms.NewAriesProviderWrapper(sp)
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore, &unlockOpts{passphrase: samplePassPhrase})
+		kmgr, err := keyManager().createKeyManager(profileInfo, sp, &unlockOpts{passphrase: samplePassPhrase})
 		require.NotEmpty(t, kmgr)
 		require.NoError(t, err)
 
@@ -410,10 +406,7 @@ func TestContentStores(t *testing.T) {
 			MasterLockCipher: masterLockCipherText,
 		}
 
-		kmsStore, err := kms.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{passphrase: samplePassPhrase})
 		require.NotEmpty(t, kmgr)
 		require.NoError(t, err)
@@ -456,10 +449,7 @@ func TestContentStores(t *testing.T) {
 			MasterLockCipher: masterLockCipherText,
 		}
 
-		kmsStore, err := kms.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{passphrase: samplePassPhrase})
 		require.NotEmpty(t, kmgr)
 		require.NoError(t, err)
@@ -0,0 +1,319 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/square/go-jose/v3"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	// EncryptedWalletType is the type of locked (encrypted) wallet produced by wallet export.
+	// https://w3c-ccg.github.io/universal-wallet-interop-spec/#encryptedwallet
+	EncryptedWalletType = "EncryptedWallet"
+
+	// UniversalWalletType is the type of unlocked wallet contents bundled inside an encrypted wallet.
+	UniversalWalletType = "UniversalWallet2020"
+
+	credentialsContext = "https://www.w3.org/2018/credentials/v1"
+	walletContext      = "https://w3id.org/wallet/v1"
+	vcType             = "VerifiableCredential"
+
+	// number of PBES2 iterations used for deriving key encryption key from export passphrase.
+	exportPBES2Count = 10000
+)
+
+// exported content types in order of import, collections has to be imported first so that
+// other contents can be mapped to them.
+// Keys are never saved in content store, they are exported from wallet's key manager by exportKeys.
+// nolint:gochecknoglobals
+var exportedContentTypes = []ContentType{Collection, Credential, DIDResolutionResponse, Metadata, Connection}
+
+// lockedWallet is an encrypted wallet representation.
+// https://w3c-ccg.github.io/universal-wallet-interop-spec/#encryptedwallet
+type lockedWallet struct {
+	Context           []string                `json:"@context"`
+	ID                string                  `json:"id"`
+	Type              []string                `json:"type"`
+	Issuer            string                  `json:"issuer"`
+	IssuanceDate      *util.TimeWrapper       `json:"issuanceDate"`
+	CredentialSubject *encryptedWalletSubject `json:"credentialSubject"`
+}
+
+// encryptedWalletSubject is a credential subject of locked wallet holding wallet contents as JWE.
+type encryptedWalletSubject struct {
+	ID                      string          `json:"id"`
+	EncryptedWalletContents json.RawMessage `json:"encryptedWalletContents"`
+}
+
+// unlockedWallet is plaintext of wallet contents encrypted inside locked wallet.
+type unlockedWallet struct {
+	Context  []string         `json:"@context"`
+	ID       string           `json:"id"`
+	Type     []string         `json:"type"`
+	Contents []*walletContent `json:"contents"`
+}
+
+// walletContent is an exported wallet content along with its type and collection.
+type walletContent struct {
+	ContentType  ContentType     `json:"contentType"`
+	CollectionID string          `json:"collectionID,omitempty"`
+	Content      json.RawMessage `json:"content"`
+	// KeyType is the key manager's key type of an exported key.
+	KeyType kms.KeyType `json:"keyType,omitempty"`
+}
+
+// exportContents reads all supported contents from wallet content store.
+func (cs *contentStore) exportContents(auth string) ([]*walletContent, error) {
+	collections, err := cs.GetAll(auth, Collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collections: %w", err)
+	}
+
+	// find collection of each content.
+	mappings := make(map[ContentType]map[string]string)
+
+	for collectionID := range collections {
+		for _, ct := range exportedContentTypes {
+			mapped, e := cs.GetAllByCollection(auth, collectionID, ct)
+			if e != nil {
+				return nil, fmt.Errorf("failed to read contents of collection '%s': %w", collectionID, e)
+			}
+
+			if len(mapped) > 0 && mappings[ct] == nil {
+				mappings[ct] = make(map[string]string)
+			}
+
+			for id := range mapped {
+				mappings[ct][id] = collectionID
+			}
+		}
+	}
+
+	var contents []*walletContent
+
+	for _, ct := range exportedContentTypes {
+		all, e := cs.GetAll(auth, ct)
+		if e != nil {
+			return nil, fmt.Errorf("failed to read contents of type '%s': %w", ct, e)
+		}
+
+		for id, content := range all {
+			contents = append(contents, &walletContent{
+				ContentType:  ct,
+				CollectionID: mappings[ct][id],
+				Content:      content,
+			})
+		}
+	}
+
+	return contents, nil
+}
+
+// importContents saves given contents to wallet content store and imports exported keys into wallet's key manager.
+// Import is all-or-nothing, contents and keys imported before a failure are removed.
+func (cs *contentStore) importContents(auth string, contents []*walletContent, options ...AddContentOptions) error {
+	for _, content := range contents {
+		if err := content.ContentType.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	km, err := sessionLocalKMS(auth)
+	if err != nil {
+		return err
+	}
+
+	var keyIDs []string
+
+	if km != nil {
+		keyIDs, err = km.readKeyIDs()
+		if err != nil {
+			return err
+		}
+	}
+
+	imported, err := cs.saveContents(auth, contents, options...)
+	if err != nil {
+		if e := cs.removeImported(auth, imported, km, keyIDs); e != nil {
+			return fmt.Errorf("%w, failed to remove imported contents: %s", err, e.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// saveContents saves given contents and returns the contents saved to wallet content store, even on failure.
+func (cs *contentStore) saveContents(auth string, contents []*walletContent,
+	options ...AddContentOptions) ([]*walletContent, error) {
+	var saved []*walletContent
+
+	// import collections first, so that other contents can be mapped to them.
+	for _, content := range contents {
+		if content.ContentType != Collection {
+			continue
+		}
+
+		if err := cs.Save(auth, content.ContentType, content.Content, options...); err != nil {
+			return saved, fmt.Errorf("failed to import collection: %w", err)
+		}
+
+		saved = append(saved, content)
+	}
+
+	for _, content := range contents {
+		switch {
+		case content.ContentType == Collection:
+			continue
+		case content.ContentType == Key && content.KeyType != "":
+			if err := importExportedKey(auth, content.Content, content.KeyType); err != nil {
+				return saved, fmt.Errorf("failed to import key: %w", err)
+			}
+
+			continue
+		}
+
+		opts := append([]AddContentOptions{AddByCollection(content.CollectionID)}, options...)
+
+		if err := cs.Save(auth, content.ContentType, content.Content, opts...); err != nil {
+			return saved, fmt.Errorf("failed to import content of type '%s': %w", content.ContentType, err)
+		}
+
+		if content.ContentType != Key {
+			saved = append(saved, content)
+		}
+	}
+
+	return saved, nil
+}
+
+// removeImported removes given imported contents and the keys added to the key manager since it had given key IDs.
+func (cs *contentStore) removeImported(auth string, imported []*walletContent, km *walletLocalKMS,
+	keyIDs []string) error {
+	for _, content := range imported {
+		contentID, err := importedContentID(content)
+		if err != nil {
+			return err
+		}
+
+		err = cs.Remove(auth, contentID, content.ContentType)
+		if err != nil {
+			return err
+		}
+	}
+
+	if km == nil {
+		return nil
+	}
+
+	currentKeyIDs, err := km.readKeyIDs()
+	if err != nil {
+		return err
+	}
+
+	return km.removeKeys(removeKeyIDs(currentKeyIDs, keyIDs...))
+}
+
+func importedContentID(content *walletContent) (string, error) {
+	if content.ContentType == DIDResolutionResponse {
+		docRes, err := did.ParseDocumentResolution(content.Content)
+		if err != nil {
+			return "", fmt.Errorf("invalid DID resolution response model: %w", err)
+		}
+
+		return docRes.DIDDocument.ID, nil
+	}
+
+	return getContentID(content.Content)
+}
+
+// lockContents encrypts given wallet contents by key derived from given passphrase and
+// returns them as locked wallet.
+func lockContents(walletID, passphrase string, contents []*walletContent) (json.RawMessage, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required for locking wallet contents")
+	}
+
+	plaintext, err := json.Marshal(&unlockedWallet{
+		Context:  []string{walletContext},
+		ID:       walletID,
+		Type:     []string{UniversalWalletType},
+		Contents: contents,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal wallet contents: %w", err)
+	}
+
+	encrypter, err := jose.NewEncrypter(jose.A256GCM,
+		jose.Recipient{Algorithm: jose.PBES2_HS512_A256KW, Key: []byte(passphrase), PBES2Count: exportPBES2Count},
+		(&jose.EncrypterOptions{}).WithContentType("json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet encrypter: %w", err)
+	}
+
+	jwe, err := encrypter.Encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt wallet contents: %w", err)
+	}
+
+	return json.Marshal(&lockedWallet{
+		Context:      []string{credentialsContext, walletContext},
+		ID:           uuid.New().URN(),
+		Type:         []string{vcType, EncryptedWalletType},
+		Issuer:       walletID,
+		IssuanceDate: util.NewTime(time.Now()),
+		CredentialSubject: &encryptedWalletSubject{
+			ID:                      walletID,
+			EncryptedWalletContents: json.RawMessage(jwe.FullSerialize()),
+		},
+	})
+}
+
+// unlockContents decrypts given locked wallet by key derived from given passphrase and
+// returns wallet contents.
+func unlockContents(passphrase string, locked json.RawMessage) ([]*walletContent, error) {
+	var wallet lockedWallet
+
+	err := json.Unmarshal(locked, &wallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locked wallet: %w", err)
+	}
+
+	if wallet.CredentialSubject == nil || len(wallet.CredentialSubject.EncryptedWalletContents) == 0 {
+		return nil, errors.New("invalid locked wallet: encrypted wallet contents not found")
+	}
+
+	jwe, err := jose.ParseEncrypted(string(wallet.CredentialSubject.EncryptedWalletContents))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted wallet contents: %w", err)
+	}
+
+	plaintext, err := jwe.Decrypt([]byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt wallet contents: %w", err)
+	}
+
+	var unlocked unlockedWallet
+
+	err = json.Unmarshal(plaintext, &unlocked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet contents: %w", err)
+	}
+
+	return unlocked.Contents, nil
+}
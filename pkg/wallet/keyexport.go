@@ -0,0 +1,473 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	rsassapkcs1pb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	rsassapsspb "github.com/google/tink/go/proto/rsa_ssa_pss_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// name of the store holding the IDs of the keys of each wallet's local key manager.
+	walletKeyIDsStoreName = "walletkeyids"
+
+	// type of exported key contents.
+	jsonWebKey2020 = "JsonWebKey2020"
+)
+
+// key types of the keys exported along with wallet contents.
+// nolint: gochecknoglobals
+var exportedKeyTypes = map[kms.KeyType]bool{
+	kms.ED25519Type:                 true,
+	kms.ECDSAP256TypeDER:            true,
+	kms.ECDSAP384TypeDER:            true,
+	kms.ECDSAP521TypeDER:            true,
+	kms.ECDSAP256TypeIEEEP1363:      true,
+	kms.ECDSAP384TypeIEEEP1363:      true,
+	kms.ECDSAP521TypeIEEEP1363:      true,
+	kms.ECDSASecp256k1TypeIEEEP1363: true,
+	kms.BLS12381G2Type:              true,
+	kms.NISTP256ECDHKWType:          true,
+	kms.NISTP384ECDHKWType:          true,
+	kms.NISTP521ECDHKWType:          true,
+	kms.X25519ECDHKWType:            true,
+	kms.RSARS256Type:                true,
+	kms.RSAPS256Type:                true,
+}
+
+// walletLocalKMS is the local key manager of a wallet. It records the IDs of the keys it creates or imports, so that
+// the private keys of the wallet can be exported along with its contents.
+// Keys stored by the key manager before it was wrapped by the wallet aren't recorded, they are not exported.
+type walletLocalKMS struct {
+	*localkms.LocalKMS
+	user    string
+	keys    kms.Store
+	keyIDs  storage.Store
+	keyLock sync.Mutex
+}
+
+func newWalletLocalKMS(user string, km *localkms.LocalKMS, keys kms.Store,
+	storeProvider storage.Provider) (*walletLocalKMS, error) {
+	keyIDs, err := storeProvider.OpenStore(walletKeyIDsStoreName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wallet key IDs store: %w", err)
+	}
+
+	return &walletLocalKMS{LocalKMS: km, user: user, keys: keys, keyIDs: keyIDs}, nil
+}
+
+// Create creates a new key of type kt and records its ID.
+func (k *walletLocalKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interface{}, error) {
+	keyID, kh, err := k.LocalKMS.Create(kt, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyID, kh, k.addKeyID(keyID)
+}
+
+// CreateAndExportPubKeyBytes creates a new key of type kt, records its ID and returns its public key.
+func (k *walletLocalKMS) CreateAndExportPubKeyBytes(kt kms.KeyType, opts ...kms.KeyOpts) (string, []byte, error) {
+	keyID, pubKey, err := k.LocalKMS.CreateAndExportPubKeyBytes(kt, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyID, pubKey, k.addKeyID(keyID)
+}
+
+// Rotate rotates the key referenced by keyID and records the ID of the new key in place of the rotated one.
+func (k *walletLocalKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (string, interface{}, error) {
+	newKeyID, kh, err := k.LocalKMS.Rotate(kt, keyID, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = k.updateKeyIDs(func(keyIDs []string) []string {
+		return append(removeKeyIDs(keyIDs, keyID), newKeyID)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return newKeyID, kh, nil
+}
+
+// ImportPrivateKey imports privKey and records its ID.
+func (k *walletLocalKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	keyID, kh, err := k.LocalKMS.ImportPrivateKey(privKey, kt, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return keyID, kh, k.addKeyID(keyID)
+}
+
+// exportKeys exports the private keys recorded by the key manager as private key jwk contents.
+func (k *walletLocalKMS) exportKeys() ([]*walletContent, error) {
+	keyIDs, err := k.readKeyIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []*walletContent
+
+	for _, keyID := range keyIDs {
+		// skip keys removed without the wallet.
+		if _, err = k.keys.Get(keyID); errors.Is(err, kms.ErrKeyNotFound) {
+			continue
+		}
+
+		_, kt, e := k.ExportPubKeyBytes(keyID)
+		if e != nil {
+			return nil, fmt.Errorf("failed to export key '%s': %w", keyID, e)
+		}
+
+		if !exportedKeyTypes[kt] {
+			continue
+		}
+
+		content, e := k.exportKey(keyID, kt)
+		if e != nil {
+			return nil, fmt.Errorf("failed to export key '%s': %w", keyID, e)
+		}
+
+		contents = append(contents, content)
+	}
+
+	return contents, nil
+}
+
+func (k *walletLocalKMS) exportKey(keyID string, kt kms.KeyType) (*walletContent, error) {
+	kh, err := k.Get(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key handle")
+	}
+
+	privKey, err := privateKey(handle, kt)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := jwksupport.JWKFromKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	j.KeyID = keyID
+
+	privateKeyJwk, err := j.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(&keyContent{ID: keyID, KeyType: jsonWebKey2020, PrivateKeyJwk: privateKeyJwk})
+	if err != nil {
+		return nil, err
+	}
+
+	return &walletContent{ContentType: Key, KeyType: kt, Content: content}, nil
+}
+
+// removeKeys removes the keys referenced by keyIDs, e.g. the keys imported by a failed wallet import.
+func (k *walletLocalKMS) removeKeys(keyIDs []string) error {
+	for _, keyID := range keyIDs {
+		if err := k.keys.Delete(keyID); err != nil {
+			return fmt.Errorf("failed to remove key '%s': %w", keyID, err)
+		}
+	}
+
+	return k.updateKeyIDs(func(ids []string) []string {
+		return removeKeyIDs(ids, keyIDs...)
+	})
+}
+
+func (k *walletLocalKMS) addKeyID(keyID string) error {
+	return k.updateKeyIDs(func(keyIDs []string) []string {
+		return append(keyIDs, keyID)
+	})
+}
+
+func (k *walletLocalKMS) updateKeyIDs(update func(keyIDs []string) []string) error {
+	k.keyLock.Lock()
+	defer k.keyLock.Unlock()
+
+	keyIDs, err := k.readKeyIDs()
+	if err != nil {
+		return err
+	}
+
+	keyIDsBytes, err := json.Marshal(update(keyIDs))
+	if err != nil {
+		return fmt.Errorf("failed to marshal wallet key IDs: %w", err)
+	}
+
+	err = k.keyIDs.Put(k.user, keyIDsBytes)
+	if err != nil {
+		return fmt.Errorf("failed to save wallet key IDs: %w", err)
+	}
+
+	return nil
+}
+
+func (k *walletLocalKMS) readKeyIDs() ([]string, error) {
+	keyIDsBytes, err := k.keyIDs.Get(k.user)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet key IDs: %w", err)
+	}
+
+	var keyIDs []string
+
+	err = json.Unmarshal(keyIDsBytes, &keyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal wallet key IDs: %w", err)
+	}
+
+	return keyIDs, nil
+}
+
+func removeKeyIDs(keyIDs []string, removed ...string) []string {
+	var remaining []string
+
+	for _, keyID := range keyIDs {
+		if !containsString(removed, keyID) {
+			remaining = append(remaining, keyID)
+		}
+	}
+
+	return remaining
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sessionLocalKMS returns the local key manager of the wallet session, or nil if the wallet uses a remote key
+// manager.
+func sessionLocalKMS(auth string) (*walletLocalKMS, error) {
+	session, err := sessionManager().getSession(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	km, ok := session.KeyManager.(*walletLocalKMS)
+	if !ok {
+		return nil, nil
+	}
+
+	return km, nil
+}
+
+// exportKeys exports the private keys of wallet's key manager as private key jwk contents.
+// Keys of a remote key manager stay on the key server, they are not exported.
+func exportKeys(auth string) ([]*walletContent, error) {
+	km, err := sessionLocalKMS(auth)
+	if err != nil || km == nil {
+		return nil, err
+	}
+
+	return km.exportKeys()
+}
+
+// importExportedKey imports private key jwk of key content exported by exportKeys into wallet's key manager
+// by its original key ID and key type.
+func importExportedKey(auth string, content json.RawMessage, kt kms.KeyType) error {
+	session, err := sessionManager().getSession(auth)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAuthToken) {
+			return ErrWalletLocked
+		}
+
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	var key keyContent
+
+	err = json.Unmarshal(content, &key)
+	if err != nil {
+		return fmt.Errorf("failed to read key contents: %w", err)
+	}
+
+	var j jwk.JWK
+	if e := j.UnmarshalJSON(key.PrivateKeyJwk); e != nil {
+		return fmt.Errorf("failed to unmarshal jwk : %w", e)
+	}
+
+	_, _, err = session.KeyManager.ImportPrivateKey(j.Key, kt, kms.WithKeyID(getKIDFromJWK(key.ID, &j)))
+	if err != nil {
+		return fmt.Errorf("failed to import jwk key : %w", err)
+	}
+
+	return nil
+}
+
+// privateKey returns the primary private key of the keyset as a key importable into a key manager with key type kt.
+func privateKey(kh *keyset.Handle, kt kms.KeyType) (interface{}, error) {
+	ks := insecurecleartextkeyset.KeysetMaterial(kh)
+
+	for _, key := range ks.Key {
+		if key.KeyId == ks.PrimaryKeyId && key.Status == tinkpb.KeyStatusType_ENABLED {
+			return privateKeyFromKeyData(key.KeyData.Value, kt)
+		}
+	}
+
+	return nil, errors.New("private key not found")
+}
+
+func privateKeyFromKeyData(keyData []byte, kt kms.KeyType) (interface{}, error) { //nolint:gocyclo
+	switch kt {
+	case kms.ED25519Type:
+		privKeyProto := new(ed25519pb.Ed25519PrivateKey)
+
+		if err := proto.Unmarshal(keyData, privKeyProto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Ed25519 private key: %w", err)
+		}
+
+		return ed25519.NewKeyFromSeed(privKeyProto.KeyValue), nil
+	case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER,
+		kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363,
+		kms.ECDSASecp256k1TypeIEEEP1363:
+		privKeyProto := new(ecdsapb.EcdsaPrivateKey)
+
+		if err := proto.Unmarshal(keyData, privKeyProto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ECDSA private key: %w", err)
+		}
+
+		return newECDSAPrivateKey(kt, privKeyProto.PublicKey.X, privKeyProto.PublicKey.Y, privKeyProto.KeyValue)
+	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType, kms.X25519ECDHKWType:
+		privKeyProto := new(ecdhpb.EcdhAeadPrivateKey)
+
+		if err := proto.Unmarshal(keyData, privKeyProto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ECDH private key: %w", err)
+		}
+
+		if kt == kms.X25519ECDHKWType {
+			return &crypto.PrivateKey{
+				PublicKey: crypto.PublicKey{X: privKeyProto.PublicKey.X, Curve: "X25519", Type: "OKP"},
+				D:         privKeyProto.KeyValue,
+			}, nil
+		}
+
+		return newECDSAPrivateKey(kt, privKeyProto.PublicKey.X, privKeyProto.PublicKey.Y, privKeyProto.KeyValue)
+	case kms.BLS12381G2Type:
+		privKeyProto := new(bbspb.BBSPrivateKey)
+
+		if err := proto.Unmarshal(keyData, privKeyProto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BBS+ private key: %w", err)
+		}
+
+		return bbs12381g2pub.UnmarshalPrivateKey(privKeyProto.KeyValue)
+	case kms.RSARS256Type:
+		privKeyProto := new(rsassapkcs1pb.RsaSsaPkcs1PrivateKey)
+
+		if err := proto.Unmarshal(keyData, privKeyProto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal RSA private key: %w", err)
+		}
+
+		return newRSAPrivateKey(privKeyProto.PublicKey.N, privKeyProto.PublicKey.E, privKeyProto.D,
+			privKeyProto.P, privKeyProto.Q)
+	case kms.RSAPS256Type:
+		privKeyProto := new(rsassapsspb.RsaSsaPssPrivateKey)
+
+		if err := proto.Unmarshal(keyData, privKeyProto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal RSA private key: %w", err)
+		}
+
+		return newRSAPrivateKey(privKeyProto.PublicKey.N, privKeyProto.PublicKey.E, privKeyProto.D,
+			privKeyProto.P, privKeyProto.Q)
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", kt)
+	}
+}
+
+func newECDSAPrivateKey(kt kms.KeyType, x, y, d []byte) (*ecdsa.PrivateKey, error) {
+	var curve elliptic.Curve
+
+	switch kt {
+	case kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363, kms.NISTP256ECDHKWType:
+		curve = elliptic.P256()
+	case kms.ECDSAP384TypeDER, kms.ECDSAP384TypeIEEEP1363, kms.NISTP384ECDHKWType:
+		curve = elliptic.P384()
+	case kms.ECDSAP521TypeDER, kms.ECDSAP521TypeIEEEP1363, kms.NISTP521ECDHKWType:
+		curve = elliptic.P521()
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		curve = btcec.S256()
+	default:
+		return nil, fmt.Errorf("unsupported ECDSA key type '%s'", kt)
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		},
+		D: new(big.Int).SetBytes(d),
+	}, nil
+}
+
+func newRSAPrivateKey(n, e, d, p, q []byte) (*rsa.PrivateKey, error) {
+	privKey := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		},
+		D:      new(big.Int).SetBytes(d),
+		Primes: []*big.Int{new(big.Int).SetBytes(p), new(big.Int).SetBytes(q)},
+	}
+
+	if err := privKey.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid RSA private key: %w", err)
+	}
+
+	privKey.Precompute()
+
+	return privKey, nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -92,7 +93,7 @@ type walletKeyManager struct {
 }
 
 func (k *walletKeyManager) createKeyManager(profileInfo *profile,
-	storeProvider kms.Store, opts *unlockOpts) (kms.KeyManager, error) {
+	storeProvider storage.Provider, opts *unlockOpts) (kms.KeyManager, error) {
 	if profileInfo.MasterLockCipher == "" && profileInfo.KeyServerURL == "" {
 		return nil, fmt.Errorf("invalid wallet profile")
 	}
@@ -104,8 +105,7 @@ func (k *walletKeyManager) createKeyManager(profileInfo *profile,
 	// create key manager
 	if profileInfo.MasterLockCipher != "" {
 		// local kms
-		keyManager, err = createLocalKeyManager(profileInfo.User, opts.passphrase,
-			profileInfo.MasterLockCipher, opts.secretLockSvc, storeProvider)
+		keyManager, err = createWalletLocalKMS(profileInfo, storeProvider, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create local key manager: %w", err)
 		}
@@ -144,6 +144,24 @@ func (k *kmsProvider) SecretLock() secretlock.Service {
 	return k.secretLock
 }
 
+// createWalletLocalKMS creates and returns local KMS instance of the wallet profile, storing keys in kms store of
+// given storage provider.
+func createWalletLocalKMS(profileInfo *profile, storeProvider storage.Provider,
+	opts *unlockOpts) (*walletLocalKMS, error) {
+	kmsStore, err := kms.NewAriesProviderWrapper(storeProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	localKMS, err := createLocalKeyManager(profileInfo.User, opts.passphrase,
+		profileInfo.MasterLockCipher, opts.secretLockSvc, kmsStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return newWalletLocalKMS(profileInfo.User, localKMS, kmsStore, storeProvider)
+}
+
 // createLocalKeyManager creates and returns local KMS instance.
 func createLocalKeyManager(user, passphrase, masterLockCipher string,
 	masterLocker secretlock.Service, storeProvider kms.Store) (*localkms.LocalKMS, error) {
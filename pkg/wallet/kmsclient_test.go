@@ -50,10 +50,7 @@ func TestKeyManager(t *testing.T) {
 			MasterLockCipher: masterLockCipherText,
 		}
 
-		kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{passphrase: samplePassPhrase})
 		require.NoError(t, err)
 		require.NotEmpty(t, kmgr)
@@ -73,10 +70,7 @@ func TestKeyManager(t *testing.T) {
 			MasterLockCipher: masterLockCipherText,
 		}
 
-		kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{secretLockSvc: masterLock})
 		require.NoError(t, err)
 		require.NotEmpty(t, kmgr)
@@ -96,11 +90,8 @@ func TestKeyManager(t *testing.T) {
 			MasterLockCipher: masterLockCipherText,
 		}
 
-		kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
 		// use wrong passphrase
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{passphrase: samplePassPhrase + "wrong"})
 		require.Empty(t, kmgr)
 		require.Error(t, err)
@@ -125,10 +116,7 @@ func TestKeyManager(t *testing.T) {
 		masterLockBad, err := pbkdf2.NewMasterLock(samplePassPhrase+"wrong", sha256.New, 0, nil)
 		require.NoError(t, err)
 
-		kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{secretLockSvc: masterLockBad})
 		require.Empty(t, kmgr)
 		require.Error(t, err)
@@ -142,10 +130,7 @@ func TestKeyManager(t *testing.T) {
 			KeyServerURL: sampleKeyServerURL,
 		}
 
-		kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{authToken: sampleRemoteKMSAuth})
 		require.NoError(t, err)
 		require.NotEmpty(t, kmgr)
@@ -159,10 +144,7 @@ func TestKeyManager(t *testing.T) {
 			User: uuid.New().String(),
 		}
 
-		kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-		require.NoError(t, err)
-
-		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+		kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 			&unlockOpts{authToken: sampleRemoteKMSAuth})
 		require.Empty(t, kmgr)
 		require.Error(t, err)
@@ -184,10 +166,7 @@ func TestImportKeyJWK(t *testing.T) {
 		MasterLockCipher: masterLockCipherText,
 	}
 
-	kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-	require.NoError(t, err)
-
-	kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+	kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 		&unlockOpts{passphrase: samplePassPhrase})
 	require.NoError(t, err)
 	require.NotEmpty(t, kmgr)
@@ -371,10 +350,7 @@ func TestImportKeyBase58(t *testing.T) {
 		MasterLockCipher: masterLockCipherText,
 	}
 
-	kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-	require.NoError(t, err)
-
-	kmgr, e := keyManager().createKeyManager(profileInfo, kmsStore,
+	kmgr, e := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 		&unlockOpts{passphrase: samplePassPhrase})
 	require.NoError(t, e)
 	require.NotEmpty(t, kmgr)
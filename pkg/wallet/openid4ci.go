@@ -0,0 +1,116 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// AcceptCredentialOffer accepts OpenID4VCI credential offer using pre-authorized code flow,
+// requests the offered credential from the credential issuer and saves it into wallet.
+//
+//	Args:
+//		- auth token for unlocking kms.
+//		- credential offer received from the credential issuer.
+//		- options for accepting the offer, like user PIN, proof options for binding issued credential to wallet DID,
+//		HTTP client or collection.
+//
+//	Returns:
+//		- verifiable credential issued by the credential issuer.
+//		- error if operation fails.
+//
+func (c *Wallet) AcceptCredentialOffer(authToken string, offer *openid4ci.CredentialOffer,
+	options ...AcceptCredentialOfferOptions) (*verifiable.Credential, error) {
+	if offer == nil {
+		return nil, errors.New("credential offer is mandatory")
+	}
+
+	// pre-authorized code can be redeemed only once, fail before contacting the issuer if wallet is locked.
+	_, err := sessionManager().getSession(authToken)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAuthToken) {
+			return nil, ErrWalletLocked
+		}
+
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	opts := &acceptCredentialOfferOpts{}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	var requestOpts []openid4ci.RequestOptions
+
+	if opts.userPIN != "" {
+		requestOpts = append(requestOpts, openid4ci.WithPIN(opts.userPIN))
+	}
+
+	if opts.proofOptions != nil {
+		err = c.validateProofOption(authToken, opts.proofOptions, did.Authentication)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare proof: %w", err)
+		}
+
+		s, e := newKMSSigner(authToken, c.walletCrypto, opts.proofOptions)
+		if e != nil {
+			return nil, fmt.Errorf("initializing signer: %w", e)
+		}
+
+		requestOpts = append(requestOpts,
+			openid4ci.WithProofSigner(&proofJWTSigner{kmsSigner: s}, opts.proofOptions.VerificationMethod))
+	}
+
+	var clientOpts []openid4ci.ClientOption
+
+	if opts.httpClient != nil {
+		clientOpts = append(clientOpts, openid4ci.WithHTTPClient(opts.httpClient))
+	}
+
+	response, err := openid4ci.NewClient(clientOpts...).RequestCredential(offer, requestOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request credential: %w", err)
+	}
+
+	vc, err := verifiable.ParseCredential(response.Credential, verifiable.WithPublicKeyFetcher(
+		verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
+	), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("issued credential verification failed: %w", err)
+	}
+
+	var addOpts []AddContentOptions
+
+	if opts.collectionID != "" {
+		addOpts = append(addOpts, AddByCollection(opts.collectionID))
+	}
+
+	err = c.Add(authToken, Credential, response.Credential, addOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save issued credential: %w", err)
+	}
+
+	return vc, nil
+}
+
+// proofJWTSigner signs proof of possession JWT with wallet key.
+type proofJWTSigner struct {
+	*kmsSigner
+}
+
+func (s *proofJWTSigner) Headers() jose.Headers {
+	return jose.Headers{
+		jose.HeaderAlgorithm: s.Alg(),
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	vdrpkg "github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const sampleOfferedCredential = `{
+	"@context": [
+		"https://www.w3.org/2018/credentials/v1",
+		"https://www.w3.org/2018/credentials/examples/v1"
+	],
+	"id": "http://example.edu/credentials/1872",
+	"type": ["VerifiableCredential", "UniversityDegreeCredential"],
+	"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+	"issuanceDate": "2010-01-01T19:23:24Z",
+	"credentialSubject": {
+		"degree": {
+			"type": "BachelorDegree",
+			"university": "MIT"
+		}
+	}
+}`
+
+func TestWallet_AcceptCredentialOffer(t *testing.T) {
+	server, issuer := newOpenID4CIServer(t)
+	defer server.Close()
+
+	user := uuid.New().String()
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = vdrpkg.New(vdrpkg.WithVDR(key.New()))
+	mockctx.CryptoValue = tinkCrypto
+
+	err = CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, walletInstance)
+
+	authToken, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+	require.NotEmpty(t, authToken)
+
+	defer walletInstance.Close()
+
+	// import keys manually
+	session, err := sessionManager().getSession(authToken)
+	require.NoError(t, err)
+
+	_, _, err = session.KeyManager.ImportPrivateKey(ed25519.PrivateKey(base58.Decode(pkBase58)),
+		kms.ED25519, kms.WithKeyID(kid))
+	require.NoError(t, err)
+
+	t.Run("test accept credential offer - success", func(t *testing.T) {
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleOfferedCredential), openid4ci.WithUserPIN("1234"))
+		require.NoError(t, err)
+
+		vc, err := walletInstance.AcceptCredentialOffer(authToken, offer,
+			WithOfferUserPIN("1234"),
+			WithOfferProofOptions(&ProofOptions{Controller: didKey}),
+			WithOfferHTTPClient(server.Client()))
+		require.NoError(t, err)
+		require.NotEmpty(t, vc.JWT)
+		require.Equal(t, didKey, vc.Subject.([]verifiable.Subject)[0].ID)
+
+		stored, err := walletInstance.GetAll(authToken, Credential)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+
+		require.NoError(t, walletInstance.Remove(authToken, Credential, vc.ID))
+	})
+
+	t.Run("test accept credential offer into collection - success", func(t *testing.T) {
+		const collectionID = "did:example:123456789abcdefghi"
+
+		require.NoError(t, walletInstance.Add(authToken, Collection, []byte(sampleContentValid)))
+
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleOfferedCredential),
+			openid4ci.WithCredentialFormat(openid4ci.LDPVCFormat))
+		require.NoError(t, err)
+
+		vc, err := walletInstance.AcceptCredentialOffer(authToken, offer,
+			WithOfferHTTPClient(server.Client()), WithOfferCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+
+		stored, err := walletInstance.GetAll(authToken, Credential, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+	})
+
+	t.Run("test accept credential offer - failure", func(t *testing.T) {
+		vc, err := walletInstance.AcceptCredentialOffer(authToken, nil)
+		require.EqualError(t, err, "credential offer is mandatory")
+		require.Nil(t, vc)
+
+		offer, err := issuer.CreateCredentialOffer(json.RawMessage(sampleOfferedCredential))
+		require.NoError(t, err)
+
+		vc, err = walletInstance.AcceptCredentialOffer(authToken, offer,
+			WithOfferProofOptions(&ProofOptions{}), WithOfferHTTPClient(server.Client()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to prepare proof")
+		require.Nil(t, vc)
+
+		vc, err = walletInstance.AcceptCredentialOffer(sampleFakeTkn, offer,
+			WithOfferProofOptions(&ProofOptions{Controller: didKey}), WithOfferHTTPClient(server.Client()))
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Nil(t, vc)
+
+		offer.CredentialIssuer = server.URL + "/unknown"
+
+		vc, err = walletInstance.AcceptCredentialOffer(authToken, offer, WithOfferHTTPClient(server.Client()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to request credential")
+		require.Nil(t, vc)
+	})
+}
+
+// newOpenID4CIServer returns an in-process OpenID4VCI credential issuer server.
+func newOpenID4CIServer(t *testing.T) (*httptest.Server, *openid4ci.Issuer) {
+	t.Helper()
+
+	var issuer *openid4ci.Issuer
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(openid4ci.IssuerMetadataPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeOpenID4CIResponse(rw, issuer.Metadata(), nil)
+	})
+
+	mux.HandleFunc(openid4ci.AuthorizationServerMetadataPath, func(rw http.ResponseWriter, _ *http.Request) {
+		writeOpenID4CIResponse(rw, issuer.AuthorizationServerMetadata(), nil)
+	})
+
+	mux.HandleFunc(openid4ci.TokenEndpointPath, func(rw http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+
+		response, err := issuer.Token(&openid4ci.TokenRequest{
+			GrantType:         req.PostForm.Get("grant_type"),
+			PreAuthorizedCode: req.PostForm.Get("pre-authorized_code"),
+			UserPIN:           req.PostForm.Get("user_pin"),
+		})
+		writeOpenID4CIResponse(rw, response, err)
+	})
+
+	mux.HandleFunc(openid4ci.CredentialEndpointPath, func(rw http.ResponseWriter, req *http.Request) {
+		request := &openid4ci.CredentialRequest{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(request))
+
+		accessToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+		response, err := issuer.Credential(accessToken, request)
+		writeOpenID4CIResponse(rw, response, err)
+	})
+
+	server := httptest.NewServer(mux)
+
+	kmsProvider, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	localKMS, err := localkms.New("local-lock://custom/master/key/", kmsProvider)
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	issuerKID, pubKey, err := localKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, verificationMethod := fingerprint.CreateDIDKey(pubKey)
+
+	p := newMockProvider(t)
+
+	issuer, err = openid4ci.NewIssuer(&mockprovider.Provider{
+		StorageProviderValue: mockstorage.NewMockStoreProvider(),
+		KMSValue:             localKMS,
+		CryptoValue:          tinkCrypto,
+		VDRegistryValue:      vdrpkg.New(vdrpkg.WithVDR(key.New())),
+		DocumentLoaderValue:  p.DocumentLoaderValue,
+	}, &openid4ci.IssuerConfig{
+		URL:                server.URL,
+		VerificationMethod: verificationMethod,
+		KeyID:              issuerKID,
+	})
+	require.NoError(t, err)
+
+	return server, issuer
+}
+
+func writeOpenID4CIResponse(rw http.ResponseWriter, response interface{}, err error) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+
+		response = err
+	}
+
+	_ = json.NewEncoder(rw).Encode(response) // nolint:errchkjson
+}
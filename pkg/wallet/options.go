@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/component/storage/edv"
+	"github.com/hyperledger/aries-framework-go/pkg/client/openid4ci"
 	"github.com/hyperledger/aries-framework-go/pkg/client/outofband"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
@@ -501,3 +502,44 @@ func ResolveCredentialID(descriptorID, credentialID string) ResolveManifestOptio
 		opts.credentialID = credentialID
 	}
 }
+
+// acceptCredentialOfferOpts contains options for accepting OpenID4VCI credential offer.
+type acceptCredentialOfferOpts struct {
+	userPIN      string
+	proofOptions *ProofOptions
+	httpClient   openid4ci.HTTPClient
+	collectionID string
+}
+
+// AcceptCredentialOfferOptions is option for accepting OpenID4VCI credential offer.
+type AcceptCredentialOfferOptions func(opts *acceptCredentialOfferOpts)
+
+// WithOfferUserPIN option for providing user PIN required by the credential offer.
+func WithOfferUserPIN(pin string) AcceptCredentialOfferOptions {
+	return func(opts *acceptCredentialOfferOpts) {
+		opts.userPIN = pin
+	}
+}
+
+// WithOfferProofOptions option for providing proof options of the proof of possession of wallet key,
+// the issued credential will be bound to 'controller' DID.
+// Verification method should be matching 'authentication' relationship of the controller.
+func WithOfferProofOptions(proofOptions *ProofOptions) AcceptCredentialOfferOptions {
+	return func(opts *acceptCredentialOfferOpts) {
+		opts.proofOptions = proofOptions
+	}
+}
+
+// WithOfferHTTPClient option for providing HTTP client for requests to the credential issuer.
+func WithOfferHTTPClient(client openid4ci.HTTPClient) AcceptCredentialOfferOptions {
+	return func(opts *acceptCredentialOfferOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithOfferCollection option for saving issued credential into given collection.
+func WithOfferCollection(collectionID string) AcceptCredentialOfferOptions {
+	return func(opts *acceptCredentialOfferOpts) {
+		opts.collectionID = collectionID
+	}
+}
@@ -14,7 +14,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storage/edv"
-	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
@@ -35,10 +34,7 @@ func TestStorageProvider_OpenStore(t *testing.T) {
 		MasterLockCipher: masterLockCipherText,
 	}
 
-	kmsStore, err := kmsapi.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
-	require.NoError(t, err)
-
-	kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore,
+	kmgr, err := keyManager().createKeyManager(profileInfo, mockstorage.NewMockStoreProvider(),
 		&unlockOpts{passphrase: samplePassPhrase})
 	require.NoError(t, err)
 	require.NotEmpty(t, kmgr)
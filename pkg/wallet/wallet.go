@@ -169,13 +169,8 @@ func CreateDataVaultKeyPairs(userID string, ctx provider, options ...UnlockOptio
 		opt(opts)
 	}
 
-	kmsStore, err := kms.NewAriesProviderWrapper(ctx.StorageProvider())
-	if err != nil {
-		return err
-	}
-
 	// unlock key manager
-	kmsm, err := keyManager().createKeyManager(profile, kmsStore, opts)
+	kmsm, err := keyManager().createKeyManager(profile, ctx.StorageProvider(), opts)
 	if err != nil {
 		return fmt.Errorf("failed to get key manager: %w", err)
 	}
@@ -268,13 +263,8 @@ func (c *Wallet) Open(options ...UnlockOptions) (string, error) {
 		opt(opts)
 	}
 
-	kmsStore, err := kms.NewAriesProviderWrapper(c.storeProvider)
-	if err != nil {
-		return "", err
-	}
-
 	// unlock key manager
-	keyManager, err := keyManager().createKeyManager(c.profile, kmsStore, opts)
+	keyManager, err := keyManager().createKeyManager(c.profile, c.storeProvider, opts)
 	if err != nil {
 		return "", err
 	}
@@ -306,7 +296,8 @@ func (c *Wallet) Close() bool {
 // Only ciphertext wallet contents can be exported.
 //
 //	Args:
-//		- auth: token to be used to lock the wallet before exporting.
+//		- authToken: authorization for performing operation.
+//		- passphrase: passphrase from which key for locking the exported wallet contents will be derived.
 //
 //	Returns exported locked wallet.
 //
@@ -316,18 +307,39 @@ func (c *Wallet) Close() bool {
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#DIDResolutionResponse
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#meta-data
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
+//	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Export(auth string) (json.RawMessage, error) {
-	// TODO to be added #2433
-	return nil, fmt.Errorf("to be implemented")
+// Note: private keys of wallet's local key manager are exported as private key JWKs, keys of a remote key
+// manager stay on the key server.
+//
+func (c *Wallet) Export(authToken, passphrase string) (json.RawMessage, error) {
+	contents, err := c.contents.exportContents(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet contents: %w", err)
+	}
+
+	keys, err := exportKeys(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export wallet keys: %w", err)
+	}
+
+	contents = append(contents, keys...)
+
+	locked, err := lockContents(c.profile.ID, passphrase, contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock exported wallet contents: %w", err)
+	}
+
+	return locked, nil
 }
 
 // Import Takes a serialized exported wallet representation as input
-// and imports all contents into wallet.
+// and imports all contents into wallet. If a content fails to be imported, none of the contents is imported.
 //
 //	Args:
-//		- contents: wallet content to be imported.
-//		- auth: token used while exporting the wallet.
+//		- authToken: authorization for performing operation.
+//		- passphrase: passphrase used while exporting the wallet.
+//		- contents: locked wallet content to be imported.
 //
 // Supported data models:
 // 	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Collection
@@ -337,9 +349,18 @@ func (c *Wallet) Export(auth string) (json.RawMessage, error) {
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#connection
 //	- https://w3c-ccg.github.io/universal-wallet-interop-spec/#Key
 //
-func (c *Wallet) Import(auth string, contents json.RawMessage) error {
-	// TODO to be added #2433
-	return fmt.Errorf("to be implemented")
+func (c *Wallet) Import(authToken, passphrase string, contents json.RawMessage, options ...AddContentOptions) error {
+	unlocked, err := unlockContents(passphrase, contents)
+	if err != nil {
+		return fmt.Errorf("failed to unlock imported wallet contents: %w", err)
+	}
+
+	err = c.contents.importContents(authToken, unlocked, options...)
+	if err != nil {
+		return fmt.Errorf("failed to import wallet contents: %w", err)
+	}
+
+	return nil
 }
 
 // Add adds given data model to wallet contents store.
@@ -11,6 +11,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	_ "embed"
 	"encoding/base64"
@@ -22,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -55,7 +57,6 @@ import (
 const (
 	sampleUserID            = "sample-user01"
 	sampleFakeTkn           = "fake-auth-tkn"
-	toBeImplementedErr      = "to be implemented"
 	sampleWalletErr         = "sample wallet err"
 	sampleCreatedDate       = "2020-12-25"
 	sampleChallenge         = "sample-challenge"
@@ -389,7 +390,13 @@ func TestCreateDataVaultKeyPairs(t *testing.T) {
 	})
 
 	t.Run("fail to create new KMS Aries provider wrapper", func(t *testing.T) {
-		testProfile := profile{EDVConf: &edvConf{}}
+		masterLock, err := getDefaultSecretLock(samplePassPhrase)
+		require.NoError(t, err)
+
+		masterLockCipherText, err := createMasterLock(masterLock)
+		require.NoError(t, err)
+
+		testProfile := profile{EDVConf: &edvConf{}, MasterLockCipher: masterLockCipherText}
 
 		testProfileBytes, err := json.Marshal(testProfile)
 		require.NoError(t, err)
@@ -405,8 +412,9 @@ func TestCreateDataVaultKeyPairs(t *testing.T) {
 			},
 		}
 
-		err = CreateDataVaultKeyPairs(sampleUserID, mockContext)
-		require.EqualError(t, err, "failed to open store for name space kmsdb")
+		err = CreateDataVaultKeyPairs(sampleUserID, mockContext, WithUnlockByPassphrase(samplePassPhrase))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open store for name space kmsdb")
 	})
 
 	t.Run("test update profile errors", func(t *testing.T) {
@@ -687,33 +695,251 @@ func TestWallet_OpenClose(t *testing.T) {
 	})
 }
 
-func TestWallet_Export(t *testing.T) {
+func TestWallet_ExportImport(t *testing.T) {
+	const vcContent = `{
+      "@context": [
+        "https://www.w3.org/2018/credentials/v1",
+        "https://www.w3.org/2018/credentials/examples/v1"
+      ],
+      "id": "http://example.edu/credentials/export-1",
+      "issuer": {
+        "id": "did:example:76e12ec712ebc6f1c221ebfeb1f"
+      },
+      "type": [
+        "VerifiableCredential",
+        "UniversityDegreeCredential"
+      ]
+    }`
+
+	const orgCollection = `{
+                    "@context": ["https://w3id.org/wallet/v1"],
+                    "id": "did:example:acme123456789abcdefghi",
+                    "type": "Organization",
+                    "name": "Acme Corp"
+                }`
+
+	const (
+		collectionID     = "did:example:acme123456789abcdefghi"
+		vcID             = "http://example.edu/credentials/export-1"
+		samplePassphrase = "sample-export-passphrase"
+	)
+
 	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
+
+	source := uuid.New().String()
+	require.NoError(t, CreateProfile(source, mockctx, WithPassphrase(samplePassPhrase)))
+
+	sourceWallet, err := New(source, mockctx)
 	require.NoError(t, err)
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
+	tkn, err := sourceWallet.Open(WithUnlockByPassphrase(samplePassPhrase))
 	require.NoError(t, err)
 
-	result, err := walletInstance.Export("")
-	require.Empty(t, result)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
-}
+	defer sourceWallet.Close()
 
-func TestWallet_Import(t *testing.T) {
-	mockctx := newMockProvider(t)
-	err := CreateProfile(sampleUserID, mockctx, WithKeyServerURL(sampleKeyServerURL))
+	require.NoError(t, sourceWallet.Add(tkn, Collection, []byte(orgCollection)))
+	require.NoError(t, sourceWallet.Add(tkn, Metadata, []byte(sampleContentValid)))
+	require.NoError(t, sourceWallet.Add(tkn, Credential, []byte(vcContent), AddByCollection(collectionID)))
+	require.NoError(t, sourceWallet.Add(tkn, DIDResolutionResponse, testdata.SampleDocResolutionResponse))
+	require.NoError(t, sourceWallet.Add(tkn, Key, []byte(sampleKeyContentJwkValid)))
+
+	edKeyPair, err := sourceWallet.CreateKeyPair(tkn, kms.ED25519Type)
 	require.NoError(t, err)
 
-	walletInstance, err := New(sampleUserID, mockctx)
-	require.NotEmpty(t, walletInstance)
+	p256KeyPair, err := sourceWallet.CreateKeyPair(tkn, kms.ECDSAP256TypeDER)
 	require.NoError(t, err)
 
-	err = walletInstance.Import("", nil)
-	require.Error(t, err)
-	require.EqualError(t, err, toBeImplementedErr)
+	ecdhKeyPair, err := sourceWallet.CreateKeyPair(tkn, kms.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	x25519KeyPair, err := sourceWallet.CreateKeyPair(tkn, kms.X25519ECDHKWType)
+	require.NoError(t, err)
+
+	// RSA and secp256k1 keys can't be created by the key manager, they are imported.
+	session, err := sessionManager().getSession(tkn)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	rsaKeyID, _, err := session.KeyManager.ImportPrivateKey(rsaKey, kms.RSAPS256Type)
+	require.NoError(t, err)
+
+	secp256k1Key, err := ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+	require.NoError(t, err)
+
+	secp256k1KeyID, _, err := session.KeyManager.ImportPrivateKey(secp256k1Key, kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+
+	keyIDs := []string{
+		"z6MkiEh8RQL83nkPo8ehDeX7", edKeyPair.KeyID, p256KeyPair.KeyID, ecdhKeyPair.KeyID, x25519KeyPair.KeyID,
+		rsaKeyID, secp256k1KeyID,
+	}
+
+	t.Run("test export and import wallet contents", func(t *testing.T) {
+		locked, err := sourceWallet.Export(tkn, samplePassphrase)
+		require.NoError(t, err)
+		require.NotEmpty(t, locked)
+
+		// contents are not readable without passphrase.
+		require.NotContains(t, string(locked), vcID)
+
+		var lw lockedWallet
+		require.NoError(t, json.Unmarshal(locked, &lw))
+		require.Contains(t, lw.Type, EncryptedWalletType)
+		require.NotEmpty(t, lw.CredentialSubject.EncryptedWalletContents)
+
+		// import into a wallet backed by another storage.
+		targetCtx := newMockProvider(t)
+
+		target := uuid.New().String()
+		require.NoError(t, CreateProfile(target, targetCtx, WithPassphrase(samplePassPhrase)))
+
+		targetWallet, err := New(target, targetCtx)
+		require.NoError(t, err)
+
+		targetTkn, err := targetWallet.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer targetWallet.Close()
+
+		require.NoError(t, targetWallet.Import(targetTkn, samplePassphrase, locked))
+
+		collections, err := targetWallet.GetAll(targetTkn, Collection)
+		require.NoError(t, err)
+		require.Len(t, collections, 1)
+
+		metadata, err := targetWallet.Get(targetTkn, Metadata, "did:example:123456789abcdefghi")
+		require.NoError(t, err)
+		require.JSONEq(t, sampleContentValid, string(metadata))
+
+		credentials, err := targetWallet.GetAll(targetTkn, Credential, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Contains(t, credentials, vcID)
+
+		didResolutions, err := targetWallet.GetAll(targetTkn, DIDResolutionResponse)
+		require.NoError(t, err)
+		require.Len(t, didResolutions, 1)
+
+		// keys are imported into target wallet's key manager.
+		sourceSession, err := sessionManager().getSession(tkn)
+		require.NoError(t, err)
+
+		targetSession, err := sessionManager().getSession(targetTkn)
+		require.NoError(t, err)
+
+		for _, keyID := range keyIDs {
+			sourcePubKey, sourceKT, e := sourceSession.KeyManager.ExportPubKeyBytes(keyID)
+			require.NoError(t, e)
+
+			targetPubKey, targetKT, e := targetSession.KeyManager.ExportPubKeyBytes(keyID)
+			require.NoError(t, e, keyID)
+			require.Equal(t, sourceKT, targetKT)
+			require.Equal(t, sourcePubKey, targetPubKey)
+		}
+
+		// importing same contents again should fail.
+		err = targetWallet.Import(targetTkn, samplePassphrase, locked)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "content with same type and id already exists in this wallet")
+	})
+
+	t.Run("test import is all-or-nothing", func(t *testing.T) {
+		keys, err := exportKeys(tkn)
+		require.NoError(t, err)
+		require.Len(t, keys, len(keyIDs))
+
+		targetCtx := newMockProvider(t)
+
+		target := uuid.New().String()
+		require.NoError(t, CreateProfile(target, targetCtx, WithPassphrase(samplePassPhrase)))
+
+		targetWallet, err := New(target, targetCtx)
+		require.NoError(t, err)
+
+		targetTkn, err := targetWallet.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		defer targetWallet.Close()
+
+		contents := []*walletContent{
+			{ContentType: Collection, Content: []byte(orgCollection)},
+			{ContentType: Metadata, Content: []byte(sampleContentValid)},
+		}
+		contents = append(contents, keys...)
+		contents = append(contents, &walletContent{ContentType: Credential, Content: []byte("invalid")})
+
+		err = targetWallet.contents.importContents(targetTkn, contents)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to import content of type 'credential'")
+
+		collections, err := targetWallet.GetAll(targetTkn, Collection)
+		require.NoError(t, err)
+		require.Empty(t, collections)
+
+		metadata, err := targetWallet.GetAll(targetTkn, Metadata)
+		require.NoError(t, err)
+		require.Empty(t, metadata)
+
+		targetSession, err := sessionManager().getSession(targetTkn)
+		require.NoError(t, err)
+
+		for _, keyID := range keyIDs {
+			_, _, err = targetSession.KeyManager.ExportPubKeyBytes(keyID)
+			require.Error(t, err, keyID)
+		}
+
+		// the same contents can be imported once the failing one is removed.
+		err = targetWallet.contents.importContents(targetTkn, contents[:len(contents)-1])
+		require.NoError(t, err)
+
+		for _, keyID := range keyIDs {
+			_, _, err = targetSession.KeyManager.ExportPubKeyBytes(keyID)
+			require.NoError(t, err, keyID)
+		}
+	})
+
+	t.Run("test export failures", func(t *testing.T) {
+		// wallet locked
+		locked, err := sourceWallet.Export(sampleFakeTkn, samplePassphrase)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+		require.Empty(t, locked)
+
+		// missing passphrase
+		locked, err = sourceWallet.Export(tkn, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "passphrase is required for locking wallet contents")
+		require.Empty(t, locked)
+	})
+
+	t.Run("test import failures", func(t *testing.T) {
+		locked, err := sourceWallet.Export(tkn, samplePassphrase)
+		require.NoError(t, err)
+
+		// wrong passphrase
+		err = sourceWallet.Import(tkn, "wrong-passphrase", locked)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt wallet contents")
+
+		// invalid locked wallet
+		err = sourceWallet.Import(tkn, samplePassphrase, []byte(`{"credentialSubject":{}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "encrypted wallet contents not found")
+
+		err = sourceWallet.Import(tkn, samplePassphrase, []byte("{"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read locked wallet")
+
+		err = sourceWallet.Import(tkn, samplePassphrase,
+			[]byte(`{"credentialSubject":{"encryptedWalletContents":{"ciphertext":"invalid"}}}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse encrypted wallet contents")
+
+		// wallet locked
+		err = sourceWallet.Import(sampleFakeTkn, samplePassphrase, locked)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	})
 }
 
 func TestWallet_Add(t *testing.T) {
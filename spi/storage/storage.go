@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	standardlog "log"
+	"strings"
+	"time"
 
 	spi "github.com/hyperledger/aries-framework-go/spi/log"
 )
@@ -28,6 +31,9 @@ var (
 	// ErrDuplicateKey is returned when a call is made to Store.Batch using the IsNewKey PutOption with a key that
 	// already exists in the database.
 	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrWatchNotSupported is returned by components that need to watch a store for changes if the store doesn't
+	// implement Watchable.
+	ErrWatchNotSupported = errors.New("store does not support watching for changes")
 )
 
 // StoreConfiguration represents the configuration of a store.
@@ -127,6 +133,12 @@ type PutOptions struct {
 	// this is set to true and the key already exists. See the documentation for the specific storage provider to
 	// see if and how this option is used.
 	IsNewKey bool `json:"isNewKey,omitempty"`
+	// ExpiresAt is an optional deadline for the data being put. Once it has passed, the data is treated as if it
+	// doesn't exist: Store.Get returns an error wrapping ErrDataNotFound and Store.Query skips it. The storage
+	// provider will eventually purge expired data. Putting the same key again without this option clears the
+	// deadline. If this is the zero time, then the data never expires. See the documentation for the specific
+	// storage provider to see if this option is supported natively.
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
 }
 
 // Operation represents an operation to be performed in the Batch method.
@@ -215,6 +227,14 @@ type Store interface {
 	// Each Criterion follows the rules for the basic expression format described above.
 	// Each operator must be either "&&" or "||" (without quotes). "&&" indicates an AND operator while "||"
 	// indicates an OR operator. The order of operations are ANDs followed by ORs.
+	// A store implementation may also support criteria comparing tag values: TagName<TagValue, TagName<=TagValue,
+	// TagName>TagValue and TagName>=TagValue. Tag values which are decimal numbers are compared numerically and
+	// RFC3339 timestamps are compared chronologically. Since tag values can't contain ':' characters, the ':'
+	// characters of a timestamp tag value are encoded as "%3A" (e.g. 2022-06-01T10%3A30%3A00Z), RFC3339 full-dates
+	// (e.g. 2022-06-01) don't need any encoding. The value of the criterion may use either form of a timestamp.
+	// Numbers are never comparable to timestamps and other tag values never satisfy such a criterion. A criterion prefixed
+	// with "!" is negated: it's satisfied by data which doesn't have any tag satisfying the criterion, including data
+	// without the tag name.
 	// This method also supports a number of QueryOptions. If none are provided, then defaults will be used.
 	// If your store contains a large amount of data, then it's recommended calling Provider.SetStoreConfig at some
 	// point before calling this method in order to create indexes which will speed up queries.
@@ -273,6 +293,88 @@ type Iterator interface {
 	Close() error
 }
 
+// EventType represents the kind of change described by an Event.
+type EventType int
+
+const (
+	// EventPut means that data was put in a Store.
+	EventPut EventType = iota
+	// EventDelete means that data was deleted from a Store.
+	EventDelete
+)
+
+// Event describes a change made to data in a Store.
+type Event struct {
+	Type EventType
+	Key  string
+	// Value is the value that was put. It's nil for EventDelete events.
+	Value []byte
+	// Tags are the tags that were put. For EventDelete events, these are the tags of the data that was deleted.
+	Tags []Tag
+}
+
+// WatchOptions represents the filters of a Watchable.Watch call.
+type WatchOptions struct {
+	// KeyPrefix restricts events to keys with this prefix.
+	KeyPrefix string
+	// TagName restricts events to data with a tag with this name.
+	TagName string
+	// TagValue further restricts events to data with a tag with TagName and this value.
+	TagValue string
+}
+
+// WatchOption represents an option for a Watchable.Watch call.
+type WatchOption func(opts *WatchOptions)
+
+// WithKeyPrefix restricts the events streamed by a Watchable.Watch call to keys with the given prefix.
+func WithKeyPrefix(prefix string) WatchOption {
+	return func(opts *WatchOptions) {
+		opts.KeyPrefix = prefix
+	}
+}
+
+// WithTag restricts the events streamed by a Watchable.Watch call to data with the given tag. If the tag value is
+// blank, then any data with a tag with the given name matches.
+func WithTag(tag Tag) WatchOption {
+	return func(opts *WatchOptions) {
+		opts.TagName = tag.Name
+		opts.TagValue = tag.Value
+	}
+}
+
+// Matches checks if the given event satisfies all the filters.
+func (o *WatchOptions) Matches(event *Event) bool {
+	if !strings.HasPrefix(event.Key, o.KeyPrefix) {
+		return false
+	}
+
+	if o.TagName == "" {
+		return true
+	}
+
+	for _, tag := range event.Tags {
+		if tag.Name == o.TagName && (o.TagValue == "" || tag.Value == o.TagValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Watchable is an optional interface that a Store may implement to stream the changes made to its data.
+// Only changes made through the Store itself (or through other Store objects from the same Provider instance) are
+// guaranteed to be streamed. Whether changes made by other processes sharing the same underlying database are
+// streamed depends on the storage implementation.
+type Watchable interface {
+	// Watch returns a channel of events for the data put in or deleted from the Store after this call.
+	// Only events that satisfy all the given options are streamed. If none are provided, then all events are streamed.
+	// The channel is closed once ctx is done or the Store is closed.
+	// Events are delivered in order through a buffered channel. If the receiver doesn't keep up and the buffer is full,
+	// then further events are dropped until there's room again.
+	// The Value and Tags of events are shared between watchers and must not be modified.
+	Watch(ctx context.Context, options ...WatchOption) (<-chan Event, error)
+}
+
 // Close closes iterator and logs any error that occurs.
 // Is logger is nil, then the standard Go logger will be used.
 func Close(iterator Iterator, logger spi.Logger) {
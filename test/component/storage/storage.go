@@ -9,9 +9,11 @@ SPDX-License-Identifier: Apache-2.0
 package storage
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -1055,6 +1057,175 @@ func TestStoreQueryWithSortingAndInitialPageOptions(t *testing.T, provider spi.P
 	doStoreQueryWithSortingAndInitialPageOptionsTests(t, provider, true, options)
 }
 
+// TestStoreQueryWithComparisonOperators tests Store Query functionality when the expression uses the "<", "<=",
+// ">" and ">=" comparison operators and "!" negations. It isn't included in TestAll since support of these
+// operators is optional. Store implementations which support them should run this test in addition to TestAll.
+func TestStoreQueryWithComparisonOperators(t *testing.T, provider spi.Provider, opts ...TestOption) {
+	options := getOptions(opts)
+
+	doStoreQueryWithComparisonOperatorsTests(t, provider, false, options)
+	doStoreQueryWithComparisonOperatorsTests(t, provider, true, options)
+}
+
+// TestStoreExpiry tests that data put with the PutOptions.ExpiresAt option is hidden once its deadline has passed.
+// It isn't included in TestAll since support of this option is optional. Store implementations which support it
+// should run this test in addition to TestAll.
+func TestStoreExpiry(t *testing.T, provider spi.Provider) { // nolint: funlen // Test file
+	storeName := randomStoreName()
+
+	store, err := provider.OpenStore(storeName)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	err = provider.SetStoreConfig(storeName, spi.StoreConfiguration{TagNames: []string{"tagName"}})
+	require.NoError(t, err)
+
+	const expiry = 200 * time.Millisecond
+
+	tags := []spi.Tag{{Name: "tagName", Value: "tagValue"}}
+
+	err = store.Batch([]spi.Operation{
+		{Key: "expired", Value: []byte("value1"), Tags: tags, PutOptions: &spi.PutOptions{
+			ExpiresAt: time.Now().Add(-time.Second),
+		}},
+		{Key: "expiring", Value: []byte("value2"), Tags: tags, PutOptions: &spi.PutOptions{
+			ExpiresAt: time.Now().Add(expiry),
+		}},
+		{Key: "renewed", Value: []byte("value3"), Tags: tags, PutOptions: &spi.PutOptions{
+			ExpiresAt: time.Now().Add(expiry),
+		}},
+		{Key: "permanent", Value: []byte("value4"), Tags: tags},
+	})
+	require.NoError(t, err)
+
+	// Putting the data again without the ExpiresAt option clears its deadline.
+	err = store.Put("renewed", []byte("value3"), tags...)
+	require.NoError(t, err)
+
+	t.Run("Data that has already expired is hidden", func(t *testing.T) {
+		value, err := store.Get("expired")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound), "Got unexpected error or no error")
+		require.Nil(t, value)
+
+		value, err = store.Get("expiring")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value2"), value)
+
+		require.ElementsMatch(t, []string{"expiring", "renewed", "permanent"}, queryKeys(t, store, "tagName"))
+	})
+
+	time.Sleep(expiry)
+
+	t.Run("Data is hidden once its deadline has passed", func(t *testing.T) {
+		value, err := store.Get("expiring")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound), "Got unexpected error or no error")
+		require.Nil(t, value)
+
+		tags, err := store.GetTags("expiring")
+		require.True(t, errors.Is(err, spi.ErrDataNotFound), "Got unexpected error or no error")
+		require.Empty(t, tags)
+
+		values, err := store.GetBulk("expired", "expiring", "renewed", "permanent")
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{nil, nil, []byte("value3"), []byte("value4")}, values)
+
+		require.ElementsMatch(t, []string{"renewed", "permanent"}, queryKeys(t, store, "tagName:tagValue"))
+	})
+	t.Run("Expired data can be put again", func(t *testing.T) {
+		err := store.Put("expiring", []byte("value5"))
+		require.NoError(t, err)
+
+		value, err := store.Get("expiring")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value5"), value)
+	})
+}
+
+// TestStoreWatch tests that a Store implementing spi.Watchable streams the changes made to its data.
+// It isn't included in TestAll since spi.Watchable is optional. Store implementations which support it
+// should run this test in addition to TestAll.
+func TestStoreWatch(t *testing.T, provider spi.Provider) { // nolint: funlen // Test file
+	store, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	watchable, ok := store.(spi.Watchable)
+	require.True(t, ok, "store doesn't implement spi.Watchable")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	allEvents, err := watchable.Watch(ctx)
+	require.NoError(t, err)
+
+	prefixEvents, err := watchable.Watch(ctx, spi.WithKeyPrefix("prefix"))
+	require.NoError(t, err)
+
+	tagNameEvents, err := watchable.Watch(ctx, spi.WithTag(spi.Tag{Name: "tagName"}))
+	require.NoError(t, err)
+
+	tagValueEvents, err := watchable.Watch(ctx, spi.WithTag(spi.Tag{Name: "tagName", Value: "tagValue2"}))
+	require.NoError(t, err)
+
+	tag1 := spi.Tag{Name: "tagName", Value: "tagValue1"}
+	tag2 := spi.Tag{Name: "tagName", Value: "tagValue2"}
+
+	require.NoError(t, store.Put("key1", []byte("value1"), tag1))
+	require.NoError(t, store.Put("prefixKey2", []byte("value2")))
+	require.NoError(t, store.Batch([]spi.Operation{
+		{Key: "prefixKey3", Value: []byte("value3"), Tags: []spi.Tag{tag2}},
+		{Key: "key1"},
+		{Key: "nonExistentKey"},
+	}))
+	require.NoError(t, store.Delete("prefixKey3"))
+
+	t.Run("All events", func(t *testing.T) {
+		verifyEvents(t, allEvents, []spi.Event{
+			{Type: spi.EventPut, Key: "key1", Value: []byte("value1"), Tags: []spi.Tag{tag1}},
+			{Type: spi.EventPut, Key: "prefixKey2", Value: []byte("value2")},
+			{Type: spi.EventPut, Key: "prefixKey3", Value: []byte("value3"), Tags: []spi.Tag{tag2}},
+			{Type: spi.EventDelete, Key: "key1", Tags: []spi.Tag{tag1}},
+			{Type: spi.EventDelete, Key: "prefixKey3", Tags: []spi.Tag{tag2}},
+		})
+	})
+	t.Run("Events filtered by key prefix", func(t *testing.T) {
+		verifyEvents(t, prefixEvents, []spi.Event{
+			{Type: spi.EventPut, Key: "prefixKey2", Value: []byte("value2")},
+			{Type: spi.EventPut, Key: "prefixKey3", Value: []byte("value3"), Tags: []spi.Tag{tag2}},
+			{Type: spi.EventDelete, Key: "prefixKey3", Tags: []spi.Tag{tag2}},
+		})
+	})
+	t.Run("Events filtered by tag name", func(t *testing.T) {
+		verifyEvents(t, tagNameEvents, []spi.Event{
+			{Type: spi.EventPut, Key: "key1", Value: []byte("value1"), Tags: []spi.Tag{tag1}},
+			{Type: spi.EventPut, Key: "prefixKey3", Value: []byte("value3"), Tags: []spi.Tag{tag2}},
+			{Type: spi.EventDelete, Key: "key1", Tags: []spi.Tag{tag1}},
+			{Type: spi.EventDelete, Key: "prefixKey3", Tags: []spi.Tag{tag2}},
+		})
+	})
+	t.Run("Events filtered by tag name and value", func(t *testing.T) {
+		verifyEvents(t, tagValueEvents, []spi.Event{
+			{Type: spi.EventPut, Key: "prefixKey3", Value: []byte("value3"), Tags: []spi.Tag{tag2}},
+			{Type: spi.EventDelete, Key: "prefixKey3", Tags: []spi.Tag{tag2}},
+		})
+	})
+	t.Run("Channel is closed once the context is done", func(t *testing.T) {
+		cancel()
+
+		require.Eventually(t, func() bool {
+			_, open := <-allEvents
+
+			return !open
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
 // TestStoreBatch tests common Store Batch functionality.
 func TestStoreBatch(t *testing.T, provider spi.Provider) { // nolint:funlen // Test file
 	t.Run("Success: put three new values", func(t *testing.T) {
@@ -3626,6 +3797,112 @@ func doStoreQueryWithSortingAndInitialPageOptionsTests(t *testing.T, // nolint:
 	})
 }
 
+func doStoreQueryWithComparisonOperatorsTests(t *testing.T, // nolint: funlen // Test file
+	provider spi.Provider, setStoreConfig bool, options testOptions) {
+	keysToPut := []string{"key1", "key2", "key3", "key4", "key5"}
+	valuesToPut := [][]byte{
+		[]byte("value1"), []byte("value2"), []byte("value3"), []byte("value4"), []byte("value5"),
+	}
+	// the ':' characters of timestamps are encoded as "%3A" since tag values can't contain them.
+	tagsToPut := [][]spi.Tag{
+		{
+			{Name: "amount", Value: "1"}, {Name: "issued", Value: "2021-01-01"}, {Name: "type", Value: "vc"},
+			{Name: "updated", Value: "2021-01-01T10%3A00%3A00Z"},
+		},
+		{
+			{Name: "amount", Value: "2"}, {Name: "issued", Value: "2021-06-01"}, {Name: "type", Value: "vc"},
+			{Name: "revoked"}, {Name: "updated", Value: "2021-01-01T12%3A30%3A00.5+02%3A00"},
+		},
+		{
+			{Name: "amount", Value: "10"}, {Name: "issued", Value: "2022-01-01"}, {Name: "type", Value: "vc"},
+			{Name: "updated", Value: "2021-01-01T10%3A30%3A00Z"},
+		},
+		{{Name: "amount", Value: "20"}, {Name: "type", Value: "vp"}},
+		{{Name: "amount", Value: "abc"}, {Name: "issued", Value: "unknown"}, {Name: "type", Value: "vp"}},
+	}
+
+	storeName := randomStoreName()
+
+	store, err := provider.OpenStore(storeName)
+	require.NoError(t, err)
+	require.NotNil(t, store)
+
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	if setStoreConfig {
+		err = provider.SetStoreConfig(storeName,
+			spi.StoreConfiguration{TagNames: []string{"amount", "issued", "type", "revoked", "updated"}})
+		require.NoError(t, err)
+	}
+
+	putData(t, store, keysToPut, valuesToPut, tagsToPut)
+
+	t.Run("Success", func(t *testing.T) {
+		tests := []struct {
+			expression   string
+			expectedKeys []string
+		}{
+			// numbers are compared numerically rather than lexicographically
+			{expression: "amount>2", expectedKeys: []string{"key3", "key4"}},
+			{expression: "amount>=2", expectedKeys: []string{"key2", "key3", "key4"}},
+			{expression: "amount<10", expectedKeys: []string{"key1", "key2"}},
+			{expression: "amount<=10", expectedKeys: []string{"key1", "key2", "key3"}},
+			{expression: "amount>-1.5", expectedKeys: []string{"key1", "key2", "key3", "key4"}},
+			{expression: "amount>1&&amount<20", expectedKeys: []string{"key2", "key3"}},
+			{expression: "amount<2||amount>10", expectedKeys: []string{"key1", "key4"}},
+			{expression: "issued>2021-03-01T00:00:00Z", expectedKeys: []string{"key2", "key3"}},
+			{expression: "issued<=2021-06-01", expectedKeys: []string{"key1", "key2"}},
+			{expression: "issued>=2021-06-01T00:00:00+02:00", expectedKeys: []string{"key2", "key3"}},
+			// timestamps with a time part are compared chronologically, whatever their time zone
+			{expression: "updated>2021-01-01T10:15:00Z", expectedKeys: []string{"key2", "key3"}},
+			{expression: "updated<2021-01-01T10%3A30%3A00.1Z", expectedKeys: []string{"key1", "key3"}},
+			{expression: "updated>=2021-01-01T10:30:00Z&&updated<=2021-01-01T10:30:00Z", expectedKeys: []string{"key3"}},
+			{expression: "updated>2021-01-01T10:30:00Z", expectedKeys: []string{"key2"}},
+			{expression: "updated<2021-01-01"},
+			// numbers aren't comparable to timestamps
+			{expression: "issued>5"},
+			{expression: "!revoked", expectedKeys: []string{"key1", "key3", "key4", "key5"}},
+			{expression: "type:vc&&!revoked", expectedKeys: []string{"key1", "key3"}},
+			{expression: "!type:vc", expectedKeys: []string{"key4", "key5"}},
+			{expression: "!amount<10", expectedKeys: []string{"key3", "key4", "key5"}},
+			{expression: "type:vp&&!amount>5||revoked", expectedKeys: []string{"key2", "key5"}},
+		}
+
+		for _, test := range tests {
+			var (
+				expectedValues [][]byte
+				expectedTags   [][]spi.Tag
+			)
+
+			for _, key := range test.expectedKeys {
+				for i := range keysToPut {
+					if keysToPut[i] == key {
+						expectedValues = append(expectedValues, valuesToPut[i])
+						expectedTags = append(expectedTags, tagsToPut[i])
+					}
+				}
+			}
+
+			t.Run(test.expression, func(t *testing.T) {
+				iterator, err := store.Query(test.expression)
+				require.NoError(t, err)
+
+				verifyExpectedIterator(t, iterator, test.expectedKeys, expectedValues, expectedTags, false,
+					determineWhetherToCheckIteratorTotalItemCounts(options, setStoreConfig), len(test.expectedKeys))
+			})
+		}
+	})
+	t.Run("Invalid comparison expressions", func(t *testing.T) {
+		for _, expression := range []string{"amount>abc", "amount>", "amount<=", ">1", "!", "issued>2021-13-01"} {
+			iterator, err := store.Query(expression)
+			require.Error(t, err, expression)
+			require.Nil(t, iterator, expression)
+		}
+	})
+}
+
 func doBatchTestPutThreeValues(t *testing.T, provider spi.Provider, useNewKeyOptimization bool) {
 	storeName := randomStoreName()
 
@@ -3991,3 +4268,52 @@ func equalTagNamesAnyOrder(tagNames1, tagNames2 []string) bool {
 
 	return true
 }
+
+func queryKeys(t *testing.T, store spi.Store, expression string) []string {
+	t.Helper()
+
+	iterator, err := store.Query(expression)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, iterator.Close())
+	}()
+
+	var keys []string
+
+	for {
+		more, err := iterator.Next()
+		require.NoError(t, err)
+
+		if !more {
+			return keys
+		}
+
+		key, err := iterator.Key()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+}
+
+func verifyEvents(t *testing.T, events <-chan spi.Event, expectedEvents []spi.Event) {
+	t.Helper()
+
+	for _, expectedEvent := range expectedEvents {
+		select {
+		case event := <-events:
+			require.Equal(t, expectedEvent.Type, event.Type)
+			require.Equal(t, expectedEvent.Key, event.Key)
+			require.Equal(t, expectedEvent.Value, event.Value)
+			require.True(t, equalTags(expectedEvent.Tags, event.Tags), "Got unexpected tags")
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for event", "expected event for key %s", expectedEvent.Key)
+		}
+	}
+
+	select {
+	case event := <-events:
+		require.FailNow(t, "unexpected event", "got event for key %s", event.Key)
+	default:
+	}
+}